@@ -0,0 +1,162 @@
+package agent
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/greenplum-db/gpdb/gp/constants"
+	"github.com/greenplum-db/gpdb/gp/idl"
+	"github.com/greenplum-db/gpdb/gp/utils"
+	"github.com/greenplum-db/gpdb/gp/utils/tracing"
+)
+
+const (
+	defaultDiskTestBytes = 1024 * 1024 * 1024
+	diskTestBlockSize    = 1024 * 1024
+)
+
+/*
+TestDiskPerformance measures the sequential write and read throughput of the
+disks of the given data directories, one directory at a time so that the
+directories sharing a disk do not interfere. As the data directories are not
+created yet, the test file is written to the closest existing parent and
+removed afterwards. Writing and reading each stop after the given size or
+duration, whichever comes first.
+*/
+func (s *Server) TestDiskPerformance(ctx context.Context, req *idl.TestDiskPerformanceRequest) (*idl.TestDiskPerformanceReply, error) {
+	size := req.SizeBytes
+	if size <= 0 {
+		size = defaultDiskTestBytes
+	}
+	maxDuration := time.Duration(req.MaxDuration) * time.Second
+	if maxDuration <= 0 {
+		maxDuration = constants.DefaultDiskTestDuration * time.Second
+	}
+
+	reply := &idl.TestDiskPerformanceReply{}
+	tested := make(map[string]bool)
+	for _, dir := range req.Directories {
+		if tested[dir] {
+			continue
+		}
+		tested[dir] = true
+
+		result := &idl.DiskResult{Directory: dir}
+		err := testDisk(ctx, result, size, maxDuration)
+		if err != nil {
+			result.Error = err.Error()
+		}
+
+		reply.Results = append(reply.Results, result)
+	}
+
+	return reply, nil
+}
+
+func testDisk(ctx context.Context, result *idl.DiskResult, size int64, maxDuration time.Duration) error {
+	testDir, err := closestExistingDir(result.Directory)
+	if err != nil {
+		return err
+	}
+	result.TestDirectory = testDir
+
+	file, err := os.CreateTemp(testDir, ".gp_check_disk_")
+	if err != nil {
+		return fmt.Errorf("could not create the test file: %w", err)
+	}
+	defer func() {
+		file.Close()
+		os.Remove(file.Name())
+	}()
+
+	block := make([]byte, diskTestBlockSize)
+	_, err = rand.Read(block) // random data so that compressing file systems write it all
+	if err != nil {
+		return err
+	}
+
+	var written int64
+	start := time.Now()
+	for written < size && time.Since(start) < maxDuration {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		n, err := file.Write(block[:min(int64(len(block)), size-written)])
+		written += int64(n)
+		if err != nil {
+			return fmt.Errorf("could not write the test file: %w", err)
+		}
+	}
+	err = file.Sync()
+	if err != nil {
+		return fmt.Errorf("could not sync the test file: %w", err)
+	}
+	result.Bytes = written
+	result.WriteMBps = mbPerSecond(written, time.Since(start))
+
+	// read the file from the disk rather than from the page cache
+	err = dropFileCache(file)
+	if err != nil {
+		tracing.Debug(ctx, "could not drop the cache of %s, the read throughput may be overestimated: %v", file.Name(), err)
+	}
+
+	_, err = file.Seek(0, io.SeekStart)
+	if err != nil {
+		return err
+	}
+
+	var read int64
+	start = time.Now()
+	for time.Since(start) < maxDuration {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		n, err := file.Read(block)
+		read += int64(n)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("could not read the test file: %w", err)
+		}
+	}
+	result.ReadMBps = mbPerSecond(read, time.Since(start))
+
+	return nil
+}
+
+// closestExistingDir returns the directory, or its closest parent which exists
+func closestExistingDir(dir string) (string, error) {
+	for dir = filepath.Clean(dir); ; dir = filepath.Dir(dir) {
+		info, err := utils.System.Stat(dir)
+		if err == nil {
+			if !info.IsDir() {
+				return "", fmt.Errorf("%s is not a directory", dir)
+			}
+			return dir, nil
+		}
+		if !os.IsNotExist(err) && !errors.Is(err, syscall.ENOTDIR) {
+			return "", err
+		}
+		if dir == filepath.Dir(dir) {
+			return "", fmt.Errorf("no parent directory of %s exists", dir)
+		}
+	}
+}
+
+func mbPerSecond(bytes int64, elapsed time.Duration) float64 {
+	if elapsed <= 0 {
+		return 0
+	}
+
+	return float64(bytes) / (1024 * 1024) / elapsed.Seconds()
+}
@@ -0,0 +1,72 @@
+package agent_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/greenplum-db/gp-common-go-libs/testhelper"
+
+	"github.com/greenplum-db/gpdb/gp/agent"
+	"github.com/greenplum-db/gpdb/gp/idl"
+)
+
+func TestTestDiskPerformance(t *testing.T) {
+	testhelper.SetupTestLogger()
+
+	agentServer := agent.New(agent.Config{})
+
+	t.Run("measures the throughput of the closest existing directory", func(t *testing.T) {
+		dir := t.TempDir()
+		dataDir := filepath.Join(dir, "primary", "gpseg0")
+
+		reply, err := agentServer.TestDiskPerformance(context.Background(), &idl.TestDiskPerformanceRequest{
+			Directories: []string{dataDir, dataDir},
+			SizeBytes:   4*1024*1024 + 512,
+			MaxDuration: 10,
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		if len(reply.Results) != 1 {
+			t.Fatalf("got %d results, want 1", len(reply.Results))
+		}
+		result := reply.Results[0]
+		if result.Error != "" || result.Directory != dataDir || result.TestDirectory != dir || result.Bytes != 4*1024*1024+512 {
+			t.Fatalf("unexpected result %v", result)
+		}
+		if result.WriteMBps <= 0 || result.ReadMBps <= 0 {
+			t.Fatalf("got write %f and read %f, want throughputs", result.WriteMBps, result.ReadMBps)
+		}
+
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		if len(entries) != 0 {
+			t.Fatalf("expected the test file to be removed, got %v", entries)
+		}
+	})
+
+	t.Run("returns the error of the directories which cannot be tested", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "file")
+		err := os.WriteFile(file, nil, 0644)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		reply, err := agentServer.TestDiskPerformance(context.Background(), &idl.TestDiskPerformanceRequest{
+			Directories: []string{filepath.Join(file, "gpseg0")},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		expected := file + " is not a directory"
+		if reply.Results[0].Error != expected {
+			t.Fatalf("got %q, want %q", reply.Results[0].Error, expected)
+		}
+	})
+}
@@ -0,0 +1,388 @@
+package agent
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/greenplum-db/gp-common-go-libs/gplog"
+	"github.com/greenplum-db/gpdb/gp/idl"
+)
+
+/*
+First byte sent on the connections to the listeners, telling them how to
+reply. It is followed by the length of the check ID as a uint16 and the check
+ID, the listeners closing the connections of the other checks, and by the
+number of bytes to send as an int64 for the throughput.
+*/
+const (
+	probeConnect    byte = 'c' // reply the same byte
+	probeLatency    byte = 'l' // echo every byte until the end of the connection
+	probeThroughput byte = 't' // reply the same byte once served, then the number of bytes received
+)
+
+const (
+	defaultListenersTimeout = 5 * time.Minute
+	defaultMeasureBytes     = 64 * 1024 * 1024
+	latencyRoundTrips       = 10
+	maxParallelConnections  = 16
+	probeConnTimeout        = 10 * time.Minute
+	throughputTimeout       = time.Minute // to receive the bytes once served
+)
+
+// networkCheck is the listeners opened for a run of gp check network
+type networkCheck struct {
+	listeners []net.Listener
+	timer     *time.Timer
+}
+
+/*
+StartListeners listens on the given ports until StopListeners is called for the
+same check or the timeout expires, so that the other hosts can check that they
+reach them before the segments are created. The ports which cannot be listened
+on, for instance because they are already in use, are returned with their
+error. The listeners of the other checks are left open.
+*/
+func (s *Server) StartListeners(ctx context.Context, req *idl.StartListenersRequest) (*idl.StartListenersReply, error) {
+	s.checkMutex.Lock()
+	defer s.checkMutex.Unlock()
+
+	s.closeCheckListeners(req.CheckId)
+
+	check := &networkCheck{}
+	reply := &idl.StartListenersReply{}
+	for _, port := range req.Ports {
+		status := &idl.ListenerStatus{Port: port}
+		listener, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
+		if errors.Is(err, syscall.EADDRINUSE) {
+			status.Error = fmt.Sprintf("port %d is already in use", port)
+		} else if err != nil {
+			status.Error = err.Error()
+		} else {
+			check.listeners = append(check.listeners, listener)
+			go s.serveProbes(listener, req.CheckId)
+		}
+
+		reply.Listeners = append(reply.Listeners, status)
+	}
+
+	timeout := time.Duration(req.Timeout) * time.Second
+	if timeout <= 0 {
+		timeout = defaultListenersTimeout
+	}
+	check.timer = time.AfterFunc(timeout, func() {
+		s.checkMutex.Lock()
+		defer s.checkMutex.Unlock()
+
+		if s.checks[req.CheckId] == check {
+			s.closeCheckListeners(req.CheckId)
+		}
+	})
+
+	if s.checks == nil {
+		s.checks = make(map[string]*networkCheck)
+	}
+	s.checks[req.CheckId] = check
+
+	return reply, nil
+}
+
+// StopListeners closes the listeners opened by StartListeners for the check
+func (s *Server) StopListeners(ctx context.Context, req *idl.StopListenersRequest) (*idl.StopListenersReply, error) {
+	s.checkMutex.Lock()
+	defer s.checkMutex.Unlock()
+
+	s.closeCheckListeners(req.CheckId)
+
+	return &idl.StopListenersReply{}, nil
+}
+
+// closeCheckListeners must be called with the check mutex held
+func (s *Server) closeCheckListeners(checkID string) {
+	check, ok := s.checks[checkID]
+	if !ok {
+		return
+	}
+
+	check.timer.Stop()
+	for _, listener := range check.listeners {
+		listener.Close()
+	}
+	delete(s.checks, checkID)
+}
+
+func (s *Server) serveProbes(listener net.Listener, checkID string) {
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+
+		go s.serveProbe(conn, checkID)
+	}
+}
+
+func (s *Server) serveProbe(conn net.Conn, checkID string) {
+	defer conn.Close()
+	_ = conn.SetDeadline(time.Now().Add(probeConnTimeout))
+
+	probe, err := readProbeHeader(conn, checkID)
+	if err != nil {
+		gplog.Debug("network check from %s refused: %v", conn.RemoteAddr(), err)
+		return
+	}
+
+	switch probe {
+	case probeConnect:
+		_, err = conn.Write([]byte{probe})
+	case probeLatency:
+		_, err = io.Copy(conn, conn)
+	case probeThroughput:
+		var size int64
+		err = binary.Read(conn, binary.BigEndian, &size)
+		if err != nil {
+			break
+		}
+		if size <= 0 {
+			err = fmt.Errorf("invalid number of bytes %d", size)
+			break
+		}
+
+		// the hosts measuring their throughput to this host at the same time
+		// would share its bandwidth, so they are served one at a time and the
+		// sender starts timing once it is told it is served. The connection
+		// served may then only take the time of the measure.
+		s.throughputMutex.Lock()
+		defer s.throughputMutex.Unlock()
+		_ = conn.SetDeadline(time.Now().Add(throughputTimeout))
+
+		_, err = conn.Write([]byte{probe})
+		if err == nil {
+			var received int64
+			received, err = io.CopyN(io.Discard, conn, size)
+			if err == nil || errors.Is(err, io.EOF) {
+				err = binary.Write(conn, binary.BigEndian, received)
+			}
+		}
+	}
+	if err != nil {
+		gplog.Debug("network check from %s failed: %v", conn.RemoteAddr(), err)
+	}
+}
+
+// readProbeHeader returns the probe sent on the connection, failing if it was
+// sent for another check than the one of the listener
+func readProbeHeader(conn net.Conn, checkID string) (byte, error) {
+	probe := make([]byte, 1)
+	_, err := io.ReadFull(conn, probe)
+	if err != nil {
+		return 0, err
+	}
+
+	var length uint16
+	err = binary.Read(conn, binary.BigEndian, &length)
+	if err != nil {
+		return 0, err
+	}
+	if int(length) != len(checkID) {
+		return 0, errors.New("the probe is not for the check of the listener")
+	}
+
+	id := make([]byte, length)
+	_, err = io.ReadFull(conn, id)
+	if err != nil {
+		return 0, err
+	}
+	if string(id) != checkID {
+		return 0, errors.New("the probe is not for the check of the listener")
+	}
+
+	return probe[0], nil
+}
+
+/*
+TestConnectivity connects to the listeners of the targets in parallel, and
+then measures the latency and throughput to the targets to be measured one at
+a time so that the measures do not interfere. The source of the results is
+left for the caller to set.
+*/
+func (s *Server) TestConnectivity(ctx context.Context, req *idl.TestConnectivityRequest) (*idl.TestConnectivityReply, error) {
+	timeout := time.Duration(req.Timeout) * time.Second
+	results := make([]*idl.NetworkResult, len(req.Targets))
+
+	var wg sync.WaitGroup
+	limit := make(chan struct{}, maxParallelConnections)
+	for i, target := range req.Targets {
+		wg.Add(1)
+		limit <- struct{}{}
+		go func(i int, target *idl.ConnectivityTarget) {
+			defer wg.Done()
+			defer func() { <-limit }()
+
+			results[i] = testConnection(ctx, req.CheckId, target, timeout)
+		}(i, target)
+	}
+	wg.Wait()
+
+	measureBytes := req.MeasureBytes
+	if measureBytes <= 0 {
+		measureBytes = defaultMeasureBytes
+	}
+	for i, target := range req.Targets {
+		if target.Measure && results[i].Reachable {
+			measureConnection(ctx, req.CheckId, results[i], timeout, measureBytes)
+		}
+	}
+
+	return &idl.TestConnectivityReply{Results: results}, nil
+}
+
+func testConnection(ctx context.Context, checkID string, target *idl.ConnectivityTarget, timeout time.Duration) *idl.NetworkResult {
+	result := &idl.NetworkResult{
+		Target:  target.Host,
+		Address: target.Address,
+		Port:    target.Port,
+		Purpose: target.Purpose,
+	}
+
+	conn, err := dialProbe(ctx, target.Address, target.Port, checkID, probeConnect, timeout)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	defer conn.Close()
+
+	if timeout > 0 {
+		_ = conn.SetDeadline(time.Now().Add(timeout))
+	}
+	reply := make([]byte, 1)
+	_, err = io.ReadFull(conn, reply)
+	if err != nil || reply[0] != probeConnect {
+		result.Error = "the port is not served by the gp check listener, it may be forwarded to another service"
+		return result
+	}
+
+	result.Reachable = true
+
+	return result
+}
+
+// measureConnection sets the average round trip time and the throughput to
+// the target of the result
+func measureConnection(ctx context.Context, checkID string, result *idl.NetworkResult, timeout time.Duration, measureBytes int64) {
+	latency, err := measureLatency(ctx, result.Address, result.Port, checkID, timeout)
+	if err != nil {
+		result.Error = fmt.Sprintf("could not measure the latency: %v", err)
+		return
+	}
+	result.LatencyMs = float64(latency) / float64(time.Millisecond)
+
+	throughput, err := measureThroughput(ctx, result.Address, result.Port, checkID, timeout, measureBytes)
+	if err != nil {
+		result.Error = fmt.Sprintf("could not measure the throughput: %v", err)
+		return
+	}
+	result.ThroughputMBps = throughput
+}
+
+func measureLatency(ctx context.Context, address string, port int32, checkID string, timeout time.Duration) (time.Duration, error) {
+	conn, err := dialProbe(ctx, address, port, checkID, probeLatency, timeout)
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close()
+
+	buf := make([]byte, 1)
+	start := time.Now()
+	for i := 0; i < latencyRoundTrips; i++ {
+		_, err = conn.Write(buf)
+		if err != nil {
+			return 0, err
+		}
+
+		_, err = io.ReadFull(conn, buf)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	return time.Since(start) / latencyRoundTrips, nil
+}
+
+// measureThroughput returns the MB per second sent to the address
+func measureThroughput(ctx context.Context, address string, port int32, checkID string, timeout time.Duration, size int64) (float64, error) {
+	conn, err := dialProbe(ctx, address, port, checkID, probeThroughput, timeout)
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close()
+
+	err = binary.Write(conn, binary.BigEndian, size)
+	if err != nil {
+		return 0, err
+	}
+
+	// wait for the other hosts measuring their throughput to the address
+	ready := make([]byte, 1)
+	_, err = io.ReadFull(conn, ready)
+	if err != nil {
+		return 0, err
+	}
+
+	buf := make([]byte, 64*1024)
+	start := time.Now()
+	for sent := int64(0); sent < size; sent += int64(len(buf)) {
+		_, err = conn.Write(buf[:min(int64(len(buf)), size-sent)])
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	var received int64
+	err = binary.Read(conn, binary.BigEndian, &received)
+	if err != nil {
+		return 0, err
+	}
+	if received != size {
+		return 0, fmt.Errorf("sent %d bytes but %d were received", size, received)
+	}
+
+	return float64(received) / (1024 * 1024) / time.Since(start).Seconds(), nil
+}
+
+// dialProbe connects to the listener and sends the probe for the check. The
+// deadline of the connection is set to the timeout of the network check.
+func dialProbe(ctx context.Context, address string, port int32, checkID string, probe byte, timeout time.Duration) (net.Conn, error) {
+	if len(checkID) > math.MaxUint16 {
+		return nil, fmt.Errorf("the check ID is longer than %d bytes", math.MaxUint16)
+	}
+
+	dialer := net.Dialer{Timeout: timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(address, fmt.Sprint(port)))
+	var netErr net.Error
+	switch {
+	case errors.As(err, &netErr) && netErr.Timeout():
+		return nil, errors.New("connection timed out, the port may be blocked by a firewall")
+	case errors.Is(err, syscall.ECONNREFUSED):
+		return nil, errors.New("connection refused, the port may be blocked by a firewall")
+	case err != nil:
+		return nil, err
+	}
+
+	_ = conn.SetDeadline(time.Now().Add(probeConnTimeout))
+	header := append([]byte{probe}, binary.BigEndian.AppendUint16(nil, uint16(len(checkID)))...)
+	_, err = conn.Write(append(header, checkID...))
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	return conn, nil
+}
@@ -0,0 +1,251 @@
+package agent_test
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/greenplum-db/gp-common-go-libs/testhelper"
+
+	"github.com/greenplum-db/gpdb/gp/agent"
+	"github.com/greenplum-db/gpdb/gp/idl"
+)
+
+func freePort(t *testing.T) int32 {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", ":0")
+	if err != nil {
+		t.Fatalf("unexpected error: %#v", err)
+	}
+	defer listener.Close()
+
+	return int32(listener.Addr().(*net.TCPAddr).Port)
+}
+
+func TestCheckNetwork(t *testing.T) {
+	testhelper.SetupTestLogger()
+
+	agentServer := agent.New(agent.Config{})
+
+	t.Run("connects to and measures the listeners", func(t *testing.T) {
+		port := freePort(t)
+		reply, err := agentServer.StartListeners(context.Background(), &idl.StartListenersRequest{Ports: []int32{port}})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		defer agentServer.StopListeners(context.Background(), &idl.StopListenersRequest{}) //nolint
+		if len(reply.Listeners) != 1 || reply.Listeners[0].Error != "" {
+			t.Fatalf("unexpected listeners %v", reply.Listeners)
+		}
+
+		resp, err := agentServer.TestConnectivity(context.Background(), &idl.TestConnectivityRequest{
+			Targets:      []*idl.ConnectivityTarget{{Host: "localhost", Address: "127.0.0.1", Port: port, Purpose: "primary of content 0", Measure: true}},
+			Timeout:      5,
+			MeasureBytes: 1024 * 1024,
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		result := resp.Results[0]
+		if !result.Reachable || result.Error != "" || result.Target != "localhost" || result.Purpose != "primary of content 0" {
+			t.Fatalf("unexpected result %v", result)
+		}
+		if result.LatencyMs <= 0 || result.ThroughputMBps <= 0 {
+			t.Fatalf("got latency %f and throughput %f, want measures", result.LatencyMs, result.ThroughputMBps)
+		}
+	})
+
+	t.Run("reports the ports already in use", func(t *testing.T) {
+		listener, err := net.Listen("tcp", ":0")
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		defer listener.Close()
+		port := int32(listener.Addr().(*net.TCPAddr).Port)
+
+		reply, err := agentServer.StartListeners(context.Background(), &idl.StartListenersRequest{Ports: []int32{port}})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		defer agentServer.StopListeners(context.Background(), &idl.StopListenersRequest{}) //nolint
+
+		expected := fmt.Sprintf("port %d is already in use", port)
+		if reply.Listeners[0].Error != expected {
+			t.Fatalf("got %q, want %q", reply.Listeners[0].Error, expected)
+		}
+	})
+
+	t.Run("keeps the listeners of the other checks open", func(t *testing.T) {
+		first, second := freePort(t), freePort(t)
+		_, err := agentServer.StartListeners(context.Background(), &idl.StartListenersRequest{Ports: []int32{first}, CheckId: "first"})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		defer agentServer.StopListeners(context.Background(), &idl.StopListenersRequest{CheckId: "first"}) //nolint
+		_, err = agentServer.StartListeners(context.Background(), &idl.StartListenersRequest{Ports: []int32{second}, CheckId: "second"})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		_, err = agentServer.StopListeners(context.Background(), &idl.StopListenersRequest{CheckId: "second"})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		resp, err := agentServer.TestConnectivity(context.Background(), &idl.TestConnectivityRequest{
+			Targets: []*idl.ConnectivityTarget{
+				{Host: "localhost", Address: "127.0.0.1", Port: first},
+				{Host: "localhost", Address: "127.0.0.1", Port: second},
+			},
+			Timeout: 5,
+			CheckId: "first",
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		if !resp.Results[0].Reachable {
+			t.Fatalf("got %v, want the listener of the first check to be open", resp.Results[0])
+		}
+		if resp.Results[1].Reachable {
+			t.Fatalf("got %v, want the listener of the second check to be closed", resp.Results[1])
+		}
+	})
+
+	t.Run("does not serve the probes of the other checks", func(t *testing.T) {
+		port := freePort(t)
+		_, err := agentServer.StartListeners(context.Background(), &idl.StartListenersRequest{Ports: []int32{port}, CheckId: "first"})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		defer agentServer.StopListeners(context.Background(), &idl.StopListenersRequest{CheckId: "first"}) //nolint
+
+		resp, err := agentServer.TestConnectivity(context.Background(), &idl.TestConnectivityRequest{
+			Targets: []*idl.ConnectivityTarget{{Host: "localhost", Address: "127.0.0.1", Port: port}},
+			Timeout: 5,
+			CheckId: "second",
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		expected := "the port is not served by the gp check listener, it may be forwarded to another service"
+		if resp.Results[0].Reachable || resp.Results[0].Error != expected {
+			t.Fatalf("got %v, want %s", resp.Results[0], expected)
+		}
+	})
+
+	t.Run("receives at most the number of bytes of the throughput probe", func(t *testing.T) {
+		port := freePort(t)
+		_, err := agentServer.StartListeners(context.Background(), &idl.StartListenersRequest{Ports: []int32{port}, CheckId: "check"})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		defer agentServer.StopListeners(context.Background(), &idl.StopListenersRequest{CheckId: "check"}) //nolint
+
+		conn, err := net.Dial("tcp", fmt.Sprintf("127.0.0.1:%d", port))
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		defer conn.Close()
+		_ = conn.SetDeadline(time.Now().Add(5 * time.Second))
+
+		// asks to send 4 bytes, sends 8 and never closes the connection
+		header := append([]byte{'t', 0, 5}, "check"...)
+		header = binary.BigEndian.AppendUint64(header, 4)
+		_, err = conn.Write(append(header, make([]byte, 8)...))
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		reply := make([]byte, 9)
+		_, err = io.ReadFull(conn, reply)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		if reply[0] != 't' || binary.BigEndian.Uint64(reply[1:]) != 4 {
+			t.Fatalf("got %v, want 4 bytes to be received", reply)
+		}
+	})
+
+	t.Run("measures the throughput of the hosts one at a time", func(t *testing.T) {
+		port := freePort(t)
+		_, err := agentServer.StartListeners(context.Background(), &idl.StartListenersRequest{Ports: []int32{port}})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		defer agentServer.StopListeners(context.Background(), &idl.StopListenersRequest{}) //nolint
+
+		// several sources measuring the same target, as other hosts would
+		var wg sync.WaitGroup
+		results := make([]*idl.NetworkResult, 4)
+		for i := range results {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+
+				resp, err := agentServer.TestConnectivity(context.Background(), &idl.TestConnectivityRequest{
+					Targets:      []*idl.ConnectivityTarget{{Host: "localhost", Address: "127.0.0.1", Port: port, Measure: true}},
+					Timeout:      5,
+					MeasureBytes: 1024 * 1024,
+				})
+				if err == nil {
+					results[i] = resp.Results[0]
+				}
+			}(i)
+		}
+		wg.Wait()
+
+		for _, result := range results {
+			if result == nil || result.Error != "" || result.ThroughputMBps <= 0 {
+				t.Fatalf("got %v, want a measure", result)
+			}
+		}
+	})
+
+	t.Run("reports the ports not served by the listeners", func(t *testing.T) {
+		// a listener which closes the connections without replying
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		defer listener.Close()
+		go func() {
+			for {
+				conn, err := listener.Accept()
+				if err != nil {
+					return
+				}
+				conn.Close()
+			}
+		}()
+
+		closedPort := freePort(t)
+		resp, err := agentServer.TestConnectivity(context.Background(), &idl.TestConnectivityRequest{
+			Targets: []*idl.ConnectivityTarget{
+				{Host: "localhost", Address: "127.0.0.1", Port: int32(listener.Addr().(*net.TCPAddr).Port)},
+				{Host: "localhost", Address: "127.0.0.1", Port: closedPort},
+			},
+			Timeout: 5,
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		expected := "the port is not served by the gp check listener, it may be forwarded to another service"
+		if resp.Results[0].Reachable || resp.Results[0].Error != expected {
+			t.Fatalf("got %v, want %s", resp.Results[0], expected)
+		}
+		expected = "connection refused, the port may be blocked by a firewall"
+		if resp.Results[1].Reachable || resp.Results[1].Error != expected {
+			t.Fatalf("got %v, want %s", resp.Results[1], expected)
+		}
+	})
+}
@@ -0,0 +1,398 @@
+package agent
+
+import (
+	"archive/tar"
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/greenplum-db/gpdb/gp/constants"
+	"github.com/greenplum-db/gpdb/gp/idl"
+	"github.com/greenplum-db/gpdb/gp/utils"
+	"github.com/greenplum-db/gpdb/gp/utils/postgres"
+	"github.com/greenplum-db/gpdb/gp/utils/tracing"
+)
+
+// Size of the chunks of the archive sent to the hub
+const diagnosticsChunkSize = 1024 * 1024
+
+// Configuration files of the segments included in the archive
+var segmentConfigFiles = []string{"postgresql.conf", "postgresql.auto.conf", "pg_hba.conf", "pg_ident.conf"}
+
+// Commands describing the host, run with the data directories of its segments
+// as arguments when ending with "..."
+var hostFactCommands = [][]string{
+	{"uname", "-a"},
+	{"uptime"},
+	{"sh", "-c", "ulimit -a"},
+	{"free", "-m"},
+	{"df", "-k", "..."},
+}
+
+// Keys of gp.conf whose values are replaced in the archive
+var redactedKeyPattern = regexp.MustCompile(`(?i)password|passphrase|secret|token`)
+
+// DiagnosticsManifest summarizes the files collected on a host. Files skipped or
+// truncated because of the size limit are listed so that they can be requested.
+type DiagnosticsManifest struct {
+	Host      string             `json:"host"`
+	Collected time.Time          `json:"collected"`
+	Files     []DiagnosticsEntry `json:"files"`
+	Skipped   []string           `json:"skipped,omitempty"`
+	Errors    []string           `json:"errors,omitempty"`
+}
+
+type DiagnosticsEntry struct {
+	Name      string `json:"name"`
+	Size      int64  `json:"size"`
+	Truncated bool   `json:"truncated,omitempty"`
+}
+
+/*
+CollectDiagnostics streams a gzipped tar archive of the logs, configuration and
+facts of the host, along with those of the given segments. The logs modified
+since the given time are added from the newest one once the configuration is
+collected, until the size limit is reached. Failures to collect a file are
+recorded in the manifest of the archive instead of failing the call.
+*/
+func (s *Server) CollectDiagnostics(req *idl.CollectDiagnosticsRequest, stream idl.Agent_CollectDiagnosticsServer) error {
+	hostname, err := utils.System.GetHostName()
+	if err != nil {
+		return tracing.LogAndReturnError(stream.Context(), err)
+	}
+
+	sender := bufio.NewWriterSize(&diagnosticsSender{stream: stream}, diagnosticsChunkSize)
+	gzipWriter := gzip.NewWriter(sender)
+	archive := &diagnosticsArchive{
+		writer:   tar.NewWriter(gzipWriter),
+		maxSize:  req.MaxSize,
+		manifest: DiagnosticsManifest{Host: hostname, Collected: time.Now()},
+	}
+
+	var since time.Time
+	if req.Since > 0 {
+		since = time.Unix(req.Since, 0)
+	}
+
+	archive.addContents("host_facts.txt", s.hostFacts(req.Segments))
+	archive.addRedactedConfig(filepath.Join(s.GpHome, constants.ConfigFileName))
+	s.addServiceFiles(archive)
+
+	var logs []diagnosticsLog
+	for _, seg := range req.Segments {
+		dir := fmt.Sprintf("segments/gpseg%d_dbid%d", seg.Contentid, seg.Dbid)
+		for _, name := range segmentConfigFiles {
+			archive.addFile(filepath.Join(dir, name), filepath.Join(seg.DataDirectory, name))
+		}
+
+		out, err := utils.RunGpCommandContext(stream.Context(), &postgres.PgControlData{PgData: seg.DataDirectory}, s.GpHome)
+		if err != nil {
+			archive.addError(fmt.Errorf("executing pg_controldata on %s: %s, %w", seg.DataDirectory, out, err))
+		} else {
+			archive.addContents(filepath.Join(dir, "pg_controldata.txt"), out.String())
+		}
+
+		logs = append(logs, archive.findLogs(segmentLogDir(seg.DataDirectory), filepath.Join(dir, "log"), since)...)
+	}
+	logs = append(logs, archive.findLogs(s.LogDir, "gp_logs", since)...)
+
+	sort.SliceStable(logs, func(i, j int) bool {
+		return logs[i].modTime.After(logs[j].modTime)
+	})
+	for _, log := range logs {
+		archive.addLog(log)
+	}
+
+	err = archive.close()
+	if err == nil {
+		err = gzipWriter.Close()
+	}
+	if err == nil {
+		err = sender.Flush()
+	}
+	if err != nil {
+		return tracing.LogAndReturnError(stream.Context(), fmt.Errorf("could not write the diagnostics archive: %w", err))
+	}
+
+	return nil
+}
+
+// hostFacts returns the output of the commands describing the host. The errors
+// are part of the output.
+func (s *Server) hostFacts(segs []*idl.Segment) string {
+	var facts strings.Builder
+	hostname, _ := utils.System.GetHostName()
+	fmt.Fprintf(&facts, "hostname: %s\nos: %s/%s\ncpus: %d\ntime: %s\n", hostname, runtime.GOOS, runtime.GOARCH, runtime.NumCPU(), time.Now().Format(time.RFC3339))
+
+	var dataDirs []string
+	for _, seg := range segs {
+		dataDirs = append(dataDirs, seg.DataDirectory)
+	}
+
+	for _, command := range hostFactCommands {
+		args := command[1:]
+		if len(args) > 0 && args[len(args)-1] == "..." {
+			args = append(args[:len(args)-1:len(args)-1], dataDirs...)
+		}
+
+		out, err := utils.System.ExecCommand(command[0], args...).CombinedOutput()
+		fmt.Fprintf(&facts, "\n$ %s\n%s", strings.Join(append([]string{command[0]}, args...), " "), out)
+		if err != nil {
+			fmt.Fprintf(&facts, "error: %s\n", err)
+		}
+	}
+
+	return facts.String()
+}
+
+// addServiceFiles adds the service files of the hub and agent of the user
+// running the agent
+func (s *Server) addServiceFiles(archive *diagnosticsArchive) {
+	// without a service manager there are no service files
+	serviceDirFormat := platform.GetDefaultServiceDir()
+	if serviceDirFormat == "" {
+		return
+	}
+
+	// the directory of the system units does not depend on the user
+	serviceDir := serviceDirFormat
+	if strings.Contains(serviceDirFormat, "%s") {
+		currentUser, err := utils.System.CurrentUser()
+		if err != nil {
+			archive.addError(err)
+			return
+		}
+		serviceDir = fmt.Sprintf(serviceDirFormat, currentUser.Username)
+	}
+
+	paths, _ := filepath.Glob(filepath.Join(serviceDir, s.ServiceName+"_*"))
+	for _, path := range paths {
+		archive.addFile(filepath.Join("services", filepath.Base(path)), path)
+	}
+}
+
+type diagnosticsLog struct {
+	name    string
+	path    string
+	modTime time.Time
+}
+
+// diagnosticsArchive writes the files to the archive until the size limit is
+// reached, keeping track of them in the manifest
+type diagnosticsArchive struct {
+	writer   *tar.Writer
+	maxSize  int64
+	size     int64
+	manifest DiagnosticsManifest
+}
+
+func (a *diagnosticsArchive) remaining() int64 {
+	if a.maxSize <= 0 {
+		return -1
+	}
+
+	return a.maxSize - a.size
+}
+
+func (a *diagnosticsArchive) addError(err error) {
+	a.manifest.Errors = append(a.manifest.Errors, err.Error())
+}
+
+func (a *diagnosticsArchive) addContents(name string, contents string) {
+	if remaining := a.remaining(); remaining >= 0 && int64(len(contents)) > remaining {
+		a.manifest.Skipped = append(a.manifest.Skipped, name)
+		return
+	}
+
+	err := a.write(name, int64(len(contents)), time.Now(), strings.NewReader(contents))
+	if err != nil {
+		a.addError(err)
+		return
+	}
+	a.manifest.Files = append(a.manifest.Files, DiagnosticsEntry{Name: name, Size: int64(len(contents))})
+}
+
+func (a *diagnosticsArchive) addFile(name string, path string) {
+	info, err := utils.System.Stat(path)
+	if os.IsNotExist(err) {
+		return
+	}
+	if err != nil {
+		a.addError(err)
+		return
+	}
+
+	a.addLog(diagnosticsLog{name: name, path: path, modTime: info.ModTime()})
+}
+
+// addRedactedConfig adds the gp.conf file with the values of the keys which
+// may hold secrets replaced
+func (a *diagnosticsArchive) addRedactedConfig(path string) {
+	contents, err := utils.System.ReadFile(path)
+	if err != nil {
+		a.addError(err)
+		return
+	}
+
+	var config map[string]interface{}
+	err = json.Unmarshal(contents, &config)
+	if err != nil {
+		a.addError(fmt.Errorf("could not parse %s: %w", path, err))
+		return
+	}
+
+	redacted, err := json.MarshalIndent(redactConfig(config), "", "    ")
+	if err != nil {
+		a.addError(err)
+		return
+	}
+
+	a.addContents(constants.ConfigFileName, string(redacted))
+}
+
+func redactConfig(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, child := range v {
+			if redactedKeyPattern.MatchString(key) {
+				v[key] = "REDACTED"
+			} else {
+				v[key] = redactConfig(child)
+			}
+		}
+	case []interface{}:
+		for i, child := range v {
+			v[i] = redactConfig(child)
+		}
+	}
+
+	return value
+}
+
+// findLogs returns the files of the log directory modified since the given time
+func (a *diagnosticsArchive) findLogs(dir string, archiveDir string, since time.Time) []diagnosticsLog {
+	files, err := listLogFiles(dir)
+	if err != nil {
+		a.addError(err)
+		return nil
+	}
+
+	var logs []diagnosticsLog
+	for _, file := range files {
+		if file.ModTime().Before(since) {
+			continue
+		}
+
+		logs = append(logs, diagnosticsLog{
+			name:    filepath.Join(archiveDir, file.Name()),
+			path:    filepath.Join(dir, file.Name()),
+			modTime: file.ModTime(),
+		})
+	}
+
+	return logs
+}
+
+// addLog adds the file, keeping only its end when it does not fit in the
+// remaining size
+func (a *diagnosticsArchive) addLog(log diagnosticsLog) {
+	remaining := a.remaining()
+	if remaining == 0 {
+		a.manifest.Skipped = append(a.manifest.Skipped, log.name)
+		return
+	}
+
+	file, err := utils.System.Open(log.path)
+	if err != nil {
+		a.addError(err)
+		return
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		a.addError(err)
+		return
+	}
+
+	size := info.Size()
+	truncated := remaining >= 0 && size > remaining
+	if truncated {
+		_, err = file.Seek(size-remaining, io.SeekStart)
+		if err != nil {
+			a.addError(err)
+			return
+		}
+		size = remaining
+	}
+
+	// The file may grow while being read, so only copy its current size
+	err = a.write(log.name, size, log.modTime, io.LimitReader(file, size))
+	if err != nil {
+		a.addError(err)
+		return
+	}
+	a.manifest.Files = append(a.manifest.Files, DiagnosticsEntry{Name: log.name, Size: size, Truncated: truncated})
+}
+
+func (a *diagnosticsArchive) write(name string, size int64, modTime time.Time, contents io.Reader) error {
+	err := a.writer.WriteHeader(&tar.Header{
+		Name:    name,
+		Mode:    0600,
+		Size:    size,
+		ModTime: modTime,
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(a.writer, contents)
+	if err != nil {
+		return fmt.Errorf("could not add %s to the archive: %w", name, err)
+	}
+	a.size += size
+
+	return nil
+}
+
+// close adds the manifest and closes the archive. The manifest is always
+// added, even when the size limit has been reached.
+func (a *diagnosticsArchive) close() error {
+	manifest, err := json.MarshalIndent(a.manifest, "", "    ")
+	if err != nil {
+		return err
+	}
+
+	err = a.write(constants.DiagnosticsManifestName, int64(len(manifest)), time.Now(), strings.NewReader(string(manifest)))
+	if err != nil {
+		return err
+	}
+
+	return a.writer.Close()
+}
+
+// diagnosticsSender sends the data written to it to the hub
+type diagnosticsSender struct {
+	stream idl.Agent_CollectDiagnosticsServer
+}
+
+func (s *diagnosticsSender) Write(p []byte) (int, error) {
+	data := make([]byte, len(p))
+	copy(data, p)
+
+	err := s.stream.Send(&idl.CollectDiagnosticsReply{Data: data})
+	if err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
@@ -0,0 +1,216 @@
+package agent_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/greenplum-db/gp-common-go-libs/testhelper"
+	"google.golang.org/grpc"
+
+	"github.com/greenplum-db/gpdb/gp/agent"
+	"github.com/greenplum-db/gpdb/gp/constants"
+	"github.com/greenplum-db/gpdb/gp/idl"
+	"github.com/greenplum-db/gpdb/gp/testutils/exectest"
+	"github.com/greenplum-db/gpdb/gp/utils"
+)
+
+type collectDiagnosticsStream struct {
+	grpc.ServerStream
+	data bytes.Buffer
+	err  error
+}
+
+func (s *collectDiagnosticsStream) Send(reply *idl.CollectDiagnosticsReply) error {
+	s.data.Write(reply.Data)
+	return s.err
+}
+
+func (s *collectDiagnosticsStream) Context() context.Context {
+	return context.Background()
+}
+
+// files returns the contents of the files of the streamed archive by name
+func (s *collectDiagnosticsStream) files(t *testing.T) map[string]string {
+	t.Helper()
+
+	gzipReader, err := gzip.NewReader(&s.data)
+	if err != nil {
+		t.Fatalf("unexpected error: %#v", err)
+	}
+
+	files := make(map[string]string)
+	archive := tar.NewReader(gzipReader)
+	for {
+		header, err := archive.Next()
+		if errors.Is(err, io.EOF) {
+			return files
+		}
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		contents, err := io.ReadAll(archive)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		files[header.Name] = string(contents)
+	}
+}
+
+func TestCollectDiagnostics(t *testing.T) {
+	testhelper.SetupTestLogger()
+
+	gpHome := t.TempDir()
+	writeLogFile(t, filepath.Join(gpHome, constants.ConfigFileName), `{"hubPort": 4242, "credentials": {"caCert": "/certs/ca.pem", "keyPassword": "secret"}}`, time.Now())
+
+	pgdata := t.TempDir()
+	writeLogFile(t, filepath.Join(pgdata, "postgresql.conf"), "port=7000", time.Now())
+	logDir := filepath.Join(pgdata, "log")
+	err := os.Mkdir(logDir, 0700)
+	if err != nil {
+		t.Fatalf("unexpected error: %#v", err)
+	}
+	writeLogFile(t, filepath.Join(logDir, "gpdb-old.csv"), "old", time.Now().Add(-48*time.Hour))
+	writeLogFile(t, filepath.Join(logDir, "gpdb-latest.csv"), "0123456789", time.Now())
+
+	agentLogDir := t.TempDir()
+	writeLogFile(t, filepath.Join(agentLogDir, "gp_agent.log"), "agent", time.Now().Add(-time.Hour))
+
+	agentServer := agent.New(agent.Config{GpHome: gpHome, LogDir: agentLogDir, ServiceName: "gp_test"})
+	segs := []*idl.Segment{{DataDirectory: pgdata, Contentid: 0, Dbid: 2}}
+	since := time.Now().Add(-24 * time.Hour).Unix()
+
+	t.Run("streams an archive of the configuration, logs and facts of the host", func(t *testing.T) {
+		var commands []string
+		utils.System.ExecCommand = exectest.NewCommandWithVerifier(exectest.Success, func(utility string, args ...string) {
+			commands = append(commands, filepath.Base(utility))
+		})
+		defer utils.ResetSystemFunctions()
+
+		stream := &collectDiagnosticsStream{}
+		err := agentServer.CollectDiagnostics(&idl.CollectDiagnosticsRequest{Segments: segs, Since: since}, stream)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		files := stream.files(t)
+		var names []string
+		for name := range files {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		expected := []string{
+			"gp.conf",
+			"gp_logs/gp_agent.log",
+			"host_facts.txt",
+			"manifest.json",
+			"segments/gpseg0_dbid2/log/gpdb-latest.csv",
+			"segments/gpseg0_dbid2/pg_controldata.txt",
+			"segments/gpseg0_dbid2/postgresql.conf",
+		}
+		if !reflect.DeepEqual(names, expected) {
+			t.Fatalf("got %v, want %v", names, expected)
+		}
+
+		if strings.Contains(files["gp.conf"], "secret") || !strings.Contains(files["gp.conf"], `"keyPassword": "REDACTED"`) || !strings.Contains(files["gp.conf"], "/certs/ca.pem") {
+			t.Fatalf("expected the password to be redacted, got %s", files["gp.conf"])
+		}
+
+		if !reflect.DeepEqual(commands, []string{"uname", "uptime", "sh", "free", "df", "pg_controldata"}) {
+			t.Fatalf("got commands %v", commands)
+		}
+		if !strings.Contains(files["host_facts.txt"], "$ df -k "+pgdata) {
+			t.Fatalf("expected the disk usage of the data directories, got %s", files["host_facts.txt"])
+		}
+	})
+
+	t.Run("keeps the end of the newest logs within the size limit", func(t *testing.T) {
+		utils.System.ExecCommand = exectest.NewCommand(exectest.Success)
+		defer utils.ResetSystemFunctions()
+
+		manifest := collectManifest(t, agentServer, &idl.CollectDiagnosticsRequest{Segments: segs, Since: since})
+		var configSize int64
+		for _, file := range manifest.Files {
+			if !strings.Contains(file.Name, "log") {
+				configSize += file.Size
+			}
+		}
+
+		stream := &collectDiagnosticsStream{}
+		err := agentServer.CollectDiagnostics(&idl.CollectDiagnosticsRequest{Segments: segs, Since: since, MaxSize: configSize + 4}, stream)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		files := stream.files(t)
+		if content := files["segments/gpseg0_dbid2/log/gpdb-latest.csv"]; content != "6789" {
+			t.Fatalf("got %q, want the end of the latest log", content)
+		}
+
+		manifest = parseManifest(t, files[constants.DiagnosticsManifestName])
+		expected := agent.DiagnosticsEntry{Name: "segments/gpseg0_dbid2/log/gpdb-latest.csv", Size: 4, Truncated: true}
+		if !reflect.DeepEqual(manifest.Files[len(manifest.Files)-1], expected) {
+			t.Fatalf("got %+v, want %+v", manifest.Files[len(manifest.Files)-1], expected)
+		}
+		if !reflect.DeepEqual(manifest.Skipped, []string{"gp_logs/gp_agent.log"}) {
+			t.Fatalf("got %v, want the agent log to be skipped", manifest.Skipped)
+		}
+	})
+
+	t.Run("records the failures in the manifest", func(t *testing.T) {
+		utils.System.ExecCommand = exectest.NewCommand(exectest.Failure)
+		defer utils.ResetSystemFunctions()
+
+		manifest := collectManifest(t, agentServer, &idl.CollectDiagnosticsRequest{Segments: segs})
+		if len(manifest.Errors) != 1 || !strings.HasPrefix(manifest.Errors[0], "executing pg_controldata on "+pgdata) {
+			t.Fatalf("got %v, want the pg_controldata error", manifest.Errors)
+		}
+	})
+
+	t.Run("errors out when the archive cannot be sent", func(t *testing.T) {
+		utils.System.ExecCommand = exectest.NewCommand(exectest.Success)
+		defer utils.ResetSystemFunctions()
+
+		expected := errors.New("error")
+		err := agentServer.CollectDiagnostics(&idl.CollectDiagnosticsRequest{Segments: segs}, &collectDiagnosticsStream{err: expected})
+		if !errors.Is(err, expected) {
+			t.Fatalf("got %#v, want %#v", err, expected)
+		}
+	})
+}
+
+func collectManifest(t *testing.T, agentServer *agent.Server, req *idl.CollectDiagnosticsRequest) agent.DiagnosticsManifest {
+	t.Helper()
+
+	stream := &collectDiagnosticsStream{}
+	err := agentServer.CollectDiagnostics(req, stream)
+	if err != nil {
+		t.Fatalf("unexpected error: %#v", err)
+	}
+
+	return parseManifest(t, stream.files(t)[constants.DiagnosticsManifestName])
+}
+
+func parseManifest(t *testing.T, contents string) agent.DiagnosticsManifest {
+	t.Helper()
+
+	var manifest agent.DiagnosticsManifest
+	err := json.Unmarshal([]byte(contents), &manifest)
+	if err != nil {
+		t.Fatalf("unexpected error: %#v", err)
+	}
+
+	return manifest
+}
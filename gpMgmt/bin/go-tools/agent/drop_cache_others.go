@@ -0,0 +1,13 @@
+//go:build !linux
+
+package agent
+
+import (
+	"errors"
+	"os"
+)
+
+// dropFileCache is only supported on Linux
+func dropFileCache(file *os.File) error {
+	return errors.New("dropping the page cache is not supported on this platform")
+}
@@ -0,0 +1,246 @@
+package agent
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"github.com/greenplum-db/gpdb/gp/constants"
+	"github.com/greenplum-db/gpdb/gp/idl"
+	"github.com/greenplum-db/gpdb/gp/utils"
+	"github.com/greenplum-db/gpdb/gp/utils/greenplum"
+)
+
+const (
+	osReleaseFile = "/etc/os-release"
+	cpuInfoFile   = "/proc/cpuinfo"
+	memInfoFile   = "/proc/meminfo"
+	netSysDir     = "/sys/class/net"
+)
+
+/*
+GetHostInfo returns the facts of the host used to size the segments: its OS,
+CPUs, memory, disks, network interfaces and Greenplum version. The facts which
+could not be read are left unset and their errors are returned along with the
+others.
+*/
+func (s *Server) GetHostInfo(ctx context.Context, req *idl.GetHostInfoRequest) (*idl.GetHostInfoReply, error) {
+	info := &idl.HostInfo{
+		Arch:     runtime.GOARCH,
+		CpuCount: int32(runtime.NumCPU()),
+		GpHome:   s.GpHome,
+	}
+	addError := func(err error) {
+		if err != nil {
+			info.Errors = append(info.Errors, err.Error())
+		}
+	}
+
+	var err error
+	info.Hostname, err = utils.System.GetHostName()
+	addError(err)
+
+	info.Os, err = getOsName()
+	addError(err)
+
+	info.Kernel, err = runHostCommand("uname", "-r")
+	addError(err)
+
+	info.CpuModel, err = getCpuModel()
+	addError(err)
+
+	info.MemoryBytes, info.SwapBytes, err = getMemory()
+	addError(err)
+
+	info.Disks, err = getDisks()
+	addError(err)
+
+	info.Interfaces, err = getInterfaces()
+	addError(err)
+
+	info.GpVersion, err = greenplum.GetPostgresGpVersion(s.GpHome)
+	addError(err)
+
+	return &idl.GetHostInfoReply{Info: info}, nil
+}
+
+func runHostCommand(name string, args ...string) (string, error) {
+	out, err := utils.System.ExecCommand(name, args...).CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("executing %s: %s, %w", name, strings.TrimSpace(string(out)), err)
+	}
+
+	return strings.TrimSpace(string(out)), nil
+}
+
+func getOsName() (string, error) {
+	if runtime.GOOS == constants.PlatformDarwin {
+		version, err := runHostCommand("sw_vers", "-productVersion")
+		return "macOS " + version, err
+	}
+
+	values, err := readKeyValues(osReleaseFile, "=")
+	if err != nil {
+		return "", err
+	}
+
+	return strings.Trim(values["PRETTY_NAME"], `"`), nil
+}
+
+func getCpuModel() (string, error) {
+	if runtime.GOOS == constants.PlatformDarwin {
+		return runHostCommand("sysctl", "-n", "machdep.cpu.brand_string")
+	}
+
+	values, err := readKeyValues(cpuInfoFile, ":")
+	if err != nil {
+		return "", err
+	}
+
+	return values["model name"], nil
+}
+
+// getMemory returns the size of the memory and swap in bytes
+func getMemory() (uint64, uint64, error) {
+	if runtime.GOOS == constants.PlatformDarwin {
+		out, err := runHostCommand("sysctl", "-n", "hw.memsize")
+		if err != nil {
+			return 0, 0, err
+		}
+
+		memory, err := strconv.ParseUint(out, 10, 64)
+		return memory, 0, err
+	}
+
+	values, err := readKeyValues(memInfoFile, ":")
+	if err != nil {
+		return 0, 0, err
+	}
+
+	memory, err := parseKiloBytes(values["MemTotal"])
+	if err != nil {
+		return 0, 0, fmt.Errorf("could not parse the memory size in %s: %w", memInfoFile, err)
+	}
+
+	swap, err := parseKiloBytes(values["SwapTotal"])
+	if err != nil {
+		return 0, 0, fmt.Errorf("could not parse the swap size in %s: %w", memInfoFile, err)
+	}
+
+	return memory, swap, nil
+}
+
+// parseKiloBytes parses a value such as "16318676 kB" to bytes
+func parseKiloBytes(value string) (uint64, error) {
+	kiloBytes, err := strconv.ParseUint(strings.TrimSpace(strings.TrimSuffix(value, "kB")), 10, 64)
+	if err != nil {
+		return 0, err
+	}
+
+	return kiloBytes * 1024, nil
+}
+
+// getDisks returns the file systems backed by a device, with the space left
+// for unprivileged users
+func getDisks() ([]*idl.HostDisk, error) {
+	out, err := runHostCommand("df", "-kP")
+	if err != nil {
+		return nil, err
+	}
+
+	var disks []*idl.HostDisk
+	scanner := bufio.NewScanner(strings.NewReader(out))
+	scanner.Scan() // skip the header
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 6 || !strings.HasPrefix(fields[0], "/") || strings.HasPrefix(fields[0], "/dev/loop") {
+			continue
+		}
+
+		total, err := strconv.ParseUint(fields[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse the size of %s: %w", fields[0], err)
+		}
+
+		free, err := strconv.ParseUint(fields[3], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse the free space of %s: %w", fields[0], err)
+		}
+
+		disks = append(disks, &idl.HostDisk{
+			Device:     fields[0],
+			MountPoint: strings.Join(fields[5:], " "),
+			TotalBytes: total * 1024,
+			FreeBytes:  free * 1024,
+		})
+	}
+
+	return disks, nil
+}
+
+// getInterfaces returns the network interfaces which are up, without the
+// loopback interface
+func getInterfaces() ([]*idl.HostInterface, error) {
+	ifaces, err := net.Interfaces()
+	if err != nil {
+		return nil, err
+	}
+
+	var result []*idl.HostInterface
+	for _, iface := range ifaces {
+		if iface.Flags&net.FlagLoopback != 0 || iface.Flags&net.FlagUp == 0 {
+			continue
+		}
+
+		hostIface := &idl.HostInterface{Name: iface.Name, Mtu: int32(iface.MTU)}
+		addrs, err := iface.Addrs()
+		if err != nil {
+			return nil, err
+		}
+		for _, addr := range addrs {
+			hostIface.Addrs = append(hostIface.Addrs, addr.String())
+		}
+
+		// The speed is only known for physical interfaces which are connected
+		speed, err := utils.System.ReadFile(filepath.Join(netSysDir, iface.Name, "speed"))
+		if err == nil {
+			mbps, err := strconv.Atoi(strings.TrimSpace(string(speed)))
+			if err == nil && mbps > 0 {
+				hostIface.SpeedMbps = int32(mbps)
+			}
+		}
+
+		result = append(result, hostIface)
+	}
+
+	return result, nil
+}
+
+// readKeyValues reads the lines of the file as key and values separated by
+// the given separator. The first value of a key is kept.
+func readKeyValues(path string, separator string) (map[string]string, error) {
+	contents, err := utils.System.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]string)
+	for _, line := range strings.Split(string(contents), "\n") {
+		key, value, found := strings.Cut(line, separator)
+		if !found {
+			continue
+		}
+
+		key = strings.TrimSpace(key)
+		if _, ok := values[key]; !ok {
+			values[key] = strings.TrimSpace(value)
+		}
+	}
+
+	return values, nil
+}
@@ -0,0 +1,127 @@
+package agent_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"testing"
+
+	"github.com/greenplum-db/gp-common-go-libs/testhelper"
+
+	"github.com/greenplum-db/gpdb/gp/agent"
+	"github.com/greenplum-db/gpdb/gp/idl"
+	"github.com/greenplum-db/gpdb/gp/testutils/exectest"
+	"github.com/greenplum-db/gpdb/gp/utils"
+)
+
+// HostCommandMain prints the output of the commands run to read the facts of
+// the host
+func HostCommandMain() {
+	switch filepath.Base(os.Args[0]) {
+	case "uname":
+		fmt.Println("5.14.0-362.el9.x86_64")
+	case "df":
+		fmt.Println("Filesystem     1024-blocks      Used Available Capacity Mounted on")
+		fmt.Println("tmpfs              1632228      1524   1630704       1% /run")
+		fmt.Println("/dev/sda1        102400000  51200000  40960000      56% /")
+		fmt.Println("/dev/sdb1       1048576000 104857600 943718400      10% /data disk")
+		fmt.Println("/dev/loop0           65536     65536         0     100% /snap/core/1")
+	case "postgres":
+		fmt.Println("postgres (Greenplum Database) 7.0.0 build dev")
+	}
+}
+
+func init() {
+	exectest.RegisterMains(HostCommandMain)
+}
+
+func TestGetHostInfo(t *testing.T) {
+	testhelper.SetupTestLogger()
+
+	if runtime.GOOS != "linux" {
+		t.Skip("the facts are read from /proc on Linux only")
+	}
+
+	agentServer := agent.New(agent.Config{GpHome: "/usr/local/gpdb"})
+
+	t.Run("returns the facts of the host", func(t *testing.T) {
+		utils.System.ExecCommand = exectest.NewCommand(HostCommandMain)
+		utils.System.GetHostName = func() (string, error) {
+			return "sdw1", nil
+		}
+		utils.System.ReadFile = func(name string) ([]byte, error) {
+			switch name {
+			case "/etc/os-release":
+				return []byte("NAME=\"Rocky Linux\"\nPRETTY_NAME=\"Rocky Linux 9.2 (Blue Onyx)\"\n"), nil
+			case "/proc/cpuinfo":
+				return []byte("processor\t: 0\nmodel name\t: Intel(R) Xeon(R) Gold 6248\nprocessor\t: 1\nmodel name\t: Intel(R) Xeon(R) Gold 6248\n"), nil
+			case "/proc/meminfo":
+				return []byte("MemTotal:       16318676 kB\nMemFree:         1024000 kB\nSwapTotal:       2097148 kB\n"), nil
+			}
+			return nil, os.ErrNotExist
+		}
+		defer utils.ResetSystemFunctions()
+
+		reply, err := agentServer.GetHostInfo(context.Background(), &idl.GetHostInfoRequest{})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		info := reply.Info
+		if info.Hostname != "sdw1" || info.Os != "Rocky Linux 9.2 (Blue Onyx)" || info.Kernel != "5.14.0-362.el9.x86_64" ||
+			info.CpuModel != "Intel(R) Xeon(R) Gold 6248" || info.CpuCount != int32(runtime.NumCPU()) || info.Arch != runtime.GOARCH {
+			t.Fatalf("unexpected facts %+v", info)
+		}
+		if info.MemoryBytes != 16318676*1024 || info.SwapBytes != 2097148*1024 {
+			t.Fatalf("got memory %d and swap %d", info.MemoryBytes, info.SwapBytes)
+		}
+		if info.GpVersion != "postgres (Greenplum Database) 7.0.0 build dev" || info.GpHome != "/usr/local/gpdb" {
+			t.Fatalf("got version %q and GPHOME %q", info.GpVersion, info.GpHome)
+		}
+
+		expected := []*idl.HostDisk{
+			{Device: "/dev/sda1", MountPoint: "/", TotalBytes: 102400000 * 1024, FreeBytes: 40960000 * 1024},
+			{Device: "/dev/sdb1", MountPoint: "/data disk", TotalBytes: 1048576000 * 1024, FreeBytes: 943718400 * 1024},
+		}
+		if !reflect.DeepEqual(info.Disks, expected) {
+			t.Fatalf("got disks %v, want %v", info.Disks, expected)
+		}
+
+		for _, iface := range info.Interfaces {
+			if iface.Name == "lo" {
+				t.Fatalf("unexpected loopback interface")
+			}
+		}
+		if len(info.Errors) != 0 {
+			t.Fatalf("unexpected errors %v", info.Errors)
+		}
+	})
+
+	t.Run("returns the errors of the facts which could not be read", func(t *testing.T) {
+		utils.System.ExecCommand = exectest.NewCommand(exectest.Failure)
+		utils.System.ReadFile = func(name string) ([]byte, error) {
+			if name == "/proc/meminfo" {
+				return nil, errors.New("error")
+			}
+			return []byte{}, nil
+		}
+		defer utils.ResetSystemFunctions()
+
+		reply, err := agentServer.GetHostInfo(context.Background(), &idl.GetHostInfoRequest{})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		// uname, /proc/meminfo, df and postgres
+		if len(reply.Info.Errors) != 4 || reply.Info.Errors[1] != "error" {
+			t.Fatalf("got errors %q", reply.Info.Errors)
+		}
+		if reply.Info.CpuCount == 0 {
+			t.Fatalf("expected the facts which could be read")
+		}
+	})
+}
@@ -6,13 +6,14 @@ import (
 
 	"github.com/greenplum-db/gpdb/gp/idl"
 	"github.com/greenplum-db/gpdb/gp/utils"
+	"github.com/greenplum-db/gpdb/gp/utils/tracing"
 )
 
 func (s *Server) GetHostName(ctx context.Context, request *idl.GetHostNameRequest) (*idl.GetHostNameReply, error) {
 	hostname, err := utils.System.GetHostName()
 	if err != nil {
 
-		return &idl.GetHostNameReply{}, utils.LogAndReturnError(fmt.Errorf("error getting hostname:%v", err))
+		return &idl.GetHostNameReply{}, tracing.LogAndReturnError(ctx, fmt.Errorf("error getting hostname:%v", err))
 	}
 
 	return &idl.GetHostNameReply{Hostname: hostname}, nil
@@ -0,0 +1,22 @@
+package agent
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/greenplum-db/gpdb/gp/idl"
+	"github.com/greenplum-db/gpdb/gp/utils"
+	"github.com/greenplum-db/gpdb/gp/utils/tracing"
+)
+
+// GetPgHbaConf is agent RPC implementation which returns the contents of the
+// pg_hba.conf file of the given data directory
+func (s *Server) GetPgHbaConf(ctx context.Context, req *idl.GetPgHbaConfRequest) (*idl.GetPgHbaConfReply, error) {
+	content, err := utils.System.ReadFile(filepath.Join(req.Pgdata, "pg_hba.conf"))
+	if err != nil {
+		return &idl.GetPgHbaConfReply{}, tracing.LogAndReturnError(ctx, fmt.Errorf("reading pg_hba.conf: %w", err))
+	}
+
+	return &idl.GetPgHbaConfReply{Content: string(content)}, nil
+}
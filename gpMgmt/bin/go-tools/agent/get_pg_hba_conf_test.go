@@ -0,0 +1,60 @@
+package agent_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/greenplum-db/gp-common-go-libs/testhelper"
+	"github.com/greenplum-db/gpdb/gp/agent"
+	"github.com/greenplum-db/gpdb/gp/idl"
+	"github.com/greenplum-db/gpdb/gp/utils"
+)
+
+func TestGetPgHbaConf(t *testing.T) {
+	testhelper.SetupTestLogger()
+
+	agentServer := agent.New(agent.Config{
+		GpHome: "gpHome",
+	})
+
+	t.Run("returns the contents of the pg_hba.conf file", func(t *testing.T) {
+		expected := "host\tall\tgpadmin\tsdw1\ttrust\n"
+		utils.System.ReadFile = func(name string) ([]byte, error) {
+			expectedName := "gpseg/pg_hba.conf"
+			if name != expectedName {
+				t.Fatalf("got %s, want %s", name, expectedName)
+			}
+			return []byte(expected), nil
+		}
+		defer utils.ResetSystemFunctions()
+
+		result, err := agentServer.GetPgHbaConf(context.Background(), &idl.GetPgHbaConfRequest{Pgdata: "gpseg"})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		if result.Content != expected {
+			t.Fatalf("got %q, want %q", result.Content, expected)
+		}
+	})
+
+	t.Run("returns error when not able to read the file", func(t *testing.T) {
+		expectedErr := errors.New("error")
+		utils.System.ReadFile = func(name string) ([]byte, error) {
+			return nil, expectedErr
+		}
+		defer utils.ResetSystemFunctions()
+
+		_, err := agentServer.GetPgHbaConf(context.Background(), &idl.GetPgHbaConfRequest{Pgdata: "gpseg"})
+		if !errors.Is(err, expectedErr) {
+			t.Fatalf("got %#v, want %#v", err, expectedErr)
+		}
+
+		expectedErrPrefix := "reading pg_hba.conf"
+		if !strings.HasPrefix(err.Error(), expectedErrPrefix) {
+			t.Fatalf("got %v, want prefix %s", err, expectedErrPrefix)
+		}
+	})
+}
@@ -0,0 +1,37 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/greenplum-db/gpdb/gp/idl"
+	"github.com/greenplum-db/gpdb/gp/utils/postgres"
+	"github.com/greenplum-db/gpdb/gp/utils/tracing"
+)
+
+// InstallSslCertificate is agent RPC implementation which writes the server
+// certificate of the host to the data directory of the segment. It is used
+// for segments which are not created with MakeSegment, such as mirrors which
+// otherwise keep the certificate copied from their primary.
+func (s *Server) InstallSslCertificate(ctx context.Context, req *idl.InstallSslCertificateRequest) (*idl.InstallSslCertificateReply, error) {
+	err := installSslCertificate(req.Pgdata, req.Certificate)
+	if err != nil {
+		return &idl.InstallSslCertificateReply{}, tracing.LogAndReturnError(ctx, err)
+	}
+
+	return &idl.InstallSslCertificateReply{}, nil
+}
+
+func installSslCertificate(pgdata string, cert *idl.SslCertificate) error {
+	if len(cert.GetCert()) == 0 || len(cert.GetKey()) == 0 {
+		return errors.New("installing SSL certificate: certificate and key are required")
+	}
+
+	err := postgres.WriteSslFiles(pgdata, cert.Cert, cert.Key, cert.Ca)
+	if err != nil {
+		return fmt.Errorf("installing SSL certificate: %w", err)
+	}
+
+	return nil
+}
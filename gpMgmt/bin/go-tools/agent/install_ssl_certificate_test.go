@@ -0,0 +1,66 @@
+package agent_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/greenplum-db/gp-common-go-libs/testhelper"
+	"github.com/greenplum-db/gpdb/gp/agent"
+	"github.com/greenplum-db/gpdb/gp/idl"
+	"github.com/greenplum-db/gpdb/gp/testutils"
+)
+
+func TestInstallSslCertificate(t *testing.T) {
+	testhelper.SetupTestLogger()
+
+	agentServer := agent.New(agent.Config{
+		GpHome: "gpHome",
+	})
+
+	t.Run("writes the certificate files to the data directory", func(t *testing.T) {
+		pgdata := t.TempDir()
+
+		_, err := agentServer.InstallSslCertificate(context.Background(), &idl.InstallSslCertificateRequest{
+			Pgdata:      pgdata,
+			Certificate: &idl.SslCertificate{Cert: []byte("cert"), Key: []byte("key"), Ca: []byte("ca")},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		testutils.AssertFileContents(t, filepath.Join(pgdata, "server.crt"), "cert")
+		testutils.AssertFileContents(t, filepath.Join(pgdata, "server.key"), "key")
+		testutils.AssertFileContents(t, filepath.Join(pgdata, "root.crt"), "ca")
+	})
+
+	t.Run("errors out when the key is missing", func(t *testing.T) {
+		pgdata := t.TempDir()
+
+		_, err := agentServer.InstallSslCertificate(context.Background(), &idl.InstallSslCertificateRequest{
+			Pgdata:      pgdata,
+			Certificate: &idl.SslCertificate{Cert: []byte("cert")},
+		})
+
+		expected := "installing SSL certificate: certificate and key are required"
+		if err == nil || err.Error() != expected {
+			t.Fatalf("got %v, want %s", err, expected)
+		}
+
+		if _, err := os.Stat(filepath.Join(pgdata, "server.crt")); !os.IsNotExist(err) {
+			t.Fatalf("expected the certificate to not be written, got %v", err)
+		}
+	})
+
+	t.Run("errors out when not able to write the files", func(t *testing.T) {
+		_, err := agentServer.InstallSslCertificate(context.Background(), &idl.InstallSslCertificateRequest{
+			Pgdata:      "/does/not/exist",
+			Certificate: &idl.SslCertificate{Cert: []byte("cert"), Key: []byte("key")},
+		})
+		if !errors.Is(err, os.ErrNotExist) {
+			t.Fatalf("got %#v, want %#v", err, os.ErrNotExist)
+		}
+	})
+}
@@ -7,10 +7,10 @@ import (
 
 	"golang.org/x/exp/maps"
 
-	"github.com/greenplum-db/gp-common-go-libs/gplog"
 	"github.com/greenplum-db/gpdb/gp/idl"
 	"github.com/greenplum-db/gpdb/gp/utils"
 	"github.com/greenplum-db/gpdb/gp/utils/postgres"
+	"github.com/greenplum-db/gpdb/gp/utils/tracing"
 )
 
 // MakeSegment is an RPC which creates a new segment instance with the specified
@@ -20,7 +20,7 @@ func (s *Server) MakeSegment(ctx context.Context, request *idl.MakeSegmentReques
 	dataDirectory := request.Segment.DataDirectory
 	locale := request.Locale
 
-	gplog.Debug("Creating segment with data directory %q", dataDirectory)
+	tracing.Debug(ctx, "Creating segment with data directory %q", dataDirectory)
 
 	initdbOptions := postgres.Initdb{
 		PgData:        dataDirectory,
@@ -34,9 +34,16 @@ func (s *Server) MakeSegment(ctx context.Context, request *idl.MakeSegmentReques
 		LcTime:        locale.LcTime,
 		DataChecksums: request.DataChecksums,
 	}
-	out, err := utils.RunGpCommand(&initdbOptions, s.GpHome)
+	out, err := utils.RunGpCommandContext(ctx, &initdbOptions, s.GpHome)
 	if err != nil {
-		return &idl.MakeSegmentReply{}, utils.LogAndReturnError(fmt.Errorf("executing initdb: %s, %w", out, err))
+		return &idl.MakeSegmentReply{}, tracing.LogAndReturnError(ctx, fmt.Errorf("executing initdb: %s, %w", out, err))
+	}
+
+	if request.SslCertificate != nil {
+		err = installSslCertificate(dataDirectory, request.SslCertificate)
+		if err != nil {
+			return &idl.MakeSegmentReply{}, tracing.LogAndReturnError(ctx, err)
+		}
 	}
 
 	configParams := make(map[string]string)
@@ -50,12 +57,12 @@ func (s *Server) MakeSegment(ctx context.Context, request *idl.MakeSegmentReques
 
 	err = postgres.UpdatePostgresqlConf(dataDirectory, configParams, false)
 	if err != nil {
-		return &idl.MakeSegmentReply{}, utils.LogAndReturnError(fmt.Errorf("updating postgresql.conf: %w", err))
+		return &idl.MakeSegmentReply{}, tracing.LogAndReturnError(ctx, fmt.Errorf("updating postgresql.conf: %w", err))
 	}
 
 	err = postgres.UpdatePostgresInternalConf(dataDirectory, int(request.Segment.Dbid))
 	if err != nil {
-		return &idl.MakeSegmentReply{}, utils.LogAndReturnError(fmt.Errorf("creating internal.auto.conf: %w", err))
+		return &idl.MakeSegmentReply{}, tracing.LogAndReturnError(ctx, fmt.Errorf("creating internal.auto.conf: %w", err))
 	}
 
 	var addrs []string
@@ -64,22 +71,27 @@ func (s *Server) MakeSegment(ctx context.Context, request *idl.MakeSegmentReques
 	} else {
 		hostAddrs, err := utils.GetHostAddrsNoLoopback()
 		if err != nil {
-			return &idl.MakeSegmentReply{}, utils.LogAndReturnError(err)
+			return &idl.MakeSegmentReply{}, tracing.LogAndReturnError(ctx, err)
 		}
 
 		addrs = append(addrs, hostAddrs...)
 	}
 
 	if request.Segment.Contentid == -1 {
-		err = postgres.BuildCoordinatorPgHbaConf(dataDirectory, addrs)
+		userAccess := postgres.HbaUserAccess{
+			Addrs: request.HbaUserAddrs,
+			Auth:  postgres.HbaAuth{Method: request.HbaUserAuthMethod, HostSsl: request.HbaHostssl},
+		}
+		err = postgres.BuildCoordinatorPgHbaConf(dataDirectory, addrs, userAccess)
 	} else {
-		err = postgres.UpdateSegmentPgHbaConf(dataDirectory, addrs, false, request.CoordinatorAddrs...)
+		auth := postgres.HbaAuth{Method: request.HbaAuthMethod, HostSsl: request.HbaHostssl}
+		err = postgres.UpdateSegmentPgHbaConf(dataDirectory, addrs, false, auth, request.CoordinatorAddrs...)
 	}
 	if err != nil {
-		return &idl.MakeSegmentReply{}, utils.LogAndReturnError(fmt.Errorf("updating pg_hba.conf: %w", err))
+		return &idl.MakeSegmentReply{}, tracing.LogAndReturnError(ctx, fmt.Errorf("updating pg_hba.conf: %w", err))
 	}
 
-	gplog.Debug("Successfully created segment with data directory %q", dataDirectory)
+	tracing.Debug(ctx, "Successfully created segment with data directory %q", dataDirectory)
 
 	return &idl.MakeSegmentReply{}, nil
 }
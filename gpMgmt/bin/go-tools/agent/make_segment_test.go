@@ -56,6 +56,9 @@ func TestMakeSegment(t *testing.T) {
 		contentId              int32
 		coordinatorAddrs       []string
 		hbaHostname            bool
+		hbaAuthMethod          string
+		hbaUserAuthMethod      string
+		hbaUserAddrs           []string
 		expectedPostgresqlConf string
 		expectedPgHbaConf      string
 	}{
@@ -90,6 +93,61 @@ port = %d
 `, request.Segment.Port),
 			expectedPgHbaConf: fmt.Sprintf(`host	all	all	cdw	trust
 host	all	gpadmin	%[1]s	trust
+`, request.Segment.HostName),
+		},
+		{ // coordinator segment with user access entries
+			contentId:         -1,
+			hbaHostname:       true,
+			hbaAuthMethod:     "md5",
+			hbaUserAuthMethod: "scram-sha-256",
+			hbaUserAddrs:      []string{"10.0.0.0/8"},
+			expectedPostgresqlConf: fmt.Sprintf(`listen_addresses = '*'
+gp_contentid = -1
+log_statement = 'all'
+key1 = 'value1'
+key2 = 'value2'
+port = %d
+`, request.Segment.Port),
+			expectedPgHbaConf: fmt.Sprintf(`local	all	gpadmin	ident
+local	replication	gpadmin	ident
+host	all	gpadmin	localhost	trust
+host	all	gpadmin	%[1]s	trust
+host	replication	gpadmin	samehost	trust
+host	replication	gpadmin	localhost	trust
+host	replication	gpadmin	%[1]s	trust
+host	all	all	10.0.0.0/8	scram-sha-256
+`, request.Segment.HostName),
+		},
+		{ // primary segment with scram-sha-256 authentication
+			contentId:         0,
+			coordinatorAddrs:  []string{"cdw"},
+			hbaHostname:       true,
+			hbaAuthMethod:     "scram-sha-256",
+			hbaUserAuthMethod: "md5",
+			hbaUserAddrs:      []string{"10.0.0.0/8"},
+			expectedPostgresqlConf: fmt.Sprintf(`listen_addresses = '*'
+gp_contentid = 0
+key1 = 'value1'
+key2 = 'value2'
+port = %d
+`, request.Segment.Port),
+			expectedPgHbaConf: fmt.Sprintf(`host	all	all	cdw	scram-sha-256
+host	all	gpadmin	%[1]s	scram-sha-256
+`, request.Segment.HostName),
+		},
+		{ // primary segment with cert authentication
+			contentId:        0,
+			coordinatorAddrs: []string{"cdw"},
+			hbaHostname:      true,
+			hbaAuthMethod:    "cert",
+			expectedPostgresqlConf: fmt.Sprintf(`listen_addresses = '*'
+gp_contentid = 0
+key1 = 'value1'
+key2 = 'value2'
+port = %d
+`, request.Segment.Port),
+			expectedPgHbaConf: fmt.Sprintf(`hostssl	all	all	cdw	cert
+hostssl	all	gpadmin	%[1]s	cert
 `, request.Segment.HostName),
 		},
 		{ // with hbaHostname set to false
@@ -152,6 +210,9 @@ host	all	gpadmin	2001:db8::/32	trust
 			request.Segment.Contentid = tc.contentId
 			request.CoordinatorAddrs = tc.coordinatorAddrs
 			request.HbaHostNames = tc.hbaHostname
+			request.HbaAuthMethod = tc.hbaAuthMethod
+			request.HbaUserAuthMethod = tc.hbaUserAuthMethod
+			request.HbaUserAddrs = tc.hbaUserAddrs
 			_, err = agentServer.MakeSegment(context.Background(), request)
 			if err != nil {
 				t.Fatalf("unexpected error: %#v", err)
@@ -0,0 +1,105 @@
+package agent
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/greenplum-db/gpdb/gp/idl"
+	"github.com/greenplum-db/gpdb/gp/utils"
+	"github.com/greenplum-db/gpdb/gp/utils/postgres"
+	"github.com/greenplum-db/gpdb/gp/utils/tracing"
+)
+
+// ModifyPgHbaConfAndReload is agent RPC implementation which adds and removes the
+// given rules from the segment pg_hba.conf. The existing file is backed up before
+// being replaced and the segment is optionally reloaded with pg_ctl reload.
+func (s *Server) ModifyPgHbaConfAndReload(ctx context.Context, req *idl.ModifyPgHbaConfRequest) (*idl.ModifyPgHbaConfReply, error) {
+	hba, err := postgres.ReadHbaFile(req.Pgdata)
+	if err != nil {
+		return &idl.ModifyPgHbaConfReply{}, tracing.LogAndReturnError(ctx, fmt.Errorf("reading pg_hba.conf: %w", err))
+	}
+
+	err = modifyHbaFile(hba, req)
+	if err != nil {
+		return &idl.ModifyPgHbaConfReply{}, tracing.LogAndReturnError(ctx, fmt.Errorf("updating pg_hba.conf: %w", err))
+	}
+
+	backupFile, err := hba.WriteWithBackup(req.Pgdata)
+	if err != nil {
+		return &idl.ModifyPgHbaConfReply{}, tracing.LogAndReturnError(ctx, fmt.Errorf("writing pg_hba.conf: %w", err))
+	}
+
+	if req.Reload {
+		pgCtlReloadCmd := &postgres.PgCtlReload{
+			PgData: req.Pgdata,
+		}
+		out, err := utils.RunGpCommandContext(ctx, pgCtlReloadCmd, s.GpHome)
+		if err != nil {
+			return &idl.ModifyPgHbaConfReply{BackupFile: backupFile}, tracing.LogAndReturnError(ctx, fmt.Errorf("executing pg_ctl reload: %s, %w", out, err))
+		}
+	}
+
+	return &idl.ModifyPgHbaConfReply{BackupFile: backupFile}, nil
+}
+
+func modifyHbaFile(hba *postgres.HbaFile, req *idl.ModifyPgHbaConfRequest) error {
+	for _, line := range req.RemoveEntries {
+		entry, err := parseHbaRule(line)
+		if err != nil {
+			return err
+		}
+
+		err = hba.Remove(entry)
+		if err != nil {
+			return err
+		}
+	}
+
+	var entries []*postgres.HbaEntry
+	for _, line := range req.AddEntries {
+		entry, err := parseHbaRule(line)
+		if err != nil {
+			return err
+		}
+		entries = append(entries, entry)
+	}
+
+	if len(entries) == 0 {
+		return nil
+	}
+
+	switch req.Position {
+	case idl.HbaPosition_BEFORE, idl.HbaPosition_AFTER:
+		reference, err := parseHbaRule(req.Reference)
+		if err != nil {
+			return err
+		}
+
+		if req.Position == idl.HbaPosition_BEFORE {
+			return hba.InsertBefore(reference, entries...)
+		}
+		return hba.InsertAfter(reference, entries...)
+	default:
+		hba.Append(entries...)
+	}
+
+	return nil
+}
+
+func parseHbaRule(line string) (*postgres.HbaEntry, error) {
+	entry, err := postgres.ParseHbaEntry(line)
+	if err != nil {
+		return nil, err
+	}
+
+	if !entry.IsRule() {
+		return nil, fmt.Errorf("invalid pg_hba.conf entry %q", line)
+	}
+
+	err = entry.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return entry, nil
+}
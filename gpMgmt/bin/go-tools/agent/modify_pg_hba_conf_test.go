@@ -0,0 +1,225 @@
+package agent_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/greenplum-db/gp-common-go-libs/testhelper"
+	"github.com/greenplum-db/gpdb/gp/agent"
+	"github.com/greenplum-db/gpdb/gp/idl"
+	"github.com/greenplum-db/gpdb/gp/testutils"
+	"github.com/greenplum-db/gpdb/gp/testutils/exectest"
+	"github.com/greenplum-db/gpdb/gp/utils"
+)
+
+func TestModifyPgHbaConfAndReload(t *testing.T) {
+	testhelper.SetupTestLogger()
+
+	agentServer := agent.New(agent.Config{
+		GpHome: "gpHome",
+	})
+
+	content := `# comment
+local	all	gpadmin	ident
+host	all	gpadmin	sdw1	trust`
+
+	createPgData := func(t *testing.T) string {
+		t.Helper()
+
+		pgdata := t.TempDir()
+		err := os.WriteFile(filepath.Join(pgdata, "pg_hba.conf"), []byte(content+"\n"), 0600)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		return pgdata
+	}
+
+	cases := []struct {
+		name     string
+		request  *idl.ModifyPgHbaConfRequest
+		expected string
+	}{
+		{
+			name: "appends the entries",
+			request: &idl.ModifyPgHbaConfRequest{
+				AddEntries: []string{"host all all 10.0.0.0/8 scram-sha-256", "host all gpadmin sdw1 trust"},
+			},
+			expected: `# comment
+local	all	gpadmin	ident
+host	all	gpadmin	sdw1	trust
+host	all	all	10.0.0.0/8	scram-sha-256`,
+		},
+		{
+			name: "inserts the entries before the reference",
+			request: &idl.ModifyPgHbaConfRequest{
+				AddEntries: []string{"host all all 10.0.0.0/8 reject"},
+				Position:   idl.HbaPosition_BEFORE,
+				Reference:  "host all gpadmin sdw1 trust",
+			},
+			expected: `# comment
+local	all	gpadmin	ident
+host	all	all	10.0.0.0/8	reject
+host	all	gpadmin	sdw1	trust`,
+		},
+		{
+			name: "inserts the entries after the reference",
+			request: &idl.ModifyPgHbaConfRequest{
+				AddEntries: []string{"host all all 10.0.0.0/8 md5"},
+				Position:   idl.HbaPosition_AFTER,
+				Reference:  "local all gpadmin ident",
+			},
+			expected: `# comment
+local	all	gpadmin	ident
+host	all	all	10.0.0.0/8	md5
+host	all	gpadmin	sdw1	trust`,
+		},
+		{
+			name: "removes the entries",
+			request: &idl.ModifyPgHbaConfRequest{
+				RemoveEntries: []string{"host   all   gpadmin   sdw1   trust"},
+			},
+			expected: `# comment
+local	all	gpadmin	ident`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			pgdata := createPgData(t)
+			tc.request.Pgdata = pgdata
+
+			result, err := agentServer.ModifyPgHbaConfAndReload(context.Background(), tc.request)
+			if err != nil {
+				t.Fatalf("unexpected error: %#v", err)
+			}
+
+			testutils.AssertFileContents(t, filepath.Join(pgdata, "pg_hba.conf"), tc.expected)
+			testutils.AssertFileContents(t, result.BackupFile, content)
+		})
+	}
+
+	t.Run("reloads the segment when requested", func(t *testing.T) {
+		pgdata := createPgData(t)
+
+		var pgCtlCalled bool
+		utils.System.ExecCommand = exectest.NewCommandWithVerifier(exectest.Success, func(utility string, args ...string) {
+			pgCtlCalled = true
+
+			expectedUtility := "pg_ctl"
+			if !strings.HasSuffix(utility, expectedUtility) {
+				t.Fatalf("got %s, want %s", utility, expectedUtility)
+			}
+
+			expectedArgs := []string{"reload", "--pgdata", pgdata}
+			if !reflect.DeepEqual(args, expectedArgs) {
+				t.Fatalf("got %+v, want %+v", args, expectedArgs)
+			}
+		})
+		defer utils.ResetSystemFunctions()
+
+		_, err := agentServer.ModifyPgHbaConfAndReload(context.Background(), &idl.ModifyPgHbaConfRequest{
+			Pgdata:     pgdata,
+			AddEntries: []string{"host all all 10.0.0.0/8 md5"},
+			Reload:     true,
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		if !pgCtlCalled {
+			t.Fatalf("expected pg_ctl to be called")
+		}
+	})
+
+	errCases := []struct {
+		name        string
+		request     *idl.ModifyPgHbaConfRequest
+		expectedErr string
+	}{
+		{
+			name: "entry to remove is not present",
+			request: &idl.ModifyPgHbaConfRequest{
+				RemoveEntries: []string{"host all all sdw2 trust"},
+			},
+			expectedErr: `updating pg_hba.conf: entry "host\tall\tall\tsdw2\ttrust" not found`,
+		},
+		{
+			name: "entry to add is invalid",
+			request: &idl.ModifyPgHbaConfRequest{
+				AddEntries: []string{"host all all sdw2 unknown"},
+			},
+			expectedErr: `updating pg_hba.conf: invalid authentication method "unknown" in entry "host\tall\tall\tsdw2\tunknown"`,
+		},
+		{
+			name: "entry to add is a comment",
+			request: &idl.ModifyPgHbaConfRequest{
+				AddEntries: []string{"# comment"},
+			},
+			expectedErr: `updating pg_hba.conf: invalid pg_hba.conf entry "# comment"`,
+		},
+		{
+			name: "reference entry is not present",
+			request: &idl.ModifyPgHbaConfRequest{
+				AddEntries: []string{"host all all sdw2 trust"},
+				Position:   idl.HbaPosition_BEFORE,
+				Reference:  "host all all sdw3 trust",
+			},
+			expectedErr: `updating pg_hba.conf: entry "host\tall\tall\tsdw3\ttrust" not found`,
+		},
+	}
+
+	for _, tc := range errCases {
+		t.Run("does not modify the file when the "+tc.name, func(t *testing.T) {
+			pgdata := createPgData(t)
+			tc.request.Pgdata = pgdata
+
+			_, err := agentServer.ModifyPgHbaConfAndReload(context.Background(), tc.request)
+			if err == nil || err.Error() != tc.expectedErr {
+				t.Fatalf("got %v, want %s", err, tc.expectedErr)
+			}
+
+			testutils.AssertFileContents(t, filepath.Join(pgdata, "pg_hba.conf"), content)
+		})
+	}
+
+	t.Run("returns error when not able to read the pg_hba.conf file", func(t *testing.T) {
+		expectedErr := errors.New("error")
+		utils.System.ReadFile = func(name string) ([]byte, error) {
+			return nil, expectedErr
+		}
+		defer utils.ResetSystemFunctions()
+
+		_, err := agentServer.ModifyPgHbaConfAndReload(context.Background(), &idl.ModifyPgHbaConfRequest{Pgdata: "gpseg"})
+		if !errors.Is(err, expectedErr) {
+			t.Fatalf("got %#v, want %#v", err, expectedErr)
+		}
+	})
+
+	t.Run("returns the backup file when not able to pg_ctl reload", func(t *testing.T) {
+		pgdata := createPgData(t)
+
+		utils.System.ExecCommand = exectest.NewCommand(exectest.Failure)
+		defer utils.ResetSystemFunctions()
+
+		result, err := agentServer.ModifyPgHbaConfAndReload(context.Background(), &idl.ModifyPgHbaConfRequest{
+			Pgdata:     pgdata,
+			AddEntries: []string{"host all all 10.0.0.0/8 md5"},
+			Reload:     true,
+		})
+		var expectedErr *exec.ExitError
+		if !errors.As(err, &expectedErr) {
+			t.Fatalf("got %T, want %T", err, expectedErr)
+		}
+
+		if result.BackupFile == "" {
+			t.Fatalf("expected backup file to be returned")
+		}
+	})
+}
@@ -39,7 +39,7 @@ func (s *Server) PgBasebackup(ctx context.Context, req *idl.PgBasebackupRequest)
 	// TODO Check if the directory is empty if ForceOverwrite is false
 
 	pgBasebackupLog := filepath.Join(s.LogDir, fmt.Sprintf("pg_basebackup.%s.dbid%d.out", time.Now().Format("20060102_150405"), req.TargetDbid))
-	out, err := utils.RunGpCommandAndRedirectOutput(pgBasebackupCmd, s.GpHome, pgBasebackupLog)
+	out, err := utils.RunGpCommandAndRedirectOutputContext(ctx, pgBasebackupCmd, s.GpHome, pgBasebackupLog)
 	if err != nil {
 		return &idl.PgBasebackupResponse{}, fmt.Errorf("executing pg_basebackup: %s, logfile: %s, %w", out, pgBasebackupLog, err)
 	}
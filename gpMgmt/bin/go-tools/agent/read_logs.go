@@ -0,0 +1,492 @@
+package agent
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/greenplum-db/gpdb/gp/constants"
+	"github.com/greenplum-db/gpdb/gp/idl"
+	"github.com/greenplum-db/gpdb/gp/utils"
+	"github.com/greenplum-db/gpdb/gp/utils/tracing"
+)
+
+const (
+	// Maximum number of bytes read from a log file at once
+	logReadSize = 1024 * 1024
+
+	// Interval at which the followed log file is checked for new lines
+	logFollowInterval = 500 * time.Millisecond
+
+	// Fields of the CSV logs of the segments
+	csvLogTimeField     = 0
+	csvLogSeverityField = 16
+	csvLogTimeLayout    = "2006-01-02 15:04:05.999999 MST"
+)
+
+// Severities of the server log messages, in increasing order as used by
+// client_min_messages
+var logSeverityLevels = map[string]int{
+	"DEBUG5":  1,
+	"DEBUG4":  2,
+	"DEBUG3":  3,
+	"DEBUG2":  4,
+	"DEBUG1":  5,
+	"LOG":     6,
+	"INFO":    7,
+	"NOTICE":  8,
+	"WARNING": 9,
+	"ERROR":   10,
+	"FATAL":   11,
+	"PANIC":   12,
+}
+
+/*
+ReadLogs streams the log files of the segment with the given data directory, or
+of the agent and the utilities it ran when no data directory is given. Only the
+latest file is read unless a file or a start time is requested. When following,
+the lines appended to the latest file are streamed until the call is cancelled.
+*/
+func (s *Server) ReadLogs(req *idl.ReadLogsRequest, stream idl.Agent_ReadLogsServer) error {
+	dir := s.LogDir
+	if req.Pgdata != "" {
+		dir = segmentLogDir(req.Pgdata)
+	}
+
+	filter, err := newLogFilter(req.Filter)
+	if err != nil {
+		return tracing.LogAndReturnError(stream.Context(), err)
+	}
+
+	files, err := listLogFiles(dir)
+	if err != nil {
+		return tracing.LogAndReturnError(stream.Context(), err)
+	}
+
+	if req.List {
+		var reply idl.ReadLogsReply
+		for _, file := range files {
+			if filter.since.IsZero() || !file.ModTime().Before(filter.since) {
+				reply.Files = append(reply.Files, &idl.LogFile{
+					Name:    file.Name(),
+					Size:    file.Size(),
+					ModTime: file.ModTime().Unix(),
+				})
+			}
+		}
+
+		return stream.Send(&reply)
+	}
+
+	selected, err := selectLogFiles(files, filter)
+	if err != nil {
+		return tracing.LogAndReturnError(stream.Context(), fmt.Errorf("%w in %s", err, dir))
+	}
+
+	var reader *logReader
+	for _, file := range selected {
+		reader = newLogReader(filepath.Join(dir, file.Name()))
+		err = reader.readAll(filter, stream)
+		if err != nil {
+			return tracing.LogAndReturnError(stream.Context(), err)
+		}
+	}
+
+	if !req.Follow {
+		return nil
+	}
+
+	// Nothing was read when no file was modified since the start of the time
+	// range, so only the lines appended to the latest file are streamed
+	if reader == nil {
+		latest := files[len(files)-1]
+		reader = newLogReader(filepath.Join(dir, latest.Name()))
+		reader.offset = latest.Size()
+	}
+
+	// Postgres switches to a new file when rotating its logs, so keep looking
+	// for files newer than the one being followed
+	ticker := time.NewTicker(logFollowInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		err = reader.read(filter, stream)
+		if err != nil {
+			return tracing.LogAndReturnError(stream.Context(), err)
+		}
+
+		if filter.file != "" {
+			continue
+		}
+
+		files, err = listLogFiles(dir)
+		if err != nil {
+			return tracing.LogAndReturnError(stream.Context(), err)
+		}
+		latest := filepath.Join(dir, files[len(files)-1].Name())
+		if latest != reader.path {
+			err = reader.flush(filter, stream)
+			if err != nil {
+				return tracing.LogAndReturnError(stream.Context(), err)
+			}
+			reader = newLogReader(latest)
+		}
+	}
+}
+
+// segmentLogDir returns the log directory of the segment, which is pg_log for
+// the clusters created before it was renamed
+func segmentLogDir(pgdata string) string {
+	dir := filepath.Join(pgdata, constants.DefaultPostgresLogDir)
+	if _, err := utils.System.Stat(dir); err != nil {
+		oldDir := filepath.Join(pgdata, "pg_log")
+		if _, err := utils.System.Stat(oldDir); err == nil {
+			return oldDir
+		}
+	}
+
+	return dir
+}
+
+// listLogFiles returns the files of the log directory from the oldest to the
+// most recently modified
+func listLogFiles(dir string) ([]os.FileInfo, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("could not list the log files: %w", err)
+	}
+
+	var files []os.FileInfo
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() {
+			continue
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		files = append(files, info)
+	}
+
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no log files found in %s", dir)
+	}
+
+	sort.SliceStable(files, func(i, j int) bool {
+		return files[i].ModTime().Before(files[j].ModTime())
+	})
+
+	return files, nil
+}
+
+// selectLogFiles returns the requested file, or the files modified since the
+// start of the time range, or else the latest file
+func selectLogFiles(files []os.FileInfo, filter *logFilter) ([]os.FileInfo, error) {
+	if filter.file != "" {
+		for _, file := range files {
+			if file.Name() == filter.file {
+				return []os.FileInfo{file}, nil
+			}
+		}
+
+		return nil, fmt.Errorf("log file %s not found", filter.file)
+	}
+
+	if filter.since.IsZero() {
+		return files[len(files)-1:], nil
+	}
+
+	var selected []os.FileInfo
+	for _, file := range files {
+		if !file.ModTime().Before(filter.since) {
+			selected = append(selected, file)
+		}
+	}
+
+	return selected, nil
+}
+
+type logFilter struct {
+	since       time.Time
+	until       time.Time
+	minSeverity int
+	grep        *regexp.Regexp
+	tail        int
+	file        string
+}
+
+func newLogFilter(req *idl.LogFilter) (*logFilter, error) {
+	filter := &logFilter{
+		tail: int(req.GetTail()),
+		file: req.GetFile(),
+	}
+
+	if req.GetSince() > 0 {
+		filter.since = time.Unix(req.GetSince(), 0)
+	}
+	if req.GetUntil() > 0 {
+		filter.until = time.Unix(req.GetUntil(), 0)
+	}
+
+	if req.GetMinSeverity() != "" {
+		level, ok := logSeverityLevels[strings.ToUpper(req.GetMinSeverity())]
+		if !ok {
+			return nil, fmt.Errorf("unknown log severity %q", req.GetMinSeverity())
+		}
+		filter.minSeverity = level
+	}
+
+	if req.GetGrep() != "" {
+		pattern, err := regexp.Compile(req.GetGrep())
+		if err != nil {
+			return nil, fmt.Errorf("invalid grep pattern: %w", err)
+		}
+		filter.grep = pattern
+	}
+
+	if filter.file != "" && filepath.Base(filter.file) != filter.file {
+		return nil, fmt.Errorf("invalid log file name %q", filter.file)
+	}
+
+	return filter, nil
+}
+
+type logEntry struct {
+	text     string
+	time     time.Time
+	severity string
+}
+
+// matches reports whether the entry passes the filter. The time range and the
+// severity are only checked for the entries having them.
+func (f *logFilter) matches(entry logEntry) bool {
+	if !entry.time.IsZero() {
+		if !f.since.IsZero() && entry.time.Before(f.since) {
+			return false
+		}
+		if !f.until.IsZero() && entry.time.After(f.until) {
+			return false
+		}
+	}
+
+	if f.minSeverity > 0 && entry.severity != "" && logSeverityLevels[entry.severity] < f.minSeverity {
+		return false
+	}
+
+	return f.grep == nil || f.grep.MatchString(entry.text)
+}
+
+/*
+logReader reads the entries of a log file incrementally, keeping the partial
+entry at the end of the file for the next read. The entries of the CSV logs
+are records which may span several lines.
+*/
+type logReader struct {
+	path    string
+	csv     bool
+	offset  int64
+	pending []byte
+}
+
+func newLogReader(path string) *logReader {
+	return &logReader{
+		path: path,
+		csv:  filepath.Ext(path) == ".csv",
+	}
+}
+
+// readAll sends the entries of the whole file, keeping only the last ones when
+// requested by the filter
+func (r *logReader) readAll(filter *logFilter, stream logSender) error {
+	if filter.tail <= 0 {
+		err := r.read(filter, stream)
+		if err != nil {
+			return err
+		}
+
+		return r.flush(filter, stream)
+	}
+
+	var last []string
+	collect := &logCollector{send: func(lines []string) {
+		last = append(last, lines...)
+		if len(last) > filter.tail {
+			last = last[len(last)-filter.tail:]
+		}
+	}}
+
+	err := r.read(filter, collect)
+	if err != nil {
+		return err
+	}
+	err = r.flush(filter, collect)
+	if err != nil {
+		return err
+	}
+
+	return sendLogLines(stream, r.path, last)
+}
+
+// read sends the entries appended to the file since the last read
+func (r *logReader) read(filter *logFilter, stream logSender) error {
+	file, err := utils.System.Open(r.path)
+	if err != nil {
+		return fmt.Errorf("could not open log file: %w", err)
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return fmt.Errorf("could not open log file: %w", err)
+	}
+
+	// The file was truncated or replaced, start over
+	if info.Size() < r.offset {
+		r.offset = 0
+		r.pending = nil
+	}
+
+	_, err = file.Seek(r.offset, io.SeekStart)
+	if err != nil {
+		return fmt.Errorf("could not read log file %s: %w", r.path, err)
+	}
+
+	buf := make([]byte, logReadSize)
+	for {
+		n, err := file.Read(buf)
+		if n > 0 {
+			r.offset += int64(n)
+			r.pending = append(r.pending, buf[:n]...)
+
+			var entries []logEntry
+			entries, r.pending = r.parse(r.pending)
+			err := sendLogEntries(stream, r.path, filter, entries)
+			if err != nil {
+				return err
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("could not read log file %s: %w", r.path, err)
+		}
+	}
+}
+
+// flush sends the last entry of the file when it does not end with a newline
+func (r *logReader) flush(filter *logFilter, stream logSender) error {
+	if len(r.pending) == 0 {
+		return nil
+	}
+
+	entry := logEntry{text: string(r.pending)}
+	r.pending = nil
+
+	return sendLogEntries(stream, r.path, filter, []logEntry{entry})
+}
+
+// parse returns the complete entries of the data, along with the remaining
+// partial entry
+func (r *logReader) parse(data []byte) ([]logEntry, []byte) {
+	end := bytes.LastIndexByte(data, '\n') + 1
+	if end == 0 {
+		return nil, data
+	}
+
+	if !r.csv {
+		var entries []logEntry
+		for _, line := range strings.Split(string(data[:end-1]), "\n") {
+			entries = append(entries, logEntry{text: line})
+		}
+
+		return entries, data[end:]
+	}
+
+	var entries []logEntry
+	reader := csv.NewReader(bytes.NewReader(data[:end]))
+	reader.FieldsPerRecord = -1
+	var consumed int64
+	for {
+		record, err := reader.Read()
+		if err != nil {
+			// Either the end of the data or a record still being written
+			break
+		}
+		consumed = reader.InputOffset()
+
+		entries = append(entries, csvLogEntry(record))
+	}
+
+	// Do not hold on to a malformed record forever
+	if consumed == 0 && len(data) > logReadSize {
+		return []logEntry{{text: string(data[:end-1])}}, data[end:]
+	}
+
+	return entries, data[consumed:]
+}
+
+func csvLogEntry(record []string) logEntry {
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	_ = writer.Write(record)
+	writer.Flush()
+
+	entry := logEntry{text: strings.TrimSuffix(buf.String(), "\n")}
+	if len(record) > csvLogSeverityField {
+		entry.time, _ = time.Parse(csvLogTimeLayout, record[csvLogTimeField])
+		entry.severity = record[csvLogSeverityField]
+	}
+
+	return entry
+}
+
+type logSender interface {
+	Send(*idl.ReadLogsReply) error
+}
+
+// logCollector gathers the lines instead of sending them
+type logCollector struct {
+	send func(lines []string)
+}
+
+func (c *logCollector) Send(reply *idl.ReadLogsReply) error {
+	c.send(reply.Lines)
+	return nil
+}
+
+func sendLogEntries(stream logSender, path string, filter *logFilter, entries []logEntry) error {
+	var lines []string
+	for _, entry := range entries {
+		if filter.matches(entry) {
+			lines = append(lines, entry.text)
+		}
+	}
+
+	return sendLogLines(stream, path, lines)
+}
+
+func sendLogLines(stream logSender, path string, lines []string) error {
+	if len(lines) == 0 {
+		return nil
+	}
+
+	return stream.Send(&idl.ReadLogsReply{
+		File:  filepath.Base(path),
+		Lines: lines,
+	})
+}
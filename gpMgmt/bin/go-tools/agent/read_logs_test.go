@@ -0,0 +1,234 @@
+package agent_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/greenplum-db/gp-common-go-libs/testhelper"
+	"google.golang.org/grpc"
+
+	"github.com/greenplum-db/gpdb/gp/agent"
+	"github.com/greenplum-db/gpdb/gp/idl"
+)
+
+type readLogsStream struct {
+	grpc.ServerStream
+	ctx     context.Context
+	replies chan *idl.ReadLogsReply
+}
+
+func newReadLogsStream(ctx context.Context) *readLogsStream {
+	return &readLogsStream{
+		ctx:     ctx,
+		replies: make(chan *idl.ReadLogsReply, 100),
+	}
+}
+
+func (s *readLogsStream) Send(reply *idl.ReadLogsReply) error {
+	s.replies <- reply
+	return nil
+}
+
+func (s *readLogsStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *readLogsStream) lines() []string {
+	var lines []string
+	for len(s.replies) > 0 {
+		reply := <-s.replies
+		for _, line := range reply.Lines {
+			lines = append(lines, reply.File+": "+line)
+		}
+	}
+
+	return lines
+}
+
+func writeLogFile(t *testing.T, path string, content string, modTime time.Time) {
+	t.Helper()
+
+	err := os.WriteFile(path, []byte(content), 0600)
+	if err != nil {
+		t.Fatalf("unexpected error: %#v", err)
+	}
+
+	err = os.Chtimes(path, modTime, modTime)
+	if err != nil {
+		t.Fatalf("unexpected error: %#v", err)
+	}
+}
+
+func csvLogLine(logTime string, severity string, message string) string {
+	fields := []string{logTime, "gpadmin", "postgres", "p1234", "th1", "", "", "", "0", "con1", "cmd1", "seg0", "", "", "", "", severity, "00000", message}
+	return strings.Join(fields, ",") + "\n"
+}
+
+func TestReadLogs(t *testing.T) {
+	testhelper.SetupTestLogger()
+
+	pgdata := t.TempDir()
+	logDir := filepath.Join(pgdata, "log")
+	err := os.Mkdir(logDir, 0700)
+	if err != nil {
+		t.Fatalf("unexpected error: %#v", err)
+	}
+	writeLogFile(t, filepath.Join(logDir, "gpdb-2024-01-30_000000.csv"),
+		csvLogLine("2024-01-30 10:00:00.000000 UTC", "LOG", "old"), time.Date(2024, 1, 30, 10, 0, 0, 0, time.UTC))
+	writeLogFile(t, filepath.Join(logDir, "gpdb-2024-01-31_000000.csv"),
+		csvLogLine("2024-01-31 10:00:00.000000 UTC", "LOG", "database system is ready")+
+			csvLogLine("2024-01-31 11:00:00.000000 UTC", "WARNING", `"checkpoints are occurring
+too frequently"`)+
+			csvLogLine("2024-01-31 12:00:00.000000 UTC", "ERROR", "relation does not exist"), time.Date(2024, 1, 31, 12, 0, 0, 0, time.UTC))
+
+	agentLogDir := t.TempDir()
+	writeLogFile(t, filepath.Join(agentLogDir, "gp_agent_20240131.log"), "line 1\nline 2\nline 3", time.Now())
+
+	agentServer := agent.New(agent.Config{LogDir: agentLogDir})
+
+	t.Run("lists the log files of the segment", func(t *testing.T) {
+		stream := newReadLogsStream(context.Background())
+		err := agentServer.ReadLogs(&idl.ReadLogsRequest{Pgdata: pgdata, List: true}, stream)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		reply := <-stream.replies
+		var names []string
+		for _, file := range reply.Files {
+			names = append(names, file.Name)
+		}
+		expected := []string{"gpdb-2024-01-30_000000.csv", "gpdb-2024-01-31_000000.csv"}
+		if !reflect.DeepEqual(names, expected) {
+			t.Fatalf("got %v, want %v", names, expected)
+		}
+	})
+
+	t.Run("reads the latest log file of the agent", func(t *testing.T) {
+		stream := newReadLogsStream(context.Background())
+		err := agentServer.ReadLogs(&idl.ReadLogsRequest{}, stream)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		expected := []string{"gp_agent_20240131.log: line 1", "gp_agent_20240131.log: line 2", "gp_agent_20240131.log: line 3"}
+		if lines := stream.lines(); !reflect.DeepEqual(lines, expected) {
+			t.Fatalf("got %v, want %v", lines, expected)
+		}
+	})
+
+	t.Run("reads the last lines matching the filter", func(t *testing.T) {
+		stream := newReadLogsStream(context.Background())
+		err := agentServer.ReadLogs(&idl.ReadLogsRequest{Filter: &idl.LogFilter{Grep: "line [12]", Tail: 1}}, stream)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		expected := []string{"gp_agent_20240131.log: line 2"}
+		if lines := stream.lines(); !reflect.DeepEqual(lines, expected) {
+			t.Fatalf("got %v, want %v", lines, expected)
+		}
+	})
+
+	t.Run("filters the CSV logs by severity and time", func(t *testing.T) {
+		stream := newReadLogsStream(context.Background())
+		err := agentServer.ReadLogs(&idl.ReadLogsRequest{
+			Pgdata: pgdata,
+			Filter: &idl.LogFilter{
+				MinSeverity: "warning",
+				Until:       time.Date(2024, 1, 31, 11, 30, 0, 0, time.UTC).Unix(),
+			},
+		}, stream)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		lines := stream.lines()
+		if len(lines) != 1 || !strings.Contains(lines[0], "checkpoints are occurring\ntoo frequently") {
+			t.Fatalf("got %q, want the warning only", lines)
+		}
+	})
+
+	t.Run("reads the files modified since the start of the time range", func(t *testing.T) {
+		stream := newReadLogsStream(context.Background())
+		err := agentServer.ReadLogs(&idl.ReadLogsRequest{
+			Pgdata: pgdata,
+			Filter: &idl.LogFilter{Since: time.Date(2024, 1, 30, 0, 0, 0, 0, time.UTC).Unix(), Grep: "old|ready"},
+		}, stream)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		lines := stream.lines()
+		if len(lines) != 2 || !strings.HasPrefix(lines[0], "gpdb-2024-01-30_000000.csv") || !strings.HasPrefix(lines[1], "gpdb-2024-01-31_000000.csv") {
+			t.Fatalf("got %q, want a line from each file", lines)
+		}
+	})
+
+	t.Run("follows the lines appended to the latest file", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		stream := newReadLogsStream(ctx)
+		errChan := make(chan error, 1)
+		go func() {
+			errChan <- agentServer.ReadLogs(&idl.ReadLogsRequest{Follow: true, Filter: &idl.LogFilter{Tail: 1}}, stream)
+		}()
+
+		reply := <-stream.replies
+		if !reflect.DeepEqual(reply.Lines, []string{"line 3"}) {
+			t.Fatalf("got %q, want the last line", reply.Lines)
+		}
+
+		file, err := os.OpenFile(filepath.Join(agentLogDir, "gp_agent_20240131.log"), os.O_APPEND|os.O_WRONLY, 0600)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		_, _ = file.WriteString(" continued\nline 4\n")
+		file.Close()
+
+		select {
+		case reply = <-stream.replies:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("expected the appended lines")
+		}
+		if !reflect.DeepEqual(reply.Lines, []string{" continued", "line 4"}) {
+			t.Fatalf("got %q, want the appended lines", reply.Lines)
+		}
+
+		cancel()
+		err = <-errChan
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+	})
+
+	t.Run("errors out when the log file is not found", func(t *testing.T) {
+		err := agentServer.ReadLogs(&idl.ReadLogsRequest{Filter: &idl.LogFilter{File: "missing.log"}}, newReadLogsStream(context.Background()))
+		expected := "log file missing.log not found in " + agentLogDir
+		if err == nil || err.Error() != expected {
+			t.Fatalf("got %v, want %s", err, expected)
+		}
+	})
+
+	t.Run("errors out for file names outside of the log directory", func(t *testing.T) {
+		err := agentServer.ReadLogs(&idl.ReadLogsRequest{Filter: &idl.LogFilter{File: "../gp.conf"}}, newReadLogsStream(context.Background()))
+		expected := `invalid log file name "../gp.conf"`
+		if err == nil || err.Error() != expected {
+			t.Fatalf("got %v, want %s", err, expected)
+		}
+	})
+
+	t.Run("errors out for unknown severities", func(t *testing.T) {
+		err := agentServer.ReadLogs(&idl.ReadLogsRequest{Filter: &idl.LogFilter{MinSeverity: "loud"}}, newReadLogsStream(context.Background()))
+		expected := `unknown log severity "loud"`
+		if err == nil || err.Error() != expected {
+			t.Fatalf("got %v, want %s", err, expected)
+		}
+	})
+}
@@ -0,0 +1,37 @@
+package agent
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/greenplum-db/gp-common-go-libs/gplog"
+	"github.com/greenplum-db/gpdb/gp/idl"
+	"github.com/greenplum-db/gpdb/gp/utils"
+	"github.com/greenplum-db/gpdb/gp/utils/tracing"
+)
+
+// ReloadCertificates reloads the server certificates of the agent after they
+// have been rotated. Established connections are not interrupted.
+func (s *Server) ReloadCertificates(ctx context.Context, req *idl.ReloadCertificatesRequest) (*idl.ReloadCertificatesReply, error) {
+	err := s.reloadCertificates()
+	if err != nil {
+		return &idl.ReloadCertificatesReply{}, tracing.LogAndReturnError(ctx, err)
+	}
+
+	return &idl.ReloadCertificatesReply{}, nil
+}
+
+func (s *Server) reloadCertificates() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if reloader, ok := s.serverCredentials.(utils.Reloader); ok {
+		err := reloader.Reload()
+		if err != nil {
+			return fmt.Errorf("could not reload agent certificates: %w", err)
+		}
+		gplog.Info("Reloaded agent certificates")
+	}
+
+	return nil
+}
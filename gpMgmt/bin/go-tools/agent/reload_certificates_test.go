@@ -0,0 +1,80 @@
+package agent_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/greenplum-db/gp-common-go-libs/testhelper"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/greenplum-db/gpdb/gp/agent"
+	"github.com/greenplum-db/gpdb/gp/idl"
+	"github.com/greenplum-db/gpdb/gp/testutils"
+)
+
+func TestReloadCertificates(t *testing.T) {
+	testhelper.SetupTestLogger()
+
+	startServer := func(t *testing.T, creds *testutils.MockReloadableCredentials) *agent.Server {
+		t.Helper()
+
+		agentServer := agent.New(agent.Config{
+			Credentials: &testutils.MockCredentials{TlsConnection: creds},
+		})
+		errChan := make(chan error, 1)
+		go func() {
+			errChan <- agentServer.Start()
+		}()
+
+		select {
+		case err := <-errChan:
+			t.Fatalf("unexpected error: %#v", err)
+		case <-time.After(500 * time.Millisecond):
+		}
+
+		return agentServer
+	}
+
+	t.Run("reloads the server certificates", func(t *testing.T) {
+		creds := &testutils.MockReloadableCredentials{TransportCredentials: insecure.NewCredentials()}
+		agentServer := startServer(t, creds)
+		defer agentServer.Shutdown()
+
+		_, err := agentServer.ReloadCertificates(context.Background(), &idl.ReloadCertificatesRequest{})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		if creds.Reloaded != 1 {
+			t.Fatalf("got %d reloads, want 1", creds.Reloaded)
+		}
+	})
+
+	t.Run("errors out when the certificates cannot be reloaded", func(t *testing.T) {
+		expected := errors.New("error")
+		creds := &testutils.MockReloadableCredentials{TransportCredentials: insecure.NewCredentials(), Err: expected}
+		agentServer := startServer(t, creds)
+		defer agentServer.Shutdown()
+
+		_, err := agentServer.ReloadCertificates(context.Background(), &idl.ReloadCertificatesRequest{})
+		if !errors.Is(err, expected) {
+			t.Fatalf("got %#v, want %#v", err, expected)
+		}
+
+		expectedErr := "could not reload agent certificates: error"
+		if err.Error() != expectedErr {
+			t.Fatalf("got %q, want %q", err, expectedErr)
+		}
+	})
+
+	t.Run("succeeds when the server is not using reloadable certificates", func(t *testing.T) {
+		agentServer := agent.New(agent.Config{})
+
+		_, err := agentServer.ReloadCertificates(context.Background(), &idl.ReloadCertificatesRequest{})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+	})
+}
@@ -6,9 +6,13 @@ import (
 	"net"
 	"sync"
 
+	"github.com/greenplum-db/gp-common-go-libs/gplog"
 	"github.com/greenplum-db/gpdb/gp/idl"
 	"github.com/greenplum-db/gpdb/gp/utils"
+	"github.com/greenplum-db/gpdb/gp/utils/metrics"
+	"github.com/greenplum-db/gpdb/gp/utils/tracing"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/reflection"
 )
 
@@ -23,14 +27,22 @@ type Config struct {
 	LogDir      string
 
 	Credentials utils.Credentials
+	Metrics     *metrics.Config
 }
 
 type Server struct {
 	*Config
 
-	mutex      sync.Mutex
-	grpcServer *grpc.Server
-	listener   net.Listener
+	mutex             sync.Mutex
+	grpcServer        *grpc.Server
+	listener          net.Listener
+	serverCredentials credentials.TransportCredentials
+
+	// temporary listeners of gp check network, by check
+	checkMutex sync.Mutex
+	checks     map[string]*networkCheck
+	// the throughput measured by the other hosts is served one at a time
+	throughputMutex sync.Mutex
 }
 
 func New(conf Config) *Server {
@@ -50,30 +62,45 @@ func (s *Server) Start() error {
 		return fmt.Errorf("could not listen on port %d: %w", s.Port, err)
 	}
 
-	interceptor := func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
-		// handle stuff here if needed
-		return handler(ctx, req)
-	}
-
-	credentials, err := s.Credentials.LoadServerCredentials()
+	serverCredentials, err := s.Credentials.LoadServerCredentials(utils.RoleAgent)
 	if err != nil {
 		listener.Close()
 		return err
 	}
 
+	if s.Metrics != nil && s.Metrics.AgentPort > 0 {
+		metricsServer, err := metrics.Serve(s.Metrics.BindAddress, s.Metrics.AgentPort)
+		if err != nil {
+			listener.Close()
+			return err
+		}
+		defer metricsServer.Close()
+	}
+
 	grpcServer := grpc.NewServer(
-		grpc.Creds(credentials),
-		grpc.UnaryInterceptor(interceptor),
+		grpc.Creds(serverCredentials),
+		grpc.ChainUnaryInterceptor(tracing.UnaryServerInterceptor, metrics.UnaryServerInterceptor),
+		grpc.ChainStreamInterceptor(tracing.StreamServerInterceptor, metrics.StreamServerInterceptor),
 	)
 
 	s.mutex.Lock()
 	s.grpcServer = grpcServer
 	s.listener = listener
+	s.serverCredentials = serverCredentials
 	s.mutex.Unlock()
 
 	idl.RegisterAgentServer(grpcServer, s)
 	reflection.Register(grpcServer)
 
+	stopReload := utils.NotifyOnReload(func() {
+		gplog.Info("Received SIGHUP, reloading certificates")
+		err := s.reloadCertificates()
+		if err != nil {
+			gplog.Error(err.Error())
+		}
+	})
+	defer stopReload()
+
 	err = grpcServer.Serve(listener)
 	if err != nil {
 		return fmt.Errorf("failed to serve: %w", err)
@@ -94,7 +121,7 @@ func (s *Server) Shutdown() {
 func (s *Server) Status(ctx context.Context, in *idl.StatusAgentRequest) (*idl.StatusAgentReply, error) {
 	status, err := s.GetStatus()
 	if err != nil {
-		return &idl.StatusAgentReply{}, utils.LogAndReturnError(fmt.Errorf("could not get agent status: %w", err))
+		return &idl.StatusAgentReply{}, tracing.LogAndReturnError(ctx, fmt.Errorf("could not get agent status: %w", err))
 	}
 
 	return &idl.StatusAgentReply{Status: status.Status, Uptime: status.Uptime, Pid: uint32(status.Pid)}, nil
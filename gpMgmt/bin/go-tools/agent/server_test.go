@@ -14,6 +14,7 @@ import (
 	"github.com/greenplum-db/gpdb/gp/constants"
 	"github.com/greenplum-db/gpdb/gp/idl"
 	"github.com/greenplum-db/gpdb/gp/testutils"
+	"github.com/greenplum-db/gpdb/gp/utils/metrics"
 )
 
 func TestStartServer(t *testing.T) {
@@ -70,6 +71,37 @@ func TestStartServer(t *testing.T) {
 		}
 	})
 
+	t.Run("fails to start if the metrics cannot be served", func(t *testing.T) {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		defer listener.Close()
+		metricsPort := listener.Addr().(*net.TCPAddr).Port
+
+		agentServer := agent.New(agent.Config{
+			ServiceName: constants.DefaultServiceName,
+			Credentials: &testutils.MockCredentials{},
+			Metrics:     &metrics.Config{BindAddress: "127.0.0.1", AgentPort: metricsPort},
+		})
+		errChan := make(chan error, 1)
+
+		go func() {
+			errChan <- agentServer.Start()
+		}()
+		defer agentServer.Shutdown()
+
+		select {
+		case err := <-errChan:
+			expected := fmt.Sprintf("could not listen on metrics port %d:", metricsPort)
+			if err == nil || !strings.HasPrefix(err.Error(), expected) {
+				t.Fatalf("got %v, want %s", err, expected)
+			}
+		case <-time.After(1 * time.Second):
+			t.Fatalf("expected server start to fail")
+		}
+	})
+
 	t.Run("listen fails when starting the server", func(t *testing.T) {
 		credentials := &testutils.MockCredentials{}
 
@@ -7,6 +7,7 @@ import (
 	"github.com/greenplum-db/gpdb/gp/idl"
 	"github.com/greenplum-db/gpdb/gp/utils"
 	"github.com/greenplum-db/gpdb/gp/utils/postgres"
+	"github.com/greenplum-db/gpdb/gp/utils/tracing"
 )
 
 /*
@@ -21,9 +22,9 @@ func (s *Server) StartSegment(ctx context.Context, in *idl.StartSegmentRequest)
 		Timeout: int(in.Timeout),
 		Options: in.Options,
 	}
-	out, err := utils.RunGpCommand(&pgCtlStartOptions, s.GpHome)
+	out, err := utils.RunGpCommandContext(ctx, &pgCtlStartOptions, s.GpHome)
 	if err != nil {
-		return &idl.StartSegmentReply{}, utils.LogAndReturnError(fmt.Errorf("executing pg_ctl start: %s, logfile: %s, %w", out, pgCtlStartOptions.Logfile, err))
+		return &idl.StartSegmentReply{}, tracing.LogAndReturnError(ctx, fmt.Errorf("executing pg_ctl start: %s, logfile: %s, %w", out, pgCtlStartOptions.Logfile, err))
 	}
 
 	return &idl.StartSegmentReply{}, nil
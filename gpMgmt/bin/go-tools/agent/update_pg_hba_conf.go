@@ -12,7 +12,8 @@ import (
 // UpdatePgHbaConf is agent RPC implementation which updates the segment pg_hba.conf
 // with the given address list and then reloads the segment with pg_ctl reload.
 func (s *Server) UpdatePgHbaConfAndReload(ctx context.Context, req *idl.UpdatePgHbaConfRequest) (*idl.UpdatePgHbaConfResponse, error) {
-	err := postgres.UpdateSegmentPgHbaConf(req.Pgdata, req.Addrs, req.Replication)
+	auth := postgres.HbaAuth{Method: req.AuthMethod, ReplicationMethod: req.ReplicationAuthMethod, HostSsl: req.Hostssl}
+	err := postgres.UpdateSegmentPgHbaConf(req.Pgdata, req.Addrs, req.Replication, auth)
 	if err != nil {
 		return &idl.UpdatePgHbaConfResponse{}, fmt.Errorf("updating pg_hba.conf: %w", err)
 	}
@@ -20,7 +21,7 @@ func (s *Server) UpdatePgHbaConfAndReload(ctx context.Context, req *idl.UpdatePg
 	pgCtlReloadCmd := &postgres.PgCtlReload{
 		PgData: req.Pgdata,
 	}
-	out, err := utils.RunGpCommand(pgCtlReloadCmd, s.GpHome)
+	out, err := utils.RunGpCommandContext(ctx, pgCtlReloadCmd, s.GpHome)
 	if err != nil {
 		return &idl.UpdatePgHbaConfResponse{}, fmt.Errorf("executing pg_ctl reload: %s, %w", out, err)
 	}
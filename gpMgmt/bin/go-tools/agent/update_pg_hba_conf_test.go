@@ -34,8 +34,7 @@ func TestUpdatePgHbaConf(t *testing.T) {
 				Addrs:       []string{"sdw1", "sdw2"},
 				Replication: true,
 			},
-			expected: `
-host	all	gpadmin	sdw1	trust
+			expected: `host	all	gpadmin	sdw1	trust
 host	all	gpadmin	sdw2	trust
 host	replication	gpadmin	samehost	trust
 host	replication	gpadmin	sdw1	trust
@@ -47,10 +46,31 @@ host	replication	gpadmin	sdw2	trust`,
 				Addrs:       []string{"sdw1", "sdw2"},
 				Replication: false,
 			},
-			expected: `
-host	all	gpadmin	sdw1	trust
+			expected: `host	all	gpadmin	sdw1	trust
 host	all	gpadmin	sdw2	trust`,
 		},
+		{
+			request: &idl.UpdatePgHbaConfRequest{
+				Pgdata:      "gpseg",
+				Addrs:       []string{"sdw1"},
+				Replication: true,
+				AuthMethod:  "md5",
+			},
+			expected: `host	all	gpadmin	sdw1	md5
+host	replication	gpadmin	samehost	trust
+host	replication	gpadmin	sdw1	trust`,
+		}, {
+			request: &idl.UpdatePgHbaConfRequest{
+				Pgdata:                "gpseg",
+				Addrs:                 []string{"sdw1"},
+				Replication:           true,
+				AuthMethod:            "md5",
+				ReplicationAuthMethod: "md5",
+			},
+			expected: `host	all	gpadmin	sdw1	md5
+host	replication	gpadmin	samehost	md5
+host	replication	gpadmin	sdw1	md5`,
+		},
 	}
 
 	for _, tc := range cases {
@@ -18,6 +18,7 @@ import (
 	"github.com/greenplum-db/gp-common-go-libs/gplog"
 	"github.com/greenplum-db/gpdb/gp/idl"
 	"github.com/greenplum-db/gpdb/gp/utils"
+	"github.com/greenplum-db/gpdb/gp/utils/tracing"
 )
 
 var (
@@ -39,7 +40,7 @@ Performs various checks on host like gpdb version, permissions to initdb, data d
 */
 
 func (s *Server) ValidateHostEnv(ctx context.Context, request *idl.ValidateHostEnvRequest) (*idl.ValidateHostEnvReply, error) {
-	gplog.Debug("Starting ValidateHostEnvFn for request:%v", request)
+	tracing.Debug(ctx, "Starting ValidateHostEnvFn for request:%v", request)
 	dirList := request.DirectoryList
 	locale := request.Locale
 	portList := request.PortList
@@ -49,35 +50,35 @@ func (s *Server) ValidateHostEnv(ctx context.Context, request *idl.ValidateHostE
 	if utils.System.Getuid() == 0 {
 		userInfo, err := utils.System.CurrentUser()
 		if err != nil {
-			return &idl.ValidateHostEnvReply{}, utils.LogAndReturnError(fmt.Errorf(
+			return &idl.ValidateHostEnvReply{}, tracing.LogAndReturnError(ctx, fmt.Errorf(
 				"failed to get user name Error:%v. Current user is a root user. Can't create cluster under root", err))
 		}
-		return &idl.ValidateHostEnvReply{}, utils.LogAndReturnError(fmt.Errorf(
+		return &idl.ValidateHostEnvReply{}, tracing.LogAndReturnError(ctx, fmt.Errorf(
 			"user:%s is a root user, Can't create cluster under root user", userInfo.Name))
 	}
 
 	//Check for GP Version
 	gpVersionErr := VerifyPgVersion(request.GpVersion, s.GpHome)
 	if gpVersionErr != nil {
-		return &idl.ValidateHostEnvReply{}, utils.LogAndReturnError(fmt.Errorf("Postgres gp-version validation failed:%v", gpVersionErr))
+		return &idl.ValidateHostEnvReply{}, tracing.LogAndReturnError(ctx, fmt.Errorf("Postgres gp-version validation failed:%v", gpVersionErr))
 	}
 
 	// Check for each directory if is empty
 	nonEmptyDirList, err := GetAllNonEmptyDir(dirList)
 	if err != nil {
-		return &idl.ValidateHostEnvReply{}, utils.LogAndReturnError(fmt.Errorf("error checking directory empty:%v", err))
+		return &idl.ValidateHostEnvReply{}, tracing.LogAndReturnError(ctx, fmt.Errorf("error checking directory empty:%v", err))
 	}
 
 	if len(nonEmptyDirList) > 0 && !forced {
-		return &idl.ValidateHostEnvReply{}, utils.LogAndReturnError(fmt.Errorf("directory not empty:%v", nonEmptyDirList))
+		return &idl.ValidateHostEnvReply{}, tracing.LogAndReturnError(ctx, fmt.Errorf("directory not empty:%v", nonEmptyDirList))
 	}
 	if forced && len(nonEmptyDirList) > 0 {
 
-		gplog.Debug("Forced init. Deleting non-empty directories:%s", nonEmptyDirList)
+		tracing.Debug(ctx, "Forced init. Deleting non-empty directories:%s", nonEmptyDirList)
 		for _, dir := range nonEmptyDirList {
 			err := utils.System.RemoveAll(dir)
 			if err != nil {
-				return &idl.ValidateHostEnvReply{}, utils.LogAndReturnError(fmt.Errorf("delete not empty dir:%s, error:%v", dir, err))
+				return &idl.ValidateHostEnvReply{}, tracing.LogAndReturnError(ctx, fmt.Errorf("delete not empty dir:%s, error:%v", dir, err))
 			}
 		}
 	}
@@ -86,19 +87,19 @@ func (s *Server) ValidateHostEnv(ctx context.Context, request *idl.ValidateHostE
 	initdbPath := filepath.Join(s.GpHome, "bin", "initdb")
 	err = CheckFilePermissions(initdbPath)
 	if err != nil {
-		return &idl.ValidateHostEnvReply{}, utils.LogAndReturnError(err)
+		return &idl.ValidateHostEnvReply{}, tracing.LogAndReturnError(ctx, err)
 	}
 
 	// Validate that the different locale settings are available on the system
 	err = ValidateLocaleSettings(locale)
 	if err != nil {
-		return &idl.ValidateHostEnvReply{}, utils.LogAndReturnError(err)
+		return &idl.ValidateHostEnvReply{}, tracing.LogAndReturnError(ctx, err)
 	}
 
 	// Check if port in use
 	err = ValidatePorts(portList)
 	if err != nil {
-		return &idl.ValidateHostEnvReply{}, utils.LogAndReturnError(err)
+		return &idl.ValidateHostEnvReply{}, tracing.LogAndReturnError(ctx, err)
 	}
 
 	// Any checks to raise warnings
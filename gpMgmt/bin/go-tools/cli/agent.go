@@ -2,6 +2,8 @@ package cli
 
 import (
 	"github.com/greenplum-db/gpdb/gp/agent"
+	"github.com/greenplum-db/gpdb/gp/utils"
+	"github.com/greenplum-db/gpdb/gp/utils/tracing"
 	"github.com/spf13/cobra"
 )
 
@@ -15,18 +17,42 @@ func agentCmd() *cobra.Command {
 		RunE:    RunAgent,
 	}
 
+	agentCmd.Flags().BoolVar(&daemonize, "daemonize", false, `Run the agent in the background, with the process service manager`)
+
 	return agentCmd
 }
 
 func RunAgent(cmd *cobra.Command, args []string) (err error) {
+	if daemonize {
+		return daemonizeService("agent")
+	}
+
+	stopLogRotation, err := utils.RedirectServiceOutput(Conf.ServiceLog, Conf.LogDir, Conf.ServiceName, "agent")
+	if err != nil {
+		return err
+	}
+	defer stopLogRotation()
+
 	agentConf := agent.Config{
 		Port:        Conf.AgentPort,
 		ServiceName: Conf.ServiceName,
 		GpHome:      Conf.GpHome,
 		Credentials: Conf.Credentials,
 		LogDir:      Conf.LogDir,
+		Metrics:     Conf.Metrics,
 	}
+	shutdownTracing, err := tracing.Setup(Conf.Tracing, tracing.ServiceAgent, Conf.LogDir)
+	if err != nil {
+		return err
+	}
+	defer shutdownTracing()
+
 	a := agent.New(agentConf)
+	release, err := trackProcess("agent", a.Shutdown)
+	if err != nil {
+		return err
+	}
+	defer release()
 
 	err = a.Start()
 	if err != nil {
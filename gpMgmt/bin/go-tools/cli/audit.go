@@ -0,0 +1,115 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/greenplum-db/gpdb/gp/hub"
+)
+
+var (
+	auditSince     string
+	auditOperation string
+)
+
+func auditCmd() *cobra.Command {
+	auditCmd := &cobra.Command{
+		Use:   "audit",
+		Short: "Query the audit log of the operations run through the hub",
+	}
+
+	auditCmd.AddCommand(auditListCmd())
+
+	return auditCmd
+}
+
+func auditListCmd() *cobra.Command {
+	auditListCmd := &cobra.Command{
+		Use:     "list",
+		Short:   "List the operations recorded in the audit log, oldest first",
+		Example: "gp audit list --since 24h --operation MakeCluster",
+		Args:    cobra.NoArgs,
+		PreRunE: InitializeCommand,
+		RunE:    RunAuditList,
+	}
+
+	auditListCmd.Flags().StringVar(&auditSince, "since", "", "Only list operations since a duration ago, e.g. 24h, or since a date, e.g. 2024-01-31 or 2024-01-31T15:04:05Z")
+	auditListCmd.Flags().StringVar(&auditOperation, "operation", "", "Only list the given operation, e.g. MakeCluster")
+
+	return auditListCmd
+}
+
+// The audit log is read directly, so that it can be queried while the hub is down
+func RunAuditList(cmd *cobra.Command, args []string) error {
+	since, err := ParseSince(auditSince, time.Now())
+	if err != nil {
+		return err
+	}
+
+	records, err := hub.ReadAuditLog(Conf.AuditLogPath(), since, auditOperation)
+	if err != nil {
+		return err
+	}
+
+	PrintAuditRecords(os.Stdout, records)
+
+	return nil
+}
+
+/*
+ParseSince parses a duration before now, or a time in RFC 3339 format, or a
+date in local time. An empty value means all times.
+*/
+func ParseSince(value string, now time.Time) (time.Time, error) {
+	return ParseTimeFlag("since", value, now)
+}
+
+// ParseTimeFlag parses the value of the given flag like ParseSince
+func ParseTimeFlag(flag string, value string, now time.Time) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err == nil {
+		return now.Add(-duration), nil
+	}
+
+	since, err := time.Parse(time.RFC3339, value)
+	if err == nil {
+		return since, nil
+	}
+
+	since, err = time.ParseInLocation(time.DateOnly, value, time.Local)
+	if err == nil {
+		return since, nil
+	}
+
+	return time.Time{}, fmt.Errorf("invalid value %q for --%s, expected a duration such as 24h or a date such as 2024-01-31", value, flag)
+}
+
+func PrintAuditRecords(out io.Writer, records []*hub.AuditRecord) {
+	w := new(tabwriter.Writer)
+	w.Init(out, 0, 8, 2, '\t', 0)
+
+	fmt.Fprintln(w, "TIME\tOPERATION\tOUTCOME\tCERTIFICATE\tUSER\tHOST\tDURATION\tERROR")
+	for _, r := range records {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			r.Time.Local().Format(time.RFC3339), r.Operation, r.Outcome, orDash(r.Certificate), orDash(r.User),
+			orDash(r.Host), time.Duration(r.DurationMs)*time.Millisecond, r.Error)
+	}
+	w.Flush()
+}
+
+func orDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+
+	return value
+}
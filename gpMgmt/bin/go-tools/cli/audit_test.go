@@ -0,0 +1,87 @@
+package cli_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/greenplum-db/gpdb/gp/cli"
+	"github.com/greenplum-db/gpdb/gp/hub"
+)
+
+func TestParseSince(t *testing.T) {
+	now := time.Date(2024, 1, 31, 12, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		value    string
+		expected time.Time
+	}{
+		{"", time.Time{}},
+		{"24h", time.Date(2024, 1, 30, 12, 0, 0, 0, time.UTC)},
+		{"2024-01-15T10:30:00Z", time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)},
+		{"2024-01-15", time.Date(2024, 1, 15, 0, 0, 0, 0, time.Local)},
+	}
+
+	for _, tc := range cases {
+		t.Run("parses "+tc.value, func(t *testing.T) {
+			since, err := cli.ParseSince(tc.value, now)
+			if err != nil {
+				t.Fatalf("unexpected error: %#v", err)
+			}
+
+			if !since.Equal(tc.expected) {
+				t.Fatalf("got %s, want %s", since, tc.expected)
+			}
+		})
+	}
+
+	t.Run("errors out for invalid values", func(t *testing.T) {
+		_, err := cli.ParseSince("yesterday", now)
+		expected := `invalid value "yesterday" for --since, expected a duration such as 24h or a date such as 2024-01-31`
+		if err == nil || err.Error() != expected {
+			t.Fatalf("got %v, want %s", err, expected)
+		}
+	})
+}
+
+func TestPrintAuditRecords(t *testing.T) {
+	records := []*hub.AuditRecord{
+		{
+			Time:        time.Date(2024, 1, 31, 12, 0, 0, 0, time.UTC),
+			Certificate: "gp-cli",
+			User:        "gpadmin",
+			Host:        "127.0.0.1",
+			Operation:   "MakeCluster",
+			Outcome:     hub.AuditFailure,
+			DurationMs:  1500,
+			Error:       "could not connect to agent on host sdw1",
+		},
+		{
+			Time:      time.Date(2024, 1, 31, 13, 0, 0, 0, time.UTC),
+			Host:      "10.0.0.1",
+			Operation: "StopAgents",
+			Outcome:   hub.AuditDenied,
+		},
+	}
+
+	var buf bytes.Buffer
+	cli.PrintAuditRecords(&buf, records)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("got %d lines, want 3: %s", len(lines), buf.String())
+	}
+
+	expected := [][]string{
+		{"TIME", "OPERATION", "OUTCOME", "CERTIFICATE", "USER", "HOST", "DURATION", "ERROR"},
+		{records[0].Time.Local().Format(time.RFC3339), "MakeCluster", "failure", "gp-cli", "gpadmin", "127.0.0.1", "1.5s", "could", "not", "connect", "to", "agent", "on", "host", "sdw1"},
+		{records[1].Time.Local().Format(time.RFC3339), "StopAgents", "denied", "-", "-", "10.0.0.1", "0s"},
+	}
+	for i, line := range lines {
+		fields := strings.Fields(line)
+		if strings.Join(fields, " ") != strings.Join(expected[i], " ") {
+			t.Errorf("got %q, want %q", fields, expected[i])
+		}
+	}
+}
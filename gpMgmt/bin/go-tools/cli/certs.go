@@ -0,0 +1,348 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/greenplum-db/gp-common-go-libs/gplog"
+	"github.com/greenplum-db/gpdb/gp/constants"
+	"github.com/greenplum-db/gpdb/gp/utils"
+	"github.com/greenplum-db/gpdb/gp/utils/certs"
+)
+
+var (
+	GenerateCertificates = GenerateCertificatesFn
+	RotateCertificates   = RotateCertificatesFn
+)
+
+/*
+GenerateCertificatesFn creates a new certificate authority and issues a server
+certificate for the coordinator and for each segment host, along with a distinct
+client certificate for the CLI, the hub and the agents. The files of
+every host are written to the same directory so that a single gp.conf works on
+all of them. The CA private key is kept on the coordinator only.
+This function depends on gpssh and gpsync. Use only in the configure command.
+*/
+func GenerateCertificatesFn(hostnames []string, certDir string, validity time.Duration) (*utils.GpCredentials, error) {
+	coordinator, err := utils.System.GetHostName()
+	if err != nil {
+		return nil, fmt.Errorf("could not get coordinator hostname: %w", err)
+	}
+
+	ca, err := certs.NewCertificateAuthority(fmt.Sprintf("%s CA %s", certs.Organization, coordinator), validity)
+	if err != nil {
+		return nil, err
+	}
+
+	err = os.MkdirAll(certDir, 0700)
+	if err != nil {
+		return nil, fmt.Errorf("could not create certificate directory %s: %w", certDir, err)
+	}
+
+	creds := generatedCredentials(certDir)
+	err = ca.Write(creds.CACertPath, creds.CAKeyPath)
+	if err != nil {
+		return nil, err
+	}
+
+	expiry, err := issueCertificates(ca, coordinator, hostnames, certDir, validity)
+	if err != nil {
+		return nil, err
+	}
+
+	gplog.Info("Generated certificates in %s on all hosts", certDir)
+	gplog.Info("The CA certificate expires on %s, the host certificates expire on %s",
+		ca.NotAfter.Format(time.RFC1123), expiry.Format(time.RFC1123))
+
+	return creds, nil
+}
+
+/*
+RotateCertificatesFn issues new certificates for all hosts with the CA created by
+GenerateCertificatesFn and replaces the existing ones. As the CA stays the same,
+hosts with the old and the new certificates keep trusting each other while the
+files are being copied.
+This function depends on gpssh and gpsync. Use only in the configure command.
+*/
+func RotateCertificatesFn(hostnames []string, creds *utils.GpCredentials, validity time.Duration) error {
+	certDir := filepath.Dir(creds.ServerCertPath)
+	expected := generatedCredentials(certDir)
+	if creds.CACertPath != expected.CACertPath || creds.CAKeyPath != expected.CAKeyPath ||
+		creds.ServerKeyPath != expected.ServerKeyPath || !reflect.DeepEqual(creds.ClientCertificates, expected.ClientCertificates) {
+		return errors.New("only certificates created by gp configure --generate-certs can be rotated")
+	}
+
+	coordinator, err := utils.System.GetHostName()
+	if err != nil {
+		return fmt.Errorf("could not get coordinator hostname: %w", err)
+	}
+
+	ca, err := certs.LoadCertificateAuthority(creds.CACertPath, creds.CAKeyPath)
+	if err != nil {
+		return fmt.Errorf("could not load the CA: %w", err)
+	}
+
+	expiry, err := issueCertificates(ca, coordinator, hostnames, certDir, validity)
+	if err != nil {
+		return err
+	}
+
+	gplog.Info("Rotated certificates in %s on all hosts, the new certificates expire on %s", certDir, expiry.Format(time.RFC1123))
+	if expiry.Equal(ca.NotAfter) {
+		gplog.Warn("The certificates expire together with the CA certificate on %s. Run gp configure --generate-certs to create a new CA.",
+			ca.NotAfter.Format(time.RFC1123))
+	}
+
+	return nil
+}
+
+// generatedCredentials returns the layout of the files created by GenerateCertificatesFn
+func generatedCredentials(certDir string) *utils.GpCredentials {
+	creds := &utils.GpCredentials{
+		CACertPath:         filepath.Join(certDir, certs.CACertFile),
+		CAKeyPath:          filepath.Join(certDir, certs.CAKeyFile),
+		ServerCertPath:     filepath.Join(certDir, certs.ServerCertFile),
+		ServerKeyPath:      filepath.Join(certDir, certs.ServerKeyFile),
+		ClientCertificates: make(map[string]utils.CertificatePaths),
+		// Only the CLI may connect to the hub, and only the hub to the agents
+		AllowedClients: map[string][]string{
+			utils.RoleHub:   {certs.ClientCommonName(utils.RoleCli)},
+			utils.RoleAgent: {certs.ClientCommonName(utils.RoleHub)},
+		},
+	}
+	for _, role := range []string{utils.RoleCli, utils.RoleHub, utils.RoleAgent} {
+		certFile, keyFile := certs.ClientCertificateFiles(role)
+		creds.ClientCertificates[role] = utils.CertificatePaths{
+			CertPath: filepath.Join(certDir, certFile),
+			KeyPath:  filepath.Join(certDir, keyFile),
+		}
+	}
+
+	return creds
+}
+
+// issueCertificates issues the certificates of all hosts and copies them to the
+// segment hosts before replacing the ones of the coordinator
+func issueCertificates(ca *certs.CertificateAuthority, coordinator string, hostnames []string, certDir string, validity time.Duration) (time.Time, error) {
+	// Stage the files of the segment hosts locally, and remove them once copied
+	stagingDir, err := os.MkdirTemp("", "gp-certificates")
+	if err != nil {
+		return time.Time{}, fmt.Errorf("could not create staging directory for certificates: %w", err)
+	}
+	defer os.RemoveAll(stagingDir)
+
+	remoteHosts := make([]string, 0)
+	for _, host := range hostnames {
+		if host == coordinator || slices.Contains(remoteHosts, host) {
+			continue
+		}
+
+		hostDir := filepath.Join(stagingDir, host)
+		err = os.Mkdir(hostDir, 0700)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("could not create staging directory for host %s: %w", host, err)
+		}
+
+		err = utils.WriteFileAtomic(filepath.Join(hostDir, certs.CACertFile), ca.CertPEM, 0644)
+		if err != nil {
+			return time.Time{}, err
+		}
+
+		expiry, err := issueHostCertificates(ca, host, hostDir, validity, utils.RoleAgent)
+		if err != nil {
+			return time.Time{}, err
+		}
+		gplog.Verbose("Certificate for host %s expires on %s", host, expiry.Format(time.RFC1123))
+
+		remoteHosts = append(remoteHosts, host)
+	}
+
+	err = distributeCertificates(remoteHosts, stagingDir, certDir)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	coordinatorRoles := []string{utils.RoleCli, utils.RoleHub}
+	if slices.Contains(hostnames, coordinator) {
+		coordinatorRoles = append(coordinatorRoles, utils.RoleAgent)
+	}
+	expiry, err := issueHostCertificates(ca, coordinator, certDir, validity, coordinatorRoles...)
+	if err != nil {
+		return time.Time{}, err
+	}
+	gplog.Verbose("Certificate for host %s expires on %s", coordinator, expiry.Format(time.RFC1123))
+
+	return expiry, nil
+}
+
+// issueHostCertificates issues the server certificate of a host and a client
+// certificate for each of the roles running on it
+func issueHostCertificates(ca *certs.CertificateAuthority, host string, dir string, validity time.Duration, roles ...string) (time.Time, error) {
+	server, err := ca.IssueServerCertificate(host, validity)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	err = server.Write(filepath.Join(dir, certs.ServerCertFile), filepath.Join(dir, certs.ServerKeyFile))
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	for _, role := range roles {
+		client, err := ca.IssueClientCertificate(certs.ClientCommonName(role), host, validity)
+		if err != nil {
+			return time.Time{}, err
+		}
+
+		certFile, keyFile := certs.ClientCertificateFiles(role)
+		err = client.Write(filepath.Join(dir, certFile), filepath.Join(dir, keyFile))
+		if err != nil {
+			return time.Time{}, err
+		}
+	}
+
+	return server.NotAfter, nil
+}
+
+func distributeCertificates(hostnames []string, stagingDir string, certDir string) error {
+	if len(hostnames) == 0 {
+		return nil
+	}
+
+	hostList := make([]string, 0)
+	for _, host := range hostnames {
+		hostList = append(hostList, "-h", host)
+	}
+
+	args := append(hostList, "mkdir", "-p", "-m", "700", certDir)
+	out, err := utils.System.ExecCommand(filepath.Join(gpHome, "bin", constants.GpSSH), args...).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("could not create certificate directory %s on hosts: %w, Command Output: %s", certDir, err, string(out))
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, len(hostnames))
+	for _, host := range hostnames {
+		wg.Add(1)
+		go func(host string) {
+			defer wg.Done()
+
+			agentCertFile, agentKeyFile := certs.ClientCertificateFiles(utils.RoleAgent)
+			files := []string{certs.CACertFile, certs.ServerCertFile, certs.ServerKeyFile, agentCertFile, agentKeyFile}
+			args := []string{"-a", "-h", host}
+			for _, file := range files {
+				args = append(args, filepath.Join(stagingDir, host, file))
+			}
+			args = append(args, fmt.Sprintf("=:%s/", certDir))
+
+			// Archive mode preserves the permissions of the private keys
+			out, err := utils.System.ExecCommand(filepath.Join(gpHome, "bin", constants.GpSync), args...).CombinedOutput()
+			if err != nil {
+				errs <- fmt.Errorf("could not copy certificates to host %s: %w, Command Output: %s", host, err, string(out))
+			}
+		}(host)
+	}
+	wg.Wait()
+	close(errs)
+
+	for e := range errs {
+		err = errors.Join(err, e)
+	}
+
+	return err
+}
+
+// CertificateStatus is the expiry of a certificate file configured in gp.conf
+type CertificateStatus struct {
+	Name     string // ca, server, or the role of a client certificate
+	Path     string
+	NotAfter time.Time
+	Error    error
+}
+
+// GetCertificateStatuses reads the certificate files configured in gp.conf on
+// this host, the CA and server certificates first and then the client
+// certificates by role
+func GetCertificateStatuses(creds *utils.GpCredentials) []CertificateStatus {
+	statuses := []CertificateStatus{
+		{Name: "ca", Path: creds.CACertPath},
+		{Name: "server", Path: creds.ServerCertPath},
+	}
+
+	roles := make([]string, 0, len(creds.ClientCertificates))
+	for role := range creds.ClientCertificates {
+		roles = append(roles, role)
+	}
+	slices.Sort(roles)
+	for _, role := range roles {
+		statuses = append(statuses, CertificateStatus{Name: "client " + role, Path: creds.ClientCertificates[role].CertPath})
+	}
+
+	for i := range statuses {
+		contents, err := utils.System.ReadFile(statuses[i].Path)
+		if err != nil {
+			statuses[i].Error = err
+			continue
+		}
+
+		cert, err := certs.ParseCertificate(contents)
+		if err != nil {
+			statuses[i].Error = fmt.Errorf("could not parse certificate %s: %w", statuses[i].Path, err)
+			continue
+		}
+		statuses[i].NotAfter = cert.NotAfter
+	}
+
+	return statuses
+}
+
+// PrintCertificateStatuses prints the expiry of each certificate, and returns
+// an error if any certificate has expired or could not be read
+func PrintCertificateStatuses(out io.Writer, statuses []CertificateStatus, now time.Time) error {
+	w := new(tabwriter.Writer)
+	w.Init(out, 0, 8, 2, '\t', 0)
+
+	var expired, failed, expiring int
+	fmt.Fprintln(w, "CERTIFICATE\tPATH\tEXPIRES\tSTATUS")
+	for _, status := range statuses {
+		if status.Error != nil {
+			failed++
+			fmt.Fprintf(w, "%s\t%s\t-\terror: %s\n", status.Name, status.Path, status.Error)
+			continue
+		}
+
+		outcome := "ok"
+		switch remaining := status.NotAfter.Sub(now); {
+		case remaining <= 0:
+			expired++
+			outcome = "expired"
+		case remaining < constants.CertExpiryWarningDays*24*time.Hour:
+			expiring++
+			outcome = fmt.Sprintf("expires in %d day(s)", int(remaining.Hours()/24))
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", status.Name, status.Path, status.NotAfter.Format(time.RFC1123), outcome)
+	}
+	w.Flush()
+
+	if expiring > 0 {
+		gplog.Warn("%d certificate(s) expire within %d days, replace them with gp configure rotate-certs", expiring, constants.CertExpiryWarningDays)
+	}
+
+	var errs []error
+	if expired > 0 {
+		errs = append(errs, fmt.Errorf("%d certificate(s) have expired", expired))
+	}
+	if failed > 0 {
+		errs = append(errs, fmt.Errorf("could not read %d certificate(s)", failed))
+	}
+
+	return errors.Join(errs...)
+}
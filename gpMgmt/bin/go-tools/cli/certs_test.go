@@ -0,0 +1,349 @@
+package cli_test
+
+import (
+	"crypto/x509"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/greenplum-db/gp-common-go-libs/testhelper"
+	"github.com/greenplum-db/gpdb/gp/cli"
+	"github.com/greenplum-db/gpdb/gp/testutils/exectest"
+	"github.com/greenplum-db/gpdb/gp/utils"
+	"github.com/greenplum-db/gpdb/gp/utils/certs"
+)
+
+func TestGenerateCertificates(t *testing.T) {
+	testhelper.SetupTestLogger()
+
+	setup := func(t *testing.T, main exectest.Main) (*[][]string, string) {
+		t.Helper()
+
+		var mu sync.Mutex
+		var commands [][]string
+		utils.System.GetHostName = func() (string, error) {
+			return "cdw", nil
+		}
+		utils.System.ExecCommand = func(name string, args ...string) *exec.Cmd {
+			mu.Lock()
+			defer mu.Unlock()
+			commands = append(commands, append([]string{filepath.Base(name)}, args...))
+
+			return exectest.NewCommand(main)(name, args...)
+		}
+
+		return &commands, filepath.Join(t.TempDir(), "certificates")
+	}
+
+	t.Run("generates the certificates and copies them to the segment hosts", func(t *testing.T) {
+		defer utils.ResetSystemFunctions()
+		commands, certDir := setup(t, exectest.Success)
+
+		creds, err := cli.GenerateCertificatesFn([]string{"cdw", "sdw1", "sdw2", "sdw1"}, certDir, 24*time.Hour)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		clientCertificates := map[string]utils.CertificatePaths{}
+		for _, role := range []string{utils.RoleCli, utils.RoleHub, utils.RoleAgent} {
+			certFile, keyFile := certs.ClientCertificateFiles(role)
+			clientCertificates[role] = utils.CertificatePaths{
+				CertPath: filepath.Join(certDir, certFile),
+				KeyPath:  filepath.Join(certDir, keyFile),
+			}
+		}
+		expectedCreds := &utils.GpCredentials{
+			CACertPath:         filepath.Join(certDir, certs.CACertFile),
+			CAKeyPath:          filepath.Join(certDir, certs.CAKeyFile),
+			ServerCertPath:     filepath.Join(certDir, certs.ServerCertFile),
+			ServerKeyPath:      filepath.Join(certDir, certs.ServerKeyFile),
+			ClientCertificates: clientCertificates,
+			AllowedClients: map[string][]string{
+				utils.RoleHub:   {"gp-cli"},
+				utils.RoleAgent: {"gp-hub"},
+			},
+		}
+		if !reflect.DeepEqual(creds, expectedCreds) {
+			t.Fatalf("got %+v, want %+v", creds, expectedCreds)
+		}
+
+		keyFiles := []string{creds.CAKeyPath, creds.ServerKeyPath}
+		for _, paths := range creds.ClientCertificates {
+			keyFiles = append(keyFiles, paths.KeyPath)
+		}
+		for _, file := range keyFiles {
+			info, err := os.Stat(file)
+			if err != nil {
+				t.Fatalf("unexpected error: %#v", err)
+			}
+			if info.Mode().Perm() != 0600 {
+				t.Fatalf("got permissions %o for %s, want 0600", info.Mode().Perm(), file)
+			}
+		}
+
+		caCert, err := os.ReadFile(creds.CACertPath)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		serverCert, err := os.ReadFile(creds.ServerCertPath)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		cert, err := certs.ParseCertificate(serverCert)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		roots := x509.NewCertPool()
+		roots.AppendCertsFromPEM(caCert)
+		for _, name := range []string{"cdw", "localhost"} {
+			_, err = cert.Verify(x509.VerifyOptions{DNSName: name, Roots: roots})
+			if err != nil {
+				t.Fatalf("unexpected error verifying the certificate for %s: %v", name, err)
+			}
+		}
+
+		if len(*commands) != 3 {
+			t.Fatalf("got %d commands, want 3: %v", len(*commands), *commands)
+		}
+		expectedMkdir := []string{"gpssh", "-h", "sdw1", "-h", "sdw2", "mkdir", "-p", "-m", "700", certDir}
+		if !reflect.DeepEqual((*commands)[0], expectedMkdir) {
+			t.Fatalf("got %v, want %v", (*commands)[0], expectedMkdir)
+		}
+
+		copied := map[string]bool{}
+		for _, command := range (*commands)[1:] {
+			if command[0] != "gpsync" || command[1] != "-a" || command[2] != "-h" {
+				t.Fatalf("unexpected command %v", command)
+			}
+			if command[len(command)-1] != "=:"+certDir+"/" {
+				t.Fatalf("got destination %s, want =:%s/", command[len(command)-1], certDir)
+			}
+			files := []string{}
+			for _, file := range command[4 : len(command)-1] {
+				if !strings.HasSuffix(filepath.Dir(file), command[3]) {
+					t.Fatalf("got file %s for host %s", file, command[3])
+				}
+				files = append(files, filepath.Base(file))
+			}
+			expectedFiles := []string{"ca-cert.pem", "server-cert.pem", "server-key.pem", "agent-cert.pem", "agent-key.pem"}
+			if !reflect.DeepEqual(files, expectedFiles) {
+				t.Fatalf("got files %v, want %v", files, expectedFiles)
+			}
+			copied[command[3]] = true
+		}
+		if !reflect.DeepEqual(copied, map[string]bool{"sdw1": true, "sdw2": true}) {
+			t.Fatalf("got certificates copied to %v, want sdw1 and sdw2", copied)
+		}
+	})
+
+	t.Run("does not copy anything when there are no segment hosts", func(t *testing.T) {
+		defer utils.ResetSystemFunctions()
+		commands, certDir := setup(t, exectest.Success)
+
+		_, err := cli.GenerateCertificatesFn([]string{"cdw"}, certDir, 24*time.Hour)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		if len(*commands) != 0 {
+			t.Fatalf("got commands %v, want none", *commands)
+		}
+	})
+
+	t.Run("errors out when the certificates could not be copied", func(t *testing.T) {
+		defer utils.ResetSystemFunctions()
+		_, certDir := setup(t, exectest.Failure)
+
+		_, err := cli.GenerateCertificatesFn([]string{"sdw1"}, certDir, 24*time.Hour)
+		expected := "could not create certificate directory " + certDir + " on hosts"
+		if err == nil || !strings.HasPrefix(err.Error(), expected) {
+			t.Fatalf("got %v, want %s", err, expected)
+		}
+	})
+
+	t.Run("errors out when the coordinator hostname is not available", func(t *testing.T) {
+		defer utils.ResetSystemFunctions()
+		_, certDir := setup(t, exectest.Success)
+		expected := errors.New("error")
+		utils.System.GetHostName = func() (string, error) {
+			return "", expected
+		}
+
+		_, err := cli.GenerateCertificatesFn([]string{"sdw1"}, certDir, 24*time.Hour)
+		if !errors.Is(err, expected) {
+			t.Fatalf("got %#v, want %#v", err, expected)
+		}
+	})
+}
+
+func TestRotateCertificates(t *testing.T) {
+	testhelper.SetupTestLogger()
+
+	setup := func(t *testing.T) (*utils.GpCredentials, *[][]string) {
+		t.Helper()
+
+		var mu sync.Mutex
+		var commands [][]string
+		utils.System.GetHostName = func() (string, error) {
+			return "cdw", nil
+		}
+		utils.System.ExecCommand = func(name string, args ...string) *exec.Cmd {
+			mu.Lock()
+			defer mu.Unlock()
+			commands = append(commands, append([]string{filepath.Base(name)}, args...))
+
+			return exectest.NewCommand(exectest.Success)(name, args...)
+		}
+
+		creds, err := cli.GenerateCertificatesFn([]string{"cdw"}, filepath.Join(t.TempDir(), "certificates"), 24*time.Hour)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		return creds, &commands
+	}
+
+	t.Run("issues new certificates with the existing CA", func(t *testing.T) {
+		defer utils.ResetSystemFunctions()
+		creds, commands := setup(t)
+
+		caCert, err := os.ReadFile(creds.CACertPath)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		oldServerCert, err := os.ReadFile(creds.ServerCertPath)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		err = cli.RotateCertificatesFn([]string{"cdw", "sdw1"}, creds, 12*time.Hour)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		newCaCert, err := os.ReadFile(creds.CACertPath)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		if !reflect.DeepEqual(newCaCert, caCert) {
+			t.Fatalf("expected the CA certificate to be kept")
+		}
+
+		serverCert, err := os.ReadFile(creds.ServerCertPath)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		if reflect.DeepEqual(serverCert, oldServerCert) {
+			t.Fatalf("expected a new server certificate")
+		}
+		cert, err := certs.ParseCertificate(serverCert)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		roots := x509.NewCertPool()
+		roots.AppendCertsFromPEM(caCert)
+		_, err = cert.Verify(x509.VerifyOptions{DNSName: "cdw", Roots: roots})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		if len(*commands) != 2 || (*commands)[0][0] != "gpssh" || (*commands)[1][0] != "gpsync" || (*commands)[1][3] != "sdw1" {
+			t.Fatalf("got commands %v, want the certificates copied to sdw1", *commands)
+		}
+	})
+
+	t.Run("errors out when the certificates were not generated by gp configure", func(t *testing.T) {
+		defer utils.ResetSystemFunctions()
+		creds, _ := setup(t)
+		creds.ClientCertificates = nil
+
+		err := cli.RotateCertificatesFn([]string{"cdw"}, creds, 12*time.Hour)
+		expected := "only certificates created by gp configure --generate-certs can be rotated"
+		if err == nil || err.Error() != expected {
+			t.Fatalf("got %v, want %s", err, expected)
+		}
+	})
+
+	t.Run("errors out when the CA cannot be loaded", func(t *testing.T) {
+		defer utils.ResetSystemFunctions()
+		creds, _ := setup(t)
+		err := os.Remove(creds.CAKeyPath)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		err = cli.RotateCertificatesFn([]string{"cdw"}, creds, 12*time.Hour)
+		if !errors.Is(err, os.ErrNotExist) || !strings.HasPrefix(err.Error(), "could not load the CA:") {
+			t.Fatalf("got %v, want the CA private key to be missing", err)
+		}
+	})
+}
+
+func TestCertificateStatuses(t *testing.T) {
+	testhelper.SetupTestLogger()
+
+	t.Run("reports the expiry of the configured certificates", func(t *testing.T) {
+		dir := t.TempDir()
+		ca, err := certs.NewCertificateAuthority("test CA", 10*24*time.Hour)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		creds := &utils.GpCredentials{
+			CACertPath:     filepath.Join(dir, certs.CACertFile),
+			ServerCertPath: filepath.Join(dir, certs.ServerCertFile),
+			ClientCertificates: map[string]utils.CertificatePaths{
+				utils.RoleCli: {CertPath: filepath.Join(dir, "cli-cert.pem")},
+			},
+		}
+		err = ca.Write(creds.CACertPath, filepath.Join(dir, certs.CAKeyFile))
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		server, err := ca.IssueServerCertificate("cdw", 5*24*time.Hour)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		err = server.Write(creds.ServerCertPath, filepath.Join(dir, certs.ServerKeyFile))
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		statuses := cli.GetCertificateStatuses(creds)
+
+		var names []string
+		for _, status := range statuses {
+			names = append(names, status.Name)
+		}
+		if !reflect.DeepEqual(names, []string{"ca", "server", "client cli"}) {
+			t.Fatalf("got %v, want the CA, server and client certificates", names)
+		}
+		if statuses[0].Error != nil || !statuses[0].NotAfter.Equal(ca.NotAfter) {
+			t.Fatalf("got %+v, want the expiry of the CA", statuses[0])
+		}
+		if !errors.Is(statuses[2].Error, os.ErrNotExist) {
+			t.Fatalf("got %+v, want the client certificate to be missing", statuses[2])
+		}
+
+		var out strings.Builder
+		err = cli.PrintCertificateStatuses(&out, statuses, time.Now())
+		expected := "could not read 1 certificate(s)"
+		if err == nil || err.Error() != expected {
+			t.Fatalf("got %v, want %s", err, expected)
+		}
+		if !strings.Contains(out.String(), "expires in 9 day(s)") {
+			t.Fatalf("got %q, want the CA to expire soon", out.String())
+		}
+
+		err = cli.PrintCertificateStatuses(&out, statuses[:2], time.Now().Add(11*24*time.Hour))
+		expected = "2 certificate(s) have expired"
+		if err == nil || err.Error() != expected {
+			t.Fatalf("got %v, want %s", err, expected)
+		}
+	})
+}
@@ -0,0 +1,330 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/greenplum-db/gpdb/gp/constants"
+	"github.com/greenplum-db/gpdb/gp/idl"
+	"github.com/greenplum-db/gpdb/gp/utils"
+)
+
+var (
+	checkNetworkTimeout   int
+	checkNetworkMeasure   bool
+	checkNetworkMeasureMB int
+	checkDiskSizeMB       int
+	checkDiskDuration     int
+)
+
+// Number of hosts listed in the summary of gp check disk
+const slowestDiskHosts = 3
+
+func checkCmd() *cobra.Command {
+	checkCmd := &cobra.Command{
+		Use:   "check",
+		Short: "Check that the hosts are ready for the cluster",
+	}
+
+	checkCmd.AddCommand(
+		checkNetworkCmd(),
+		checkDiskCmd(),
+	)
+
+	return checkCmd
+}
+
+func checkNetworkCmd() *cobra.Command {
+	checkNetworkCmd := &cobra.Command{
+		Use:   "network <config-file>",
+		Short: "Check that the hosts reach each other on the ports of the planned cluster",
+		Long: `Check that the hosts reach each other on the ports of the planned cluster.
+
+The layout of the cluster is read from the same configuration file as gp init.
+The agents listen on the planned ports of the segments, and each host connects
+to the ports it will use: the coordinator to all the segments, and the
+primaries and mirrors to each other for replication. With --measure, the
+latency and throughput between every pair of hosts are also measured.`,
+		Args:    cobra.ExactArgs(1),
+		PreRunE: InitializeCommand,
+		RunE:    RunCheckNetwork,
+	}
+
+	checkNetworkCmd.Flags().IntVar(&checkNetworkTimeout, "timeout", constants.DefaultCheckNetworkTimeout, "Seconds to wait for each connection")
+	checkNetworkCmd.Flags().BoolVar(&checkNetworkMeasure, "measure", false, "Measure the latency and throughput between every pair of hosts")
+	checkNetworkCmd.Flags().IntVar(&checkNetworkMeasureMB, "measure-size", constants.DefaultCheckNetworkMeasureMB, "MB sent to measure the throughput")
+
+	return checkNetworkCmd
+}
+
+func RunCheckNetwork(cmd *cobra.Command, args []string) error {
+	if checkNetworkTimeout <= 0 {
+		return fmt.Errorf("the timeout must be a positive number of seconds")
+	}
+	if checkNetworkMeasureMB <= 0 {
+		return fmt.Errorf("the measure size must be a positive number of MB")
+	}
+
+	config, err := LoadInitConfig(args[0], viper.New())
+	if err != nil {
+		return err
+	}
+
+	client, err := ConnectToHub(Conf)
+	if err != nil {
+		return err
+	}
+
+	reply, err := client.CheckNetwork(CommandContext, &idl.CheckNetworkRequest{
+		GpArray:      CreateMakeClusterReq(config, false, false).GpArray,
+		Timeout:      int32(checkNetworkTimeout),
+		Measure:      checkNetworkMeasure,
+		MeasureBytes: int64(checkNetworkMeasureMB) * 1024 * 1024,
+	})
+	if err != nil {
+		return utils.FormatGrpcError(err)
+	}
+
+	return PrintNetworkCheck(os.Stdout, reply.Results)
+}
+
+/*
+PrintNetworkCheck prints a matrix of the hosts, with a row per source and a
+column per target, followed by the failed connections and the measures. A cell
+is "ok" when all the ports of the target are reached, "FAIL" otherwise, or the
+throughput when it was measured. It returns an error if any connection failed.
+*/
+func PrintNetworkCheck(out io.Writer, results []*idl.NetworkResult) error {
+	type cell struct {
+		failed     bool
+		throughput float64
+	}
+
+	hostSet := make(map[string]bool)
+	cells := make(map[string]map[string]*cell)
+	var failures, measures []*idl.NetworkResult
+	for _, result := range results {
+		hostSet[result.Source] = true
+		hostSet[result.Target] = true
+		if cells[result.Source] == nil {
+			cells[result.Source] = make(map[string]*cell)
+		}
+		c := cells[result.Source][result.Target]
+		if c == nil {
+			c = &cell{}
+			cells[result.Source][result.Target] = c
+		}
+
+		if !result.Reachable || result.Error != "" {
+			c.failed = c.failed || !result.Reachable
+			failures = append(failures, result)
+		}
+		if result.ThroughputMBps > 0 {
+			c.throughput = result.ThroughputMBps
+			measures = append(measures, result)
+		}
+	}
+
+	hosts := make([]string, 0, len(hostSet))
+	for host := range hostSet {
+		hosts = append(hosts, host)
+	}
+	sort.Strings(hosts)
+
+	w := new(tabwriter.Writer)
+	w.Init(out, 0, 8, 2, '\t', 0)
+
+	fmt.Fprint(w, "SOURCE \\ TARGET")
+	for _, host := range hosts {
+		fmt.Fprintf(w, "\t%s", host)
+	}
+	fmt.Fprintln(w)
+	for _, source := range hosts {
+		fmt.Fprint(w, source)
+		for _, target := range hosts {
+			value := "-"
+			if c := cells[source][target]; c != nil {
+				switch {
+				case c.failed:
+					value = "FAIL"
+				case c.throughput > 0:
+					value = fmt.Sprintf("%.1fMB/s", c.throughput)
+				default:
+					value = "ok"
+				}
+			}
+			fmt.Fprintf(w, "\t%s", value)
+		}
+		fmt.Fprintln(w)
+	}
+	w.Flush()
+
+	if len(measures) > 0 {
+		fmt.Fprintln(out)
+		fmt.Fprintln(w, "SOURCE\tTARGET\tLATENCY\tTHROUGHPUT")
+		for _, result := range measures {
+			fmt.Fprintf(w, "%s\t%s\t%.3fms\t%.1fMB/s\n", result.Source, result.Target, result.LatencyMs, result.ThroughputMBps)
+		}
+		w.Flush()
+	}
+
+	if len(failures) == 0 {
+		return nil
+	}
+
+	fmt.Fprintln(out)
+	fmt.Fprintln(w, "SOURCE\tTARGET\tPORT\tPURPOSE\tERROR")
+	failed := 0
+	for _, result := range failures {
+		if !result.Reachable {
+			failed++
+		}
+		fmt.Fprintf(w, "%s\t%s\t%d\t%s\t%s\n", result.Source, result.Address, result.Port, result.Purpose, orDash(result.Error))
+	}
+	w.Flush()
+
+	if failed > 0 {
+		return fmt.Errorf("%d connection(s) failed", failed)
+	}
+
+	return nil
+}
+
+func checkDiskCmd() *cobra.Command {
+	checkDiskCmd := &cobra.Command{
+		Use:   "disk <config-file>",
+		Short: "Measure the throughput of the disks of the planned data directories",
+		Long: `Measure the throughput of the disks of the planned data directories.
+
+The data directories are read from the same configuration file as gp init. The
+agents write and then read a file sequentially in each data directory, or in its
+closest existing parent, and report the throughput in MB/s. The hosts are
+tested in parallel and the directories of each host one at a time. Writing and
+reading each stop after --size MB or --duration seconds, whichever comes first.`,
+		Args:    cobra.ExactArgs(1),
+		PreRunE: InitializeCommand,
+		RunE:    RunCheckDisk,
+	}
+
+	checkDiskCmd.Flags().IntVar(&checkDiskSizeMB, "size", constants.DefaultCheckDiskSizeMB, "MB written to each data directory at most")
+	checkDiskCmd.Flags().IntVar(&checkDiskDuration, "duration", constants.DefaultCheckDiskDuration, "Seconds spent writing or reading each data directory at most")
+
+	return checkDiskCmd
+}
+
+func RunCheckDisk(cmd *cobra.Command, args []string) error {
+	if checkDiskSizeMB <= 0 {
+		return fmt.Errorf("the size must be a positive number of MB")
+	}
+	if checkDiskDuration <= 0 {
+		return fmt.Errorf("the duration must be a positive number of seconds")
+	}
+
+	config, err := LoadInitConfig(args[0], viper.New())
+	if err != nil {
+		return err
+	}
+
+	client, err := ConnectToHub(Conf)
+	if err != nil {
+		return err
+	}
+
+	reply, err := client.CheckDisk(CommandContext, &idl.CheckDiskRequest{
+		GpArray:     CreateMakeClusterReq(config, false, false).GpArray,
+		SizeBytes:   int64(checkDiskSizeMB) * 1024 * 1024,
+		MaxDuration: int32(checkDiskDuration),
+	})
+	if err != nil {
+		return utils.FormatGrpcError(err)
+	}
+
+	return PrintDiskCheck(os.Stdout, reply.Results)
+}
+
+/*
+PrintDiskCheck prints the throughput of each data directory, followed by the
+slowest hosts compared to the median host. The throughput of a host is the
+lowest among its directories. It returns an error if any directory could not
+be tested.
+*/
+func PrintDiskCheck(out io.Writer, results []*idl.DiskResult) error {
+	type hostThroughput struct {
+		host        string
+		write, read float64
+	}
+
+	w := new(tabwriter.Writer)
+	w.Init(out, 0, 8, 2, '\t', 0)
+
+	var failures []*idl.DiskResult
+	var hosts []*hostThroughput
+	byHost := make(map[string]*hostThroughput)
+	fmt.Fprintln(w, "HOST\tDIRECTORY\tSIZE\tWRITE\tREAD")
+	for _, result := range results {
+		if result.Error != "" {
+			failures = append(failures, result)
+			continue
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%.1fMB/s\t%.1fMB/s\n", result.Host, result.Directory, formatBytes(uint64(result.Bytes)), result.WriteMBps, result.ReadMBps)
+
+		host := byHost[result.Host]
+		if host == nil {
+			host = &hostThroughput{host: result.Host, write: result.WriteMBps, read: result.ReadMBps}
+			byHost[result.Host] = host
+			hosts = append(hosts, host)
+		}
+		host.write = min(host.write, result.WriteMBps)
+		host.read = min(host.read, result.ReadMBps)
+	}
+	w.Flush()
+
+	var medianWrite, medianRead float64
+	if len(hosts) > 1 {
+		writes := make([]float64, len(hosts))
+		reads := make([]float64, len(hosts))
+		for i, host := range hosts {
+			writes[i], reads[i] = host.write, host.read
+		}
+		sort.Float64s(writes)
+		sort.Float64s(reads)
+		medianWrite, medianRead = writes[len(hosts)/2], reads[len(hosts)/2]
+	}
+
+	if medianWrite > 0 && medianRead > 0 {
+		// the slowest hosts by the lower of their write and read ratios
+		ratio := func(host *hostThroughput) float64 {
+			return min(host.write/medianWrite, host.read/medianRead)
+		}
+		sort.SliceStable(hosts, func(i, j int) bool {
+			return ratio(hosts[i]) < ratio(hosts[j])
+		})
+
+		fmt.Fprintln(out)
+		fmt.Fprintf(out, "Slowest hosts, compared to the median of %.1fMB/s write and %.1fMB/s read:\n", medianWrite, medianRead)
+		fmt.Fprintln(w, "HOST\tWRITE\tREAD")
+		for _, host := range hosts[:min(slowestDiskHosts, len(hosts))] {
+			fmt.Fprintf(w, "%s\t%.1fMB/s (%.0f%%)\t%.1fMB/s (%.0f%%)\n", host.host, host.write, 100*host.write/medianWrite, host.read, 100*host.read/medianRead)
+		}
+		w.Flush()
+	}
+
+	if len(failures) > 0 {
+		fmt.Fprintln(out)
+		fmt.Fprintln(w, "HOST\tDIRECTORY\tERROR")
+		for _, result := range failures {
+			fmt.Fprintf(w, "%s\t%s\t%s\n", result.Host, result.Directory, result.Error)
+		}
+		w.Flush()
+
+		return fmt.Errorf("failed to test %d data directory(ies)", len(failures))
+	}
+
+	return nil
+}
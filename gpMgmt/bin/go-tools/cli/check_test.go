@@ -0,0 +1,102 @@
+package cli_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/greenplum-db/gpdb/gp/cli"
+	"github.com/greenplum-db/gpdb/gp/idl"
+)
+
+func TestPrintNetworkCheck(t *testing.T) {
+	t.Run("prints the matrix of the hosts", func(t *testing.T) {
+		var out bytes.Buffer
+		err := cli.PrintNetworkCheck(&out, []*idl.NetworkResult{
+			{Source: "cdw", Target: "sdw1", Address: "sdw1", Port: 7000, Reachable: true, LatencyMs: 0.25, ThroughputMBps: 1100},
+			{Source: "cdw", Target: "sdw1", Address: "sdw1", Port: 7001, Reachable: true},
+			{Source: "sdw1", Target: "cdw", Address: "cdw", Port: 5432, Reachable: true},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		expected := strings.Join([]string{
+			"SOURCE \\ TARGET\t\tcdw\tsdw1",
+			"cdw\t\t\t-\t1100.0MB/s",
+			"sdw1\t\t\tok\t-",
+			"",
+			"SOURCE\tTARGET\tLATENCY\t\tTHROUGHPUT",
+			"cdw\tsdw1\t0.250ms\t\t1100.0MB/s",
+			"",
+		}, "\n")
+		if out.String() != expected {
+			t.Fatalf("got %q, want %q", out.String(), expected)
+		}
+	})
+
+	t.Run("lists the failed connections", func(t *testing.T) {
+		var out bytes.Buffer
+		err := cli.PrintNetworkCheck(&out, []*idl.NetworkResult{
+			{Source: "cdw", Target: "sdw1", Address: "sdw1", Port: 7000, Reachable: true},
+			{Source: "cdw", Target: "sdw1", Address: "sdw1", Port: 7001, Purpose: "mirror of content 1", Error: "connection refused, the port may be blocked by a firewall"},
+		})
+
+		expected := "1 connection(s) failed"
+		if err == nil || err.Error() != expected {
+			t.Fatalf("got %v, want %s", err, expected)
+		}
+		if !strings.Contains(out.String(), "cdw\t\t\t-\tFAIL") || !strings.Contains(out.String(), "cdw\tsdw1\t7001\tmirror of content 1\tconnection refused") {
+			t.Fatalf("unexpected output %q", out.String())
+		}
+	})
+}
+
+func TestPrintDiskCheck(t *testing.T) {
+	t.Run("prints the slowest hosts", func(t *testing.T) {
+		var out bytes.Buffer
+		err := cli.PrintDiskCheck(&out, []*idl.DiskResult{
+			{Host: "sdw1", Directory: "/data/primary/gpseg0", Bytes: 1 << 30, WriteMBps: 500, ReadMBps: 800},
+			{Host: "sdw1", Directory: "/data/mirror/gpseg1", Bytes: 1 << 30, WriteMBps: 400, ReadMBps: 800},
+			{Host: "sdw2", Directory: "/data/primary/gpseg1", Bytes: 1 << 30, WriteMBps: 200, ReadMBps: 800},
+			{Host: "sdw3", Directory: "/data/primary/gpseg2", Bytes: 1 << 30, WriteMBps: 400, ReadMBps: 400},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		expected := strings.Join([]string{
+			"HOST\tDIRECTORY\t\tSIZE\tWRITE\t\tREAD",
+			"sdw1\t/data/primary/gpseg0\t1.0GiB\t500.0MB/s\t800.0MB/s",
+			"sdw1\t/data/mirror/gpseg1\t1.0GiB\t400.0MB/s\t800.0MB/s",
+			"sdw2\t/data/primary/gpseg1\t1.0GiB\t200.0MB/s\t800.0MB/s",
+			"sdw3\t/data/primary/gpseg2\t1.0GiB\t400.0MB/s\t400.0MB/s",
+			"",
+			"Slowest hosts, compared to the median of 400.0MB/s write and 800.0MB/s read:",
+			"HOST\tWRITE\t\t\tREAD",
+			"sdw2\t200.0MB/s (50%)\t\t800.0MB/s (100%)",
+			"sdw3\t400.0MB/s (100%)\t400.0MB/s (50%)",
+			"sdw1\t400.0MB/s (100%)\t800.0MB/s (100%)",
+			"",
+		}, "\n")
+		if out.String() != expected {
+			t.Fatalf("got %q, want %q", out.String(), expected)
+		}
+	})
+
+	t.Run("lists the directories which could not be tested", func(t *testing.T) {
+		var out bytes.Buffer
+		err := cli.PrintDiskCheck(&out, []*idl.DiskResult{
+			{Host: "sdw1", Directory: "/data/primary/gpseg0", WriteMBps: 500, ReadMBps: 800},
+			{Host: "sdw2", Directory: "/data/primary/gpseg1", Error: "could not create the test file: permission denied"},
+		})
+
+		expected := "failed to test 1 data directory(ies)"
+		if err == nil || err.Error() != expected {
+			t.Fatalf("got %v, want %s", err, expected)
+		}
+		if strings.Contains(out.String(), "Slowest hosts") || !strings.Contains(out.String(), "sdw2\t/data/primary/gpseg1\tcould not create the test file") {
+			t.Fatalf("unexpected output %q", out.String())
+		}
+	})
+}
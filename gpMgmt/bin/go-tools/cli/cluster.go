@@ -0,0 +1,448 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/greenplum-db/gp-common-go-libs/gplog"
+	"github.com/greenplum-db/gpdb/gp/constants"
+	"github.com/greenplum-db/gpdb/gp/hub"
+	"github.com/greenplum-db/gpdb/gp/utils"
+)
+
+var (
+	// Name of the cluster context to run the command for, or to register
+	// with gp configure
+	clusterName string
+
+	ClustersFilePath = defaultClustersFilePath()
+)
+
+// ClusterContext points to the gp.conf of a cluster
+type ClusterContext struct {
+	ConfigFile string `json:"configFile"`
+}
+
+/*
+Clusters are the named cluster contexts of the user, stored in the user config
+directory, so that several clusters on the same hosts can be managed with
+gp --cluster <name>. The current cluster is used when neither --cluster nor
+--config-file is passed.
+*/
+type Clusters struct {
+	Current  string                     `json:"currentCluster,omitempty"`
+	Clusters map[string]*ClusterContext `json:"clusters"`
+}
+
+func defaultClustersFilePath() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		dir = filepath.Join(os.Getenv("HOME"), ".config")
+	}
+
+	return filepath.Join(dir, constants.ClustersDirName, constants.ClustersFileName)
+}
+
+// LoadClusters reads the cluster contexts, which are empty when the file does
+// not exist
+func LoadClusters(path string) (*Clusters, error) {
+	clusters := &Clusters{Clusters: make(map[string]*ClusterContext)}
+	contents, err := utils.System.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return clusters, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not read cluster contexts: %w", err)
+	}
+
+	err = json.Unmarshal(contents, clusters)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse cluster contexts %s: %w", path, err)
+	}
+	if clusters.Clusters == nil {
+		clusters.Clusters = make(map[string]*ClusterContext)
+	}
+
+	return clusters, nil
+}
+
+func (c *Clusters) Write(path string) error {
+	contents, err := json.MarshalIndent(c, "", "\t")
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return fmt.Errorf("could not create the directory of the cluster contexts: %w", err)
+	}
+
+	// the contexts are written atomically so that commands run at the same
+	// time do not read a partial file
+	err = utils.WriteFileAtomic(path, append(contents, '\n'), 0644)
+	if err != nil {
+		return fmt.Errorf("could not write cluster contexts: %w", err)
+	}
+
+	return nil
+}
+
+// Names returns the names of the clusters, sorted
+func (c *Clusters) Names() []string {
+	names := make([]string, 0, len(c.Clusters))
+	for name := range c.Clusters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+/*
+resolveClusterConfigFile points ConfigFilePath to the gp.conf of the cluster
+passed with --cluster, or of the current cluster when no configuration file is
+passed. The hub and agents are left with the configuration file of their
+service.
+*/
+func resolveClusterConfigFile(cmd *cobra.Command) error {
+	configFileChanged := cmd.Flags().Lookup("config-file") != nil && cmd.Flags().Lookup("config-file").Changed
+	if clusterName == "" && (configFileChanged || cmd.Hidden) {
+		return nil
+	}
+	if clusterName != "" && configFileChanged {
+		return errors.New("--cluster and --config-file cannot be used together")
+	}
+
+	clusters, err := LoadClusters(ClustersFilePath)
+	if err != nil {
+		return err
+	}
+
+	name := clusterName
+	if name == "" {
+		name = clusters.Current
+	}
+	if name == "" {
+		return nil
+	}
+
+	context, ok := clusters.Clusters[name]
+	if !ok {
+		return fmt.Errorf("unknown cluster %q, see gp cluster list", name)
+	}
+	ConfigFilePath = context.ConfigFile
+	gplog.Verbose("Using the configuration file %s of cluster %s", ConfigFilePath, name)
+
+	return nil
+}
+
+type clusterResource struct {
+	host     string
+	resource string // e.g. port 4242
+	purpose  string // e.g. hub port
+}
+
+// clusterResources returns the ports and service files the cluster uses on
+// each host, the hub running on the coordinator host
+func clusterResources(conf *hub.Config, coordinator string) []clusterResource {
+	var resources []clusterResource
+	add := func(host string, port int, purpose string) {
+		if port > 0 {
+			resources = append(resources, clusterResource{host, fmt.Sprintf("port %d", port), purpose})
+		}
+	}
+
+	add(coordinator, conf.Port, "hub port")
+	if conf.Metrics != nil {
+		add(coordinator, conf.Metrics.HubPort, "hub metrics port")
+	}
+	resources = append(resources, clusterResource{coordinator, fmt.Sprintf("service %s_hub", conf.ServiceName), "hub service"})
+
+	for _, host := range conf.Hostnames {
+		add(host, conf.AgentPort, "agent port")
+		if conf.Metrics != nil {
+			add(host, conf.Metrics.AgentPort, "agent metrics port")
+		}
+		resources = append(resources, clusterResource{host, fmt.Sprintf("service %s_agent", conf.ServiceName), "agent service"})
+	}
+
+	return resources
+}
+
+// shortHostname returns the host name without its domain, so that the same
+// host matches whether it is given by its short name or its FQDN
+func shortHostname(host string) string {
+	if net.ParseIP(host) != nil {
+		return host
+	}
+
+	return strings.ToLower(strings.SplitN(host, ".", 2)[0])
+}
+
+/*
+FindClusterConflicts returns the ports and service names of the cluster which
+are also used by the other clusters on the same hosts, as the services of both
+clusters could not run side by side. The hosts are matched on their short name.
+*/
+func FindClusterConflicts(conf *hub.Config, others map[string]*hub.Config, coordinator string) []string {
+	used := make(map[clusterResource]string) // the purpose is blanked to match the resource on its own
+	names := make([]string, 0, len(others))
+	for name := range others {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for i := len(names) - 1; i >= 0; i-- { // the first cluster by name is reported
+		for _, r := range clusterResources(others[names[i]], coordinator) {
+			used[clusterResource{host: shortHostname(r.host), resource: r.resource}] = fmt.Sprintf("%s of cluster %s", r.purpose, names[i])
+		}
+	}
+
+	var conflicts []string
+	for _, r := range clusterResources(conf, coordinator) {
+		if other, ok := used[clusterResource{host: shortHostname(r.host), resource: r.resource}]; ok {
+			conflicts = append(conflicts, fmt.Sprintf("%s on %s: the %s is already the %s", r.resource, r.host, r.purpose, other))
+		}
+	}
+
+	return conflicts
+}
+
+// checkClusterConflicts returns an error if the cluster conflicts with the
+// registered clusters, other than the one it replaces: the one of the same name,
+// or of the same configuration file when the cluster is not named
+func checkClusterConflicts(name string, configFile string, conf *hub.Config) error {
+	clusters, err := LoadClusters(ClustersFilePath)
+	if err != nil {
+		return err
+	}
+
+	others := make(map[string]*hub.Config)
+	for otherName, context := range clusters.Clusters {
+		if otherName == name {
+			continue
+		}
+		if context.ConfigFile == configFile {
+			if name != "" {
+				return fmt.Errorf("the configuration file %s is already used by cluster %s", configFile, otherName)
+			}
+			continue
+		}
+
+		other := &hub.Config{}
+		err = other.Load(context.ConfigFile)
+		if err != nil {
+			gplog.Warn("Could not check the ports of cluster %s: %v", otherName, err)
+			continue
+		}
+		others[otherName] = other
+	}
+
+	coordinator, err := utils.System.GetHostName()
+	if err != nil {
+		return err
+	}
+
+	conflicts := FindClusterConflicts(conf, others, coordinator)
+	if len(conflicts) > 0 {
+		return fmt.Errorf("the cluster conflicts with the other clusters on the same hosts:\n%s", strings.Join(conflicts, "\n"))
+	}
+
+	return nil
+}
+
+// registerCluster adds or replaces the cluster context, and makes it the
+// current cluster if there is none
+func registerCluster(name string, configFile string) error {
+	clusters, err := LoadClusters(ClustersFilePath)
+	if err != nil {
+		return err
+	}
+
+	clusters.Clusters[name] = &ClusterContext{ConfigFile: configFile}
+	if clusters.Current == "" {
+		clusters.Current = name
+	}
+
+	err = clusters.Write(ClustersFilePath)
+	if err != nil {
+		return err
+	}
+	gplog.Info("Registered cluster %s with the configuration file %s", name, configFile)
+
+	return nil
+}
+
+func clusterCmd() *cobra.Command {
+	clusterCmd := &cobra.Command{
+		Use:   "cluster",
+		Short: "Manage the contexts of the clusters on the same hosts",
+		Long: fmt.Sprintf(`Manage the contexts of the clusters on the same hosts.
+
+A cluster context names the gp.conf of a cluster, so that the commands can be
+run for it with gp --cluster <name>. The contexts are stored in %s.
+Clusters are registered by gp configure --cluster <name>, or with gp cluster add.`, ClustersFilePath),
+	}
+
+	clusterCmd.AddCommand(
+		clusterListCmd(),
+		clusterAddCmd(),
+		clusterRemoveCmd(),
+		clusterUseCmd(),
+	)
+
+	return clusterCmd
+}
+
+func clusterListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:     "list",
+		Short:   "List the clusters, their ports and hosts",
+		Args:    cobra.NoArgs,
+		PreRunE: InitializeLogger,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clusters, err := LoadClusters(ClustersFilePath)
+			if err != nil {
+				return err
+			}
+
+			PrintClusters(os.Stdout, clusters)
+			return nil
+		},
+	}
+}
+
+// PrintClusters prints a table of the clusters, with the current cluster
+// marked with a star
+func PrintClusters(out io.Writer, clusters *Clusters) {
+	if len(clusters.Clusters) == 0 {
+		fmt.Fprintln(out, "No clusters, they are registered with gp configure --cluster <name> or gp cluster add")
+		return
+	}
+
+	w := new(tabwriter.Writer)
+	w.Init(out, 0, 8, 2, '\t', 0)
+
+	fmt.Fprintln(w, "CURRENT\tNAME\tCONFIG FILE\tHUB PORT\tAGENT PORT\tSERVICE\tHOSTS")
+	for _, name := range clusters.Names() {
+		current := ""
+		if name == clusters.Current {
+			current = "*"
+		}
+
+		configFile := clusters.Clusters[name].ConfigFile
+		conf := &hub.Config{}
+		err := conf.Load(configFile)
+		if err != nil {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", current, name, configFile, err)
+			continue
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%d\t%s\t%s\n", current, name, configFile, conf.Port, conf.AgentPort, conf.ServiceName, strings.Join(conf.Hostnames, ","))
+	}
+	w.Flush()
+}
+
+func clusterAddCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "add <name>",
+		Short: "Register the cluster of the configuration file given with --config-file",
+		Long: `Register the cluster of the configuration file given with --config-file, by
+default $GPHOME/gp.conf. This is refused if the cluster uses the same ports or
+service name as another cluster on the same hosts.`,
+		Args:    cobra.ExactArgs(1),
+		PreRunE: InitializeLogger,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			configFile, err := filepath.Abs(ConfigFilePath)
+			if err != nil {
+				return err
+			}
+
+			conf := &hub.Config{}
+			err = conf.Load(configFile)
+			if err != nil {
+				return err
+			}
+
+			err = checkClusterConflicts(args[0], configFile, conf)
+			if err != nil {
+				return err
+			}
+
+			return registerCluster(args[0], configFile)
+		},
+	}
+}
+
+func clusterRemoveCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:     "remove <name>",
+		Short:   "Forget the cluster, without changing its services or gp.conf",
+		Args:    cobra.ExactArgs(1),
+		PreRunE: InitializeLogger,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return unregisterCluster(args[0])
+		},
+	}
+}
+
+// unregisterCluster removes the cluster context, and the current cluster if
+// it is the one removed
+func unregisterCluster(name string) error {
+	clusters, err := LoadClusters(ClustersFilePath)
+	if err != nil {
+		return err
+	}
+
+	if _, ok := clusters.Clusters[name]; !ok {
+		return fmt.Errorf("unknown cluster %q, see gp cluster list", name)
+	}
+	delete(clusters.Clusters, name)
+	if clusters.Current == name {
+		clusters.Current = ""
+	}
+
+	err = clusters.Write(ClustersFilePath)
+	if err != nil {
+		return err
+	}
+	gplog.Info("Removed cluster %s", name)
+
+	return nil
+}
+
+func clusterUseCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:     "use <name>",
+		Short:   "Make the cluster the one the commands run for by default",
+		Args:    cobra.ExactArgs(1),
+		PreRunE: InitializeLogger,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clusters, err := LoadClusters(ClustersFilePath)
+			if err != nil {
+				return err
+			}
+
+			if _, ok := clusters.Clusters[args[0]]; !ok {
+				return fmt.Errorf("unknown cluster %q, see gp cluster list", args[0])
+			}
+			clusters.Current = args[0]
+
+			err = clusters.Write(ClustersFilePath)
+			if err != nil {
+				return err
+			}
+			gplog.Info("Switched to cluster %s", args[0])
+
+			return nil
+		},
+	}
+}
@@ -0,0 +1,353 @@
+package cli_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/greenplum-db/gp-common-go-libs/testhelper"
+	"github.com/greenplum-db/gpdb/gp/cli"
+	"github.com/greenplum-db/gpdb/gp/hub"
+	"github.com/greenplum-db/gpdb/gp/testutils"
+	"github.com/greenplum-db/gpdb/gp/utils"
+	"github.com/greenplum-db/gpdb/gp/utils/metrics"
+)
+
+// setupClusters points the cluster contexts to a temporary file, and writes
+// the configuration file of each cluster to the same directory
+func setupClusters(t *testing.T, current string, confs map[string]*hub.Config) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	clusters := &cli.Clusters{Current: current, Clusters: make(map[string]*cli.ClusterContext)}
+	for name, conf := range confs {
+		contents, err := json.Marshal(conf)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		configFile := filepath.Join(dir, name+".conf")
+		err = os.WriteFile(configFile, contents, 0644)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		clusters.Clusters[name] = &cli.ClusterContext{ConfigFile: configFile}
+	}
+
+	originalClustersFilePath := cli.ClustersFilePath
+	cli.ClustersFilePath = filepath.Join(dir, "gp", "clusters.json")
+	err := clusters.Write(cli.ClustersFilePath)
+	if err != nil {
+		t.Fatalf("unexpected error: %#v", err)
+	}
+
+	originalPlatform := cli.Platform
+	cli.Platform = &testutils.MockPlatform{}
+	t.Cleanup(func() {
+		cli.ClustersFilePath = originalClustersFilePath
+		cli.Platform = originalPlatform
+	})
+
+	return dir
+}
+
+func TestLoadClusters(t *testing.T) {
+	t.Run("returns no clusters when the file does not exist", func(t *testing.T) {
+		clusters, err := cli.LoadClusters(filepath.Join(t.TempDir(), "clusters.json"))
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		if clusters.Current != "" || len(clusters.Clusters) != 0 {
+			t.Fatalf("got %+v, want no clusters", clusters)
+		}
+	})
+
+	t.Run("reads the clusters written", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "gp", "clusters.json")
+		expected := &cli.Clusters{
+			Current: "prod",
+			Clusters: map[string]*cli.ClusterContext{
+				"prod": {ConfigFile: "/usr/local/gpdb/gp.conf"},
+				"dev":  {ConfigFile: "/home/gpadmin/dev/gp.conf"},
+			},
+		}
+		err := expected.Write(path)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		clusters, err := cli.LoadClusters(path)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		if !reflect.DeepEqual(clusters, expected) {
+			t.Fatalf("got %+v, want %+v", clusters, expected)
+		}
+		if !reflect.DeepEqual(clusters.Names(), []string{"dev", "prod"}) {
+			t.Fatalf("got %+v, want the names sorted", clusters.Names())
+		}
+	})
+
+	t.Run("errors when the file is not valid", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "clusters.json")
+		err := os.WriteFile(path, []byte("{"), 0644)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		_, err = cli.LoadClusters(path)
+		if err == nil || !strings.HasPrefix(err.Error(), "could not parse cluster contexts") {
+			t.Fatalf("got %v, want a parse error", err)
+		}
+	})
+}
+
+func TestFindClusterConflicts(t *testing.T) {
+	prod := &hub.Config{
+		Port:        4242,
+		AgentPort:   8000,
+		ServiceName: "gp",
+		Hostnames:   []string{"sdw1", "sdw2"},
+		Metrics:     &metrics.Config{HubPort: 9100, AgentPort: 9101},
+	}
+
+	t.Run("finds no conflict between clusters with other ports and service names", func(t *testing.T) {
+		dev := &hub.Config{Port: 5242, AgentPort: 9000, ServiceName: "gp_dev", Hostnames: []string{"sdw1", "sdw2"}}
+
+		conflicts := cli.FindClusterConflicts(dev, map[string]*hub.Config{"prod": prod}, "cdw")
+		if len(conflicts) != 0 {
+			t.Fatalf("got %+v, want no conflicts", conflicts)
+		}
+	})
+
+	t.Run("finds no conflict between clusters on other hosts", func(t *testing.T) {
+		test := &hub.Config{Port: 5242, AgentPort: 8000, ServiceName: "gp_test", Hostnames: []string{"sdw3"}}
+
+		conflicts := cli.FindClusterConflicts(test, map[string]*hub.Config{"prod": prod}, "cdw")
+		if len(conflicts) != 0 {
+			t.Fatalf("got %+v, want no conflicts", conflicts)
+		}
+	})
+
+	t.Run("finds the ports and services used on the shared hosts", func(t *testing.T) {
+		dev := &hub.Config{Port: 9101, AgentPort: 8000, ServiceName: "gp_dev", Hostnames: []string{"cdw", "sdw2"}}
+
+		conflicts := cli.FindClusterConflicts(dev, map[string]*hub.Config{"prod": prod}, "cdw")
+		expected := []string{
+			"port 8000 on sdw2: the agent port is already the agent port of cluster prod",
+		}
+		if !reflect.DeepEqual(conflicts, expected) {
+			t.Fatalf("got %+v, want %+v", conflicts, expected)
+		}
+
+		test := &hub.Config{Port: 9100, AgentPort: 9101, ServiceName: "gp", Hostnames: []string{"sdw1"}}
+		conflicts = cli.FindClusterConflicts(test, map[string]*hub.Config{"prod": prod, "dev": dev}, "cdw")
+		expected = []string{
+			"port 9100 on cdw: the hub port is already the hub metrics port of cluster prod",
+			"service gp_hub on cdw: the hub service is already the hub service of cluster prod",
+			"port 9101 on sdw1: the agent port is already the agent metrics port of cluster prod",
+			"service gp_agent on sdw1: the agent service is already the agent service of cluster prod",
+		}
+		if !reflect.DeepEqual(conflicts, expected) {
+			t.Fatalf("got %+v, want %+v", conflicts, expected)
+		}
+	})
+
+	t.Run("matches the hosts given by their short name or their FQDN", func(t *testing.T) {
+		dev := &hub.Config{Port: 5242, AgentPort: 8000, ServiceName: "gp_dev", Hostnames: []string{"SDW1.example.com"}}
+
+		conflicts := cli.FindClusterConflicts(dev, map[string]*hub.Config{"prod": prod}, "cdw.example.com")
+		expected := []string{
+			"port 8000 on SDW1.example.com: the agent port is already the agent port of cluster prod",
+		}
+		if !reflect.DeepEqual(conflicts, expected) {
+			t.Fatalf("got %+v, want %+v", conflicts, expected)
+		}
+	})
+}
+
+func TestClusterCommands(t *testing.T) {
+	testhelper.SetupTestLogger()
+
+	prod := &hub.Config{Port: 4242, AgentPort: 8000, ServiceName: "gp", Hostnames: []string{"sdw1"}, LogDir: t.TempDir()}
+	dev := &hub.Config{Port: 5242, AgentPort: 9000, ServiceName: "gp_dev", Hostnames: []string{"sdw1"}, LogDir: t.TempDir()}
+
+	runCommand := func(t *testing.T, args ...string) error {
+		t.Helper()
+
+		root := cli.RootCommand()
+		root.SetArgs(args)
+		root.SilenceUsage = true
+		root.SilenceErrors = true
+
+		return root.Execute()
+	}
+
+	loadClusters := func(t *testing.T) *cli.Clusters {
+		t.Helper()
+
+		clusters, err := cli.LoadClusters(cli.ClustersFilePath)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		return clusters
+	}
+
+	t.Run("switches the current cluster and removes clusters", func(t *testing.T) {
+		setupClusters(t, "prod", map[string]*hub.Config{"prod": prod, "dev": dev})
+
+		err := runCommand(t, "cluster", "use", "dev")
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		if clusters := loadClusters(t); clusters.Current != "dev" {
+			t.Fatalf("got current cluster %q, want dev", clusters.Current)
+		}
+
+		err = runCommand(t, "cluster", "remove", "dev")
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		clusters := loadClusters(t)
+		if clusters.Current != "" || !reflect.DeepEqual(clusters.Names(), []string{"prod"}) {
+			t.Fatalf("got %+v, want only the prod cluster without a current cluster", clusters)
+		}
+
+		err = runCommand(t, "cluster", "use", "dev")
+		expected := `unknown cluster "dev", see gp cluster list`
+		if err == nil || err.Error() != expected {
+			t.Fatalf("got %v, want %s", err, expected)
+		}
+	})
+
+	t.Run("refuses to add a cluster conflicting with another cluster", func(t *testing.T) {
+		dir := setupClusters(t, "", map[string]*hub.Config{"prod": prod, "dev": dev})
+		utils.System.GetHostName = func() (string, error) {
+			return "cdw", nil
+		}
+		defer utils.ResetSystemFunctions()
+
+		err := runCommand(t, "cluster", "add", "copy", "--config-file", filepath.Join(dir, "dev.conf"))
+		expected := "the configuration file " + filepath.Join(dir, "dev.conf") + " is already used by cluster dev"
+		if err == nil || err.Error() != expected {
+			t.Fatalf("got %v, want %s", err, expected)
+		}
+
+		contents, err := json.Marshal(&hub.Config{Port: 4243, AgentPort: 9000, ServiceName: "gp_test", Hostnames: []string{"sdw1"}})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		configFile := filepath.Join(dir, "test.conf")
+		err = os.WriteFile(configFile, contents, 0644)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		err = runCommand(t, "cluster", "add", "test", "--config-file", configFile)
+		expected = "the cluster conflicts with the other clusters on the same hosts:\nport 9000 on sdw1: the agent port is already the agent port of cluster dev"
+		if err == nil || err.Error() != expected {
+			t.Fatalf("got %v, want %s", err, expected)
+		}
+		if _, ok := loadClusters(t).Clusters["test"]; ok {
+			t.Fatalf("expected the conflicting cluster not to be added")
+		}
+	})
+
+	t.Run("commands use the configuration file of the cluster", func(t *testing.T) {
+		dir := setupClusters(t, "prod", map[string]*hub.Config{"prod": prod, "dev": dev})
+
+		cases := []struct {
+			name       string
+			args       []string
+			configFile string
+			err        string
+		}{
+			{name: "uses the current cluster by default", configFile: filepath.Join(dir, "prod.conf")},
+			{name: "uses the cluster passed", args: []string{"--cluster", "dev"}, configFile: filepath.Join(dir, "dev.conf")},
+			{name: "uses the configuration file passed", args: []string{"--config-file", "/tmp/gp.conf"}, configFile: "/tmp/gp.conf"},
+			{name: "errors on an unknown cluster", args: []string{"--cluster", "test"}, err: `unknown cluster "test", see gp cluster list`},
+			{name: "errors when both are passed", args: []string{"--cluster", "dev", "--config-file", "/tmp/gp.conf"}, err: "--cluster and --config-file cannot be used together"},
+		}
+
+		for _, tc := range cases {
+			t.Run(tc.name, func(t *testing.T) {
+				root := cli.RootCommand()
+				cmd, _, err := root.Find([]string{"status", "hub"})
+				if err != nil {
+					t.Fatalf("unexpected error: %#v", err)
+				}
+				err = cmd.ParseFlags(tc.args)
+				if err != nil {
+					t.Fatalf("unexpected error: %#v", err)
+				}
+
+				err = cli.InitializeCommand(cmd, nil)
+				cli.FinishCommand(err)
+				if tc.err != "" {
+					if err == nil || err.Error() != tc.err {
+						t.Fatalf("got %v, want %s", err, tc.err)
+					}
+					return
+				}
+
+				// the configuration file passed does not exist
+				if err != nil && !strings.HasPrefix(err.Error(), "could not open config file") {
+					t.Fatalf("unexpected error: %#v", err)
+				}
+				if cli.ConfigFilePath != tc.configFile {
+					t.Fatalf("got %s, want %s", cli.ConfigFilePath, tc.configFile)
+				}
+			})
+		}
+	})
+}
+
+func TestPrintClusters(t *testing.T) {
+	t.Run("prints the clusters with the current one marked", func(t *testing.T) {
+		dir := setupClusters(t, "prod", map[string]*hub.Config{
+			"prod": {Port: 4242, AgentPort: 8000, ServiceName: "gp", Hostnames: []string{"sdw1", "sdw2"}},
+		})
+		clusters := &cli.Clusters{
+			Current: "prod",
+			Clusters: map[string]*cli.ClusterContext{
+				"prod": {ConfigFile: filepath.Join(dir, "prod.conf")},
+				"old":  {ConfigFile: filepath.Join(dir, "old.conf")},
+			},
+		}
+
+		var out strings.Builder
+		cli.PrintClusters(&out, clusters)
+
+		expected := "CURRENT\tNAME\tCONFIG FILE\tHUB PORT\tAGENT PORT\tSERVICE\tHOSTS\n"
+		lines := strings.Split(out.String(), "\n")
+		if len(lines) != 4 {
+			t.Fatalf("got %q, want a header and two clusters", out.String())
+		}
+		if strings.Join(strings.Fields(lines[0]), " ") != strings.Join(strings.Fields(expected), " ") {
+			t.Fatalf("got %q, want %q", lines[0], expected)
+		}
+		if !strings.HasPrefix(strings.TrimSpace(lines[1]), "old") || !strings.Contains(lines[1], "could not open config file") {
+			t.Fatalf("got %q, want the error of the old cluster", lines[1])
+		}
+		expected = "* prod " + filepath.Join(dir, "prod.conf") + " 4242 8000 gp sdw1,sdw2"
+		if strings.Join(strings.Fields(lines[2]), " ") != expected {
+			t.Fatalf("got %q, want %q", lines[2], expected)
+		}
+	})
+
+	t.Run("prints how to add a cluster when there is none", func(t *testing.T) {
+		var out strings.Builder
+		cli.PrintClusters(&out, &cli.Clusters{})
+
+		expected := "No clusters, they are registered with gp configure --cluster <name> or gp cluster add\n"
+		if out.String() != expected {
+			t.Fatalf("got %q, want %q", out.String(), expected)
+		}
+	})
+}
@@ -13,6 +13,8 @@ import (
 	"github.com/greenplum-db/gpdb/gp/constants"
 	"github.com/greenplum-db/gpdb/gp/hub"
 	"github.com/greenplum-db/gpdb/gp/idl"
+	"github.com/greenplum-db/gpdb/gp/utils"
+	"github.com/greenplum-db/gpdb/gp/utils/tracing"
 	"github.com/spf13/cobra"
 	"google.golang.org/grpc"
 )
@@ -27,6 +29,11 @@ var (
 	Conf           *hub.Config
 
 	Verbose bool
+
+	// CommandContext carries the span of the running command, so that the hub
+	// and agents record the work done for it in the same trace
+	CommandContext = context.Background()
+	finishTracing  = func(err error) {}
 )
 
 func RootCommand() *cobra.Command {
@@ -36,15 +43,23 @@ func RootCommand() *cobra.Command {
 
 	root.PersistentFlags().StringVar(&ConfigFilePath, "config-file", filepath.Join(os.Getenv("GPHOME"), constants.ConfigFileName), `Path to gp configuration file`)
 	root.PersistentFlags().BoolVar(&Verbose, "verbose", false, `Provide verbose output`)
+	root.PersistentFlags().StringVar(&clusterName, "cluster", "", `Name of the cluster to run the command for, see gp cluster list (default the current cluster)`)
 
 	root.AddCommand(
 		agentCmd(),
 		configureCmd(),
+		unconfigureCmd(),
 		hubCmd(),
 		startCmd(),
 		statusCmd(),
 		stopCmd(),
 		initCmd(),
+		hbaCmd(),
+		auditCmd(),
+		logsCmd(),
+		hostsCmd(),
+		checkCmd(),
+		clusterCmd(),
 	)
 
 	return root
@@ -54,21 +69,72 @@ func RootCommand() *cobra.Command {
 // Public, so it can be mocked out in testing
 func InitializeCommand(cmd *cobra.Command, args []string) error {
 	// TODO: Add a new constructor to gplog to allow initializing with a custom logfile path directly
+	err := resolveClusterConfigFile(cmd)
+	if err != nil {
+		return err
+	}
+
 	Conf = &hub.Config{}
-	err := Conf.Load(ConfigFilePath)
+	err = Conf.Load(ConfigFilePath)
 	if err != nil {
 		return err
 	}
 	hubLogDir = Conf.LogDir
 
+	err = selectPlatform(utils.ServiceOptions{
+		Manager:       Conf.ServiceManager,
+		GpHome:        Conf.GpHome,
+		LogDir:        Conf.LogDir,
+		SystemService: Conf.SystemService,
+		ServiceLog:    Conf.ServiceLog,
+		ConfigFile:    ConfigFilePath,
+	})
+	if err != nil {
+		return err
+	}
+
 	err = InitializeLogger(cmd, args)
 	if err != nil {
 		return err
 	}
 
+	// The hub and agents set up their own tracing when they start
+	if !cmd.Hidden {
+		err = startCommandSpan(cmd)
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
+func startCommandSpan(cmd *cobra.Command) error {
+	shutdown, err := tracing.Setup(Conf.Tracing, tracing.ServiceCli, Conf.LogDir)
+	if err != nil {
+		return err
+	}
+
+	ctx, span := tracing.StartSpan(context.Background(), cmd.CommandPath())
+	CommandContext = ctx
+	gplog.Verbose("Trace ID of the command: %s", tracing.TraceID(ctx))
+
+	finishTracing = func(err error) {
+		tracing.EndSpan(span, err)
+		shutdown()
+	}
+
+	return nil
+}
+
+// FinishCommand ends the span of the command with its outcome and exports the
+// pending spans. It must be called once the command has run.
+func FinishCommand(err error) {
+	finishTracing(err)
+	finishTracing = func(err error) {}
+	CommandContext = context.Background()
+}
+
 func InitializeLogger(cmd *cobra.Command, args []string) error {
 	// CommandPath lists the names of the called command and all of its parent commands, so this
 	// turns e.g. "gp stop hub" into "gp_stop_hub" to generate a unique log file name for each command.
@@ -88,7 +154,7 @@ func ConnectToHubFunc(conf *hub.Config) (idl.HubClient, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
 	defer cancel()
 
-	credentials, err := conf.Credentials.LoadClientCredentials()
+	credentials, err := conf.Credentials.LoadClientCredentials(utils.RoleCli)
 	if err != nil {
 		return nil, err
 	}
@@ -99,9 +165,11 @@ func ConnectToHubFunc(conf *hub.Config) (idl.HubClient, error) {
 		grpc.WithBlock(),
 		grpc.FailOnNonTempDialError(true),
 		grpc.WithReturnConnectionError(),
+		grpc.WithUnaryInterceptor(tracing.UnaryClientInterceptor),
+		grpc.WithStreamInterceptor(tracing.StreamClientInterceptor),
 	)
 	if err != nil {
-		return nil, fmt.Errorf("could not connect to hub on port %d: %w", conf.Port, err)
+		return nil, fmt.Errorf("could not connect to hub on port %d: %w", conf.Port, utils.FormatGrpcError(err))
 	}
 
 	return idl.NewHubClient(conn), nil
@@ -3,6 +3,7 @@ package cli_test
 import (
 	"context"
 	"errors"
+	"os"
 	"strings"
 	"testing"
 
@@ -32,6 +33,7 @@ func teardownTest() {
 }
 
 func resetCLIVars() {
+	cli.ReadFile = os.ReadFile
 	cli.DialContextFunc = grpc.DialContext
 	cli.ConnectToHub = cli.ConnectToHubFunc
 	cli.StartHubService = cli.StartHubServiceFunc
@@ -50,6 +52,9 @@ func resetCLIVars() {
 	cli.SetDefaultLocale = cli.SetDefaultLocaleFn
 	cli.ParseStreamResponse = cli.ParseStreamResponseFn
 	cli.IsGpServicesEnabled = cli.IsGpServicesEnabledFn
+	cli.GenerateCertificates = cli.GenerateCertificatesFn
+	cli.RotateCertificates = cli.RotateCertificatesFn
+	cli.PromptSuPassword = cli.PromptSuPasswordFn
 }
 
 func funcNilError() func() error {
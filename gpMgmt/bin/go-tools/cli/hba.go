@@ -0,0 +1,323 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/greenplum-db/gpdb/gp/idl"
+	"github.com/greenplum-db/gpdb/gp/utils"
+	"github.com/greenplum-db/gpdb/gp/utils/postgres"
+	"github.com/spf13/cobra"
+)
+
+var (
+	hbaCoordinatorDataDir string
+	hbaCoordinator        bool
+	hbaStandby            bool
+	hbaSegments           bool
+	hbaBefore             string
+	hbaAfter              string
+	hbaNoReload           bool
+	hbaExpectedFile       string
+)
+
+func hbaCmd() *cobra.Command {
+	hbaCmd := &cobra.Command{
+		Use:   "hba",
+		Short: "Manage the pg_hba.conf files of the cluster",
+	}
+
+	hbaCmd.PersistentFlags().StringVar(&hbaCoordinatorDataDir, "coordinator-data-directory", os.Getenv("COORDINATOR_DATA_DIRECTORY"), "Data directory of the coordinator (default $COORDINATOR_DATA_DIRECTORY)")
+	hbaCmd.PersistentFlags().BoolVar(&hbaCoordinator, "coordinator", false, "Target the coordinator")
+	hbaCmd.PersistentFlags().BoolVar(&hbaStandby, "standby", false, "Target the standby coordinator")
+	hbaCmd.PersistentFlags().BoolVar(&hbaSegments, "segments", false, "Target the primary and mirror segments")
+
+	hbaCmd.AddCommand(
+		hbaListCmd(),
+		hbaAddCmd(),
+		hbaRemoveCmd(),
+		hbaCheckCmd(),
+	)
+
+	return hbaCmd
+}
+
+func hbaListCmd() *cobra.Command {
+	hbaListCmd := &cobra.Command{
+		Use:     "list",
+		Short:   "List the pg_hba.conf rules of the cluster",
+		Args:    cobra.NoArgs,
+		PreRunE: InitializeCommand,
+		RunE:    RunHbaList,
+	}
+
+	return hbaListCmd
+}
+
+func hbaAddCmd() *cobra.Command {
+	hbaAddCmd := &cobra.Command{
+		Use:     "add <rule>...",
+		Short:   "Add rules to the pg_hba.conf files of the cluster and reload the configuration",
+		Example: `gp hba add "host all all 10.0.0.0/8 scram-sha-256" --before "host all all 0.0.0.0/0 reject"`,
+		Args:    cobra.MinimumNArgs(1),
+		PreRunE: InitializeCommand,
+		RunE:    RunHbaAdd,
+	}
+
+	hbaAddCmd.Flags().StringVar(&hbaBefore, "before", "", "Add the rules before the given existing rule")
+	hbaAddCmd.Flags().StringVar(&hbaAfter, "after", "", "Add the rules after the given existing rule")
+	hbaAddCmd.Flags().BoolVar(&hbaNoReload, "no-reload", false, "Do not reload the configuration after modifying the files")
+	hbaAddCmd.MarkFlagsMutuallyExclusive("before", "after")
+
+	return hbaAddCmd
+}
+
+func hbaRemoveCmd() *cobra.Command {
+	hbaRemoveCmd := &cobra.Command{
+		Use:     "remove <rule>...",
+		Short:   "Remove rules from the pg_hba.conf files of the cluster and reload the configuration",
+		Args:    cobra.MinimumNArgs(1),
+		PreRunE: InitializeCommand,
+		RunE:    RunHbaRemove,
+	}
+
+	hbaRemoveCmd.Flags().BoolVar(&hbaNoReload, "no-reload", false, "Do not reload the configuration after modifying the files")
+
+	return hbaRemoveCmd
+}
+
+func hbaCheckCmd() *cobra.Command {
+	hbaCheckCmd := &cobra.Command{
+		Use:     "check",
+		Short:   "Check the pg_hba.conf files of the cluster for rules which differ from the expected ones",
+		Args:    cobra.NoArgs,
+		PreRunE: InitializeCommand,
+		RunE:    RunHbaCheck,
+	}
+
+	hbaCheckCmd.Flags().StringVar(&hbaExpectedFile, "expected-file", "", "File containing the expected pg_hba.conf rules")
+	_ = hbaCheckCmd.MarkFlagRequired("expected-file")
+
+	return hbaCheckCmd
+}
+
+func RunHbaList(cmd *cobra.Command, args []string) error {
+	client, err := ConnectToHub(Conf)
+	if err != nil {
+		return err
+	}
+
+	reply, err := client.ListPgHba(CommandContext, &idl.ListPgHbaRequest{
+		CoordinatorDataDir: hbaCoordinatorDataDir,
+		Targets:            getHbaTargets(),
+	})
+	if err != nil {
+		return utils.FormatGrpcError(err)
+	}
+
+	return PrintPgHbaConfs(os.Stdout, reply.Confs)
+}
+
+func RunHbaAdd(cmd *cobra.Command, args []string) error {
+	err := ValidateHbaRules(append([]string{hbaBefore, hbaAfter}, args...)...)
+	if err != nil {
+		return err
+	}
+
+	req := &idl.ModifyPgHbaRequest{
+		CoordinatorDataDir: hbaCoordinatorDataDir,
+		Targets:            getHbaTargets(),
+		AddEntries:         args,
+		NoReload:           hbaNoReload,
+	}
+	if hbaBefore != "" {
+		req.Position = idl.HbaPosition_BEFORE
+		req.Reference = hbaBefore
+	} else if hbaAfter != "" {
+		req.Position = idl.HbaPosition_AFTER
+		req.Reference = hbaAfter
+	}
+
+	return modifyPgHba(req)
+}
+
+func RunHbaRemove(cmd *cobra.Command, args []string) error {
+	err := ValidateHbaRules(args...)
+	if err != nil {
+		return err
+	}
+
+	return modifyPgHba(&idl.ModifyPgHbaRequest{
+		CoordinatorDataDir: hbaCoordinatorDataDir,
+		Targets:            getHbaTargets(),
+		RemoveEntries:      args,
+		NoReload:           hbaNoReload,
+	})
+}
+
+func RunHbaCheck(cmd *cobra.Command, args []string) error {
+	content, err := ReadFile(hbaExpectedFile)
+	if err != nil {
+		return err
+	}
+
+	expected := postgres.ParseHbaFile(string(content))
+	err = expected.Validate()
+	if err != nil {
+		return fmt.Errorf("invalid rules in %s: %w", hbaExpectedFile, err)
+	}
+
+	client, err := ConnectToHub(Conf)
+	if err != nil {
+		return err
+	}
+
+	reply, err := client.CheckPgHba(CommandContext, &idl.CheckPgHbaRequest{
+		CoordinatorDataDir: hbaCoordinatorDataDir,
+		Targets:            getHbaTargets(),
+		ExpectedEntries:    expected.Lines(),
+	})
+	if err != nil {
+		return utils.FormatGrpcError(err)
+	}
+
+	return PrintPgHbaDrifts(os.Stdout, reply.Drifts)
+}
+
+func modifyPgHba(req *idl.ModifyPgHbaRequest) error {
+	client, err := ConnectToHub(Conf)
+	if err != nil {
+		return err
+	}
+
+	reply, err := client.ModifyPgHba(CommandContext, req)
+	if err != nil {
+		return utils.FormatGrpcError(err)
+	}
+
+	return PrintPgHbaResults(os.Stdout, reply.Results)
+}
+
+// ValidateHbaRules checks that the given rules are well formed before they are
+// sent to the segments. Empty rules are ignored.
+func ValidateHbaRules(rules ...string) error {
+	for _, rule := range rules {
+		if rule == "" {
+			continue
+		}
+
+		entry, err := postgres.ParseHbaEntry(rule)
+		if err != nil {
+			return err
+		}
+
+		if !entry.IsRule() {
+			return fmt.Errorf("invalid pg_hba.conf entry %q", rule)
+		}
+
+		err = entry.Validate()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func getHbaTargets() *idl.HbaTargets {
+	return &idl.HbaTargets{
+		Coordinator: hbaCoordinator,
+		Standby:     hbaStandby,
+		Segments:    hbaSegments,
+	}
+}
+
+// PrintPgHbaConfs prints the rules of every segment and returns an error if
+// the rules could not be listed for any of them
+func PrintPgHbaConfs(out io.Writer, confs []*idl.PgHbaConf) error {
+	var failed int
+	for _, conf := range confs {
+		fmt.Fprintln(out, formatHbaSegment(conf.Segment))
+		if conf.Error != "" {
+			failed++
+			fmt.Fprintf(out, "\tERROR: %s\n", conf.Error)
+			continue
+		}
+
+		for _, entry := range conf.Entries {
+			fmt.Fprintf(out, "\t%s\n", entry)
+		}
+	}
+
+	return failedHbaSegmentsError("list the pg_hba.conf rules", failed)
+}
+
+// PrintPgHbaResults prints the outcome of the modification on every segment
+// and returns an error if it failed for any of them
+func PrintPgHbaResults(out io.Writer, results []*idl.PgHbaResult) error {
+	var failed int
+	for _, result := range results {
+		if result.Error != "" {
+			failed++
+			fmt.Fprintf(out, "%s: ERROR: %s\n", formatHbaSegment(result.Segment), result.Error)
+			continue
+		}
+
+		if result.BackupFile == "" {
+			fmt.Fprintf(out, "%s: unchanged\n", formatHbaSegment(result.Segment))
+			continue
+		}
+
+		fmt.Fprintf(out, "%s: updated, backup at %s\n", formatHbaSegment(result.Segment), result.BackupFile)
+	}
+
+	return failedHbaSegmentsError("update the pg_hba.conf", failed)
+}
+
+// PrintPgHbaDrifts prints the rules which differ from the expected ones and
+// returns an error if any drift was found or the check failed
+func PrintPgHbaDrifts(out io.Writer, drifts []*idl.PgHbaDrift) error {
+	var failed, drifted int
+	for _, drift := range drifts {
+		switch {
+		case drift.Error != "":
+			failed++
+			fmt.Fprintf(out, "%s: ERROR: %s\n", formatHbaSegment(drift.Segment), drift.Error)
+		case len(drift.Missing) == 0 && len(drift.Unexpected) == 0 && len(drift.Misordered) == 0:
+			fmt.Fprintf(out, "%s: OK\n", formatHbaSegment(drift.Segment))
+		default:
+			drifted++
+			fmt.Fprintf(out, "%s: DRIFT\n", formatHbaSegment(drift.Segment))
+			for _, entry := range drift.Missing {
+				fmt.Fprintf(out, "\t- %s\n", entry)
+			}
+			for _, entry := range drift.Unexpected {
+				fmt.Fprintf(out, "\t+ %s\n", entry)
+			}
+			for _, entry := range drift.Misordered {
+				fmt.Fprintf(out, "\t~ %s\n", entry)
+			}
+		}
+	}
+
+	var err error
+	if drifted > 0 {
+		err = fmt.Errorf("found pg_hba.conf drift on %d segment(s)", drifted)
+	}
+
+	return errors.Join(err, failedHbaSegmentsError("check the pg_hba.conf", failed))
+}
+
+func formatHbaSegment(seg *idl.Segment) string {
+	return fmt.Sprintf("content %d, dbid %d on %s:%s", seg.GetContentid(), seg.GetDbid(), seg.GetHostName(), seg.GetDataDirectory())
+}
+
+func failedHbaSegmentsError(action string, failed int) error {
+	if failed == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("failed to %s on %d segment(s)", action, failed)
+}
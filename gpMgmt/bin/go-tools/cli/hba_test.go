@@ -0,0 +1,226 @@
+package cli_test
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/greenplum-db/gpdb/gp/cli"
+	"github.com/greenplum-db/gpdb/gp/hub"
+	"github.com/greenplum-db/gpdb/gp/idl"
+	"github.com/greenplum-db/gpdb/gp/idl/mock_idl"
+)
+
+var hbaSegment = &idl.Segment{HostName: "sdw1", DataDirectory: "/data/primary/gpseg0", Contentid: 0, Dbid: 2}
+
+func TestValidateHbaRules(t *testing.T) {
+	t.Run("succeeds for valid rules and ignores empty ones", func(t *testing.T) {
+		err := cli.ValidateHbaRules("host all all 10.0.0.0/8 md5", "", "local all gpadmin ident")
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+	})
+
+	cases := []struct {
+		rule        string
+		expectedErr string
+	}{
+		{
+			rule:        "host all all",
+			expectedErr: `invalid pg_hba.conf entry "host all all": expected at least 5 fields, got 3`,
+		},
+		{
+			rule:        "# comment",
+			expectedErr: `invalid pg_hba.conf entry "# comment"`,
+		},
+		{
+			rule:        "host all all 10.0.0.0/8 unknown",
+			expectedErr: `invalid authentication method "unknown"`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run("errors out for invalid rule "+tc.rule, func(t *testing.T) {
+			err := cli.ValidateHbaRules(tc.rule)
+			if err == nil || !strings.HasPrefix(err.Error(), tc.expectedErr) {
+				t.Fatalf("got %v, want prefix %s", err, tc.expectedErr)
+			}
+		})
+	}
+}
+
+func TestRunHbaAdd(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	t.Run("sends the rules to the hub", func(t *testing.T) {
+		defer resetCLIVars()
+		cli.ConnectToHub = func(conf *hub.Config) (idl.HubClient, error) {
+			hubClient := mock_idl.NewMockHubClient(ctrl)
+			hubClient.EXPECT().ModifyPgHba(gomock.Any(), &idl.ModifyPgHbaRequest{
+				Targets:    &idl.HbaTargets{},
+				AddEntries: []string{"host all all 10.0.0.0/8 md5"},
+			}).Return(&idl.ModifyPgHbaReply{
+				Results: []*idl.PgHbaResult{{Segment: hbaSegment, BackupFile: "backup"}},
+			}, nil)
+			return hubClient, nil
+		}
+
+		err := cli.RunHbaAdd(nil, []string{"host all all 10.0.0.0/8 md5"})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+	})
+
+	t.Run("does not contact the hub when the rules are invalid", func(t *testing.T) {
+		defer resetCLIVars()
+		cli.ConnectToHub = func(conf *hub.Config) (idl.HubClient, error) {
+			t.Fatalf("unexpected call to the hub")
+			return nil, nil
+		}
+
+		err := cli.RunHbaAdd(nil, []string{"host all all"})
+		if err == nil {
+			t.Fatalf("expected error")
+		}
+	})
+
+	t.Run("returns error when the hub fails", func(t *testing.T) {
+		defer resetCLIVars()
+		expectedStr := "TEST Error modifying pg_hba.conf"
+		cli.ConnectToHub = func(conf *hub.Config) (idl.HubClient, error) {
+			hubClient := mock_idl.NewMockHubClient(ctrl)
+			hubClient.EXPECT().ModifyPgHba(gomock.Any(), gomock.Any()).Return(nil, errors.New(expectedStr))
+			return hubClient, nil
+		}
+
+		err := cli.RunHbaAdd(nil, []string{"host all all 10.0.0.0/8 md5"})
+		if err == nil || !strings.Contains(err.Error(), expectedStr) {
+			t.Fatalf("got %v, want %v", err, expectedStr)
+		}
+	})
+}
+
+func TestRunHbaCheck(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	t.Run("sends the expected rules to the hub", func(t *testing.T) {
+		defer resetCLIVars()
+		cli.ReadFile = func(name string) ([]byte, error) {
+			return []byte("# expected\nhost all all 10.0.0.0/8 md5\n"), nil
+		}
+		cli.ConnectToHub = func(conf *hub.Config) (idl.HubClient, error) {
+			hubClient := mock_idl.NewMockHubClient(ctrl)
+			hubClient.EXPECT().CheckPgHba(gomock.Any(), &idl.CheckPgHbaRequest{
+				Targets:         &idl.HbaTargets{},
+				ExpectedEntries: []string{"# expected", "host\tall\tall\t10.0.0.0/8\tmd5"},
+			}).Return(&idl.CheckPgHbaReply{
+				Drifts: []*idl.PgHbaDrift{{Segment: hbaSegment}},
+			}, nil)
+			return hubClient, nil
+		}
+
+		err := cli.RunHbaCheck(nil, nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+	})
+
+	t.Run("returns error when the expected file is invalid", func(t *testing.T) {
+		defer resetCLIVars()
+		cli.ReadFile = func(name string) ([]byte, error) {
+			return []byte("host all all 10.0.0.0/8 unknown\n"), nil
+		}
+
+		err := cli.RunHbaCheck(nil, nil)
+		expectedStr := `line 1: invalid authentication method "unknown"`
+		if err == nil || !strings.Contains(err.Error(), expectedStr) {
+			t.Fatalf("got %v, want %v", err, expectedStr)
+		}
+	})
+
+	t.Run("returns error when not able to read the expected file", func(t *testing.T) {
+		defer resetCLIVars()
+		expectedErr := errors.New("error")
+		cli.ReadFile = func(name string) ([]byte, error) {
+			return nil, expectedErr
+		}
+
+		err := cli.RunHbaCheck(nil, nil)
+		if !errors.Is(err, expectedErr) {
+			t.Fatalf("got %#v, want %#v", err, expectedErr)
+		}
+	})
+}
+
+func TestPrintPgHba(t *testing.T) {
+	t.Run("prints the rules of the segments", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := cli.PrintPgHbaConfs(&buf, []*idl.PgHbaConf{{Segment: hbaSegment, Entries: []string{"host\tall\tall\tsdw1\ttrust"}}})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		expected := "content 0, dbid 2 on sdw1:/data/primary/gpseg0\n\thost\tall\tall\tsdw1\ttrust\n"
+		if buf.String() != expected {
+			t.Fatalf("got %q, want %q", buf.String(), expected)
+		}
+	})
+
+	t.Run("returns error when the segments failed", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := cli.PrintPgHbaResults(&buf, []*idl.PgHbaResult{
+			{Segment: hbaSegment, Error: "entry not found"},
+			{Segment: hbaSegment, BackupFile: "backup"},
+			{Segment: hbaSegment},
+		})
+
+		expectedErr := "failed to update the pg_hba.conf on 1 segment(s)"
+		if err == nil || err.Error() != expectedErr {
+			t.Fatalf("got %v, want %s", err, expectedErr)
+		}
+
+		expected := "content 0, dbid 2 on sdw1:/data/primary/gpseg0: ERROR: entry not found\ncontent 0, dbid 2 on sdw1:/data/primary/gpseg0: updated, backup at backup\ncontent 0, dbid 2 on sdw1:/data/primary/gpseg0: unchanged\n"
+		if buf.String() != expected {
+			t.Fatalf("got %q, want %q", buf.String(), expected)
+		}
+	})
+
+	t.Run("reports the drift", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := cli.PrintPgHbaDrifts(&buf, []*idl.PgHbaDrift{
+			{Segment: hbaSegment, Missing: []string{"host\tall\tall\tsdw1\tmd5"}, Unexpected: []string{"host\tall\tall\tsdw1\ttrust"}},
+		})
+
+		expectedErr := "found pg_hba.conf drift on 1 segment(s)"
+		if err == nil || err.Error() != expectedErr {
+			t.Fatalf("got %v, want %s", err, expectedErr)
+		}
+
+		expected := "content 0, dbid 2 on sdw1:/data/primary/gpseg0: DRIFT\n\t- host\tall\tall\tsdw1\tmd5\n\t+ host\tall\tall\tsdw1\ttrust\n"
+		if buf.String() != expected {
+			t.Fatalf("got %q, want %q", buf.String(), expected)
+		}
+	})
+
+	t.Run("reports the misordered rules as a drift", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := cli.PrintPgHbaDrifts(&buf, []*idl.PgHbaDrift{
+			{Segment: hbaSegment, Misordered: []string{"host\tall\tall\t0.0.0.0/0\tmd5"}},
+		})
+
+		expectedErr := "found pg_hba.conf drift on 1 segment(s)"
+		if err == nil || err.Error() != expectedErr {
+			t.Fatalf("got %v, want %s", err, expectedErr)
+		}
+
+		expected := "content 0, dbid 2 on sdw1:/data/primary/gpseg0: DRIFT\n\t~ host\tall\tall\t0.0.0.0/0\tmd5\n"
+		if buf.String() != expected {
+			t.Fatalf("got %q, want %q", buf.String(), expected)
+		}
+	})
+}
@@ -0,0 +1,217 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/greenplum-db/gpdb/gp/idl"
+	"github.com/greenplum-db/gpdb/gp/utils"
+)
+
+var (
+	hostsInfoHosts []string
+)
+
+func hostsCmd() *cobra.Command {
+	hostsCmd := &cobra.Command{
+		Use:   "hosts",
+		Short: "Inspect the hosts of the cluster",
+	}
+
+	hostsCmd.AddCommand(hostsInfoCmd())
+
+	return hostsCmd
+}
+
+func hostsInfoCmd() *cobra.Command {
+	hostsInfoCmd := &cobra.Command{
+		Use:   "info",
+		Short: "Show the OS, CPUs, memory, disks and network interfaces of the hosts",
+		Long: `Show the OS, CPUs, memory, disks and network interfaces of the hosts.
+
+The facts are read by the agents on all the configured hosts, and the facts
+which differ between hosts are reported, since the segments are expected to
+run on identical hosts.`,
+		Args:    cobra.NoArgs,
+		PreRunE: InitializeCommand,
+		RunE:    RunHostsInfo,
+	}
+
+	hostsInfoCmd.Flags().StringSliceVar(&hostsInfoHosts, "hosts", nil, "Only show the given hosts")
+
+	return hostsInfoCmd
+}
+
+func RunHostsInfo(cmd *cobra.Command, args []string) error {
+	client, err := ConnectToHub(Conf)
+	if err != nil {
+		return err
+	}
+
+	reply, err := client.GetHostsInfo(CommandContext, &idl.GetHostsInfoRequest{Hosts: hostsInfoHosts})
+	if err != nil {
+		return utils.FormatGrpcError(err)
+	}
+
+	return PrintHostsInfo(os.Stdout, reply.Hosts)
+}
+
+/*
+PrintHostsInfo prints the facts of the hosts as tables followed by the facts
+which differ between hosts, and returns an error if the facts of any host could
+not be read.
+*/
+func PrintHostsInfo(out io.Writer, hosts []*idl.HostInfoResult) error {
+	w := new(tabwriter.Writer)
+	w.Init(out, 0, 8, 2, '\t', 0)
+
+	var failed []string
+	fmt.Fprintln(w, "HOST\tOS\tKERNEL\tARCH\tCPUS\tCPU MODEL\tMEMORY\tSWAP\tGP VERSION")
+	for _, host := range hosts {
+		if host.Error != "" {
+			failed = append(failed, host.Host)
+			continue
+		}
+
+		info := host.Info
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%d\t%s\t%s\t%s\t%s\n", host.Host, orDash(info.Os), orDash(info.Kernel), info.Arch,
+			info.CpuCount, orDash(info.CpuModel), formatBytes(info.MemoryBytes), formatBytes(info.SwapBytes), orDash(info.GpVersion))
+	}
+	w.Flush()
+
+	fmt.Fprintln(out)
+	fmt.Fprintln(w, "HOST\tDEVICE\tMOUNT POINT\tSIZE\tFREE")
+	for _, host := range hosts {
+		for _, disk := range host.Info.GetDisks() {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", host.Host, disk.Device, disk.MountPoint, formatBytes(disk.TotalBytes), formatBytes(disk.FreeBytes))
+		}
+	}
+	w.Flush()
+
+	fmt.Fprintln(out)
+	fmt.Fprintln(w, "HOST\tINTERFACE\tADDRESSES\tMTU\tSPEED")
+	for _, host := range hosts {
+		for _, iface := range host.Info.GetInterfaces() {
+			speed := "-"
+			if iface.SpeedMbps > 0 {
+				speed = fmt.Sprintf("%dMb/s", iface.SpeedMbps)
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%s\n", host.Host, iface.Name, orDash(strings.Join(iface.Addrs, ",")), iface.Mtu, speed)
+		}
+	}
+	w.Flush()
+
+	var warnings []string
+	for _, host := range hosts {
+		for _, err := range host.Info.GetErrors() {
+			warnings = append(warnings, fmt.Sprintf("could not read some facts of host %s: %s", host.Host, err))
+		}
+		if host.Error != "" {
+			warnings = append(warnings, fmt.Sprintf("could not read the facts of host %s: %s", host.Host, host.Error))
+		}
+	}
+	warnings = append(warnings, HostsInfoDifferences(hosts)...)
+	if len(warnings) > 0 {
+		fmt.Fprintln(out)
+	}
+	for _, warning := range warnings {
+		fmt.Fprintf(out, "WARNING: %s\n", warning)
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("failed to read the facts of %d host(s): %s", len(failed), strings.Join(failed, ","))
+	}
+
+	return nil
+}
+
+// Facts of the hosts compared by HostsInfoDifferences
+var hostFacts = []struct {
+	name  string
+	value func(info *idl.HostInfo) string
+}{
+	{"OS", func(info *idl.HostInfo) string { return info.Os }},
+	{"kernel", func(info *idl.HostInfo) string { return info.Kernel }},
+	{"architecture", func(info *idl.HostInfo) string { return info.Arch }},
+	{"number of CPUs", func(info *idl.HostInfo) string { return fmt.Sprint(info.CpuCount) }},
+	{"CPU model", func(info *idl.HostInfo) string { return info.CpuModel }},
+	{"memory", func(info *idl.HostInfo) string { return formatBytes(info.MemoryBytes) }},
+	{"swap", func(info *idl.HostInfo) string { return formatBytes(info.SwapBytes) }},
+	{"Greenplum version", func(info *idl.HostInfo) string { return info.GpVersion }},
+	{"GPHOME", func(info *idl.HostInfo) string { return info.GpHome }},
+	{"largest MTU", func(info *idl.HostInfo) string {
+		var mtu int32
+		for _, iface := range info.Interfaces {
+			if iface.Mtu > mtu {
+				mtu = iface.Mtu
+			}
+		}
+		return fmt.Sprint(mtu)
+	}},
+}
+
+/*
+HostsInfoDifferences returns a description of each fact which is not the same
+on all hosts, listing the hosts having each value, e.g.
+"the hosts differ in memory: 62.8GiB on sdw1,sdw2; 31.4GiB on sdw3".
+The hosts whose facts could not be read are ignored.
+*/
+func HostsInfoDifferences(hosts []*idl.HostInfoResult) []string {
+	var differences []string
+	for _, fact := range hostFacts {
+		hostsByValue := make(map[string][]string)
+		var values []string
+		for _, host := range hosts {
+			if host.Info == nil {
+				continue
+			}
+
+			value := fact.value(host.Info)
+			if _, ok := hostsByValue[value]; !ok {
+				values = append(values, value)
+			}
+			hostsByValue[value] = append(hostsByValue[value], host.Host)
+		}
+
+		if len(values) < 2 {
+			continue
+		}
+
+		// list the most common value first
+		sort.SliceStable(values, func(i, j int) bool {
+			return len(hostsByValue[values[i]]) > len(hostsByValue[values[j]])
+		})
+
+		var parts []string
+		for _, value := range values {
+			parts = append(parts, fmt.Sprintf("%s on %s", orDash(value), strings.Join(hostsByValue[value], ",")))
+		}
+		differences = append(differences, fmt.Sprintf("the hosts differ in %s: %s", fact.name, strings.Join(parts, "; ")))
+	}
+
+	return differences
+}
+
+// formatBytes formats a size in bytes with a binary unit, e.g. 1.5GiB
+func formatBytes(size uint64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%dB", size)
+	}
+
+	value := float64(size) / unit
+	units := []string{"KiB", "MiB", "GiB", "TiB", "PiB"}
+	i := 0
+	for value >= unit && i < len(units)-1 {
+		value /= unit
+		i++
+	}
+
+	return fmt.Sprintf("%.1f%s", value, units[i])
+}
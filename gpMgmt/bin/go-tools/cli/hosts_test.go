@@ -0,0 +1,73 @@
+package cli_test
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/greenplum-db/gpdb/gp/cli"
+	"github.com/greenplum-db/gpdb/gp/idl"
+)
+
+func hostInfo(memory uint64, cpus int32, mtu int32) *idl.HostInfo {
+	return &idl.HostInfo{
+		Os:          "Rocky Linux 9.2",
+		Kernel:      "5.14.0",
+		Arch:        "amd64",
+		CpuCount:    cpus,
+		CpuModel:    "Xeon",
+		MemoryBytes: memory,
+		Disks:       []*idl.HostDisk{{Device: "/dev/sdb1", MountPoint: "/data", TotalBytes: 1 << 40, FreeBytes: 1 << 39}},
+		Interfaces:  []*idl.HostInterface{{Name: "eth0", Addrs: []string{"10.0.0.1/24"}, Mtu: mtu, SpeedMbps: 10000}},
+		GpVersion:   "7.0.0",
+		GpHome:      "/usr/local/gpdb",
+	}
+}
+
+func TestHostsInfoDifferences(t *testing.T) {
+	hosts := []*idl.HostInfoResult{
+		{Host: "sdw1", Info: hostInfo(64<<30, 16, 9000)},
+		{Host: "sdw2", Info: hostInfo(32<<30, 16, 9000)},
+		{Host: "sdw3", Info: hostInfo(64<<30, 16, 1500)},
+		{Host: "sdw4", Error: "error"},
+	}
+
+	expected := []string{
+		"the hosts differ in memory: 64.0GiB on sdw1,sdw3; 32.0GiB on sdw2",
+		"the hosts differ in largest MTU: 9000 on sdw1,sdw2; 1500 on sdw3",
+	}
+	if differences := cli.HostsInfoDifferences(hosts); !reflect.DeepEqual(differences, expected) {
+		t.Fatalf("got %q, want %q", differences, expected)
+	}
+
+	if differences := cli.HostsInfoDifferences(hosts[:1]); len(differences) != 0 {
+		t.Fatalf("unexpected differences %q", differences)
+	}
+}
+
+func TestPrintHostsInfo(t *testing.T) {
+	var out bytes.Buffer
+	err := cli.PrintHostsInfo(&out, []*idl.HostInfoResult{
+		{Host: "sdw1", Info: hostInfo(64<<30, 16, 9000)},
+		{Host: "sdw2", Info: hostInfo(64<<30, 8, 9000)},
+		{Host: "sdw3", Error: "connection refused"},
+	})
+
+	expected := "failed to read the facts of 1 host(s): sdw3"
+	if err == nil || err.Error() != expected {
+		t.Fatalf("got %v, want %s", err, expected)
+	}
+
+	for _, line := range []string{
+		"sdw1\tRocky Linux 9.2\t\t5.14.0\tamd64\t16\tXeon\t\t64.0GiB\t\t0B\t7.0.0\n",
+		"sdw2\t/dev/sdb1\t/data\t\t1.0TiB\t512.0GiB\n",
+		"sdw1\teth0\t\t10.0.0.1/24\t9000\t10000Mb/s\n",
+		"WARNING: could not read the facts of host sdw3: connection refused",
+		"WARNING: the hosts differ in number of CPUs: 16 on sdw1; 8 on sdw2",
+	} {
+		if !strings.Contains(out.String(), line) {
+			t.Fatalf("expected %q in the output, got\n%s", line, out.String())
+		}
+	}
+}
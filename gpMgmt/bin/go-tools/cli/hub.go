@@ -3,40 +3,80 @@ package cli
 import (
 	"errors"
 	"fmt"
+	"io"
 	"os"
+	"os/user"
 	"path/filepath"
+	"runtime"
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
 	"github.com/greenplum-db/gp-common-go-libs/gplog"
+	"github.com/greenplum-db/gpdb/gp/agent"
 	"github.com/greenplum-db/gpdb/gp/constants"
 	"github.com/greenplum-db/gpdb/gp/hub"
+	"github.com/greenplum-db/gpdb/gp/idl"
 	"github.com/greenplum-db/gpdb/gp/utils"
 	"github.com/greenplum-db/gpdb/gp/utils/greenplum"
+	"github.com/greenplum-db/gpdb/gp/utils/metrics"
+	"github.com/greenplum-db/gpdb/gp/utils/tracing"
 )
 
 var (
 	Platform          = utils.GetPlatform()
 	DefaultServiceDir = Platform.GetDefaultServiceDir()
 	agentPort         int
+	agentMetricsPort  int
+	authzPolicyPath   string
+	batchSize         int
 	caCertPath        string
 	caKeyPath         string
+	certDir           string
+	certValidityDays  int
+	daemonize         bool
+	generateCerts     bool
 	gpHome            string
 	hubLogDir         string
+	printServiceFiles bool
+	hubMetricsPort    int
 	hubPort           int
 	hostnames         []string
 	hostfilePath      string
+	metricsBindAddr   string
+	parallel          int
 	serverCertPath    string
 	serverKeyPath     string
 	serviceDir        string // Provide the service file's directory and name separately so users can name different files for different clusters
+	serviceGroup      string
+	serviceLogOutput  string
+	serviceLogSizeMB  int
+	serviceLogHours   int
+	serviceLogFiles   int
+	serviceLogAgeDays int
+	serviceManager    string
 	serviceName       string
 	serviceUser       string
+	systemService     bool
+	tracingEndpoint   string
+	tracingExporter   string
+	tracingInsecure   bool
+	uninstall         bool
 
-	GetUlimitSsh = GetUlimitSshFn
+	GetUlimitSsh    = GetUlimitSshFn
+	LookupUserGroup = LookupUserGroupFn
+
+	// Required unless the certificates are generated by gp configure
+	certificateFlags = []string{
+		"ca-certificate",
+		"ca-key",
+		"server-certificate",
+		"server-key",
+	}
 )
 
 func hubCmd() *cobra.Command {
@@ -49,11 +89,35 @@ func hubCmd() *cobra.Command {
 		RunE:    RunHub,
 	}
 
+	hubCmd.Flags().BoolVar(&daemonize, "daemonize", false, `Run the hub in the background, with the process service manager`)
+
 	return hubCmd
 }
 
 func RunHub(cmd *cobra.Command, args []string) (err error) {
+	if daemonize {
+		return daemonizeService("hub")
+	}
+
+	stopLogRotation, err := utils.RedirectServiceOutput(Conf.ServiceLog, Conf.LogDir, Conf.ServiceName, "hub")
+	if err != nil {
+		return err
+	}
+	defer stopLogRotation()
+
+	shutdownTracing, err := tracing.Setup(Conf.Tracing, tracing.ServiceHub, Conf.LogDir)
+	if err != nil {
+		return err
+	}
+	defer shutdownTracing()
+
 	h := hub.New(Conf, nil)
+	release, err := trackProcess("hub", h.Shutdown)
+	if err != nil {
+		return err
+	}
+	defer release()
+
 	err = h.Start()
 	if err != nil {
 		return err
@@ -62,6 +126,71 @@ func RunHub(cmd *cobra.Command, args []string) (err error) {
 	return nil
 }
 
+// selectPlatform runs the hub and agents with the given service manager
+func selectPlatform(opts utils.ServiceOptions) error {
+	if opts.ConfigFile != "" {
+		configFile, err := filepath.Abs(opts.ConfigFile)
+		if err != nil {
+			return err
+		}
+		opts.ConfigFile = configFile
+	}
+
+	p, err := utils.NewServicePlatform(runtime.GOOS, opts)
+	if err != nil {
+		return err
+	}
+
+	utils.SetPlatform(p)
+	Platform = p
+	hub.SetPlatform(p)
+	agent.SetPlatform(p)
+
+	return nil
+}
+
+// daemonizeService starts the hub or agent again in the background, without
+// the --daemonize flag
+func daemonizeService(process string) error {
+	processPlatform, ok := Platform.(utils.ProcessPlatform)
+	if !ok {
+		return fmt.Errorf("--daemonize is only supported with the %s service manager", constants.ServiceManagerProcess)
+	}
+
+	args := make([]string, 0, len(os.Args))
+	for _, arg := range os.Args[1:] {
+		if arg != "--daemonize" {
+			args = append(args, arg)
+		}
+	}
+
+	return processPlatform.Daemonize(fmt.Sprintf("%s_%s", Conf.ServiceName, process), args)
+}
+
+/*
+trackProcess writes the pid file of the hub or agent and shuts it down on
+SIGTERM when run with the process service manager, so that it can be stopped
+by its pid like under an external supervisor. The returned function removes
+the pid file.
+*/
+func trackProcess(process string, shutdown func()) (release func(), err error) {
+	processPlatform, ok := Platform.(utils.ProcessPlatform)
+	if !ok {
+		return func() {}, nil
+	}
+
+	removePidFile, err := processPlatform.CreatePidFile(fmt.Sprintf("%s_%s", Conf.ServiceName, process))
+	if err != nil {
+		return nil, err
+	}
+	stopNotify := utils.NotifyOnStop(shutdown)
+
+	return func() {
+		stopNotify()
+		removePidFile()
+	}, nil
+}
+
 func configureCmd() *cobra.Command {
 	configureCmd := &cobra.Command{
 		Use:     "configure",
@@ -78,33 +207,102 @@ func configureCmd() *cobra.Command {
 	configureCmd.Flags().StringVar(&hubLogDir, "log-dir", greenplum.GetDefaultHubLogDir(), `Path to gp hub log directory`)
 	configureCmd.Flags().StringVar(&serviceName, "service-name", constants.DefaultServiceName, `Name for the generated systemd service file`)
 	configureCmd.Flags().StringVar(&serviceDir, "service-dir", fmt.Sprintf(DefaultServiceDir, os.Getenv("USER")), `Path to service file directory`)
+	configureCmd.Flags().StringVar(&serviceManager, "service-manager", "", `Service manager running the hub and agents: systemd, launchd, or process to run them with pid files (default the one of the OS)`)
 	configureCmd.Flags().StringVar(&serviceUser, "service-user", os.Getenv("USER"), `User for whom to configure the service`)
+	configureCmd.Flags().BoolVar(&systemService, "system-service", false, fmt.Sprintf(`Install systemd system units run as the service user, with sudo, instead of user units (default directory %q)`, constants.SystemServiceDir))
+	configureCmd.Flags().StringVar(&serviceGroup, "service-group", "", `Group the system units run as (default the primary group of the service user)`)
+	configureCmd.Flags().StringVar(&serviceLogOutput, "service-log-output", constants.ServiceLogOutputFile, `Output of the hub and agents: file, to <log-dir>/<service-name>_<hub|agent>.log, journald or syslog`)
+	configureCmd.Flags().IntVar(&serviceLogSizeMB, "service-log-max-size", constants.DefaultServiceLogMaxSizeMB, `Size in MB at which the log files of the hub and agents are rotated`)
+	configureCmd.Flags().IntVar(&serviceLogHours, "service-log-rotate-hours", constants.DefaultServiceLogRotateHours, `Hours after which the log files of the hub and agents are rotated`)
+	configureCmd.Flags().IntVar(&serviceLogFiles, "service-log-max-files", constants.DefaultServiceLogMaxFiles, `Number of rotated log files of the hub and agents kept`)
+	configureCmd.Flags().IntVar(&serviceLogAgeDays, "service-log-max-age", constants.DefaultServiceLogMaxAgeDays, `Days after which the rotated log files of the hub and agents are removed`)
+	configureCmd.Flags().BoolVar(&printServiceFiles, "print-service-files", false, `Print the service files instead of installing them, e.g. to install them with a configuration management tool`)
 	// TLS credentials are deliberately left blank if not provided, and need to be filled in by the user
 	configureCmd.Flags().StringVar(&caCertPath, "ca-certificate", "", `Path to SSL/TLS CA certificate`)
 	configureCmd.Flags().StringVar(&caKeyPath, "ca-key", "", `Path to SSL/TLS CA private key`)
 	configureCmd.Flags().StringVar(&serverCertPath, "server-certificate", "", `Path to hub SSL/TLS server certificate`)
 	configureCmd.Flags().StringVar(&serverKeyPath, "server-key", "", `Path to hub SSL/TLS server private key`)
+	configureCmd.Flags().BoolVar(&generateCerts, "generate-certs", false, `Generate a CA and SSL/TLS certificates for all hosts instead of providing them`)
+	configureCmd.Flags().StringVar(&certDir, "certificate-dir", "", `Path to directory for the generated certificates on all hosts (default "<gphome>/certificates")`)
+	configureCmd.Flags().IntVar(&certValidityDays, "certificate-validity", constants.DefaultCertValidityDays, `Number of days the generated certificates are valid`)
+	configureCmd.Flags().IntVar(&hubMetricsPort, "hub-metrics-port", 0, `Port on which the hub serves Prometheus metrics (default not served)`)
+	configureCmd.Flags().IntVar(&agentMetricsPort, "agent-metrics-port", 0, `Port on which the agents serve Prometheus metrics (default not served)`)
+	configureCmd.Flags().StringVar(&metricsBindAddr, "metrics-bind-address", "", `Address on which the metrics are served (default all interfaces)`)
+	configureCmd.Flags().StringVar(&tracingExporter, "tracing-exporter", "", `Exporter of the traces of the CLI, hub and agents, either otlp or file (default not exported)`)
+	configureCmd.Flags().StringVar(&tracingEndpoint, "tracing-endpoint", "", `Address of the OTLP collector receiving the traces (default "localhost:4317")`)
+	configureCmd.Flags().BoolVar(&tracingInsecure, "tracing-insecure", false, `Connect to the OTLP collector without TLS`)
+	configureCmd.Flags().IntVar(&batchSize, "batch-size", constants.DefaultBatchSize, `Number of segment operations, e.g. initdb or pg_basebackup, the hub runs at once on each host`)
+	configureCmd.Flags().IntVar(&parallel, "parallel", constants.DefaultParallel, `Number of segment operations the hub runs at once in the whole cluster, and of hosts it calls at once`)
+	configureCmd.Flags().StringVar(&authzPolicyPath, "authorization-policy", "", `Path to the policy granting roles to the clients of the hub (default all clients may run every command)`)
 	// Allow passing a hostfile for "real" use cases or a few host names for tests, but not both
+	configureCmd.Flags().BoolVar(&uninstall, "uninstall", false, `Remove the services and gp.conf from all hosts instead, the same as gp unconfigure`)
+	addUnconfigureFlags(configureCmd)
 	configureCmd.Flags().StringArrayVar(&hostnames, "host", []string{}, `Segment hostname`)
 	configureCmd.Flags().StringVar(&hostfilePath, "hostfile", "", `Path to file containing a list of segment hostnames`)
 	configureCmd.MarkFlagsMutuallyExclusive("host", "hostfile")
-
-	requiredFlags := []string{
-		"ca-certificate",
-		"ca-key",
-		"server-certificate",
-		"server-key",
-	}
-	for _, flag := range requiredFlags {
-		configureCmd.MarkFlagRequired(flag) // nolint
+	for _, flag := range certificateFlags {
+		configureCmd.MarkFlagsMutuallyExclusive("generate-certs", flag)
 	}
 
 	viper.BindPFlag("gphome", configureCmd.Flags().Lookup("gphome")) // nolint
 	gpHome = viper.GetString("gphome")
 
+	configureCmd.AddCommand(rotateCertsCmd())
+
 	return configureCmd
 }
 
+func rotateCertsCmd() *cobra.Command {
+	rotateCertsCmd := &cobra.Command{
+		Use:     "rotate-certs",
+		Short:   "Replace the certificates generated by gp configure on all hosts",
+		Args:    cobra.NoArgs,
+		PreRunE: InitializeCommand,
+		RunE:    RunRotateCertificates,
+	}
+
+	rotateCertsCmd.Flags().IntVar(&certValidityDays, "certificate-validity", constants.DefaultCertValidityDays, `Number of days the new certificates are valid`)
+
+	return rotateCertsCmd
+}
+
+/*
+RunRotateCertificates issues new certificates for all hosts and has the running
+hub and agents reload them. Established connections are not interrupted.
+*/
+func RunRotateCertificates(cmd *cobra.Command, args []string) error {
+	if certValidityDays < 1 {
+		return fmt.Errorf("certificate validity must be at least one day, got %d", certValidityDays)
+	}
+
+	creds, ok := Conf.Credentials.(*utils.GpCredentials)
+	if !ok {
+		return errors.New("could not read the certificate configuration")
+	}
+
+	gpHome = Conf.GpHome
+	err := RotateCertificates(Conf.Hostnames, creds, time.Duration(certValidityDays)*24*time.Hour)
+	if err != nil {
+		return err
+	}
+
+	// The services also pick up the new files on their next connection, so they
+	// do not need to be running
+	client, err := ConnectToHub(Conf)
+	if err != nil {
+		gplog.Warn("Could not connect to the hub to reload the certificates, they are used once the services are started: %s", err)
+		return nil
+	}
+
+	_, err = client.ReloadCertificates(CommandContext, &idl.ReloadCertificatesRequest{})
+	if err != nil {
+		return utils.FormatGrpcError(err)
+	}
+	gplog.Info("Reloaded the certificates on the hub and agents")
+
+	return nil
+}
+
 func RunConfigure(cmd *cobra.Command, args []string) (err error) {
 	if gpHome == "" {
 		return fmt.Errorf("not a valid gpHome found\n")
@@ -119,6 +317,32 @@ func RunConfigure(cmd *cobra.Command, args []string) (err error) {
 		serviceDir = fmt.Sprintf(DefaultServiceDir, serviceUser)
 	}
 
+	if uninstall {
+		err = InitializeCommand(cmd, args)
+		if err != nil {
+			return err
+		}
+
+		return RunUnconfigure(cmd, args)
+	}
+
+	var systemServiceConf *utils.SystemServiceConfig
+	if systemService {
+		if !cmd.Flags().Lookup("service-dir").Changed {
+			serviceDir = constants.SystemServiceDir
+		}
+
+		if serviceGroup == "" {
+			serviceGroup, err = LookupUserGroup(serviceUser)
+			if err != nil {
+				return err
+			}
+		}
+		systemServiceConf = &utils.SystemServiceConfig{User: serviceUser, Group: serviceGroup}
+	} else if cmd.Flags().Lookup("service-group").Changed {
+		return errors.New("--service-group can only be used with --system-service")
+	}
+
 	if !cmd.Flags().Lookup("host").Changed && !cmd.Flags().Lookup("hostfile").Changed {
 		return errors.New("at least one hostname must be provided using either --host or --hostfile")
 	}
@@ -127,12 +351,71 @@ func RunConfigure(cmd *cobra.Command, args []string) (err error) {
 		return errors.New("hub port and agent port must be different")
 	}
 
+	for _, port := range []int{hubMetricsPort, agentMetricsPort} {
+		if port != 0 && (port == hubPort || port == agentPort) {
+			return fmt.Errorf("metrics port %d must be different from the hub and agent ports", port)
+		}
+	}
+
+	tracingConf := &tracing.Config{
+		Exporter: tracingExporter,
+		Endpoint: tracingEndpoint,
+		Insecure: tracingInsecure,
+	}
+	err = tracingConf.Validate()
+	if err != nil {
+		return err
+	}
+
+	err = validateCertificateFlags(cmd)
+	if err != nil {
+		return err
+	}
+
 	// Convert file/directory paths to absolute path before writing to gp.Conf file
 	err = resolveAbsolutePaths()
 	if err != nil {
 		return err
 	}
 
+	serviceLogConf := &utils.ServiceLogConfig{
+		Output:      serviceLogOutput,
+		MaxSizeMB:   serviceLogSizeMB,
+		RotateHours: serviceLogHours,
+		MaxFiles:    serviceLogFiles,
+		MaxAgeDays:  serviceLogAgeDays,
+	}
+	err = serviceLogConf.Validate()
+	if err != nil {
+		return err
+	}
+
+	parallelismConf := &hub.ParallelismConfig{BatchSize: batchSize, Parallel: parallel}
+	err = parallelismConf.Validate()
+	if err != nil {
+		return err
+	}
+
+	err = selectPlatform(utils.ServiceOptions{
+		Manager:       serviceManager,
+		GpHome:        gpHome,
+		LogDir:        hubLogDir,
+		SystemService: systemServiceConf,
+		ServiceLog:    serviceLogConf,
+		ConfigFile:    ConfigFilePath,
+	})
+	if err != nil {
+		return err
+	}
+
+	// The hub refuses to start with an invalid policy, so check it upfront
+	if authzPolicyPath != "" {
+		_, err = hub.LoadAuthorizationPolicy(authzPolicyPath)
+		if err != nil {
+			return err
+		}
+	}
+
 	if cmd.Flags().Lookup("hostfile").Changed {
 		hostnames, err = GetHostnames(hostfilePath)
 		if err != nil {
@@ -154,18 +437,65 @@ func RunConfigure(cmd *cobra.Command, args []string) (err error) {
 		LogDir:      hubLogDir,
 		ServiceName: serviceName,
 		GpHome:      gpHome,
-		Credentials: &utils.GpCredentials{
+
+		ServiceManager:      serviceManager,
+		SystemService:       systemServiceConf,
+		ServiceLog:          serviceLogConf,
+		Parallelism:         parallelismConf,
+		AuthorizationPolicy: authzPolicyPath,
+	}
+	if hubMetricsPort > 0 || agentMetricsPort > 0 {
+		Conf.Metrics = &metrics.Config{
+			BindAddress: metricsBindAddr,
+			HubPort:     hubMetricsPort,
+			AgentPort:   agentMetricsPort,
+		}
+	}
+	if tracingExporter != "" {
+		Conf.Tracing = tracingConf
+	}
+
+	// Check the conflicts before generating the certificates, which are not
+	// to be replaced when the configuration is refused
+	configFile, err := filepath.Abs(ConfigFilePath)
+	if err != nil {
+		return err
+	}
+	err = checkClusterConflicts(clusterName, configFile, Conf)
+	if err != nil {
+		return err
+	}
+
+	if generateCerts {
+		Conf.Credentials, err = GenerateCertificates(hostnames, certDir, time.Duration(certValidityDays)*24*time.Hour)
+		if err != nil {
+			return err
+		}
+	} else {
+		Conf.Credentials = &utils.GpCredentials{
 			CACertPath:     caCertPath,
 			CAKeyPath:      caKeyPath,
 			ServerCertPath: serverCertPath,
 			ServerKeyPath:  serverKeyPath,
-		},
+		}
 	}
+
 	err = Conf.Write(ConfigFilePath)
 	if err != nil {
 		return err
 	}
 
+	if clusterName != "" {
+		err = registerCluster(clusterName, configFile)
+		if err != nil {
+			return err
+		}
+	}
+
+	if printServiceFiles {
+		return PrintServiceFiles(os.Stdout, Platform, gpHome, serviceDir, serviceName)
+	}
+
 	err = Platform.CreateServiceDir(hostnames, serviceDir, gpHome)
 	if err != nil {
 		return err
@@ -190,6 +520,45 @@ func RunConfigure(cmd *cobra.Command, args []string) (err error) {
 	return nil
 }
 
+/*
+PrintServiceFiles prints the service files of the hub and agents along with the
+paths they are to be installed at, the hub on the coordinator host and the
+agents on all hosts, followed by the commands to load them.
+*/
+func PrintServiceFiles(out io.Writer, platform utils.Platform, gpHome string, serviceDir string, serviceName string) error {
+	gpPlatform, ok := platform.(utils.GpPlatform)
+	if !ok {
+		return fmt.Errorf("there are no service files with the %s service manager", constants.ServiceManagerProcess)
+	}
+
+	for _, process := range []string{"hub", "agent"} {
+		path := filepath.Join(serviceDir, fmt.Sprintf("%s_%s.%s", serviceName, process, gpPlatform.ServiceExt))
+		fmt.Fprintf(out, "# %s\n", path)
+		fmt.Fprintln(out, gpPlatform.GenerateServiceFileContents(process, gpHome, serviceName))
+	}
+
+	if gpPlatform.OS == constants.PlatformLinux {
+		fmt.Fprintf(out, "# then run on each host: %s\n", strings.Join(gpPlatform.ReloadCommand(), " "))
+	}
+
+	return nil
+}
+
+// LookupUserGroupFn returns the name of the primary group of the user
+func LookupUserGroupFn(username string) (string, error) {
+	u, err := user.Lookup(username)
+	if err != nil {
+		return "", fmt.Errorf("could not find the service user: %w", err)
+	}
+
+	group, err := user.LookupGroupId(u.Gid)
+	if err != nil {
+		return "", fmt.Errorf("could not find the primary group of user %s: %w", username, err)
+	}
+
+	return group.Name, nil
+}
+
 /*
 CheckOpenFilesLimitOnHosts checks for open files limit by calling ulimit command
 Executes gpssh command to get the ulimit from remote hosts using go routine
@@ -257,8 +626,36 @@ type Response struct {
 	Ulimit   int
 }
 
+func validateCertificateFlags(cmd *cobra.Command) error {
+	if generateCerts {
+		if certValidityDays < 1 {
+			return fmt.Errorf("certificate validity must be at least one day, got %d", certValidityDays)
+		}
+		if certDir == "" {
+			certDir = filepath.Join(gpHome, constants.DefaultCertificatesDir)
+		}
+
+		return nil
+	}
+
+	missing := make([]string, 0)
+	for _, flag := range certificateFlags {
+		if !cmd.Flags().Lookup(flag).Changed {
+			missing = append(missing, fmt.Sprintf("%q", flag))
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("required flag(s) %s not set, or use --generate-certs to generate the certificates", strings.Join(missing, ", "))
+	}
+
+	return nil
+}
+
 func resolveAbsolutePaths() error {
-	paths := []*string{&caCertPath, &caKeyPath, &serverCertPath, &serverKeyPath, &hubLogDir, &gpHome}
+	paths := []*string{&caCertPath, &caKeyPath, &serverCertPath, &serverKeyPath, &certDir, &hubLogDir, &gpHome}
+	if authzPolicyPath != "" {
+		paths = append(paths, &authzPolicyPath)
+	}
 	for _, path := range paths {
 		p, err := filepath.Abs(*path)
 		if err != nil {
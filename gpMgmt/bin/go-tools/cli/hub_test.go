@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"reflect"
+	"strings"
 	"sync"
 	"testing"
 
@@ -174,3 +175,42 @@ func TestGetHostnames(t *testing.T) {
 		}
 	})
 }
+
+func TestPrintServiceFiles(t *testing.T) {
+	t.Run("prints the system units with their paths", func(t *testing.T) {
+		platform, err := utils.NewServicePlatform(constants.PlatformLinux, utils.ServiceOptions{
+			SystemService: &utils.SystemServiceConfig{User: "gpadmin", Group: "gpadmin"},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		var out strings.Builder
+		err = cli.PrintServiceFiles(&out, platform, "/usr/local/gpdb", constants.SystemServiceDir, "gp")
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		for _, expected := range []string{
+			"# /etc/systemd/system/gp_hub.service\n[Unit]",
+			"ExecStart=/usr/local/gpdb/bin/gp hub\n",
+			"# /etc/systemd/system/gp_agent.service\n[Unit]",
+			"User=gpadmin\nGroup=gpadmin\n",
+			"# then run on each host: sudo -n systemctl daemon-reload\n",
+		} {
+			if !strings.Contains(out.String(), expected) {
+				t.Fatalf("got %q, want it to contain %q", out.String(), expected)
+			}
+		}
+	})
+
+	t.Run("errors when there are no service files", func(t *testing.T) {
+		platform := utils.ProcessPlatform{OS: constants.PlatformLinux}
+
+		err := cli.PrintServiceFiles(&strings.Builder{}, platform, "/usr/local/gpdb", "", "gp")
+		expected := "there are no service files with the process service manager"
+		if err == nil || err.Error() != expected {
+			t.Fatalf("got %v, want %s", err, expected)
+		}
+	})
+}
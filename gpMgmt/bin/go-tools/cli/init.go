@@ -2,8 +2,10 @@ package cli
 
 import (
 	"bytes"
-	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
+	"os"
 	"path/filepath"
 	"slices"
 	"strconv"
@@ -11,11 +13,13 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"golang.org/x/term"
 
 	"github.com/greenplum-db/gp-common-go-libs/gplog"
 	"github.com/greenplum-db/gpdb/gp/constants"
 	"github.com/greenplum-db/gpdb/gp/idl"
 	"github.com/greenplum-db/gpdb/gp/utils"
+	"github.com/greenplum-db/gpdb/gp/utils/postgres"
 )
 
 type Locale struct {
@@ -40,18 +44,38 @@ type SegmentPair struct {
 	Mirror  *Segment `mapstructure:"mirror"`
 }
 
+type SslCertFiles struct {
+	CertFile string `mapstructure:"cert-file"`
+	KeyFile  string `mapstructure:"key-file"`
+}
+
+type SslConfig struct {
+	Enabled          bool                    `mapstructure:"enabled"`
+	CaFile           string                  `mapstructure:"ca-file"`
+	CertFile         string                  `mapstructure:"cert-file"`
+	KeyFile          string                  `mapstructure:"key-file"`
+	HostCertificates map[string]SslCertFiles `mapstructure:"host-certificates"`
+	HbaHostssl       bool                    `mapstructure:"hba-hostssl"`
+}
+
 type InitConfig struct {
 	DbName            string            `mapstructure:"db-name"`
 	Encoding          string            `mapstructure:"encoding"`
 	HbaHostnames      bool              `mapstructure:"hba-hostnames"`
+	HbaAuthMethod     string            `mapstructure:"hba-auth-method"`
+	HbaReplAuthMethod string            `mapstructure:"hba-replication-auth-method"`
+	HbaUserAuthMethod string            `mapstructure:"hba-user-auth-method"`
+	HbaUserAddresses  []string          `mapstructure:"hba-user-addresses"`
 	DataChecksums     bool              `mapstructure:"data-checksums"`
-	SuPassword        string            `mapstructure:"su-password"` //TODO set to default if not provided
+	SuPassword        string            `mapstructure:"su-password"` // deprecated, kept in clear text in the config file
+	SuPasswordFile    string            `mapstructure:"su-password-file"`
 	Locale            Locale            `mapstructure:"locale"`
 	CommonConfig      map[string]string `mapstructure:"common-config"`
 	CoordinatorConfig map[string]string `mapstructure:"coordinator-config"`
 	SegmentConfig     map[string]string `mapstructure:"segment-config"`
 	Coordinator       Segment           `mapstructure:"coordinator"`
 	SegmentArray      []SegmentPair     `mapstructure:"segment-array"`
+	Ssl               SslConfig         `mapstructure:"ssl"`
 
 	//Expansion config parameters
 	PrimaryBasePort        int      `mapstructure:"primary-base-port"`
@@ -71,8 +95,11 @@ var (
 	GetSystemLocale                      = GetSystemLocaleFn
 	SetDefaultLocale                     = SetDefaultLocaleFn
 	IsGpServicesEnabled                  = IsGpServicesEnabledFn
+	PromptSuPassword                     = PromptSuPasswordFn
 )
 var cliForceFlag bool
+var cliBatchSize int
+var cliParallel int
 var ContainsMirror bool
 var HubClient idl.HubClient
 
@@ -85,6 +112,8 @@ func initCmd() *cobra.Command {
 		RunE:    RunInitClusterCmd,
 	}
 	initCmd.PersistentFlags().BoolVar(&cliForceFlag, "force", false, "Create cluster forcefully by overwriting existing directories")
+	initCmd.PersistentFlags().IntVar(&cliBatchSize, "batch-size", 0, "Number of segments created at once on each host (default the one of gp.conf)")
+	initCmd.PersistentFlags().IntVar(&cliParallel, "parallel", 0, "Number of segments created at once in the whole cluster (default the one of gp.conf)")
 	initCmd.AddCommand(initClusterCmd())
 	return initCmd
 }
@@ -92,8 +121,24 @@ func initCmd() *cobra.Command {
 // initClusterCmd adds support for command "gp init cluster <config-file> [--force]
 func initClusterCmd() *cobra.Command {
 	initClusterCmd := &cobra.Command{
-		Use:     "cluster",
-		Short:   "Initialize the cluster",
+		Use:   "cluster",
+		Short: "Initialize the cluster",
+		Long: `Initialize the cluster described by the given configuration file.
+
+The authentication of the generated pg_hba.conf entries is set by the keys:
+  hba-auth-method              method of the connections between the segments,
+                               one of trust, scram-sha-256, md5 or cert. Required,
+                               trust is only used when given.
+  hba-replication-auth-method  method of the replication connections of the
+                               mirrors, trust by default. pg_basebackup and the
+                               walreceiver of the mirrors connect without a
+                               password, so the other methods need the mirror
+                               hosts to provide the credentials of the user,
+                               in ~/.pgpass or as a client certificate in
+                               ~/.postgresql.
+  hba-user-auth-method         method of the users connecting to the coordinator
+                               from hba-user-addresses, scram-sha-256 by default.
+The cert method requires ssl to be enabled.`,
 		PreRunE: InitializeCommand,
 		RunE:    RunInitClusterCmd,
 	}
@@ -129,6 +174,10 @@ func InitClusterServiceFn(inputConfigFile string, force, verbose bool) error {
 	if err != nil {
 		return err
 	}
+	parallelism, err := ParallelismRequest(cliBatchSize, cliParallel)
+	if err != nil {
+		return err
+	}
 	// Viper instance to read the input config
 	cliHandler := viper.New()
 
@@ -148,9 +197,12 @@ func InitClusterServiceFn(inputConfigFile string, force, verbose bool) error {
 	if err := ValidateInputConfigAndSetDefaults(clusterReq, cliHandler); err != nil {
 		return err
 	}
+	if parallelism != nil {
+		clusterReq.Parallelism = parallelism
+	}
 
 	// Call RPC on Hub to create the cluster
-	stream, err := HubClient.MakeCluster(context.Background(), clusterReq)
+	stream, err := HubClient.MakeCluster(CommandContext, clusterReq)
 	if err != nil {
 		return utils.FormatGrpcError(err)
 	}
@@ -163,10 +215,52 @@ func InitClusterServiceFn(inputConfigFile string, force, verbose bool) error {
 	return nil
 }
 
+/*
+ParallelismRequest returns the limits of the segment operations given on the
+command line, or nil when none is given for the hub to use the ones of gp.conf
+*/
+func ParallelismRequest(batchSize int, parallel int) (*idl.Parallelism, error) {
+	if batchSize < 0 || parallel < 0 {
+		return nil, fmt.Errorf("the batch size and the parallelism must not be negative")
+	}
+	if batchSize == 0 && parallel == 0 {
+		return nil, nil
+	}
+
+	return &idl.Parallelism{BatchSize: int32(batchSize), Parallel: int32(parallel)}, nil
+}
+
 /*
 LoadInputConfigToIdlFn reads config file and populates RPC IDL request structure
 */
 func LoadInputConfigToIdlFn(inputConfigFile string, cliHandler *viper.Viper, force bool, verbose bool) (*idl.MakeClusterRequest, error) {
+	config, err := LoadInitConfig(inputConfigFile, cliHandler)
+	if err != nil {
+		return &idl.MakeClusterRequest{}, err
+	}
+
+	password, err := GetSuPassword(config)
+	if err != nil {
+		return &idl.MakeClusterRequest{}, err
+	}
+
+	request := CreateMakeClusterReq(config, force, verbose)
+	if password != "" {
+		// Only the verifier of the password leaves this host
+		request.ClusterParams.SuPasswordVerifier, err = postgres.ScramSha256Verifier(password)
+		if err != nil {
+			return &idl.MakeClusterRequest{}, err
+		}
+	}
+
+	return request, nil
+}
+
+/*
+LoadInitConfig reads the init config file, expanding the segment array when
+the expansion config is used
+*/
+func LoadInitConfig(inputConfigFile string, cliHandler *viper.Viper) (*InitConfig, error) {
 	cliHandler.SetConfigFile(inputConfigFile)
 
 	cliHandler.SetDefault("common-config", make(map[string]string))
@@ -175,32 +269,32 @@ func LoadInputConfigToIdlFn(inputConfigFile string, cliHandler *viper.Viper, for
 	cliHandler.SetDefault("data-checksums", true)
 
 	if err := cliHandler.ReadInConfig(); err != nil {
-		return &idl.MakeClusterRequest{}, fmt.Errorf("while reading config file: %w", err)
+		return nil, fmt.Errorf("while reading config file: %w", err)
 	}
 
 	var config InitConfig
 	if err := cliHandler.UnmarshalExact(&config); err != nil {
-		return &idl.MakeClusterRequest{}, fmt.Errorf("while unmarshaling config file: %w", err)
+		return nil, fmt.Errorf("while unmarshaling config file: %w", err)
 	}
 
 	if AnyExpansionConfigPresent(cliHandler) {
 		// Validate expansion config
 		err := ValidateExpansionConfigAndSetDefault(&config, cliHandler)
 		if err != nil {
-			return &idl.MakeClusterRequest{}, err
+			return nil, err
 		}
 
 		// Check for multi-home
 		isMultiHome, NameAddressMap, AddressNameMap, err := IsMultiHome(config.HostList)
 		if err != nil {
 			gplog.Error("multihome detection failed, error: %v", err)
-			return &idl.MakeClusterRequest{}, err
+			return nil, err
 		}
 
 		if isMultiHome {
 			isValidMultiHomeConfig, err := ValidateMultiHomeConfig(config, NameAddressMap)
 			if !isValidMultiHomeConfig {
-				return &idl.MakeClusterRequest{}, err
+				return nil, err
 			}
 		}
 
@@ -210,7 +304,85 @@ func LoadInputConfigToIdlFn(inputConfigFile string, cliHandler *viper.Viper, for
 		// TODO to print expanded configuration here for user reference and print to file if required
 	}
 
-	return CreateMakeClusterReq(&config, force, verbose), nil
+	return &config, nil
+}
+
+/*
+GetSuPassword returns the superuser password read from the su-password-file of
+the config, or else from the GP_SU_PASSWORD environment variable, or else from
+the deprecated su-password of the config, or else prompted for. An empty
+password means that none was given.
+*/
+func GetSuPassword(config *InitConfig) (string, error) {
+	if config.SuPasswordFile != "" && config.SuPassword != "" {
+		return "", fmt.Errorf("only one of su-password and su-password-file can be provided")
+	}
+
+	if config.SuPasswordFile != "" {
+		info, err := utils.System.Stat(config.SuPasswordFile)
+		if err != nil {
+			return "", fmt.Errorf("could not read the superuser password file: %w", err)
+		}
+		if info.Mode().Perm()&0077 != 0 {
+			gplog.Warn("The superuser password file %s is accessible by other users, its permissions should be 0600", config.SuPasswordFile)
+		}
+
+		contents, err := utils.System.ReadFile(config.SuPasswordFile)
+		if err != nil {
+			return "", fmt.Errorf("could not read the superuser password file: %w", err)
+		}
+
+		password := strings.TrimRight(string(contents), "\r\n")
+		if password == "" {
+			return "", fmt.Errorf("the superuser password file %s is empty", config.SuPasswordFile)
+		}
+
+		return password, nil
+	}
+
+	if password := os.Getenv(constants.SuPasswordEnvVar); password != "" {
+		return password, nil
+	}
+
+	if config.SuPassword != "" {
+		gplog.Warn("su-password keeps the superuser password in clear text in the config file, use su-password-file or the %s environment variable instead", constants.SuPasswordEnvVar)
+		return config.SuPassword, nil
+	}
+
+	return PromptSuPassword()
+}
+
+// PromptSuPasswordFn asks for the superuser password twice on the terminal,
+// and returns an empty password when not run from a terminal
+func PromptSuPasswordFn() (string, error) {
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		gplog.Warn("No superuser password provided, it will not be set")
+		return "", nil
+	}
+
+	fmt.Fprint(os.Stderr, "Enter the superuser password (leave empty to not set it): ")
+	password, err := term.ReadPassword(fd)
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return "", fmt.Errorf("could not read the superuser password: %w", err)
+	}
+	if len(password) == 0 {
+		return "", nil
+	}
+
+	fmt.Fprint(os.Stderr, "Enter the superuser password again: ")
+	confirmation, err := term.ReadPassword(fd)
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return "", fmt.Errorf("could not read the superuser password: %w", err)
+	}
+
+	if !bytes.Equal(password, confirmation) {
+		return "", fmt.Errorf("the passwords do not match")
+	}
+
+	return string(password), nil
 }
 
 // ValidateMultiHomeConfig performs validation checks for multi-home environment
@@ -250,7 +422,7 @@ func ValidateMultiHomeConfig(config InitConfig, addressMap map[string][]string)
 func IsMultiHome(hostlist []string) (isMultiHome bool, NameAddress map[string][]string, AddressNameMap map[string]string, err error) {
 	// get a list of hostnames against each address
 	request := idl.GetAllHostNamesRequest{HostList: hostlist}
-	reply, err := HubClient.GetAllHostNames(context.Background(), &request)
+	reply, err := HubClient.GetAllHostNames(CommandContext, &request)
 	if err != nil {
 
 		return false, nil, nil, utils.LogAndReturnError(fmt.Errorf("failed names of the host against address: %v", err))
@@ -630,11 +802,31 @@ func ClusterParamsToIdl(config *InitConfig) *idl.ClusterParams {
 			LcNumeric:  config.Locale.LcNumeric,
 			LcTime:     config.Locale.LcTime,
 		},
-		HbaHostnames:  config.HbaHostnames,
-		Encoding:      config.Encoding,
-		SuPassword:    config.SuPassword,
-		DbName:        config.DbName,
-		DataChecksums: config.DataChecksums,
+		HbaHostnames:      config.HbaHostnames,
+		Encoding:          config.Encoding,
+		DbName:            config.DbName,
+		DataChecksums:     config.DataChecksums,
+		HbaAuthMethod:     config.HbaAuthMethod,
+		HbaUserAuthMethod: config.HbaUserAuthMethod,
+		HbaUserAddrs:      config.HbaUserAddresses,
+		Ssl:               SslConfigToIdl(config.Ssl),
+
+		HbaReplicationAuthMethod: config.HbaReplAuthMethod,
+	}
+}
+
+func SslConfigToIdl(config SslConfig) *idl.SslParams {
+	hostCerts := make(map[string]*idl.SslCertFiles)
+	for host, files := range config.HostCertificates {
+		hostCerts[host] = &idl.SslCertFiles{CertFile: files.CertFile, KeyFile: files.KeyFile}
+	}
+
+	return &idl.SslParams{
+		Enabled:     config.Enabled,
+		CaFile:      config.CaFile,
+		DefaultCert: &idl.SslCertFiles{CertFile: config.CertFile, KeyFile: config.KeyFile},
+		HostCerts:   hostCerts,
+		HbaHostssl:  config.HbaHostssl,
 	}
 }
 
@@ -717,12 +909,156 @@ func ValidateInputConfigAndSetDefaultsFn(request *idl.MakeClusterRequest, cliHan
 		return err
 	}
 
+	err = ValidateHbaAuthConfig(request.ClusterParams)
+	if err != nil {
+		return err
+	}
+
+	err = ValidateSslConfig(request)
+	if err != nil {
+		return err
+	}
+
 	// if shared_buffers not provided in config then set the COORDINATOR_SHARED_BUFFERS and QE_SHARED_BUFFERS to DEFAULT_BUFFERS (128000 kB)
 	CheckAndSetDefaultConfigParams(request.ClusterParams, "shared_buffers", constants.DefaultBuffer)
 
 	return nil
 }
 
+/*
+ValidateHbaAuthConfig checks the authentication methods used for the generated pg_hba.conf entries.
+The method of the cluster internal entries must be chosen, trust being only used when given.
+The replication entries of the mirrors default to trust, see the help of gp init cluster, while
+the user access entries default to scram-sha-256.
+*/
+func ValidateHbaAuthConfig(clusterParams *idl.ClusterParams) error {
+	validMethods := []string{constants.AuthTrust, constants.AuthScramSha256, constants.AuthMd5, constants.AuthCert}
+
+	if clusterParams.HbaAuthMethod == "" {
+		return fmt.Errorf("hba-auth-method must be set for the cluster internal pg_hba.conf entries. Valid options are %s", strings.Join(validMethods, ", "))
+	}
+	clusterParams.HbaAuthMethod = strings.ToLower(clusterParams.HbaAuthMethod)
+	if !slices.Contains(validMethods, clusterParams.HbaAuthMethod) {
+		return fmt.Errorf("invalid hba-auth-method %q. Valid options are %s", clusterParams.HbaAuthMethod, strings.Join(validMethods, ", "))
+	}
+
+	if clusterParams.HbaReplicationAuthMethod == "" {
+		gplog.Warn("hba-replication-auth-method not specified, the replication connections of the mirrors are trusted from the segment addresses")
+		clusterParams.HbaReplicationAuthMethod = constants.AuthTrust
+	}
+	clusterParams.HbaReplicationAuthMethod = strings.ToLower(clusterParams.HbaReplicationAuthMethod)
+	if !slices.Contains(validMethods, clusterParams.HbaReplicationAuthMethod) {
+		return fmt.Errorf("invalid hba-replication-auth-method %q. Valid options are %s", clusterParams.HbaReplicationAuthMethod, strings.Join(validMethods, ", "))
+	}
+
+	if len(clusterParams.HbaUserAddrs) == 0 {
+		if clusterParams.HbaUserAuthMethod != "" {
+			return fmt.Errorf("hba-user-auth-method is set but no hba-user-addresses are provided")
+		}
+
+		return nil
+	}
+
+	if clusterParams.HbaUserAuthMethod == "" {
+		gplog.Info("hba-user-auth-method not specified, setting default to %q", constants.AuthScramSha256)
+		clusterParams.HbaUserAuthMethod = constants.AuthScramSha256
+	}
+	clusterParams.HbaUserAuthMethod = strings.ToLower(clusterParams.HbaUserAuthMethod)
+	if !slices.Contains(validMethods, clusterParams.HbaUserAuthMethod) {
+		return fmt.Errorf("invalid hba-user-auth-method %q. Valid options are %s", clusterParams.HbaUserAuthMethod, strings.Join(validMethods, ", "))
+	}
+
+	for _, addr := range clusterParams.HbaUserAddrs {
+		entry := postgres.NewHbaEntry("host", "all", "all", addr, clusterParams.HbaUserAuthMethod)
+		if clusterParams.HbaUserAuthMethod == constants.AuthCert {
+			entry.Type = "hostssl"
+		}
+
+		err := entry.Validate()
+		if err != nil {
+			return fmt.Errorf("invalid hba-user-addresses: %w", err)
+		}
+	}
+
+	return nil
+}
+
+/*
+ValidateSslConfig checks that every host of the cluster has a valid server certificate and key
+when SSL is enabled, and that the options which depend on SSL are not used without it.
+*/
+func ValidateSslConfig(request *idl.MakeClusterRequest) error {
+	params := request.ClusterParams
+	ssl := params.GetSsl()
+	usesCertAuth := slices.Contains([]string{params.HbaAuthMethod, params.HbaReplicationAuthMethod, params.HbaUserAuthMethod}, constants.AuthCert)
+
+	if !ssl.GetEnabled() {
+		if ssl.GetHbaHostssl() {
+			return fmt.Errorf("ssl hba-hostssl is set but ssl is not enabled")
+		}
+		if usesCertAuth {
+			return fmt.Errorf("%q authentication requires ssl to be enabled", constants.AuthCert)
+		}
+
+		return nil
+	}
+
+	if ssl.CaFile == "" {
+		if usesCertAuth {
+			return fmt.Errorf("%q authentication requires the ssl ca-file to be provided", constants.AuthCert)
+		}
+	} else {
+		ca, err := ReadFile(ssl.CaFile)
+		if err != nil {
+			return fmt.Errorf("reading ssl ca-file: %w", err)
+		}
+
+		if !x509.NewCertPool().AppendCertsFromPEM(ca) {
+			return fmt.Errorf("no valid certificates found in ssl ca-file %s", ssl.CaFile)
+		}
+	}
+
+	hosts := []string{request.GpArray.GetCoordinator().GetHostName()}
+	for _, seg := range append(request.GetPrimarySegments(), request.GetMirrorSegments()...) {
+		if !slices.Contains(hosts, seg.HostName) {
+			hosts = append(hosts, seg.HostName)
+		}
+	}
+
+	for _, host := range hosts {
+		files, ok := ssl.HostCerts[host]
+		if !ok {
+			files = ssl.DefaultCert
+		}
+		if files.GetCertFile() == "" || files.GetKeyFile() == "" {
+			return fmt.Errorf("no ssl cert-file and key-file provided for host %s", host)
+		}
+
+		err := validateSslKeyPair(files.CertFile, files.KeyFile)
+		if err != nil {
+			return fmt.Errorf("invalid ssl certificate for host %s: %w", host, err)
+		}
+	}
+
+	return nil
+}
+
+func validateSslKeyPair(certFile, keyFile string) error {
+	cert, err := ReadFile(certFile)
+	if err != nil {
+		return err
+	}
+
+	key, err := ReadFile(keyFile)
+	if err != nil {
+		return err
+	}
+
+	_, err = tls.X509KeyPair(cert, key)
+
+	return err
+}
+
 /*
 ValidateSegment checks if valid values have been provided for the segment hostname, address, port and data-directory.
 If hostname is not provided then the function returns an error.
@@ -1,12 +1,21 @@
 package cli_test
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
 	"errors"
 	"fmt"
+	"math/big"
 	"os"
+	"path/filepath"
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/greenplum-db/gpdb/gp/constants"
 
@@ -1531,8 +1540,8 @@ func TestValidateInputConfigAndSetDefaults(t *testing.T) {
 				LcCollate:  "en_US.UTF-8",
 			},
 			HbaHostnames:  false,
+			HbaAuthMethod: constants.AuthScramSha256,
 			Encoding:      "Unicode",
-			SuPassword:    "gp",
 			DbName:        "gpadmin",
 			DataChecksums: false,
 		}
@@ -1767,6 +1776,84 @@ func TestCheckForDuplicatePortAndDataDirectoryFn(t *testing.T) {
 	})
 }
 
+func TestValidateHbaAuthConfig(t *testing.T) {
+	_, _, logfile := testhelper.SetupTestLogger()
+
+	t.Run("sets the default authentication methods", func(t *testing.T) {
+		params := &idl.ClusterParams{HbaAuthMethod: constants.AuthMd5, HbaUserAddrs: []string{"10.0.0.0/8"}}
+
+		err := cli.ValidateHbaAuthConfig(params)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if params.HbaReplicationAuthMethod != constants.AuthTrust {
+			t.Fatalf("got %s, want %s", params.HbaReplicationAuthMethod, constants.AuthTrust)
+		}
+
+		if params.HbaUserAuthMethod != constants.AuthScramSha256 {
+			t.Fatalf("got %s, want %s", params.HbaUserAuthMethod, constants.AuthScramSha256)
+		}
+		testutils.AssertLogMessage(t, logfile, `hba-replication-auth-method not specified, the replication connections of the mirrors are trusted from the segment addresses`)
+	})
+
+	t.Run("accepts the authentication methods irrespective of case", func(t *testing.T) {
+		params := &idl.ClusterParams{
+			HbaAuthMethod:     "SCRAM-SHA-256",
+			HbaUserAuthMethod: "Cert",
+			HbaUserAddrs:      []string{"client.example.com", "2001:db8::/32"},
+		}
+
+		err := cli.ValidateHbaAuthConfig(params)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if params.HbaAuthMethod != constants.AuthScramSha256 || params.HbaUserAuthMethod != constants.AuthCert {
+			t.Fatalf("got %s and %s, want %s and %s", params.HbaAuthMethod, params.HbaUserAuthMethod, constants.AuthScramSha256, constants.AuthCert)
+		}
+	})
+
+	cases := []struct {
+		params   *idl.ClusterParams
+		expected string
+	}{
+		{
+			params:   &idl.ClusterParams{},
+			expected: `hba-auth-method must be set for the cluster internal pg_hba.conf entries. Valid options are trust, scram-sha-256, md5, cert`,
+		},
+		{
+			params:   &idl.ClusterParams{HbaAuthMethod: "password"},
+			expected: `invalid hba-auth-method "password". Valid options are trust, scram-sha-256, md5, cert`,
+		},
+		{
+			params:   &idl.ClusterParams{HbaAuthMethod: "trust", HbaReplicationAuthMethod: "peer"},
+			expected: `invalid hba-replication-auth-method "peer". Valid options are trust, scram-sha-256, md5, cert`,
+		},
+		{
+			params:   &idl.ClusterParams{HbaAuthMethod: "trust", HbaUserAuthMethod: "md5"},
+			expected: "hba-user-auth-method is set but no hba-user-addresses are provided",
+		},
+		{
+			params:   &idl.ClusterParams{HbaAuthMethod: "trust", HbaUserAuthMethod: "ident", HbaUserAddrs: []string{"10.0.0.0/8"}},
+			expected: `invalid hba-user-auth-method "ident". Valid options are trust, scram-sha-256, md5, cert`,
+		},
+		{
+			params:   &idl.ClusterParams{HbaAuthMethod: "trust", HbaUserAddrs: []string{"10.0.0.0/8", "10.0.0.300/8"}},
+			expected: `invalid hba-user-addresses: invalid CIDR address "10.0.0.300/8"`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run("returns error for invalid configuration", func(t *testing.T) {
+			err := cli.ValidateHbaAuthConfig(tc.params)
+			if err == nil || !strings.Contains(err.Error(), tc.expected) {
+				t.Fatalf("got %v, want %v", err, tc.expected)
+			}
+		})
+	}
+}
+
 func TestValidateSegmentFn(t *testing.T) {
 	setupTest(t)
 	defer teardownTest()
@@ -1915,6 +2002,113 @@ func CommandFailure() {
 	os.Exit(1)
 }
 
+func TestGetSuPassword(t *testing.T) {
+	_, _, logfile := testhelper.SetupTestLogger()
+
+	writePasswordFile := func(t *testing.T, contents string, perm os.FileMode) string {
+		path := filepath.Join(t.TempDir(), "password")
+		err := os.WriteFile(path, []byte(contents), perm)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		return path
+	}
+
+	t.Run("reads the password from the file without the trailing newline", func(t *testing.T) {
+		t.Setenv(constants.SuPasswordEnvVar, "from env")
+		config := &cli.InitConfig{SuPasswordFile: writePasswordFile(t, "from file\n", 0600)}
+
+		password, err := cli.GetSuPassword(config)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		if password != "from file" {
+			t.Fatalf("got %q, want %q", password, "from file")
+		}
+	})
+
+	t.Run("warns when the password file is readable by other users", func(t *testing.T) {
+		path := writePasswordFile(t, "from file", 0644)
+		config := &cli.InitConfig{SuPasswordFile: path}
+
+		_, err := cli.GetSuPassword(config)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		testutils.AssertLogMessage(t, logfile, fmt.Sprintf("The superuser password file %s is accessible by other users", path))
+	})
+
+	t.Run("errors out when the password file is empty or missing", func(t *testing.T) {
+		path := writePasswordFile(t, "\n", 0600)
+
+		_, err := cli.GetSuPassword(&cli.InitConfig{SuPasswordFile: path})
+		expected := fmt.Sprintf("the superuser password file %s is empty", path)
+		if err == nil || err.Error() != expected {
+			t.Fatalf("got %v, want %v", err, expected)
+		}
+
+		_, err = cli.GetSuPassword(&cli.InitConfig{SuPasswordFile: filepath.Join(t.TempDir(), "missing")})
+		if !errors.Is(err, os.ErrNotExist) {
+			t.Fatalf("got %#v, want %#v", err, os.ErrNotExist)
+		}
+	})
+
+	t.Run("errors out when both the password and the password file are given", func(t *testing.T) {
+		config := &cli.InitConfig{SuPassword: "gp", SuPasswordFile: "/tmp/password"}
+
+		_, err := cli.GetSuPassword(config)
+		expected := "only one of su-password and su-password-file can be provided"
+		if err == nil || err.Error() != expected {
+			t.Fatalf("got %v, want %v", err, expected)
+		}
+	})
+
+	t.Run("reads the password from the environment before the config", func(t *testing.T) {
+		t.Setenv(constants.SuPasswordEnvVar, "from env")
+
+		password, err := cli.GetSuPassword(&cli.InitConfig{SuPassword: "from config"})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		if password != "from env" {
+			t.Fatalf("got %q, want %q", password, "from env")
+		}
+	})
+
+	t.Run("warns when the password is in clear text in the config", func(t *testing.T) {
+		t.Setenv(constants.SuPasswordEnvVar, "")
+
+		password, err := cli.GetSuPassword(&cli.InitConfig{SuPassword: "from config"})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		if password != "from config" {
+			t.Fatalf("got %q, want %q", password, "from config")
+		}
+
+		testutils.AssertLogMessage(t, logfile, "su-password keeps the superuser password in clear text in the config file")
+	})
+
+	t.Run("prompts for the password when none is given", func(t *testing.T) {
+		defer resetCLIVars()
+		t.Setenv(constants.SuPasswordEnvVar, "")
+
+		cli.PromptSuPassword = func() (string, error) {
+			return "prompted", nil
+		}
+
+		password, err := cli.GetSuPassword(&cli.InitConfig{})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		if password != "prompted" {
+			t.Fatalf("got %q, want %q", password, "prompted")
+		}
+	})
+}
+
 func TestIsGpServicesEnabledFn(t *testing.T) {
 	setupTest(t)
 	defer teardownTest()
@@ -1972,3 +2166,166 @@ func TestIsGpServicesEnabledFn(t *testing.T) {
 		}
 	})
 }
+
+func TestValidateSslConfig(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := createSslCertificate(t, dir, "server")
+	otherCertFile, otherKeyFile := createSslCertificate(t, dir, "other")
+
+	request := func(ssl *idl.SslParams) *idl.MakeClusterRequest {
+		return &idl.MakeClusterRequest{
+			GpArray: &idl.GpArray{
+				Coordinator: &idl.Segment{HostName: "cdw"},
+				SegmentArray: []*idl.SegmentPair{
+					{Primary: &idl.Segment{HostName: "sdw1"}, Mirror: &idl.Segment{HostName: "sdw2"}},
+				},
+			},
+			ClusterParams: &idl.ClusterParams{Ssl: ssl},
+		}
+	}
+
+	t.Run("succeeds when ssl is not enabled", func(t *testing.T) {
+		err := cli.ValidateSslConfig(request(nil))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("succeeds when all the hosts have a valid certificate", func(t *testing.T) {
+		err := cli.ValidateSslConfig(request(&idl.SslParams{
+			Enabled:     true,
+			CaFile:      certFile,
+			DefaultCert: &idl.SslCertFiles{CertFile: certFile, KeyFile: keyFile},
+			HostCerts: map[string]*idl.SslCertFiles{
+				"sdw2": {CertFile: otherCertFile, KeyFile: otherKeyFile},
+			},
+		}))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	cases := []struct {
+		name     string
+		request  *idl.MakeClusterRequest
+		expected string
+	}{
+		{
+			name:     "hostssl entries are requested without ssl",
+			request:  request(&idl.SslParams{HbaHostssl: true}),
+			expected: "ssl hba-hostssl is set but ssl is not enabled",
+		},
+		{
+			name: "cert authentication is used without ssl",
+			request: func() *idl.MakeClusterRequest {
+				req := request(nil)
+				req.ClusterParams.HbaUserAuthMethod = constants.AuthCert
+				return req
+			}(),
+			expected: `"cert" authentication requires ssl to be enabled`,
+		},
+		{
+			name: "cert authentication is used without a CA",
+			request: func() *idl.MakeClusterRequest {
+				req := request(&idl.SslParams{Enabled: true})
+				req.ClusterParams.HbaAuthMethod = constants.AuthCert
+				return req
+			}(),
+			expected: `"cert" authentication requires the ssl ca-file to be provided`,
+		},
+		{
+			name:     "a host has no certificate",
+			request:  request(&idl.SslParams{Enabled: true, HostCerts: map[string]*idl.SslCertFiles{"cdw": {CertFile: certFile, KeyFile: keyFile}}}),
+			expected: "no ssl cert-file and key-file provided for host sdw1",
+		},
+		{
+			name:     "the key does not match the certificate",
+			request:  request(&idl.SslParams{Enabled: true, DefaultCert: &idl.SslCertFiles{CertFile: certFile, KeyFile: otherKeyFile}}),
+			expected: "invalid ssl certificate for host cdw: tls: private key does not match public key",
+		},
+		{
+			name:     "the CA file is not a certificate",
+			request:  request(&idl.SslParams{Enabled: true, CaFile: keyFile}),
+			expected: fmt.Sprintf("no valid certificates found in ssl ca-file %s", keyFile),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run("errors out when "+tc.name, func(t *testing.T) {
+			err := cli.ValidateSslConfig(tc.request)
+			if err == nil || err.Error() != tc.expected {
+				t.Fatalf("got %v, want %s", err, tc.expected)
+			}
+		})
+	}
+}
+
+func createSslCertificate(t *testing.T, dir string, name string) (string, string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	certFile := filepath.Join(dir, name+".crt")
+	keyFile := filepath.Join(dir, name+".key")
+	err = os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0644)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	err = os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0600)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	return certFile, keyFile
+}
+
+func TestParallelismRequest(t *testing.T) {
+	t.Run("returns nil when no limit is given", func(t *testing.T) {
+		parallelism, err := cli.ParallelismRequest(0, 0)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		if parallelism != nil {
+			t.Fatalf("got %+v, want nil", parallelism)
+		}
+	})
+
+	t.Run("returns the limits given", func(t *testing.T) {
+		parallelism, err := cli.ParallelismRequest(2, 0)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		if parallelism.BatchSize != 2 || parallelism.Parallel != 0 {
+			t.Fatalf("got %+v, want a batch size of 2", parallelism)
+		}
+	})
+
+	t.Run("returns error when a limit is negative", func(t *testing.T) {
+		_, err := cli.ParallelismRequest(0, -1)
+		expected := "the batch size and the parallelism must not be negative"
+		if err == nil || err.Error() != expected {
+			t.Fatalf("got %v, want %s", err, expected)
+		}
+	})
+}
@@ -0,0 +1,252 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/greenplum-db/gpdb/gp/constants"
+	"github.com/greenplum-db/gpdb/gp/idl"
+	"github.com/greenplum-db/gpdb/gp/utils"
+)
+
+var (
+	logsCoordinatorDataDir string
+	logsContents           []int
+	logsHosts              []string
+	logsAgent              bool
+	logsList               bool
+	logsFollow             bool
+	logsGrep               string
+	logsSince              string
+	logsUntil              string
+	logsSeverity           string
+	logsTail               int
+	logsFile               string
+
+	collectCoordinatorDataDir string
+	collectSince              string
+	collectHosts              []string
+	collectMaxHostSizeMB      int64
+	collectOutputFile         string
+)
+
+func logsCmd() *cobra.Command {
+	logsCmd := &cobra.Command{
+		Use:   "logs",
+		Short: "Show the logs of the segments or agents across the cluster",
+		Long: `Show the logs of the segments or agents across the cluster.
+
+Only the latest log file of each segment is read, unless --file or --since is
+given. The time range and the severity filters only apply to the CSV logs of
+the segments.`,
+		Example: `gp logs --content 0 --severity error --since 1h
+gp logs --host sdw1 --follow --grep "checkpoint"
+gp logs --agent --list`,
+		Args:    cobra.NoArgs,
+		PreRunE: InitializeCommand,
+		RunE:    RunLogs,
+	}
+
+	logsCmd.Flags().StringVar(&logsCoordinatorDataDir, "coordinator-data-directory", os.Getenv("COORDINATOR_DATA_DIRECTORY"), "Data directory of the coordinator (default $COORDINATOR_DATA_DIRECTORY)")
+	logsCmd.Flags().IntSliceVar(&logsContents, "content", nil, "Only show the logs of the segments with the given content IDs")
+	logsCmd.Flags().StringArrayVar(&logsHosts, "host", nil, "Only show the logs on the given hosts")
+	logsCmd.Flags().BoolVar(&logsAgent, "agent", false, "Show the logs of the agents and the utilities they ran instead of the segments")
+	logsCmd.Flags().BoolVar(&logsList, "list", false, "List the log files instead of showing their contents")
+	logsCmd.Flags().BoolVarP(&logsFollow, "follow", "f", false, "Keep showing the lines appended to the latest log files")
+	logsCmd.Flags().StringVar(&logsGrep, "grep", "", "Only show the lines matching the regular expression")
+	logsCmd.Flags().StringVar(&logsSince, "since", "", "Only show the lines logged since a duration ago, e.g. 1h, or since a date, e.g. 2024-01-31 or 2024-01-31T15:04:05Z")
+	logsCmd.Flags().StringVar(&logsUntil, "until", "", "Only show the lines logged until a duration ago or a date, in the same format as --since")
+	logsCmd.Flags().StringVar(&logsSeverity, "severity", "", "Only show the lines with at least the given severity, e.g. warning")
+	logsCmd.Flags().IntVar(&logsTail, "tail", 100, "Number of last lines to show from each log file, 0 to show all of them")
+	logsCmd.Flags().StringVar(&logsFile, "file", "", "Name of the log file to show instead of the latest one")
+	logsCmd.MarkFlagsMutuallyExclusive("agent", "content")
+	logsCmd.MarkFlagsMutuallyExclusive("list", "follow")
+
+	logsCmd.AddCommand(logsCollectCmd())
+
+	return logsCmd
+}
+
+func logsCollectCmd() *cobra.Command {
+	collectCmd := &cobra.Command{
+		Use:   "collect",
+		Short: "Create a support bundle with the logs, configuration and facts of all hosts",
+		Long: `Create a support bundle with the logs, configuration and facts of all hosts.
+
+The bundle is a compressed tar archive holding, for each host, the logs of the
+agent and segments, gp.conf with its secrets redacted, the service files, the
+configuration files and pg_controldata output of the segments, and the host
+facts. It also holds a snapshot of gp_segment_configuration and a manifest
+listing the files collected, skipped or truncated because of the size limit,
+and the hosts which could not be collected.`,
+		Example: `gp logs collect
+gp logs collect --since 2h --hosts sdw1,sdw2 --output /tmp/case_1234.tar.gz`,
+		Args:    cobra.NoArgs,
+		PreRunE: InitializeCommand,
+		RunE:    RunLogsCollect,
+	}
+
+	collectCmd.Flags().StringVar(&collectCoordinatorDataDir, "coordinator-data-directory", os.Getenv("COORDINATOR_DATA_DIRECTORY"), "Data directory of the coordinator (default $COORDINATOR_DATA_DIRECTORY)")
+	collectCmd.Flags().StringVar(&collectSince, "since", constants.DefaultSupportBundleSince, "Only collect the log files modified since a duration ago, e.g. 2h, or since a date, e.g. 2024-01-31")
+	collectCmd.Flags().StringSliceVar(&collectHosts, "hosts", nil, "Only collect the given hosts")
+	collectCmd.Flags().Int64Var(&collectMaxHostSizeMB, "max-host-size", constants.DefaultSupportBundleMaxHostSizeMB, "Maximum size in MB of the files collected on each host, 0 for no limit")
+	collectCmd.Flags().StringVar(&collectOutputFile, "output", "", "Path of the support bundle (default gp_support_<time>.tar.gz in the current directory)")
+
+	return collectCmd
+}
+
+func RunLogsCollect(cmd *cobra.Command, args []string) error {
+	now := time.Now()
+	since, err := ParseSince(collectSince, now)
+	if err != nil {
+		return err
+	}
+
+	if collectMaxHostSizeMB < 0 {
+		return fmt.Errorf("invalid value %d for --max-host-size, expected 0 or more MB", collectMaxHostSizeMB)
+	}
+
+	output := collectOutputFile
+	if output == "" {
+		output = fmt.Sprintf("gp_support_%s.tar.gz", now.Format("20060102_150405"))
+	}
+	// the bundle is written by the hub, which runs in another directory
+	output, err = filepath.Abs(output)
+	if err != nil {
+		return err
+	}
+
+	req := &idl.CollectLogsRequest{
+		CoordinatorDataDir: collectCoordinatorDataDir,
+		Hosts:              collectHosts,
+		MaxHostSize:        collectMaxHostSizeMB * 1024 * 1024,
+		OutputFile:         output,
+	}
+	if !since.IsZero() {
+		req.Since = since.Unix()
+	}
+
+	client, err := ConnectToHub(Conf)
+	if err != nil {
+		return err
+	}
+
+	stream, err := client.CollectLogs(CommandContext, req)
+	if err != nil {
+		return utils.FormatGrpcError(err)
+	}
+
+	return ParseStreamResponse(stream)
+}
+
+func RunLogs(cmd *cobra.Command, args []string) error {
+	now := time.Now()
+	since, err := ParseTimeFlag("since", logsSince, now)
+	if err != nil {
+		return err
+	}
+
+	until, err := ParseTimeFlag("until", logsUntil, now)
+	if err != nil {
+		return err
+	}
+
+	if logsTail < 0 {
+		return fmt.Errorf("invalid value %d for --tail, expected 0 or more lines", logsTail)
+	}
+
+	req := &idl.GetLogsRequest{
+		CoordinatorDataDir: logsCoordinatorDataDir,
+		Hosts:              logsHosts,
+		Agent:              logsAgent,
+		List:               logsList,
+		Follow:             logsFollow,
+		Filter: &idl.LogFilter{
+			MinSeverity: logsSeverity,
+			Grep:        logsGrep,
+			Tail:        int32(logsTail),
+			File:        logsFile,
+		},
+	}
+	for _, content := range logsContents {
+		req.Contents = append(req.Contents, int32(content))
+	}
+	if !since.IsZero() {
+		req.Filter.Since = since.Unix()
+	}
+	if !until.IsZero() {
+		req.Filter.Until = until.Unix()
+	}
+
+	client, err := ConnectToHub(Conf)
+	if err != nil {
+		return err
+	}
+
+	stream, err := client.GetLogs(CommandContext, req)
+	if err != nil {
+		return utils.FormatGrpcError(err)
+	}
+
+	return PrintLogs(os.Stdout, stream)
+}
+
+type LogsReceiver interface {
+	Recv() (*idl.GetLogsReply, error)
+}
+
+/*
+PrintLogs prints the log lines or files as they are received, prefixed with
+their host and segment, and returns an error if the logs could not be read on
+any of them.
+*/
+func PrintLogs(out io.Writer, stream LogsReceiver) error {
+	var failed int
+	for {
+		reply, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return utils.FormatGrpcError(err)
+		}
+
+		source := formatLogSource(reply)
+		if reply.Error != "" {
+			failed++
+			fmt.Fprintf(out, "%s: ERROR: %s\n", source, reply.Error)
+			continue
+		}
+
+		if len(reply.Files) > 0 {
+			fmt.Fprintln(out, source)
+			for _, file := range reply.Files {
+				fmt.Fprintf(out, "\t%s\t%d bytes\t%s\n", file.Name, file.Size, time.Unix(file.ModTime, 0).Local().Format(time.RFC3339))
+			}
+		}
+
+		for _, line := range reply.Lines {
+			fmt.Fprintf(out, "%s %s: %s\n", source, reply.File, line)
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("failed to read the logs on %d host(s) or segment(s)", failed)
+	}
+
+	return nil
+}
+
+func formatLogSource(reply *idl.GetLogsReply) string {
+	if reply.Segment == nil {
+		return reply.Host
+	}
+
+	return fmt.Sprintf("%s content=%d dbid=%d", reply.Host, reply.Segment.Contentid, reply.Segment.Dbid)
+}
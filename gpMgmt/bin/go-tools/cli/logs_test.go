@@ -0,0 +1,91 @@
+package cli_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/greenplum-db/gpdb/gp/cli"
+	"github.com/greenplum-db/gpdb/gp/idl"
+)
+
+type logsReceiver struct {
+	replies []*idl.GetLogsReply
+	err     error
+}
+
+func (r *logsReceiver) Recv() (*idl.GetLogsReply, error) {
+	if len(r.replies) == 0 {
+		return nil, r.err
+	}
+
+	reply := r.replies[0]
+	r.replies = r.replies[1:]
+
+	return reply, nil
+}
+
+func TestPrintLogs(t *testing.T) {
+	seg := &idl.Segment{HostName: "sdw1", Contentid: 0, Dbid: 2}
+
+	t.Run("prints the lines and files with their host and segment", func(t *testing.T) {
+		modTime := time.Date(2024, 1, 31, 12, 0, 0, 0, time.Local)
+		var out bytes.Buffer
+		err := cli.PrintLogs(&out, &logsReceiver{
+			replies: []*idl.GetLogsReply{
+				{Host: "sdw1", Segment: seg, File: "gpdb.csv", Lines: []string{"line 1", "line 2"}},
+				{Host: "sdw2", Files: []*idl.LogFile{{Name: "gp_agent.log", Size: 42, ModTime: modTime.Unix()}}},
+			},
+			err: io.EOF,
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		expected := `sdw1 content=0 dbid=2 gpdb.csv: line 1
+sdw1 content=0 dbid=2 gpdb.csv: line 2
+sdw2
+	gp_agent.log	42 bytes	` + modTime.Format(time.RFC3339) + "\n"
+		if out.String() != expected {
+			t.Fatalf("got %q, want %q", out.String(), expected)
+		}
+	})
+
+	t.Run("returns an error when the logs could not be read on some hosts", func(t *testing.T) {
+		var out bytes.Buffer
+		err := cli.PrintLogs(&out, &logsReceiver{
+			replies: []*idl.GetLogsReply{
+				{Host: "sdw1", Segment: seg, Error: "no log files found"},
+				{Host: "sdw2", File: "gp_agent.log", Lines: []string{"line"}},
+			},
+			err: io.EOF,
+		})
+
+		expected := "failed to read the logs on 1 host(s) or segment(s)"
+		if err == nil || err.Error() != expected {
+			t.Fatalf("got %v, want %s", err, expected)
+		}
+		if !strings.Contains(out.String(), "sdw1 content=0 dbid=2: ERROR: no log files found\n") {
+			t.Fatalf("expected the error to be printed, got %q", out.String())
+		}
+	})
+
+	t.Run("returns the error of the stream", func(t *testing.T) {
+		expected := errors.New("error")
+		err := cli.PrintLogs(&bytes.Buffer{}, &logsReceiver{err: expected})
+		if !errors.Is(err, expected) {
+			t.Fatalf("got %#v, want %#v", err, expected)
+		}
+	})
+}
+
+func TestParseTimeFlag(t *testing.T) {
+	_, err := cli.ParseTimeFlag("until", "tomorrow", time.Now())
+	expected := `invalid value "tomorrow" for --until, expected a duration such as 24h or a date such as 2024-01-31`
+	if err == nil || err.Error() != expected {
+		t.Fatalf("got %v, want %s", err, expected)
+	}
+}
@@ -1,7 +1,6 @@
 package cli
 
 import (
-	"context"
 	"fmt"
 	"time"
 
@@ -118,7 +117,7 @@ func StartAgentsAllFunc(hubConfig *hub.Config) (idl.HubClient, error) {
 		return client, err
 	}
 
-	_, err = client.StartAgents(context.Background(), &idl.StartAgentsRequest{})
+	_, err = client.StartAgents(CommandContext, &idl.StartAgentsRequest{})
 	if err != nil {
 		return client, fmt.Errorf("could not start agents: %w", err)
 	}
@@ -1,12 +1,15 @@
 package cli
 
 import (
-	"context"
+	"errors"
 	"fmt"
+	"io"
 	"os"
+	"time"
 
 	"github.com/greenplum-db/gpdb/gp/hub"
 	"github.com/greenplum-db/gpdb/gp/idl"
+	"github.com/greenplum-db/gpdb/gp/utils"
 	"github.com/spf13/cobra"
 )
 
@@ -25,6 +28,7 @@ func statusCmd() *cobra.Command {
 	statusCmd.AddCommand(statusHubCmd())
 	statusCmd.AddCommand(statusAgentsCmd())
 	statusCmd.AddCommand(statusServicesCmd())
+	statusCmd.AddCommand(statusCertsCmd())
 
 	return statusCmd
 }
@@ -101,13 +105,31 @@ func ShowAgentsStatusFunc(conf *hub.Config, skipHeader bool) error {
 		return err
 	}
 
-	reply, err := client.StatusAgents(context.Background(), &idl.StatusAgentsRequest{})
+	reply, err := client.StatusAgents(CommandContext, &idl.StatusAgentsRequest{})
 
 	if err != nil {
 		return err
 	}
 	Platform.DisplayServiceStatus(os.Stdout, "Agent", reply.Statuses, skipHeader)
 
+	return PrintStatusErrors(os.Stdout, reply.Statuses)
+}
+
+// PrintStatusErrors prints the error of each host whose status could not be
+// fetched, and returns an error if there is any
+func PrintStatusErrors(out io.Writer, statuses []*idl.ServiceStatus) error {
+	failed := 0
+	for _, status := range statuses {
+		if status.Error != "" {
+			failed++
+			fmt.Fprintf(out, "Could not get the status of the agent on host %s: %s\n", status.Host, status.Error)
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("could not get the status of the agents on %d host(s)", failed)
+	}
+
 	return nil
 }
 
@@ -137,3 +159,29 @@ func PrintServicesStatusFunc() error {
 
 	return nil
 }
+
+func statusCertsCmd() *cobra.Command {
+	statusCertsCmd := &cobra.Command{
+		Use:     "certs",
+		Short:   "Display the expiry of the certificates configured in gp.conf",
+		Args:    cobra.NoArgs,
+		PreRunE: InitializeCommand,
+		RunE:    RunStatusCerts,
+	}
+
+	return statusCertsCmd
+}
+
+/*
+RunStatusCerts prints when each certificate configured in gp.conf expires, as
+read on this host. The certificates generated by gp configure are issued
+together for all hosts, so they expire at the same time on the segment hosts.
+*/
+func RunStatusCerts(cmd *cobra.Command, args []string) error {
+	creds, ok := Conf.Credentials.(*utils.GpCredentials)
+	if !ok {
+		return errors.New("could not read the certificate configuration")
+	}
+
+	return PrintCertificateStatuses(os.Stdout, GetCertificateStatuses(creds), time.Now())
+}
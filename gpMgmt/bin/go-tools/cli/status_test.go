@@ -117,6 +117,25 @@ func TestShowAgentStatus(t *testing.T) {
 			t.Fatalf("unexpected error: %#v", err)
 		}
 	})
+	t.Run("returns error when the status of some hosts could not be fetched", func(t *testing.T) {
+		defer resetCLIVars()
+		cli.ConnectToHub = func(conf *hub.Config) (idl.HubClient, error) {
+			hubClient := mock_idl.NewMockHubClient(ctrl)
+			hubClient.EXPECT().StatusAgents(gomock.Any(), gomock.Any()).Return(&idl.StatusAgentsReply{
+				Statuses: []*idl.ServiceStatus{
+					{Host: "sdw1", Status: "running", Uptime: "5H", Pid: 123},
+					{Host: "sdw2", Status: "Unknown", Error: "connection refused"},
+				},
+			}, nil)
+			return hubClient, nil
+		}
+
+		err := cli.ShowAgentsStatus(cli.Conf, true)
+		expected := "could not get the status of the agents on 1 host(s)"
+		if err == nil || err.Error() != expected {
+			t.Fatalf("got %v, want %s", err, expected)
+		}
+	})
 	t.Run("returns error when there error connecting Hub", func(t *testing.T) {
 		defer resetCLIVars()
 		expectedStr := "TEST Error connecting Hub"
@@ -217,3 +236,23 @@ func TestRunStatusHub(t *testing.T) {
 		}
 	})
 }
+
+func TestPrintStatusErrors(t *testing.T) {
+	var out strings.Builder
+	err := cli.PrintStatusErrors(&out, []*idl.ServiceStatus{
+		{Host: "sdw1", Status: "running"},
+		{Host: "sdw2", Status: "Unknown", Error: "connection refused"},
+		{Host: "sdw3", Status: "Unknown", Error: "deadline exceeded"},
+	})
+
+	expected := "Could not get the status of the agent on host sdw2: connection refused\n" +
+		"Could not get the status of the agent on host sdw3: deadline exceeded\n"
+	if out.String() != expected {
+		t.Fatalf("got %q, want %q", out.String(), expected)
+	}
+
+	expectedErr := "could not get the status of the agents on 2 host(s)"
+	if err == nil || err.Error() != expectedErr {
+		t.Fatalf("got %v, want %s", err, expectedErr)
+	}
+}
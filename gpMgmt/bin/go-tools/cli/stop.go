@@ -1,11 +1,11 @@
 package cli
 
 import (
-	"context"
 	"fmt"
 
 	"github.com/greenplum-db/gp-common-go-libs/gplog"
 	"github.com/greenplum-db/gpdb/gp/idl"
+	"github.com/greenplum-db/gpdb/gp/utils"
 	"github.com/spf13/cobra"
 	"google.golang.org/grpc/codes"
 	grpcStatus "google.golang.org/grpc/status"
@@ -55,9 +55,15 @@ func RunStopHub(cmd *cobra.Command, args []string) error {
 func StopHubServiceFunc() error {
 	client, err := ConnectToHub(Conf)
 	if err != nil {
+		// without a service manager, the hub can still be stopped by its pid
+		if processPlatform, ok := Platform.(utils.ProcessPlatform); ok {
+			gplog.Warn("Could not connect to the hub, stopping it by its pid file: %v", err)
+			return processPlatform.StopService(fmt.Sprintf("%s_hub", Conf.ServiceName))
+		}
+
 		return fmt.Errorf("could not connect to hub; is the hub running? Error: %v", err)
 	}
-	_, err = client.Stop(context.Background(), &idl.StopHubRequest{})
+	_, err = client.Stop(CommandContext, &idl.StopHubRequest{})
 	// Ignore a "hub already stopped" error
 	if err != nil {
 		errCode := grpcStatus.Code(err)
@@ -100,7 +106,7 @@ func StopAgentServiceFunc() error {
 		return fmt.Errorf("could not connect to hub; is the hub running? Error: %v", err)
 	}
 
-	_, err = client.StopAgents(context.Background(), &idl.StopAgentsRequest{})
+	_, err = client.StopAgents(CommandContext, &idl.StopAgentsRequest{})
 	if err != nil {
 		return fmt.Errorf("could not stop agents: %w", err)
 	}
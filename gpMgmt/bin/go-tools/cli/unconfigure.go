@@ -0,0 +1,242 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/exp/slices"
+
+	"github.com/greenplum-db/gp-common-go-libs/gplog"
+	"github.com/greenplum-db/gpdb/gp/constants"
+	"github.com/greenplum-db/gpdb/gp/utils"
+)
+
+var (
+	unconfigureForce              bool
+	unconfigureDisableLingering   bool
+	unconfigureCoordinatorDataDir string
+
+	RunOnHost = RunOnHostFn
+)
+
+func unconfigureCmd() *cobra.Command {
+	unconfigureCmd := &cobra.Command{
+		Use:   "unconfigure",
+		Short: "Remove the hub and agent services and gp.conf from all hosts",
+		Long: `Remove the hub and agent services and gp.conf from all hosts.
+
+The hub and agents are stopped, their service files are removed from all hosts
+and the service manager is reloaded, and gp.conf is removed from all hosts. The
+result is reported for each host. This is refused while the cluster is running,
+or when the coordinator data directory is not set, unless --force is passed.`,
+		Args:    cobra.NoArgs,
+		PreRunE: InitializeCommand,
+		RunE:    RunUnconfigure,
+	}
+
+	addUnconfigureFlags(unconfigureCmd)
+	unconfigureCmd.Flags().StringVar(&serviceDir, "service-dir", fmt.Sprintf(DefaultServiceDir, os.Getenv("USER")), `Path to service file directory`)
+	unconfigureCmd.Flags().StringVar(&serviceUser, "service-user", os.Getenv("USER"), `User for whom the service was configured`)
+
+	return unconfigureCmd
+}
+
+// addUnconfigureFlags adds the flags of gp unconfigure which gp configure
+// --uninstall also accepts
+func addUnconfigureFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&unconfigureForce, "force", false, `Remove the services even if the cluster is running`)
+	cmd.Flags().BoolVar(&unconfigureDisableLingering, "disable-lingering", false, `Also disable lingering of the service user, enabled by gp configure`)
+	cmd.Flags().StringVar(&unconfigureCoordinatorDataDir, "coordinator-data-directory", os.Getenv("COORDINATOR_DATA_DIRECTORY"), "Data directory of the coordinator, to check whether the cluster is running (default $COORDINATOR_DATA_DIRECTORY)")
+}
+
+// UnconfigureResult is the outcome of removing the services on a host
+type UnconfigureResult struct {
+	Host  string
+	Error error
+}
+
+func RunUnconfigure(cmd *cobra.Command, args []string) error {
+	if !unconfigureForce {
+		err := checkClusterStopped(unconfigureCoordinatorDataDir)
+		if err != nil {
+			return err
+		}
+	}
+
+	// The services are also stopped when their service files are removed, so
+	// this only stops them gracefully. Without a service manager, the pid
+	// files of the processes still running are kept, so that they can still
+	// be found and stopped.
+	_, processManager := Platform.(utils.ProcessPlatform)
+	var running []string
+	err := StopAgentService()
+	if err != nil {
+		gplog.Warn("Could not stop the agents: %v", err)
+		if processManager {
+			running = append(running, "agent")
+		}
+	}
+	err = StopHubService()
+	if err != nil {
+		gplog.Warn("Could not stop the hub: %v", err)
+		if processManager {
+			running = append(running, "hub")
+		}
+	}
+
+	dir := serviceDir
+	if !cmd.Flags().Lookup("service-dir").Changed {
+		dir = Platform.GetDefaultServiceDir()
+		if strings.Contains(dir, "%s") {
+			dir = fmt.Sprintf(dir, serviceUser)
+		}
+	}
+
+	coordinator, err := utils.System.GetHostName()
+	if err != nil {
+		return err
+	}
+
+	results := UnconfigureHosts(coordinator, Conf.Hostnames, dir, running)
+
+	err = PrintUnconfigureReport(os.Stdout, results)
+	if err != nil {
+		return err
+	}
+
+	if clusterName != "" {
+		return unregisterCluster(clusterName)
+	}
+
+	return nil
+}
+
+// checkClusterStopped returns an error if the coordinator is running
+func checkClusterStopped(coordinatorDataDir string) error {
+	if coordinatorDataDir == "" {
+		return errors.New("could not check whether the cluster is running, as the coordinator data directory is not set; pass --coordinator-data-directory, or --force if the cluster is stopped")
+	}
+
+	pid, err := readPostmasterPid(filepath.Join(coordinatorDataDir, "postmaster.pid"))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if utils.ProcessIsRunning(pid) {
+		return fmt.Errorf("the cluster is running with the coordinator pid %d, stop it first or pass --force", pid)
+	}
+
+	return nil
+}
+
+// readPostmasterPid returns the pid on the first line of postmaster.pid
+func readPostmasterPid(path string) (int, error) {
+	contents, err := utils.System.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+
+	line, _, _ := strings.Cut(string(contents), "\n")
+	pid, err := strconv.Atoi(strings.TrimSpace(line))
+	if err != nil || pid <= 0 {
+		return 0, fmt.Errorf("invalid pid file %s: %q", path, line)
+	}
+
+	return pid, nil
+}
+
+/*
+UnconfigureHosts removes the agent service and gp.conf from all hosts, and the
+hub service from the coordinator host, in parallel. The services of the
+running processes, "hub" or "agent", are kept. It returns the result of each
+host, sorted by host.
+*/
+func UnconfigureHosts(coordinator string, hostnames []string, serviceDir string, running []string) []*UnconfigureResult {
+	hosts := append([]string{coordinator}, hostnames...)
+	sort.Strings(hosts)
+
+	var results []*UnconfigureResult
+	var wg sync.WaitGroup
+	for i, host := range hosts {
+		if i > 0 && host == hosts[i-1] {
+			continue
+		}
+
+		var steps []string
+		if host == coordinator && !slices.Contains(running, "hub") {
+			steps = append(steps, Platform.GetRemoveServiceCommand(serviceDir, Conf.ServiceName, "hub"))
+		}
+		if slices.Contains(hostnames, host) && !slices.Contains(running, "agent") {
+			steps = append(steps, Platform.GetRemoveServiceCommand(serviceDir, Conf.ServiceName, "agent"))
+		}
+		if lingering := Platform.GetDisableLingeringCommand(serviceUser); unconfigureDisableLingering && lingering != "" {
+			steps = append(steps, lingering)
+		}
+		steps = append(steps, fmt.Sprintf("rm -f %s", ConfigFilePath))
+
+		result := &UnconfigureResult{Host: host}
+		results = append(results, result)
+		wg.Add(1)
+		go func(host string, command string) {
+			defer wg.Done()
+
+			result.Error = RunOnHost(host, command)
+		}(host, fmt.Sprintf("(%s)", strings.Join(steps, ") && (")))
+	}
+	wg.Wait()
+
+	return results
+}
+
+// RunOnHostFn runs the shell command on the host with gpssh, and checks that
+// it succeeded from its output
+func RunOnHostFn(host string, command string) error {
+	utility := filepath.Join(Conf.GpHome, "bin", constants.GpSSH)
+	out, err := utils.System.ExecCommand(utility, "-h", host, fmt.Sprintf("%s && echo %s", command, constants.RemoteSuccessMarker)).CombinedOutput()
+	output := strings.TrimSpace(strings.ReplaceAll(string(out), constants.RemoteSuccessMarker, ""))
+	if err != nil {
+		return fmt.Errorf("%w: %s", err, output)
+	}
+	if !strings.Contains(string(out), constants.RemoteSuccessMarker) {
+		return fmt.Errorf("the command failed: %s", output)
+	}
+
+	return nil
+}
+
+// PrintUnconfigureReport prints the result of each host, and returns an error
+// if any host failed
+func PrintUnconfigureReport(out io.Writer, results []*UnconfigureResult) error {
+	w := new(tabwriter.Writer)
+	w.Init(out, 0, 8, 2, '\t', 0)
+
+	failed := 0
+	fmt.Fprintln(w, "HOST\tRESULT\tERROR")
+	for _, result := range results {
+		outcome, message := "removed", ""
+		if result.Error != nil {
+			failed++
+			outcome, message = "failed", result.Error.Error()
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", result.Host, outcome, orDash(message))
+	}
+	w.Flush()
+
+	if failed > 0 {
+		return fmt.Errorf("failed to remove the services from %d host(s)", failed)
+	}
+
+	return nil
+}
@@ -0,0 +1,204 @@
+package cli_test
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/greenplum-db/gp-common-go-libs/testhelper"
+	"github.com/greenplum-db/gpdb/gp/cli"
+	"github.com/greenplum-db/gpdb/gp/hub"
+	"github.com/greenplum-db/gpdb/gp/testutils"
+	"github.com/greenplum-db/gpdb/gp/testutils/exectest"
+	"github.com/greenplum-db/gpdb/gp/utils"
+)
+
+func init() {
+	exectest.RegisterMains(RemoteCommandSucceeded, RemoteCommandFailed)
+}
+
+func RemoteCommandSucceeded() {
+	os.Stdout.WriteString("[sdw1] GP_REMOTE_COMMAND_SUCCEEDED\n")
+}
+
+func RemoteCommandFailed() {
+	os.Stdout.WriteString("[sdw1] sudo: a password is required\n")
+}
+
+func TestUnconfigureHosts(t *testing.T) {
+	testhelper.SetupTestLogger()
+
+	setup := func(t *testing.T, platform *testutils.MockPlatform, failedHost string) map[string]string {
+		t.Helper()
+
+		var mu sync.Mutex
+		commands := make(map[string]string)
+		cli.RunOnHost = func(host string, command string) error {
+			mu.Lock()
+			defer mu.Unlock()
+			commands[host] = command
+
+			if host == failedHost {
+				return errors.New("the command failed")
+			}
+			return nil
+		}
+		cli.Platform = platform
+		cli.Conf = &hub.Config{ServiceName: "gp"}
+		cli.ConfigFilePath = "/usr/local/gpdb/gp.conf"
+		t.Cleanup(func() {
+			cli.RunOnHost = cli.RunOnHostFn
+			cli.Platform = utils.GetPlatform()
+		})
+
+		return commands
+	}
+
+	t.Run("removes the hub from the coordinator and the agents from all hosts", func(t *testing.T) {
+		commands := setup(t, &testutils.MockPlatform{}, "sdw2")
+
+		results := cli.UnconfigureHosts("cdw", []string{"sdw2", "cdw", "sdw1"}, "/services", nil)
+
+		expected := map[string]string{
+			"cdw":  "(remove /services/gp_hub) && (remove /services/gp_agent) && (rm -f /usr/local/gpdb/gp.conf)",
+			"sdw1": "(remove /services/gp_agent) && (rm -f /usr/local/gpdb/gp.conf)",
+			"sdw2": "(remove /services/gp_agent) && (rm -f /usr/local/gpdb/gp.conf)",
+		}
+		if !reflect.DeepEqual(commands, expected) {
+			t.Fatalf("got %+v, want %+v", commands, expected)
+		}
+
+		var hosts []string
+		for _, result := range results {
+			hosts = append(hosts, result.Host)
+			if (result.Error != nil) != (result.Host == "sdw2") {
+				t.Fatalf("got error %v for host %s", result.Error, result.Host)
+			}
+		}
+		if !reflect.DeepEqual(hosts, []string{"cdw", "sdw1", "sdw2"}) {
+			t.Fatalf("got hosts %+v, want them sorted", hosts)
+		}
+	})
+
+	t.Run("does not remove an agent from a coordinator without segments", func(t *testing.T) {
+		commands := setup(t, &testutils.MockPlatform{}, "")
+
+		cli.UnconfigureHosts("cdw", []string{"sdw1"}, "/services", nil)
+
+		expected := "(remove /services/gp_hub) && (rm -f /usr/local/gpdb/gp.conf)"
+		if commands["cdw"] != expected {
+			t.Fatalf("got %q, want %q", commands["cdw"], expected)
+		}
+	})
+
+	t.Run("keeps the services of the processes still running", func(t *testing.T) {
+		commands := setup(t, &testutils.MockPlatform{}, "")
+
+		cli.UnconfigureHosts("cdw", []string{"cdw", "sdw1"}, "/services", []string{"agent"})
+
+		expected := map[string]string{
+			"cdw":  "(remove /services/gp_hub) && (rm -f /usr/local/gpdb/gp.conf)",
+			"sdw1": "(rm -f /usr/local/gpdb/gp.conf)",
+		}
+		if !reflect.DeepEqual(commands, expected) {
+			t.Fatalf("got %+v, want %+v", commands, expected)
+		}
+	})
+}
+
+func TestRunOnHostFn(t *testing.T) {
+	cli.Conf = &hub.Config{GpHome: "/usr/local/gpdb"}
+
+	t.Run("succeeds when the command reports its success", func(t *testing.T) {
+		defer utils.ResetSystemFunctions()
+		utils.System.ExecCommand = exectest.NewCommandWithVerifier(RemoteCommandSucceeded, func(utility string, args ...string) {
+			expected := []string{"-h", "sdw1", "rm -f /usr/local/gpdb/gp.conf && echo GP_REMOTE_COMMAND_SUCCEEDED"}
+			if utility != "/usr/local/gpdb/bin/gpssh" || !reflect.DeepEqual(args, expected) {
+				t.Fatalf("got %s %+v, want gpssh %+v", utility, args, expected)
+			}
+		})
+
+		err := cli.RunOnHostFn("sdw1", "rm -f /usr/local/gpdb/gp.conf")
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+	})
+
+	t.Run("errors with the output when the command fails", func(t *testing.T) {
+		defer utils.ResetSystemFunctions()
+		utils.System.ExecCommand = exectest.NewCommand(RemoteCommandFailed)
+
+		err := cli.RunOnHostFn("sdw1", "sudo -n rm -f /etc/systemd/system/gp_agent.service")
+		expected := "the command failed: [sdw1] sudo: a password is required"
+		if err == nil || err.Error() != expected {
+			t.Fatalf("got %v, want %s", err, expected)
+		}
+	})
+}
+
+func TestRunUnconfigure(t *testing.T) {
+	testhelper.SetupTestLogger()
+
+	t.Run("refuses while the coordinator is running", func(t *testing.T) {
+		dataDir := t.TempDir()
+		err := os.WriteFile(filepath.Join(dataDir, "postmaster.pid"), []byte(fmt.Sprintf("%d\n%s\n", os.Getpid(), dataDir)), 0600)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		cmd, _, err := cli.RootCommand().Find([]string{"unconfigure"})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		err = cmd.Flags().Set("coordinator-data-directory", dataDir)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		err = cli.RunUnconfigure(cmd, nil)
+		expected := fmt.Sprintf("the cluster is running with the coordinator pid %d, stop it first or pass --force", os.Getpid())
+		if err == nil || err.Error() != expected {
+			t.Fatalf("got %v, want %s", err, expected)
+		}
+	})
+
+	t.Run("refuses when the coordinator data directory is not set", func(t *testing.T) {
+		cmd, _, err := cli.RootCommand().Find([]string{"unconfigure"})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		err = cmd.Flags().Set("coordinator-data-directory", "")
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		err = cli.RunUnconfigure(cmd, nil)
+		expected := "could not check whether the cluster is running, as the coordinator data directory is not set; pass --coordinator-data-directory, or --force if the cluster is stopped"
+		if err == nil || err.Error() != expected {
+			t.Fatalf("got %v, want %s", err, expected)
+		}
+	})
+}
+
+func TestPrintUnconfigureReport(t *testing.T) {
+	var out strings.Builder
+	err := cli.PrintUnconfigureReport(&out, []*cli.UnconfigureResult{
+		{Host: "cdw"},
+		{Host: "sdw1", Error: errors.New("sudo: a password is required")},
+	})
+
+	expected := "HOST\tRESULT\t\tERROR\ncdw\tremoved\t\t-\nsdw1\tfailed\t\tsudo: a password is required\n"
+	if out.String() != expected {
+		t.Fatalf("got %q, want %q", out.String(), expected)
+	}
+
+	expectedErr := "failed to remove the services from 1 host(s)"
+	if err == nil || err.Error() != expectedErr {
+		t.Fatalf("got %v, want %s", err, expectedErr)
+	}
+}
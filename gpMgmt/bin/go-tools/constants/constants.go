@@ -7,6 +7,7 @@ const (
 	ConfigFileName      = "gp.conf"
 	ShellPath           = "/bin/bash"
 	GpSSH               = "gpssh"
+	GpSync              = "gpsync"
 	MaxRetries          = 10
 	PlatformDarwin      = "darwin"
 	PlatformLinux       = "linux"
@@ -35,3 +36,86 @@ const (
 const (
 	GpSegmentConfiguration = "gp_segment_configuration"
 )
+
+// pg_hba.conf authentication methods
+const (
+	AuthTrust       = "trust"
+	AuthScramSha256 = "scram-sha-256"
+	AuthMd5         = "md5"
+	AuthCert        = "cert"
+)
+
+// Echoed by the commands run with gpssh, which does not return their status
+const RemoteSuccessMarker = "GP_REMOTE_COMMAND_SUCCEEDED"
+
+// Certificates generated by gp configure
+const (
+	DefaultCertificatesDir  = "certificates"
+	DefaultCertValidityDays = 365
+	CertExpiryWarningDays   = 30 // certificates expiring sooner are reported by gp status certs
+)
+
+// Limits of the segment operations the hub runs at once, e.g. initdb or
+// pg_basebackup
+const (
+	DefaultBatchSize = 4  // on each host
+	DefaultParallel  = 64 // in the whole cluster
+)
+
+// Seconds the disk check writes and then reads each directory at most, when
+// the request does not set it
+const DefaultDiskTestDuration = 60
+
+// Audit log of the hub
+const (
+	AuditLogFileName         = "gp_audit.log"
+	DefaultAuditLogMaxSizeMB = 100
+	DefaultAuditLogMaxFiles  = 10
+)
+
+// Support bundle created by gp logs collect
+const (
+	DiagnosticsManifestName           = "manifest.json"
+	DefaultSupportBundleMaxHostSizeMB = 500
+	DefaultSupportBundleSince         = "24h"
+)
+
+// Service managers running the hub and agents, set in gp.conf
+const (
+	ServiceManagerSystemd = "systemd"
+	ServiceManagerLaunchd = "launchd"
+	ServiceManagerProcess = "process" // pid files, without a service manager
+	SystemServiceDir      = "/etc/systemd/system"
+)
+
+// Cluster contexts of the user, in <user-config-dir>/gp, e.g. ~/.config/gp
+const (
+	ClustersDirName  = "gp"
+	ClustersFileName = "clusters.json"
+)
+
+// Output of the hub and agent services, set in gp.conf
+const (
+	ServiceLogOutputFile         = "file" // <log-dir>/<service-name>_<hub|agent>.log
+	ServiceLogOutputJournald     = "journald"
+	ServiceLogOutputSyslog       = "syslog"
+	DefaultServiceLogMaxSizeMB   = 100
+	DefaultServiceLogRotateHours = 24
+	DefaultServiceLogMaxFiles    = 10
+	DefaultServiceLogMaxAgeDays  = 30
+)
+
+// Network check run by gp check network
+const (
+	DefaultCheckNetworkTimeout   = 5
+	DefaultCheckNetworkMeasureMB = 64
+)
+
+// Disk check run by gp check disk
+const (
+	DefaultCheckDiskSizeMB   = 1024
+	DefaultCheckDiskDuration = 60
+)
+
+// Environment variable holding the superuser password for gp init
+const SuPasswordEnvVar = "GP_SU_PASSWORD"
@@ -7,11 +7,12 @@ import (
 	"strconv"
 	"sync"
 
-	"github.com/greenplum-db/gp-common-go-libs/gplog"
 	"github.com/greenplum-db/gpdb/gp/constants"
 	"github.com/greenplum-db/gpdb/gp/idl"
 	"github.com/greenplum-db/gpdb/gp/utils"
 	"github.com/greenplum-db/gpdb/gp/utils/greenplum"
+	"github.com/greenplum-db/gpdb/gp/utils/postgres"
+	"github.com/greenplum-db/gpdb/gp/utils/tracing"
 )
 
 func (s *Server) AddMirrors(req *idl.AddMirrorsRequest, stream idl.Hub_AddMirrorsServer) error {
@@ -21,67 +22,68 @@ func (s *Server) AddMirrors(req *idl.AddMirrorsRequest, stream idl.Hub_AddMirror
 	// Make sure all agents are up and listening for requests
 	err := s.DialAllAgents()
 	if err != nil {
-		return utils.LogAndReturnError(err)
+		return tracing.LogAndReturnError(stream.Context(), err)
 	}
 
 	conn, err := greenplum.GetCoordinatorConn(req.CoordinatorDataDir, "", true)
 	if err != nil {
-		return utils.LogAndReturnError(err)
+		return tracing.LogAndReturnError(stream.Context(), err)
 	}
 	defer conn.Close()
 
 	gparray, err := greenplum.NewGpArrayFromCatalog(conn)
 	if err != nil {
-		return utils.LogAndReturnError(err)
+		return tracing.LogAndReturnError(stream.Context(), err)
 	}
 
 	// Check if the number of primary and mirror segments are equal
 	hubStream.StreamLogMsg("Checking if the number of primary segments and the number of mirrors to add are equal")
 	if len(gparray.GetPrimarySegments()) != len(req.Mirrors) {
-		return utils.LogAndReturnError(fmt.Errorf("number of mirrors %d is not equal to the number of primaries %d present in the cluster", len(req.Mirrors), len(gparray.GetPrimarySegments())))
+		return tracing.LogAndReturnError(stream.Context(), fmt.Errorf("number of mirrors %d is not equal to the number of primaries %d present in the cluster", len(req.Mirrors), len(gparray.GetPrimarySegments())))
 	}
 
 	// Check if the cluster already has mirrors, if yes error out
 	hubStream.StreamLogMsg("Checking if the cluster already has mirrors")
 	if gparray.HasMirrors() {
-		return utils.LogAndReturnError(fmt.Errorf("cannot add mirrors, the cluster is already configured with mirrors"))
+		return tracing.LogAndReturnError(stream.Context(), fmt.Errorf("cannot add mirrors, the cluster is already configured with mirrors"))
 	}
 
 	// Register the mirrors to the gp_segment_configuration
 	hubStream.StreamLogMsg("Starting to register mirror segments with the coordinator")
 	err = greenplum.RegisterMirrorSegments(req.Mirrors, conn)
 	if err != nil {
-		return utils.LogAndReturnError(err)
+		return tracing.LogAndReturnError(stream.Context(), err)
 	}
 	hubStream.StreamLogMsg("Successfully registered the mirror segments with the coordinator")
 
 	// Build the new gparray and validate it
 	gparray, err = greenplum.NewGpArrayFromCatalog(conn)
 	if err != nil {
-		return utils.LogAndReturnError(err)
+		return tracing.LogAndReturnError(stream.Context(), err)
 	}
 
 	// Update the pg_hba.conf on the primary segments - Agent RPC
 	hubStream.StreamLogMsg("Starting to modify the pg_hba.conf on the primary segments to add mirror entries")
-	err = s.UpdatePgHbaConfWithMirrorEntries(gparray, req.Mirrors, req.HbaHostnames)
+	auth := postgres.HbaAuth{Method: req.HbaAuthMethod, ReplicationMethod: req.HbaReplicationAuthMethod, HostSsl: req.Ssl.GetHbaHostssl()}
+	err = s.UpdatePgHbaConfWithMirrorEntries(hubStream.Context(), gparray, req.Mirrors, req.HbaHostnames, auth, req.Parallelism)
 	if err != nil {
-		return utils.LogAndReturnError(err)
+		return tracing.LogAndReturnError(stream.Context(), err)
 	}
 	hubStream.StreamLogMsg("Successfully modified the pg_hba.conf on the primary segments")
 
 	// Run pg_basebackup aon the mirror hosts - Agent RPC
 	hubStream.StreamLogMsg("Creating mirror segments")
-	err = s.CreateMirrorSegments(&hubStream, gparray, req.Mirrors)
+	err = s.CreateMirrorSegments(&hubStream, gparray, req.Mirrors, req.Ssl, req.Parallelism)
 	if err != nil {
-		return utils.LogAndReturnError(err)
+		return tracing.LogAndReturnError(stream.Context(), err)
 	}
 	hubStream.StreamLogMsg("Successfully created mirror segments")
 
 	// Start the segment - Agent RPC
 	hubStream.StreamLogMsg("Starting up the mirror segments")
-	err = s.StartMirrorSegments(req.Mirrors)
+	err = s.StartMirrorSegments(hubStream.Context(), req.Mirrors, req.Parallelism)
 	if err != nil {
-		return utils.LogAndReturnError(err)
+		return tracing.LogAndReturnError(stream.Context(), err)
 	}
 	hubStream.StreamLogMsg("Successfully started the mirror segments")
 
@@ -89,7 +91,7 @@ func (s *Server) AddMirrors(req *idl.AddMirrorsRequest, stream idl.Hub_AddMirror
 	hubStream.StreamLogMsg("Triggering FTS probe")
 	err = greenplum.TriggerFtsProbe(req.CoordinatorDataDir)
 	if err != nil {
-		return utils.LogAndReturnError(err)
+		return tracing.LogAndReturnError(stream.Context(), err)
 	}
 
 	hubStream.StreamLogMsg("Mirror segments have been added")
@@ -99,7 +101,11 @@ func (s *Server) AddMirrors(req *idl.AddMirrorsRequest, stream idl.Hub_AddMirror
 	return nil
 }
 
-func (s *Server) CreateMirrorSegments(stream hubStreamer, gparray *greenplum.GpArray, mirrorSegs []*idl.Segment) error {
+// CreateMirrorSegments creates the mirrors from their primaries using pg_basebackup.
+// When SSL is enabled, the certificate of the mirror host replaces the one copied
+// from the primary. At most as many mirrors as the parallelism allows are copied
+// at once, so as not to saturate the network.
+func (s *Server) CreateMirrorSegments(stream hubStreamer, gparray *greenplum.GpArray, mirrorSegs []*idl.Segment, ssl *idl.SslParams, parallelism *idl.Parallelism) error {
 	mirrorHostToSegPairMap := make(map[string][]*greenplum.SegmentPair)
 	for _, seg := range mirrorSegs {
 		pair, err := gparray.GetSegmentPairForContent(int(seg.Contentid))
@@ -110,10 +116,12 @@ func (s *Server) CreateMirrorSegments(stream hubStreamer, gparray *greenplum.GpA
 		mirrorHostToSegPairMap[pair.Mirror.Hostname] = append(mirrorHostToSegPairMap[pair.Mirror.Hostname], pair)
 	}
 
+	ctx := stream.Context()
 	progressLabel := "Initializing mirror segments:"
 	progressTotal := len(mirrorSegs)
 	stream.StreamProgressMsg(progressLabel, progressTotal)
 
+	limiter := s.newLimiter(parallelism)
 	request := func(conn *Connection) error {
 		var wg sync.WaitGroup
 
@@ -126,7 +134,14 @@ func (s *Server) CreateMirrorSegments(stream hubStreamer, gparray *greenplum.GpA
 			go func(pair *greenplum.SegmentPair) {
 				defer wg.Done()
 
-				gplog.Debug(fmt.Sprintf("Starting to create mirror segment: %v", *pair.Mirror))
+				release, err := limiter.Acquire(ctx, conn.Hostname)
+				if err != nil {
+					errs <- err
+					return
+				}
+				defer release()
+
+				tracing.Debug(stream.Context(), fmt.Sprintf("Starting to create mirror segment: %v", *pair.Mirror))
 				req := &idl.PgBasebackupRequest{
 					TargetDir:           pair.Mirror.DataDir,
 					SourceHost:          pair.Primary.Hostname,
@@ -136,15 +151,32 @@ func (s *Server) CreateMirrorSegments(stream hubStreamer, gparray *greenplum.GpA
 					WriteRecoveryConf:   true,
 					ReplicationSlotName: constants.ReplicationSlotName,
 				}
-				_, err := conn.AgentClient.PgBasebackup(context.Background(), req)
+				_, err = conn.AgentClient.PgBasebackup(ctx, req)
 				if err != nil {
 					errs <- utils.FormatGrpcError(err)
 					return
 				}
-				gplog.Debug("Successfully ran pg_basebackup on segment with data directory %s on host %s", pair.Primary.DataDir, pair.Primary.Hostname)
+				tracing.Debug(stream.Context(), "Successfully ran pg_basebackup on segment with data directory %s on host %s", pair.Primary.DataDir, pair.Primary.Hostname)
+
+				if ssl.GetEnabled() {
+					cert, err := LoadSslCertificate(ssl, pair.Mirror.Hostname)
+					if err != nil {
+						errs <- err
+						return
+					}
+
+					_, err = conn.AgentClient.InstallSslCertificate(ctx, &idl.InstallSslCertificateRequest{
+						Pgdata:      pair.Mirror.DataDir,
+						Certificate: cert,
+					})
+					if err != nil {
+						errs <- utils.FormatGrpcError(err)
+						return
+					}
+				}
 
-				gplog.Debug("Starting to modify the postgresql.conf for segment with data directory %s on host %s with port value %d", pair.Mirror.DataDir, pair.Mirror.Hostname, pair.Mirror.Port)
-				_, err = conn.AgentClient.UpdatePgConf(context.Background(), &idl.UpdatePgConfRequest{
+				tracing.Debug(stream.Context(), "Starting to modify the postgresql.conf for segment with data directory %s on host %s with port value %d", pair.Mirror.DataDir, pair.Mirror.Hostname, pair.Mirror.Port)
+				_, err = conn.AgentClient.UpdatePgConf(ctx, &idl.UpdatePgConfRequest{
 					Pgdata: pair.Mirror.DataDir,
 					Params: map[string]string{
 						"port": strconv.Itoa(pair.Mirror.Port),
@@ -154,9 +186,9 @@ func (s *Server) CreateMirrorSegments(stream hubStreamer, gparray *greenplum.GpA
 				if err != nil {
 					errs <- err
 				} else {
-					gplog.Debug("Successfully modified the postgresql.conf for segment with data directory %s on host %s with port value %d", pair.Mirror.DataDir, pair.Mirror.Hostname, pair.Mirror.Port)
+					tracing.Debug(stream.Context(), "Successfully modified the postgresql.conf for segment with data directory %s on host %s with port value %d", pair.Mirror.DataDir, pair.Mirror.Hostname, pair.Mirror.Port)
 					stream.StreamProgressMsg(progressLabel, progressTotal)
-					gplog.Debug(fmt.Sprintf("Successfully created mirror segment: %v", *pair.Mirror))
+					tracing.Debug(stream.Context(), fmt.Sprintf("Successfully created mirror segment: %v", *pair.Mirror))
 				}
 			}(pair)
 		}
@@ -172,15 +204,18 @@ func (s *Server) CreateMirrorSegments(stream hubStreamer, gparray *greenplum.GpA
 		return err
 	}
 
-	return ExecuteRPC(s.Conns, request)
+	return ExecuteRPC(s.agentConns(nil), request)
 }
 
-func (s *Server) StartMirrorSegments(mirrorSegs []*idl.Segment) error {
+// StartMirrorSegments starts the mirrors, at most as many at once as the
+// parallelism allows
+func (s *Server) StartMirrorSegments(ctx context.Context, mirrorSegs []*idl.Segment, parallelism *idl.Parallelism) error {
 	hostToSegMap := make(map[string][]*idl.Segment)
 	for _, seg := range mirrorSegs {
 		hostToSegMap[seg.HostName] = append(hostToSegMap[seg.HostName], seg)
 	}
 
+	limiter := s.newLimiter(parallelism)
 	request := func(conn *Connection) error {
 		var wg sync.WaitGroup
 
@@ -193,12 +228,19 @@ func (s *Server) StartMirrorSegments(mirrorSegs []*idl.Segment) error {
 			go func(seg *idl.Segment) {
 				defer wg.Done()
 
+				release, err := limiter.Acquire(ctx, conn.Hostname)
+				if err != nil {
+					errs <- err
+					return
+				}
+				defer release()
+
 				req := &idl.StartSegmentRequest{
 					DataDir: seg.DataDirectory,
 					Wait:    true,
 					Options: "-c gp_role=execute",
 				}
-				_, err := conn.AgentClient.StartSegment(context.Background(), req)
+				_, err = conn.AgentClient.StartSegment(ctx, req)
 				if err != nil {
 					errs <- utils.FormatGrpcError(err)
 				}
@@ -216,5 +258,5 @@ func (s *Server) StartMirrorSegments(mirrorSegs []*idl.Segment) error {
 		return err
 	}
 
-	return ExecuteRPC(s.Conns, request)
+	return ExecuteRPC(s.agentConns(nil), request)
 }
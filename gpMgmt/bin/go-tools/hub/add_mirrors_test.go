@@ -1,6 +1,7 @@
 package hub_test
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"os"
@@ -56,13 +57,13 @@ func initialize(t *testing.T) {
 
 	credentials := &testutils.MockCredentials{TlsConnection: insecure.NewCredentials()}
 	hubConfig := &hub.Config{
-		1234,
-		5678,
-		[]string{"sdw1", "sdw2"},
-		"/tmp/logDir",
-		"gp",
-		"gpHome",
-		credentials,
+		Port:        1234,
+		AgentPort:   5678,
+		Hostnames:   []string{"sdw1", "sdw2"},
+		LogDir:      "/tmp/logDir",
+		ServiceName: "gp",
+		GpHome:      "gpHome",
+		Credentials: credentials,
 	}
 	hubServer = hub.New(hubConfig, nil)
 }
@@ -131,7 +132,7 @@ func TestCreateMirrorSegments(t *testing.T) {
 		hubServer.Conns = agentConns
 
 		mock, stream := testutils.NewMockStream()
-		err := hubServer.CreateMirrorSegments(mock, gparray, mirrorSegs)
+		err := hubServer.CreateMirrorSegments(mock, gparray, mirrorSegs, nil, nil)
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
@@ -159,6 +160,55 @@ func TestCreateMirrorSegments(t *testing.T) {
 		testutils.AssertLogMessage(t, logfile, `\[DEBUG\]:-Successfully created mirror segment`)
 	})
 
+	t.Run("installs the certificate of the mirror host when SSL is enabled", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockSslFiles(t, map[string]string{
+			"/certs/sdw1.crt": "sdw1 cert",
+			"/certs/sdw1.key": "sdw1 key",
+			"/certs/sdw2.crt": "sdw2 cert",
+			"/certs/sdw2.key": "sdw2 key",
+		})
+		defer utils.ResetSystemFunctions()
+
+		ssl := &idl.SslParams{
+			Enabled: true,
+			HostCerts: map[string]*idl.SslCertFiles{
+				"sdw1": {CertFile: "/certs/sdw1.crt", KeyFile: "/certs/sdw1.key"},
+				"sdw2": {CertFile: "/certs/sdw2.crt", KeyFile: "/certs/sdw2.key"},
+			},
+		}
+
+		sdw1 := mock_idl.NewMockAgentClient(ctrl)
+		sdw1.EXPECT().PgBasebackup(gomock.Any(), gomock.Any()).Return(&idl.PgBasebackupResponse{}, nil)
+		sdw1.EXPECT().InstallSslCertificate(gomock.Any(), &idl.InstallSslCertificateRequest{
+			Pgdata:      mirror2.DataDir,
+			Certificate: &idl.SslCertificate{Cert: []byte("sdw1 cert"), Key: []byte("sdw1 key")},
+		}).Return(&idl.InstallSslCertificateReply{}, nil)
+		sdw1.EXPECT().UpdatePgConf(gomock.Any(), gomock.Any()).Return(&idl.UpdatePgConfRespoonse{}, nil)
+
+		sdw2 := mock_idl.NewMockAgentClient(ctrl)
+		sdw2.EXPECT().PgBasebackup(gomock.Any(), gomock.Any()).Return(&idl.PgBasebackupResponse{}, nil)
+		sdw2.EXPECT().InstallSslCertificate(gomock.Any(), &idl.InstallSslCertificateRequest{
+			Pgdata:      mirror1.DataDir,
+			Certificate: &idl.SslCertificate{Cert: []byte("sdw2 cert"), Key: []byte("sdw2 key")},
+		}).Return(nil, errors.New("error"))
+
+		hubServer.Conns = []*hub.Connection{
+			{AgentClient: sdw1, Hostname: "sdw1"},
+			{AgentClient: sdw2, Hostname: "sdw2"},
+		}
+
+		mock, _ := testutils.NewMockStream()
+		err := hubServer.CreateMirrorSegments(mock, gparray, mirrorSegs, ssl, nil)
+
+		expectedErr := "host: sdw2, error"
+		if err == nil || err.Error() != expectedErr {
+			t.Fatalf("got %v, want %s", err, expectedErr)
+		}
+	})
+
 	t.Run("errors out when fails to run pg_basebackup", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
@@ -187,7 +237,7 @@ func TestCreateMirrorSegments(t *testing.T) {
 		hubServer.Conns = agentConns
 
 		mock, stream := testutils.NewMockStream()
-		err := hubServer.CreateMirrorSegments(mock, gparray, mirrorSegs)
+		err := hubServer.CreateMirrorSegments(mock, gparray, mirrorSegs, nil, nil)
 		if !errors.Is(err, expectedErr) {
 			t.Fatalf("got %#v, want %#v", err, expectedErr)
 		}
@@ -246,7 +296,7 @@ func TestCreateMirrorSegments(t *testing.T) {
 		hubServer.Conns = agentConns
 
 		mock, stream := testutils.NewMockStream()
-		err := hubServer.CreateMirrorSegments(mock, gparray, mirrorSegs)
+		err := hubServer.CreateMirrorSegments(mock, gparray, mirrorSegs, nil, nil)
 		if !errors.Is(err, expectedErr) {
 			t.Fatalf("got %#v, want %#v", err, expectedErr)
 		}
@@ -277,7 +327,7 @@ func TestCreateMirrorSegments(t *testing.T) {
 		segs := []*idl.Segment{{Contentid: 1234}}
 
 		mock, stream := testutils.NewMockStream()
-		err := hubServer.CreateMirrorSegments(mock, gparray, segs)
+		err := hubServer.CreateMirrorSegments(mock, gparray, segs, nil, nil)
 
 		expectedErrString := "could not find any segments with content 1234"
 		if err.Error() != expectedErrString {
@@ -341,7 +391,7 @@ func TestStartMirrorSegments(t *testing.T) {
 		}
 		hubServer.Conns = agentConns
 
-		err := hubServer.StartMirrorSegments(mirrorSegs)
+		err := hubServer.StartMirrorSegments(context.Background(), mirrorSegs, nil)
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
@@ -370,7 +420,7 @@ func TestStartMirrorSegments(t *testing.T) {
 		}
 		hubServer.Conns = agentConns
 
-		err := hubServer.StartMirrorSegments(mirrorSegs)
+		err := hubServer.StartMirrorSegments(context.Background(), mirrorSegs, nil)
 		if !errors.Is(err, expectedErr) {
 			t.Fatalf("got%#v, want %#v", err, expectedErr)
 		}
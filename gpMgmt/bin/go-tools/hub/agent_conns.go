@@ -0,0 +1,437 @@
+package hub
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"golang.org/x/exp/slices"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+
+	"github.com/greenplum-db/gp-common-go-libs/gplog"
+	"github.com/greenplum-db/gpdb/gp/idl"
+	"github.com/greenplum-db/gpdb/gp/utils"
+	"github.com/greenplum-db/gpdb/gp/utils/tracing"
+)
+
+var (
+	// Interval at which the agent connections are checked, and the lost ones
+	// made again
+	AgentHealthCheckInterval = 30 * time.Second
+	// Delay before the first attempt to connect again to a lost agent, which
+	// doubles with each failed attempt up to the maximum
+	AgentReconnectBackoff    = time.Second
+	AgentReconnectMaxBackoff = 30 * time.Second
+)
+
+/*
+agentHealth tracks the failed attempts to connect to the agent of a host. The
+health check connects again to the agent once retryAt has passed, while the
+commands connect to it right away.
+*/
+type agentHealth struct {
+	failures int
+	lastErr  error
+	retryAt  time.Time
+}
+
+/*
+DialAgents returns the connections to the agents of the hosts, connecting to
+the agents the hub is not connected to yet, and again to the ones whose
+connection was lost. The connections are kept for the next calls, so that the
+agents of the other hosts need not be running for the operations which do not
+involve them.
+*/
+func (s *Server) DialAgents(hosts []string) ([]*Connection, error) {
+	conns, dialErrs := s.dialAgentsByHost(hosts)
+	if len(dialErrs) > 0 {
+		var errs []error
+		for _, host := range uniqueHosts(hosts) {
+			if err, ok := dialErrs[host]; ok {
+				errs = append(errs, err)
+			}
+		}
+
+		return nil, errors.Join(errs...)
+	}
+
+	err := ensureConnectionsAreReadyFunc(conns)
+	if err != nil {
+		return nil, err
+	}
+
+	return conns, nil
+}
+
+// pendingDial is a connection to an agent being made, which the calls needing
+// the agent wait for rather than connecting to it again
+type pendingDial struct {
+	done chan struct{}
+	err  error
+}
+
+/*
+dialAgentsByHost returns the connections to the agents it could connect to,
+and the error of each host it could not connect to, see DialAgents. The mutex
+is only held to read and update the pool, so that the calls to the other agents
+are not held up while the agents are connected to.
+*/
+func (s *Server) dialAgentsByHost(hosts []string) ([]*Connection, map[string]error) {
+	s.mutex.Lock()
+	if len(s.Conns) > 0 && s.certFingerprint != s.clientFingerprint {
+		gplog.Info("The hub client certificate has changed, reconnecting to the agents")
+		s.retireAgentConns()
+	}
+	s.clientFingerprint = s.certFingerprint
+
+	pooled := make(map[string]*Connection)
+	for _, host := range uniqueHosts(hosts) {
+		if conn := s.pooledConn(host); conn != nil {
+			pooled[host] = conn
+		}
+	}
+	s.mutex.Unlock()
+
+	lost := unusableConns(pooled)
+
+	s.mutex.Lock()
+	for _, conn := range lost {
+		gplog.Info("The connection to the agent on host %s was lost, reconnecting", conn.Hostname)
+		s.removeAgentConn(conn)
+	}
+
+	var toDial []string
+	waiting := make(map[string]*pendingDial)
+	for _, host := range uniqueHosts(hosts) {
+		if s.pooledConn(host) != nil {
+			continue
+		}
+		if pending, ok := s.pendingDials[host]; ok {
+			waiting[host] = pending
+			continue
+		}
+
+		if s.pendingDials == nil {
+			s.pendingDials = make(map[string]*pendingDial)
+		}
+		s.pendingDials[host] = &pendingDial{done: make(chan struct{})}
+		toDial = append(toDial, host)
+	}
+	s.mutex.Unlock()
+
+	results := s.dialAgents(toDial)
+
+	s.mutex.Lock()
+	errs := make(map[string]error)
+	for _, result := range results {
+		pending := s.pendingDials[result.host]
+		delete(s.pendingDials, result.host)
+		pending.err = result.err
+		close(pending.done)
+
+		if result.err != nil {
+			s.recordAgentFailure(result.host, result.err)
+			errs[result.host] = result.err
+			continue
+		}
+
+		if health, ok := s.agentHealth[result.host]; ok {
+			gplog.Info("Reconnected to the agent on host %s after %d failed attempt(s)", result.host, health.failures)
+			delete(s.agentHealth, result.host)
+		}
+		s.Conns = append(s.Conns, result.conn)
+	}
+	s.mutex.Unlock()
+
+	for host, pending := range waiting {
+		<-pending.done
+		if pending.err != nil {
+			errs[host] = pending.err
+		}
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return getConnForHosts(s.Conns, hosts), errs
+}
+
+// unusableConns returns the connections calls can no longer be made on,
+// checking them in parallel as they may wait for the connection to be made
+func unusableConns(conns map[string]*Connection) []*Connection {
+	var unusable []*Connection
+	var mutex sync.Mutex
+	var wg sync.WaitGroup
+	for _, conn := range conns {
+		wg.Add(1)
+		go func(conn *Connection) {
+			defer wg.Done()
+
+			if !conn.usable() {
+				mutex.Lock()
+				unusable = append(unusable, conn)
+				mutex.Unlock()
+			}
+		}(conn)
+	}
+	wg.Wait()
+
+	sort.Slice(unusable, func(i, j int) bool {
+		return unusable[i].Hostname < unusable[j].Hostname
+	})
+
+	return unusable
+}
+
+type dialResult struct {
+	host string
+	conn *Connection
+	err  error
+}
+
+// dialAgents connects to the agents of the hosts in parallel, and returns the
+// results sorted by host
+func (s *Server) dialAgents(hosts []string) []dialResult {
+	results := make([]dialResult, len(hosts))
+	var wg sync.WaitGroup
+	for i, host := range hosts {
+		wg.Add(1)
+		go func(i int, host string) {
+			defer wg.Done()
+
+			conn, err := s.dialAgent(host)
+			results[i] = dialResult{host: host, conn: conn, err: err}
+		}(i, host)
+	}
+	wg.Wait()
+
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].host < results[j].host
+	})
+
+	return results
+}
+
+func (s *Server) dialAgent(host string) (*Connection, error) {
+	credentials, err := s.Credentials.LoadClientCredentials(utils.RoleHub)
+	if err != nil {
+		return nil, err
+	}
+
+	// The context only bounds the dial, the connection outlives it
+	ctx, cancel := context.WithTimeout(context.Background(), DialTimeout)
+	defer cancel()
+
+	agentConn := &Connection{Hostname: host}
+	address := fmt.Sprintf("%s:%d", host, s.AgentPort)
+	opts := []grpc.DialOption{
+		grpc.WithBlock(),
+		grpc.WithTransportCredentials(credentials),
+		grpc.WithReturnConnectionError(),
+		grpc.WithChainUnaryInterceptor(agentConn.trackCall, s.rpcPolicyInterceptor(host), tracing.UnaryClientInterceptor),
+		grpc.WithStreamInterceptor(tracing.StreamClientInterceptor),
+	}
+	if s.grpcDialer != nil {
+		opts = append(opts, grpc.WithContextDialer(s.grpcDialer))
+	}
+	conn, err := grpc.DialContext(ctx, address, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("could not connect to agent on host %s: %w", host, utils.FormatGrpcError(err))
+	}
+	agentConn.Conn = conn
+	agentConn.AgentClient = idl.NewAgentClient(conn)
+
+	return agentConn, nil
+}
+
+// usable returns whether calls can be made on the connection, waiting for a
+// connection which is being made to become ready
+func (c *Connection) usable() bool {
+	if c.Conn == nil { // connections made outside of the pool, e.g. in tests
+		return true
+	}
+
+	switch c.Conn.GetState() {
+	case connectivity.Ready:
+		return true
+	case connectivity.TransientFailure, connectivity.Shutdown:
+		return false
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), DialTimeout)
+	defer cancel()
+	c.Conn.Connect()
+	for {
+		state := c.Conn.GetState()
+		if state == connectivity.Ready {
+			return true
+		}
+		if state == connectivity.TransientFailure || state == connectivity.Shutdown || !c.Conn.WaitForStateChange(ctx, state) {
+			return false
+		}
+	}
+}
+
+// pooledConn must be called with the mutex held
+func (s *Server) pooledConn(host string) *Connection {
+	for _, conn := range s.Conns {
+		if conn.Hostname == host {
+			return conn
+		}
+	}
+
+	return nil
+}
+
+// removeAgentConns must be called with the mutex held. It retires the
+// connection to the agent of the host, without forgetting its failures.
+func (s *Server) removeAgentConns(host string) {
+	conns := make([]*Connection, 0, len(s.Conns))
+	for _, conn := range s.Conns {
+		if conn.Hostname == host {
+			conn.retire()
+			continue
+		}
+		conns = append(conns, conn)
+	}
+	s.Conns = conns
+}
+
+// removeAgentConn must be called with the mutex held. It retires the
+// connection if it is still in the pool, as it may have been replaced already.
+func (s *Server) removeAgentConn(lost *Connection) {
+	conns := make([]*Connection, 0, len(s.Conns))
+	for _, conn := range s.Conns {
+		if conn == lost {
+			conn.retire()
+			continue
+		}
+		conns = append(conns, conn)
+	}
+	s.Conns = conns
+}
+
+// recordAgentFailure must be called with the mutex held
+func (s *Server) recordAgentFailure(host string, err error) {
+	if s.agentHealth == nil {
+		s.agentHealth = make(map[string]*agentHealth)
+	}
+
+	health, ok := s.agentHealth[host]
+	if !ok {
+		health = &agentHealth{}
+		s.agentHealth[host] = health
+	}
+	health.failures++
+	health.lastErr = err
+
+	backoff := AgentReconnectMaxBackoff
+	if health.failures < 16 { // the shift would overflow beyond that
+		backoff = min(AgentReconnectBackoff<<(health.failures-1), AgentReconnectMaxBackoff)
+	}
+	health.retryAt = time.Now().Add(backoff)
+}
+
+// agentConns returns the connections to the agents of the hosts, or to all the
+// agents if not set, which the hub is connected to
+func (s *Server) agentConns(hosts []string) []*Connection {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if hosts == nil {
+		return slices.Clone(s.Conns)
+	}
+
+	return getConnForHosts(s.Conns, hosts)
+}
+
+// forgetAgents retires the connections to the agents of the hosts, which are
+// not connected to again by the health check, e.g. once they are stopped
+func (s *Server) forgetAgents(hosts []string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for _, host := range hosts {
+		s.removeAgentConns(host)
+		delete(s.agentHealth, host)
+	}
+}
+
+/*
+checkAgentConns connects again to the agents whose connection was lost, or
+which could not be connected to, once their backoff has passed. The agents the
+hub never connected to are left to be connected to when first needed.
+*/
+func (s *Server) checkAgentConns(now time.Time) {
+	s.mutex.Lock()
+	var hosts []string
+	for _, conn := range s.Conns {
+		if conn.Conn == nil {
+			continue
+		}
+
+		switch conn.Conn.GetState() {
+		case connectivity.Idle:
+			conn.Conn.Connect()
+		case connectivity.TransientFailure, connectivity.Shutdown:
+			if health, ok := s.agentHealth[conn.Hostname]; !ok || !now.Before(health.retryAt) {
+				hosts = append(hosts, conn.Hostname)
+			}
+		}
+	}
+	for host, health := range s.agentHealth {
+		if !now.Before(health.retryAt) && !slices.Contains(hosts, host) {
+			hosts = append(hosts, host)
+		}
+	}
+	s.mutex.Unlock()
+
+	if len(hosts) == 0 {
+		return
+	}
+
+	_, err := s.DialAgents(hosts)
+	if err != nil {
+		gplog.Verbose("Could not reconnect to all agents: %v", err)
+	}
+}
+
+// monitorAgentConns runs the health check of the agent connections until done
+// is closed
+func (s *Server) monitorAgentConns(done <-chan struct{}) {
+	ticker := time.NewTicker(AgentHealthCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case now := <-ticker.C:
+			s.checkAgentConns(now)
+		case <-done:
+			return
+		}
+	}
+}
+
+// closeAgentConns closes the connections to the agents when the hub shuts down
+func (s *Server) closeAgentConns() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.retireAgentConns()
+	s.agentHealth = nil
+}
+
+func uniqueHosts(hosts []string) []string {
+	var unique []string
+	for _, host := range hosts {
+		if !slices.Contains(unique, host) {
+			unique = append(unique, host)
+		}
+	}
+
+	return unique
+}
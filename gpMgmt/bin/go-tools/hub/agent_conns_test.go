@@ -0,0 +1,169 @@
+package hub_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/greenplum-db/gp-common-go-libs/testhelper"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/greenplum-db/gpdb/gp/agent"
+	"github.com/greenplum-db/gpdb/gp/hub"
+	"github.com/greenplum-db/gpdb/gp/idl"
+	"github.com/greenplum-db/gpdb/gp/testutils"
+)
+
+func TestAgentConnections(t *testing.T) {
+	testhelper.SetupTestLogger()
+
+	listener := bufconn.Listen(1024 * 1024)
+	agentServer := grpc.NewServer()
+	defer agentServer.Stop()
+	idl.RegisterAgentServer(agentServer, &agent.Server{})
+	go agentServer.Serve(listener) //nolint:errcheck
+
+	hub.AgentHealthCheckInterval = 20 * time.Millisecond
+	hub.AgentReconnectBackoff = 10 * time.Millisecond
+	hub.DialTimeout = 500 * time.Millisecond
+	defer func() {
+		hub.AgentHealthCheckInterval = 30 * time.Second
+		hub.AgentReconnectBackoff = time.Second
+		hub.DialTimeout = 3 * time.Second
+	}()
+
+	// the agent on sdw2 is down until started
+	var sdw2Started atomic.Bool
+	var sdw2Dials atomic.Int32
+	dialer := func(ctx context.Context, address string) (net.Conn, error) {
+		if strings.HasPrefix(address, "sdw2") {
+			sdw2Dials.Add(1)
+			if !sdw2Started.Load() {
+				return nil, errors.New("connection refused")
+			}
+		}
+
+		return listener.Dial()
+	}
+
+	startHub := func(t *testing.T) *hub.Server {
+		t.Helper()
+
+		hubServer := hub.New(&hub.Config{
+			Hostnames:   []string{"sdw1", "sdw2"},
+			LogDir:      t.TempDir(),
+			Credentials: &testutils.MockCredentials{TlsConnection: insecure.NewCredentials()},
+		}, dialer)
+		errChan := make(chan error, 1)
+		go func() {
+			errChan <- hubServer.Start()
+		}()
+
+		select {
+		case err := <-errChan:
+			t.Fatalf("unexpected error: %#v", err)
+		case <-time.After(500 * time.Millisecond):
+		}
+		t.Cleanup(hubServer.Shutdown)
+
+		return hubServer
+	}
+
+	t.Run("reconnects in the background to the agents which could not be connected to", func(t *testing.T) {
+		hubServer := startHub(t)
+
+		_, err := hubServer.DialAgents([]string{"sdw1", "sdw2"})
+		expected := "could not connect to agent on host sdw2:"
+		if err == nil || !strings.HasPrefix(err.Error(), expected) {
+			t.Fatalf("got %v, want %s", err, expected)
+		}
+
+		sdw2Started.Store(true)
+		deadline := time.Now().Add(5 * time.Second)
+		dials := sdw2Dials.Load()
+		for sdw2Dials.Load() == dials {
+			if time.Now().After(deadline) {
+				t.Fatalf("expected the hub to connect again to the agent on sdw2")
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+
+		dials = sdw2Dials.Load()
+		conns, err := hubServer.DialAgents([]string{"sdw1", "sdw2"})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		if len(conns) != 2 {
+			t.Fatalf("got %d connections, want 2", len(conns))
+		}
+		if sdw2Dials.Load() != dials {
+			t.Fatalf("expected the connection made by the health check to be used")
+		}
+	})
+
+	t.Run("does not hold up the other agents while connecting to an agent", func(t *testing.T) {
+		release := make(chan struct{})
+		hubServer := hub.New(&hub.Config{
+			Hostnames:   []string{"sdw1", "sdw3"},
+			Credentials: &testutils.MockCredentials{TlsConnection: insecure.NewCredentials()},
+		}, func(ctx context.Context, address string) (net.Conn, error) {
+			if strings.HasPrefix(address, "sdw3") {
+				<-release
+			}
+
+			return listener.Dial()
+		})
+		defer hubServer.Shutdown()
+
+		_, err := hubServer.DialAgents([]string{"sdw1"})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			hubServer.DialAgents([]string{"sdw3"}) //nolint:errcheck
+		}()
+		time.Sleep(50 * time.Millisecond)
+
+		start := time.Now()
+		_, err = hubServer.DialAgents([]string{"sdw1"})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		if time.Since(start) >= 250*time.Millisecond {
+			t.Fatalf("expected the connection to sdw1 to be returned while connecting to sdw3")
+		}
+
+		close(release)
+		<-done
+	})
+
+	t.Run("closes the connections when shutting down", func(t *testing.T) {
+		sdw2Started.Store(true)
+		hubServer := startHub(t)
+
+		conns, err := hubServer.DialAgents([]string{"sdw1"})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		hubServer.Shutdown()
+
+		deadline := time.Now().Add(5 * time.Second)
+		for conns[0].Conn.GetState() != connectivity.Shutdown {
+			if time.Now().After(deadline) {
+				t.Fatalf("got state %v, want the connection to be closed", conns[0].Conn.GetState())
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	})
+}
@@ -0,0 +1,348 @@
+package hub
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	grpcStatus "google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/greenplum-db/gp-common-go-libs/gplog"
+	"github.com/greenplum-db/gpdb/gp/constants"
+	"github.com/greenplum-db/gpdb/gp/utils/tracing"
+)
+
+// Outcomes of an audited call
+const (
+	AuditSuccess = "success"
+	AuditFailure = "failure"
+	AuditDenied  = "denied"
+)
+
+type AuditConfig struct {
+	File      string `json:"file,omitempty"`      // defaults to gp_audit.log in the hub log directory
+	MaxSizeMB int    `json:"maxSizeMB,omitempty"` // size at which the file is rotated
+	MaxFiles  int    `json:"maxFiles,omitempty"`  // number of rotated files kept
+}
+
+// AuditLogPath returns the path of the audit log of the hub
+func (conf *Config) AuditLogPath() string {
+	if conf.Audit != nil && conf.Audit.File != "" {
+		return conf.Audit.File
+	}
+
+	return filepath.Join(conf.LogDir, constants.AuditLogFileName)
+}
+
+// AuditRecord describes a call to the hub which changes the cluster, or which
+// has been denied
+type AuditRecord struct {
+	Time        time.Time       `json:"time"`
+	Certificate string          `json:"certificate,omitempty"`
+	User        string          `json:"user,omitempty"`
+	Host        string          `json:"host"`
+	Operation   string          `json:"operation"`
+	Request     json.RawMessage `json:"request,omitempty"`
+	Outcome     string          `json:"outcome"`
+	DurationMs  int64           `json:"durationMs"`
+	Error       string          `json:"error,omitempty"`
+}
+
+/*
+AuditLog appends records as JSON lines to a file that is only readable by the
+owner. Once the file grows beyond the maximum size it is renamed to <file>.1,
+shifting the older files, and the oldest file beyond the maximum count is removed.
+*/
+type AuditLog struct {
+	mutex    sync.Mutex
+	path     string
+	maxSize  int64
+	maxFiles int
+	file     *os.File
+	size     int64
+}
+
+func OpenAuditLog(path string, conf *AuditConfig) (*AuditLog, error) {
+	l := &AuditLog{
+		path:     path,
+		maxSize:  constants.DefaultAuditLogMaxSizeMB * 1024 * 1024,
+		maxFiles: constants.DefaultAuditLogMaxFiles,
+	}
+	if conf != nil && conf.MaxSizeMB > 0 {
+		l.maxSize = int64(conf.MaxSizeMB) * 1024 * 1024
+	}
+	if conf != nil && conf.MaxFiles > 0 {
+		l.maxFiles = conf.MaxFiles
+	}
+
+	err := os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return nil, fmt.Errorf("could not create audit log directory: %w", err)
+	}
+
+	err = l.open()
+	if err != nil {
+		return nil, err
+	}
+
+	return l, nil
+}
+
+func (l *AuditLog) Write(record *AuditRecord) error {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if l.size > 0 && l.size+int64(len(line)) > l.maxSize {
+		err = l.rotate()
+		if err != nil {
+			return err
+		}
+	}
+
+	n, err := l.file.Write(line)
+	l.size += int64(n)
+	if err != nil {
+		return fmt.Errorf("could not write to audit log %s: %w", l.path, err)
+	}
+
+	return l.file.Sync()
+}
+
+func (l *AuditLog) Close() error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	return l.file.Close()
+}
+
+func (l *AuditLog) open() error {
+	file, err := os.OpenFile(l.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("could not open audit log: %w", err)
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("could not open audit log: %w", err)
+	}
+
+	l.file = file
+	l.size = info.Size()
+	return nil
+}
+
+func (l *AuditLog) rotate() error {
+	err := l.file.Close()
+	if err != nil {
+		return fmt.Errorf("could not rotate audit log %s: %w", l.path, err)
+	}
+
+	err = os.Remove(rotatedAuditLog(l.path, l.maxFiles))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("could not rotate audit log %s: %w", l.path, err)
+	}
+	for i := l.maxFiles - 1; i >= 0; i-- {
+		err = os.Rename(rotatedAuditLog(l.path, i), rotatedAuditLog(l.path, i+1))
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("could not rotate audit log %s: %w", l.path, err)
+		}
+	}
+
+	return l.open()
+}
+
+func rotatedAuditLog(path string, index int) string {
+	if index == 0 {
+		return path
+	}
+
+	return fmt.Sprintf("%s.%d", path, index)
+}
+
+/*
+ReadAuditLog returns the records of the audit log and of its rotated files,
+oldest first, which are not older than since and, if given, which are for the
+operation. Lines which cannot be parsed, such as one left incomplete by a crash,
+are skipped.
+*/
+func ReadAuditLog(path string, since time.Time, operation string) ([]*AuditRecord, error) {
+	files, err := filepath.Glob(path + ".*")
+	if err != nil {
+		return nil, err
+	}
+
+	records := []*AuditRecord{}
+	for i := len(files); i >= 0; i-- {
+		file, err := os.Open(rotatedAuditLog(path, i))
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("could not read audit log: %w", err)
+		}
+
+		scanner := bufio.NewScanner(file)
+		scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+		for scanner.Scan() {
+			record := &AuditRecord{}
+			err = json.Unmarshal(scanner.Bytes(), record)
+			if err != nil {
+				gplog.Warn("Skipping invalid record in audit log %s: %s", file.Name(), err)
+				continue
+			}
+
+			if record.Time.Before(since) || (operation != "" && !strings.EqualFold(record.Operation, operation)) {
+				continue
+			}
+			records = append(records, record)
+		}
+		err = scanner.Err()
+		file.Close()
+		if err != nil {
+			return nil, fmt.Errorf("could not read audit log %s: %w", file.Name(), err)
+		}
+	}
+
+	return records, nil
+}
+
+func (s *Server) auditUnary(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	s.audit(ctx, info.FullMethod, req, start, err)
+
+	return resp, err
+}
+
+func (s *Server) auditStream(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	start := time.Now()
+	audited := &auditedStream{ServerStream: stream}
+	err := handler(srv, audited)
+	s.audit(stream.Context(), info.FullMethod, audited.request, start, err)
+
+	return err
+}
+
+// auditedStream keeps the request of a server streaming RPC
+type auditedStream struct {
+	grpc.ServerStream
+	request interface{}
+}
+
+func (a *auditedStream) RecvMsg(m interface{}) error {
+	err := a.ServerStream.RecvMsg(m)
+	if err == nil && a.request == nil {
+		a.request = m
+	}
+
+	return err
+}
+
+// audit records the calls which need more than the viewer role, and all denied calls
+func (s *Server) audit(ctx context.Context, method string, req interface{}, start time.Time, err error) {
+	if s.auditLog == nil {
+		return
+	}
+
+	denied := grpcStatus.Code(err) == codes.PermissionDenied
+	if requiredRole(method) == RoleViewer && !denied {
+		return
+	}
+
+	identity := clientIdentity(ctx, s.Port, true)
+	record := &AuditRecord{
+		Time:        start,
+		Certificate: identity.Certificate,
+		User:        identity.User,
+		Operation:   method[strings.LastIndex(method, "/")+1:],
+		Request:     sanitizeRequest(req),
+		Outcome:     AuditSuccess,
+		DurationMs:  time.Since(start).Milliseconds(),
+	}
+	if p, ok := peer.FromContext(ctx); ok {
+		record.Host = p.Addr.String()
+		if host, _, err := net.SplitHostPort(record.Host); err == nil {
+			record.Host = host
+		}
+	}
+	if err != nil {
+		record.Outcome = AuditFailure
+		if denied {
+			record.Outcome = AuditDenied
+		}
+		record.Error = grpcStatus.Convert(err).Message()
+	}
+
+	err = s.auditLog.Write(record)
+	if err != nil {
+		tracing.Error(ctx, "Could not write the audit record of %s: %s", method, err)
+	}
+}
+
+// sanitizeRequest returns the request as JSON without its passwords
+func sanitizeRequest(req interface{}) json.RawMessage {
+	msg, ok := req.(proto.Message)
+	if !ok {
+		return nil
+	}
+
+	// The generated messages use the original protobuf API, so adapt them to
+	// the current one to walk their fields
+	clone := proto.MessageV2(proto.Clone(msg))
+	redactPasswords(clone.ProtoReflect())
+	contents, err := protojson.Marshal(clone)
+	if err != nil {
+		gplog.Verbose("Could not record the request in the audit log: %s", err)
+		return nil
+	}
+
+	return contents
+}
+
+func redactPasswords(m protoreflect.Message) {
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		switch {
+		case fd.Kind() == protoreflect.StringKind && strings.Contains(strings.ToLower(string(fd.Name())), "password"):
+			m.Clear(fd)
+		case fd.IsMap():
+			if fd.MapValue().Kind() == protoreflect.MessageKind {
+				v.Map().Range(func(_ protoreflect.MapKey, value protoreflect.Value) bool {
+					redactPasswords(value.Message())
+					return true
+				})
+			}
+		case fd.IsList():
+			if fd.Kind() == protoreflect.MessageKind {
+				for i := 0; i < v.List().Len(); i++ {
+					redactPasswords(v.List().Get(i).Message())
+				}
+			}
+		case fd.Kind() == protoreflect.MessageKind:
+			redactPasswords(v.Message())
+		}
+
+		return true
+	})
+}
@@ -0,0 +1,232 @@
+package hub_test
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/greenplum-db/gp-common-go-libs/testhelper"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/greenplum-db/gpdb/gp/hub"
+	"github.com/greenplum-db/gpdb/gp/idl"
+	"github.com/greenplum-db/gpdb/gp/testutils"
+)
+
+func TestAuditLog(t *testing.T) {
+	testhelper.SetupTestLogger()
+
+	t.Run("appends records to a file only readable by the owner", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "audit", "gp_audit.log")
+		auditLog, err := hub.OpenAuditLog(path, nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		record := &hub.AuditRecord{Time: time.Now().UTC().Truncate(time.Second), Operation: "StopAgents", Outcome: hub.AuditSuccess}
+		err = auditLog.Write(record)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		auditLog.Close()
+
+		// Reopening keeps the existing records
+		auditLog, err = hub.OpenAuditLog(path, nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		err = auditLog.Write(record)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		auditLog.Close()
+
+		info, err := os.Stat(path)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		if info.Mode().Perm() != 0600 {
+			t.Fatalf("got permissions %o, want 0600", info.Mode().Perm())
+		}
+
+		records, err := hub.ReadAuditLog(path, time.Time{}, "")
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		if !reflect.DeepEqual(records, []*hub.AuditRecord{record, record}) {
+			t.Fatalf("got %+v, want the record twice", records)
+		}
+	})
+
+	t.Run("rotates the file and keeps the configured number of files", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "gp_audit.log")
+		auditLog, err := hub.OpenAuditLog(path, &hub.AuditConfig{MaxSizeMB: 1, MaxFiles: 2})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		defer auditLog.Close()
+
+		// Three records fit into each file, so the first three are in the file
+		// which has been removed
+		for i := 0; i < 10; i++ {
+			err = auditLog.Write(&hub.AuditRecord{Operation: fmt.Sprintf("op%d", i), Error: strings.Repeat("x", 300*1024)})
+			if err != nil {
+				t.Fatalf("unexpected error: %#v", err)
+			}
+		}
+
+		files, err := filepath.Glob(path + "*")
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		expectedFiles := []string{path, path + ".1", path + ".2"}
+		if !reflect.DeepEqual(files, expectedFiles) {
+			t.Fatalf("got files %v, want %v", files, expectedFiles)
+		}
+
+		records, err := hub.ReadAuditLog(path, time.Time{}, "")
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		operations := []string{}
+		for _, record := range records {
+			operations = append(operations, record.Operation)
+		}
+		expected := []string{"op3", "op4", "op5", "op6", "op7", "op8", "op9"}
+		if !reflect.DeepEqual(operations, expected) {
+			t.Fatalf("got %v, want %v", operations, expected)
+		}
+	})
+}
+
+func TestReadAuditLog(t *testing.T) {
+	testhelper.SetupTestLogger()
+
+	path := filepath.Join(t.TempDir(), "gp_audit.log")
+	err := os.WriteFile(path, []byte(`{"time":"2024-01-01T10:00:00Z","operation":"MakeCluster","outcome":"success"}
+{"time":"2024-01-02T10:00:00Z","operation":"StopAgents","outcome":"denied"}
+{"time":"2024-01-03T10:00:00Z","operation":"Mak
+{"time":"2024-01-04T10:00:00Z","operation":"MakeCluster","outcome":"failure"}
+`), 0600)
+	if err != nil {
+		t.Fatalf("unexpected error: %#v", err)
+	}
+
+	cases := []struct {
+		name      string
+		since     time.Time
+		operation string
+		expected  []string
+	}{
+		{"all records", time.Time{}, "", []string{"2024-01-01", "2024-01-02", "2024-01-04"}},
+		{"records since a time", time.Date(2024, 1, 2, 10, 0, 0, 0, time.UTC), "", []string{"2024-01-02", "2024-01-04"}},
+		{"records of an operation", time.Time{}, "makecluster", []string{"2024-01-01", "2024-01-04"}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			records, err := hub.ReadAuditLog(path, tc.since, tc.operation)
+			if err != nil {
+				t.Fatalf("unexpected error: %#v", err)
+			}
+
+			dates := []string{}
+			for _, record := range records {
+				dates = append(dates, record.Time.Format("2006-01-02"))
+			}
+			if !reflect.DeepEqual(dates, tc.expected) {
+				t.Fatalf("got %v, want %v", dates, tc.expected)
+			}
+		})
+	}
+
+	t.Run("returns no records when there is no audit log", func(t *testing.T) {
+		records, err := hub.ReadAuditLog(filepath.Join(t.TempDir(), "gp_audit.log"), time.Time{}, "")
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		if len(records) != 0 {
+			t.Fatalf("got %+v, want no records", records)
+		}
+	})
+}
+
+func TestAuditHubCalls(t *testing.T) {
+	testhelper.SetupTestLogger()
+
+	dialTimeout := hub.DialTimeout
+	hub.DialTimeout = 100 * time.Millisecond
+	defer func() { hub.DialTimeout = dialTimeout }()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("unexpected error: %#v", err)
+	}
+	port := listener.Addr().(*net.TCPAddr).Port
+	listener.Close()
+
+	hubServer := hub.New(&hub.Config{
+		Port:        port,
+		Hostnames:   []string{"unknown.invalid"},
+		LogDir:      t.TempDir(),
+		Credentials: &testutils.MockCredentials{TlsConnection: insecure.NewCredentials()},
+	}, nil)
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- hubServer.Start()
+	}()
+	select {
+	case err := <-errChan:
+		t.Fatalf("unexpected error: %#v", err)
+	case <-time.After(500 * time.Millisecond):
+	}
+	defer hubServer.Shutdown()
+
+	conn, err := grpc.Dial(fmt.Sprintf("localhost:%d", port), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("unexpected error: %#v", err)
+	}
+	defer conn.Close()
+	client := idl.NewHubClient(conn)
+
+	// Only the call changing the cluster is audited
+	_, _ = client.StatusAgents(context.Background(), &idl.StatusAgentsRequest{})
+	stream, err := client.MakeCluster(context.Background(), &idl.MakeClusterRequest{
+		GpArray:       &idl.GpArray{},
+		ClusterParams: &idl.ClusterParams{SuPasswordVerifier: "secret", DbName: "postgres"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %#v", err)
+	}
+	_, err = stream.Recv()
+	if err == nil {
+		t.Fatalf("expected the cluster creation to fail")
+	}
+
+	records, err := hub.ReadAuditLog(hubServer.AuditLogPath(), time.Time{}, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %#v", err)
+	}
+	if len(records) != 1 {
+		t.Fatalf("got %d records, want 1: %+v", len(records), records)
+	}
+
+	record := records[0]
+	if record.Operation != "MakeCluster" || record.Outcome != hub.AuditFailure || record.Host != "127.0.0.1" {
+		t.Fatalf("got %+v, want a failed MakeCluster from 127.0.0.1", record)
+	}
+	if !strings.Contains(record.Error, "could not connect to agent on host unknown.invalid") {
+		t.Fatalf("got error %q, want the agent connection failure", record.Error)
+	}
+	if !strings.Contains(string(record.Request), `"dbName":"postgres"`) || strings.Contains(string(record.Request), "secret") {
+		t.Fatalf("got request %s, want it without the password", record.Request)
+	}
+}
@@ -0,0 +1,215 @@
+package hub
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	grpcStatus "google.golang.org/grpc/status"
+
+	"github.com/greenplum-db/gpdb/gp/utils"
+	"github.com/greenplum-db/gpdb/gp/utils/tracing"
+)
+
+// Roles granted to the clients of the hub. Each role includes the permissions
+// of the roles before it.
+const (
+	RoleViewer   = "viewer"
+	RoleOperator = "operator"
+	RoleAdmin    = "admin"
+)
+
+var roleLevels = map[string]int{
+	RoleViewer:   1,
+	RoleOperator: 2,
+	RoleAdmin:    3,
+}
+
+// Role required to call each hub RPC. Methods not listed here, such as those
+// of the reflection service, require the admin role.
+var requiredRoles = map[string]string{
+	"/idl.Hub/StatusAgents":       RoleViewer,
+	"/idl.Hub/GetAllHostNames":    RoleViewer,
+	"/idl.Hub/ListPgHba":          RoleViewer,
+	"/idl.Hub/CheckPgHba":         RoleViewer,
+	"/idl.Hub/GetLogs":            RoleViewer,
+	"/idl.Hub/GetHostsInfo":       RoleViewer,
+	"/idl.Hub/StartAgents":        RoleOperator,
+	"/idl.Hub/StopAgents":         RoleOperator,
+	"/idl.Hub/Stop":               RoleOperator,
+	"/idl.Hub/CollectLogs":        RoleOperator,
+	"/idl.Hub/CheckNetwork":       RoleOperator,
+	"/idl.Hub/CheckDisk":          RoleOperator,
+	"/idl.Hub/MakeCluster":        RoleAdmin,
+	"/idl.Hub/AddMirrors":         RoleAdmin,
+	"/idl.Hub/ModifyPgHba":        RoleAdmin,
+	"/idl.Hub/ReloadCertificates": RoleAdmin,
+}
+
+// Identity of a client of the hub
+type Identity struct {
+	Certificate string // common name of the verified client certificate
+	User        string // OS user of a client on the hub host
+}
+
+func (i Identity) String() string {
+	names := []string{}
+	if i.Certificate != "" {
+		names = append(names, fmt.Sprintf("certificate %q", i.Certificate))
+	}
+	if i.User != "" {
+		names = append(names, fmt.Sprintf("user %q", i.User))
+	}
+	if len(names) == 0 {
+		return "unidentified client"
+	}
+
+	return strings.Join(names, " and ")
+}
+
+/*
+AuthorizationPolicy maps client identities to roles. A client having several
+identities gets the highest of their roles, and clients matching none of them
+get the default role, if any.
+*/
+type AuthorizationPolicy struct {
+	Certificates map[string]string `json:"certificates"`
+	Users        map[string]string `json:"users"`
+	DefaultRole  string            `json:"defaultRole"`
+}
+
+func LoadAuthorizationPolicy(path string) (*AuthorizationPolicy, error) {
+	contents, err := utils.System.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read authorization policy: %w", err)
+	}
+
+	policy := &AuthorizationPolicy{}
+	err = json.Unmarshal(contents, policy)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse authorization policy %s: %w", path, err)
+	}
+
+	err = policy.validate()
+	if err != nil {
+		return nil, fmt.Errorf("invalid authorization policy %s: %w", path, err)
+	}
+
+	return policy, nil
+}
+
+func (p *AuthorizationPolicy) validate() error {
+	for kind, roles := range map[string]map[string]string{"certificate": p.Certificates, "user": p.Users} {
+		for name, role := range roles {
+			if _, ok := roleLevels[role]; !ok {
+				return fmt.Errorf("unknown role %q for %s %q", role, kind, name)
+			}
+		}
+	}
+
+	if _, ok := roleLevels[p.DefaultRole]; p.DefaultRole != "" && !ok {
+		return fmt.Errorf("unknown default role %q", p.DefaultRole)
+	}
+
+	return nil
+}
+
+// Role returns the role granted to the client, or an empty string if none
+func (p *AuthorizationPolicy) Role(identity Identity) string {
+	role := ""
+	for _, granted := range []string{p.Certificates[identity.Certificate], p.Users[identity.User]} {
+		if roleLevels[granted] > roleLevels[role] {
+			role = granted
+		}
+	}
+
+	if role == "" {
+		return p.DefaultRole
+	}
+
+	return role
+}
+
+// Allows checks whether the client may call the given RPC method
+func (p *AuthorizationPolicy) Allows(identity Identity, method string) bool {
+	return roleLevels[p.Role(identity)] >= roleLevels[requiredRole(method)]
+}
+
+func requiredRole(method string) string {
+	role, ok := requiredRoles[method]
+	if !ok {
+		return RoleAdmin
+	}
+
+	return role
+}
+
+func (s *Server) authorizeUnary(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	err := s.authorize(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+
+	return handler(ctx, req)
+}
+
+func (s *Server) authorizeStream(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	err := s.authorize(stream.Context(), info.FullMethod)
+	if err != nil {
+		return err
+	}
+
+	return handler(srv, stream)
+}
+
+// authorize lets all calls through unless an authorization policy is configured
+func (s *Server) authorize(ctx context.Context, method string) error {
+	if s.policy == nil {
+		return nil
+	}
+
+	// Looking up the user is only worth it when the policy refers to users
+	identity := clientIdentity(ctx, s.Port, len(s.policy.Users) > 0)
+	if s.policy.Allows(identity, method) {
+		return nil
+	}
+
+	role := s.policy.Role(identity)
+	if role == "" {
+		role = "no role"
+	}
+	tracing.Warn(ctx, "Denied call to %s by %s with %s", method, identity, role)
+
+	return grpcStatus.Errorf(codes.PermissionDenied, "%s with %s is not allowed to call %s", identity, role, method)
+}
+
+// clientIdentity returns the identity of the client of the hub listening on
+// port, looking up the OS user of local clients if requested
+func clientIdentity(ctx context.Context, port int, lookupUser bool) Identity {
+	identity := Identity{}
+
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return identity
+	}
+
+	if tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo); ok && len(tlsInfo.State.VerifiedChains) > 0 {
+		identity.Certificate = tlsInfo.State.PeerCertificates[0].Subject.CommonName
+	}
+
+	if addr, ok := p.Addr.(*net.TCPAddr); ok && lookupUser && addr.IP.IsLoopback() {
+		user, err := utils.LoopbackPeerUser(addr, port)
+		if err != nil {
+			tracing.Verbose(ctx, "Could not identify the user of the client: %s", err)
+		}
+		identity.User = user
+	}
+
+	return identity
+}
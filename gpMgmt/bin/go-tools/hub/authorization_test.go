@@ -0,0 +1,281 @@
+package hub_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/greenplum-db/gp-common-go-libs/testhelper"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	grpcStatus "google.golang.org/grpc/status"
+
+	"github.com/greenplum-db/gpdb/gp/hub"
+	"github.com/greenplum-db/gpdb/gp/idl"
+	"github.com/greenplum-db/gpdb/gp/utils"
+	"github.com/greenplum-db/gpdb/gp/utils/certs"
+)
+
+func TestLoadAuthorizationPolicy(t *testing.T) {
+	writePolicy := func(t *testing.T, contents string) string {
+		t.Helper()
+
+		path := filepath.Join(t.TempDir(), "policy.json")
+		err := os.WriteFile(path, []byte(contents), 0644)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		return path
+	}
+
+	t.Run("grants the highest role of the client identities", func(t *testing.T) {
+		path := writePolicy(t, `{
+			"certificates": {"gp-cli": "admin", "monitor": "viewer"},
+			"users": {"gpadmin": "operator"},
+			"defaultRole": "viewer"
+		}`)
+
+		policy, err := hub.LoadAuthorizationPolicy(path)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		cases := []struct {
+			identity hub.Identity
+			role     string
+		}{
+			{hub.Identity{Certificate: "gp-cli"}, hub.RoleAdmin},
+			{hub.Identity{Certificate: "monitor", User: "gpadmin"}, hub.RoleOperator},
+			{hub.Identity{Certificate: "gp-cli", User: "gpadmin"}, hub.RoleAdmin},
+			{hub.Identity{User: "other"}, hub.RoleViewer},
+			{hub.Identity{}, hub.RoleViewer},
+		}
+		for _, tc := range cases {
+			role := policy.Role(tc.identity)
+			if role != tc.role {
+				t.Errorf("got role %q for %s, want %q", role, tc.identity, tc.role)
+			}
+		}
+	})
+
+	t.Run("allows calls according to the role", func(t *testing.T) {
+		policy := &hub.AuthorizationPolicy{
+			Certificates: map[string]string{"viewer": hub.RoleViewer, "operator": hub.RoleOperator, "admin": hub.RoleAdmin},
+		}
+
+		cases := []struct {
+			certificate string
+			method      string
+			allowed     bool
+		}{
+			{"viewer", "/idl.Hub/StatusAgents", true},
+			{"viewer", "/idl.Hub/StopAgents", false},
+			{"operator", "/idl.Hub/StopAgents", true},
+			{"operator", "/idl.Hub/MakeCluster", false},
+			{"admin", "/idl.Hub/MakeCluster", true},
+			{"operator", "/grpc.reflection.v1alpha.ServerReflection/ServerReflectionInfo", false},
+			{"unknown", "/idl.Hub/StatusAgents", false},
+		}
+		for _, tc := range cases {
+			allowed := policy.Allows(hub.Identity{Certificate: tc.certificate}, tc.method)
+			if allowed != tc.allowed {
+				t.Errorf("got %t for %s calling %s, want %t", allowed, tc.certificate, tc.method, tc.allowed)
+			}
+		}
+	})
+
+	t.Run("errors out for unknown roles", func(t *testing.T) {
+		path := writePolicy(t, `{"users": {"gpadmin": "superuser"}}`)
+
+		_, err := hub.LoadAuthorizationPolicy(path)
+		expected := fmt.Sprintf(`invalid authorization policy %s: unknown role "superuser" for user "gpadmin"`, path)
+		if err == nil || err.Error() != expected {
+			t.Fatalf("got %v, want %s", err, expected)
+		}
+	})
+
+	t.Run("errors out for an unknown default role", func(t *testing.T) {
+		path := writePolicy(t, `{"defaultRole": "everyone"}`)
+
+		_, err := hub.LoadAuthorizationPolicy(path)
+		expected := fmt.Sprintf(`invalid authorization policy %s: unknown default role "everyone"`, path)
+		if err == nil || err.Error() != expected {
+			t.Fatalf("got %v, want %s", err, expected)
+		}
+	})
+
+	t.Run("errors out when the policy cannot be parsed", func(t *testing.T) {
+		path := writePolicy(t, `{`)
+
+		_, err := hub.LoadAuthorizationPolicy(path)
+		expected := fmt.Sprintf("could not parse authorization policy %s", path)
+		if err == nil || !strings.HasPrefix(err.Error(), expected) {
+			t.Fatalf("got %v, want %s", err, expected)
+		}
+	})
+}
+
+func TestAuthorization(t *testing.T) {
+	testhelper.SetupTestLogger()
+
+	current, err := user.Current()
+	if err != nil {
+		t.Fatalf("unexpected error: %#v", err)
+	}
+
+	// Issue the certificates of the hub and of the clients "gp-cli", "monitor" and "stranger"
+	dir := t.TempDir()
+	ca, err := certs.NewCertificateAuthority("test CA", time.Hour)
+	if err != nil {
+		t.Fatalf("unexpected error: %#v", err)
+	}
+	creds := &utils.GpCredentials{
+		CACertPath:         filepath.Join(dir, certs.CACertFile),
+		CAKeyPath:          filepath.Join(dir, certs.CAKeyFile),
+		ServerCertPath:     filepath.Join(dir, certs.ServerCertFile),
+		ServerKeyPath:      filepath.Join(dir, certs.ServerKeyFile),
+		ClientCertificates: map[string]utils.CertificatePaths{},
+	}
+	err = ca.Write(creds.CACertPath, creds.CAKeyPath)
+	if err != nil {
+		t.Fatalf("unexpected error: %#v", err)
+	}
+	serverCert, err := ca.IssueServerCertificate("localhost", time.Hour)
+	if err != nil {
+		t.Fatalf("unexpected error: %#v", err)
+	}
+	err = serverCert.Write(creds.ServerCertPath, creds.ServerKeyPath)
+	if err != nil {
+		t.Fatalf("unexpected error: %#v", err)
+	}
+	for _, name := range []string{"gp-cli", "monitor", "stranger"} {
+		clientCert, err := ca.IssueClientCertificate(name, "localhost", time.Hour)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		paths := utils.CertificatePaths{CertPath: filepath.Join(dir, name+"-cert.pem"), KeyPath: filepath.Join(dir, name+"-key.pem")}
+		err = clientCert.Write(paths.CertPath, paths.KeyPath)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		creds.ClientCertificates[name] = paths
+	}
+
+	policyPath := filepath.Join(dir, "policy.json")
+	err = os.WriteFile(policyPath, []byte(fmt.Sprintf(`{
+		"certificates": {"gp-cli": "admin", "monitor": "viewer"},
+		"users": {%q: "operator"}
+	}`, current.Username)), 0644)
+	if err != nil {
+		t.Fatalf("unexpected error: %#v", err)
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("unexpected error: %#v", err)
+	}
+	port := listener.Addr().(*net.TCPAddr).Port
+	listener.Close()
+
+	hubServer := hub.New(&hub.Config{
+		Port:                port,
+		LogDir:              dir,
+		Credentials:         creds,
+		AuthorizationPolicy: policyPath,
+	}, nil)
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- hubServer.Start()
+	}()
+	select {
+	case err := <-errChan:
+		t.Fatalf("unexpected error: %#v", err)
+	case <-time.After(500 * time.Millisecond):
+	}
+	defer hubServer.Shutdown()
+
+	connect := func(t *testing.T, client string) idl.HubClient {
+		t.Helper()
+
+		clientCreds, err := creds.LoadClientCredentials(client)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		conn, err := grpc.Dial(fmt.Sprintf("localhost:%d", port), grpc.WithTransportCredentials(clientCreds))
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		t.Cleanup(func() { conn.Close() })
+
+		return idl.NewHubClient(conn)
+	}
+
+	expectDenied := func(t *testing.T, err error) {
+		t.Helper()
+
+		if grpcStatus.Code(err) != codes.PermissionDenied {
+			t.Fatalf("got %v, want a permission denied error", err)
+		}
+	}
+
+	t.Run("allows the certificates of the policy", func(t *testing.T) {
+		client := connect(t, "monitor")
+
+		_, err := client.StatusAgents(context.Background(), &idl.StatusAgentsRequest{})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+	})
+
+	t.Run("allows the OS users of the policy", func(t *testing.T) {
+		client := connect(t, "stranger")
+
+		_, err := client.StopAgents(context.Background(), &idl.StopAgentsRequest{})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+	})
+
+	t.Run("denies unary calls requiring a higher role", func(t *testing.T) {
+		client := connect(t, "stranger")
+
+		_, err := client.ModifyPgHba(context.Background(), &idl.ModifyPgHbaRequest{})
+		expectDenied(t, err)
+
+		expected := fmt.Sprintf(`certificate "stranger" and user %q with operator is not allowed to call /idl.Hub/ModifyPgHba`, current.Username)
+		if grpcStatus.Convert(err).Message() != expected {
+			t.Fatalf("got %q, want %q", grpcStatus.Convert(err).Message(), expected)
+		}
+	})
+
+	t.Run("denies streaming calls requiring a higher role", func(t *testing.T) {
+		client := connect(t, "monitor")
+
+		stream, err := client.MakeCluster(context.Background(), &idl.MakeClusterRequest{})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		_, err = stream.Recv()
+		if err == io.EOF {
+			t.Fatalf("expected the stream to fail")
+		}
+		expectDenied(t, err)
+	})
+
+	t.Run("allows admins to call everything", func(t *testing.T) {
+		client := connect(t, "gp-cli")
+
+		_, err := client.ModifyPgHba(context.Background(), &idl.ModifyPgHbaRequest{})
+		if grpcStatus.Code(err) == codes.PermissionDenied {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+	})
+}
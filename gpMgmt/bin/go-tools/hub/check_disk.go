@@ -0,0 +1,89 @@
+package hub
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/greenplum-db/gpdb/gp/idl"
+	"github.com/greenplum-db/gpdb/gp/utils"
+	"github.com/greenplum-db/gpdb/gp/utils/tracing"
+)
+
+/*
+CheckDisk measures the sequential write and read throughput of the disks of
+the data directories of the planned layout. The hosts are tested in parallel
+and the directories of each host one at a time. Failures are reported in the
+results rather than as an error.
+*/
+func (s *Server) CheckDisk(ctx context.Context, req *idl.CheckDiskRequest) (*idl.CheckDiskReply, error) {
+	if req.GpArray == nil || req.GpArray.Coordinator == nil {
+		return nil, tracing.LogAndReturnError(ctx, errors.New("the planned layout of the cluster is not set"))
+	}
+
+	dirsByHost := make(map[string][]string)
+	addDir := func(seg *idl.Segment) {
+		if seg != nil {
+			dirsByHost[seg.HostName] = append(dirsByHost[seg.HostName], seg.DataDirectory)
+		}
+	}
+	addDir(req.GpArray.Coordinator)
+	for _, pair := range req.GpArray.SegmentArray {
+		addDir(pair.Primary)
+		addDir(pair.Mirror)
+	}
+
+	hosts := make([]string, 0, len(dirsByHost))
+	for host := range dirsByHost {
+		hosts = append(hosts, host)
+	}
+
+	var mutex sync.Mutex
+	reply := &idl.CheckDiskReply{}
+	err := s.executeOnHosts(ctx, hosts, func(conn *Connection) {
+		var results []*idl.DiskResult
+		resp, err := conn.AgentClient.TestDiskPerformance(ctx, &idl.TestDiskPerformanceRequest{
+			Directories: dirsByHost[conn.Hostname],
+			SizeBytes:   req.SizeBytes,
+			MaxDuration: req.MaxDuration,
+		})
+		if err != nil {
+			for _, dir := range dirsByHost[conn.Hostname] {
+				results = append(results, &idl.DiskResult{
+					Directory: dir,
+					Error:     fmt.Sprintf("could not run the check: %v", utils.FormatGrpcError(err)),
+				})
+			}
+		} else {
+			results = resp.Results
+		}
+
+		mutex.Lock()
+		defer mutex.Unlock()
+		for _, result := range results {
+			result.Host = conn.Hostname
+			reply.Results = append(reply.Results, result)
+		}
+	}, func(host string, err error) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		for _, dir := range dirsByHost[host] {
+			reply.Results = append(reply.Results, &idl.DiskResult{
+				Host:      host,
+				Directory: dir,
+				Error:     fmt.Sprintf("could not run the check: %v", err),
+			})
+		}
+	})
+	if err != nil {
+		return nil, tracing.LogAndReturnError(ctx, err)
+	}
+
+	sort.SliceStable(reply.Results, func(i, j int) bool {
+		return reply.Results[i].Host < reply.Results[j].Host
+	})
+
+	return reply, nil
+}
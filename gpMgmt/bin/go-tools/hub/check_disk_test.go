@@ -0,0 +1,78 @@
+package hub_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/greenplum-db/gp-common-go-libs/testhelper"
+
+	"github.com/greenplum-db/gpdb/gp/hub"
+	"github.com/greenplum-db/gpdb/gp/idl"
+	"github.com/greenplum-db/gpdb/gp/idl/mock_idl"
+)
+
+func TestCheckDisk(t *testing.T) {
+	testhelper.SetupTestLogger()
+	initialize(t)
+
+	hub.SetEnsureConnectionsAreReady(func(conns []*hub.Connection) error {
+		return nil
+	})
+	defer hub.ResetEnsureConnectionsAreReady()
+
+	gparray := &idl.GpArray{
+		Coordinator: &idl.Segment{HostName: "sdw1", DataDirectory: "/data/coordinator/gpseg-1"},
+		SegmentArray: []*idl.SegmentPair{{
+			Primary: &idl.Segment{HostName: "sdw2", DataDirectory: "/data/primary/gpseg0"},
+			Mirror:  &idl.Segment{HostName: "sdw1", DataDirectory: "/data/mirror/gpseg0"},
+		}},
+	}
+
+	t.Run("tests the data directories of every host", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		sdw1 := mock_idl.NewMockAgentClient(ctrl)
+		sdw1.EXPECT().TestDiskPerformance(gomock.Any(), &idl.TestDiskPerformanceRequest{
+			Directories: []string{"/data/coordinator/gpseg-1", "/data/mirror/gpseg0"},
+			SizeBytes:   1024,
+			MaxDuration: 10,
+		}).Return(&idl.TestDiskPerformanceReply{Results: []*idl.DiskResult{
+			{Directory: "/data/coordinator/gpseg-1", WriteMBps: 500, ReadMBps: 800},
+			{Directory: "/data/mirror/gpseg0", WriteMBps: 400, ReadMBps: 700},
+		}}, nil)
+
+		sdw2 := mock_idl.NewMockAgentClient(ctrl)
+		sdw2.EXPECT().TestDiskPerformance(gomock.Any(), gomock.Any()).Return(nil, errors.New("error"))
+
+		hubServer.Conns = []*hub.Connection{
+			{AgentClient: sdw2, Hostname: "sdw2"},
+			{AgentClient: sdw1, Hostname: "sdw1"},
+		}
+
+		reply, err := hubServer.CheckDisk(context.Background(), &idl.CheckDiskRequest{GpArray: gparray, SizeBytes: 1024, MaxDuration: 10})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		expected := []*idl.DiskResult{
+			{Host: "sdw1", Directory: "/data/coordinator/gpseg-1", WriteMBps: 500, ReadMBps: 800},
+			{Host: "sdw1", Directory: "/data/mirror/gpseg0", WriteMBps: 400, ReadMBps: 700},
+			{Host: "sdw2", Directory: "/data/primary/gpseg0", Error: "could not run the check: error"},
+		}
+		if !reflect.DeepEqual(reply.Results, expected) {
+			t.Fatalf("got %v, want %v", reply.Results, expected)
+		}
+	})
+
+	t.Run("errors out when the layout is not set", func(t *testing.T) {
+		_, err := hubServer.CheckDisk(context.Background(), &idl.CheckDiskRequest{})
+		expected := "the planned layout of the cluster is not set"
+		if err == nil || err.Error() != expected {
+			t.Fatalf("got %v, want %s", err, expected)
+		}
+	})
+}
@@ -0,0 +1,282 @@
+package hub
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/greenplum-db/gp-common-go-libs/gplog"
+	"github.com/greenplum-db/gpdb/gp/idl"
+	"github.com/greenplum-db/gpdb/gp/utils"
+	"github.com/greenplum-db/gpdb/gp/utils/tracing"
+	"golang.org/x/exp/slices"
+)
+
+// Time after which the agents close their listeners if the hub does not
+const checkListenersTimeout = 30 * time.Minute
+
+/*
+CheckNetwork checks that the hosts of the planned layout reach each other on
+the ports the segments will use. The hosts open temporary listeners on the
+planned ports, and each host connects to the ports it will use: the
+coordinator to all the segments, and the primaries and mirrors to each other
+for replication. Optionally the latency and throughput between every pair of
+hosts is measured. Failures are reported in the results rather than as an
+error, so it can be run before gp init.
+*/
+func (s *Server) CheckNetwork(ctx context.Context, req *idl.CheckNetworkRequest) (*idl.CheckNetworkReply, error) {
+	if req.GpArray == nil || req.GpArray.Coordinator == nil {
+		return nil, tracing.LogAndReturnError(ctx, errors.New("the planned layout of the cluster is not set"))
+	}
+
+	targets := NetworkCheckTargets(req.GpArray, req.Measure)
+	portsByHost := make(map[string][]int32)
+	for _, hostTargets := range targets {
+		for _, target := range hostTargets {
+			if !slices.Contains(portsByHost[target.Host], target.Port) {
+				portsByHost[target.Host] = append(portsByHost[target.Host], target.Port)
+			}
+		}
+	}
+
+	listenerHosts := make([]string, 0, len(portsByHost))
+	for host := range portsByHost {
+		listenerHosts = append(listenerHosts, host)
+	}
+	sourceHosts := make([]string, 0, len(targets))
+	for host := range targets {
+		sourceHosts = append(sourceHosts, host)
+	}
+
+	// the checks running at the same time only stop their own listeners
+	checkID, err := newCheckID()
+	if err != nil {
+		return nil, tracing.LogAndReturnError(ctx, err)
+	}
+
+	var mutex sync.Mutex
+	listenerErrs := make(map[string]string) // by host:port
+	err = s.executeOnHosts(ctx, listenerHosts, func(conn *Connection) {
+		reply, err := conn.AgentClient.StartListeners(ctx, &idl.StartListenersRequest{
+			Ports:   portsByHost[conn.Hostname],
+			Timeout: int32(checkListenersTimeout.Seconds()),
+			CheckId: checkID,
+		})
+
+		mutex.Lock()
+		defer mutex.Unlock()
+		if err != nil {
+			for _, port := range portsByHost[conn.Hostname] {
+				listenerErrs[hostPort(conn.Hostname, port)] = fmt.Sprintf("could not listen on the port: %v", utils.FormatGrpcError(err))
+			}
+			return
+		}
+
+		for _, listener := range reply.Listeners {
+			if listener.Error != "" {
+				listenerErrs[hostPort(conn.Hostname, listener.Port)] = listener.Error
+			}
+		}
+	}, func(host string, err error) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		for _, port := range portsByHost[host] {
+			listenerErrs[hostPort(host, port)] = fmt.Sprintf("could not listen on the port: %v", err)
+		}
+	})
+	if err != nil {
+		return nil, tracing.LogAndReturnError(ctx, err)
+	}
+	defer s.stopListeners(listenerHosts, checkID)
+
+	reply := &idl.CheckNetworkReply{}
+	err = s.executeOnHosts(ctx, sourceHosts, func(conn *Connection) {
+		var results []*idl.NetworkResult
+		var reachable []*idl.ConnectivityTarget
+		for _, target := range targets[conn.Hostname] {
+			if listenerErr, ok := listenerErrs[hostPort(target.Host, target.Port)]; ok {
+				results = append(results, networkResult(target, listenerErr))
+			} else {
+				reachable = append(reachable, target)
+			}
+		}
+
+		resp, err := conn.AgentClient.TestConnectivity(ctx, &idl.TestConnectivityRequest{
+			Targets:      reachable,
+			Timeout:      req.Timeout,
+			MeasureBytes: req.MeasureBytes,
+			CheckId:      checkID,
+		})
+		if err != nil {
+			for _, target := range reachable {
+				results = append(results, networkResult(target, fmt.Sprintf("could not run the check: %v", utils.FormatGrpcError(err))))
+			}
+		} else {
+			results = append(results, resp.Results...)
+		}
+
+		mutex.Lock()
+		defer mutex.Unlock()
+		for _, result := range results {
+			result.Source = conn.Hostname
+			reply.Results = append(reply.Results, result)
+		}
+	}, func(host string, err error) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		for _, target := range targets[host] {
+			result := networkResult(target, fmt.Sprintf("could not run the check: %v", err))
+			result.Source = host
+			reply.Results = append(reply.Results, result)
+		}
+	})
+	if err != nil {
+		return nil, tracing.LogAndReturnError(ctx, err)
+	}
+
+	sort.Slice(reply.Results, func(i, j int) bool {
+		a, b := reply.Results[i], reply.Results[j]
+		if a.Source != b.Source {
+			return a.Source < b.Source
+		}
+		if a.Target != b.Target {
+			return a.Target < b.Target
+		}
+		return a.Port < b.Port
+	})
+
+	return reply, nil
+}
+
+/*
+NetworkCheckTargets returns, by source host, the ports of the other hosts the
+source connects to in the planned layout. The coordinator connects to every
+segment, a mirror to its primary for replication, and a primary to its mirror
+once the mirror is promoted. When measuring, one target is measured for every
+pair of hosts, adding one when the source does not otherwise connect to the
+host.
+*/
+func NetworkCheckTargets(gparray *idl.GpArray, measure bool) map[string][]*idl.ConnectivityTarget {
+	targets := make(map[string][]*idl.ConnectivityTarget)
+	add := func(source string, target *idl.Segment, purpose string) {
+		if source == target.HostName {
+			return
+		}
+
+		address := target.HostAddress
+		if address == "" {
+			address = target.HostName
+		}
+		for _, existing := range targets[source] {
+			if existing.Address == address && existing.Port == target.Port {
+				return
+			}
+		}
+
+		targets[source] = append(targets[source], &idl.ConnectivityTarget{
+			Host:    target.HostName,
+			Address: address,
+			Port:    target.Port,
+			Purpose: purpose,
+		})
+	}
+
+	// The first segment of each host, whose port is used to measure the pairs
+	// of hosts which do not connect otherwise
+	coordinator := gparray.Coordinator
+	var firstSegs []*idl.Segment
+	addHost := func(seg *idl.Segment) {
+		for _, first := range firstSegs {
+			if first.HostName == seg.HostName {
+				return
+			}
+		}
+		firstSegs = append(firstSegs, seg)
+	}
+	addHost(coordinator)
+
+	for content, pair := range gparray.SegmentArray {
+		primary, mirror := pair.Primary, pair.Mirror
+		if primary == nil {
+			continue
+		}
+
+		add(coordinator.HostName, primary, fmt.Sprintf("primary of content %d", content))
+		addHost(primary)
+		if mirror == nil {
+			continue
+		}
+
+		add(coordinator.HostName, mirror, fmt.Sprintf("mirror of content %d", content))
+		add(mirror.HostName, primary, fmt.Sprintf("replication of content %d", content))
+		add(primary.HostName, mirror, fmt.Sprintf("replication of content %d after failover", content))
+		addHost(mirror)
+	}
+
+	if !measure {
+		return targets
+	}
+
+	for _, source := range firstSegs {
+		for _, target := range firstSegs {
+			if source.HostName == target.HostName {
+				continue
+			}
+
+			if !slices.ContainsFunc(targets[source.HostName], func(t *idl.ConnectivityTarget) bool { return t.Host == target.HostName }) {
+				add(source.HostName, target, "measure")
+			}
+
+			// measure the first connection to the host
+			for _, existing := range targets[source.HostName] {
+				if existing.Host == target.HostName {
+					existing.Measure = true
+					break
+				}
+			}
+		}
+	}
+
+	return targets
+}
+
+// stopListeners stops the listeners of the check even when it was cancelled
+func (s *Server) stopListeners(hosts []string, checkID string) {
+	err := s.executeRPC(context.Background(), s.agentConns(hosts), func(conn *Connection) error {
+		_, err := conn.AgentClient.StopListeners(context.Background(), &idl.StopListenersRequest{CheckId: checkID})
+		return utils.FormatGrpcError(err)
+	})
+	if err != nil {
+		gplog.Warn("could not stop the listeners of the network check, they are stopped after %s: %v", checkListenersTimeout, err)
+	}
+}
+
+// newCheckID returns a random ID telling the listeners of a check apart
+func newCheckID() (string, error) {
+	id := make([]byte, 8)
+	_, err := rand.Read(id)
+	if err != nil {
+		return "", fmt.Errorf("could not generate the ID of the check: %w", err)
+	}
+
+	return hex.EncodeToString(id), nil
+}
+
+func networkResult(target *idl.ConnectivityTarget, err string) *idl.NetworkResult {
+	return &idl.NetworkResult{
+		Target:  target.Host,
+		Address: target.Address,
+		Port:    target.Port,
+		Purpose: target.Purpose,
+		Error:   err,
+	}
+}
+
+func hostPort(host string, port int32) string {
+	return fmt.Sprintf("%s:%d", host, port)
+}
@@ -0,0 +1,201 @@
+package hub_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/greenplum-db/gp-common-go-libs/testhelper"
+
+	"github.com/greenplum-db/gpdb/gp/hub"
+	"github.com/greenplum-db/gpdb/gp/idl"
+	"github.com/greenplum-db/gpdb/gp/idl/mock_idl"
+)
+
+func TestNetworkCheckTargets(t *testing.T) {
+	gparray := &idl.GpArray{
+		Coordinator: &idl.Segment{HostName: "cdw", HostAddress: "cdw", Port: 5432},
+		SegmentArray: []*idl.SegmentPair{
+			{
+				Primary: &idl.Segment{HostName: "sdw1", HostAddress: "sdw1-1", Port: 7000},
+				Mirror:  &idl.Segment{HostName: "sdw2", HostAddress: "sdw2-1", Port: 8000},
+			},
+			{
+				Primary: &idl.Segment{HostName: "sdw2", HostAddress: "sdw2-1", Port: 7000},
+				Mirror:  &idl.Segment{HostName: "sdw1", HostAddress: "sdw1-1", Port: 8000},
+			},
+		},
+	}
+
+	t.Run("returns the ports each host connects to", func(t *testing.T) {
+		targets := hub.NetworkCheckTargets(gparray, false)
+
+		expected := map[string][]*idl.ConnectivityTarget{
+			"cdw": {
+				{Host: "sdw1", Address: "sdw1-1", Port: 7000, Purpose: "primary of content 0"},
+				{Host: "sdw2", Address: "sdw2-1", Port: 8000, Purpose: "mirror of content 0"},
+				{Host: "sdw2", Address: "sdw2-1", Port: 7000, Purpose: "primary of content 1"},
+				{Host: "sdw1", Address: "sdw1-1", Port: 8000, Purpose: "mirror of content 1"},
+			},
+			"sdw1": {
+				{Host: "sdw2", Address: "sdw2-1", Port: 8000, Purpose: "replication of content 0 after failover"},
+				{Host: "sdw2", Address: "sdw2-1", Port: 7000, Purpose: "replication of content 1"},
+			},
+			"sdw2": {
+				{Host: "sdw1", Address: "sdw1-1", Port: 7000, Purpose: "replication of content 0"},
+				{Host: "sdw1", Address: "sdw1-1", Port: 8000, Purpose: "replication of content 1 after failover"},
+			},
+		}
+		if !reflect.DeepEqual(targets, expected) {
+			t.Fatalf("got %v, want %v", targets, expected)
+		}
+	})
+
+	t.Run("measures one target for every pair of hosts", func(t *testing.T) {
+		targets := hub.NetworkCheckTargets(gparray, true)
+
+		measured := make(map[string]int)
+		for source, hostTargets := range targets {
+			for _, target := range hostTargets {
+				if target.Measure {
+					measured[source+"->"+target.Host]++
+				}
+			}
+		}
+
+		expected := map[string]int{
+			"cdw->sdw1": 1, "cdw->sdw2": 1,
+			"sdw1->sdw2": 1, "sdw2->sdw1": 1,
+			"sdw1->cdw": 1, "sdw2->cdw": 1,
+		}
+		if !reflect.DeepEqual(measured, expected) {
+			t.Fatalf("got measured pairs %v, want %v", measured, expected)
+		}
+
+		last := targets["sdw1"][len(targets["sdw1"])-1]
+		if last.Host != "cdw" || last.Port != 5432 || last.Purpose != "measure" {
+			t.Fatalf("got %v, want a target to measure the coordinator host", last)
+		}
+	})
+}
+
+func TestCheckNetwork(t *testing.T) {
+	testhelper.SetupTestLogger()
+	initialize(t)
+
+	hub.SetEnsureConnectionsAreReady(func(conns []*hub.Connection) error {
+		return nil
+	})
+	defer hub.ResetEnsureConnectionsAreReady()
+
+	// the coordinator runs on sdw1, so sdw1 connects to the primary on sdw2
+	// and sdw2 to the mirror on sdw1
+	gparray := &idl.GpArray{
+		Coordinator: &idl.Segment{HostName: "sdw1", HostAddress: "sdw1", Port: 5432},
+		SegmentArray: []*idl.SegmentPair{{
+			Primary: &idl.Segment{HostName: "sdw2", HostAddress: "sdw2", Port: 7000},
+			Mirror:  &idl.Segment{HostName: "sdw1", HostAddress: "sdw1", Port: 7001},
+		}},
+	}
+
+	t.Run("returns the results of the connections from all the hosts", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		var checkID string
+		sdw1 := mock_idl.NewMockAgentClient(ctrl)
+		sdw1.EXPECT().StartListeners(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, req *idl.StartListenersRequest, opts ...any) (*idl.StartListenersReply, error) {
+			if !reflect.DeepEqual(req.Ports, []int32{7001}) {
+				t.Fatalf("got ports %v, want [7001]", req.Ports)
+			}
+			if req.CheckId == "" {
+				t.Fatalf("got no check ID")
+			}
+			checkID = req.CheckId
+			return &idl.StartListenersReply{Listeners: []*idl.ListenerStatus{{Port: 7001, Error: "port 7001 is already in use"}}}, nil
+		})
+		sdw1.EXPECT().TestConnectivity(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, req *idl.TestConnectivityRequest, opts ...any) (*idl.TestConnectivityReply, error) {
+			if req.Timeout != 5 || len(req.Targets) != 1 || req.Targets[0].Port != 7000 || req.CheckId != checkID {
+				t.Fatalf("unexpected request %v", req)
+			}
+			return &idl.TestConnectivityReply{Results: []*idl.NetworkResult{
+				{Target: "sdw2", Address: "sdw2", Port: 7000, Purpose: "primary of content 0", Reachable: true},
+			}}, nil
+		})
+		sdw1.EXPECT().StopListeners(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, req *idl.StopListenersRequest, opts ...any) (*idl.StopListenersReply, error) {
+			if req.CheckId != checkID {
+				t.Fatalf("got check ID %q, want %q", req.CheckId, checkID)
+			}
+			return &idl.StopListenersReply{}, nil
+		})
+
+		sdw2 := mock_idl.NewMockAgentClient(ctrl)
+		sdw2.EXPECT().StartListeners(gomock.Any(), gomock.Any()).Return(&idl.StartListenersReply{Listeners: []*idl.ListenerStatus{{Port: 7000}}}, nil)
+		sdw2.EXPECT().TestConnectivity(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, req *idl.TestConnectivityRequest, opts ...any) (*idl.TestConnectivityReply, error) {
+			if req.Timeout != 5 || len(req.Targets) != 0 || req.CheckId != checkID {
+				t.Fatalf("unexpected request %v", req)
+			}
+			return &idl.TestConnectivityReply{}, nil
+		})
+		sdw2.EXPECT().StopListeners(gomock.Any(), gomock.Any()).Return(nil, errors.New("error"))
+
+		hubServer.Conns = []*hub.Connection{
+			{AgentClient: sdw1, Hostname: "sdw1"},
+			{AgentClient: sdw2, Hostname: "sdw2"},
+		}
+
+		reply, err := hubServer.CheckNetwork(context.Background(), &idl.CheckNetworkRequest{GpArray: gparray, Timeout: 5})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		expected := []*idl.NetworkResult{
+			{Source: "sdw1", Target: "sdw2", Address: "sdw2", Port: 7000, Purpose: "primary of content 0", Reachable: true},
+			{Source: "sdw2", Target: "sdw1", Address: "sdw1", Port: 7001, Purpose: "replication of content 0 after failover", Error: "port 7001 is already in use"},
+		}
+		if !reflect.DeepEqual(reply.Results, expected) {
+			t.Fatalf("got %v, want %v", reply.Results, expected)
+		}
+	})
+
+	t.Run("reports the targets of the hosts whose check failed", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		sdw1 := mock_idl.NewMockAgentClient(ctrl)
+		sdw1.EXPECT().StartListeners(gomock.Any(), gomock.Any()).Return(&idl.StartListenersReply{}, nil)
+		sdw1.EXPECT().TestConnectivity(gomock.Any(), gomock.Any()).Return(nil, errors.New("error"))
+		sdw1.EXPECT().StopListeners(gomock.Any(), gomock.Any()).Return(&idl.StopListenersReply{}, nil)
+
+		sdw2 := mock_idl.NewMockAgentClient(ctrl)
+		sdw2.EXPECT().StartListeners(gomock.Any(), gomock.Any()).Return(&idl.StartListenersReply{}, nil)
+		sdw2.EXPECT().TestConnectivity(gomock.Any(), gomock.Any()).Return(&idl.TestConnectivityReply{Results: []*idl.NetworkResult{
+			{Target: "sdw1", Address: "sdw1", Port: 7001, Reachable: true},
+		}}, nil)
+		sdw2.EXPECT().StopListeners(gomock.Any(), gomock.Any()).Return(&idl.StopListenersReply{}, nil)
+
+		hubServer.Conns = []*hub.Connection{
+			{AgentClient: sdw1, Hostname: "sdw1"},
+			{AgentClient: sdw2, Hostname: "sdw2"},
+		}
+
+		reply, err := hubServer.CheckNetwork(context.Background(), &idl.CheckNetworkRequest{GpArray: gparray})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		if len(reply.Results) != 2 || reply.Results[0].Error != "could not run the check: error" || !reply.Results[1].Reachable {
+			t.Fatalf("unexpected results %v", reply.Results)
+		}
+	})
+
+	t.Run("errors out when the layout is not set", func(t *testing.T) {
+		_, err := hubServer.CheckNetwork(context.Background(), &idl.CheckNetworkRequest{})
+		expected := "the planned layout of the cluster is not set"
+		if err == nil || err.Error() != expected {
+			t.Fatalf("got %v, want %s", err, expected)
+		}
+	})
+}
@@ -0,0 +1,323 @@
+package hub
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/greenplum-db/gpdb/gp/constants"
+	"github.com/greenplum-db/gpdb/gp/idl"
+	"github.com/greenplum-db/gpdb/gp/utils"
+	"github.com/greenplum-db/gpdb/gp/utils/greenplum"
+	"github.com/greenplum-db/gpdb/gp/utils/tracing"
+)
+
+// SupportBundleManifest describes the content of the support bundle and the
+// failures to collect it
+type SupportBundleManifest struct {
+	Created     time.Time           `json:"created"`
+	Since       time.Time           `json:"since"`
+	MaxHostSize int64               `json:"maxHostSize"`
+	Hosts       []SupportBundleHost `json:"hosts"`
+	Errors      []string            `json:"errors,omitempty"`
+}
+
+// SupportBundleHost holds the manifest of the part of a host, or the error
+// which prevented collecting it
+type SupportBundleHost struct {
+	Host     string          `json:"host"`
+	Error    string          `json:"error,omitempty"`
+	Manifest json.RawMessage `json:"manifest,omitempty"`
+}
+
+/*
+CollectLogs creates a support bundle in the output file on the coordinator host.
+Each agent collects its part in parallel, which are then assembled under a
+directory per host along with a snapshot of gp_segment_configuration and the
+manifest of the bundle. Hosts failing to collect their part are reported in
+the manifest, and the bundle is still created for the rest of the cluster.
+*/
+func (s *Server) CollectLogs(req *idl.CollectLogsRequest, stream idl.Hub_CollectLogsServer) error {
+	hubStream := NewHubStream(stream)
+	manifest := SupportBundleManifest{
+		Created:     time.Now(),
+		MaxHostSize: req.MaxHostSize,
+	}
+	if req.Since > 0 {
+		manifest.Since = time.Unix(req.Since, 0)
+	}
+
+	if req.OutputFile == "" {
+		return tracing.LogAndReturnError(stream.Context(), errors.New("the output file of the support bundle is not set"))
+	}
+	if _, err := utils.System.Stat(req.OutputFile); err == nil {
+		return tracing.LogAndReturnError(stream.Context(), fmt.Errorf("output file %s already exists", req.OutputFile))
+	}
+
+	// The hosts are still collected when the catalog cannot be read, without
+	// the configuration and logs of their segments
+	gparray, err := getGpArray(req.CoordinatorDataDir)
+	if err != nil {
+		hubStream.StreamLogMsg(fmt.Sprintf("Could not read the segment configuration, collecting the logs of the hosts only: %v", err), idl.LogLevel_WARNING)
+		manifest.Errors = append(manifest.Errors, fmt.Sprintf("could not read the segment configuration: %v", err))
+	}
+
+	segsByHost := make(map[string][]*idl.Segment)
+	if gparray != nil {
+		for _, seg := range allSegments(gparray) {
+			segsByHost[seg.Hostname] = append(segsByHost[seg.Hostname], segmentToIdl(seg))
+		}
+	}
+
+	partsDir, err := os.MkdirTemp("", "gp_support_")
+	if err != nil {
+		return tracing.LogAndReturnError(stream.Context(), err)
+	}
+	defer os.RemoveAll(partsDir)
+
+	progressLabel := "Collecting diagnostics:"
+	progressTotal := len(s.Hostnames)
+	if len(req.Hosts) > 0 {
+		progressTotal = len(req.Hosts)
+	}
+	hubStream.StreamProgressMsg(progressLabel, progressTotal)
+
+	var mutex sync.Mutex
+	hostErrs := make(map[string]error)
+	err = s.executeOnHosts(stream.Context(), req.Hosts, func(conn *Connection) {
+		err := collectAgentDiagnostics(stream.Context(), conn, &idl.CollectDiagnosticsRequest{
+			Segments: segsByHost[conn.Hostname],
+			Since:    req.Since,
+			MaxSize:  req.MaxHostSize,
+		}, filepath.Join(partsDir, conn.Hostname))
+
+		mutex.Lock()
+		defer mutex.Unlock()
+		hostErrs[conn.Hostname] = err
+		if err != nil {
+			hubStream.StreamLogMsg(fmt.Sprintf("Could not collect the diagnostics of host %s: %v", conn.Hostname, err), idl.LogLevel_WARNING)
+		}
+		hubStream.StreamProgressMsg(progressLabel, progressTotal)
+	}, func(host string, err error) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		hostErrs[host] = err
+		hubStream.StreamLogMsg(fmt.Sprintf("Could not collect the diagnostics of host %s: %v", host, err), idl.LogLevel_WARNING)
+		hubStream.StreamProgressMsg(progressLabel, progressTotal)
+	})
+	if err != nil {
+		return tracing.LogAndReturnError(stream.Context(), err)
+	}
+
+	size, err := writeSupportBundle(req.OutputFile, partsDir, hostErrs, gparray, manifest)
+	if err != nil {
+		return tracing.LogAndReturnError(stream.Context(), fmt.Errorf("could not write the support bundle %s: %w", req.OutputFile, err))
+	}
+
+	hubStream.StreamLogMsg(fmt.Sprintf("Support bundle written to %s (%d bytes)", req.OutputFile, size))
+
+	return nil
+}
+
+// collectAgentDiagnostics writes the part streamed by the agent to the given file
+func collectAgentDiagnostics(ctx context.Context, conn *Connection, req *idl.CollectDiagnosticsRequest, path string) error {
+	stream, err := conn.AgentClient.CollectDiagnostics(ctx, req)
+	if err != nil {
+		return utils.FormatGrpcError(err)
+	}
+
+	file, err := utils.System.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	for {
+		reply, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return utils.FormatGrpcError(err)
+		}
+
+		_, err = file.Write(reply.Data)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// writeSupportBundle assembles the parts of the hosts into the output file and
+// returns its size. The file is removed when it could not be written.
+func writeSupportBundle(path string, partsDir string, hostErrs map[string]error, gparray *greenplum.GpArray, manifest SupportBundleManifest) (size int64, err error) {
+	file, err := utils.System.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		closeErr := file.Close()
+		if err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			_ = utils.System.RemoveAll(path)
+		}
+	}()
+
+	gzipWriter := gzip.NewWriter(file)
+	bundle := tar.NewWriter(gzipWriter)
+
+	if gparray != nil {
+		err = addBundleFile(bundle, "gp_segment_configuration.csv", segmentConfigurationCSV(gparray))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	hosts := make([]string, 0, len(hostErrs))
+	for host := range hostErrs {
+		hosts = append(hosts, host)
+	}
+	sort.Strings(hosts)
+
+	for _, host := range hosts {
+		hostManifest := SupportBundleHost{Host: host}
+		if hostErrs[host] != nil {
+			hostManifest.Error = hostErrs[host].Error()
+		} else {
+			hostManifest.Manifest, err = addBundlePart(bundle, host, filepath.Join(partsDir, host))
+			if err != nil {
+				return 0, fmt.Errorf("could not add the diagnostics of host %s: %w", host, err)
+			}
+		}
+
+		manifest.Hosts = append(manifest.Hosts, hostManifest)
+	}
+
+	contents, err := json.MarshalIndent(manifest, "", "    ")
+	if err != nil {
+		return 0, err
+	}
+
+	err = addBundleFile(bundle, constants.DiagnosticsManifestName, contents)
+	if err != nil {
+		return 0, err
+	}
+
+	err = bundle.Close()
+	if err != nil {
+		return 0, err
+	}
+
+	err = gzipWriter.Close()
+	if err != nil {
+		return 0, err
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		return 0, err
+	}
+
+	return info.Size(), nil
+}
+
+// addBundlePart copies the files of the part of a host under a directory named
+// after the host, and returns the manifest of the part
+func addBundlePart(bundle *tar.Writer, host string, path string) (json.RawMessage, error) {
+	file, err := utils.System.OpenFile(path, os.O_RDONLY, 0)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	gzipReader, err := gzip.NewReader(file)
+	if err != nil {
+		return nil, err
+	}
+
+	var manifest json.RawMessage
+	part := tar.NewReader(gzipReader)
+	for {
+		header, err := part.Next()
+		if errors.Is(err, io.EOF) {
+			return manifest, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		var contents io.Reader = part
+		if header.Name == constants.DiagnosticsManifestName {
+			manifest, err = io.ReadAll(part)
+			if err != nil {
+				return nil, err
+			}
+			contents = bytes.NewReader(manifest)
+		}
+
+		header.Name = filepath.Join(host, header.Name)
+		err = bundle.WriteHeader(header)
+		if err != nil {
+			return nil, err
+		}
+
+		_, err = io.Copy(bundle, contents)
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+func addBundleFile(bundle *tar.Writer, name string, contents []byte) error {
+	err := bundle.WriteHeader(&tar.Header{
+		Name:    name,
+		Mode:    0600,
+		Size:    int64(len(contents)),
+		ModTime: time.Now(),
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = bundle.Write(contents)
+
+	return err
+}
+
+// segmentConfigurationCSV returns a snapshot of gp_segment_configuration
+func segmentConfigurationCSV(gparray *greenplum.GpArray) []byte {
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	_ = writer.Write([]string{"dbid", "content", "role", "preferred_role", "mode", "status", "port", "hostname", "address", "datadir"})
+	for _, seg := range allSegments(gparray) {
+		_ = writer.Write([]string{
+			strconv.Itoa(seg.Dbid),
+			strconv.Itoa(seg.Content),
+			seg.Role,
+			seg.PreferredRole,
+			seg.Mode,
+			seg.Status,
+			strconv.Itoa(seg.Port),
+			seg.Hostname,
+			seg.Address,
+			seg.DataDir,
+		})
+	}
+	writer.Flush()
+
+	return buf.Bytes()
+}
@@ -0,0 +1,276 @@
+package hub_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/greenplum-db/gp-common-go-libs/testhelper"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/greenplum-db/gpdb/gp/hub"
+	"github.com/greenplum-db/gpdb/gp/idl"
+	"github.com/greenplum-db/gpdb/gp/idl/mock_idl"
+	"github.com/greenplum-db/gpdb/gp/testutils"
+)
+
+type collectLogsStream struct {
+	grpc.ServerStream
+	replies []*idl.HubReply
+}
+
+func (s *collectLogsStream) Send(reply *idl.HubReply) error {
+	s.replies = append(s.replies, reply)
+	return nil
+}
+
+func (s *collectLogsStream) Context() context.Context {
+	return context.Background()
+}
+
+func (s *collectLogsStream) logs() []string {
+	var logs []string
+	for _, reply := range s.replies {
+		if msg := reply.GetLogMsg(); msg != nil {
+			logs = append(logs, msg.Message)
+		}
+	}
+
+	return logs
+}
+
+// diagnosticsPart returns a gzipped tar archive with the given files
+func diagnosticsPart(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	gzipWriter := gzip.NewWriter(&buf)
+	archive := tar.NewWriter(gzipWriter)
+	for name, contents := range files {
+		err := archive.WriteHeader(&tar.Header{Name: name, Mode: 0600, Size: int64(len(contents))})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		_, _ = archive.Write([]byte(contents))
+	}
+	archive.Close()
+	gzipWriter.Close()
+
+	return buf.Bytes()
+}
+
+func mockCollectDiagnosticsStream(ctrl *gomock.Controller, data []byte) *mock_idl.MockAgent_CollectDiagnosticsClient {
+	stream := mock_idl.NewMockAgent_CollectDiagnosticsClient(ctrl)
+	half := len(data) / 2
+	stream.EXPECT().Recv().Return(&idl.CollectDiagnosticsReply{Data: data[:half]}, nil)
+	stream.EXPECT().Recv().Return(&idl.CollectDiagnosticsReply{Data: data[half:]}, nil)
+	stream.EXPECT().Recv().Return(nil, io.EOF)
+
+	return stream
+}
+
+// readBundle returns the contents of the files of the support bundle by name
+func readBundle(t *testing.T, path string) map[string]string {
+	t.Helper()
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %#v", err)
+	}
+	defer file.Close()
+
+	gzipReader, err := gzip.NewReader(file)
+	if err != nil {
+		t.Fatalf("unexpected error: %#v", err)
+	}
+
+	files := make(map[string]string)
+	archive := tar.NewReader(gzipReader)
+	for {
+		header, err := archive.Next()
+		if errors.Is(err, io.EOF) {
+			return files
+		}
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		contents, _ := io.ReadAll(archive)
+		files[header.Name] = string(contents)
+	}
+}
+
+func TestCollectLogs(t *testing.T) {
+	testhelper.SetupTestLogger()
+	initialize(t)
+
+	t.Run("assembles the parts of the hosts into the support bundle", func(t *testing.T) {
+		setupPgHbaTest(t)
+		defer teardownPgHbaTest()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		sdw1 := mock_idl.NewMockAgentClient(ctrl)
+		sdw1.EXPECT().CollectDiagnostics(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, req *idl.CollectDiagnosticsRequest, opts ...grpc.CallOption) (idl.Agent_CollectDiagnosticsClient, error) {
+			var dataDirs []string
+			for _, seg := range req.Segments {
+				dataDirs = append(dataDirs, seg.DataDirectory)
+			}
+			sort.Strings(dataDirs)
+			if !reflect.DeepEqual(dataDirs, []string{mirror2.DataDir, primary1.DataDir}) || req.Since != 1706659200 || req.MaxSize != 1024 {
+				t.Fatalf("unexpected request %v", req)
+			}
+
+			return mockCollectDiagnosticsStream(ctrl, diagnosticsPart(t, map[string]string{
+				"host_facts.txt": "hostname: sdw1",
+				"manifest.json":  `{"host":"sdw1"}`,
+			})), nil
+		})
+
+		sdw2 := mock_idl.NewMockAgentClient(ctrl)
+		sdw2.EXPECT().CollectDiagnostics(gomock.Any(), gomock.Any()).Return(nil, errors.New("error"))
+
+		hubServer.Conns = []*hub.Connection{
+			{AgentClient: sdw1, Hostname: "sdw1"},
+			{AgentClient: sdw2, Hostname: "sdw2"},
+		}
+
+		output := filepath.Join(t.TempDir(), "bundle.tar.gz")
+		stream := &collectLogsStream{}
+		err := hubServer.CollectLogs(&idl.CollectLogsRequest{Since: 1706659200, MaxHostSize: 1024, OutputFile: output}, stream)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		files := readBundle(t, output)
+		if files["sdw1/host_facts.txt"] != "hostname: sdw1" {
+			t.Fatalf("got %v, want the files of sdw1 under its directory", files)
+		}
+		if !strings.Contains(files["gp_segment_configuration.csv"], "2,0,p,p,,,7001,sdw1,sdw1,/data/primary/gpseg0\n") {
+			t.Fatalf("got %q, want the segment configuration", files["gp_segment_configuration.csv"])
+		}
+
+		var manifest hub.SupportBundleManifest
+		err = json.Unmarshal([]byte(files["manifest.json"]), &manifest)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		if len(manifest.Hosts) != 2 || manifest.Hosts[0].Host != "sdw1" || !strings.Contains(string(manifest.Hosts[0].Manifest), `"host": "sdw1"`) ||
+			!reflect.DeepEqual(manifest.Hosts[1], hub.SupportBundleHost{Host: "sdw2", Error: "error"}) {
+			t.Fatalf("got %+v, want the manifest of sdw1 and the error of sdw2", manifest.Hosts)
+		}
+
+		logs := stream.logs()
+		if len(logs) != 2 || logs[0] != "Could not collect the diagnostics of host sdw2: error" || !strings.HasPrefix(logs[1], "Support bundle written to "+output) {
+			t.Fatalf("got %q", logs)
+		}
+	})
+
+	t.Run("collects the hosts when the segment configuration cannot be read", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		sdw2 := mock_idl.NewMockAgentClient(ctrl)
+		sdw2.EXPECT().CollectDiagnostics(gomock.Any(), &idl.CollectDiagnosticsRequest{}).
+			Return(mockCollectDiagnosticsStream(ctrl, diagnosticsPart(t, map[string]string{"manifest.json": "{}"})), nil)
+
+		hub.SetEnsureConnectionsAreReady(func(conns []*hub.Connection) error {
+			return nil
+		})
+		defer hub.ResetEnsureConnectionsAreReady()
+		hubServer.Conns = []*hub.Connection{
+			{AgentClient: mock_idl.NewMockAgentClient(ctrl), Hostname: "sdw1"},
+			{AgentClient: sdw2, Hostname: "sdw2"},
+		}
+
+		output := filepath.Join(t.TempDir(), "bundle.tar.gz")
+		err := hubServer.CollectLogs(&idl.CollectLogsRequest{CoordinatorDataDir: "/non/existent", Hosts: []string{"sdw2"}, OutputFile: output}, &collectLogsStream{})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		files := readBundle(t, output)
+		if _, ok := files["gp_segment_configuration.csv"]; ok {
+			t.Fatalf("unexpected segment configuration")
+		}
+
+		var manifest hub.SupportBundleManifest
+		err = json.Unmarshal([]byte(files["manifest.json"]), &manifest)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		if len(manifest.Hosts) != 1 || manifest.Hosts[0].Host != "sdw2" || len(manifest.Errors) != 1 {
+			t.Fatalf("got %+v, want sdw2 and the catalog error", manifest)
+		}
+	})
+
+	t.Run("reports the hosts whose agent could not be connected to in the manifest", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		server := hub.New(&hub.Config{
+			AgentPort:   5678,
+			Hostnames:   []string{"sdw1", "sdw2"},
+			Credentials: &testutils.MockCredentials{TlsConnection: insecure.NewCredentials()},
+		}, func(ctx context.Context, address string) (net.Conn, error) {
+			return nil, errors.New("connection refused")
+		})
+		sdw1 := mock_idl.NewMockAgentClient(ctrl)
+		sdw1.EXPECT().CollectDiagnostics(gomock.Any(), &idl.CollectDiagnosticsRequest{}).
+			Return(mockCollectDiagnosticsStream(ctrl, diagnosticsPart(t, map[string]string{"manifest.json": "{}"})), nil)
+		server.Conns = []*hub.Connection{
+			{AgentClient: sdw1, Hostname: "sdw1"},
+		}
+
+		output := filepath.Join(t.TempDir(), "bundle.tar.gz")
+		stream := &collectLogsStream{}
+		err := server.CollectLogs(&idl.CollectLogsRequest{CoordinatorDataDir: "/non/existent", OutputFile: output}, stream)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		var manifest hub.SupportBundleManifest
+		err = json.Unmarshal([]byte(readBundle(t, output)["manifest.json"]), &manifest)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		if len(manifest.Hosts) != 2 || manifest.Hosts[0].Host != "sdw1" || manifest.Hosts[0].Error != "" {
+			t.Fatalf("got %+v, want the part of sdw1", manifest.Hosts)
+		}
+		expected := "could not connect to agent on host sdw2:"
+		if manifest.Hosts[1].Host != "sdw2" || !strings.HasPrefix(manifest.Hosts[1].Error, expected) {
+			t.Fatalf("got %+v, want the error of sdw2 %s", manifest.Hosts[1], expected)
+		}
+		if logs := strings.Join(stream.logs(), "\n"); !strings.Contains(logs, "Could not collect the diagnostics of host sdw2: "+expected) {
+			t.Fatalf("got %q, want the warning for sdw2", logs)
+		}
+	})
+
+	t.Run("errors out when the output file already exists", func(t *testing.T) {
+		output := filepath.Join(t.TempDir(), "bundle.tar.gz")
+		err := os.WriteFile(output, []byte("existing"), 0600)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		err = hubServer.CollectLogs(&idl.CollectLogsRequest{OutputFile: output}, &collectLogsStream{})
+		expected := "output file " + output + " already exists"
+		if err == nil || err.Error() != expected {
+			t.Fatalf("got %v, want %s", err, expected)
+		}
+	})
+}
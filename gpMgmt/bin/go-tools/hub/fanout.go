@@ -0,0 +1,193 @@
+package hub
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ErrFanOutCancelled is the error of the hosts whose call was cancelled after
+// another host failed, with the fail-fast policy
+var ErrFanOutCancelled = errors.New("cancelled after the failure of another host")
+
+/*
+FanOutPolicy decides whether a call made on several hosts failed as a whole.
+The calls on the hosts are made in parallel, and their results are kept
+whatever the policy, so that all the failing hosts are reported at once.
+*/
+type FanOutPolicy struct {
+	failFast bool
+	quorum   int
+	limit    int
+}
+
+var (
+	// FailFast cancels the calls on the other hosts once a host fails, and
+	// fails if any host failed
+	FailFast = FanOutPolicy{failFast: true}
+	// BestEffort lets the calls on all hosts finish, and fails if any host
+	// failed
+	BestEffort = FanOutPolicy{}
+)
+
+// Quorum lets the calls on all hosts finish, and fails if fewer than n hosts
+// succeeded
+func Quorum(n int) FanOutPolicy {
+	return FanOutPolicy{quorum: n}
+}
+
+// Limit returns the policy calling at most n hosts at once, or all of them if n
+// is not positive
+func (p FanOutPolicy) Limit(n int) FanOutPolicy {
+	p.limit = n
+	return p
+}
+
+// HostResult is the outcome of the call on a host, which succeeded if Err is
+// nil
+type HostResult struct {
+	Host     string
+	Duration time.Duration
+	Err      error
+}
+
+// FanOutResult holds the result of the call on each host, sorted by host
+type FanOutResult struct {
+	Hosts  []*HostResult
+	policy FanOutPolicy
+}
+
+/*
+FanOut makes the request on the agent of each connection in parallel, and
+returns the result of each host. The context passed to the request is cancelled
+once a host fails with the fail-fast policy, and the hosts still waiting for
+their turn under a limit are not called then.
+*/
+func FanOut(ctx context.Context, conns []*Connection, policy FanOutPolicy, request func(ctx context.Context, conn *Connection) error) *FanOutResult {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	result := &FanOutResult{Hosts: make([]*HostResult, len(conns)), policy: policy}
+	var slots chan struct{}
+	if policy.limit > 0 {
+		slots = make(chan struct{}, policy.limit)
+	}
+	var mutex sync.Mutex
+	var failed bool
+	var wg sync.WaitGroup
+	for i, conn := range conns {
+		wg.Add(1)
+		go func(i int, conn *Connection) {
+			defer wg.Done()
+
+			if slots != nil {
+				select {
+				case slots <- struct{}{}:
+					defer func() { <-slots }()
+				case <-ctx.Done():
+				}
+
+				// the slot may be free as well once the context is done
+				if err := ctx.Err(); err != nil {
+					mutex.Lock()
+					if failed {
+						err = fmt.Errorf("%w: %v", ErrFanOutCancelled, err)
+					}
+					mutex.Unlock()
+					result.Hosts[i] = &HostResult{Host: conn.Hostname, Err: err}
+					return
+				}
+			}
+
+			start := time.Now()
+			err := request(ctx, conn)
+			if err != nil && policy.failFast {
+				mutex.Lock()
+				if failed && ctx.Err() != nil {
+					err = fmt.Errorf("%w: %v", ErrFanOutCancelled, err)
+				} else {
+					failed = true
+					cancel()
+				}
+				mutex.Unlock()
+			}
+			result.Hosts[i] = &HostResult{Host: conn.Hostname, Duration: time.Since(start), Err: err}
+		}(i, conn)
+	}
+	wg.Wait()
+
+	sort.SliceStable(result.Hosts, func(i, j int) bool {
+		return result.Hosts[i].Host < result.Hosts[j].Host
+	})
+
+	return result
+}
+
+// Succeeded returns the hosts the call succeeded on
+func (r *FanOutResult) Succeeded() []string {
+	var hosts []string
+	for _, host := range r.Hosts {
+		if host.Err == nil {
+			hosts = append(hosts, host.Host)
+		}
+	}
+
+	return hosts
+}
+
+// Failed returns the results of the hosts the call failed on, including the
+// cancelled ones
+func (r *FanOutResult) Failed() []*HostResult {
+	var failed []*HostResult
+	for _, host := range r.Hosts {
+		if host.Err != nil {
+			failed = append(failed, host)
+		}
+	}
+
+	return failed
+}
+
+// Host returns the result of the host, or nil if the call was not made on it
+func (r *FanOutResult) Host(host string) *HostResult {
+	for _, result := range r.Hosts {
+		if result.Host == host {
+			return result
+		}
+	}
+
+	return nil
+}
+
+/*
+Err returns whether the call failed as a whole under its policy, with the
+error of every failed host, one per line. The hosts cancelled by the fail-fast
+policy are left out, as they did not fail on their own.
+*/
+func (r *FanOutResult) Err() error {
+	var errs []error
+	for _, host := range r.Failed() {
+		if !errors.Is(host.Err, ErrFanOutCancelled) {
+			errs = append(errs, fmt.Errorf("host: %s, %w", host.Host, host.Err))
+		}
+	}
+
+	if r.policy.quorum > 0 {
+		succeeded := len(r.Succeeded())
+		if succeeded >= r.policy.quorum {
+			return nil
+		}
+
+		err := fmt.Errorf("succeeded on %d of %d hosts, %d required", succeeded, len(r.Hosts), r.policy.quorum)
+		if len(errs) == 0 {
+			return err
+		}
+
+		return fmt.Errorf("%w:\n%w", err, errors.Join(errs...))
+	}
+
+	return errors.Join(errs...)
+}
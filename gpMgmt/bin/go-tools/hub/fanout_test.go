@@ -0,0 +1,145 @@
+package hub_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/greenplum-db/gpdb/gp/hub"
+)
+
+func TestFanOut(t *testing.T) {
+	conns := []*hub.Connection{{Hostname: "sdw3"}, {Hostname: "sdw1"}, {Hostname: "sdw2"}}
+
+	// fails on the hosts given, after waiting for the context to be cancelled
+	// on the other hosts for at most a second
+	request := func(failing ...string) func(ctx context.Context, conn *hub.Connection) error {
+		return func(ctx context.Context, conn *hub.Connection) error {
+			for _, host := range failing {
+				if conn.Hostname == host {
+					return errors.New("error")
+				}
+			}
+
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(time.Second):
+				return nil
+			}
+		}
+	}
+
+	t.Run("records the result of every host sorted by host", func(t *testing.T) {
+		result := hub.FanOut(context.Background(), conns, hub.BestEffort, request("sdw1", "sdw3"))
+
+		var hosts []string
+		for _, host := range result.Hosts {
+			hosts = append(hosts, host.Host)
+			if host.Duration <= 0 {
+				t.Fatalf("expected the duration of host %s to be recorded", host.Host)
+			}
+		}
+		if !reflect.DeepEqual(hosts, []string{"sdw1", "sdw2", "sdw3"}) {
+			t.Fatalf("got %+v, want the hosts sorted", hosts)
+		}
+		if !reflect.DeepEqual(result.Succeeded(), []string{"sdw2"}) {
+			t.Fatalf("got %+v, want sdw2 to succeed", result.Succeeded())
+		}
+		if result.Host("sdw3").Err == nil || result.Host("sdw4") != nil {
+			t.Fatalf("got %+v, want the result of each host", result.Hosts)
+		}
+	})
+
+	t.Run("best effort reports all the failed hosts", func(t *testing.T) {
+		result := hub.FanOut(context.Background(), conns, hub.BestEffort, request("sdw1", "sdw3"))
+
+		err := result.Err()
+		expected := "host: sdw1, error\nhost: sdw3, error"
+		if err == nil || err.Error() != expected {
+			t.Fatalf("got %v, want %s", err, expected)
+		}
+	})
+
+	t.Run("fail fast cancels the calls on the other hosts", func(t *testing.T) {
+		start := time.Now()
+		result := hub.FanOut(context.Background(), conns, hub.FailFast, request("sdw2"))
+
+		if time.Since(start) >= time.Second {
+			t.Fatalf("expected the calls on the other hosts to be cancelled")
+		}
+		for _, host := range []string{"sdw1", "sdw3"} {
+			if !errors.Is(result.Host(host).Err, hub.ErrFanOutCancelled) {
+				t.Fatalf("got %v for host %s, want it to be cancelled", result.Host(host).Err, host)
+			}
+		}
+
+		err := result.Err()
+		expected := "host: sdw2, error"
+		if err == nil || err.Error() != expected {
+			t.Fatalf("got %v, want %s", err, expected)
+		}
+	})
+
+	t.Run("quorum succeeds when enough hosts succeeded", func(t *testing.T) {
+		result := hub.FanOut(context.Background(), conns, hub.Quorum(2), request("sdw3"))
+		if err := result.Err(); err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		result = hub.FanOut(context.Background(), conns, hub.Quorum(2), request("sdw1", "sdw3"))
+		expected := "succeeded on 1 of 3 hosts, 2 required:\nhost: sdw1, error\nhost: sdw3, error"
+		if err := result.Err(); err == nil || err.Error() != expected {
+			t.Fatalf("got %v, want %s", err, expected)
+		}
+	})
+
+	t.Run("calls at most as many hosts at once as the limit", func(t *testing.T) {
+		var running, maxRunning atomic.Int32
+		result := hub.FanOut(context.Background(), conns, hub.BestEffort.Limit(2), func(ctx context.Context, conn *hub.Connection) error {
+			current := running.Add(1)
+			defer running.Add(-1)
+			for {
+				prev := maxRunning.Load()
+				if current <= prev || maxRunning.CompareAndSwap(prev, current) {
+					break
+				}
+			}
+			time.Sleep(50 * time.Millisecond)
+
+			return nil
+		})
+
+		if err := result.Err(); err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		if len(result.Succeeded()) != 3 {
+			t.Fatalf("got %+v, want all hosts to succeed", result.Succeeded())
+		}
+		if maxRunning.Load() != 2 {
+			t.Fatalf("got %d hosts called at once, want 2", maxRunning.Load())
+		}
+	})
+
+	t.Run("fail fast does not call the hosts waiting for their turn", func(t *testing.T) {
+		var calls atomic.Int32
+		result := hub.FanOut(context.Background(), conns, hub.FailFast.Limit(1), func(ctx context.Context, conn *hub.Connection) error {
+			calls.Add(1)
+			return errors.New("error")
+		})
+
+		if calls.Load() != 1 {
+			t.Fatalf("got %d hosts called, want 1", calls.Load())
+		}
+		if len(result.Failed()) != 3 {
+			t.Fatalf("got %+v, want all hosts to fail", result.Hosts)
+		}
+		if err := result.Err(); err == nil || strings.Count(err.Error(), "host:") != 1 {
+			t.Fatalf("got %v, want only the failure of the host called", err)
+		}
+	})
+}
@@ -6,8 +6,8 @@ import (
 	"sync"
 
 	"github.com/greenplum-db/gpdb/gp/utils"
+	"github.com/greenplum-db/gpdb/gp/utils/tracing"
 
-	"github.com/greenplum-db/gp-common-go-libs/gplog"
 	"github.com/greenplum-db/gpdb/gp/idl"
 	"google.golang.org/grpc"
 )
@@ -25,7 +25,7 @@ func (s *Server) ConnectHostList(hostList []string) (map[string]idl.AgentClient,
 	ctx, cancelFunc := context.WithTimeout(context.Background(), DialTimeout)
 	defer cancelFunc()
 
-	credentials, err := s.Credentials.LoadClientCredentials()
+	credentials, err := s.Credentials.LoadClientCredentials(utils.RoleHub)
 	if err != nil {
 		cancelFunc()
 		return nil, err
@@ -39,6 +39,7 @@ func (s *Server) ConnectHostList(hostList []string) (map[string]idl.AgentClient,
 				grpc.WithBlock(),
 				grpc.WithTransportCredentials(credentials),
 				grpc.WithReturnConnectionError(),
+				grpc.WithChainUnaryInterceptor(s.rpcPolicyInterceptor(address), tracing.UnaryClientInterceptor),
 			}
 			if s.grpcDialer != nil {
 				opts = append(opts, grpc.WithContextDialer(s.grpcDialer))
@@ -46,7 +47,7 @@ func (s *Server) ConnectHostList(hostList []string) (map[string]idl.AgentClient,
 			conn, err := grpc.DialContext(ctx, remoteAddress, opts...)
 			if err != nil {
 				cancelFunc()
-				return nil, fmt.Errorf("could not connect to agent on host %s: %w", address, err)
+				return nil, fmt.Errorf("could not connect to agent on host %s: %w", address, utils.FormatGrpcError(err))
 			}
 			addressConnectionMap[address] = idl.NewAgentClient(conn)
 		}
@@ -55,7 +56,7 @@ func (s *Server) ConnectHostList(hostList []string) (map[string]idl.AgentClient,
 	return addressConnectionMap, nil
 }
 func (s *Server) GetAllHostNames(ctx context.Context, request *idl.GetAllHostNamesRequest) (*idl.GetAllHostNamesReply, error) {
-	gplog.Debug("Starting with rpc GetAllHostNames")
+	tracing.Debug(ctx, "Starting with rpc GetAllHostNames")
 	addressConnectionMap, err := s.ConnectHostList(request.HostList)
 	if err != nil {
 		return nil, err
@@ -70,10 +71,10 @@ func (s *Server) GetAllHostNames(ctx context.Context, request *idl.GetAllHostNam
 		go func(addr string, connection idl.AgentClient) {
 			defer wg.Done()
 			request := idl.GetHostNameRequest{}
-			reply, err := connection.GetHostName(context.Background(), &request)
+			reply, err := connection.GetHostName(ctx, &request)
 			if err != nil {
 				errs <- fmt.Errorf("host: %s, %w", addr, err)
-				errs <- utils.LogAndReturnError(fmt.Errorf("getting hostname for %s failed with error:%v", addr, err))
+				errs <- tracing.LogAndReturnError(ctx, fmt.Errorf("getting hostname for %s failed with error:%v", addr, err))
 				return
 			}
 
@@ -0,0 +1,45 @@
+package hub
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/greenplum-db/gpdb/gp/idl"
+	"github.com/greenplum-db/gpdb/gp/utils"
+	"github.com/greenplum-db/gpdb/gp/utils/tracing"
+)
+
+// GetHostsInfo returns the facts of the given hosts, or of all the hosts of
+// the cluster. Hosts whose agent could not be reached are returned with their
+// error so that the facts of the others are still returned.
+func (s *Server) GetHostsInfo(ctx context.Context, req *idl.GetHostsInfoRequest) (*idl.GetHostsInfoReply, error) {
+	var mutex sync.Mutex
+	reply := &idl.GetHostsInfoReply{}
+	err := s.executeOnHosts(ctx, req.Hosts, func(conn *Connection) {
+		result := &idl.HostInfoResult{Host: conn.Hostname}
+		resp, err := conn.AgentClient.GetHostInfo(ctx, &idl.GetHostInfoRequest{})
+		if err != nil {
+			result.Error = utils.FormatGrpcError(err).Error()
+		} else {
+			result.Info = resp.Info
+		}
+
+		mutex.Lock()
+		defer mutex.Unlock()
+		reply.Hosts = append(reply.Hosts, result)
+	}, func(host string, err error) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		reply.Hosts = append(reply.Hosts, &idl.HostInfoResult{Host: host, Error: err.Error()})
+	})
+	if err != nil {
+		return nil, tracing.LogAndReturnError(ctx, err)
+	}
+
+	sort.Slice(reply.Hosts, func(i, j int) bool {
+		return reply.Hosts[i].Host < reply.Hosts[j].Host
+	})
+
+	return reply, nil
+}
@@ -0,0 +1,119 @@
+package hub_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/greenplum-db/gp-common-go-libs/testhelper"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/greenplum-db/gpdb/gp/hub"
+	"github.com/greenplum-db/gpdb/gp/idl"
+	"github.com/greenplum-db/gpdb/gp/idl/mock_idl"
+	"github.com/greenplum-db/gpdb/gp/testutils"
+)
+
+func TestGetHostsInfo(t *testing.T) {
+	testhelper.SetupTestLogger()
+	initialize(t)
+
+	hub.SetEnsureConnectionsAreReady(func(conns []*hub.Connection) error {
+		return nil
+	})
+	defer hub.ResetEnsureConnectionsAreReady()
+
+	t.Run("returns the facts of all hosts along with their errors", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		sdw1 := mock_idl.NewMockAgentClient(ctrl)
+		sdw1.EXPECT().GetHostInfo(gomock.Any(), &idl.GetHostInfoRequest{}).Return(&idl.GetHostInfoReply{Info: &idl.HostInfo{Hostname: "sdw1", CpuCount: 8}}, nil)
+
+		sdw2 := mock_idl.NewMockAgentClient(ctrl)
+		sdw2.EXPECT().GetHostInfo(gomock.Any(), &idl.GetHostInfoRequest{}).Return(nil, errors.New("error"))
+
+		hubServer.Conns = []*hub.Connection{
+			{AgentClient: sdw2, Hostname: "sdw2"},
+			{AgentClient: sdw1, Hostname: "sdw1"},
+		}
+
+		reply, err := hubServer.GetHostsInfo(context.Background(), &idl.GetHostsInfoRequest{})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		expected := []*idl.HostInfoResult{
+			{Host: "sdw1", Info: &idl.HostInfo{Hostname: "sdw1", CpuCount: 8}},
+			{Host: "sdw2", Error: "error"},
+		}
+		if !reflect.DeepEqual(reply.Hosts, expected) {
+			t.Fatalf("got %v, want %v", reply.Hosts, expected)
+		}
+	})
+
+	t.Run("only returns the facts of the given hosts", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		sdw2 := mock_idl.NewMockAgentClient(ctrl)
+		sdw2.EXPECT().GetHostInfo(gomock.Any(), &idl.GetHostInfoRequest{}).Return(&idl.GetHostInfoReply{Info: &idl.HostInfo{Hostname: "sdw2"}}, nil)
+
+		hubServer.Conns = []*hub.Connection{
+			{AgentClient: mock_idl.NewMockAgentClient(ctrl), Hostname: "sdw1"},
+			{AgentClient: sdw2, Hostname: "sdw2"},
+		}
+
+		reply, err := hubServer.GetHostsInfo(context.Background(), &idl.GetHostsInfoRequest{Hosts: []string{"sdw2"}})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		if len(reply.Hosts) != 1 || reply.Hosts[0].Host != "sdw2" {
+			t.Fatalf("got %v, want the facts of sdw2", reply.Hosts)
+		}
+	})
+
+	t.Run("returns the error of the hosts whose agent could not be connected to", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		server := hub.New(&hub.Config{
+			AgentPort:   5678,
+			Hostnames:   []string{"sdw1", "sdw2"},
+			Credentials: &testutils.MockCredentials{TlsConnection: insecure.NewCredentials()},
+		}, func(ctx context.Context, address string) (net.Conn, error) {
+			return nil, errors.New("connection refused")
+		})
+		sdw1 := mock_idl.NewMockAgentClient(ctrl)
+		sdw1.EXPECT().GetHostInfo(gomock.Any(), &idl.GetHostInfoRequest{}).Return(&idl.GetHostInfoReply{Info: &idl.HostInfo{Hostname: "sdw1"}}, nil)
+		server.Conns = []*hub.Connection{
+			{AgentClient: sdw1, Hostname: "sdw1"},
+		}
+
+		reply, err := server.GetHostsInfo(context.Background(), &idl.GetHostsInfoRequest{})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		if len(reply.Hosts) != 2 || reply.Hosts[0].Host != "sdw1" || reply.Hosts[0].Error != "" {
+			t.Fatalf("got %v, want the facts of sdw1", reply.Hosts)
+		}
+		expected := "could not connect to agent on host sdw2:"
+		if reply.Hosts[1].Host != "sdw2" || !strings.HasPrefix(reply.Hosts[1].Error, expected) {
+			t.Fatalf("got %v, want the error of sdw2 %s", reply.Hosts[1], expected)
+		}
+	})
+
+	t.Run("errors out for hosts not part of the cluster", func(t *testing.T) {
+		_, err := hubServer.GetHostsInfo(context.Background(), &idl.GetHostsInfoRequest{Hosts: []string{"sdw9"}})
+		expected := "hosts not part of the cluster: sdw9"
+		if err == nil || err.Error() != expected {
+			t.Fatalf("got %v, want %s", err, expected)
+		}
+	})
+}
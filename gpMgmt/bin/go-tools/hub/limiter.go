@@ -0,0 +1,132 @@
+package hub
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/greenplum-db/gpdb/gp/constants"
+	"github.com/greenplum-db/gpdb/gp/idl"
+)
+
+// ParallelismConfig limits the segment operations the hub runs at once, such as
+// initdb or pg_basebackup, so as not to overload the hosts or the network
+type ParallelismConfig struct {
+	BatchSize int `json:"batchSize,omitempty"` // on each host, defaults to constants.DefaultBatchSize
+	Parallel  int `json:"parallel,omitempty"`  // in the whole cluster, defaults to constants.DefaultParallel
+}
+
+func (c *ParallelismConfig) Validate() error {
+	if c == nil {
+		return nil
+	}
+	if c.BatchSize < 0 || c.Parallel < 0 {
+		return errors.New("the batch size and the parallelism must not be negative")
+	}
+
+	return nil
+}
+
+// limits returns the limits of gp.conf, overridden by the ones of the request
+// which are set
+func (s *Server) limits(parallelism *idl.Parallelism) (batchSize int, parallel int) {
+	batchSize, parallel = constants.DefaultBatchSize, constants.DefaultParallel
+	if s.Config != nil && s.Parallelism != nil {
+		if s.Parallelism.BatchSize > 0 {
+			batchSize = s.Parallelism.BatchSize
+		}
+		if s.Parallelism.Parallel > 0 {
+			parallel = s.Parallelism.Parallel
+		}
+	}
+
+	if parallelism.GetBatchSize() > 0 {
+		batchSize = int(parallelism.GetBatchSize())
+	}
+	if parallelism.GetParallel() > 0 {
+		parallel = int(parallelism.GetParallel())
+	}
+
+	return batchSize, parallel
+}
+
+// newLimiter returns the limiter of a segment operation, see limits
+func (s *Server) newLimiter(parallelism *idl.Parallelism) *Limiter {
+	return NewLimiter(s.limits(parallelism))
+}
+
+// executeRPC makes the request on the agents like ExecuteRPC, calling at most
+// as many hosts at once as the parallelism of gp.conf allows. The hosts still
+// waiting for their turn are not called once the context is done.
+func (s *Server) executeRPC(ctx context.Context, agentConns []*Connection, executeRequest func(conn *Connection) error) error {
+	_, parallel := s.limits(nil)
+
+	return FanOut(ctx, agentConns, BestEffort.Limit(parallel), func(ctx context.Context, conn *Connection) error {
+		return executeRequest(conn)
+	}).Err()
+}
+
+/*
+Limiter bounds the number of segment operations running at once on each host,
+and in the whole cluster. A limiter is made for each operation, so that the
+operations it makes in turn, e.g. to look up the addresses of a host, do not
+wait for the slots it holds.
+*/
+type Limiter struct {
+	batchSize int
+	cluster   chan struct{}
+
+	mutex sync.Mutex
+	hosts map[string]chan struct{}
+}
+
+// NewLimiter returns a limiter running at most batchSize operations at once on
+// each host, and parallel operations at once in the whole cluster
+func NewLimiter(batchSize int, parallel int) *Limiter {
+	return &Limiter{
+		batchSize: max(batchSize, 1),
+		cluster:   make(chan struct{}, max(parallel, 1)),
+		hosts:     make(map[string]chan struct{}),
+	}
+}
+
+// Acquire waits until an operation can run on the host, and returns the
+// function to call once it is done. It fails if the context is done first.
+func (l *Limiter) Acquire(ctx context.Context, host string) (func(), error) {
+	// a free slot would otherwise be taken at random with the context done
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	hostSlots := l.hostSlots(host)
+	select {
+	case hostSlots <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	select {
+	case l.cluster <- struct{}{}:
+	case <-ctx.Done():
+		<-hostSlots
+		return nil, ctx.Err()
+	}
+
+	return func() {
+		<-l.cluster
+		<-hostSlots
+	}, nil
+}
+
+func (l *Limiter) hostSlots(host string) chan struct{} {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	slots, ok := l.hosts[host]
+	if !ok {
+		slots = make(chan struct{}, l.batchSize)
+		l.hosts[host] = slots
+	}
+
+	return slots
+}
@@ -0,0 +1,98 @@
+package hub_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/greenplum-db/gpdb/gp/hub"
+)
+
+func TestLimiter(t *testing.T) {
+	t.Run("runs at most the batch size on each host and the parallelism in the cluster", func(t *testing.T) {
+		limiter := hub.NewLimiter(2, 3)
+
+		var mutex sync.Mutex
+		running := make(map[string]int)
+		maxRunning := make(map[string]int)
+		var total, maxTotal int
+
+		var wg sync.WaitGroup
+		for i := 0; i < 12; i++ {
+			host := []string{"sdw1", "sdw2", "sdw3"}[i%3]
+			wg.Add(1)
+			go func(host string) {
+				defer wg.Done()
+
+				release, err := limiter.Acquire(context.Background(), host)
+				if err != nil {
+					t.Errorf("unexpected error: %#v", err)
+					return
+				}
+				defer release()
+
+				mutex.Lock()
+				running[host]++
+				total++
+				maxRunning[host] = max(maxRunning[host], running[host])
+				maxTotal = max(maxTotal, total)
+				mutex.Unlock()
+
+				time.Sleep(20 * time.Millisecond)
+
+				mutex.Lock()
+				running[host]--
+				total--
+				mutex.Unlock()
+			}(host)
+		}
+		wg.Wait()
+
+		for host, count := range maxRunning {
+			if count > 2 {
+				t.Fatalf("got %d operations at once on %s, want at most 2", count, host)
+			}
+		}
+		if maxTotal != 3 {
+			t.Fatalf("got %d operations at once in the cluster, want 3", maxTotal)
+		}
+	})
+
+	t.Run("fails when the context is done while waiting", func(t *testing.T) {
+		limiter := hub.NewLimiter(1, 1)
+
+		release, err := limiter.Acquire(context.Background(), "sdw1")
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		_, err = limiter.Acquire(ctx, "sdw2")
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("got %v, want %v", err, context.DeadlineExceeded)
+		}
+
+		release()
+		release, err = limiter.Acquire(context.Background(), "sdw2")
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		release()
+	})
+}
+
+func TestParallelismConfig(t *testing.T) {
+	err := (&hub.ParallelismConfig{BatchSize: 4, Parallel: 64}).Validate()
+	if err != nil {
+		t.Fatalf("unexpected error: %#v", err)
+	}
+
+	err = (&hub.ParallelismConfig{BatchSize: -1}).Validate()
+	expected := "the batch size and the parallelism must not be negative"
+	if err == nil || err.Error() != expected {
+		t.Fatalf("got %v, want %s", err, expected)
+	}
+}
@@ -0,0 +1,192 @@
+package hub
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/greenplum-db/gpdb/gp/idl"
+	"github.com/greenplum-db/gpdb/gp/utils"
+	"github.com/greenplum-db/gpdb/gp/utils/greenplum"
+	"github.com/greenplum-db/gpdb/gp/utils/tracing"
+	"golang.org/x/exp/slices"
+)
+
+/*
+GetLogs streams the logs of the targeted segments, or of the agents and the
+utilities they ran, from all hosts. Failures are reported per segment or host
+so that the logs of the rest of the cluster are still streamed. When following,
+the logs are streamed until the call is cancelled.
+*/
+func (s *Server) GetLogs(req *idl.GetLogsRequest, stream idl.Hub_GetLogsServer) error {
+	var mutex sync.Mutex
+	send := func(reply *idl.GetLogsReply) error {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		return stream.Send(reply)
+	}
+
+	readLogs := func(conn *Connection, seg *idl.Segment) {
+		err := readAgentLogs(stream.Context(), conn, &idl.ReadLogsRequest{
+			Pgdata: seg.GetDataDirectory(),
+			List:   req.List,
+			Follow: req.Follow,
+			Filter: req.Filter,
+		}, func(reply *idl.ReadLogsReply) error {
+			return send(&idl.GetLogsReply{
+				Host:    conn.Hostname,
+				Segment: seg,
+				File:    reply.File,
+				Lines:   reply.Lines,
+				Files:   reply.Files,
+			})
+		})
+		if err != nil {
+			_ = send(&idl.GetLogsReply{
+				Host:    conn.Hostname,
+				Segment: seg,
+				Error:   err.Error(),
+			})
+		}
+	}
+
+	if req.Agent {
+		err := s.executeOnHosts(stream.Context(), req.Hosts, func(conn *Connection) {
+			readLogs(conn, nil)
+		}, func(host string, err error) {
+			_ = send(&idl.GetLogsReply{Host: host, Error: err.Error()})
+		})
+		if err != nil {
+			return tracing.LogAndReturnError(stream.Context(), err)
+		}
+
+		return nil
+	}
+
+	segs, err := s.getLogSegments(req.CoordinatorDataDir, req.Contents, req.Hosts)
+	if err != nil {
+		return tracing.LogAndReturnError(stream.Context(), err)
+	}
+
+	err = s.executeOnSegments(stream.Context(), segs, func(conn *Connection, idx int, seg greenplum.Segment) {
+		readLogs(conn, segmentToIdl(seg))
+	}, func(idx int, seg greenplum.Segment, err error) {
+		_ = send(&idl.GetLogsReply{Host: seg.Hostname, Segment: segmentToIdl(seg), Error: err.Error()})
+	})
+	if err != nil {
+		return tracing.LogAndReturnError(stream.Context(), err)
+	}
+
+	return nil
+}
+
+// readAgentLogs passes the replies of the ReadLogs stream of the agent to the
+// reply function until the stream ends or the call is cancelled
+func readAgentLogs(ctx context.Context, conn *Connection, req *idl.ReadLogsRequest, reply func(*idl.ReadLogsReply) error) error {
+	stream, err := conn.AgentClient.ReadLogs(ctx, req)
+	if err != nil {
+		return utils.FormatGrpcError(err)
+	}
+
+	for {
+		resp, err := stream.Recv()
+		if errors.Is(err, io.EOF) || ctx.Err() != nil {
+			return nil
+		}
+		if err != nil {
+			return utils.FormatGrpcError(err)
+		}
+
+		err = reply(resp)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// getLogSegments returns the segments with the given contents on the given
+// hosts, or all the segments, coordinator and standby included, if not set
+func (s *Server) getLogSegments(coordinatorDataDir string, contents []int32, hosts []string) ([]greenplum.Segment, error) {
+	gparray, err := getGpArray(coordinatorDataDir)
+	if err != nil {
+		return nil, err
+	}
+
+	var segs []greenplum.Segment
+	for _, seg := range allSegments(gparray) {
+		if len(contents) > 0 && !slices.Contains(contents, int32(seg.Content)) {
+			continue
+		}
+		if len(hosts) > 0 && !slices.Contains(hosts, seg.Hostname) {
+			continue
+		}
+
+		segs = append(segs, seg)
+	}
+
+	if len(segs) == 0 {
+		return nil, errors.New("no segments found with the given contents and hosts")
+	}
+
+	return segs, nil
+}
+
+func getGpArray(coordinatorDataDir string) (*greenplum.GpArray, error) {
+	conn, err := greenplum.GetCoordinatorConn(coordinatorDataDir, "", true)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	return greenplum.NewGpArrayFromCatalog(conn)
+}
+
+// allSegments returns the coordinator, the standby and the segments
+func allSegments(gparray *greenplum.GpArray) []greenplum.Segment {
+	all := gparray.GetAllSegments()
+	if gparray.Standby != nil {
+		all = append([]greenplum.Segment{*gparray.Standby}, all...)
+	}
+	if gparray.Coordinator != nil {
+		all = append([]greenplum.Segment{*gparray.Coordinator}, all...)
+	}
+
+	return all
+}
+
+/*
+executeOnHosts runs the request in parallel on the given hosts, or on all the
+hosts of the cluster if not set. The hosts whose agent could not be connected to
+are passed to unreachable with their error instead, so that the request still
+runs on the other hosts.
+*/
+func (s *Server) executeOnHosts(ctx context.Context, hosts []string, request func(conn *Connection), unreachable func(host string, err error)) error {
+	var unknown []string
+	for _, host := range hosts {
+		if !slices.Contains(s.Hostnames, host) {
+			unknown = append(unknown, host)
+		}
+	}
+	if len(unknown) > 0 {
+		return fmt.Errorf("hosts not part of the cluster: %s", strings.Join(unknown, ","))
+	}
+
+	if len(hosts) == 0 {
+		hosts = s.Hostnames
+	}
+	conns, dialErrs := s.dialAgentsByHost(hosts)
+	for _, host := range uniqueHosts(hosts) {
+		if err, ok := dialErrs[host]; ok {
+			unreachable(host, err)
+		}
+	}
+
+	return s.executeRPC(ctx, conns, func(conn *Connection) error {
+		request(conn)
+		return nil
+	})
+}
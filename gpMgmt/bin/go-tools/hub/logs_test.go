@@ -0,0 +1,145 @@
+package hub_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/greenplum-db/gp-common-go-libs/testhelper"
+	"google.golang.org/grpc"
+
+	"github.com/greenplum-db/gpdb/gp/hub"
+	"github.com/greenplum-db/gpdb/gp/idl"
+	"github.com/greenplum-db/gpdb/gp/idl/mock_idl"
+)
+
+type getLogsStream struct {
+	grpc.ServerStream
+	replies []*idl.GetLogsReply
+}
+
+func (s *getLogsStream) Send(reply *idl.GetLogsReply) error {
+	s.replies = append(s.replies, reply)
+	return nil
+}
+
+func (s *getLogsStream) Context() context.Context {
+	return context.Background()
+}
+
+// sortedReplies returns the replies as strings in a stable order, since the
+// hosts are read in parallel
+func (s *getLogsStream) sortedReplies() []string {
+	var result []string
+	for _, reply := range s.replies {
+		result = append(result, strings.Join([]string{reply.Host, reply.Segment.GetDataDirectory(), reply.File, strings.Join(reply.Lines, "|"), reply.Error}, " "))
+	}
+	sort.Strings(result)
+
+	return result
+}
+
+func mockReadLogsStream(ctrl *gomock.Controller, replies ...*idl.ReadLogsReply) *mock_idl.MockAgent_ReadLogsClient {
+	stream := mock_idl.NewMockAgent_ReadLogsClient(ctrl)
+	for _, reply := range replies {
+		stream.EXPECT().Recv().Return(reply, nil)
+	}
+	stream.EXPECT().Recv().Return(nil, io.EOF)
+
+	return stream
+}
+
+func TestGetLogs(t *testing.T) {
+	testhelper.SetupTestLogger()
+	initialize(t)
+
+	filter := &idl.LogFilter{MinSeverity: "error", Tail: 10}
+
+	t.Run("streams the logs of the segments with the given contents", func(t *testing.T) {
+		setupPgHbaTest(t)
+		defer teardownPgHbaTest()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		sdw1 := mock_idl.NewMockAgentClient(ctrl)
+		sdw1.EXPECT().ReadLogs(gomock.Any(), &idl.ReadLogsRequest{Pgdata: primary1.DataDir, Follow: true, Filter: filter}).
+			Return(mockReadLogsStream(ctrl, &idl.ReadLogsReply{File: "gpdb.csv", Lines: []string{"line 1", "line 2"}}), nil)
+
+		sdw2 := mock_idl.NewMockAgentClient(ctrl)
+		sdw2.EXPECT().ReadLogs(gomock.Any(), &idl.ReadLogsRequest{Pgdata: mirror1.DataDir, Follow: true, Filter: filter}).
+			Return(nil, errors.New("error"))
+
+		hubServer.Conns = []*hub.Connection{
+			{AgentClient: mock_idl.NewMockAgentClient(ctrl), Hostname: "cdw"},
+			{AgentClient: sdw1, Hostname: "sdw1"},
+			{AgentClient: sdw2, Hostname: "sdw2"},
+		}
+
+		stream := &getLogsStream{}
+		err := hubServer.GetLogs(&idl.GetLogsRequest{Contents: []int32{0}, Follow: true, Filter: filter}, stream)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		expected := []string{
+			"sdw1 /data/primary/gpseg0 gpdb.csv line 1|line 2 ",
+			"sdw2 /data/mirror/gpseg0   error",
+		}
+		if replies := stream.sortedReplies(); !reflect.DeepEqual(replies, expected) {
+			t.Fatalf("got %q, want %q", replies, expected)
+		}
+	})
+
+	t.Run("streams the logs of the agents on the given hosts", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		sdw2 := mock_idl.NewMockAgentClient(ctrl)
+		sdw2.EXPECT().ReadLogs(gomock.Any(), &idl.ReadLogsRequest{List: true, Filter: filter}).
+			Return(mockReadLogsStream(ctrl, &idl.ReadLogsReply{Files: []*idl.LogFile{{Name: "gp_agent.log"}}}), nil)
+
+		hub.SetEnsureConnectionsAreReady(func(conns []*hub.Connection) error {
+			return nil
+		})
+		defer hub.ResetEnsureConnectionsAreReady()
+		hubServer.Conns = []*hub.Connection{
+			{AgentClient: mock_idl.NewMockAgentClient(ctrl), Hostname: "sdw1"},
+			{AgentClient: sdw2, Hostname: "sdw2"},
+		}
+
+		stream := &getLogsStream{}
+		err := hubServer.GetLogs(&idl.GetLogsRequest{Agent: true, Hosts: []string{"sdw2"}, List: true, Filter: filter}, stream)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		if len(stream.replies) != 1 || stream.replies[0].Host != "sdw2" || stream.replies[0].Segment != nil || stream.replies[0].Files[0].Name != "gp_agent.log" {
+			t.Fatalf("got %v, want the files of the agent on sdw2", stream.replies)
+		}
+	})
+
+	t.Run("errors out for hosts not part of the cluster", func(t *testing.T) {
+		err := hubServer.GetLogs(&idl.GetLogsRequest{Agent: true, Hosts: []string{"sdw1", "sdw9"}}, &getLogsStream{})
+		expected := "hosts not part of the cluster: sdw9"
+		if err == nil || err.Error() != expected {
+			t.Fatalf("got %v, want %s", err, expected)
+		}
+	})
+
+	t.Run("errors out when no segment has the given contents", func(t *testing.T) {
+		setupPgHbaTest(t)
+		defer teardownPgHbaTest()
+
+		err := hubServer.GetLogs(&idl.GetLogsRequest{Contents: []int32{5}}, &getLogsStream{})
+		expected := "no segments found with the given contents and hosts"
+		if err == nil || err.Error() != expected {
+			t.Fatalf("got %v, want %s", err, expected)
+		}
+	})
+}
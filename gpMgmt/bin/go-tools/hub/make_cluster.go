@@ -4,20 +4,22 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
 	"sync"
 
 	"golang.org/x/exp/maps"
 
 	"github.com/greenplum-db/gp-common-go-libs/dbconn"
-	"github.com/greenplum-db/gp-common-go-libs/gplog"
 	"github.com/greenplum-db/gpdb/gp/constants"
 	"github.com/greenplum-db/gpdb/gp/idl"
 	"github.com/greenplum-db/gpdb/gp/utils"
 	"github.com/greenplum-db/gpdb/gp/utils/greenplum"
 	"github.com/greenplum-db/gpdb/gp/utils/postgres"
+	"github.com/greenplum-db/gpdb/gp/utils/tracing"
 )
 
 var execOnDatabaseFunc = ExecOnDatabase
+var execWithoutStatementLoggingFunc = ExecWithoutStatementLogging
 
 func (s *Server) MakeCluster(request *idl.MakeClusterRequest, stream idl.Hub_MakeClusterServer) error {
 	var err error
@@ -32,26 +34,26 @@ func (s *Server) MakeCluster(request *idl.MakeClusterRequest, stream idl.Hub_Mak
 			hubStream.StreamLogMsg("Not able to create the the cluster, proceeding to shutdown the coordinator segment")
 			err := s.StopCoordinator(&hubStream, request.GpArray.Coordinator.DataDirectory)
 			if err != nil {
-				gplog.Error(err.Error())
+				tracing.Error(stream.Context(), err.Error())
 			}
 		}
 	}()
 
 	err = s.DialAllAgents()
 	if err != nil {
-		return utils.LogAndReturnError(err)
+		return tracing.LogAndReturnError(stream.Context(), err)
 	}
 
 	hubStream.StreamLogMsg("Starting to create the cluster")
 	err = s.ValidateEnvironment(&hubStream, request)
 	if err != nil {
-		return utils.LogAndReturnError(fmt.Errorf("validating hosts: %w", err))
+		return tracing.LogAndReturnError(stream.Context(), fmt.Errorf("validating hosts: %w", err))
 	}
 
 	hubStream.StreamLogMsg("Creating coordinator segment")
-	err = s.CreateAndStartCoordinator(request.GpArray.Coordinator, request.ClusterParams)
+	err = s.CreateAndStartCoordinator(hubStream.Context(), request.GpArray.Coordinator, request.ClusterParams)
 	if err != nil {
-		return utils.LogAndReturnError(err)
+		return tracing.LogAndReturnError(stream.Context(), err)
 	}
 	hubStream.StreamLogMsg("Successfully created coordinator segment")
 
@@ -61,23 +63,23 @@ func (s *Server) MakeCluster(request *idl.MakeClusterRequest, stream idl.Hub_Mak
 
 	conn, err := greenplum.GetCoordinatorConn(request.GpArray.Coordinator.DataDirectory, "template1", true)
 	if err != nil {
-		return utils.LogAndReturnError(err)
+		return tracing.LogAndReturnError(stream.Context(), err)
 	}
 
 	err = greenplum.RegisterCoordinator(request.GpArray.Coordinator, conn)
 	if err != nil {
-		return utils.LogAndReturnError(err)
+		return tracing.LogAndReturnError(stream.Context(), err)
 	}
 
 	err = greenplum.RegisterPrimarySegments(request.GetPrimarySegments(), conn)
 	if err != nil {
-		return utils.LogAndReturnError(err)
+		return tracing.LogAndReturnError(stream.Context(), err)
 	}
 	hubStream.StreamLogMsg("Successfully registered primary segments with the coordinator")
 
 	gparray, err := greenplum.NewGpArrayFromCatalog(conn)
 	if err != nil {
-		return utils.LogAndReturnError(err)
+		return tracing.LogAndReturnError(stream.Context(), err)
 	}
 	conn.Close()
 
@@ -89,16 +91,16 @@ func (s *Server) MakeCluster(request *idl.MakeClusterRequest, stream idl.Hub_Mak
 	} else {
 		addrs, err := utils.GetHostAddrsNoLoopback()
 		if err != nil {
-			return utils.LogAndReturnError(err)
+			return tracing.LogAndReturnError(stream.Context(), err)
 		}
 
 		coordinatorAddrs = append(coordinatorAddrs, addrs...)
 	}
 
 	hubStream.StreamLogMsg("Creating primary segments")
-	err = s.CreateSegments(&hubStream, primarySegs, request.ClusterParams, coordinatorAddrs)
+	err = s.CreateSegments(&hubStream, primarySegs, request.ClusterParams, coordinatorAddrs, request.Parallelism)
 	if err != nil {
-		return utils.LogAndReturnError(err)
+		return tracing.LogAndReturnError(stream.Context(), err)
 	}
 	hubStream.StreamLogMsg("Successfully created primary segments")
 
@@ -107,7 +109,7 @@ func (s *Server) MakeCluster(request *idl.MakeClusterRequest, stream idl.Hub_Mak
 	hubStream.StreamLogMsg("Restarting the Greenplum cluster in production mode")
 	err = s.StopCoordinator(&hubStream, request.GpArray.Coordinator.DataDirectory)
 	if err != nil {
-		return utils.LogAndReturnError(err)
+		return tracing.LogAndReturnError(stream.Context(), err)
 	}
 
 	// TODO: Replace this with the new gp start once it is complete
@@ -118,35 +120,39 @@ func (s *Server) MakeCluster(request *idl.MakeClusterRequest, stream idl.Hub_Mak
 	cmd := utils.NewGpSourcedCommand(gpstartOptions, s.GpHome)
 	err = hubStream.StreamExecCommand(cmd, s.GpHome)
 	if err != nil {
-		return utils.LogAndReturnError(fmt.Errorf("executing gpstart: %w", err))
+		return tracing.LogAndReturnError(stream.Context(), fmt.Errorf("executing gpstart: %w", err))
 	}
 	hubStream.StreamLogMsg("Completed restart of Greenplum cluster in production mode")
 
 	hubStream.StreamLogMsg("Creating core GPDB extensions")
 	err = CreateGpToolkitExt(conn)
 	if err != nil {
-		return utils.LogAndReturnError(err)
+		return tracing.LogAndReturnError(stream.Context(), err)
 	}
 	hubStream.StreamLogMsg("Successfully created core GPDB extensions")
 
 	hubStream.StreamLogMsg("Importing system collations")
 	err = ImportCollation(conn)
 	if err != nil {
-		return utils.LogAndReturnError(err)
+		return tracing.LogAndReturnError(stream.Context(), err)
 	}
 
 	if request.ClusterParams.DbName != "" {
 		hubStream.StreamLogMsg(fmt.Sprintf("Creating database %q", request.ClusterParams.DbName))
 		err = CreateDatabase(conn, request.ClusterParams.DbName)
 		if err != nil {
-			return utils.LogAndReturnError(err)
+			return tracing.LogAndReturnError(stream.Context(), err)
 		}
 	}
 
-	hubStream.StreamLogMsg("Setting Greenplum superuser password")
-	err = SetGpUserPasswd(conn, request.ClusterParams.SuPassword)
-	if err != nil {
-		return utils.LogAndReturnError(err)
+	if request.ClusterParams.SuPasswordVerifier != "" {
+		hubStream.StreamLogMsg("Setting Greenplum superuser password")
+		err = SetGpUserPasswd(conn, request.ClusterParams.SuPasswordVerifier)
+		if err != nil {
+			return tracing.LogAndReturnError(stream.Context(), err)
+		}
+	} else {
+		hubStream.StreamLogMsg("No Greenplum superuser password provided, skipping setting it")
 	}
 
 	if !mirrorless {
@@ -156,8 +162,12 @@ func (s *Server) MakeCluster(request *idl.MakeClusterRequest, stream idl.Hub_Mak
 		}
 
 		addMirrosReq := &idl.AddMirrorsRequest{
-			CoordinatorDataDir: request.GpArray.Coordinator.DataDirectory,
-			Mirrors:            mirrorSegs,
+			CoordinatorDataDir:       request.GpArray.Coordinator.DataDirectory,
+			Mirrors:                  mirrorSegs,
+			HbaAuthMethod:            request.ClusterParams.HbaAuthMethod,
+			HbaReplicationAuthMethod: request.ClusterParams.HbaReplicationAuthMethod,
+			Ssl:                      request.ClusterParams.Ssl,
+			Parallelism:              request.Parallelism,
 		}
 		err = s.AddMirrors(addMirrosReq, stream)
 		if err != nil {
@@ -170,6 +180,7 @@ func (s *Server) MakeCluster(request *idl.MakeClusterRequest, stream idl.Hub_Mak
 
 func (s *Server) ValidateEnvironment(stream hubStreamer, request *idl.MakeClusterRequest) error {
 	var replies []*idl.LogMessage
+	var repliesMutex sync.Mutex
 
 	gparray := request.GpArray
 	hostDirMap := make(map[string][]string)
@@ -192,21 +203,22 @@ func (s *Server) ValidateEnvironment(stream hubStreamer, request *idl.MakeCluste
 		}
 		hostAddressMap[seg.HostName][seg.HostAddress] = true
 	}
-	gplog.Debug("Host-Address-Map:[%v]", hostAddressMap)
+	tracing.Debug(stream.Context(), "Host-Address-Map:[%v]", hostAddressMap)
 
 	// Get local gpVersion
 
 	localPgVersion, err := greenplum.GetPostgresGpVersion(s.GpHome)
 	if err != nil {
-		gplog.Error("fetching postgres gp-version:%v", err)
+		tracing.Error(stream.Context(), "fetching postgres gp-version:%v", err)
 		return err
 	}
 
+	ctx := stream.Context()
 	progressLabel := "Validating Hosts:"
 	progressTotal := len(hostDirMap)
 	stream.StreamProgressMsg(progressLabel, progressTotal)
 	validateFn := func(conn *Connection) error {
-		gplog.Debug(fmt.Sprintf("Starting to validate host: %s", conn.Hostname))
+		tracing.Debug(stream.Context(), fmt.Sprintf("Starting to validate host: %s", conn.Hostname))
 
 		dirList := hostDirMap[conn.Hostname]
 		portList := hostPortMap[conn.Hostname]
@@ -214,7 +226,8 @@ func (s *Server) ValidateEnvironment(stream hubStreamer, request *idl.MakeCluste
 		for address := range hostAddressMap[conn.Hostname] {
 			addressList = append(addressList, address)
 		}
-		gplog.Debug("AddressList:[%v]", addressList)
+		sort.Strings(addressList)
+		tracing.Debug(stream.Context(), "AddressList:[%v]", addressList)
 
 		validateReq := idl.ValidateHostEnvRequest{
 			DirectoryList:   dirList,
@@ -224,15 +237,17 @@ func (s *Server) ValidateEnvironment(stream hubStreamer, request *idl.MakeCluste
 			HostAddressList: addressList,
 			GpVersion:       localPgVersion,
 		}
-		reply, err := conn.AgentClient.ValidateHostEnv(context.Background(), &validateReq)
+		reply, err := conn.AgentClient.ValidateHostEnv(ctx, &validateReq)
 		if err != nil {
 			return utils.FormatGrpcError(err)
 		}
 
 		stream.StreamProgressMsg(progressLabel, progressTotal)
-		gplog.Debug(fmt.Sprintf("Successfully completed validation for host: %s", conn.Hostname))
+		tracing.Debug(stream.Context(), fmt.Sprintf("Successfully completed validation for host: %s", conn.Hostname))
 
 		// Add host-name to each reply message
+		repliesMutex.Lock()
+		defer repliesMutex.Unlock()
 		for _, msg := range reply.Messages {
 			msg.Message = fmt.Sprintf("Host: %s %s", conn.Hostname, msg.Message)
 			replies = append(replies, msg)
@@ -241,7 +256,7 @@ func (s *Server) ValidateEnvironment(stream hubStreamer, request *idl.MakeCluste
 		return nil
 	}
 
-	err = ExecuteRPC(s.Conns, validateFn)
+	err = s.executeRPC(stream.Context(), s.agentConns(nil), validateFn)
 	if err != nil {
 		return err
 	}
@@ -253,7 +268,7 @@ func (s *Server) ValidateEnvironment(stream hubStreamer, request *idl.MakeCluste
 	return nil
 }
 
-func CreateSingleSegment(conn *Connection, seg *idl.Segment, clusterParams *idl.ClusterParams, coordinatorAddrs []string) error {
+func CreateSingleSegment(ctx context.Context, conn *Connection, seg *idl.Segment, clusterParams *idl.ClusterParams, coordinatorAddrs []string) error {
 	pgConfig := make(map[string]string)
 	maps.Copy(pgConfig, clusterParams.CommonConfig)
 	if seg.Contentid == -1 {
@@ -271,8 +286,19 @@ func CreateSingleSegment(conn *Connection, seg *idl.Segment, clusterParams *idl.
 		HbaHostNames:     clusterParams.HbaHostnames,
 		DataChecksums:    clusterParams.DataChecksums,
 	}
+	if seg.Contentid == -1 {
+		makeSegmentReq.HbaUserAuthMethod = clusterParams.HbaUserAuthMethod
+		makeSegmentReq.HbaUserAddrs = clusterParams.HbaUserAddrs
+	} else {
+		makeSegmentReq.HbaAuthMethod = clusterParams.HbaAuthMethod
+	}
+
+	err := setSslConfig(makeSegmentReq, clusterParams.Ssl)
+	if err != nil {
+		return err
+	}
 
-	_, err := conn.AgentClient.MakeSegment(context.Background(), makeSegmentReq)
+	_, err = conn.AgentClient.MakeSegment(ctx, makeSegmentReq)
 	if err != nil {
 		return utils.FormatGrpcError(err)
 	}
@@ -280,13 +306,13 @@ func CreateSingleSegment(conn *Connection, seg *idl.Segment, clusterParams *idl.
 	return nil
 }
 
-func (s *Server) CreateAndStartCoordinator(seg *idl.Segment, clusterParams *idl.ClusterParams) error {
-	coordinatorConn := getConnForHosts(s.Conns, []string{seg.HostName})
+func (s *Server) CreateAndStartCoordinator(ctx context.Context, seg *idl.Segment, clusterParams *idl.ClusterParams) error {
+	coordinatorConn := s.agentConns([]string{seg.HostName})
 
 	seg.Contentid = -1
 	seg.Dbid = 1
 	request := func(conn *Connection) error {
-		err := CreateSingleSegment(conn, seg, clusterParams, []string{})
+		err := CreateSingleSegment(ctx, conn, seg, clusterParams, []string{})
 		if err != nil {
 			return err
 		}
@@ -296,12 +322,12 @@ func (s *Server) CreateAndStartCoordinator(seg *idl.Segment, clusterParams *idl.
 			Wait:    true,
 			Options: "-c gp_role=utility",
 		}
-		_, err = conn.AgentClient.StartSegment(context.Background(), startSegReq)
+		_, err = conn.AgentClient.StartSegment(ctx, startSegReq)
 
 		return utils.FormatGrpcError(err)
 	}
 
-	return ExecuteRPC(coordinatorConn, request)
+	return s.executeRPC(ctx, coordinatorConn, request)
 }
 
 func (s *Server) StopCoordinator(stream hubStreamer, pgdata string) error {
@@ -310,7 +336,7 @@ func (s *Server) StopCoordinator(stream hubStreamer, pgdata string) error {
 		PgData: pgdata,
 	}
 
-	out, err := utils.RunGpCommand(pgCtlStopCmd, s.GpHome)
+	out, err := utils.RunGpCommandContext(stream.Context(), pgCtlStopCmd, s.GpHome)
 	if err != nil {
 		return fmt.Errorf("executing pg_ctl stop: %s, %w", out, err)
 	}
@@ -319,7 +345,9 @@ func (s *Server) StopCoordinator(stream hubStreamer, pgdata string) error {
 	return nil
 }
 
-func (s *Server) CreateSegments(stream hubStreamer, segs []greenplum.Segment, clusterParams *idl.ClusterParams, coordinatorAddrs []string) error {
+// CreateSegments creates the primary segments, running at most as many initdb
+// at once as the parallelism allows
+func (s *Server) CreateSegments(stream hubStreamer, segs []greenplum.Segment, clusterParams *idl.ClusterParams, coordinatorAddrs []string, parallelism *idl.Parallelism) error {
 	hostSegmentMap := map[string][]*idl.Segment{}
 	for _, seg := range segs {
 		segReq := &idl.Segment{
@@ -342,7 +370,8 @@ func (s *Server) CreateSegments(stream hubStreamer, segs []greenplum.Segment, cl
 	progressTotal := len(segs)
 	stream.StreamProgressMsg(progressLabel, progressTotal)
 
-	request := func(conn *Connection) error {
+	limiter := s.newLimiter(parallelism)
+	request := func(ctx context.Context, conn *Connection) error {
 		var wg sync.WaitGroup
 
 		segs := hostSegmentMap[conn.Hostname]
@@ -353,13 +382,20 @@ func (s *Server) CreateSegments(stream hubStreamer, segs []greenplum.Segment, cl
 			go func(seg *idl.Segment) {
 				defer wg.Done()
 
-				gplog.Debug(fmt.Sprintf("Starting to create primary segment: %s", seg))
-				err := CreateSingleSegment(conn, seg, clusterParams, coordinatorAddrs)
+				release, err := limiter.Acquire(ctx, conn.Hostname)
+				if err != nil {
+					errs <- err
+					return
+				}
+				defer release()
+
+				tracing.Debug(stream.Context(), fmt.Sprintf("Starting to create primary segment: %s", seg))
+				err = CreateSingleSegment(ctx, conn, seg, clusterParams, coordinatorAddrs)
 				if err != nil {
 					errs <- err
 				} else {
 					stream.StreamProgressMsg(progressLabel, progressTotal)
-					gplog.Debug(fmt.Sprintf("Successfully created primary segment: %s", seg))
+					tracing.Debug(stream.Context(), fmt.Sprintf("Successfully created primary segment: %s", seg))
 				}
 			}(seg)
 		}
@@ -374,7 +410,9 @@ func (s *Server) CreateSegments(stream hubStreamer, segs []greenplum.Segment, cl
 		return err
 	}
 
-	return ExecuteRPC(s.Conns, request)
+	// there is no use creating the other segments once one of them failed,
+	// as the cluster is not created then
+	return FanOut(stream.Context(), s.agentConns(nil), FailFast, request).Err()
 }
 
 func ExecOnDatabase(conn *dbconn.DBConn, dbname string, query string) error {
@@ -391,6 +429,39 @@ func ExecOnDatabase(conn *dbconn.DBConn, dbname string, query string) error {
 	return nil
 }
 
+/*
+ExecWithoutStatementLogging runs the query in a transaction which does not log
+its statements, so that queries containing secrets do not end up in the server
+log even when log_statement is set.
+*/
+func ExecWithoutStatementLogging(conn *dbconn.DBConn, dbname string, query string) error {
+	conn.DBName = dbname
+	if err := conn.Connect(1); err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if err := conn.Begin(); err != nil {
+		return err
+	}
+
+	// The settings are sent as separate statements, as the server logs the
+	// whole query string before running any of it
+	for _, setting := range []string{
+		"SET LOCAL log_statement = 'none'",
+		"SET LOCAL log_min_duration_statement = -1",
+		"SET LOCAL log_min_error_statement = 'panic'",
+		query,
+	} {
+		if _, err := conn.Exec(setting); err != nil {
+			_ = conn.Rollback()
+			return err
+		}
+	}
+
+	return conn.Commit()
+}
+
 func CreateGpToolkitExt(conn *dbconn.DBConn) error {
 	createExtensionQuery := "CREATE EXTENSION gp_toolkit"
 
@@ -443,14 +514,26 @@ func CreateDatabase(conn *dbconn.DBConn, dbname string) error {
 	return nil
 }
 
-func SetGpUserPasswd(conn *dbconn.DBConn, passwd string) error {
+/*
+SetGpUserPasswd sets the password of the current user from its SCRAM-SHA-256
+verifier. Clients which still send the password itself have it hashed here, so
+that the password is never known to the server.
+*/
+func SetGpUserPasswd(conn *dbconn.DBConn, verifier string) error {
 	user, err := utils.System.CurrentUser()
 	if err != nil {
 		return err
 	}
 
-	alterPasswdQuery := fmt.Sprintf("ALTER USER %q WITH PASSWORD '%s'", user.Username, passwd)
-	if err := execOnDatabaseFunc(conn, constants.DefaultDatabase, alterPasswdQuery); err != nil {
+	if !postgres.IsScramSha256Verifier(verifier) {
+		verifier, err = postgres.ScramSha256Verifier(verifier)
+		if err != nil {
+			return err
+		}
+	}
+
+	alterPasswdQuery := fmt.Sprintf("ALTER USER %q WITH PASSWORD '%s'", user.Username, verifier)
+	if err := execWithoutStatementLoggingFunc(conn, constants.DefaultDatabase, alterPasswdQuery); err != nil {
 		return err
 	}
 
@@ -465,6 +548,14 @@ func ResetExecOnDatabase() {
 	execOnDatabaseFunc = ExecOnDatabase
 }
 
+func SetExecWithoutStatementLogging(customFunc func(*dbconn.DBConn, string, string) error) {
+	execWithoutStatementLoggingFunc = customFunc
+}
+
+func ResetExecWithoutStatementLogging() {
+	execWithoutStatementLoggingFunc = ExecWithoutStatementLogging
+}
+
 // Content ID is generated only when the primaries are
 // registered in gp_segment_configuration. Use the info
 // from the table to populate the mirror content IDs correctly
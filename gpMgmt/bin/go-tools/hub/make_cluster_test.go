@@ -1,6 +1,7 @@
 package hub_test
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"maps"
@@ -9,10 +10,13 @@ import (
 	"reflect"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/jmoiron/sqlx"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 
 	"github.com/greenplum-db/gp-common-go-libs/dbconn"
@@ -35,13 +39,13 @@ func TestCreateSegments(t *testing.T) {
 
 	credentials := &testutils.MockCredentials{TlsConnection: insecure.NewCredentials()}
 	hubConfig := &hub.Config{
-		1234,
-		5678,
-		[]string{"sdw1", "sdw2"},
-		"/tmp/logDir",
-		"gp",
-		"gpHome",
-		credentials,
+		Port:        1234,
+		AgentPort:   5678,
+		Hostnames:   []string{"sdw1", "sdw2"},
+		LogDir:      "/tmp/logDir",
+		ServiceName: "gp",
+		GpHome:      "gpHome",
+		Credentials: credentials,
 	}
 	hubServer := hub.New(hubConfig, nil)
 
@@ -91,6 +95,7 @@ func TestCreateSegments(t *testing.T) {
 				Segment:          segmentToProto(segs[0]),
 				SegConfig:        expectedSegConfig,
 				CoordinatorAddrs: make([]string, 0),
+				HbaAuthMethod:    "md5",
 			},
 		).Return(&idl.MakeSegmentReply{}, nil)
 
@@ -101,6 +106,7 @@ func TestCreateSegments(t *testing.T) {
 				Segment:          segmentToProto(segs[1]),
 				SegConfig:        expectedSegConfig,
 				CoordinatorAddrs: make([]string, 0),
+				HbaAuthMethod:    "md5",
 			},
 		).Return(&idl.MakeSegmentReply{}, nil)
 
@@ -110,6 +116,7 @@ func TestCreateSegments(t *testing.T) {
 				Segment:          segmentToProto(segs[2]),
 				SegConfig:        expectedSegConfig,
 				CoordinatorAddrs: make([]string, 0),
+				HbaAuthMethod:    "md5",
 			},
 		).Return(&idl.MakeSegmentReply{}, nil)
 
@@ -123,10 +130,13 @@ func TestCreateSegments(t *testing.T) {
 			CommonConfig:      commonConfig,
 			CoordinatorConfig: coordinatorConfig,
 			SegmentConfig:     segConfig,
+			HbaAuthMethod:     "md5",
+			HbaUserAuthMethod: "scram-sha-256",
+			HbaUserAddrs:      []string{"10.0.0.0/8"},
 		}
 
 		mock, stream := testutils.NewMockStream()
-		err := hubServer.CreateSegments(mock, segs, clusterParams, []string{})
+		err := hubServer.CreateSegments(mock, segs, clusterParams, []string{}, nil)
 		if err != nil {
 			t.Fatalf("unexpected error: %#v", err)
 		}
@@ -184,7 +194,7 @@ func TestCreateSegments(t *testing.T) {
 		}
 
 		mock, stream := testutils.NewMockStream()
-		err := hubServer.CreateSegments(mock, segs, clusterParams, []string{})
+		err := hubServer.CreateSegments(mock, segs, clusterParams, []string{}, nil)
 		if !errors.Is(err, expectedErr) {
 			t.Fatalf("got %#v, want %#V", err, expectedErr)
 		}
@@ -206,6 +216,81 @@ func TestCreateSegments(t *testing.T) {
 		}
 	})
 
+	t.Run("stops creating the segments on the other hosts once one of them failed", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		expectedErr := errors.New("error")
+
+		sdw1 := mock_idl.NewMockAgentClient(ctrl)
+		sdw1.EXPECT().MakeSegment(gomock.Any(), gomock.Any()).Return(nil, expectedErr)
+
+		sdw2 := mock_idl.NewMockAgentClient(ctrl)
+		sdw2.EXPECT().MakeSegment(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, req *idl.MakeSegmentRequest, opts ...grpc.CallOption) (*idl.MakeSegmentReply, error) {
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(time.Minute):
+				return &idl.MakeSegmentReply{}, nil
+			}
+		}).AnyTimes()
+
+		hubServer.Conns = []*hub.Connection{
+			{AgentClient: sdw1, Hostname: "sdw1"},
+			{AgentClient: sdw2, Hostname: "sdw2"},
+		}
+
+		mock, _ := testutils.NewMockStream()
+		err := hubServer.CreateSegments(mock, segs, &idl.ClusterParams{}, []string{}, nil)
+		if !errors.Is(err, expectedErr) {
+			t.Fatalf("got %#v, want %#v", err, expectedErr)
+		}
+		if strings.Contains(err.Error(), "sdw2") {
+			t.Fatalf("got %v, want only the failure of sdw1", err)
+		}
+	})
+
+	t.Run("creates at most the batch size of segments at once on each host", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		var running, maxRunning atomic.Int32
+		makeSegment := func(ctx context.Context, req *idl.MakeSegmentRequest, opts ...grpc.CallOption) (*idl.MakeSegmentReply, error) {
+			current := running.Add(1)
+			defer running.Add(-1)
+			for {
+				prev := maxRunning.Load()
+				if current <= prev || maxRunning.CompareAndSwap(prev, current) {
+					break
+				}
+			}
+			time.Sleep(50 * time.Millisecond)
+
+			return &idl.MakeSegmentReply{}, nil
+		}
+
+		sdw1 := mock_idl.NewMockAgentClient(ctrl)
+		sdw1.EXPECT().MakeSegment(gomock.Any(), gomock.Any()).Return(&idl.MakeSegmentReply{}, nil)
+
+		sdw2 := mock_idl.NewMockAgentClient(ctrl)
+		sdw2.EXPECT().MakeSegment(gomock.Any(), gomock.Any()).DoAndReturn(makeSegment).Times(2)
+
+		hubServer.Conns = []*hub.Connection{
+			{AgentClient: sdw1, Hostname: "sdw1"},
+			{AgentClient: sdw2, Hostname: "sdw2"},
+		}
+
+		mock, _ := testutils.NewMockStream()
+		err := hubServer.CreateSegments(mock, segs, &idl.ClusterParams{}, []string{}, &idl.Parallelism{BatchSize: 1})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		if maxRunning.Load() != 1 {
+			t.Fatalf("got %d segments created at once on sdw2, want 1", maxRunning.Load())
+		}
+	})
+
 	t.Run("successfully creates and starts the coordinator segment", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
@@ -227,9 +312,11 @@ func TestCreateSegments(t *testing.T) {
 		cdw.EXPECT().MakeSegment(
 			gomock.Any(),
 			&idl.MakeSegmentRequest{
-				Segment:          seg,
-				SegConfig:        expectedSegConfig,
-				CoordinatorAddrs: make([]string, 0),
+				Segment:           seg,
+				SegConfig:         expectedSegConfig,
+				CoordinatorAddrs:  make([]string, 0),
+				HbaUserAuthMethod: "scram-sha-256",
+				HbaUserAddrs:      []string{"10.0.0.0/8"},
 			},
 		).Return(&idl.MakeSegmentReply{}, nil)
 
@@ -251,9 +338,12 @@ func TestCreateSegments(t *testing.T) {
 			CommonConfig:      commonConfig,
 			CoordinatorConfig: coordinatorConfig,
 			SegmentConfig:     segConfig,
+			HbaAuthMethod:     "md5",
+			HbaUserAuthMethod: "scram-sha-256",
+			HbaUserAddrs:      []string{"10.0.0.0/8"},
 		}
 
-		err := hubServer.CreateAndStartCoordinator(seg, clusterParams)
+		err := hubServer.CreateAndStartCoordinator(context.Background(), seg, clusterParams)
 		if err != nil {
 			t.Fatalf("unexpected error: %#v", err)
 		}
@@ -298,7 +388,7 @@ func TestCreateSegments(t *testing.T) {
 			SegmentConfig:     segConfig,
 		}
 
-		err := hubServer.CreateAndStartCoordinator(seg, clusterParams)
+		err := hubServer.CreateAndStartCoordinator(context.Background(), seg, clusterParams)
 		if !errors.Is(err, expectedErr) {
 			t.Fatalf("got %#v, want %#v", err, expectedErr)
 		}
@@ -310,13 +400,13 @@ func TestStopCoordinator(t *testing.T) {
 
 	credentials := &testutils.MockCredentials{TlsConnection: insecure.NewCredentials()}
 	hubConfig := &hub.Config{
-		1234,
-		5678,
-		[]string{"sdw1", "sdw2"},
-		"/tmp/logDir",
-		"gp",
-		"gpHome",
-		credentials,
+		Port:        1234,
+		AgentPort:   5678,
+		Hostnames:   []string{"sdw1", "sdw2"},
+		LogDir:      "/tmp/logDir",
+		ServiceName: "gp",
+		GpHome:      "gpHome",
+		Credentials: credentials,
 	}
 	hubServer := hub.New(hubConfig, nil)
 
@@ -411,13 +501,13 @@ func TestValidateEnvironment(t *testing.T) {
 
 	credentials := &testutils.MockCredentials{TlsConnection: insecure.NewCredentials()}
 	hubConfig := &hub.Config{
-		1234,
-		5678,
-		[]string{"sdw1", "sdw2"},
-		"/tmp/logDir",
-		"gp",
-		"gpHome",
-		credentials,
+		Port:        1234,
+		AgentPort:   5678,
+		Hostnames:   []string{"sdw1", "sdw2"},
+		LogDir:      "/tmp/logDir",
+		ServiceName: "gp",
+		GpHome:      "gpHome",
+		Credentials: credentials,
 	}
 	hubServer := hub.New(hubConfig, nil)
 
@@ -840,21 +930,51 @@ func TestCreateDatabase(t *testing.T) {
 func TestSetGpUserPasswd(t *testing.T) {
 	testhelper.SetupTestLogger()
 
-	t.Run("succesfully sets the database user password", func(t *testing.T) {
+	verifier := "SCRAM-SHA-256$4096:MDEyMzQ1Njc4OWFiY2RlZg==$bpSY5Ze9NUH+I35LC3gVq+DpBfK46iXBxvhAKqVu9pE=:VpYlBuxyzeCI1KnctrefdljpB1mk3Gp7sBI/t11+NkQ="
+
+	t.Run("succesfully sets the database user password from its verifier", func(t *testing.T) {
 		utils.System.CurrentUser = func() (*user.User, error) {
 			return &user.User{Username: "gpadmin"}, nil
 		}
 		defer utils.ResetSystemFunctions()
 
 		hub.SetExecOnDatabase(func(conn *dbconn.DBConn, dbname, query string) error {
-			expectedQuery := `ALTER USER "gpadmin" WITH PASSWORD 'abc'`
+			t.Fatalf("unexpected query with statement logging: %s", query)
+			return nil
+		})
+		defer hub.ResetExecOnDatabase()
+
+		hub.SetExecWithoutStatementLogging(func(conn *dbconn.DBConn, dbname, query string) error {
+			expectedQuery := fmt.Sprintf(`ALTER USER "gpadmin" WITH PASSWORD '%s'`, verifier)
 			if query != expectedQuery {
 				t.Fatalf("got %v, want %v", query, expectedQuery)
 			}
 
 			return nil
 		})
-		defer hub.ResetExecOnDatabase()
+		defer hub.ResetExecWithoutStatementLogging()
+
+		conn := &dbconn.DBConn{}
+		err := hub.SetGpUserPasswd(conn, verifier)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+	})
+
+	t.Run("hashes the password sent by older clients", func(t *testing.T) {
+		utils.System.CurrentUser = func() (*user.User, error) {
+			return &user.User{Username: "gpadmin"}, nil
+		}
+		defer utils.ResetSystemFunctions()
+
+		hub.SetExecWithoutStatementLogging(func(conn *dbconn.DBConn, dbname, query string) error {
+			if strings.Contains(query, "abc") || !strings.HasPrefix(query, `ALTER USER "gpadmin" WITH PASSWORD 'SCRAM-SHA-256$4096:`) {
+				t.Fatalf("unexpected query %v", query)
+			}
+
+			return nil
+		})
+		defer hub.ResetExecWithoutStatementLogging()
 
 		conn := &dbconn.DBConn{}
 		err := hub.SetGpUserPasswd(conn, "abc")
@@ -871,7 +991,7 @@ func TestSetGpUserPasswd(t *testing.T) {
 		defer utils.ResetSystemFunctions()
 
 		conn := &dbconn.DBConn{}
-		err := hub.SetGpUserPasswd(conn, "abc")
+		err := hub.SetGpUserPasswd(conn, verifier)
 		if !errors.Is(err, expectedErr) {
 			t.Fatalf("got %#v, want %#v", err, expectedErr)
 		}
@@ -884,13 +1004,13 @@ func TestSetGpUserPasswd(t *testing.T) {
 		}
 		defer utils.ResetSystemFunctions()
 
-		hub.SetExecOnDatabase(func(conn *dbconn.DBConn, dbname, query string) error {
+		hub.SetExecWithoutStatementLogging(func(conn *dbconn.DBConn, dbname, query string) error {
 			return expectedErr
 		})
-		defer hub.ResetExecOnDatabase()
+		defer hub.ResetExecWithoutStatementLogging()
 
 		conn := &dbconn.DBConn{}
-		err := hub.SetGpUserPasswd(conn, "abc")
+		err := hub.SetGpUserPasswd(conn, verifier)
 		if !errors.Is(err, expectedErr) {
 			t.Fatalf("got %#v, want %#v", err, expectedErr)
 		}
@@ -0,0 +1,65 @@
+package hub
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc/connectivity"
+)
+
+var agentConnectionStateDesc = prometheus.NewDesc(
+	"gp_hub_agent_connection_state",
+	"State of the connection of the hub to each agent, 1 for the current state.",
+	[]string{"host", "state"}, nil,
+)
+
+var agentConnectionFailuresDesc = prometheus.NewDesc(
+	"gp_hub_agent_connection_failures",
+	"Consecutive failed attempts of the hub to connect to each agent, reset once connected.",
+	[]string{"host"}, nil,
+)
+
+var connectivityStates = []connectivity.State{
+	connectivity.Idle,
+	connectivity.Connecting,
+	connectivity.Ready,
+	connectivity.TransientFailure,
+	connectivity.Shutdown,
+}
+
+// agentConnectionsCollector reports the state of the agent connections of the
+// hub at the time of the scrape
+type agentConnectionsCollector struct {
+	server *Server
+}
+
+func (c agentConnectionsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- agentConnectionStateDesc
+	ch <- agentConnectionFailuresDesc
+}
+
+func (c agentConnectionsCollector) Collect(ch chan<- prometheus.Metric) {
+	c.server.mutex.Lock()
+	defer c.server.mutex.Unlock()
+
+	for _, conn := range c.server.Conns {
+		if conn.Conn == nil {
+			continue
+		}
+
+		current := conn.Conn.GetState()
+		for _, state := range connectivityStates {
+			value := 0.0
+			if state == current {
+				value = 1
+			}
+			ch <- prometheus.MustNewConstMetric(agentConnectionStateDesc, prometheus.GaugeValue, value, conn.Hostname, state.String())
+		}
+	}
+
+	for _, host := range c.server.Hostnames {
+		failures := 0
+		if health, ok := c.server.agentHealth[host]; ok {
+			failures = health.failures
+		}
+		ch <- prometheus.MustNewConstMetric(agentConnectionFailuresDesc, prometheus.GaugeValue, float64(failures), host)
+	}
+}
@@ -0,0 +1,72 @@
+package hub_test
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/greenplum-db/gp-common-go-libs/testhelper"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/greenplum-db/gpdb/gp/hub"
+	"github.com/greenplum-db/gpdb/gp/testutils"
+	"github.com/greenplum-db/gpdb/gp/utils/metrics"
+)
+
+func TestHubMetrics(t *testing.T) {
+	testhelper.SetupTestLogger()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("unexpected error: %#v", err)
+	}
+	metricsPort := listener.Addr().(*net.TCPAddr).Port
+	listener.Close()
+
+	hubServer := hub.New(&hub.Config{
+		Hostnames:   []string{"sdw1", "sdw2"},
+		LogDir:      t.TempDir(),
+		Credentials: &testutils.MockCredentials{TlsConnection: insecure.NewCredentials()},
+		Metrics:     &metrics.Config{BindAddress: "127.0.0.1", HubPort: metricsPort},
+	}, startReloadingAgent(t, &reloadingAgent{}))
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- hubServer.Start()
+	}()
+	select {
+	case err := <-errChan:
+		t.Fatalf("unexpected error: %#v", err)
+	case <-time.After(500 * time.Millisecond):
+	}
+	defer hubServer.Shutdown()
+
+	err = hubServer.DialAllAgents()
+	if err != nil {
+		t.Fatalf("unexpected error: %#v", err)
+	}
+
+	resp, err := http.Get(fmt.Sprintf("http://127.0.0.1:%d/metrics", metricsPort))
+	if err != nil {
+		t.Fatalf("unexpected error: %#v", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("unexpected error: %#v", err)
+	}
+
+	for _, expected := range []string{
+		`gp_hub_agent_connection_state{host="sdw1",state="READY"} 1`,
+		`gp_hub_agent_connection_state{host="sdw1",state="TRANSIENT_FAILURE"} 0`,
+		`gp_hub_agent_connection_state{host="sdw2",state="READY"} 1`,
+		`gp_hub_agent_connection_failures{host="sdw1"} 0`,
+	} {
+		if !strings.Contains(string(body), expected) {
+			t.Errorf("expected metrics to contain %q, got:\n%s", expected, body)
+		}
+	}
+}
@@ -0,0 +1,250 @@
+package hub
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/greenplum-db/gpdb/gp/idl"
+	"github.com/greenplum-db/gpdb/gp/utils"
+	"github.com/greenplum-db/gpdb/gp/utils/greenplum"
+	"github.com/greenplum-db/gpdb/gp/utils/postgres"
+	"github.com/greenplum-db/gpdb/gp/utils/tracing"
+)
+
+// ListPgHba returns the rules present in the pg_hba.conf file of the targeted segments.
+// Failures are reported per segment so that the rest of the cluster is still listed.
+func (s *Server) ListPgHba(ctx context.Context, req *idl.ListPgHbaRequest) (*idl.ListPgHbaReply, error) {
+	segs, err := s.getHbaTargetSegments(req.CoordinatorDataDir, req.Targets)
+	if err != nil {
+		return &idl.ListPgHbaReply{}, tracing.LogAndReturnError(ctx, err)
+	}
+
+	confs := make([]*idl.PgHbaConf, len(segs))
+	err = s.executeOnSegments(ctx, segs, func(conn *Connection, idx int, seg greenplum.Segment) {
+		conf := &idl.PgHbaConf{Segment: segmentToIdl(seg)}
+		confs[idx] = conf
+
+		hba, err := getSegmentHbaFile(ctx, conn, seg)
+		if err != nil {
+			conf.Error = err.Error()
+			return
+		}
+
+		for _, entry := range hba.Rules() {
+			conf.Entries = append(conf.Entries, entry.String())
+		}
+	}, func(idx int, seg greenplum.Segment, err error) {
+		confs[idx] = &idl.PgHbaConf{Segment: segmentToIdl(seg), Error: err.Error()}
+	})
+	if err != nil {
+		return &idl.ListPgHbaReply{}, tracing.LogAndReturnError(ctx, err)
+	}
+
+	return &idl.ListPgHbaReply{Confs: confs}, nil
+}
+
+// ModifyPgHba adds and removes rules from the pg_hba.conf file of the targeted segments
+// and reloads them unless requested otherwise. The existing files are backed up by the
+// agents before being replaced.
+func (s *Server) ModifyPgHba(ctx context.Context, req *idl.ModifyPgHbaRequest) (*idl.ModifyPgHbaReply, error) {
+	if len(req.AddEntries) == 0 && len(req.RemoveEntries) == 0 {
+		return &idl.ModifyPgHbaReply{}, tracing.LogAndReturnError(ctx, fmt.Errorf("no pg_hba.conf entries to add or remove"))
+	}
+
+	segs, err := s.getHbaTargetSegments(req.CoordinatorDataDir, req.Targets)
+	if err != nil {
+		return &idl.ModifyPgHbaReply{}, tracing.LogAndReturnError(ctx, err)
+	}
+
+	results := make([]*idl.PgHbaResult, len(segs))
+	err = s.executeOnSegments(ctx, segs, func(conn *Connection, idx int, seg greenplum.Segment) {
+		result := &idl.PgHbaResult{Segment: segmentToIdl(seg)}
+		results[idx] = result
+
+		reply, err := conn.AgentClient.ModifyPgHbaConfAndReload(ctx, &idl.ModifyPgHbaConfRequest{
+			Pgdata:        seg.DataDir,
+			AddEntries:    req.AddEntries,
+			RemoveEntries: req.RemoveEntries,
+			Position:      req.Position,
+			Reference:     req.Reference,
+			Reload:        !req.NoReload,
+		})
+		if reply != nil {
+			result.BackupFile = reply.BackupFile
+		}
+		if err != nil {
+			result.Error = utils.FormatGrpcError(err).Error()
+		}
+	}, func(idx int, seg greenplum.Segment, err error) {
+		results[idx] = &idl.PgHbaResult{Segment: segmentToIdl(seg), Error: err.Error()}
+	})
+	if err != nil {
+		return &idl.ModifyPgHbaReply{}, tracing.LogAndReturnError(ctx, err)
+	}
+
+	return &idl.ModifyPgHbaReply{Results: results}, nil
+}
+
+// CheckPgHba compares the rules of the pg_hba.conf file of the targeted segments against
+// the expected rules and reports the rules which are missing, not expected, or
+// found after a rule they are expected to precede.
+func (s *Server) CheckPgHba(ctx context.Context, req *idl.CheckPgHbaRequest) (*idl.CheckPgHbaReply, error) {
+	expected := postgres.ParseHbaFile("")
+	for _, line := range req.ExpectedEntries {
+		entry, err := postgres.ParseHbaEntry(line)
+		if err != nil {
+			return &idl.CheckPgHbaReply{}, tracing.LogAndReturnError(ctx, err)
+		}
+		if entry.IsRule() {
+			expected.Append(entry)
+		}
+	}
+
+	segs, err := s.getHbaTargetSegments(req.CoordinatorDataDir, req.Targets)
+	if err != nil {
+		return &idl.CheckPgHbaReply{}, tracing.LogAndReturnError(ctx, err)
+	}
+
+	drifts := make([]*idl.PgHbaDrift, len(segs))
+	err = s.executeOnSegments(ctx, segs, func(conn *Connection, idx int, seg greenplum.Segment) {
+		drift := &idl.PgHbaDrift{Segment: segmentToIdl(seg)}
+		drifts[idx] = drift
+
+		hba, err := getSegmentHbaFile(ctx, conn, seg)
+		if err != nil {
+			drift.Error = err.Error()
+			return
+		}
+
+		// the first rule matching a connection is used, so the expected rules
+		// present must also come in the expected order
+		last := -1
+		for _, entry := range expected.Entries {
+			idx := hba.Index(entry)
+			switch {
+			case idx == -1:
+				drift.Missing = append(drift.Missing, entry.String())
+			case idx < last:
+				drift.Misordered = append(drift.Misordered, entry.String())
+			default:
+				last = idx
+			}
+		}
+
+		for _, entry := range hba.Rules() {
+			if expected.Index(entry) == -1 {
+				drift.Unexpected = append(drift.Unexpected, entry.String())
+			}
+		}
+	}, func(idx int, seg greenplum.Segment, err error) {
+		drifts[idx] = &idl.PgHbaDrift{Segment: segmentToIdl(seg), Error: err.Error()}
+	})
+	if err != nil {
+		return &idl.CheckPgHbaReply{}, tracing.LogAndReturnError(ctx, err)
+	}
+
+	return &idl.CheckPgHbaReply{Drifts: drifts}, nil
+}
+
+// getHbaTargetSegments returns the segments selected by the targets, all of
+// them when no target is set
+func (s *Server) getHbaTargetSegments(coordinatorDataDir string, targets *idl.HbaTargets) ([]greenplum.Segment, error) {
+	conn, err := greenplum.GetCoordinatorConn(coordinatorDataDir, "", true)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	gparray, err := greenplum.NewGpArrayFromCatalog(conn)
+	if err != nil {
+		return nil, err
+	}
+
+	if targets == nil || (!targets.Coordinator && !targets.Standby && !targets.Segments) {
+		targets = &idl.HbaTargets{Coordinator: true, Standby: true, Segments: true}
+	}
+
+	var segs []greenplum.Segment
+	if targets.Coordinator && gparray.Coordinator != nil {
+		segs = append(segs, *gparray.Coordinator)
+	}
+	if targets.Standby && gparray.Standby != nil {
+		segs = append(segs, *gparray.Standby)
+	}
+	if targets.Segments {
+		segs = append(segs, gparray.GetAllSegments()...)
+	}
+
+	return segs, nil
+}
+
+// executeOnSegments runs the request for every segment in parallel using the
+// agent connection of its host, at most as many at once as the parallelism of
+// gp.conf allows. The request is passed the index of the segment so that the
+// results can be stored in the same order as the segments. The segments of the
+// hosts whose agent could not be connected to, and the ones still waiting for
+// their turn once the context is done, are passed to failed instead.
+func (s *Server) executeOnSegments(ctx context.Context, segs []greenplum.Segment, request func(conn *Connection, idx int, seg greenplum.Segment), failed func(idx int, seg greenplum.Segment, err error)) error {
+	hostToSegIdxMap := make(map[string][]int)
+	var hosts []string
+	for idx, seg := range segs {
+		if _, ok := hostToSegIdxMap[seg.Hostname]; !ok {
+			hosts = append(hosts, seg.Hostname)
+		}
+		hostToSegIdxMap[seg.Hostname] = append(hostToSegIdxMap[seg.Hostname], idx)
+	}
+
+	conns, dialErrs := s.dialAgentsByHost(hosts)
+	for _, host := range hosts {
+		if err, ok := dialErrs[host]; ok {
+			for _, idx := range hostToSegIdxMap[host] {
+				failed(idx, segs[idx], err)
+			}
+		}
+	}
+
+	limiter := s.newLimiter(nil)
+	return ExecuteRPC(conns, func(conn *Connection) error {
+		var wg sync.WaitGroup
+		for _, idx := range hostToSegIdxMap[conn.Hostname] {
+			wg.Add(1)
+			go func(idx int) {
+				defer wg.Done()
+
+				release, err := limiter.Acquire(ctx, conn.Hostname)
+				if err != nil {
+					failed(idx, segs[idx], err)
+					return
+				}
+				defer release()
+				request(conn, idx, segs[idx])
+			}(idx)
+		}
+		wg.Wait()
+
+		return nil
+	})
+}
+
+func getSegmentHbaFile(ctx context.Context, conn *Connection, seg greenplum.Segment) (*postgres.HbaFile, error) {
+	reply, err := conn.AgentClient.GetPgHbaConf(ctx, &idl.GetPgHbaConfRequest{
+		Pgdata: seg.DataDir,
+	})
+	if err != nil {
+		return nil, utils.FormatGrpcError(err)
+	}
+
+	return postgres.ParseHbaFile(reply.Content), nil
+}
+
+func segmentToIdl(seg greenplum.Segment) *idl.Segment {
+	return &idl.Segment{
+		Port:          int32(seg.Port),
+		DataDirectory: seg.DataDir,
+		HostName:      seg.Hostname,
+		HostAddress:   seg.Address,
+		Contentid:     int32(seg.Content),
+		Dbid:          int32(seg.Dbid),
+	}
+}
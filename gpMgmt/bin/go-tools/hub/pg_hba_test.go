@@ -0,0 +1,422 @@
+package hub_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/greenplum-db/gp-common-go-libs/dbconn"
+	"github.com/greenplum-db/gp-common-go-libs/testhelper"
+	"github.com/greenplum-db/gpdb/gp/hub"
+	"github.com/greenplum-db/gpdb/gp/idl"
+	"github.com/greenplum-db/gpdb/gp/idl/mock_idl"
+	"github.com/greenplum-db/gpdb/gp/testutils"
+	"github.com/greenplum-db/gpdb/gp/utils"
+	"github.com/greenplum-db/gpdb/gp/utils/greenplum"
+)
+
+func setupPgHbaTest(t *testing.T) {
+	t.Helper()
+
+	utils.System.Open = func(name string) (*os.File, error) {
+		reader, writer, _ := os.Pipe()
+		defer writer.Close()
+
+		_, err := writer.WriteString("port=1234")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		return reader, nil
+	}
+
+	hub.SetEnsureConnectionsAreReady(func(conns []*hub.Connection) error {
+		return nil
+	})
+
+	greenplum.SetNewDBConnFromEnvironment(func(dbname string) *dbconn.DBConn {
+		conn, mock := testutils.CreateMockDBConnForUtilityMode(t)
+		testhelper.ExpectVersionQuery(mock, "7.0.0")
+
+		rows := sqlmock.NewRows([]string{"dbid", "content", "role", "preferredrole", "port", "hostname", "address", "datadir"})
+		addSegmentRows(t, rows, coordinator, primary1, primary2, mirror1, mirror2)
+		mock.ExpectQuery("SELECT").WillReturnRows(rows)
+
+		return conn
+	})
+}
+
+func teardownPgHbaTest() {
+	utils.ResetSystemFunctions()
+	hub.ResetEnsureConnectionsAreReady()
+	greenplum.ResetNewDBConnFromEnvironment()
+}
+
+func TestListPgHba(t *testing.T) {
+	testhelper.SetupTestLogger()
+	initialize(t)
+
+	t.Run("lists the rules of the targeted segments", func(t *testing.T) {
+		setupPgHbaTest(t)
+		defer teardownPgHbaTest()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		sdw1 := mock_idl.NewMockAgentClient(ctrl)
+		sdw1.EXPECT().GetPgHbaConf(gomock.Any(), &idl.GetPgHbaConfRequest{Pgdata: primary1.DataDir}).
+			Return(&idl.GetPgHbaConfReply{Content: "# comment\nhost all gpadmin sdw2 trust\n"}, nil)
+		sdw1.EXPECT().GetPgHbaConf(gomock.Any(), &idl.GetPgHbaConfRequest{Pgdata: mirror2.DataDir}).
+			Return(nil, errors.New("error"))
+
+		sdw2 := mock_idl.NewMockAgentClient(ctrl)
+		sdw2.EXPECT().GetPgHbaConf(gomock.Any(), gomock.Any()).
+			Return(&idl.GetPgHbaConfReply{Content: "host all gpadmin sdw1 md5\n"}, nil).Times(2)
+
+		hubServer.Conns = []*hub.Connection{
+			{AgentClient: mock_idl.NewMockAgentClient(ctrl), Hostname: "cdw"},
+			{AgentClient: sdw1, Hostname: "sdw1"},
+			{AgentClient: sdw2, Hostname: "sdw2"},
+		}
+
+		reply, err := hubServer.ListPgHba(context.Background(), &idl.ListPgHbaRequest{
+			Targets: &idl.HbaTargets{Segments: true},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		result := make(map[string][]string)
+		errs := make(map[string]string)
+		for _, conf := range reply.Confs {
+			result[conf.Segment.DataDirectory] = conf.Entries
+			errs[conf.Segment.DataDirectory] = conf.Error
+		}
+
+		expected := map[string][]string{
+			primary1.DataDir: {"host\tall\tgpadmin\tsdw2\ttrust"},
+			primary2.DataDir: {"host\tall\tgpadmin\tsdw1\tmd5"},
+			mirror1.DataDir:  {"host\tall\tgpadmin\tsdw1\tmd5"},
+			mirror2.DataDir:  nil,
+		}
+		if !reflect.DeepEqual(result, expected) {
+			t.Fatalf("got %q, want %q", result, expected)
+		}
+
+		expectedErrs := map[string]string{
+			primary1.DataDir: "",
+			primary2.DataDir: "",
+			mirror1.DataDir:  "",
+			mirror2.DataDir:  "error",
+		}
+		if !reflect.DeepEqual(errs, expectedErrs) {
+			t.Fatalf("got %q, want %q", errs, expectedErrs)
+		}
+	})
+
+	t.Run("targets all the segments when no target is set", func(t *testing.T) {
+		setupPgHbaTest(t)
+		defer teardownPgHbaTest()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		cdw := mock_idl.NewMockAgentClient(ctrl)
+		cdw.EXPECT().GetPgHbaConf(gomock.Any(), &idl.GetPgHbaConfRequest{Pgdata: coordinator.DataDir}).
+			Return(&idl.GetPgHbaConfReply{}, nil)
+		sdw1 := mock_idl.NewMockAgentClient(ctrl)
+		sdw1.EXPECT().GetPgHbaConf(gomock.Any(), gomock.Any()).Return(&idl.GetPgHbaConfReply{}, nil).Times(2)
+		sdw2 := mock_idl.NewMockAgentClient(ctrl)
+		sdw2.EXPECT().GetPgHbaConf(gomock.Any(), gomock.Any()).Return(&idl.GetPgHbaConfReply{}, nil).Times(2)
+
+		hubServer.Conns = []*hub.Connection{
+			{AgentClient: cdw, Hostname: "cdw"},
+			{AgentClient: sdw1, Hostname: "sdw1"},
+			{AgentClient: sdw2, Hostname: "sdw2"},
+		}
+
+		reply, err := hubServer.ListPgHba(context.Background(), &idl.ListPgHbaRequest{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(reply.Confs) != 5 {
+			t.Fatalf("got %d results, want 5", len(reply.Confs))
+		}
+	})
+
+	t.Run("reports the segments not listed once the context is done", func(t *testing.T) {
+		setupPgHbaTest(t)
+		defer teardownPgHbaTest()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		hubServer.Conns = []*hub.Connection{
+			{AgentClient: mock_idl.NewMockAgentClient(ctrl), Hostname: "cdw"},
+			{AgentClient: mock_idl.NewMockAgentClient(ctrl), Hostname: "sdw1"},
+			{AgentClient: mock_idl.NewMockAgentClient(ctrl), Hostname: "sdw2"},
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		reply, err := hubServer.ListPgHba(ctx, &idl.ListPgHbaRequest{
+			Targets: &idl.HbaTargets{Segments: true},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(reply.Confs) != 4 {
+			t.Fatalf("got %d segments, want 4", len(reply.Confs))
+		}
+		for _, conf := range reply.Confs {
+			if conf.Error != context.Canceled.Error() {
+				t.Fatalf("got %q for segment %s, want %q", conf.Error, conf.Segment.DataDirectory, context.Canceled)
+			}
+		}
+	})
+
+	t.Run("errors out when not able to connect to the coordinator", func(t *testing.T) {
+		setupPgHbaTest(t)
+		defer teardownPgHbaTest()
+
+		expectedErr := errors.New("error")
+		greenplum.SetNewDBConnFromEnvironment(func(dbname string) *dbconn.DBConn {
+			conn, _ := testutils.CreateMockDBConnForUtilityMode(t, expectedErr)
+			return conn
+		})
+		defer greenplum.ResetNewDBConnFromEnvironment()
+
+		_, err := hubServer.ListPgHba(context.Background(), &idl.ListPgHbaRequest{})
+		if err == nil || !strings.Contains(err.Error(), expectedErr.Error()) {
+			t.Fatalf("got %v, want %v", err, expectedErr)
+		}
+	})
+
+	t.Run("reports the segments of the hosts whose agent could not be connected to", func(t *testing.T) {
+		setupPgHbaTest(t)
+		defer teardownPgHbaTest()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		server := hub.New(&hub.Config{
+			AgentPort:   5678,
+			Hostnames:   []string{"sdw1", "sdw2"},
+			Credentials: &testutils.MockCredentials{TlsConnection: insecure.NewCredentials()},
+		}, func(ctx context.Context, address string) (net.Conn, error) {
+			return nil, errors.New("connection refused")
+		})
+		sdw1 := mock_idl.NewMockAgentClient(ctrl)
+		sdw1.EXPECT().GetPgHbaConf(gomock.Any(), gomock.Any()).
+			Return(&idl.GetPgHbaConfReply{Content: "host all gpadmin sdw2 trust\n"}, nil).Times(2)
+		server.Conns = []*hub.Connection{
+			{AgentClient: sdw1, Hostname: "sdw1"},
+		}
+
+		reply, err := server.ListPgHba(context.Background(), &idl.ListPgHbaRequest{
+			Targets: &idl.HbaTargets{Segments: true},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		for _, conf := range reply.Confs {
+			if conf.Segment.HostName == "sdw1" {
+				if conf.Error != "" || len(conf.Entries) != 1 {
+					t.Fatalf("got %+v, want the rules of the segment", conf)
+				}
+				continue
+			}
+
+			expected := "could not connect to agent on host sdw2:"
+			if !strings.HasPrefix(conf.Error, expected) {
+				t.Fatalf("got %q, want %s", conf.Error, expected)
+			}
+		}
+	})
+}
+
+func TestModifyPgHba(t *testing.T) {
+	testhelper.SetupTestLogger()
+	initialize(t)
+
+	t.Run("modifies the pg_hba.conf of the targeted segments", func(t *testing.T) {
+		setupPgHbaTest(t)
+		defer teardownPgHbaTest()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		cdw := mock_idl.NewMockAgentClient(ctrl)
+		cdw.EXPECT().ModifyPgHbaConfAndReload(gomock.Any(), &idl.ModifyPgHbaConfRequest{
+			Pgdata:     coordinator.DataDir,
+			AddEntries: []string{"host all all 10.0.0.0/8 md5"},
+			Position:   idl.HbaPosition_BEFORE,
+			Reference:  "host all all 0.0.0.0/0 reject",
+			Reload:     false,
+		}).Return(&idl.ModifyPgHbaConfReply{BackupFile: "backup"}, nil)
+
+		hubServer.Conns = []*hub.Connection{
+			{AgentClient: cdw, Hostname: "cdw"},
+			{AgentClient: mock_idl.NewMockAgentClient(ctrl), Hostname: "sdw1"},
+			{AgentClient: mock_idl.NewMockAgentClient(ctrl), Hostname: "sdw2"},
+		}
+
+		reply, err := hubServer.ModifyPgHba(context.Background(), &idl.ModifyPgHbaRequest{
+			Targets:    &idl.HbaTargets{Coordinator: true},
+			AddEntries: []string{"host all all 10.0.0.0/8 md5"},
+			Position:   idl.HbaPosition_BEFORE,
+			Reference:  "host all all 0.0.0.0/0 reject",
+			NoReload:   true,
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(reply.Results) != 1 || reply.Results[0].BackupFile != "backup" || reply.Results[0].Error != "" {
+			t.Fatalf("unexpected result: %+v", reply.Results)
+		}
+	})
+
+	t.Run("reports the segments which failed", func(t *testing.T) {
+		setupPgHbaTest(t)
+		defer teardownPgHbaTest()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		sdw1 := mock_idl.NewMockAgentClient(ctrl)
+		sdw1.EXPECT().ModifyPgHbaConfAndReload(gomock.Any(), gomock.Any()).
+			Return(&idl.ModifyPgHbaConfReply{BackupFile: "backup"}, nil).Times(2)
+		sdw2 := mock_idl.NewMockAgentClient(ctrl)
+		sdw2.EXPECT().ModifyPgHbaConfAndReload(gomock.Any(), gomock.Any()).
+			Return(nil, errors.New("error")).Times(2)
+
+		hubServer.Conns = []*hub.Connection{
+			{AgentClient: sdw1, Hostname: "sdw1"},
+			{AgentClient: sdw2, Hostname: "sdw2"},
+		}
+
+		reply, err := hubServer.ModifyPgHba(context.Background(), &idl.ModifyPgHbaRequest{
+			Targets:       &idl.HbaTargets{Segments: true},
+			RemoveEntries: []string{"host all all sdw3 trust"},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		for _, result := range reply.Results {
+			if result.Segment.HostName == "sdw2" && !strings.Contains(result.Error, "error") {
+				t.Fatalf("expected error for segment %v", result.Segment)
+			}
+			if result.Segment.HostName == "sdw1" && (result.Error != "" || result.BackupFile != "backup") {
+				t.Fatalf("unexpected result for segment %v: %+v", result.Segment, result)
+			}
+		}
+	})
+
+	t.Run("errors out when there are no entries to modify", func(t *testing.T) {
+		_, err := hubServer.ModifyPgHba(context.Background(), &idl.ModifyPgHbaRequest{})
+		expected := "no pg_hba.conf entries to add or remove"
+		if err == nil || err.Error() != expected {
+			t.Fatalf("got %v, want %s", err, expected)
+		}
+	})
+}
+
+func TestCheckPgHba(t *testing.T) {
+	testhelper.SetupTestLogger()
+	initialize(t)
+
+	t.Run("reports the missing and unexpected rules", func(t *testing.T) {
+		setupPgHbaTest(t)
+		defer teardownPgHbaTest()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		cdw := mock_idl.NewMockAgentClient(ctrl)
+		cdw.EXPECT().GetPgHbaConf(gomock.Any(), gomock.Any()).
+			Return(&idl.GetPgHbaConfReply{Content: "# comment\nlocal all gpadmin ident\nhost all all 10.0.0.0/8 trust\n"}, nil)
+
+		hubServer.Conns = []*hub.Connection{
+			{AgentClient: cdw, Hostname: "cdw"},
+		}
+
+		reply, err := hubServer.CheckPgHba(context.Background(), &idl.CheckPgHbaRequest{
+			Targets:         &idl.HbaTargets{Coordinator: true},
+			ExpectedEntries: []string{"# expected rules", "local   all   gpadmin   ident", "host all all 10.0.0.0/8 md5", ""},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		expected := []*idl.PgHbaDrift{{
+			Segment: &idl.Segment{
+				Port:          int32(coordinator.Port),
+				DataDirectory: coordinator.DataDir,
+				HostName:      coordinator.Hostname,
+				HostAddress:   coordinator.Address,
+				Contentid:     int32(coordinator.Content),
+				Dbid:          int32(coordinator.Dbid),
+			},
+			Missing:    []string{"host\tall\tall\t10.0.0.0/8\tmd5"},
+			Unexpected: []string{"host\tall\tall\t10.0.0.0/8\ttrust"},
+		}}
+		if !reflect.DeepEqual(reply.Drifts, expected) {
+			t.Fatalf("got %+v, want %+v", reply.Drifts, expected)
+		}
+	})
+
+	t.Run("reports the rules found in a different order than expected", func(t *testing.T) {
+		setupPgHbaTest(t)
+		defer teardownPgHbaTest()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		cdw := mock_idl.NewMockAgentClient(ctrl)
+		cdw.EXPECT().GetPgHbaConf(gomock.Any(), gomock.Any()).
+			Return(&idl.GetPgHbaConfReply{Content: "host all all 0.0.0.0/0 md5\nlocal all gpadmin ident\nhost all gpadmin 10.0.0.1/32 trust\n"}, nil)
+
+		hubServer.Conns = []*hub.Connection{
+			{AgentClient: cdw, Hostname: "cdw"},
+		}
+
+		reply, err := hubServer.CheckPgHba(context.Background(), &idl.CheckPgHbaRequest{
+			Targets:         &idl.HbaTargets{Coordinator: true},
+			ExpectedEntries: []string{"local all gpadmin ident", "host all gpadmin 10.0.0.1/32 trust", "host all all 0.0.0.0/0 md5"},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		expected := []string{"host\tall\tall\t0.0.0.0/0\tmd5"}
+		if len(reply.Drifts) != 1 || !reflect.DeepEqual(reply.Drifts[0].Misordered, expected) {
+			t.Fatalf("got %+v, want the misordered rules %q", reply.Drifts, expected)
+		}
+		if len(reply.Drifts[0].Missing) != 0 || len(reply.Drifts[0].Unexpected) != 0 {
+			t.Fatalf("got %+v, want no missing or unexpected rules", reply.Drifts[0])
+		}
+	})
+
+	t.Run("errors out when the expected rules are invalid", func(t *testing.T) {
+		_, err := hubServer.CheckPgHba(context.Background(), &idl.CheckPgHbaRequest{
+			ExpectedEntries: []string{"host all"},
+		})
+		expected := `invalid pg_hba.conf entry "host all": expected at least 5 fields, got 2`
+		if err == nil || err.Error() != expected {
+			t.Fatalf("got %v, want %s", err, expected)
+		}
+	})
+}
@@ -8,12 +8,15 @@ import (
 
 	"github.com/greenplum-db/gpdb/gp/idl"
 	"github.com/greenplum-db/gpdb/gp/utils/greenplum"
+	"github.com/greenplum-db/gpdb/gp/utils/postgres"
 )
 
 // UpdatePgHbaConfWithMirrorEntries updates the pg_hba.conf file on the primary segments
 // with the details of its corresponding mirror segment pair. The hbaHostname parameter
-// determines whether to use hostnames or IP addresses in the pg_hba.conf file.
-func (s *Server) UpdatePgHbaConfWithMirrorEntries(gparray *greenplum.GpArray, mirrorSegs []*idl.Segment, hbaHostname bool) error {
+// determines whether to use hostnames or IP addresses in the pg_hba.conf file and the
+// auth determines the authentication of the entries. At most as many primaries as
+// the parallelism allows are updated at once.
+func (s *Server) UpdatePgHbaConfWithMirrorEntries(ctx context.Context, gparray *greenplum.GpArray, mirrorSegs []*idl.Segment, hbaHostname bool, auth postgres.HbaAuth, parallelism *idl.Parallelism) error {
 	primaryHostToSegPairMap := make(map[string][]*greenplum.SegmentPair)
 	for _, seg := range mirrorSegs {
 		pair, err := gparray.GetSegmentPairForContent(int(seg.Contentid))
@@ -24,6 +27,7 @@ func (s *Server) UpdatePgHbaConfWithMirrorEntries(gparray *greenplum.GpArray, mi
 		primaryHostToSegPairMap[pair.Primary.Hostname] = append(primaryHostToSegPairMap[pair.Primary.Hostname], pair)
 	}
 
+	limiter := s.newLimiter(parallelism)
 	request := func(conn *Connection) error {
 		var wg sync.WaitGroup
 
@@ -38,16 +42,23 @@ func (s *Server) UpdatePgHbaConfWithMirrorEntries(gparray *greenplum.GpArray, mi
 				var err error
 				defer wg.Done()
 
+				release, err := limiter.Acquire(ctx, conn.Hostname)
+				if err != nil {
+					errs <- err
+					return
+				}
+				defer release()
+
 				if hbaHostname {
 					addrs = []string{pair.Primary.Address, pair.Mirror.Address}
 				} else {
-					primaryAddrs, err := s.GetInterfaceAddrs(pair.Primary.Hostname)
+					primaryAddrs, err := s.GetInterfaceAddrs(ctx, pair.Primary.Hostname)
 					if err != nil {
 						errs <- err
 						return
 					}
 
-					mirrorAddrs, err := s.GetInterfaceAddrs(pair.Mirror.Hostname)
+					mirrorAddrs, err := s.GetInterfaceAddrs(ctx, pair.Mirror.Hostname)
 					if err != nil {
 						errs <- err
 						return
@@ -56,10 +67,13 @@ func (s *Server) UpdatePgHbaConfWithMirrorEntries(gparray *greenplum.GpArray, mi
 					addrs = append(primaryAddrs, mirrorAddrs...)
 				}
 
-				_, err = conn.AgentClient.UpdatePgHbaConfAndReload(context.Background(), &idl.UpdatePgHbaConfRequest{
-					Pgdata:      pair.Primary.DataDir,
-					Addrs:       addrs,
-					Replication: true,
+				_, err = conn.AgentClient.UpdatePgHbaConfAndReload(ctx, &idl.UpdatePgHbaConfRequest{
+					Pgdata:                pair.Primary.DataDir,
+					Addrs:                 addrs,
+					Replication:           true,
+					AuthMethod:            auth.Method,
+					ReplicationAuthMethod: auth.ReplicationMethod,
+					Hostssl:               auth.HostSsl,
 				})
 				if err != nil {
 					errs <- err
@@ -78,17 +92,17 @@ func (s *Server) UpdatePgHbaConfWithMirrorEntries(gparray *greenplum.GpArray, mi
 		return err
 	}
 
-	return ExecuteRPC(s.Conns, request)
+	return ExecuteRPC(s.agentConns(nil), request)
 }
 
 // GetInterfaceAddrs returns the interface addresses for a given host.
 // It retrieves the interface addresses by executing an RPC call to the agent client.
-func (s *Server) GetInterfaceAddrs(host string) ([]string, error) {
-	conns := getConnForHosts(s.Conns, []string{host})
+func (s *Server) GetInterfaceAddrs(ctx context.Context, host string) ([]string, error) {
+	conns := s.agentConns([]string{host})
 
 	var addrs []string
 	request := func(conn *Connection) error {
-		resp, err := conn.AgentClient.GetInterfaceAddrs(context.Background(), &idl.GetInterfaceAddrsRequest{})
+		resp, err := conn.AgentClient.GetInterfaceAddrs(ctx, &idl.GetInterfaceAddrsRequest{})
 		if err != nil {
 			return fmt.Errorf("failed to get interface addresses for host %s: %w", conn.Hostname, err)
 		}
@@ -97,7 +111,7 @@ func (s *Server) GetInterfaceAddrs(host string) ([]string, error) {
 		return nil
 	}
 
-	err := ExecuteRPC(conns, request)
+	err := s.executeRPC(ctx, conns, request)
 
 	return addrs, err
 }
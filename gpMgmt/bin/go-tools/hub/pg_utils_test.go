@@ -1,6 +1,7 @@
 package hub_test
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"strings"
@@ -11,6 +12,7 @@ import (
 	"github.com/greenplum-db/gpdb/gp/hub"
 	"github.com/greenplum-db/gpdb/gp/idl"
 	"github.com/greenplum-db/gpdb/gp/idl/mock_idl"
+	"github.com/greenplum-db/gpdb/gp/utils/postgres"
 )
 
 func TestUpdatePgHbaConf(t *testing.T) {
@@ -80,7 +82,7 @@ func TestUpdatePgHbaConf(t *testing.T) {
 		}
 		hubServer.Conns = agentConns
 
-		err := hubServer.UpdatePgHbaConfWithMirrorEntries(gparray, mirrorSegs, false)
+		err := hubServer.UpdatePgHbaConfWithMirrorEntries(context.Background(), gparray, mirrorSegs, false, postgres.HbaAuth{}, nil)
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
@@ -94,9 +96,12 @@ func TestUpdatePgHbaConf(t *testing.T) {
 		sdw1.EXPECT().UpdatePgHbaConfAndReload(
 			gomock.Any(),
 			&idl.UpdatePgHbaConfRequest{
-				Pgdata:      primary1.DataDir,
-				Addrs:       []string{primary1.Hostname, mirror1.Hostname},
-				Replication: true,
+				Pgdata:                primary1.DataDir,
+				Addrs:                 []string{primary1.Hostname, mirror1.Hostname},
+				Replication:           true,
+				AuthMethod:            "scram-sha-256",
+				ReplicationAuthMethod: "cert",
+				Hostssl:               true,
 			},
 		).Return(&idl.UpdatePgHbaConfResponse{}, nil)
 
@@ -104,9 +109,12 @@ func TestUpdatePgHbaConf(t *testing.T) {
 		sdw2.EXPECT().UpdatePgHbaConfAndReload(
 			gomock.Any(),
 			&idl.UpdatePgHbaConfRequest{
-				Pgdata:      primary2.DataDir,
-				Addrs:       []string{primary2.Hostname, mirror2.Hostname},
-				Replication: true,
+				Pgdata:                primary2.DataDir,
+				Addrs:                 []string{primary2.Hostname, mirror2.Hostname},
+				Replication:           true,
+				AuthMethod:            "scram-sha-256",
+				ReplicationAuthMethod: "cert",
+				Hostssl:               true,
 			},
 		).Return(&idl.UpdatePgHbaConfResponse{}, nil)
 
@@ -116,7 +124,7 @@ func TestUpdatePgHbaConf(t *testing.T) {
 		}
 		hubServer.Conns = agentConns
 
-		err := hubServer.UpdatePgHbaConfWithMirrorEntries(gparray, mirrorSegs, true)
+		err := hubServer.UpdatePgHbaConfWithMirrorEntries(context.Background(), gparray, mirrorSegs, true, postgres.HbaAuth{Method: "scram-sha-256", ReplicationMethod: "cert", HostSsl: true}, nil)
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
@@ -124,7 +132,7 @@ func TestUpdatePgHbaConf(t *testing.T) {
 
 	t.Run("errors out when not able to find the mirror content in gparray", func(t *testing.T) {
 		segs := []*idl.Segment{{Contentid: 1234}}
-		err := hubServer.UpdatePgHbaConfWithMirrorEntries(gparray, segs, true)
+		err := hubServer.UpdatePgHbaConfWithMirrorEntries(context.Background(), gparray, segs, true, postgres.HbaAuth{}, nil)
 
 		expectedErrString := "could not find any segments with content 1234"
 		if err.Error() != expectedErrString {
@@ -172,7 +180,7 @@ func TestUpdatePgHbaConf(t *testing.T) {
 		}
 		hubServer.Conns = agentConns
 
-		err := hubServer.UpdatePgHbaConfWithMirrorEntries(gparray, mirrorSegs, false)
+		err := hubServer.UpdatePgHbaConfWithMirrorEntries(context.Background(), gparray, mirrorSegs, false, postgres.HbaAuth{}, nil)
 		if !errors.Is(err, expectedErr) {
 			t.Fatalf("got %#v, want %#v", err, expectedErr)
 		}
@@ -207,7 +215,7 @@ func TestUpdatePgHbaConf(t *testing.T) {
 		}
 		hubServer.Conns = agentConns
 
-		err := hubServer.UpdatePgHbaConfWithMirrorEntries(gparray, mirrorSegs, true)
+		err := hubServer.UpdatePgHbaConfWithMirrorEntries(context.Background(), gparray, mirrorSegs, true, postgres.HbaAuth{}, nil)
 		if !errors.Is(err, expectedErr) {
 			t.Fatalf("got %#v, want %#v", err, expectedErr)
 		}
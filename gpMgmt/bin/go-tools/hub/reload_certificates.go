@@ -0,0 +1,63 @@
+package hub
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/greenplum-db/gpdb/gp/idl"
+	"github.com/greenplum-db/gpdb/gp/utils"
+	"github.com/greenplum-db/gpdb/gp/utils/tracing"
+)
+
+// ReloadCertificates reloads the certificates of the hub and the agents after
+// they have been rotated, and reconnects to the agents with the new client
+// certificate of the hub. Established connections are not interrupted.
+func (s *Server) ReloadCertificates(ctx context.Context, req *idl.ReloadCertificatesRequest) (*idl.ReloadCertificatesReply, error) {
+	err := s.reloadCertificates()
+	if err != nil {
+		return &idl.ReloadCertificatesReply{}, tracing.LogAndReturnError(ctx, err)
+	}
+
+	conns, err := s.DialAgents(s.Hostnames)
+	if err != nil {
+		return &idl.ReloadCertificatesReply{}, tracing.LogAndReturnError(ctx, err)
+	}
+
+	request := func(conn *Connection) error {
+		_, err := conn.AgentClient.ReloadCertificates(ctx, &idl.ReloadCertificatesRequest{})
+		if err != nil {
+			return utils.FormatGrpcError(err)
+		}
+
+		return nil
+	}
+
+	err = s.executeRPC(ctx, conns, request)
+	if err != nil {
+		return &idl.ReloadCertificatesReply{}, tracing.LogAndReturnError(ctx, fmt.Errorf("could not reload certificates on the agents: %w", err))
+	}
+	tracing.Info(ctx, "Reloaded certificates on the hub and agents")
+
+	return &idl.ReloadCertificatesReply{}, nil
+}
+
+// reloadCertificates reloads the server certificates of the hub and retires the
+// agent connections, so that they are made again with the current client
+// certificate when next needed
+func (s *Server) reloadCertificates() error {
+	fingerprint := s.readClientFingerprint()
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if reloader, ok := s.serverCredentials.(utils.Reloader); ok {
+		err := reloader.Reload()
+		if err != nil {
+			return fmt.Errorf("could not reload hub certificates: %w", err)
+		}
+	}
+	s.certFingerprint = fingerprint
+	s.retireAgentConns()
+
+	return nil
+}
@@ -0,0 +1,233 @@
+package hub_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/greenplum-db/gp-common-go-libs/testhelper"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/greenplum-db/gpdb/gp/hub"
+	"github.com/greenplum-db/gpdb/gp/idl"
+	"github.com/greenplum-db/gpdb/gp/testutils"
+)
+
+type reloadingAgent struct {
+	idl.UnimplementedAgentServer
+
+	mutex    sync.Mutex
+	reloaded int
+	err      error
+
+	started chan struct{}
+	release chan struct{}
+}
+
+func (a *reloadingAgent) ReloadCertificates(context.Context, *idl.ReloadCertificatesRequest) (*idl.ReloadCertificatesReply, error) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	a.reloaded++
+
+	return &idl.ReloadCertificatesReply{}, a.err
+}
+
+// Status blocks until released, to keep a call in flight
+func (a *reloadingAgent) Status(context.Context, *idl.StatusAgentRequest) (*idl.StatusAgentReply, error) {
+	a.started <- struct{}{}
+	<-a.release
+
+	return &idl.StatusAgentReply{}, nil
+}
+
+func startReloadingAgent(t *testing.T, agentServer *reloadingAgent) hub.Dialer {
+	t.Helper()
+
+	listener := bufconn.Listen(1024 * 1024)
+	grpcServer := grpc.NewServer()
+	t.Cleanup(grpcServer.Stop)
+
+	idl.RegisterAgentServer(grpcServer, agentServer)
+	go grpcServer.Serve(listener) //nolint:errcheck
+
+	return func(ctx context.Context, address string) (net.Conn, error) {
+		return listener.Dial()
+	}
+}
+
+func TestReloadCertificates(t *testing.T) {
+	testhelper.SetupTestLogger()
+
+	startHub := func(t *testing.T, creds *testutils.MockReloadableCredentials, dialer hub.Dialer) *hub.Server {
+		t.Helper()
+
+		hubServer := hub.New(&hub.Config{
+			Hostnames:   []string{"sdw1", "sdw2"},
+			LogDir:      t.TempDir(),
+			Credentials: &testutils.MockCredentials{TlsConnection: creds},
+		}, dialer)
+		errChan := make(chan error, 1)
+		go func() {
+			errChan <- hubServer.Start()
+		}()
+
+		select {
+		case err := <-errChan:
+			t.Fatalf("unexpected error: %#v", err)
+		case <-time.After(500 * time.Millisecond):
+		}
+		t.Cleanup(hubServer.Shutdown)
+
+		return hubServer
+	}
+
+	t.Run("reloads the certificates of the hub and the agents", func(t *testing.T) {
+		agentServer := &reloadingAgent{}
+		creds := &testutils.MockReloadableCredentials{TransportCredentials: insecure.NewCredentials()}
+		hubServer := startHub(t, creds, startReloadingAgent(t, agentServer))
+
+		err := hubServer.DialAllAgents()
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		oldConn := hubServer.Conns[0].Conn
+
+		_, err = hubServer.ReloadCertificates(context.Background(), &idl.ReloadCertificatesRequest{})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		if creds.Reloaded != 1 {
+			t.Fatalf("got %d hub reloads, want 1", creds.Reloaded)
+		}
+		if agentServer.reloaded != 2 {
+			t.Fatalf("got %d agent reloads, want 2", agentServer.reloaded)
+		}
+		if oldConn.GetState() != connectivity.Shutdown {
+			t.Fatalf("expected the previous agent connections to be closed")
+		}
+	})
+
+	t.Run("errors out when the hub certificates cannot be reloaded", func(t *testing.T) {
+		agentServer := &reloadingAgent{}
+		creds := &testutils.MockReloadableCredentials{TransportCredentials: insecure.NewCredentials(), Err: errors.New("error")}
+		hubServer := startHub(t, creds, startReloadingAgent(t, agentServer))
+
+		_, err := hubServer.ReloadCertificates(context.Background(), &idl.ReloadCertificatesRequest{})
+		expected := "could not reload hub certificates: error"
+		if err == nil || err.Error() != expected {
+			t.Fatalf("got %v, want %s", err, expected)
+		}
+
+		if agentServer.reloaded != 0 {
+			t.Fatalf("got %d agent reloads, want none", agentServer.reloaded)
+		}
+	})
+
+	t.Run("errors out when the agents cannot reload their certificates", func(t *testing.T) {
+		agentServer := &reloadingAgent{err: errors.New("error")}
+		creds := &testutils.MockReloadableCredentials{TransportCredentials: insecure.NewCredentials()}
+		hubServer := startHub(t, creds, startReloadingAgent(t, agentServer))
+
+		_, err := hubServer.ReloadCertificates(context.Background(), &idl.ReloadCertificatesRequest{})
+		expected := "could not reload certificates on the agents:"
+		if err == nil || !strings.HasPrefix(err.Error(), expected) {
+			t.Fatalf("got %v, want %s", err, expected)
+		}
+	})
+}
+
+func TestDialAllAgentsAfterRotation(t *testing.T) {
+	testhelper.SetupTestLogger()
+
+	t.Run("reconnects to the agents when the client certificate changes once reloaded", func(t *testing.T) {
+		credentials := &testutils.MockCredentials{TlsConnection: insecure.NewCredentials(), Fingerprint: "old"}
+		hubServer := hub.New(&hub.Config{
+			Hostnames:   []string{"sdw1"},
+			Credentials: credentials,
+		}, startReloadingAgent(t, &reloadingAgent{}))
+
+		err := hubServer.DialAllAgents()
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		oldConn := hubServer.Conns[0].Conn
+
+		err = hubServer.DialAllAgents()
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		if hubServer.Conns[0].Conn != oldConn {
+			t.Fatalf("expected the connection to be reused while the certificate is unchanged")
+		}
+
+		// the certificate files are only read when the certificates are reloaded
+		credentials.Fingerprint = "new"
+		err = hubServer.DialAllAgents()
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		if hubServer.Conns[0].Conn != oldConn {
+			t.Fatalf("expected the connection to be reused until the certificates are reloaded")
+		}
+
+		_, err = hubServer.ReloadCertificates(context.Background(), &idl.ReloadCertificatesRequest{})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		if len(hubServer.Conns) != 1 || hubServer.Conns[0].Conn == oldConn {
+			t.Fatalf("expected a new connection to the agent")
+		}
+		if oldConn.GetState() != connectivity.Shutdown {
+			t.Fatalf("expected the previous connection to be closed")
+		}
+	})
+
+	t.Run("closes the previous connection only after the calls in flight complete", func(t *testing.T) {
+		agentServer := &reloadingAgent{started: make(chan struct{}), release: make(chan struct{})}
+		credentials := &testutils.MockCredentials{TlsConnection: insecure.NewCredentials(), Fingerprint: "old"}
+		hubServer := hub.New(&hub.Config{
+			Hostnames:   []string{"sdw1"},
+			Credentials: credentials,
+		}, startReloadingAgent(t, agentServer))
+
+		err := hubServer.DialAllAgents()
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		oldConn := hubServer.Conns[0]
+
+		errChan := make(chan error, 1)
+		go func() {
+			_, err := oldConn.AgentClient.Status(context.Background(), &idl.StatusAgentRequest{})
+			errChan <- err
+		}()
+		<-agentServer.started
+
+		credentials.Fingerprint = "new"
+		_, err = hubServer.ReloadCertificates(context.Background(), &idl.ReloadCertificatesRequest{})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		if oldConn.Conn.GetState() == connectivity.Shutdown {
+			t.Fatalf("expected the previous connection to stay open during the call")
+		}
+
+		close(agentServer.release)
+		err = <-errChan
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		if oldConn.Conn.GetState() != connectivity.Shutdown {
+			t.Fatalf("expected the previous connection to be closed")
+		}
+	})
+}
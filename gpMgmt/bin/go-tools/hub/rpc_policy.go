@@ -0,0 +1,191 @@
+package hub
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/greenplum-db/gpdb/gp/constants"
+	"github.com/greenplum-db/gpdb/gp/idl"
+	"github.com/greenplum-db/gpdb/gp/utils/tracing"
+)
+
+var (
+	// Delay before calling again an agent which was unavailable, which doubles
+	// with each attempt up to AgentReconnectMaxBackoff
+	AgentRetryBackoff = time.Second
+	// Time given to the calls whose request sets how long the agent waits, such
+	// as StartSegment or TestDiskPerformance, on top of that wait
+	AgentWaitGrace = 30 * time.Second
+)
+
+/*
+RPCPolicy sets the deadline of the calls of the hub to an agent RPC, and how
+many times they are made again when the agent is unavailable. The fields left
+to 0 take the default of the RPC, and a negative value disables the deadline or
+the retries.
+*/
+type RPCPolicy struct {
+	Timeout int `json:"timeout,omitempty"` // seconds
+	Retries int `json:"retries,omitempty"` // only for the idempotent RPCs
+}
+
+// defaultRPCPolicies holds the policy of each unary agent RPC. The streaming
+// RPCs are bounded by the context of the command instead.
+var defaultRPCPolicies = map[string]RPCPolicy{
+	"Stop":                     {Timeout: 60},
+	"Status":                   {Timeout: 30, Retries: 3},
+	"MakeSegment":              {Timeout: 3600},
+	"StartSegment":             {Timeout: constants.DefaultStartTimeout}, // or the timeout of the request
+	"ValidateHostEnv":          {Timeout: 600, Retries: 3},
+	"GetInterfaceAddrs":        {Timeout: 30, Retries: 3},
+	"UpdatePgHbaConfAndReload": {Timeout: 300},
+	"UpdatePgConf":             {Timeout: 300},
+	"PgBasebackup":             {Timeout: 86400}, // copies the whole primary
+	"GetHostName":              {Timeout: 30, Retries: 3},
+	"GetPgHbaConf":             {Timeout: 30, Retries: 3},
+	"ModifyPgHbaConfAndReload": {Timeout: 300},
+	"InstallSslCertificate":    {Timeout: 60, Retries: 3},
+	"ReloadCertificates":       {Timeout: 60, Retries: 3},
+	"GetHostInfo":              {Timeout: 60, Retries: 3},
+	"StartListeners":           {Timeout: 60},
+	"StopListeners":            {Timeout: 60, Retries: 3},
+	"TestConnectivity":         {Timeout: -1}, // the request bounds each connection
+	"TestDiskPerformance":      {Timeout: 60}, // or the time the request allows the test
+}
+
+// The RPCs which can be called again with the same result, e.g. not
+// MakeSegment which fails once the data directory exists
+var idempotentRPCs = map[string]bool{
+	"Status":                true,
+	"ValidateHostEnv":       true,
+	"GetInterfaceAddrs":     true,
+	"GetHostName":           true,
+	"GetPgHbaConf":          true,
+	"InstallSslCertificate": true,
+	"ReloadCertificates":    true,
+	"GetHostInfo":           true,
+	"StopListeners":         true,
+}
+
+// ValidateRPCPolicies checks the policies of gp.conf, which may only retry the
+// idempotent RPCs
+func ValidateRPCPolicies(policies map[string]*RPCPolicy) error {
+	var names []string
+	for name := range policies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if _, ok := defaultRPCPolicies[name]; !ok {
+			return fmt.Errorf("unknown agent RPC %q in the RPC policies", name)
+		}
+		if policies[name] != nil && policies[name].Retries > 0 && !idempotentRPCs[name] {
+			return fmt.Errorf("the agent RPC %s is not idempotent and cannot be retried", name)
+		}
+	}
+
+	return nil
+}
+
+// rpcPolicy returns the policy of the RPC, the default one overridden by the
+// one of gp.conf
+func (s *Server) rpcPolicy(name string) RPCPolicy {
+	policy := defaultRPCPolicies[name]
+	if s.Config == nil {
+		return policy
+	}
+
+	if override := s.AgentRPCs[name]; override != nil {
+		if override.Timeout != 0 {
+			policy.Timeout = override.Timeout
+		}
+		if override.Retries != 0 {
+			policy.Retries = override.Retries
+		}
+	}
+	if !idempotentRPCs[name] {
+		policy.Retries = 0
+	}
+
+	return policy
+}
+
+// timeout returns the deadline of the call, which leaves the agent the time the
+// request asks it to wait for or to run the test
+func (p RPCPolicy) timeout(req interface{}) time.Duration {
+	if p.Timeout < 0 {
+		return 0
+	}
+	timeout := time.Duration(p.Timeout) * time.Second
+
+	switch req := req.(type) {
+	case *idl.StartSegmentRequest:
+		if req.Timeout > 0 {
+			timeout = max(timeout, time.Duration(req.Timeout)*time.Second+AgentWaitGrace)
+		}
+	case *idl.TestDiskPerformanceRequest:
+		// each directory is written and then read for at most the duration
+		duration := time.Duration(req.MaxDuration) * time.Second
+		if duration <= 0 {
+			duration = constants.DefaultDiskTestDuration * time.Second
+		}
+		timeout = max(timeout, 2*duration*time.Duration(len(req.Directories))+AgentWaitGrace)
+	}
+
+	return timeout
+}
+
+/*
+rpcPolicyInterceptor applies the policies of the agent RPCs to the calls made to
+the agent of the host. A call which times out fails with an error naming the
+host and the RPC, and a call which fails as the agent is unavailable is made
+again when the RPC is idempotent.
+*/
+func (s *Server) rpcPolicyInterceptor(host string) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		name := path.Base(method)
+		policy := s.rpcPolicy(name)
+		timeout := policy.timeout(req)
+
+		backoff := AgentRetryBackoff
+		for attempt := 0; ; attempt++ {
+			err := invokeWithTimeout(ctx, timeout, method, req, reply, cc, invoker, opts...)
+			if err == nil {
+				return nil
+			}
+
+			if status.Code(err) == codes.DeadlineExceeded && timeout > 0 && ctx.Err() == nil {
+				return status.Errorf(codes.DeadlineExceeded, "%s on host %s timed out after %s", name, host, timeout)
+			}
+			if status.Code(err) != codes.Unavailable || attempt >= policy.Retries {
+				return err
+			}
+
+			tracing.Verbose(ctx, "%s on host %s failed, retrying in %s: %v", name, host, backoff, err)
+			select {
+			case <-time.After(backoff):
+			case <-ctx.Done():
+				return err
+			}
+			backoff = min(2*backoff, AgentReconnectMaxBackoff)
+		}
+	}
+}
+
+func invokeWithTimeout(ctx context.Context, timeout time.Duration, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	return invoker(ctx, method, req, reply, cc, opts...)
+}
@@ -0,0 +1,229 @@
+package hub_test
+
+import (
+	"context"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/greenplum-db/gp-common-go-libs/testhelper"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/greenplum-db/gpdb/gp/hub"
+	"github.com/greenplum-db/gpdb/gp/idl"
+	"github.com/greenplum-db/gpdb/gp/testutils"
+)
+
+// flakyAgent fails the first calls to Status and MakeSegment as unavailable,
+// and waits before answering StartSegment and TestDiskPerformance
+type flakyAgent struct {
+	idl.UnimplementedAgentServer
+
+	unavailable   int32
+	statusCalls   atomic.Int32
+	makeSegCalls  atomic.Int32
+	makeSegDelay  time.Duration
+	startSegDelay time.Duration
+	diskTestDelay time.Duration
+}
+
+func (a *flakyAgent) Status(ctx context.Context, req *idl.StatusAgentRequest) (*idl.StatusAgentReply, error) {
+	if a.statusCalls.Add(1) <= a.unavailable {
+		return nil, status.Error(codes.Unavailable, "agent unavailable")
+	}
+
+	return &idl.StatusAgentReply{Status: "running"}, nil
+}
+
+func (a *flakyAgent) MakeSegment(ctx context.Context, req *idl.MakeSegmentRequest) (*idl.MakeSegmentReply, error) {
+	if a.makeSegCalls.Add(1) <= a.unavailable {
+		return nil, status.Error(codes.Unavailable, "agent unavailable")
+	}
+
+	select {
+	case <-time.After(a.makeSegDelay):
+		return &idl.MakeSegmentReply{}, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (a *flakyAgent) StartSegment(ctx context.Context, req *idl.StartSegmentRequest) (*idl.StartSegmentReply, error) {
+	time.Sleep(a.startSegDelay)
+
+	return &idl.StartSegmentReply{}, nil
+}
+
+func (a *flakyAgent) TestDiskPerformance(ctx context.Context, req *idl.TestDiskPerformanceRequest) (*idl.TestDiskPerformanceReply, error) {
+	time.Sleep(a.diskTestDelay)
+
+	return &idl.TestDiskPerformanceReply{}, nil
+}
+
+func TestRPCPolicies(t *testing.T) {
+	testhelper.SetupTestLogger()
+
+	hub.AgentRetryBackoff = 10 * time.Millisecond
+	defer func() {
+		hub.AgentRetryBackoff = time.Second
+	}()
+	hub.SetEnsureConnectionsAreReady(func(conns []*hub.Connection) error {
+		return nil
+	})
+	defer hub.ResetEnsureConnectionsAreReady()
+
+	dialAgent := func(t *testing.T, agent *flakyAgent, policies map[string]*hub.RPCPolicy) *hub.Connection {
+		t.Helper()
+
+		listener := bufconn.Listen(1024 * 1024)
+		agentServer := grpc.NewServer()
+		t.Cleanup(agentServer.Stop)
+		idl.RegisterAgentServer(agentServer, agent)
+		go agentServer.Serve(listener) //nolint:errcheck
+
+		hubServer := hub.New(&hub.Config{
+			Hostnames:   []string{"sdw1"},
+			Credentials: &testutils.MockCredentials{TlsConnection: insecure.NewCredentials()},
+			AgentRPCs:   policies,
+		}, func(ctx context.Context, address string) (net.Conn, error) {
+			return listener.Dial()
+		})
+
+		conns, err := hubServer.DialAgents([]string{"sdw1"})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		t.Cleanup(func() {
+			conns[0].Conn.Close()
+		})
+
+		return conns[0]
+	}
+
+	t.Run("retries the idempotent calls when the agent is unavailable", func(t *testing.T) {
+		agent := &flakyAgent{unavailable: 2}
+		conn := dialAgent(t, agent, nil)
+
+		_, err := conn.AgentClient.Status(context.Background(), &idl.StatusAgentRequest{})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		if agent.statusCalls.Load() != 3 {
+			t.Fatalf("got %d calls, want 3", agent.statusCalls.Load())
+		}
+	})
+
+	t.Run("gives up once the retries are exhausted", func(t *testing.T) {
+		agent := &flakyAgent{unavailable: 5}
+		conn := dialAgent(t, agent, map[string]*hub.RPCPolicy{"Status": {Retries: 1}})
+
+		_, err := conn.AgentClient.Status(context.Background(), &idl.StatusAgentRequest{})
+		if status.Code(err) != codes.Unavailable {
+			t.Fatalf("got %v, want the agent to be unavailable", err)
+		}
+		if agent.statusCalls.Load() != 2 {
+			t.Fatalf("got %d calls, want 2", agent.statusCalls.Load())
+		}
+	})
+
+	t.Run("never retries MakeSegment", func(t *testing.T) {
+		agent := &flakyAgent{unavailable: 1}
+		conn := dialAgent(t, agent, nil)
+
+		_, err := conn.AgentClient.MakeSegment(context.Background(), &idl.MakeSegmentRequest{})
+		if status.Code(err) != codes.Unavailable {
+			t.Fatalf("got %v, want the agent to be unavailable", err)
+		}
+		if agent.makeSegCalls.Load() != 1 {
+			t.Fatalf("got %d calls, want 1", agent.makeSegCalls.Load())
+		}
+	})
+
+	t.Run("fails the calls which time out naming the host and the RPC", func(t *testing.T) {
+		agent := &flakyAgent{makeSegDelay: time.Minute}
+		conn := dialAgent(t, agent, map[string]*hub.RPCPolicy{"MakeSegment": {Timeout: 1}})
+
+		_, err := conn.AgentClient.MakeSegment(context.Background(), &idl.MakeSegmentRequest{})
+		expected := "MakeSegment on host sdw1 timed out after 1s"
+		if status.Code(err) != codes.DeadlineExceeded || status.Convert(err).Message() != expected {
+			t.Fatalf("got %v, want %s", err, expected)
+		}
+	})
+
+	t.Run("leaves StartSegment the time its request asks the agent to wait", func(t *testing.T) {
+		hub.AgentWaitGrace = time.Second
+		defer func() {
+			hub.AgentWaitGrace = 30 * time.Second
+		}()
+
+		agent := &flakyAgent{startSegDelay: 1500 * time.Millisecond}
+		conn := dialAgent(t, agent, map[string]*hub.RPCPolicy{"StartSegment": {Timeout: 1}})
+
+		_, err := conn.AgentClient.StartSegment(context.Background(), &idl.StartSegmentRequest{Timeout: 1})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+	})
+
+	t.Run("leaves TestDiskPerformance the time its request allows the test", func(t *testing.T) {
+		hub.AgentWaitGrace = 0
+		defer func() {
+			hub.AgentWaitGrace = 30 * time.Second
+		}()
+
+		agent := &flakyAgent{diskTestDelay: 1500 * time.Millisecond}
+		conn := dialAgent(t, agent, map[string]*hub.RPCPolicy{"TestDiskPerformance": {Timeout: 1}})
+
+		_, err := conn.AgentClient.TestDiskPerformance(context.Background(), &idl.TestDiskPerformanceRequest{
+			Directories: []string{"/data/primary"},
+			MaxDuration: 1,
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+	})
+}
+
+func TestValidateRPCPolicies(t *testing.T) {
+	cases := []struct {
+		name     string
+		policies map[string]*hub.RPCPolicy
+		expected string
+	}{
+		{
+			name:     "accepts the policies of known RPCs",
+			policies: map[string]*hub.RPCPolicy{"MakeSegment": {Timeout: 7200}, "Status": {Retries: 5}},
+		},
+		{
+			name:     "rejects unknown RPCs",
+			policies: map[string]*hub.RPCPolicy{"MakeCluster": {Timeout: 10}},
+			expected: `unknown agent RPC "MakeCluster" in the RPC policies`,
+		},
+		{
+			name:     "rejects the retries of RPCs which are not idempotent",
+			policies: map[string]*hub.RPCPolicy{"MakeSegment": {Retries: 1}},
+			expected: "the agent RPC MakeSegment is not idempotent and cannot be retried",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := hub.ValidateRPCPolicies(tc.policies)
+			if tc.expected == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %#v", err)
+				}
+				return
+			}
+
+			if err == nil || err.Error() != tc.expected {
+				t.Fatalf("got %v, want %s", err, tc.expected)
+			}
+		})
+	}
+}
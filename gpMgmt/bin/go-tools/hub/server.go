@@ -8,6 +8,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -16,6 +17,7 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/reflection"
 	grpcStatus "google.golang.org/grpc/status"
 
@@ -24,6 +26,8 @@ import (
 	"github.com/greenplum-db/gpdb/gp/idl"
 	"github.com/greenplum-db/gpdb/gp/testutils/exectest"
 	"github.com/greenplum-db/gpdb/gp/utils"
+	"github.com/greenplum-db/gpdb/gp/utils/metrics"
+	"github.com/greenplum-db/gpdb/gp/utils/tracing"
 )
 
 var (
@@ -43,7 +47,30 @@ type Config struct {
 	ServiceName string   `json:"serviceName"`
 	GpHome      string   `json:"gphome"`
 
+	// Service manager running the hub and agents, the one of the OS when not set
+	ServiceManager string `json:"serviceManager,omitempty"`
+	// Run the hub and agents as systemd system units instead of user units
+	SystemService *utils.SystemServiceConfig `json:"systemService,omitempty"`
+	// Output of the hub and agents and rotation of their log files, written to
+	// the log directory with the defaults when not set
+	ServiceLog *utils.ServiceLogConfig `json:"serviceLog,omitempty"`
+	// Segment operations run at once on each host and in the cluster, the
+	// defaults when not set
+	Parallelism *ParallelismConfig `json:"parallelism,omitempty"`
+	// Deadlines and retries of the calls to the agents by RPC name, e.g.
+	// MakeSegment, overriding the defaults
+	AgentRPCs map[string]*RPCPolicy `json:"agentRpcs,omitempty"`
+
 	Credentials utils.Credentials
+	// Path to the policy granting roles to the clients of the hub, all clients
+	// are allowed to call every RPC when it is not set
+	AuthorizationPolicy string `json:"authorizationPolicy,omitempty"`
+	// Audit log of the RPCs changing the cluster, enabled with defaults when not set
+	Audit *AuditConfig `json:"audit,omitempty"`
+	// Prometheus endpoints of the hub and agents, not served when not set
+	Metrics *metrics.Config `json:"metrics,omitempty"`
+	// Export of the traces of the CLI, hub and agents, not exported when not set
+	Tracing *tracing.Config `json:"tracing,omitempty"`
 }
 
 type Server struct {
@@ -51,17 +78,33 @@ type Server struct {
 	Conns      []*Connection
 	grpcDialer Dialer
 
-	mutex      sync.Mutex
-	grpcServer *grpc.Server
-	listener   net.Listener
-	finish     chan struct{}
+	// Fingerprint of the client certificate of the hub, read when the
+	// certificates are loaded, and the one the agent connections were made with
+	certFingerprint   string
+	clientFingerprint string
+	// Failed attempts to connect to the agents, by host
+	agentHealth map[string]*agentHealth
+	// Connections to the agents being made, by host
+	pendingDials map[string]*pendingDial
+
+	mutex             sync.Mutex
+	grpcServer        *grpc.Server
+	listener          net.Listener
+	serverCredentials credentials.TransportCredentials
+	policy            *AuthorizationPolicy
+	auditLog          *AuditLog
+	finish            chan struct{}
 }
 
 type Connection struct {
-	Conn          *grpc.ClientConn
-	AgentClient   idl.AgentClient
-	Hostname      string
-	CancelContext func()
+	Conn        *grpc.ClientConn
+	AgentClient idl.AgentClient
+	Hostname    string
+
+	// A retired connection is closed once the calls in flight have finished
+	mutex    sync.Mutex
+	inFlight int
+	retired  bool
 }
 
 func New(conf *Config, grpcDialer Dialer) *Server {
@@ -82,28 +125,78 @@ func (s *Server) Start() error {
 		return fmt.Errorf("could not listen on port %d: %w", s.Port, err)
 	}
 
-	interceptor := func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
-		// handle stuff here if needed
-		return handler(ctx, req)
+	serverCredentials, err := s.Credentials.LoadServerCredentials(utils.RoleHub)
+	if err != nil {
+		return err
+	}
+
+	var policy *AuthorizationPolicy
+	if s.AuthorizationPolicy != "" {
+		policy, err = LoadAuthorizationPolicy(s.AuthorizationPolicy)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = ValidateRPCPolicies(s.AgentRPCs)
+	if err != nil {
+		return err
 	}
 
-	credentials, err := s.Credentials.LoadServerCredentials()
+	auditLog, err := OpenAuditLog(s.AuditLogPath(), s.Audit)
 	if err != nil {
 		return err
 	}
+	defer auditLog.Close()
+
+	if s.Metrics != nil && s.Metrics.HubPort > 0 {
+		metricsServer, err := metrics.Serve(s.Metrics.BindAddress, s.Metrics.HubPort)
+		if err != nil {
+			return err
+		}
+		defer metricsServer.Close()
+
+		collector := agentConnectionsCollector{server: s}
+		err = metrics.Registry.Register(collector)
+		if err != nil {
+			return fmt.Errorf("could not register the agent connection metrics: %w", err)
+		}
+		defer metrics.Registry.Unregister(collector)
+	}
+
+	// Denied calls are audited as well, so authorize them within the audit
 	grpcServer := grpc.NewServer(
-		grpc.Creds(credentials),
-		grpc.UnaryInterceptor(interceptor),
+		grpc.Creds(serverCredentials),
+		grpc.ChainUnaryInterceptor(tracing.UnaryServerInterceptor, metrics.UnaryServerInterceptor, s.auditUnary, s.authorizeUnary),
+		grpc.ChainStreamInterceptor(tracing.StreamServerInterceptor, metrics.StreamServerInterceptor, s.auditStream, s.authorizeStream),
 	)
 
+	fingerprint := s.readClientFingerprint()
+
 	s.mutex.Lock()
 	s.grpcServer = grpcServer
 	s.listener = listener
+	s.serverCredentials = serverCredentials
+	s.certFingerprint = fingerprint
+	s.policy = policy
+	s.auditLog = auditLog
 	s.mutex.Unlock()
 
 	idl.RegisterHubServer(grpcServer, s)
 	reflection.Register(grpcServer)
 
+	stopReload := utils.NotifyOnReload(func() {
+		gplog.Info("Received SIGHUP, reloading certificates")
+		err := s.reloadCertificates()
+		if err != nil {
+			gplog.Error(err.Error())
+		}
+	})
+	defer stopReload()
+
+	stopMonitor := make(chan struct{})
+	go s.monitorAgentConns(stopMonitor)
+
 	wg := sync.WaitGroup{}
 	wg.Add(1)
 	go func() {
@@ -111,6 +204,8 @@ func (s *Server) Start() error {
 		gplog.Info("Received stop command, attempting graceful shutdown")
 		s.grpcServer.GracefulStop()
 		gplog.Info("gRPC server has shut down")
+		close(stopMonitor)
+		s.closeAgentConns()
 		cancel()
 		wg.Done()
 	}()
@@ -173,61 +268,76 @@ func (s *Server) StartAllAgents() error {
 	return nil
 }
 
+// DialAllAgents connects to the agents of all the hosts of the cluster, see
+// DialAgents
 func (s *Server) DialAllAgents() error {
-	s.mutex.Lock()
-	defer s.mutex.Unlock()
+	_, err := s.DialAgents(s.Hostnames)
 
-	if s.Conns != nil {
-		err := ensureConnectionsAreReadyFunc(s.Conns)
-		if err != nil {
-			return err
-		}
+	return err
+}
 
-		return nil
+// readClientFingerprint reads the files of the client certificate of the hub,
+// which is only done when the certificates are loaded rather than with the
+// mutex held
+func (s *Server) readClientFingerprint() string {
+	fingerprint, err := s.Credentials.ClientFingerprint(utils.RoleHub)
+	if err != nil {
+		gplog.Warn("could not read the hub client certificate: %s", err)
 	}
 
-	for _, host := range s.Hostnames {
-		ctx, cancelFunc := context.WithTimeout(context.Background(), DialTimeout)
+	return fingerprint
+}
 
-		credentials, err := s.Credentials.LoadClientCredentials()
-		if err != nil {
-			cancelFunc()
-			return err
-		}
+// retireAgentConns must be called with the mutex held. Calls in flight on the
+// retired connections, such as a running cluster creation, are not interrupted.
+func (s *Server) retireAgentConns() {
+	for _, conn := range s.Conns {
+		conn.retire()
+	}
 
-		address := fmt.Sprintf("%s:%d", host, s.AgentPort)
-		opts := []grpc.DialOption{
-			grpc.WithBlock(),
-			grpc.WithTransportCredentials(credentials),
-			grpc.WithReturnConnectionError(),
-		}
-		if s.grpcDialer != nil {
-			opts = append(opts, grpc.WithContextDialer(s.grpcDialer))
-		}
-		conn, err := grpc.DialContext(ctx, address, opts...)
-		if err != nil {
-			cancelFunc()
-			return fmt.Errorf("could not connect to agent on host %s: %w", host, err)
+	s.Conns = nil
+}
+
+func (c *Connection) trackCall(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	c.mutex.Lock()
+	c.inFlight++
+	c.mutex.Unlock()
+
+	defer func() {
+		c.mutex.Lock()
+		c.inFlight--
+		closeNow := c.retired && c.inFlight == 0
+		c.mutex.Unlock()
+
+		if closeNow {
+			c.close()
 		}
-		s.Conns = append(s.Conns, &Connection{
-			Conn:          conn,
-			AgentClient:   idl.NewAgentClient(conn),
-			Hostname:      host,
-			CancelContext: cancelFunc,
-		})
-	}
+	}()
 
-	err := ensureConnectionsAreReadyFunc(s.Conns)
-	if err != nil {
-		return err
+	return invoker(ctx, method, req, reply, cc, opts...)
+}
+
+// retire closes the connection once the calls in flight have finished
+func (c *Connection) retire() {
+	c.mutex.Lock()
+	c.retired = true
+	closeNow := c.inFlight == 0
+	c.mutex.Unlock()
+
+	if closeNow {
+		c.close()
 	}
+}
 
-	return nil
+func (c *Connection) close() {
+	if c.Conn != nil {
+		c.Conn.Close()
+	}
 }
 
 func (s *Server) StopAgents(ctx context.Context, in *idl.StopAgentsRequest) (*idl.StopAgentsReply, error) {
 	request := func(conn *Connection) error {
-		_, err := conn.AgentClient.Stop(context.Background(), &idl.StopAgentRequest{})
+		_, err := conn.AgentClient.Stop(ctx, &idl.StopAgentRequest{})
 		if err == nil { // no error -> didn't stop
 			return fmt.Errorf("failed to stop agent on host %s", conn.Hostname)
 		}
@@ -240,52 +350,65 @@ func (s *Server) StopAgents(ctx context.Context, in *idl.StopAgentsRequest) (*id
 		return nil
 	}
 
-	err := s.DialAllAgents()
+	conns, err := s.DialAgents(s.Hostnames)
 	if err != nil {
 		return &idl.StopAgentsReply{}, err
 	}
 
-	err = ExecuteRPC(s.Conns, request)
-	s.Conns = nil
+	err = s.executeRPC(ctx, conns, request)
+	s.forgetAgents(s.Hostnames)
 
 	return &idl.StopAgentsReply{}, err
 }
 
+/*
+StatusAgents returns the status of the agents of all hosts. The hosts whose
+status could not be fetched are reported with their error, along with the
+status of the other hosts.
+*/
 func (s *Server) StatusAgents(ctx context.Context, in *idl.StatusAgentsRequest) (*idl.StatusAgentsReply, error) {
-	statusChan := make(chan *idl.ServiceStatus, len(s.Conns))
-
-	request := func(conn *Connection) error {
-		status, err := conn.AgentClient.Status(context.Background(), &idl.StatusAgentRequest{})
+	var mutex sync.Mutex
+	replies := make(map[string]*idl.StatusAgentReply)
+	request := func(ctx context.Context, conn *Connection) error {
+		status, err := conn.AgentClient.Status(ctx, &idl.StatusAgentRequest{})
 		if err != nil {
-			return fmt.Errorf("failed to get agent status on host %s", conn.Hostname)
+			return fmt.Errorf("failed to get agent status: %w", utils.FormatGrpcError(err))
 		}
-		s := idl.ServiceStatus{
-			Host:   conn.Hostname,
-			Status: status.Status,
-			Uptime: status.Uptime,
-			Pid:    status.Pid,
-		}
-		statusChan <- &s
+
+		mutex.Lock()
+		replies[conn.Hostname] = status
+		mutex.Unlock()
 
 		return nil
 	}
 
-	err := s.DialAllAgents()
-	if err != nil {
-		return &idl.StatusAgentsReply{}, err
-	}
-	err = ExecuteRPC(s.Conns, request)
-	if err != nil {
-		return &idl.StatusAgentsReply{}, err
-	}
-	close(statusChan)
+	conns, dialErrs := s.dialAgentsByHost(s.Hostnames)
+	result := FanOut(ctx, conns, BestEffort, request)
 
-	statuses := make([]*idl.ServiceStatus, 0)
-	for status := range statusChan {
-		statuses = append(statuses, status)
+	hosts := uniqueHosts(s.Hostnames)
+	sort.Strings(hosts)
+	statuses := make([]*idl.ServiceStatus, 0, len(hosts))
+	for _, host := range hosts {
+		err := dialErrs[host]
+		if hostResult := result.Host(host); hostResult != nil && hostResult.Err != nil {
+			err = hostResult.Err
+		}
+		if err != nil {
+			tracing.Warn(ctx, "Could not get the status of the agent on host %s: %v", host, err)
+			statuses = append(statuses, &idl.ServiceStatus{Host: host, Status: "Unknown", Error: err.Error()})
+			continue
+		}
+
+		reply := replies[host]
+		statuses = append(statuses, &idl.ServiceStatus{
+			Host:   host,
+			Status: reply.Status,
+			Uptime: reply.Uptime,
+			Pid:    reply.Pid,
+		})
 	}
 
-	return &idl.StatusAgentsReply{Statuses: statuses}, err
+	return &idl.StatusAgentsReply{Statuses: statuses}, nil
 }
 
 func ensureConnectionsAreReady(conns []*Connection) error {
@@ -303,32 +426,12 @@ func ensureConnectionsAreReady(conns []*Connection) error {
 	return nil
 }
 
+// ExecuteRPC makes the request on all the agents in parallel, and returns the
+// errors of all the hosts which failed, see BestEffort
 func ExecuteRPC(agentConns []*Connection, executeRequest func(conn *Connection) error) error {
-	var wg sync.WaitGroup
-	errs := make(chan error, len(agentConns))
-
-	for _, conn := range agentConns {
-		conn := conn
-		wg.Add(1)
-		go func() {
-			defer wg.Done()
-			err := executeRequest(conn)
-			if err != nil {
-				errs <- fmt.Errorf("host: %s, %w", conn.Hostname, err)
-			}
-		}()
-	}
-
-	wg.Wait()
-	close(errs)
-
-	var err error
-	for e := range errs {
-		err = e
-		break
-	}
-
-	return err
+	return FanOut(context.Background(), agentConns, BestEffort, func(ctx context.Context, conn *Connection) error {
+		return executeRequest(conn)
+	}).Err()
 }
 
 func (conf *Config) Load(ConfigFilePath string) error {
@@ -414,6 +517,14 @@ func ResetEnsureConnectionsAreReady() {
 	ensureConnectionsAreReadyFunc = ensureConnectionsAreReady
 }
 
+func SetPlatform(p utils.Platform) {
+	platform = p
+}
+
+func ResetPlatform() {
+	platform = utils.GetPlatform()
+}
+
 func SetExecCommand(command exectest.Command) {
 	execCommand = command
 }
@@ -52,13 +52,13 @@ func TestStartServer(t *testing.T) {
 		credentials := &testutils.MockCredentials{}
 
 		hubConfig := &hub.Config{
-			1234,
-			8080,
-			[]string{host},
-			"/tmp/logDir",
-			"gp",
-			gpHome,
-			credentials,
+			Port:        1234,
+			AgentPort:   8080,
+			Hostnames:   []string{host},
+			LogDir:      t.TempDir(),
+			ServiceName: "gp",
+			GpHome:      gpHome,
+			Credentials: credentials,
 		}
 
 		hubServer := hub.New(hubConfig, nil)
@@ -88,13 +88,13 @@ func TestStartServer(t *testing.T) {
 		}
 
 		hubConfig := &hub.Config{
-			1235,
-			8080,
-			[]string{host},
-			"/tmp/logDir",
-			"gp",
-			gpHome,
-			credentials,
+			Port:        1235,
+			AgentPort:   8080,
+			Hostnames:   []string{host},
+			LogDir:      "/tmp/logDir",
+			ServiceName: "gp",
+			GpHome:      gpHome,
+			Credentials: credentials,
 		}
 		hubServer := hub.New(hubConfig, nil)
 
@@ -132,13 +132,13 @@ func TestStartAgents(t *testing.T) {
 	}()
 
 	hubConfig := &hub.Config{
-		constants.DefaultHubPort,
-		constants.DefaultAgentPort,
-		[]string{"sdw1", "sdw2"},
-		"/tmp/logDir",
-		"gp",
-		"gpHome",
-		credentials,
+		Port:        constants.DefaultHubPort,
+		AgentPort:   constants.DefaultAgentPort,
+		Hostnames:   []string{"sdw1", "sdw2"},
+		LogDir:      "/tmp/logDir",
+		ServiceName: "gp",
+		GpHome:      "gpHome",
+		Credentials: credentials,
 	}
 
 	t.Run("successfully starts the agents from hub", func(t *testing.T) {
@@ -175,16 +175,16 @@ func TestDialAllAgents(t *testing.T) {
 	}()
 
 	hubConfig := &hub.Config{
-		constants.DefaultHubPort,
-		constants.DefaultAgentPort,
-		[]string{"sdw1", "sdw2"},
-		"/tmp/logDir",
-		"gp",
-		"gpHome",
-		credentials,
+		Port:        constants.DefaultHubPort,
+		AgentPort:   constants.DefaultAgentPort,
+		Hostnames:   []string{"sdw1", "sdw2"},
+		LogDir:      "/tmp/logDir",
+		ServiceName: "gp",
+		GpHome:      "gpHome",
+		Credentials: credentials,
 	}
 
-	t.Run("successfully establishes connections to agent hosts and errors out when some of the connections are not ready", func(t *testing.T) {
+	t.Run("successfully establishes connections to agent hosts and reconnects the lost ones", func(t *testing.T) {
 
 		dialer := func(ctx context.Context, address string) (net.Conn, error) {
 			return listener.Dial()
@@ -215,16 +215,51 @@ func TestDialAllAgents(t *testing.T) {
 		}
 
 		// close one of the connections
+		var lost, kept *hub.Connection
 		for _, conn := range hubServer.Conns {
 			if conn.Hostname == "sdw2" {
+				lost = conn
 				conn.Conn.Close()
+			} else {
+				kept = conn
 			}
 		}
 
 		err = hubServer.DialAllAgents()
-		expectedErr := "could not ensure connections were ready: unready hosts: sdw2"
-		if err.Error() != expectedErr {
-			t.Fatalf("got %v, want %v", err.Error(), expectedErr)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		if len(hubServer.Conns) != 2 {
+			t.Fatalf("got %d connections, want 2", len(hubServer.Conns))
+		}
+		for _, conn := range hubServer.Conns {
+			if conn == lost || conn.Conn.GetState() != connectivity.Ready {
+				t.Fatalf("expected the connection to %s to be made again, got state %v", conn.Hostname, conn.Conn.GetState())
+			}
+			if conn.Hostname == "sdw1" && conn != kept {
+				t.Fatalf("expected the connection to sdw1 to be kept")
+			}
+		}
+	})
+
+	t.Run("only connects to the agents of the targeted hosts", func(t *testing.T) {
+		dialer := func(ctx context.Context, address string) (net.Conn, error) {
+			if strings.HasPrefix(address, "sdw2") {
+				return nil, errors.New("error")
+			}
+
+			return listener.Dial()
+		}
+
+		hubServer := hub.New(hubConfig, dialer)
+		conns, err := hubServer.DialAgents([]string{"sdw1"})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		if len(conns) != 1 || conns[0].Hostname != "sdw1" {
+			t.Fatalf("got %+v, want the connection to sdw1", conns)
 		}
 	})
 
@@ -251,13 +286,13 @@ func TestStatusAgents(t *testing.T) {
 
 	credentials := &testutils.MockCredentials{TlsConnection: insecure.NewCredentials()}
 	hubConfig := &hub.Config{
-		constants.DefaultHubPort,
-		constants.DefaultAgentPort,
-		[]string{"sdw1", "sdw2"},
-		"/tmp/logDir",
-		"gp",
-		"gpHome",
-		credentials,
+		Port:        constants.DefaultHubPort,
+		AgentPort:   constants.DefaultAgentPort,
+		Hostnames:   []string{"sdw1", "sdw2"},
+		LogDir:      "/tmp/logDir",
+		ServiceName: "gp",
+		GpHome:      "gpHome",
+		Credentials: credentials,
 	}
 	hubServer := hub.New(hubConfig, nil)
 
@@ -305,8 +340,8 @@ func TestStatusAgents(t *testing.T) {
 
 		expected := &idl.StatusAgentsReply{
 			Statuses: []*idl.ServiceStatus{
-				{Host: "sdw2", Status: "running", Uptime: "2H", Pid: 456},
 				{Host: "sdw1", Status: "running", Uptime: "5H", Pid: 123},
+				{Host: "sdw2", Status: "running", Uptime: "2H", Pid: 456},
 			},
 		}
 		if !reflect.DeepEqual(result, expected) {
@@ -314,7 +349,7 @@ func TestStatusAgents(t *testing.T) {
 		}
 	})
 
-	t.Run("errors out when not able to get the status from one of the hosts", func(t *testing.T) {
+	t.Run("returns the status of the other hosts when not able to get the status from one of the hosts", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 
@@ -346,10 +381,19 @@ func TestStatusAgents(t *testing.T) {
 		}
 		hubServer.Conns = agentConns
 
-		_, err := hubServer.StatusAgents(context.Background(), &idl.StatusAgentsRequest{})
-		expectedErr := "failed to get agent status on host sdw2"
-		if err == nil || !strings.Contains(err.Error(), expectedErr) {
-			t.Fatalf("got %v, want %v", err, expectedErr)
+		result, err := hubServer.StatusAgents(context.Background(), &idl.StatusAgentsRequest{})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		expected := &idl.StatusAgentsReply{
+			Statuses: []*idl.ServiceStatus{
+				{Host: "sdw1", Status: "running", Uptime: "5H", Pid: 123},
+				{Host: "sdw2", Status: "Unknown", Error: "failed to get agent status: error"},
+			},
+		}
+		if !reflect.DeepEqual(result, expected) {
+			t.Fatalf("got %+v, want %+v", result, expected)
 		}
 	})
 }
@@ -359,13 +403,13 @@ func TestStopAgents(t *testing.T) {
 
 	credentials := &testutils.MockCredentials{TlsConnection: insecure.NewCredentials()}
 	hubConfig := &hub.Config{
-		constants.DefaultHubPort,
-		constants.DefaultAgentPort,
-		[]string{"sdw1", "sdw2"},
-		"/tmp/logDir",
-		"gp",
-		"gpHome",
-		credentials,
+		Port:        constants.DefaultHubPort,
+		AgentPort:   constants.DefaultAgentPort,
+		Hostnames:   []string{"sdw1", "sdw2"},
+		LogDir:      "/tmp/logDir",
+		ServiceName: "gp",
+		GpHome:      "gpHome",
+		Credentials: credentials,
 	}
 	hubServer := hub.New(hubConfig, nil)
 
@@ -0,0 +1,63 @@
+package hub
+
+import (
+	"fmt"
+
+	"golang.org/x/exp/maps"
+
+	"github.com/greenplum-db/gpdb/gp/idl"
+	"github.com/greenplum-db/gpdb/gp/utils"
+	"github.com/greenplum-db/gpdb/gp/utils/postgres"
+)
+
+// LoadSslCertificate reads the server certificate and key configured for the
+// host along with the CA certificate, so that they can be sent to the agent.
+// The default certificate is used for hosts without their own certificate.
+func LoadSslCertificate(ssl *idl.SslParams, hostname string) (*idl.SslCertificate, error) {
+	certFiles, ok := ssl.GetHostCerts()[hostname]
+	if !ok {
+		certFiles = ssl.GetDefaultCert()
+	}
+	if certFiles.GetCertFile() == "" || certFiles.GetKeyFile() == "" {
+		return nil, fmt.Errorf("no SSL certificate configured for host %s", hostname)
+	}
+
+	cert, err := utils.System.ReadFile(certFiles.CertFile)
+	if err != nil {
+		return nil, fmt.Errorf("reading SSL certificate for host %s: %w", hostname, err)
+	}
+
+	key, err := utils.System.ReadFile(certFiles.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("reading SSL key for host %s: %w", hostname, err)
+	}
+
+	var ca []byte
+	if ssl.CaFile != "" {
+		ca, err = utils.System.ReadFile(ssl.CaFile)
+		if err != nil {
+			return nil, fmt.Errorf("reading SSL CA certificate: %w", err)
+		}
+	}
+
+	return &idl.SslCertificate{Cert: cert, Key: key, Ca: ca}, nil
+}
+
+// setSslConfig adds the certificate of the segment host and the ssl parameters
+// to the segment request when SSL is enabled
+func setSslConfig(req *idl.MakeSegmentRequest, ssl *idl.SslParams) error {
+	if !ssl.GetEnabled() {
+		return nil
+	}
+
+	cert, err := LoadSslCertificate(ssl, req.Segment.HostName)
+	if err != nil {
+		return err
+	}
+
+	req.SslCertificate = cert
+	req.HbaHostssl = ssl.HbaHostssl
+	maps.Copy(req.SegConfig, postgres.SslConfigParams(len(cert.Ca) > 0))
+
+	return nil
+}
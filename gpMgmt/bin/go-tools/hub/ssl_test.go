@@ -0,0 +1,160 @@
+package hub_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/greenplum-db/gpdb/gp/hub"
+	"github.com/greenplum-db/gpdb/gp/idl"
+	"github.com/greenplum-db/gpdb/gp/idl/mock_idl"
+	"github.com/greenplum-db/gpdb/gp/utils"
+)
+
+func mockSslFiles(t *testing.T, files map[string]string) {
+	t.Helper()
+
+	utils.System.ReadFile = func(name string) ([]byte, error) {
+		content, ok := files[name]
+		if !ok {
+			return nil, os.ErrNotExist
+		}
+		return []byte(content), nil
+	}
+}
+
+func TestLoadSslCertificate(t *testing.T) {
+	ssl := &idl.SslParams{
+		Enabled:     true,
+		CaFile:      "/certs/ca.crt",
+		DefaultCert: &idl.SslCertFiles{CertFile: "/certs/server.crt", KeyFile: "/certs/server.key"},
+		HostCerts: map[string]*idl.SslCertFiles{
+			"sdw1": {CertFile: "/certs/sdw1.crt", KeyFile: "/certs/sdw1.key"},
+		},
+	}
+
+	files := map[string]string{
+		"/certs/ca.crt":     "ca",
+		"/certs/server.crt": "cert",
+		"/certs/server.key": "key",
+		"/certs/sdw1.crt":   "sdw1 cert",
+		"/certs/sdw1.key":   "sdw1 key",
+	}
+
+	t.Run("loads the certificate of the host", func(t *testing.T) {
+		mockSslFiles(t, files)
+		defer utils.ResetSystemFunctions()
+
+		result, err := hub.LoadSslCertificate(ssl, "sdw1")
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		expected := &idl.SslCertificate{Cert: []byte("sdw1 cert"), Key: []byte("sdw1 key"), Ca: []byte("ca")}
+		if !reflect.DeepEqual(result, expected) {
+			t.Fatalf("got %+v, want %+v", result, expected)
+		}
+	})
+
+	t.Run("loads the default certificate for hosts without their own", func(t *testing.T) {
+		mockSslFiles(t, files)
+		defer utils.ResetSystemFunctions()
+
+		result, err := hub.LoadSslCertificate(ssl, "sdw2")
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		expected := &idl.SslCertificate{Cert: []byte("cert"), Key: []byte("key"), Ca: []byte("ca")}
+		if !reflect.DeepEqual(result, expected) {
+			t.Fatalf("got %+v, want %+v", result, expected)
+		}
+	})
+
+	t.Run("errors out when there is no certificate for the host", func(t *testing.T) {
+		_, err := hub.LoadSslCertificate(&idl.SslParams{Enabled: true}, "sdw2")
+
+		expected := "no SSL certificate configured for host sdw2"
+		if err == nil || err.Error() != expected {
+			t.Fatalf("got %v, want %s", err, expected)
+		}
+	})
+
+	t.Run("errors out when not able to read the files", func(t *testing.T) {
+		mockSslFiles(t, map[string]string{"/certs/sdw1.crt": "sdw1 cert"})
+		defer utils.ResetSystemFunctions()
+
+		_, err := hub.LoadSslCertificate(ssl, "sdw1")
+		if !errors.Is(err, os.ErrNotExist) {
+			t.Fatalf("got %#v, want %#v", err, os.ErrNotExist)
+		}
+
+		expected := "reading SSL key for host sdw1"
+		if !strings.HasPrefix(err.Error(), expected) {
+			t.Fatalf("got %v, want prefix %s", err, expected)
+		}
+	})
+}
+
+func TestCreateSingleSegmentWithSsl(t *testing.T) {
+	ssl := &idl.SslParams{
+		Enabled:     true,
+		DefaultCert: &idl.SslCertFiles{CertFile: "/certs/server.crt", KeyFile: "/certs/server.key"},
+		HbaHostssl:  true,
+	}
+
+	t.Run("sends the certificate and the ssl parameters to the agent", func(t *testing.T) {
+		mockSslFiles(t, map[string]string{
+			"/certs/server.crt": "cert",
+			"/certs/server.key": "key",
+		})
+		defer utils.ResetSystemFunctions()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		seg := &idl.Segment{HostName: "sdw1", DataDirectory: "/gpseg0", Contentid: 0}
+		sdw1 := mock_idl.NewMockAgentClient(ctrl)
+		sdw1.EXPECT().MakeSegment(gomock.Any(), &idl.MakeSegmentRequest{
+			Segment: seg,
+			SegConfig: map[string]string{
+				"key1":          "value1",
+				"ssl":           "on",
+				"ssl_cert_file": "server.crt",
+				"ssl_key_file":  "server.key",
+			},
+			SslCertificate: &idl.SslCertificate{Cert: []byte("cert"), Key: []byte("key")},
+			HbaHostssl:     true,
+		}).Return(&idl.MakeSegmentReply{}, nil)
+
+		clusterParams := &idl.ClusterParams{
+			CommonConfig: map[string]string{"key1": "value1"},
+			Ssl:          ssl,
+		}
+		err := hub.CreateSingleSegment(context.Background(), &hub.Connection{AgentClient: sdw1, Hostname: "sdw1"}, seg, clusterParams, nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+	})
+
+	t.Run("does not create the segment when not able to load the certificate", func(t *testing.T) {
+		mockSslFiles(t, map[string]string{})
+		defer utils.ResetSystemFunctions()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		seg := &idl.Segment{HostName: "sdw1", DataDirectory: "/gpseg0", Contentid: 0}
+		sdw1 := mock_idl.NewMockAgentClient(ctrl)
+
+		err := hub.CreateSingleSegment(context.Background(), &hub.Connection{AgentClient: sdw1, Hostname: "sdw1"}, seg, &idl.ClusterParams{Ssl: ssl}, nil)
+		if !errors.Is(err, os.ErrNotExist) {
+			t.Fatalf("got %#v, want %#v", err, os.ErrNotExist)
+		}
+	})
+}
@@ -1,10 +1,14 @@
 package hub
 
 import (
+	"context"
 	"os/exec"
+	"path/filepath"
+
+	"go.opentelemetry.io/otel/attribute"
 
-	"github.com/greenplum-db/gp-common-go-libs/gplog"
 	"github.com/greenplum-db/gpdb/gp/idl"
+	"github.com/greenplum-db/gpdb/gp/utils/tracing"
 )
 
 // Common interface for all hub side streaming RPC servers
@@ -17,6 +21,7 @@ type hubStreamer interface {
 	StreamStdoutMsg(msg string)
 	StreamExecCommand(cmd *exec.Cmd, gpHome string) error
 	StreamProgressMsg(label string, total int)
+	Context() context.Context
 }
 
 type HubStream struct {
@@ -33,6 +38,15 @@ func (h *HubStream) GetHandler() streamSender {
 	return h.handler
 }
 
+// Context returns the context of the streaming RPC, which holds its trace
+func (h *HubStream) Context() context.Context {
+	if s, ok := h.handler.(interface{ Context() context.Context }); ok {
+		return s.Context()
+	}
+
+	return context.Background()
+}
+
 /*
 StreamLogMsg streams a log message from hub to the CLI.
 Default log level is set to INFO
@@ -56,7 +70,7 @@ func (h *HubStream) StreamLogMsg(msg string, level ...idl.LogLevel) {
 
 	err := h.handler.Send(message)
 	if err != nil {
-		gplog.Error("unable to stream message %q: %s", message, err)
+		tracing.Error(h.Context(), "unable to stream message %q: %s", message, err)
 	}
 }
 
@@ -73,7 +87,7 @@ func (h *HubStream) StreamStdoutMsg(msg string) {
 
 	err := h.handler.Send(message)
 	if err != nil {
-		gplog.Error("unable to stream message %q: %s", message, err)
+		tracing.Error(h.Context(), "unable to stream message %q: %s", message, err)
 	}
 }
 
@@ -81,7 +95,10 @@ func (h *HubStream) StreamStdoutMsg(msg string) {
 StreamExecCommand runs the given exec.Cmd and streams its
 stdout and stderr from hub to the CLI
 */
-func (h *HubStream) StreamExecCommand(cmd *exec.Cmd, gpHome string) error {
+func (h *HubStream) StreamExecCommand(cmd *exec.Cmd, gpHome string) (err error) {
+	ctx, span := tracing.StartSpan(h.Context(), "exec "+filepath.Base(cmd.Path), attribute.String("gp.command", cmd.String()))
+	defer func() { tracing.EndSpan(span, err) }()
+
 	stdout, err := cmd.StdoutPipe()
 	if err != nil {
 		return err
@@ -92,7 +109,7 @@ func (h *HubStream) StreamExecCommand(cmd *exec.Cmd, gpHome string) error {
 		return err
 	}
 
-	gplog.Verbose("Executing command: %s", cmd.String())
+	tracing.Verbose(ctx, "Executing command: %s", cmd.String())
 	if err := cmd.Start(); err != nil {
 		return err
 	}
@@ -152,6 +169,6 @@ func (h *HubStream) StreamProgressMsg(label string, total int) {
 
 	err := h.handler.Send(message)
 	if err != nil {
-		gplog.Error("unable to stream message %q: %s", message, err)
+		tracing.Error(h.Context(), "unable to stream message %q: %s", message, err)
 	}
 }
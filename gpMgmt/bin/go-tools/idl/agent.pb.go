@@ -462,6 +462,11 @@ type MakeSegmentRequest struct {
 	CoordinatorAddrs     []string          `protobuf:"bytes,5,rep,name=coordinatorAddrs,proto3" json:"coordinatorAddrs,omitempty"`
 	HbaHostNames         bool              `protobuf:"varint,6,opt,name=hbaHostNames,proto3" json:"hbaHostNames,omitempty"`
 	DataChecksums        bool              `protobuf:"varint,7,opt,name=dataChecksums,proto3" json:"dataChecksums,omitempty"`
+	HbaAuthMethod        string            `protobuf:"bytes,8,opt,name=hbaAuthMethod,proto3" json:"hbaAuthMethod,omitempty"`
+	HbaUserAuthMethod    string            `protobuf:"bytes,9,opt,name=hbaUserAuthMethod,proto3" json:"hbaUserAuthMethod,omitempty"`
+	HbaUserAddrs         []string          `protobuf:"bytes,10,rep,name=hbaUserAddrs,proto3" json:"hbaUserAddrs,omitempty"`
+	SslCertificate       *SslCertificate   `protobuf:"bytes,11,opt,name=sslCertificate,proto3" json:"sslCertificate,omitempty"`
+	HbaHostssl           bool              `protobuf:"varint,12,opt,name=hbaHostssl,proto3" json:"hbaHostssl,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
 	XXX_unrecognized     []byte            `json:"-"`
 	XXX_sizecache        int32             `json:"-"`
@@ -541,6 +546,96 @@ func (m *MakeSegmentRequest) GetDataChecksums() bool {
 	return false
 }
 
+func (m *MakeSegmentRequest) GetHbaAuthMethod() string {
+	if m != nil {
+		return m.HbaAuthMethod
+	}
+	return ""
+}
+
+func (m *MakeSegmentRequest) GetHbaUserAuthMethod() string {
+	if m != nil {
+		return m.HbaUserAuthMethod
+	}
+	return ""
+}
+
+func (m *MakeSegmentRequest) GetHbaUserAddrs() []string {
+	if m != nil {
+		return m.HbaUserAddrs
+	}
+	return nil
+}
+
+func (m *MakeSegmentRequest) GetSslCertificate() *SslCertificate {
+	if m != nil {
+		return m.SslCertificate
+	}
+	return nil
+}
+
+func (m *MakeSegmentRequest) GetHbaHostssl() bool {
+	if m != nil {
+		return m.HbaHostssl
+	}
+	return false
+}
+
+type SslCertificate struct {
+	Cert                 []byte   `protobuf:"bytes,1,opt,name=cert,proto3" json:"cert,omitempty"`
+	Key                  []byte   `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	Ca                   []byte   `protobuf:"bytes,3,opt,name=ca,proto3" json:"ca,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SslCertificate) Reset()         { *m = SslCertificate{} }
+func (m *SslCertificate) String() string { return proto.CompactTextString(m) }
+func (*SslCertificate) ProtoMessage()    {}
+func (*SslCertificate) Descriptor() ([]byte, []int) {
+	return fileDescriptor_56ede974c0020f77, []int{11}
+}
+
+func (m *SslCertificate) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SslCertificate.Unmarshal(m, b)
+}
+func (m *SslCertificate) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SslCertificate.Marshal(b, m, deterministic)
+}
+func (m *SslCertificate) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SslCertificate.Merge(m, src)
+}
+func (m *SslCertificate) XXX_Size() int {
+	return xxx_messageInfo_SslCertificate.Size(m)
+}
+func (m *SslCertificate) XXX_DiscardUnknown() {
+	xxx_messageInfo_SslCertificate.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SslCertificate proto.InternalMessageInfo
+
+func (m *SslCertificate) GetCert() []byte {
+	if m != nil {
+		return m.Cert
+	}
+	return nil
+}
+
+func (m *SslCertificate) GetKey() []byte {
+	if m != nil {
+		return m.Key
+	}
+	return nil
+}
+
+func (m *SslCertificate) GetCa() []byte {
+	if m != nil {
+		return m.Ca
+	}
+	return nil
+}
+
 type MakeSegmentReply struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -551,7 +646,7 @@ func (m *MakeSegmentReply) Reset()         { *m = MakeSegmentReply{} }
 func (m *MakeSegmentReply) String() string { return proto.CompactTextString(m) }
 func (*MakeSegmentReply) ProtoMessage()    {}
 func (*MakeSegmentReply) Descriptor() ([]byte, []int) {
-	return fileDescriptor_56ede974c0020f77, []int{11}
+	return fileDescriptor_56ede974c0020f77, []int{12}
 }
 
 func (m *MakeSegmentReply) XXX_Unmarshal(b []byte) error {
@@ -582,7 +677,7 @@ func (m *GetInterfaceAddrsRequest) Reset()         { *m = GetInterfaceAddrsReque
 func (m *GetInterfaceAddrsRequest) String() string { return proto.CompactTextString(m) }
 func (*GetInterfaceAddrsRequest) ProtoMessage()    {}
 func (*GetInterfaceAddrsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_56ede974c0020f77, []int{12}
+	return fileDescriptor_56ede974c0020f77, []int{13}
 }
 
 func (m *GetInterfaceAddrsRequest) XXX_Unmarshal(b []byte) error {
@@ -614,7 +709,7 @@ func (m *GetInterfaceAddrsResponse) Reset()         { *m = GetInterfaceAddrsResp
 func (m *GetInterfaceAddrsResponse) String() string { return proto.CompactTextString(m) }
 func (*GetInterfaceAddrsResponse) ProtoMessage()    {}
 func (*GetInterfaceAddrsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_56ede974c0020f77, []int{13}
+	return fileDescriptor_56ede974c0020f77, []int{14}
 }
 
 func (m *GetInterfaceAddrsResponse) XXX_Unmarshal(b []byte) error {
@@ -643,19 +738,22 @@ func (m *GetInterfaceAddrsResponse) GetAddrs() []string {
 }
 
 type UpdatePgHbaConfRequest struct {
-	Pgdata               string   `protobuf:"bytes,1,opt,name=pgdata,proto3" json:"pgdata,omitempty"`
-	Addrs                []string `protobuf:"bytes,2,rep,name=addrs,proto3" json:"addrs,omitempty"`
-	Replication          bool     `protobuf:"varint,3,opt,name=replication,proto3" json:"replication,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Pgdata                string   `protobuf:"bytes,1,opt,name=pgdata,proto3" json:"pgdata,omitempty"`
+	Addrs                 []string `protobuf:"bytes,2,rep,name=addrs,proto3" json:"addrs,omitempty"`
+	Replication           bool     `protobuf:"varint,3,opt,name=replication,proto3" json:"replication,omitempty"`
+	AuthMethod            string   `protobuf:"bytes,4,opt,name=authMethod,proto3" json:"authMethod,omitempty"`
+	Hostssl               bool     `protobuf:"varint,5,opt,name=hostssl,proto3" json:"hostssl,omitempty"`
+	ReplicationAuthMethod string   `protobuf:"bytes,6,opt,name=replicationAuthMethod,proto3" json:"replicationAuthMethod,omitempty"`
+	XXX_NoUnkeyedLiteral  struct{} `json:"-"`
+	XXX_unrecognized      []byte   `json:"-"`
+	XXX_sizecache         int32    `json:"-"`
 }
 
 func (m *UpdatePgHbaConfRequest) Reset()         { *m = UpdatePgHbaConfRequest{} }
 func (m *UpdatePgHbaConfRequest) String() string { return proto.CompactTextString(m) }
 func (*UpdatePgHbaConfRequest) ProtoMessage()    {}
 func (*UpdatePgHbaConfRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_56ede974c0020f77, []int{14}
+	return fileDescriptor_56ede974c0020f77, []int{15}
 }
 
 func (m *UpdatePgHbaConfRequest) XXX_Unmarshal(b []byte) error {
@@ -697,6 +795,27 @@ func (m *UpdatePgHbaConfRequest) GetReplication() bool {
 	return false
 }
 
+func (m *UpdatePgHbaConfRequest) GetAuthMethod() string {
+	if m != nil {
+		return m.AuthMethod
+	}
+	return ""
+}
+
+func (m *UpdatePgHbaConfRequest) GetHostssl() bool {
+	if m != nil {
+		return m.Hostssl
+	}
+	return false
+}
+
+func (m *UpdatePgHbaConfRequest) GetReplicationAuthMethod() string {
+	if m != nil {
+		return m.ReplicationAuthMethod
+	}
+	return ""
+}
+
 type UpdatePgHbaConfResponse struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -707,7 +826,7 @@ func (m *UpdatePgHbaConfResponse) Reset()         { *m = UpdatePgHbaConfResponse
 func (m *UpdatePgHbaConfResponse) String() string { return proto.CompactTextString(m) }
 func (*UpdatePgHbaConfResponse) ProtoMessage()    {}
 func (*UpdatePgHbaConfResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_56ede974c0020f77, []int{15}
+	return fileDescriptor_56ede974c0020f77, []int{16}
 }
 
 func (m *UpdatePgHbaConfResponse) XXX_Unmarshal(b []byte) error {
@@ -741,7 +860,7 @@ func (m *UpdatePgConfRequest) Reset()         { *m = UpdatePgConfRequest{} }
 func (m *UpdatePgConfRequest) String() string { return proto.CompactTextString(m) }
 func (*UpdatePgConfRequest) ProtoMessage()    {}
 func (*UpdatePgConfRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_56ede974c0020f77, []int{16}
+	return fileDescriptor_56ede974c0020f77, []int{17}
 }
 
 func (m *UpdatePgConfRequest) XXX_Unmarshal(b []byte) error {
@@ -793,7 +912,7 @@ func (m *UpdatePgConfRespoonse) Reset()         { *m = UpdatePgConfRespoonse{} }
 func (m *UpdatePgConfRespoonse) String() string { return proto.CompactTextString(m) }
 func (*UpdatePgConfRespoonse) ProtoMessage()    {}
 func (*UpdatePgConfRespoonse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_56ede974c0020f77, []int{17}
+	return fileDescriptor_56ede974c0020f77, []int{18}
 }
 
 func (m *UpdatePgConfRespoonse) XXX_Unmarshal(b []byte) error {
@@ -833,7 +952,7 @@ func (m *PgBasebackupRequest) Reset()         { *m = PgBasebackupRequest{} }
 func (m *PgBasebackupRequest) String() string { return proto.CompactTextString(m) }
 func (*PgBasebackupRequest) ProtoMessage()    {}
 func (*PgBasebackupRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_56ede974c0020f77, []int{18}
+	return fileDescriptor_56ede974c0020f77, []int{19}
 }
 
 func (m *PgBasebackupRequest) XXX_Unmarshal(b []byte) error {
@@ -927,7 +1046,7 @@ func (m *PgBasebackupResponse) Reset()         { *m = PgBasebackupResponse{} }
 func (m *PgBasebackupResponse) String() string { return proto.CompactTextString(m) }
 func (*PgBasebackupResponse) ProtoMessage()    {}
 func (*PgBasebackupResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_56ede974c0020f77, []int{19}
+	return fileDescriptor_56ede974c0020f77, []int{20}
 }
 
 func (m *PgBasebackupResponse) XXX_Unmarshal(b []byte) error {
@@ -948,237 +1067,1522 @@ func (m *PgBasebackupResponse) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_PgBasebackupResponse proto.InternalMessageInfo
 
-func init() {
-	proto.RegisterType((*GetHostNameReply)(nil), "idl.GetHostNameReply")
-	proto.RegisterType((*GetHostNameRequest)(nil), "idl.GetHostNameRequest")
-	proto.RegisterType((*StartSegmentRequest)(nil), "idl.StartSegmentRequest")
-	proto.RegisterType((*StartSegmentReply)(nil), "idl.StartSegmentReply")
-	proto.RegisterType((*StopAgentRequest)(nil), "idl.StopAgentRequest")
-	proto.RegisterType((*StopAgentReply)(nil), "idl.StopAgentReply")
-	proto.RegisterType((*StatusAgentRequest)(nil), "idl.StatusAgentRequest")
-	proto.RegisterType((*StatusAgentReply)(nil), "idl.StatusAgentReply")
-	proto.RegisterType((*ValidateHostEnvRequest)(nil), "idl.ValidateHostEnvRequest")
-	proto.RegisterType((*ValidateHostEnvReply)(nil), "idl.ValidateHostEnvReply")
-	proto.RegisterType((*MakeSegmentRequest)(nil), "idl.MakeSegmentRequest")
-	proto.RegisterMapType((map[string]string)(nil), "idl.MakeSegmentRequest.SegConfigEntry")
-	proto.RegisterType((*MakeSegmentReply)(nil), "idl.MakeSegmentReply")
-	proto.RegisterType((*GetInterfaceAddrsRequest)(nil), "idl.GetInterfaceAddrsRequest")
-	proto.RegisterType((*GetInterfaceAddrsResponse)(nil), "idl.GetInterfaceAddrsResponse")
-	proto.RegisterType((*UpdatePgHbaConfRequest)(nil), "idl.UpdatePgHbaConfRequest")
-	proto.RegisterType((*UpdatePgHbaConfResponse)(nil), "idl.UpdatePgHbaConfResponse")
-	proto.RegisterType((*UpdatePgConfRequest)(nil), "idl.UpdatePgConfRequest")
-	proto.RegisterMapType((map[string]string)(nil), "idl.UpdatePgConfRequest.ParamsEntry")
-	proto.RegisterType((*UpdatePgConfRespoonse)(nil), "idl.UpdatePgConfRespoonse")
-	proto.RegisterType((*PgBasebackupRequest)(nil), "idl.PgBasebackupRequest")
-	proto.RegisterType((*PgBasebackupResponse)(nil), "idl.PgBasebackupResponse")
+type GetPgHbaConfRequest struct {
+	Pgdata               string   `protobuf:"bytes,1,opt,name=pgdata,proto3" json:"pgdata,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func init() { proto.RegisterFile("agent.proto", fileDescriptor_56ede974c0020f77) }
+func (m *GetPgHbaConfRequest) Reset()         { *m = GetPgHbaConfRequest{} }
+func (m *GetPgHbaConfRequest) String() string { return proto.CompactTextString(m) }
+func (*GetPgHbaConfRequest) ProtoMessage()    {}
+func (*GetPgHbaConfRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_56ede974c0020f77, []int{21}
+}
 
-var fileDescriptor_56ede974c0020f77 = []byte{
-	// 1049 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x56, 0x5b, 0x6f, 0xe3, 0x44,
-	0x14, 0x6e, 0xd2, 0x26, 0x9b, 0x9c, 0x74, 0x7b, 0x99, 0xb4, 0xa9, 0x6b, 0xca, 0x2a, 0x32, 0xab,
-	0x2a, 0x02, 0x14, 0x20, 0xf0, 0x00, 0xab, 0x95, 0x50, 0x6f, 0xec, 0x22, 0x76, 0x21, 0x72, 0x96,
-	0x45, 0xe2, 0x6d, 0x62, 0x4f, 0x1d, 0xab, 0x8e, 0xc7, 0xcc, 0x8c, 0x5b, 0xf2, 0xff, 0x78, 0xe2,
-	0x4f, 0xec, 0xcf, 0xe0, 0x15, 0xcd, 0xc5, 0x89, 0x6f, 0x95, 0xe0, 0xcd, 0xe7, 0x3b, 0x67, 0xce,
-	0x9c, 0xdb, 0x77, 0xc6, 0xd0, 0xc3, 0x01, 0x89, 0xc5, 0x38, 0x61, 0x54, 0x50, 0xb4, 0x1d, 0xfa,
-	0x91, 0xdd, 0x5d, 0xa4, 0x73, 0x2d, 0x3b, 0x63, 0x38, 0x78, 0x45, 0xc4, 0x6b, 0xca, 0xc5, 0xcf,
-	0x78, 0x49, 0x5c, 0x92, 0x44, 0x2b, 0x64, 0x43, 0x67, 0x41, 0xb9, 0x88, 0xf1, 0x92, 0x58, 0x8d,
-	0x61, 0x63, 0xd4, 0x75, 0xd7, 0xb2, 0x73, 0x04, 0xa8, 0x60, 0xff, 0x47, 0x4a, 0xb8, 0x70, 0x1e,
-	0xa0, 0x3f, 0x13, 0x98, 0x89, 0x19, 0x09, 0x96, 0x24, 0x16, 0x06, 0x46, 0x16, 0x3c, 0xf1, 0xb1,
-	0xc0, 0xd7, 0x21, 0x33, 0x7e, 0x32, 0x11, 0x21, 0xd8, 0x79, 0xc0, 0xa1, 0xb0, 0x9a, 0xc3, 0xc6,
-	0xa8, 0xe3, 0xaa, 0x6f, 0x69, 0x2d, 0xc2, 0x25, 0xa1, 0xa9, 0xb0, 0x76, 0x86, 0x8d, 0x51, 0xcb,
-	0xcd, 0x44, 0xa9, 0xa1, 0x89, 0x08, 0x69, 0xcc, 0xad, 0x96, 0xf6, 0x63, 0x44, 0xa7, 0x0f, 0x87,
-	0xc5, 0x8b, 0x93, 0x68, 0xe5, 0x20, 0x38, 0x98, 0x09, 0x9a, 0x5c, 0x04, 0x9b, 0x50, 0x9c, 0x03,
-	0xd8, 0xcb, 0x61, 0xd2, 0xea, 0x08, 0xd0, 0x4c, 0x60, 0x91, 0xf2, 0x82, 0xdd, 0x3b, 0x79, 0x36,
-	0x87, 0xca, 0x7a, 0x0c, 0xa0, 0xcd, 0x15, 0x66, 0xb2, 0x30, 0x92, 0xc4, 0xd3, 0x44, 0xc6, 0xa8,
-	0xd2, 0xe8, 0xba, 0x46, 0x42, 0x07, 0xb0, 0x9d, 0x84, 0xbe, 0xb5, 0x3d, 0x6c, 0x8c, 0x9e, 0xba,
-	0xf2, 0xd3, 0xf9, 0xd0, 0x80, 0xc1, 0x7b, 0x1c, 0x85, 0x3e, 0x16, 0x44, 0xd6, 0xee, 0x26, 0xbe,
-	0xcf, 0x6a, 0x34, 0x82, 0x7d, 0x59, 0xdc, 0x0b, 0xdf, 0x67, 0x84, 0xf3, 0x37, 0x21, 0x17, 0x56,
-	0x63, 0xb8, 0x3d, 0xea, 0xba, 0x65, 0x18, 0x3d, 0x87, 0xa7, 0xd7, 0x21, 0x23, 0x9e, 0xa0, 0x6c,
-	0xa5, 0xec, 0x9a, 0xca, 0xae, 0x08, 0xca, 0xe6, 0x25, 0x94, 0x09, 0x65, 0xb0, 0xad, 0x0c, 0xd6,
-	0x32, 0xfa, 0x04, 0xda, 0x11, 0xf5, 0x70, 0x44, 0x54, 0x81, 0x7b, 0x93, 0xde, 0x38, 0xf4, 0xa3,
-	0xf1, 0x1b, 0x05, 0xb9, 0x46, 0x85, 0xce, 0xa0, 0x1b, 0x24, 0xef, 0x09, 0xe3, 0x21, 0x8d, 0x4d,
-	0xb9, 0x37, 0x80, 0xcc, 0xf9, 0x96, 0x32, 0x8f, 0xf8, 0x56, 0x5b, 0xb5, 0xce, 0x48, 0xce, 0x15,
-	0x1c, 0x55, 0x12, 0x94, 0xb5, 0xfb, 0x0c, 0x3a, 0x4b, 0xc2, 0x39, 0x0e, 0x08, 0x57, 0x79, 0xf5,
-	0x26, 0xfb, 0xe6, 0xd2, 0xe0, 0xad, 0xc6, 0xdd, 0xb5, 0x81, 0xf3, 0x4f, 0x13, 0xd0, 0x5b, 0x7c,
-	0x47, 0x4a, 0x63, 0x74, 0x0e, 0x4f, 0xb8, 0x46, 0x54, 0x03, 0x7a, 0x93, 0x5d, 0xe5, 0x22, 0xb3,
-	0xca, 0x94, 0xb9, 0xf4, 0x9a, 0x8f, 0xa7, 0x67, 0x43, 0xe7, 0x26, 0xf6, 0xa8, 0x1f, 0xc6, 0x81,
-	0xea, 0x50, 0xd7, 0x5d, 0xcb, 0xe8, 0x1a, 0xba, 0x33, 0x12, 0x5c, 0xd1, 0xf8, 0x36, 0x0c, 0xac,
-	0x1d, 0x15, 0xed, 0xb9, 0xf2, 0x51, 0x0d, 0x6a, 0xbc, 0x36, 0xbc, 0x89, 0x05, 0x5b, 0xb9, 0x9b,
-	0x83, 0xe8, 0x53, 0x38, 0xf0, 0x28, 0x65, 0x7e, 0x18, 0x63, 0x41, 0x99, 0xec, 0xa0, 0x1c, 0x5b,
-	0xd9, 0x89, 0x0a, 0x8e, 0x1c, 0xd8, 0x5d, 0xcc, 0x71, 0x46, 0x27, 0x6e, 0x8a, 0x5a, 0xc0, 0x64,
-	0xdf, 0x25, 0x6d, 0xae, 0x16, 0xc4, 0xbb, 0xe3, 0xe9, 0x92, 0x5b, 0x4f, 0x94, 0x51, 0x11, 0xb4,
-	0x5f, 0xc2, 0x5e, 0x31, 0x24, 0x39, 0x86, 0x77, 0x64, 0x65, 0x66, 0x56, 0x7e, 0xa2, 0x23, 0x68,
-	0xdd, 0xe3, 0x28, 0xcd, 0xe6, 0x55, 0x0b, 0x2f, 0x9a, 0xdf, 0x36, 0x24, 0x65, 0x0a, 0x39, 0x4a,
-	0x82, 0xd8, 0x60, 0xbd, 0x22, 0xe2, 0xc7, 0x58, 0x10, 0x76, 0x8b, 0x3d, 0xa2, 0x02, 0xce, 0x68,
-	0xf2, 0x15, 0x9c, 0xd6, 0xe8, 0x78, 0x42, 0x63, 0x4e, 0xe4, 0x35, 0x58, 0x65, 0xad, 0x07, 0x59,
-	0x0b, 0xce, 0x02, 0x06, 0xbf, 0x26, 0x72, 0x3e, 0xa6, 0xc1, 0xeb, 0x39, 0x96, 0x81, 0x66, 0xfd,
-	0x1d, 0x40, 0x3b, 0x09, 0x64, 0x36, 0x19, 0xbf, 0xb4, 0xb4, 0xf1, 0xd3, 0xcc, 0xf9, 0x41, 0x43,
-	0xe8, 0x31, 0x92, 0x44, 0xa1, 0x87, 0xe5, 0x0a, 0x50, 0x3d, 0xec, 0xb8, 0x79, 0xc8, 0x39, 0x85,
-	0x93, 0xca, 0x4d, 0x3a, 0x34, 0xe7, 0xaf, 0x06, 0xf4, 0x33, 0xdd, 0x7f, 0x09, 0xe1, 0x25, 0xb4,
-	0x13, 0xcc, 0xf0, 0x52, 0xc7, 0xd0, 0x9b, 0x3c, 0x57, 0xe3, 0x50, 0xe3, 0x61, 0x3c, 0x55, 0x66,
-	0x7a, 0x18, 0xcc, 0x19, 0x49, 0x25, 0x7a, 0x4f, 0xd8, 0x03, 0x0b, 0x05, 0x31, 0x81, 0x6e, 0x00,
-	0xfb, 0x3b, 0xe8, 0xe5, 0x0e, 0xfd, 0xaf, 0x76, 0x9d, 0xc0, 0x71, 0x31, 0x06, 0x9e, 0x50, 0x95,
-	0xdf, 0x87, 0x26, 0xf4, 0xa7, 0xc1, 0x25, 0xe6, 0x64, 0x8e, 0xbd, 0xbb, 0x34, 0xc9, 0xf2, 0x3b,
-	0x83, 0xae, 0xc0, 0x2c, 0x20, 0x62, 0xb3, 0x8b, 0x37, 0x00, 0x7a, 0x06, 0xc0, 0x69, 0xca, 0x3c,
-	0x45, 0x5d, 0x73, 0x5b, 0x0e, 0xd9, 0xe8, 0xa7, 0x94, 0x09, 0x95, 0x48, 0xcb, 0xcd, 0x21, 0x52,
-	0xef, 0x31, 0x82, 0x05, 0x99, 0x45, 0x54, 0x2f, 0xef, 0x8e, 0x9b, 0x43, 0xd0, 0x39, 0xec, 0xa9,
-	0x35, 0xf1, 0xcb, 0xba, 0x18, 0x2d, 0x65, 0x53, 0x42, 0xa5, 0x1f, 0x13, 0xd4, 0x3c, 0xd4, 0x0b,
-	0xa6, 0xe5, 0xe6, 0x10, 0xf4, 0x39, 0x1c, 0x2a, 0x43, 0x97, 0x78, 0xb2, 0x8c, 0x2b, 0x99, 0xbb,
-	0x61, 0x43, 0x55, 0x81, 0xbe, 0x84, 0x7e, 0x6e, 0x2a, 0x64, 0x20, 0x92, 0x4f, 0x56, 0x47, 0xa5,
-	0x57, 0xa7, 0x92, 0x6c, 0x24, 0x7f, 0x7a, 0x51, 0xea, 0x93, 0x29, 0x16, 0x0b, 0x6e, 0x75, 0xd5,
-	0xdc, 0x15, 0x30, 0x67, 0x00, 0x47, 0xc5, 0x02, 0xeb, 0xc9, 0x9a, 0xfc, 0xdd, 0x82, 0x96, 0x7a,
-	0x33, 0xd0, 0x37, 0xb0, 0x23, 0x9f, 0x1a, 0x74, 0xac, 0xb7, 0x54, 0xe9, 0x25, 0xb2, 0xfb, 0x65,
-	0x58, 0x72, 0x6d, 0x0b, 0xbd, 0x80, 0xb6, 0x7e, 0x78, 0xd0, 0x89, 0x31, 0x28, 0xbf, 0x4d, 0xf6,
-	0x71, 0x55, 0xa1, 0xcf, 0x7e, 0x0f, 0xbd, 0x1c, 0x7b, 0x8d, 0x83, 0xea, 0xce, 0x32, 0x0e, 0x2a,
-	0x44, 0xdf, 0x42, 0x97, 0xb0, 0x9b, 0x7f, 0x46, 0x91, 0x95, 0xdd, 0x54, 0x7e, 0xd2, 0xed, 0x41,
-	0x8d, 0x46, 0xfb, 0xf8, 0x09, 0xf6, 0x4b, 0x2f, 0x00, 0xfa, 0x48, 0x19, 0xd7, 0x3f, 0x7c, 0xf6,
-	0x69, 0xbd, 0x52, 0x3b, 0x7b, 0x07, 0x87, 0x95, 0xfd, 0x82, 0x3e, 0x56, 0x27, 0x1e, 0xdb, 0x49,
-	0xf6, 0xb3, 0xc7, 0xd4, 0x86, 0xfb, 0x5b, 0xe8, 0x37, 0xb0, 0x4a, 0x8b, 0xe1, 0x22, 0xf6, 0x5d,
-	0x12, 0x51, 0xec, 0x9b, 0x58, 0xeb, 0x37, 0x94, 0x7d, 0x56, 0xaf, 0x5c, 0x3b, 0xfe, 0x01, 0x76,
-	0xf3, 0x7c, 0x34, 0xf5, 0xab, 0x59, 0x13, 0xb6, 0x5d, 0xa3, 0xc9, 0xc8, 0xbb, 0x85, 0x6e, 0x60,
-	0x37, 0x3f, 0x5c, 0xc6, 0x4f, 0x0d, 0xa1, 0x4d, 0xf5, 0xea, 0x26, 0x51, 0xcf, 0x43, 0xee, 0x27,
-	0xcd, 0xcc, 0x43, 0xf5, 0xb7, 0xcd, 0xcc, 0x43, 0xf9, 0xff, 0xcf, 0xd9, 0xba, 0xec, 0xfc, 0xde,
-	0x1e, 0x8f, 0xbf, 0x08, 0xfd, 0x68, 0xde, 0x56, 0xbf, 0x89, 0x5f, 0xff, 0x1b, 0x00, 0x00, 0xff,
-	0xff, 0xc8, 0xd1, 0x1e, 0xad, 0x45, 0x0a, 0x00, 0x00,
+func (m *GetPgHbaConfRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetPgHbaConfRequest.Unmarshal(m, b)
+}
+func (m *GetPgHbaConfRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetPgHbaConfRequest.Marshal(b, m, deterministic)
+}
+func (m *GetPgHbaConfRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetPgHbaConfRequest.Merge(m, src)
+}
+func (m *GetPgHbaConfRequest) XXX_Size() int {
+	return xxx_messageInfo_GetPgHbaConfRequest.Size(m)
+}
+func (m *GetPgHbaConfRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetPgHbaConfRequest.DiscardUnknown(m)
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
+var xxx_messageInfo_GetPgHbaConfRequest proto.InternalMessageInfo
 
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
+func (m *GetPgHbaConfRequest) GetPgdata() string {
+	if m != nil {
+		return m.Pgdata
+	}
+	return ""
+}
 
-// AgentClient is the client API for Agent service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type AgentClient interface {
-	Stop(ctx context.Context, in *StopAgentRequest, opts ...grpc.CallOption) (*StopAgentReply, error)
-	Status(ctx context.Context, in *StatusAgentRequest, opts ...grpc.CallOption) (*StatusAgentReply, error)
-	MakeSegment(ctx context.Context, in *MakeSegmentRequest, opts ...grpc.CallOption) (*MakeSegmentReply, error)
-	StartSegment(ctx context.Context, in *StartSegmentRequest, opts ...grpc.CallOption) (*StartSegmentReply, error)
-	ValidateHostEnv(ctx context.Context, in *ValidateHostEnvRequest, opts ...grpc.CallOption) (*ValidateHostEnvReply, error)
-	GetInterfaceAddrs(ctx context.Context, in *GetInterfaceAddrsRequest, opts ...grpc.CallOption) (*GetInterfaceAddrsResponse, error)
-	UpdatePgHbaConfAndReload(ctx context.Context, in *UpdatePgHbaConfRequest, opts ...grpc.CallOption) (*UpdatePgHbaConfResponse, error)
-	UpdatePgConf(ctx context.Context, in *UpdatePgConfRequest, opts ...grpc.CallOption) (*UpdatePgConfRespoonse, error)
-	PgBasebackup(ctx context.Context, in *PgBasebackupRequest, opts ...grpc.CallOption) (*PgBasebackupResponse, error)
-	GetHostName(ctx context.Context, in *GetHostNameRequest, opts ...grpc.CallOption) (*GetHostNameReply, error)
+type GetPgHbaConfReply struct {
+	Content              string   `protobuf:"bytes,1,opt,name=content,proto3" json:"content,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-type agentClient struct {
-	cc *grpc.ClientConn
+func (m *GetPgHbaConfReply) Reset()         { *m = GetPgHbaConfReply{} }
+func (m *GetPgHbaConfReply) String() string { return proto.CompactTextString(m) }
+func (*GetPgHbaConfReply) ProtoMessage()    {}
+func (*GetPgHbaConfReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_56ede974c0020f77, []int{22}
 }
 
-func NewAgentClient(cc *grpc.ClientConn) AgentClient {
-	return &agentClient{cc}
+func (m *GetPgHbaConfReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetPgHbaConfReply.Unmarshal(m, b)
+}
+func (m *GetPgHbaConfReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetPgHbaConfReply.Marshal(b, m, deterministic)
+}
+func (m *GetPgHbaConfReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetPgHbaConfReply.Merge(m, src)
+}
+func (m *GetPgHbaConfReply) XXX_Size() int {
+	return xxx_messageInfo_GetPgHbaConfReply.Size(m)
+}
+func (m *GetPgHbaConfReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetPgHbaConfReply.DiscardUnknown(m)
 }
 
-func (c *agentClient) Stop(ctx context.Context, in *StopAgentRequest, opts ...grpc.CallOption) (*StopAgentReply, error) {
-	out := new(StopAgentReply)
-	err := c.cc.Invoke(ctx, "/idl.Agent/Stop", in, out, opts...)
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_GetPgHbaConfReply proto.InternalMessageInfo
+
+func (m *GetPgHbaConfReply) GetContent() string {
+	if m != nil {
+		return m.Content
 	}
-	return out, nil
+	return ""
 }
 
-func (c *agentClient) Status(ctx context.Context, in *StatusAgentRequest, opts ...grpc.CallOption) (*StatusAgentReply, error) {
-	out := new(StatusAgentReply)
-	err := c.cc.Invoke(ctx, "/idl.Agent/Status", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+type ModifyPgHbaConfRequest struct {
+	Pgdata               string      `protobuf:"bytes,1,opt,name=pgdata,proto3" json:"pgdata,omitempty"`
+	AddEntries           []string    `protobuf:"bytes,2,rep,name=addEntries,proto3" json:"addEntries,omitempty"`
+	RemoveEntries        []string    `protobuf:"bytes,3,rep,name=removeEntries,proto3" json:"removeEntries,omitempty"`
+	Position             HbaPosition `protobuf:"varint,4,opt,name=position,proto3,enum=idl.HbaPosition" json:"position,omitempty"`
+	Reference            string      `protobuf:"bytes,5,opt,name=reference,proto3" json:"reference,omitempty"`
+	Reload               bool        `protobuf:"varint,6,opt,name=reload,proto3" json:"reload,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
 }
 
-func (c *agentClient) MakeSegment(ctx context.Context, in *MakeSegmentRequest, opts ...grpc.CallOption) (*MakeSegmentReply, error) {
-	out := new(MakeSegmentReply)
-	err := c.cc.Invoke(ctx, "/idl.Agent/MakeSegment", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+func (m *ModifyPgHbaConfRequest) Reset()         { *m = ModifyPgHbaConfRequest{} }
+func (m *ModifyPgHbaConfRequest) String() string { return proto.CompactTextString(m) }
+func (*ModifyPgHbaConfRequest) ProtoMessage()    {}
+func (*ModifyPgHbaConfRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_56ede974c0020f77, []int{23}
 }
 
-func (c *agentClient) StartSegment(ctx context.Context, in *StartSegmentRequest, opts ...grpc.CallOption) (*StartSegmentReply, error) {
-	out := new(StartSegmentReply)
-	err := c.cc.Invoke(ctx, "/idl.Agent/StartSegment", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+func (m *ModifyPgHbaConfRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ModifyPgHbaConfRequest.Unmarshal(m, b)
+}
+func (m *ModifyPgHbaConfRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ModifyPgHbaConfRequest.Marshal(b, m, deterministic)
+}
+func (m *ModifyPgHbaConfRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ModifyPgHbaConfRequest.Merge(m, src)
+}
+func (m *ModifyPgHbaConfRequest) XXX_Size() int {
+	return xxx_messageInfo_ModifyPgHbaConfRequest.Size(m)
+}
+func (m *ModifyPgHbaConfRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ModifyPgHbaConfRequest.DiscardUnknown(m)
 }
 
-func (c *agentClient) ValidateHostEnv(ctx context.Context, in *ValidateHostEnvRequest, opts ...grpc.CallOption) (*ValidateHostEnvReply, error) {
-	out := new(ValidateHostEnvReply)
-	err := c.cc.Invoke(ctx, "/idl.Agent/ValidateHostEnv", in, out, opts...)
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_ModifyPgHbaConfRequest proto.InternalMessageInfo
+
+func (m *ModifyPgHbaConfRequest) GetPgdata() string {
+	if m != nil {
+		return m.Pgdata
 	}
-	return out, nil
+	return ""
 }
 
-func (c *agentClient) GetInterfaceAddrs(ctx context.Context, in *GetInterfaceAddrsRequest, opts ...grpc.CallOption) (*GetInterfaceAddrsResponse, error) {
-	out := new(GetInterfaceAddrsResponse)
-	err := c.cc.Invoke(ctx, "/idl.Agent/GetInterfaceAddrs", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *ModifyPgHbaConfRequest) GetAddEntries() []string {
+	if m != nil {
+		return m.AddEntries
 	}
-	return out, nil
+	return nil
 }
 
-func (c *agentClient) UpdatePgHbaConfAndReload(ctx context.Context, in *UpdatePgHbaConfRequest, opts ...grpc.CallOption) (*UpdatePgHbaConfResponse, error) {
-	out := new(UpdatePgHbaConfResponse)
-	err := c.cc.Invoke(ctx, "/idl.Agent/UpdatePgHbaConfAndReload", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *ModifyPgHbaConfRequest) GetRemoveEntries() []string {
+	if m != nil {
+		return m.RemoveEntries
 	}
-	return out, nil
+	return nil
 }
 
-func (c *agentClient) UpdatePgConf(ctx context.Context, in *UpdatePgConfRequest, opts ...grpc.CallOption) (*UpdatePgConfRespoonse, error) {
-	out := new(UpdatePgConfRespoonse)
-	err := c.cc.Invoke(ctx, "/idl.Agent/UpdatePgConf", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *ModifyPgHbaConfRequest) GetPosition() HbaPosition {
+	if m != nil {
+		return m.Position
 	}
-	return out, nil
+	return HbaPosition_APPEND
 }
 
-func (c *agentClient) PgBasebackup(ctx context.Context, in *PgBasebackupRequest, opts ...grpc.CallOption) (*PgBasebackupResponse, error) {
-	out := new(PgBasebackupResponse)
-	err := c.cc.Invoke(ctx, "/idl.Agent/PgBasebackup", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *ModifyPgHbaConfRequest) GetReference() string {
+	if m != nil {
+		return m.Reference
 	}
-	return out, nil
+	return ""
 }
 
-func (c *agentClient) GetHostName(ctx context.Context, in *GetHostNameRequest, opts ...grpc.CallOption) (*GetHostNameReply, error) {
-	out := new(GetHostNameReply)
-	err := c.cc.Invoke(ctx, "/idl.Agent/GetHostName", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *ModifyPgHbaConfRequest) GetReload() bool {
+	if m != nil {
+		return m.Reload
 	}
-	return out, nil
+	return false
 }
 
-// AgentServer is the server API for Agent service.
-type AgentServer interface {
-	Stop(context.Context, *StopAgentRequest) (*StopAgentReply, error)
-	Status(context.Context, *StatusAgentRequest) (*StatusAgentReply, error)
-	MakeSegment(context.Context, *MakeSegmentRequest) (*MakeSegmentReply, error)
-	StartSegment(context.Context, *StartSegmentRequest) (*StartSegmentReply, error)
-	ValidateHostEnv(context.Context, *ValidateHostEnvRequest) (*ValidateHostEnvReply, error)
-	GetInterfaceAddrs(context.Context, *GetInterfaceAddrsRequest) (*GetInterfaceAddrsResponse, error)
-	UpdatePgHbaConfAndReload(context.Context, *UpdatePgHbaConfRequest) (*UpdatePgHbaConfResponse, error)
-	UpdatePgConf(context.Context, *UpdatePgConfRequest) (*UpdatePgConfRespoonse, error)
-	PgBasebackup(context.Context, *PgBasebackupRequest) (*PgBasebackupResponse, error)
-	GetHostName(context.Context, *GetHostNameRequest) (*GetHostNameReply, error)
+type ModifyPgHbaConfReply struct {
+	BackupFile           string   `protobuf:"bytes,1,opt,name=backupFile,proto3" json:"backupFile,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ModifyPgHbaConfReply) Reset()         { *m = ModifyPgHbaConfReply{} }
+func (m *ModifyPgHbaConfReply) String() string { return proto.CompactTextString(m) }
+func (*ModifyPgHbaConfReply) ProtoMessage()    {}
+func (*ModifyPgHbaConfReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_56ede974c0020f77, []int{24}
+}
+
+func (m *ModifyPgHbaConfReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ModifyPgHbaConfReply.Unmarshal(m, b)
+}
+func (m *ModifyPgHbaConfReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ModifyPgHbaConfReply.Marshal(b, m, deterministic)
+}
+func (m *ModifyPgHbaConfReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ModifyPgHbaConfReply.Merge(m, src)
+}
+func (m *ModifyPgHbaConfReply) XXX_Size() int {
+	return xxx_messageInfo_ModifyPgHbaConfReply.Size(m)
+}
+func (m *ModifyPgHbaConfReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_ModifyPgHbaConfReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ModifyPgHbaConfReply proto.InternalMessageInfo
+
+func (m *ModifyPgHbaConfReply) GetBackupFile() string {
+	if m != nil {
+		return m.BackupFile
+	}
+	return ""
+}
+
+type InstallSslCertificateRequest struct {
+	Pgdata               string          `protobuf:"bytes,1,opt,name=pgdata,proto3" json:"pgdata,omitempty"`
+	Certificate          *SslCertificate `protobuf:"bytes,2,opt,name=certificate,proto3" json:"certificate,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *InstallSslCertificateRequest) Reset()         { *m = InstallSslCertificateRequest{} }
+func (m *InstallSslCertificateRequest) String() string { return proto.CompactTextString(m) }
+func (*InstallSslCertificateRequest) ProtoMessage()    {}
+func (*InstallSslCertificateRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_56ede974c0020f77, []int{25}
+}
+
+func (m *InstallSslCertificateRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_InstallSslCertificateRequest.Unmarshal(m, b)
+}
+func (m *InstallSslCertificateRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_InstallSslCertificateRequest.Marshal(b, m, deterministic)
+}
+func (m *InstallSslCertificateRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InstallSslCertificateRequest.Merge(m, src)
+}
+func (m *InstallSslCertificateRequest) XXX_Size() int {
+	return xxx_messageInfo_InstallSslCertificateRequest.Size(m)
+}
+func (m *InstallSslCertificateRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_InstallSslCertificateRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_InstallSslCertificateRequest proto.InternalMessageInfo
+
+func (m *InstallSslCertificateRequest) GetPgdata() string {
+	if m != nil {
+		return m.Pgdata
+	}
+	return ""
+}
+
+func (m *InstallSslCertificateRequest) GetCertificate() *SslCertificate {
+	if m != nil {
+		return m.Certificate
+	}
+	return nil
+}
+
+type InstallSslCertificateReply struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *InstallSslCertificateReply) Reset()         { *m = InstallSslCertificateReply{} }
+func (m *InstallSslCertificateReply) String() string { return proto.CompactTextString(m) }
+func (*InstallSslCertificateReply) ProtoMessage()    {}
+func (*InstallSslCertificateReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_56ede974c0020f77, []int{26}
+}
+
+func (m *InstallSslCertificateReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_InstallSslCertificateReply.Unmarshal(m, b)
+}
+func (m *InstallSslCertificateReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_InstallSslCertificateReply.Marshal(b, m, deterministic)
+}
+func (m *InstallSslCertificateReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InstallSslCertificateReply.Merge(m, src)
+}
+func (m *InstallSslCertificateReply) XXX_Size() int {
+	return xxx_messageInfo_InstallSslCertificateReply.Size(m)
+}
+func (m *InstallSslCertificateReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_InstallSslCertificateReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_InstallSslCertificateReply proto.InternalMessageInfo
+
+type ReadLogsRequest struct {
+	Pgdata               string     `protobuf:"bytes,1,opt,name=pgdata,proto3" json:"pgdata,omitempty"`
+	List                 bool       `protobuf:"varint,2,opt,name=list,proto3" json:"list,omitempty"`
+	Follow               bool       `protobuf:"varint,3,opt,name=follow,proto3" json:"follow,omitempty"`
+	Filter               *LogFilter `protobuf:"bytes,4,opt,name=filter,proto3" json:"filter,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *ReadLogsRequest) Reset()         { *m = ReadLogsRequest{} }
+func (m *ReadLogsRequest) String() string { return proto.CompactTextString(m) }
+func (*ReadLogsRequest) ProtoMessage()    {}
+func (*ReadLogsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_56ede974c0020f77, []int{27}
+}
+
+func (m *ReadLogsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReadLogsRequest.Unmarshal(m, b)
+}
+func (m *ReadLogsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReadLogsRequest.Marshal(b, m, deterministic)
+}
+func (m *ReadLogsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReadLogsRequest.Merge(m, src)
+}
+func (m *ReadLogsRequest) XXX_Size() int {
+	return xxx_messageInfo_ReadLogsRequest.Size(m)
+}
+func (m *ReadLogsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReadLogsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReadLogsRequest proto.InternalMessageInfo
+
+func (m *ReadLogsRequest) GetPgdata() string {
+	if m != nil {
+		return m.Pgdata
+	}
+	return ""
+}
+
+func (m *ReadLogsRequest) GetList() bool {
+	if m != nil {
+		return m.List
+	}
+	return false
+}
+
+func (m *ReadLogsRequest) GetFollow() bool {
+	if m != nil {
+		return m.Follow
+	}
+	return false
+}
+
+func (m *ReadLogsRequest) GetFilter() *LogFilter {
+	if m != nil {
+		return m.Filter
+	}
+	return nil
+}
+
+type ReadLogsReply struct {
+	File                 string     `protobuf:"bytes,1,opt,name=file,proto3" json:"file,omitempty"`
+	Lines                []string   `protobuf:"bytes,2,rep,name=lines,proto3" json:"lines,omitempty"`
+	Files                []*LogFile `protobuf:"bytes,3,rep,name=files,proto3" json:"files,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *ReadLogsReply) Reset()         { *m = ReadLogsReply{} }
+func (m *ReadLogsReply) String() string { return proto.CompactTextString(m) }
+func (*ReadLogsReply) ProtoMessage()    {}
+func (*ReadLogsReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_56ede974c0020f77, []int{28}
+}
+
+func (m *ReadLogsReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReadLogsReply.Unmarshal(m, b)
+}
+func (m *ReadLogsReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReadLogsReply.Marshal(b, m, deterministic)
+}
+func (m *ReadLogsReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReadLogsReply.Merge(m, src)
+}
+func (m *ReadLogsReply) XXX_Size() int {
+	return xxx_messageInfo_ReadLogsReply.Size(m)
+}
+func (m *ReadLogsReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReadLogsReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReadLogsReply proto.InternalMessageInfo
+
+func (m *ReadLogsReply) GetFile() string {
+	if m != nil {
+		return m.File
+	}
+	return ""
+}
+
+func (m *ReadLogsReply) GetLines() []string {
+	if m != nil {
+		return m.Lines
+	}
+	return nil
+}
+
+func (m *ReadLogsReply) GetFiles() []*LogFile {
+	if m != nil {
+		return m.Files
+	}
+	return nil
+}
+
+type CollectDiagnosticsRequest struct {
+	Segments             []*Segment `protobuf:"bytes,1,rep,name=segments,proto3" json:"segments,omitempty"`
+	Since                int64      `protobuf:"varint,2,opt,name=since,proto3" json:"since,omitempty"`
+	MaxSize              int64      `protobuf:"varint,3,opt,name=maxSize,proto3" json:"maxSize,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *CollectDiagnosticsRequest) Reset()         { *m = CollectDiagnosticsRequest{} }
+func (m *CollectDiagnosticsRequest) String() string { return proto.CompactTextString(m) }
+func (*CollectDiagnosticsRequest) ProtoMessage()    {}
+func (*CollectDiagnosticsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_56ede974c0020f77, []int{29}
+}
+
+func (m *CollectDiagnosticsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CollectDiagnosticsRequest.Unmarshal(m, b)
+}
+func (m *CollectDiagnosticsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CollectDiagnosticsRequest.Marshal(b, m, deterministic)
+}
+func (m *CollectDiagnosticsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CollectDiagnosticsRequest.Merge(m, src)
+}
+func (m *CollectDiagnosticsRequest) XXX_Size() int {
+	return xxx_messageInfo_CollectDiagnosticsRequest.Size(m)
+}
+func (m *CollectDiagnosticsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CollectDiagnosticsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CollectDiagnosticsRequest proto.InternalMessageInfo
+
+func (m *CollectDiagnosticsRequest) GetSegments() []*Segment {
+	if m != nil {
+		return m.Segments
+	}
+	return nil
+}
+
+func (m *CollectDiagnosticsRequest) GetSince() int64 {
+	if m != nil {
+		return m.Since
+	}
+	return 0
+}
+
+func (m *CollectDiagnosticsRequest) GetMaxSize() int64 {
+	if m != nil {
+		return m.MaxSize
+	}
+	return 0
+}
+
+type CollectDiagnosticsReply struct {
+	Data                 []byte   `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CollectDiagnosticsReply) Reset()         { *m = CollectDiagnosticsReply{} }
+func (m *CollectDiagnosticsReply) String() string { return proto.CompactTextString(m) }
+func (*CollectDiagnosticsReply) ProtoMessage()    {}
+func (*CollectDiagnosticsReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_56ede974c0020f77, []int{30}
+}
+
+func (m *CollectDiagnosticsReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CollectDiagnosticsReply.Unmarshal(m, b)
+}
+func (m *CollectDiagnosticsReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CollectDiagnosticsReply.Marshal(b, m, deterministic)
+}
+func (m *CollectDiagnosticsReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CollectDiagnosticsReply.Merge(m, src)
+}
+func (m *CollectDiagnosticsReply) XXX_Size() int {
+	return xxx_messageInfo_CollectDiagnosticsReply.Size(m)
+}
+func (m *CollectDiagnosticsReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_CollectDiagnosticsReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CollectDiagnosticsReply proto.InternalMessageInfo
+
+func (m *CollectDiagnosticsReply) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+type GetHostInfoRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetHostInfoRequest) Reset()         { *m = GetHostInfoRequest{} }
+func (m *GetHostInfoRequest) String() string { return proto.CompactTextString(m) }
+func (*GetHostInfoRequest) ProtoMessage()    {}
+func (*GetHostInfoRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_56ede974c0020f77, []int{31}
+}
+
+func (m *GetHostInfoRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetHostInfoRequest.Unmarshal(m, b)
+}
+func (m *GetHostInfoRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetHostInfoRequest.Marshal(b, m, deterministic)
+}
+func (m *GetHostInfoRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetHostInfoRequest.Merge(m, src)
+}
+func (m *GetHostInfoRequest) XXX_Size() int {
+	return xxx_messageInfo_GetHostInfoRequest.Size(m)
+}
+func (m *GetHostInfoRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetHostInfoRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetHostInfoRequest proto.InternalMessageInfo
+
+type GetHostInfoReply struct {
+	Info                 *HostInfo `protobuf:"bytes,1,opt,name=info,proto3" json:"info,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *GetHostInfoReply) Reset()         { *m = GetHostInfoReply{} }
+func (m *GetHostInfoReply) String() string { return proto.CompactTextString(m) }
+func (*GetHostInfoReply) ProtoMessage()    {}
+func (*GetHostInfoReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_56ede974c0020f77, []int{32}
+}
+
+func (m *GetHostInfoReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetHostInfoReply.Unmarshal(m, b)
+}
+func (m *GetHostInfoReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetHostInfoReply.Marshal(b, m, deterministic)
+}
+func (m *GetHostInfoReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetHostInfoReply.Merge(m, src)
+}
+func (m *GetHostInfoReply) XXX_Size() int {
+	return xxx_messageInfo_GetHostInfoReply.Size(m)
+}
+func (m *GetHostInfoReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetHostInfoReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetHostInfoReply proto.InternalMessageInfo
+
+func (m *GetHostInfoReply) GetInfo() *HostInfo {
+	if m != nil {
+		return m.Info
+	}
+	return nil
+}
+
+type StartListenersRequest struct {
+	Ports                []int32  `protobuf:"varint,1,rep,packed,name=ports,proto3" json:"ports,omitempty"`
+	Timeout              int32    `protobuf:"varint,2,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	CheckId              string   `protobuf:"bytes,3,opt,name=checkId,proto3" json:"checkId,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StartListenersRequest) Reset()         { *m = StartListenersRequest{} }
+func (m *StartListenersRequest) String() string { return proto.CompactTextString(m) }
+func (*StartListenersRequest) ProtoMessage()    {}
+func (*StartListenersRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_56ede974c0020f77, []int{33}
+}
+
+func (m *StartListenersRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StartListenersRequest.Unmarshal(m, b)
+}
+func (m *StartListenersRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StartListenersRequest.Marshal(b, m, deterministic)
+}
+func (m *StartListenersRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StartListenersRequest.Merge(m, src)
+}
+func (m *StartListenersRequest) XXX_Size() int {
+	return xxx_messageInfo_StartListenersRequest.Size(m)
+}
+func (m *StartListenersRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_StartListenersRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StartListenersRequest proto.InternalMessageInfo
+
+func (m *StartListenersRequest) GetPorts() []int32 {
+	if m != nil {
+		return m.Ports
+	}
+	return nil
+}
+
+func (m *StartListenersRequest) GetTimeout() int32 {
+	if m != nil {
+		return m.Timeout
+	}
+	return 0
+}
+
+func (m *StartListenersRequest) GetCheckId() string {
+	if m != nil {
+		return m.CheckId
+	}
+	return ""
+}
+
+type ListenerStatus struct {
+	Port                 int32    `protobuf:"varint,1,opt,name=port,proto3" json:"port,omitempty"`
+	Error                string   `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListenerStatus) Reset()         { *m = ListenerStatus{} }
+func (m *ListenerStatus) String() string { return proto.CompactTextString(m) }
+func (*ListenerStatus) ProtoMessage()    {}
+func (*ListenerStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_56ede974c0020f77, []int{34}
+}
+
+func (m *ListenerStatus) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListenerStatus.Unmarshal(m, b)
+}
+func (m *ListenerStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListenerStatus.Marshal(b, m, deterministic)
+}
+func (m *ListenerStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListenerStatus.Merge(m, src)
+}
+func (m *ListenerStatus) XXX_Size() int {
+	return xxx_messageInfo_ListenerStatus.Size(m)
+}
+func (m *ListenerStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListenerStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListenerStatus proto.InternalMessageInfo
+
+func (m *ListenerStatus) GetPort() int32 {
+	if m != nil {
+		return m.Port
+	}
+	return 0
+}
+
+func (m *ListenerStatus) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+type StartListenersReply struct {
+	Listeners            []*ListenerStatus `protobuf:"bytes,1,rep,name=listeners,proto3" json:"listeners,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *StartListenersReply) Reset()         { *m = StartListenersReply{} }
+func (m *StartListenersReply) String() string { return proto.CompactTextString(m) }
+func (*StartListenersReply) ProtoMessage()    {}
+func (*StartListenersReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_56ede974c0020f77, []int{35}
+}
+
+func (m *StartListenersReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StartListenersReply.Unmarshal(m, b)
+}
+func (m *StartListenersReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StartListenersReply.Marshal(b, m, deterministic)
+}
+func (m *StartListenersReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StartListenersReply.Merge(m, src)
+}
+func (m *StartListenersReply) XXX_Size() int {
+	return xxx_messageInfo_StartListenersReply.Size(m)
+}
+func (m *StartListenersReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_StartListenersReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StartListenersReply proto.InternalMessageInfo
+
+func (m *StartListenersReply) GetListeners() []*ListenerStatus {
+	if m != nil {
+		return m.Listeners
+	}
+	return nil
+}
+
+type StopListenersRequest struct {
+	CheckId              string   `protobuf:"bytes,1,opt,name=checkId,proto3" json:"checkId,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StopListenersRequest) Reset()         { *m = StopListenersRequest{} }
+func (m *StopListenersRequest) String() string { return proto.CompactTextString(m) }
+func (*StopListenersRequest) ProtoMessage()    {}
+func (*StopListenersRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_56ede974c0020f77, []int{36}
+}
+
+func (m *StopListenersRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StopListenersRequest.Unmarshal(m, b)
+}
+func (m *StopListenersRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StopListenersRequest.Marshal(b, m, deterministic)
+}
+func (m *StopListenersRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StopListenersRequest.Merge(m, src)
+}
+func (m *StopListenersRequest) XXX_Size() int {
+	return xxx_messageInfo_StopListenersRequest.Size(m)
+}
+func (m *StopListenersRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_StopListenersRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StopListenersRequest proto.InternalMessageInfo
+
+func (m *StopListenersRequest) GetCheckId() string {
+	if m != nil {
+		return m.CheckId
+	}
+	return ""
+}
+
+type StopListenersReply struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StopListenersReply) Reset()         { *m = StopListenersReply{} }
+func (m *StopListenersReply) String() string { return proto.CompactTextString(m) }
+func (*StopListenersReply) ProtoMessage()    {}
+func (*StopListenersReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_56ede974c0020f77, []int{37}
+}
+
+func (m *StopListenersReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StopListenersReply.Unmarshal(m, b)
+}
+func (m *StopListenersReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StopListenersReply.Marshal(b, m, deterministic)
+}
+func (m *StopListenersReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StopListenersReply.Merge(m, src)
+}
+func (m *StopListenersReply) XXX_Size() int {
+	return xxx_messageInfo_StopListenersReply.Size(m)
+}
+func (m *StopListenersReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_StopListenersReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StopListenersReply proto.InternalMessageInfo
+
+type ConnectivityTarget struct {
+	Host                 string   `protobuf:"bytes,1,opt,name=host,proto3" json:"host,omitempty"`
+	Address              string   `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	Port                 int32    `protobuf:"varint,3,opt,name=port,proto3" json:"port,omitempty"`
+	Purpose              string   `protobuf:"bytes,4,opt,name=purpose,proto3" json:"purpose,omitempty"`
+	Measure              bool     `protobuf:"varint,5,opt,name=measure,proto3" json:"measure,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ConnectivityTarget) Reset()         { *m = ConnectivityTarget{} }
+func (m *ConnectivityTarget) String() string { return proto.CompactTextString(m) }
+func (*ConnectivityTarget) ProtoMessage()    {}
+func (*ConnectivityTarget) Descriptor() ([]byte, []int) {
+	return fileDescriptor_56ede974c0020f77, []int{38}
+}
+
+func (m *ConnectivityTarget) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ConnectivityTarget.Unmarshal(m, b)
+}
+func (m *ConnectivityTarget) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ConnectivityTarget.Marshal(b, m, deterministic)
+}
+func (m *ConnectivityTarget) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConnectivityTarget.Merge(m, src)
+}
+func (m *ConnectivityTarget) XXX_Size() int {
+	return xxx_messageInfo_ConnectivityTarget.Size(m)
+}
+func (m *ConnectivityTarget) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConnectivityTarget.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConnectivityTarget proto.InternalMessageInfo
+
+func (m *ConnectivityTarget) GetHost() string {
+	if m != nil {
+		return m.Host
+	}
+	return ""
+}
+
+func (m *ConnectivityTarget) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *ConnectivityTarget) GetPort() int32 {
+	if m != nil {
+		return m.Port
+	}
+	return 0
+}
+
+func (m *ConnectivityTarget) GetPurpose() string {
+	if m != nil {
+		return m.Purpose
+	}
+	return ""
+}
+
+func (m *ConnectivityTarget) GetMeasure() bool {
+	if m != nil {
+		return m.Measure
+	}
+	return false
+}
+
+type TestConnectivityRequest struct {
+	Targets              []*ConnectivityTarget `protobuf:"bytes,1,rep,name=targets,proto3" json:"targets,omitempty"`
+	Timeout              int32                 `protobuf:"varint,2,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	MeasureBytes         int64                 `protobuf:"varint,3,opt,name=measureBytes,proto3" json:"measureBytes,omitempty"`
+	CheckId              string                `protobuf:"bytes,4,opt,name=checkId,proto3" json:"checkId,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *TestConnectivityRequest) Reset()         { *m = TestConnectivityRequest{} }
+func (m *TestConnectivityRequest) String() string { return proto.CompactTextString(m) }
+func (*TestConnectivityRequest) ProtoMessage()    {}
+func (*TestConnectivityRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_56ede974c0020f77, []int{39}
+}
+
+func (m *TestConnectivityRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TestConnectivityRequest.Unmarshal(m, b)
+}
+func (m *TestConnectivityRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TestConnectivityRequest.Marshal(b, m, deterministic)
+}
+func (m *TestConnectivityRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TestConnectivityRequest.Merge(m, src)
+}
+func (m *TestConnectivityRequest) XXX_Size() int {
+	return xxx_messageInfo_TestConnectivityRequest.Size(m)
+}
+func (m *TestConnectivityRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_TestConnectivityRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TestConnectivityRequest proto.InternalMessageInfo
+
+func (m *TestConnectivityRequest) GetTargets() []*ConnectivityTarget {
+	if m != nil {
+		return m.Targets
+	}
+	return nil
+}
+
+func (m *TestConnectivityRequest) GetTimeout() int32 {
+	if m != nil {
+		return m.Timeout
+	}
+	return 0
+}
+
+func (m *TestConnectivityRequest) GetMeasureBytes() int64 {
+	if m != nil {
+		return m.MeasureBytes
+	}
+	return 0
+}
+
+func (m *TestConnectivityRequest) GetCheckId() string {
+	if m != nil {
+		return m.CheckId
+	}
+	return ""
+}
+
+type TestConnectivityReply struct {
+	Results              []*NetworkResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *TestConnectivityReply) Reset()         { *m = TestConnectivityReply{} }
+func (m *TestConnectivityReply) String() string { return proto.CompactTextString(m) }
+func (*TestConnectivityReply) ProtoMessage()    {}
+func (*TestConnectivityReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_56ede974c0020f77, []int{40}
+}
+
+func (m *TestConnectivityReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TestConnectivityReply.Unmarshal(m, b)
+}
+func (m *TestConnectivityReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TestConnectivityReply.Marshal(b, m, deterministic)
+}
+func (m *TestConnectivityReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TestConnectivityReply.Merge(m, src)
+}
+func (m *TestConnectivityReply) XXX_Size() int {
+	return xxx_messageInfo_TestConnectivityReply.Size(m)
+}
+func (m *TestConnectivityReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_TestConnectivityReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TestConnectivityReply proto.InternalMessageInfo
+
+func (m *TestConnectivityReply) GetResults() []*NetworkResult {
+	if m != nil {
+		return m.Results
+	}
+	return nil
+}
+
+type TestDiskPerformanceRequest struct {
+	Directories          []string `protobuf:"bytes,1,rep,name=directories,proto3" json:"directories,omitempty"`
+	SizeBytes            int64    `protobuf:"varint,2,opt,name=sizeBytes,proto3" json:"sizeBytes,omitempty"`
+	MaxDuration          int32    `protobuf:"varint,3,opt,name=maxDuration,proto3" json:"maxDuration,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TestDiskPerformanceRequest) Reset()         { *m = TestDiskPerformanceRequest{} }
+func (m *TestDiskPerformanceRequest) String() string { return proto.CompactTextString(m) }
+func (*TestDiskPerformanceRequest) ProtoMessage()    {}
+func (*TestDiskPerformanceRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_56ede974c0020f77, []int{41}
+}
+
+func (m *TestDiskPerformanceRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TestDiskPerformanceRequest.Unmarshal(m, b)
+}
+func (m *TestDiskPerformanceRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TestDiskPerformanceRequest.Marshal(b, m, deterministic)
+}
+func (m *TestDiskPerformanceRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TestDiskPerformanceRequest.Merge(m, src)
+}
+func (m *TestDiskPerformanceRequest) XXX_Size() int {
+	return xxx_messageInfo_TestDiskPerformanceRequest.Size(m)
+}
+func (m *TestDiskPerformanceRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_TestDiskPerformanceRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TestDiskPerformanceRequest proto.InternalMessageInfo
+
+func (m *TestDiskPerformanceRequest) GetDirectories() []string {
+	if m != nil {
+		return m.Directories
+	}
+	return nil
+}
+
+func (m *TestDiskPerformanceRequest) GetSizeBytes() int64 {
+	if m != nil {
+		return m.SizeBytes
+	}
+	return 0
+}
+
+func (m *TestDiskPerformanceRequest) GetMaxDuration() int32 {
+	if m != nil {
+		return m.MaxDuration
+	}
+	return 0
+}
+
+type TestDiskPerformanceReply struct {
+	Results              []*DiskResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *TestDiskPerformanceReply) Reset()         { *m = TestDiskPerformanceReply{} }
+func (m *TestDiskPerformanceReply) String() string { return proto.CompactTextString(m) }
+func (*TestDiskPerformanceReply) ProtoMessage()    {}
+func (*TestDiskPerformanceReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_56ede974c0020f77, []int{42}
+}
+
+func (m *TestDiskPerformanceReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TestDiskPerformanceReply.Unmarshal(m, b)
+}
+func (m *TestDiskPerformanceReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TestDiskPerformanceReply.Marshal(b, m, deterministic)
+}
+func (m *TestDiskPerformanceReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TestDiskPerformanceReply.Merge(m, src)
+}
+func (m *TestDiskPerformanceReply) XXX_Size() int {
+	return xxx_messageInfo_TestDiskPerformanceReply.Size(m)
+}
+func (m *TestDiskPerformanceReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_TestDiskPerformanceReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TestDiskPerformanceReply proto.InternalMessageInfo
+
+func (m *TestDiskPerformanceReply) GetResults() []*DiskResult {
+	if m != nil {
+		return m.Results
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*GetHostNameReply)(nil), "idl.GetHostNameReply")
+	proto.RegisterType((*GetHostNameRequest)(nil), "idl.GetHostNameRequest")
+	proto.RegisterType((*StartSegmentRequest)(nil), "idl.StartSegmentRequest")
+	proto.RegisterType((*StartSegmentReply)(nil), "idl.StartSegmentReply")
+	proto.RegisterType((*StopAgentRequest)(nil), "idl.StopAgentRequest")
+	proto.RegisterType((*StopAgentReply)(nil), "idl.StopAgentReply")
+	proto.RegisterType((*StatusAgentRequest)(nil), "idl.StatusAgentRequest")
+	proto.RegisterType((*StatusAgentReply)(nil), "idl.StatusAgentReply")
+	proto.RegisterType((*ValidateHostEnvRequest)(nil), "idl.ValidateHostEnvRequest")
+	proto.RegisterType((*ValidateHostEnvReply)(nil), "idl.ValidateHostEnvReply")
+	proto.RegisterType((*MakeSegmentRequest)(nil), "idl.MakeSegmentRequest")
+	proto.RegisterMapType((map[string]string)(nil), "idl.MakeSegmentRequest.SegConfigEntry")
+	proto.RegisterType((*SslCertificate)(nil), "idl.SslCertificate")
+	proto.RegisterType((*MakeSegmentReply)(nil), "idl.MakeSegmentReply")
+	proto.RegisterType((*GetInterfaceAddrsRequest)(nil), "idl.GetInterfaceAddrsRequest")
+	proto.RegisterType((*GetInterfaceAddrsResponse)(nil), "idl.GetInterfaceAddrsResponse")
+	proto.RegisterType((*UpdatePgHbaConfRequest)(nil), "idl.UpdatePgHbaConfRequest")
+	proto.RegisterType((*UpdatePgHbaConfResponse)(nil), "idl.UpdatePgHbaConfResponse")
+	proto.RegisterType((*UpdatePgConfRequest)(nil), "idl.UpdatePgConfRequest")
+	proto.RegisterMapType((map[string]string)(nil), "idl.UpdatePgConfRequest.ParamsEntry")
+	proto.RegisterType((*UpdatePgConfRespoonse)(nil), "idl.UpdatePgConfRespoonse")
+	proto.RegisterType((*PgBasebackupRequest)(nil), "idl.PgBasebackupRequest")
+	proto.RegisterType((*PgBasebackupResponse)(nil), "idl.PgBasebackupResponse")
+	proto.RegisterType((*GetPgHbaConfRequest)(nil), "idl.GetPgHbaConfRequest")
+	proto.RegisterType((*GetPgHbaConfReply)(nil), "idl.GetPgHbaConfReply")
+	proto.RegisterType((*ModifyPgHbaConfRequest)(nil), "idl.ModifyPgHbaConfRequest")
+	proto.RegisterType((*ModifyPgHbaConfReply)(nil), "idl.ModifyPgHbaConfReply")
+	proto.RegisterType((*InstallSslCertificateRequest)(nil), "idl.InstallSslCertificateRequest")
+	proto.RegisterType((*InstallSslCertificateReply)(nil), "idl.InstallSslCertificateReply")
+	proto.RegisterType((*ReadLogsRequest)(nil), "idl.ReadLogsRequest")
+	proto.RegisterType((*ReadLogsReply)(nil), "idl.ReadLogsReply")
+	proto.RegisterType((*CollectDiagnosticsRequest)(nil), "idl.CollectDiagnosticsRequest")
+	proto.RegisterType((*CollectDiagnosticsReply)(nil), "idl.CollectDiagnosticsReply")
+	proto.RegisterType((*GetHostInfoRequest)(nil), "idl.GetHostInfoRequest")
+	proto.RegisterType((*GetHostInfoReply)(nil), "idl.GetHostInfoReply")
+	proto.RegisterType((*StartListenersRequest)(nil), "idl.StartListenersRequest")
+	proto.RegisterType((*ListenerStatus)(nil), "idl.ListenerStatus")
+	proto.RegisterType((*StartListenersReply)(nil), "idl.StartListenersReply")
+	proto.RegisterType((*StopListenersRequest)(nil), "idl.StopListenersRequest")
+	proto.RegisterType((*StopListenersReply)(nil), "idl.StopListenersReply")
+	proto.RegisterType((*ConnectivityTarget)(nil), "idl.ConnectivityTarget")
+	proto.RegisterType((*TestConnectivityRequest)(nil), "idl.TestConnectivityRequest")
+	proto.RegisterType((*TestConnectivityReply)(nil), "idl.TestConnectivityReply")
+	proto.RegisterType((*TestDiskPerformanceRequest)(nil), "idl.TestDiskPerformanceRequest")
+	proto.RegisterType((*TestDiskPerformanceReply)(nil), "idl.TestDiskPerformanceReply")
+}
+
+func init() { proto.RegisterFile("agent.proto", fileDescriptor_56ede974c0020f77) }
+
+var fileDescriptor_56ede974c0020f77 = []byte{
+	// 2024 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x58, 0xef, 0x6e, 0x1b, 0xc7,
+	0x11, 0x37, 0x29, 0x91, 0x12, 0x87, 0x34, 0x2d, 0xaf, 0x44, 0xe9, 0x74, 0x55, 0x1c, 0xe5, 0x1a,
+	0x18, 0x6a, 0x6b, 0xab, 0xb6, 0x9a, 0x14, 0xa9, 0x1b, 0x20, 0xb0, 0x25, 0xf9, 0x0f, 0x6a, 0xbb,
+	0xc2, 0xc9, 0x71, 0x80, 0x02, 0xf9, 0xb0, 0xba, 0x5b, 0x92, 0x07, 0x1d, 0x6f, 0xd9, 0xdd, 0xa5,
+	0x65, 0x05, 0x68, 0x1f, 0xa0, 0xcf, 0x51, 0xf4, 0x49, 0xfa, 0xb1, 0x0f, 0x50, 0xf4, 0x4b, 0x5e,
+	0xa5, 0x98, 0xfd, 0x73, 0xbc, 0xe3, 0x1d, 0x1d, 0xe7, 0xdb, 0xcd, 0x6f, 0x66, 0x67, 0x67, 0x66,
+	0x67, 0x67, 0x66, 0x0f, 0xba, 0x74, 0xc4, 0x32, 0x75, 0x38, 0x15, 0x5c, 0x71, 0xb2, 0x92, 0xc4,
+	0xa9, 0xdf, 0x19, 0xcf, 0x2e, 0x0c, 0x1d, 0x1c, 0xc2, 0xc6, 0x33, 0xa6, 0x9e, 0x73, 0xa9, 0x5e,
+	0xd3, 0x09, 0x0b, 0xd9, 0x34, 0xbd, 0x26, 0x3e, 0xac, 0x8f, 0xb9, 0x54, 0x19, 0x9d, 0x30, 0xaf,
+	0xb1, 0xdf, 0x38, 0xe8, 0x84, 0x39, 0x1d, 0x6c, 0x01, 0x29, 0xc9, 0xff, 0x75, 0xc6, 0xa4, 0x0a,
+	0xae, 0x60, 0xf3, 0x5c, 0x51, 0xa1, 0xce, 0xd9, 0x68, 0xc2, 0x32, 0x65, 0x61, 0xe2, 0xc1, 0x5a,
+	0x4c, 0x15, 0x3d, 0x49, 0x84, 0xd5, 0xe3, 0x48, 0x42, 0x60, 0xf5, 0x8a, 0x26, 0xca, 0x6b, 0xee,
+	0x37, 0x0e, 0xd6, 0x43, 0xfd, 0x8d, 0xd2, 0x2a, 0x99, 0x30, 0x3e, 0x53, 0xde, 0xea, 0x7e, 0xe3,
+	0xa0, 0x15, 0x3a, 0x12, 0x39, 0x7c, 0xaa, 0x12, 0x9e, 0x49, 0xaf, 0x65, 0xf4, 0x58, 0x32, 0xd8,
+	0x84, 0xdb, 0xe5, 0x8d, 0xa7, 0xe9, 0x75, 0x40, 0x60, 0xe3, 0x5c, 0xf1, 0xe9, 0xe3, 0xd1, 0xdc,
+	0x94, 0x60, 0x03, 0xfa, 0x05, 0x0c, 0xa5, 0xb6, 0x80, 0x9c, 0x2b, 0xaa, 0x66, 0xb2, 0x24, 0xf7,
+	0x06, 0x36, 0x4a, 0x28, 0xc6, 0x63, 0x1b, 0xda, 0x52, 0x63, 0xd6, 0x0b, 0x4b, 0x21, 0x3e, 0x9b,
+	0xa2, 0x8d, 0xda, 0x8d, 0x4e, 0x68, 0x29, 0xb2, 0x01, 0x2b, 0xd3, 0x24, 0xf6, 0x56, 0xf6, 0x1b,
+	0x07, 0x37, 0x43, 0xfc, 0x0c, 0x7e, 0x6c, 0xc0, 0xf6, 0x5b, 0x9a, 0x26, 0x31, 0x55, 0x0c, 0x63,
+	0x77, 0x9a, 0xbd, 0x73, 0x31, 0x3a, 0x80, 0x5b, 0x18, 0xdc, 0xc7, 0x71, 0x2c, 0x98, 0x94, 0x2f,
+	0x13, 0xa9, 0xbc, 0xc6, 0xfe, 0xca, 0x41, 0x27, 0x5c, 0x84, 0xc9, 0xe7, 0x70, 0xf3, 0x24, 0x11,
+	0x2c, 0x52, 0x5c, 0x5c, 0x6b, 0xb9, 0xa6, 0x96, 0x2b, 0x83, 0x78, 0x78, 0x53, 0x2e, 0x94, 0x16,
+	0x58, 0xd1, 0x02, 0x39, 0x4d, 0x7e, 0x09, 0xed, 0x94, 0x47, 0x34, 0x65, 0x3a, 0xc0, 0xdd, 0xa3,
+	0xee, 0x61, 0x12, 0xa7, 0x87, 0x2f, 0x35, 0x14, 0x5a, 0x16, 0xd9, 0x83, 0xce, 0x68, 0xfa, 0x96,
+	0x09, 0x99, 0xf0, 0xcc, 0x86, 0x7b, 0x0e, 0xa0, 0xcf, 0x43, 0x2e, 0x22, 0x16, 0x7b, 0x6d, 0x7d,
+	0x74, 0x96, 0x0a, 0x8e, 0x61, 0xab, 0xe2, 0x20, 0xc6, 0xee, 0x37, 0xb0, 0x3e, 0x61, 0x52, 0xd2,
+	0x11, 0x93, 0xda, 0xaf, 0xee, 0xd1, 0x2d, 0xbb, 0xe9, 0xe8, 0x95, 0xc1, 0xc3, 0x5c, 0x20, 0xf8,
+	0xcf, 0x2a, 0x90, 0x57, 0xf4, 0x92, 0x2d, 0xa4, 0xd1, 0x5d, 0x58, 0x93, 0x06, 0xd1, 0x07, 0xd0,
+	0x3d, 0xea, 0x69, 0x15, 0x4e, 0xca, 0x31, 0x0b, 0xee, 0x35, 0x97, 0xbb, 0xe7, 0xc3, 0xfa, 0x69,
+	0x16, 0xf1, 0x38, 0xc9, 0x46, 0xfa, 0x84, 0x3a, 0x61, 0x4e, 0x93, 0x13, 0xe8, 0x9c, 0xb3, 0xd1,
+	0x31, 0xcf, 0x86, 0xc9, 0xc8, 0x5b, 0xd5, 0xd6, 0xde, 0xd5, 0x3a, 0xaa, 0x46, 0x1d, 0xe6, 0x82,
+	0xa7, 0x99, 0x12, 0xd7, 0xe1, 0x7c, 0x21, 0xf9, 0x35, 0x6c, 0x44, 0x9c, 0x8b, 0x38, 0xc9, 0xa8,
+	0xe2, 0x02, 0x4f, 0x10, 0xd3, 0x16, 0x4f, 0xa2, 0x82, 0x93, 0x00, 0x7a, 0xe3, 0x0b, 0xea, 0xae,
+	0x93, 0xb4, 0x41, 0x2d, 0x61, 0x78, 0xee, 0x78, 0x6d, 0x8e, 0xc7, 0x2c, 0xba, 0x94, 0xb3, 0x89,
+	0xf4, 0xd6, 0xb4, 0x50, 0x19, 0x44, 0xa9, 0xf1, 0x05, 0x7d, 0x3c, 0x53, 0xe3, 0x57, 0x4c, 0x8d,
+	0x79, 0xec, 0xad, 0x6b, 0xe7, 0xca, 0x20, 0xb9, 0x07, 0xb7, 0xc7, 0x17, 0xf4, 0x5b, 0xc9, 0x44,
+	0x41, 0xb2, 0xa3, 0x25, 0xab, 0x0c, 0x6b, 0x9d, 0x06, 0xb5, 0x17, 0xa0, 0xbd, 0x28, 0x61, 0xe4,
+	0x8f, 0xd0, 0x97, 0x32, 0x3d, 0x66, 0x42, 0x25, 0xc3, 0x24, 0xa2, 0x8a, 0x79, 0x5d, 0x1d, 0xfc,
+	0x4d, 0x73, 0x46, 0x25, 0x56, 0xb8, 0x20, 0x4a, 0xee, 0x00, 0x58, 0x57, 0xa5, 0x4c, 0xbd, 0x9e,
+	0xf6, 0xab, 0x80, 0xf8, 0x5f, 0x43, 0xbf, 0x1c, 0x67, 0xbc, 0x5b, 0x97, 0xec, 0xda, 0x5e, 0x44,
+	0xfc, 0x24, 0x5b, 0xd0, 0x7a, 0x47, 0xd3, 0x99, 0xbb, 0x84, 0x86, 0x78, 0xd4, 0xfc, 0xaa, 0x11,
+	0x3c, 0x85, 0x7e, 0x79, 0x7f, 0x2c, 0x3b, 0x11, 0x13, 0x26, 0x8d, 0x7a, 0xa1, 0xfe, 0x76, 0x1a,
+	0x9b, 0x1a, 0xd2, 0x1a, 0xfb, 0xd0, 0x8c, 0xa8, 0x4e, 0x8e, 0x5e, 0xd8, 0x8c, 0x28, 0xd6, 0x93,
+	0x52, 0x02, 0x60, 0xf5, 0xf0, 0xc1, 0x7b, 0xc6, 0xd4, 0x8b, 0x4c, 0x31, 0x31, 0xa4, 0x11, 0xd3,
+	0xb1, 0x70, 0x35, 0xe4, 0x21, 0xec, 0xd6, 0xf0, 0xe4, 0x94, 0x67, 0x92, 0xa1, 0xb9, 0x54, 0x07,
+	0xd3, 0xdc, 0x72, 0x43, 0x04, 0xff, 0x6d, 0xc0, 0xf6, 0xb7, 0x53, 0xbc, 0x3d, 0x67, 0xa3, 0xe7,
+	0x17, 0x14, 0x3d, 0x76, 0xd9, 0xbf, 0x0d, 0xed, 0xe9, 0x08, 0xcf, 0xda, 0x55, 0x1f, 0x43, 0xcd,
+	0x15, 0x35, 0x0b, 0x8a, 0xc8, 0x3e, 0x74, 0x05, 0x9b, 0xa6, 0xe8, 0x2e, 0xde, 0xdf, 0x15, 0x1d,
+	0xd2, 0x22, 0x84, 0x31, 0xa7, 0xf3, 0xb3, 0x5f, 0xd5, 0x3a, 0x0b, 0x08, 0x16, 0xdb, 0xb1, 0x3d,
+	0x90, 0x96, 0x5e, 0xed, 0x48, 0xf2, 0x05, 0x0c, 0x0a, 0x8a, 0x0a, 0x09, 0xd4, 0xd6, 0x4a, 0xea,
+	0x99, 0xc1, 0x2e, 0xec, 0x54, 0x3c, 0x33, 0xb1, 0x08, 0xfe, 0xdd, 0x80, 0x4d, 0xc7, 0xfb, 0x18,
+	0x97, 0xbf, 0x86, 0xf6, 0x94, 0x0a, 0x3a, 0x31, 0x3e, 0x77, 0x8f, 0x3e, 0xd7, 0x39, 0x56, 0xa3,
+	0xe1, 0xf0, 0x4c, 0x8b, 0x99, 0xab, 0x69, 0xd7, 0x60, 0x61, 0xe3, 0xef, 0x98, 0xb8, 0x12, 0x89,
+	0x62, 0x36, 0x30, 0x73, 0xc0, 0xff, 0x03, 0x74, 0x0b, 0x8b, 0x7e, 0x56, 0x9e, 0xed, 0xc0, 0xa0,
+	0x6c, 0x83, 0x9c, 0x72, 0xed, 0xdf, 0x8f, 0x4d, 0xd8, 0x3c, 0x1b, 0x3d, 0xa1, 0x92, 0x5d, 0xd0,
+	0xe8, 0x72, 0x36, 0x75, 0xfe, 0xed, 0x41, 0x47, 0x51, 0x31, 0x62, 0x6a, 0xde, 0x19, 0xe7, 0x00,
+	0x1e, 0x90, 0xe4, 0x33, 0x11, 0xe9, 0x42, 0x6a, 0x77, 0x2b, 0x20, 0x73, 0xfe, 0x19, 0x17, 0x4a,
+	0x3b, 0xd2, 0x0a, 0x0b, 0x08, 0xf2, 0x23, 0xc1, 0xa8, 0x62, 0xe7, 0x29, 0x37, 0xad, 0x74, 0x3d,
+	0x2c, 0x20, 0xe4, 0x2e, 0xf4, 0x75, 0xd1, 0xfe, 0x73, 0x1e, 0x0c, 0x73, 0xce, 0x0b, 0x28, 0xea,
+	0xb1, 0x46, 0x5d, 0x24, 0xe6, 0x8c, 0x5b, 0x61, 0x01, 0xc1, 0x5a, 0xa2, 0x05, 0x43, 0x16, 0x61,
+	0x18, 0xaf, 0xd1, 0x77, 0x5b, 0x9b, 0xaa, 0x0c, 0xf2, 0x00, 0x36, 0x0b, 0xf9, 0x81, 0x86, 0x60,
+	0x75, 0xb3, 0x55, 0xaa, 0x8e, 0x85, 0xd5, 0x87, 0xbd, 0x8f, 0xd2, 0x59, 0xcc, 0xce, 0xa8, 0x1a,
+	0x4b, 0xaf, 0x63, 0xaa, 0x4f, 0x11, 0x0b, 0xb6, 0x61, 0xab, 0x1c, 0x60, 0x9b, 0x59, 0xf7, 0x61,
+	0xf3, 0x19, 0x53, 0x1f, 0x7b, 0x97, 0x82, 0xfb, 0x70, 0xbb, 0x2c, 0x8e, 0xad, 0xcb, 0x83, 0xb5,
+	0x88, 0x67, 0xca, 0xb5, 0x9d, 0x4e, 0xe8, 0xc8, 0xe0, 0x7f, 0x0d, 0xd8, 0x7e, 0xc5, 0xe3, 0x64,
+	0x78, 0xfd, 0xd1, 0xb7, 0x15, 0x6f, 0x5d, 0x1c, 0x63, 0x6e, 0x25, 0xcc, 0x5d, 0xd9, 0x02, 0x82,
+	0xe5, 0x5b, 0xb0, 0x09, 0x7f, 0xc7, 0x9c, 0x88, 0xe9, 0xdd, 0x65, 0x90, 0xdc, 0xc3, 0xe6, 0x2e,
+	0x13, 0x7d, 0xb5, 0xf1, 0x60, 0xfb, 0x47, 0x1b, 0xfa, 0x0a, 0x3c, 0xbf, 0xa0, 0x67, 0x16, 0x0f,
+	0x73, 0x09, 0x4c, 0x33, 0xc1, 0x86, 0x4c, 0xb0, 0x2c, 0x62, 0xae, 0x93, 0xe7, 0x00, 0x5a, 0x2a,
+	0x58, 0xca, 0x69, 0xde, 0xc9, 0x0d, 0x15, 0xfc, 0x1e, 0xb6, 0x2a, 0xbe, 0x61, 0x38, 0xee, 0x00,
+	0x98, 0x20, 0x3f, 0x4d, 0x52, 0x37, 0x17, 0x16, 0x90, 0x60, 0x02, 0x7b, 0x2f, 0x32, 0xa9, 0x68,
+	0x9a, 0x2e, 0x14, 0xfd, 0x9f, 0x88, 0xcc, 0x97, 0xd0, 0x8d, 0xe6, 0xd2, 0x5e, 0x73, 0x79, 0xf7,
+	0x28, 0xca, 0x05, 0x7b, 0xe0, 0x2f, 0xd9, 0x0e, 0xcb, 0xf3, 0xdf, 0xe0, 0x56, 0xc8, 0x68, 0xfc,
+	0x92, 0x8f, 0xe4, 0x4f, 0xed, 0x4f, 0x60, 0x35, 0x4d, 0x64, 0x3e, 0x8a, 0xa6, 0x89, 0x91, 0x1d,
+	0xf2, 0x34, 0xe5, 0x57, 0xb6, 0x4e, 0x58, 0x8a, 0xdc, 0x85, 0xf6, 0x30, 0x49, 0x15, 0x13, 0x76,
+	0x80, 0xea, 0xbb, 0x59, 0xe6, 0xa9, 0x46, 0x43, 0xcb, 0x0d, 0xbe, 0x87, 0x9b, 0xf3, 0xed, 0x31,
+	0x78, 0x04, 0x56, 0x87, 0xf3, 0xb0, 0xe9, 0x6f, 0x2c, 0x28, 0x69, 0x92, 0xe5, 0xd9, 0x60, 0x08,
+	0x12, 0x40, 0x0b, 0xb9, 0x26, 0x01, 0xdc, 0xa8, 0x63, 0x76, 0x60, 0xa1, 0x61, 0x05, 0x33, 0xd8,
+	0x3d, 0xe6, 0x69, 0xca, 0x22, 0x75, 0x92, 0xd0, 0x51, 0xc6, 0xa5, 0x4a, 0x22, 0x39, 0x1f, 0x28,
+	0xd7, 0xed, 0x40, 0xe4, 0x26, 0xae, 0xf2, 0xb8, 0x94, 0x73, 0xd1, 0x00, 0x99, 0x60, 0x6e, 0xa0,
+	0xeb, 0x2b, 0xa1, 0x21, 0x30, 0xed, 0x27, 0xf4, 0xfd, 0x79, 0xf2, 0x83, 0x29, 0x92, 0x2b, 0xa1,
+	0x23, 0x83, 0xfb, 0xb0, 0x53, 0xb7, 0xad, 0xf5, 0x2f, 0x0f, 0x6d, 0x2f, 0xd4, 0xdf, 0x85, 0xa7,
+	0xc2, 0x8b, 0x6c, 0xc8, 0x5d, 0x73, 0xfc, 0x12, 0x36, 0x4a, 0x28, 0xae, 0xfe, 0x0c, 0x56, 0x93,
+	0x6c, 0xc8, 0xed, 0x74, 0x77, 0xd3, 0xa4, 0xb4, 0x93, 0xd0, 0xac, 0x80, 0xc2, 0x40, 0x0f, 0xfa,
+	0x38, 0xc7, 0xb2, 0x8c, 0xe5, 0xcd, 0x16, 0x9d, 0xc0, 0xf9, 0xd6, 0xf8, 0xda, 0x0a, 0x0d, 0x51,
+	0x7c, 0x4b, 0x34, 0x2b, 0x6f, 0x89, 0x08, 0x87, 0xa6, 0x17, 0xb1, 0x1d, 0xff, 0x1c, 0x19, 0x3c,
+	0x82, 0xbe, 0xd3, 0x6e, 0x9e, 0x00, 0xe8, 0x15, 0xaa, 0xd3, 0x76, 0xb5, 0x42, 0xfd, 0x8d, 0xfb,
+	0x31, 0x21, 0xb8, 0x70, 0x6d, 0x40, 0x13, 0xc1, 0x73, 0xfb, 0x00, 0x2a, 0x98, 0x87, 0x8e, 0x3d,
+	0x84, 0x4e, 0xea, 0x10, 0x7b, 0x18, 0x26, 0xb3, 0xcb, 0x1b, 0x85, 0x73, 0xa9, 0xe0, 0x01, 0x6c,
+	0xe1, 0x43, 0xa5, 0xe2, 0x67, 0xc1, 0xee, 0x46, 0xd9, 0x6e, 0xfd, 0x90, 0x29, 0xad, 0xc0, 0x1b,
+	0xf0, 0x8f, 0x06, 0x90, 0x63, 0x9e, 0x65, 0x2c, 0x52, 0xc9, 0xbb, 0x44, 0x5d, 0xbf, 0xd1, 0x85,
+	0x1b, 0x5d, 0xc2, 0x76, 0xee, 0x12, 0x71, 0xcc, 0x8d, 0x6a, 0x6a, 0xde, 0x19, 0xd6, 0x29, 0x47,
+	0xe6, 0x01, 0x58, 0x29, 0x04, 0xc0, 0x83, 0xb5, 0xe9, 0x4c, 0x4c, 0xb9, 0x64, 0x76, 0x78, 0x70,
+	0xa4, 0xce, 0x1c, 0x46, 0xe5, 0x4c, 0xb8, 0x8e, 0xe2, 0xc8, 0xe0, 0x9f, 0x0d, 0xd8, 0x79, 0xc3,
+	0xa4, 0x2a, 0x1a, 0xe4, 0x1c, 0x7b, 0x08, 0x6b, 0xa6, 0xa9, 0xb8, 0x08, 0xed, 0xe8, 0x08, 0x55,
+	0x6d, 0x0f, 0x9d, 0xdc, 0x07, 0x4e, 0x37, 0x80, 0x9e, 0xdd, 0xf3, 0xc9, 0xb5, 0x62, 0xd2, 0x66,
+	0x70, 0x09, 0x2b, 0x46, 0x72, 0xb5, 0x1c, 0xc9, 0x53, 0x18, 0x54, 0xad, 0xc4, 0x73, 0xbc, 0x07,
+	0x6b, 0x82, 0xc9, 0x59, 0x9a, 0xdb, 0x48, 0xb4, 0x8d, 0xaf, 0x99, 0xba, 0xe2, 0xe2, 0x32, 0xd4,
+	0xac, 0xd0, 0x89, 0x04, 0x7f, 0x07, 0x1f, 0xd5, 0x9c, 0x24, 0xf2, 0xf2, 0x8c, 0x89, 0x21, 0x17,
+	0x13, 0x9a, 0x45, 0x79, 0x1d, 0xdc, 0x87, 0x6e, 0x6c, 0x5f, 0x6c, 0x09, 0x73, 0x63, 0x60, 0x11,
+	0xc2, 0xba, 0x2d, 0x93, 0x1f, 0xac, 0x07, 0xe6, 0x6e, 0xce, 0x01, 0x5c, 0x3f, 0xa1, 0xef, 0x4f,
+	0x66, 0x62, 0x3e, 0xe1, 0xb5, 0xc2, 0x22, 0x14, 0x9c, 0x82, 0x57, 0xbb, 0x3f, 0x7a, 0xf2, 0xab,
+	0x45, 0x4f, 0xcc, 0x73, 0x0c, 0x65, 0x17, 0xdc, 0x38, 0xfa, 0x57, 0x0f, 0x5a, 0xfa, 0x15, 0x4c,
+	0xbe, 0x80, 0x55, 0xcc, 0x30, 0x32, 0x30, 0x85, 0x64, 0xe1, 0x6d, 0xed, 0x6f, 0x2e, 0xc2, 0x98,
+	0x7f, 0x37, 0xc8, 0x23, 0x68, 0xdb, 0x7b, 0xb4, 0x63, 0x05, 0x16, 0x5f, 0xdb, 0xfe, 0xa0, 0xca,
+	0x30, 0x6b, 0xbf, 0x81, 0x6e, 0x61, 0xe4, 0xb6, 0x0a, 0xaa, 0xaf, 0x30, 0x7f, 0x50, 0x65, 0x18,
+	0x05, 0x4f, 0xa0, 0x57, 0xfc, 0x31, 0x40, 0x3c, 0xb7, 0xd3, 0xe2, 0x4f, 0x0a, 0x7f, 0xbb, 0x86,
+	0x63, 0x74, 0xfc, 0x09, 0x6e, 0x2d, 0xbc, 0x69, 0xc9, 0x2f, 0xb4, 0x70, 0xfd, 0x53, 0xde, 0xdf,
+	0xad, 0x67, 0x1a, 0x65, 0x6f, 0xe0, 0x76, 0xe5, 0x51, 0x40, 0x3e, 0xd1, 0x2b, 0x96, 0x3d, 0x24,
+	0xfc, 0x3b, 0xcb, 0xd8, 0x76, 0xca, 0xb9, 0x41, 0xbe, 0x03, 0x6f, 0x61, 0xb8, 0x7e, 0x9c, 0xc5,
+	0xa1, 0x6e, 0xe4, 0xd6, 0xd6, 0xfa, 0x57, 0x85, 0xbf, 0x57, 0xcf, 0xcc, 0x15, 0x3f, 0x85, 0x5e,
+	0x71, 0xa6, 0xb5, 0xf1, 0xab, 0x19, 0xb5, 0x7d, 0xbf, 0x86, 0xe3, 0x06, 0xe0, 0x1b, 0xe4, 0x14,
+	0x7a, 0xc5, 0x01, 0xcd, 0xea, 0xa9, 0x19, 0x8a, 0xfd, 0xdd, 0x1a, 0x4e, 0x6e, 0xce, 0x37, 0xd0,
+	0x2d, 0xfc, 0x76, 0xb2, 0xf9, 0x50, 0xfd, 0x11, 0xe5, 0x0f, 0xaa, 0x8c, 0x3c, 0x1f, 0x8a, 0x13,
+	0x9e, 0xb5, 0xa3, 0x66, 0x46, 0xf4, 0xb7, 0x6b, 0x38, 0xee, 0x08, 0xbd, 0x85, 0xc9, 0x68, 0x31,
+	0xd8, 0xf5, 0x43, 0xa1, 0xbf, 0x5b, 0xcf, 0x34, 0x5a, 0xbf, 0x87, 0x41, 0xed, 0x20, 0x43, 0x3e,
+	0xd3, 0xab, 0x3e, 0x34, 0x53, 0xf9, 0x9f, 0x7e, 0x48, 0xc4, 0x19, 0x4d, 0x8c, 0x89, 0x05, 0x9e,
+	0x24, 0x26, 0xb3, 0xaa, 0x8c, 0x72, 0x7a, 0xd4, 0xf1, 0x8d, 0xd6, 0xaf, 0x60, 0xdd, 0x0d, 0x38,
+	0x64, 0xcb, 0xca, 0x96, 0xc6, 0x2d, 0x9f, 0x2c, 0xa0, 0x7a, 0xdd, 0x83, 0x06, 0x79, 0x0b, 0xa4,
+	0x3a, 0x44, 0x58, 0x7b, 0x96, 0x0e, 0x35, 0xfe, 0xde, 0x52, 0xbe, 0xd3, 0x3b, 0xcf, 0x10, 0x9c,
+	0x1a, 0xca, 0x19, 0x52, 0x98, 0x3f, 0xfc, 0x41, 0x95, 0x61, 0x5c, 0x7a, 0x0e, 0xfd, 0x72, 0x0b,
+	0x27, 0xfe, 0xbc, 0x32, 0x2c, 0xb6, 0x63, 0xdf, 0xab, 0xe5, 0x19, 0x4d, 0xa7, 0x70, 0xb3, 0xd4,
+	0x90, 0xc9, 0x6e, 0x5e, 0x20, 0x2b, 0x7a, 0x76, 0xea, 0x58, 0x46, 0xcd, 0x6b, 0xd8, 0x58, 0xec,
+	0x46, 0xc4, 0xc4, 0x61, 0x49, 0x2b, 0xf5, 0xfd, 0x25, 0x5c, 0xa3, 0xef, 0x3b, 0xd8, 0xac, 0x69,
+	0x0b, 0xe4, 0xd3, 0x7c, 0x51, 0x7d, 0xc3, 0xf2, 0x3f, 0x59, 0x2e, 0xa0, 0x15, 0x3f, 0x59, 0xff,
+	0x4b, 0xfb, 0xf0, 0xf0, 0xb7, 0x49, 0x9c, 0x5e, 0xb4, 0xf5, 0x4f, 0xe5, 0xdf, 0xfd, 0x7f, 0x00,
+	0x37, 0x14, 0x99, 0xc3, 0x73, 0x16, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// AgentClient is the client API for Agent service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type AgentClient interface {
+	Stop(ctx context.Context, in *StopAgentRequest, opts ...grpc.CallOption) (*StopAgentReply, error)
+	Status(ctx context.Context, in *StatusAgentRequest, opts ...grpc.CallOption) (*StatusAgentReply, error)
+	MakeSegment(ctx context.Context, in *MakeSegmentRequest, opts ...grpc.CallOption) (*MakeSegmentReply, error)
+	StartSegment(ctx context.Context, in *StartSegmentRequest, opts ...grpc.CallOption) (*StartSegmentReply, error)
+	ValidateHostEnv(ctx context.Context, in *ValidateHostEnvRequest, opts ...grpc.CallOption) (*ValidateHostEnvReply, error)
+	GetInterfaceAddrs(ctx context.Context, in *GetInterfaceAddrsRequest, opts ...grpc.CallOption) (*GetInterfaceAddrsResponse, error)
+	UpdatePgHbaConfAndReload(ctx context.Context, in *UpdatePgHbaConfRequest, opts ...grpc.CallOption) (*UpdatePgHbaConfResponse, error)
+	UpdatePgConf(ctx context.Context, in *UpdatePgConfRequest, opts ...grpc.CallOption) (*UpdatePgConfRespoonse, error)
+	PgBasebackup(ctx context.Context, in *PgBasebackupRequest, opts ...grpc.CallOption) (*PgBasebackupResponse, error)
+	GetHostName(ctx context.Context, in *GetHostNameRequest, opts ...grpc.CallOption) (*GetHostNameReply, error)
+	GetPgHbaConf(ctx context.Context, in *GetPgHbaConfRequest, opts ...grpc.CallOption) (*GetPgHbaConfReply, error)
+	ModifyPgHbaConfAndReload(ctx context.Context, in *ModifyPgHbaConfRequest, opts ...grpc.CallOption) (*ModifyPgHbaConfReply, error)
+	InstallSslCertificate(ctx context.Context, in *InstallSslCertificateRequest, opts ...grpc.CallOption) (*InstallSslCertificateReply, error)
+	ReloadCertificates(ctx context.Context, in *ReloadCertificatesRequest, opts ...grpc.CallOption) (*ReloadCertificatesReply, error)
+	ReadLogs(ctx context.Context, in *ReadLogsRequest, opts ...grpc.CallOption) (Agent_ReadLogsClient, error)
+	CollectDiagnostics(ctx context.Context, in *CollectDiagnosticsRequest, opts ...grpc.CallOption) (Agent_CollectDiagnosticsClient, error)
+	GetHostInfo(ctx context.Context, in *GetHostInfoRequest, opts ...grpc.CallOption) (*GetHostInfoReply, error)
+	StartListeners(ctx context.Context, in *StartListenersRequest, opts ...grpc.CallOption) (*StartListenersReply, error)
+	StopListeners(ctx context.Context, in *StopListenersRequest, opts ...grpc.CallOption) (*StopListenersReply, error)
+	TestConnectivity(ctx context.Context, in *TestConnectivityRequest, opts ...grpc.CallOption) (*TestConnectivityReply, error)
+	TestDiskPerformance(ctx context.Context, in *TestDiskPerformanceRequest, opts ...grpc.CallOption) (*TestDiskPerformanceReply, error)
+}
+
+type agentClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewAgentClient(cc *grpc.ClientConn) AgentClient {
+	return &agentClient{cc}
+}
+
+func (c *agentClient) Stop(ctx context.Context, in *StopAgentRequest, opts ...grpc.CallOption) (*StopAgentReply, error) {
+	out := new(StopAgentReply)
+	err := c.cc.Invoke(ctx, "/idl.Agent/Stop", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) Status(ctx context.Context, in *StatusAgentRequest, opts ...grpc.CallOption) (*StatusAgentReply, error) {
+	out := new(StatusAgentReply)
+	err := c.cc.Invoke(ctx, "/idl.Agent/Status", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) MakeSegment(ctx context.Context, in *MakeSegmentRequest, opts ...grpc.CallOption) (*MakeSegmentReply, error) {
+	out := new(MakeSegmentReply)
+	err := c.cc.Invoke(ctx, "/idl.Agent/MakeSegment", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) StartSegment(ctx context.Context, in *StartSegmentRequest, opts ...grpc.CallOption) (*StartSegmentReply, error) {
+	out := new(StartSegmentReply)
+	err := c.cc.Invoke(ctx, "/idl.Agent/StartSegment", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) ValidateHostEnv(ctx context.Context, in *ValidateHostEnvRequest, opts ...grpc.CallOption) (*ValidateHostEnvReply, error) {
+	out := new(ValidateHostEnvReply)
+	err := c.cc.Invoke(ctx, "/idl.Agent/ValidateHostEnv", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) GetInterfaceAddrs(ctx context.Context, in *GetInterfaceAddrsRequest, opts ...grpc.CallOption) (*GetInterfaceAddrsResponse, error) {
+	out := new(GetInterfaceAddrsResponse)
+	err := c.cc.Invoke(ctx, "/idl.Agent/GetInterfaceAddrs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) UpdatePgHbaConfAndReload(ctx context.Context, in *UpdatePgHbaConfRequest, opts ...grpc.CallOption) (*UpdatePgHbaConfResponse, error) {
+	out := new(UpdatePgHbaConfResponse)
+	err := c.cc.Invoke(ctx, "/idl.Agent/UpdatePgHbaConfAndReload", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) UpdatePgConf(ctx context.Context, in *UpdatePgConfRequest, opts ...grpc.CallOption) (*UpdatePgConfRespoonse, error) {
+	out := new(UpdatePgConfRespoonse)
+	err := c.cc.Invoke(ctx, "/idl.Agent/UpdatePgConf", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) PgBasebackup(ctx context.Context, in *PgBasebackupRequest, opts ...grpc.CallOption) (*PgBasebackupResponse, error) {
+	out := new(PgBasebackupResponse)
+	err := c.cc.Invoke(ctx, "/idl.Agent/PgBasebackup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) GetHostName(ctx context.Context, in *GetHostNameRequest, opts ...grpc.CallOption) (*GetHostNameReply, error) {
+	out := new(GetHostNameReply)
+	err := c.cc.Invoke(ctx, "/idl.Agent/GetHostName", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) GetPgHbaConf(ctx context.Context, in *GetPgHbaConfRequest, opts ...grpc.CallOption) (*GetPgHbaConfReply, error) {
+	out := new(GetPgHbaConfReply)
+	err := c.cc.Invoke(ctx, "/idl.Agent/GetPgHbaConf", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) ModifyPgHbaConfAndReload(ctx context.Context, in *ModifyPgHbaConfRequest, opts ...grpc.CallOption) (*ModifyPgHbaConfReply, error) {
+	out := new(ModifyPgHbaConfReply)
+	err := c.cc.Invoke(ctx, "/idl.Agent/ModifyPgHbaConfAndReload", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) InstallSslCertificate(ctx context.Context, in *InstallSslCertificateRequest, opts ...grpc.CallOption) (*InstallSslCertificateReply, error) {
+	out := new(InstallSslCertificateReply)
+	err := c.cc.Invoke(ctx, "/idl.Agent/InstallSslCertificate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) ReloadCertificates(ctx context.Context, in *ReloadCertificatesRequest, opts ...grpc.CallOption) (*ReloadCertificatesReply, error) {
+	out := new(ReloadCertificatesReply)
+	err := c.cc.Invoke(ctx, "/idl.Agent/ReloadCertificates", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) ReadLogs(ctx context.Context, in *ReadLogsRequest, opts ...grpc.CallOption) (Agent_ReadLogsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Agent_serviceDesc.Streams[0], "/idl.Agent/ReadLogs", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &agentReadLogsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Agent_ReadLogsClient interface {
+	Recv() (*ReadLogsReply, error)
+	grpc.ClientStream
+}
+
+type agentReadLogsClient struct {
+	grpc.ClientStream
+}
+
+func (x *agentReadLogsClient) Recv() (*ReadLogsReply, error) {
+	m := new(ReadLogsReply)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *agentClient) CollectDiagnostics(ctx context.Context, in *CollectDiagnosticsRequest, opts ...grpc.CallOption) (Agent_CollectDiagnosticsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Agent_serviceDesc.Streams[1], "/idl.Agent/CollectDiagnostics", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &agentCollectDiagnosticsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Agent_CollectDiagnosticsClient interface {
+	Recv() (*CollectDiagnosticsReply, error)
+	grpc.ClientStream
+}
+
+type agentCollectDiagnosticsClient struct {
+	grpc.ClientStream
+}
+
+func (x *agentCollectDiagnosticsClient) Recv() (*CollectDiagnosticsReply, error) {
+	m := new(CollectDiagnosticsReply)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *agentClient) GetHostInfo(ctx context.Context, in *GetHostInfoRequest, opts ...grpc.CallOption) (*GetHostInfoReply, error) {
+	out := new(GetHostInfoReply)
+	err := c.cc.Invoke(ctx, "/idl.Agent/GetHostInfo", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) StartListeners(ctx context.Context, in *StartListenersRequest, opts ...grpc.CallOption) (*StartListenersReply, error) {
+	out := new(StartListenersReply)
+	err := c.cc.Invoke(ctx, "/idl.Agent/StartListeners", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) StopListeners(ctx context.Context, in *StopListenersRequest, opts ...grpc.CallOption) (*StopListenersReply, error) {
+	out := new(StopListenersReply)
+	err := c.cc.Invoke(ctx, "/idl.Agent/StopListeners", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) TestConnectivity(ctx context.Context, in *TestConnectivityRequest, opts ...grpc.CallOption) (*TestConnectivityReply, error) {
+	out := new(TestConnectivityReply)
+	err := c.cc.Invoke(ctx, "/idl.Agent/TestConnectivity", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) TestDiskPerformance(ctx context.Context, in *TestDiskPerformanceRequest, opts ...grpc.CallOption) (*TestDiskPerformanceReply, error) {
+	out := new(TestDiskPerformanceReply)
+	err := c.cc.Invoke(ctx, "/idl.Agent/TestDiskPerformance", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AgentServer is the server API for Agent service.
+type AgentServer interface {
+	Stop(context.Context, *StopAgentRequest) (*StopAgentReply, error)
+	Status(context.Context, *StatusAgentRequest) (*StatusAgentReply, error)
+	MakeSegment(context.Context, *MakeSegmentRequest) (*MakeSegmentReply, error)
+	StartSegment(context.Context, *StartSegmentRequest) (*StartSegmentReply, error)
+	ValidateHostEnv(context.Context, *ValidateHostEnvRequest) (*ValidateHostEnvReply, error)
+	GetInterfaceAddrs(context.Context, *GetInterfaceAddrsRequest) (*GetInterfaceAddrsResponse, error)
+	UpdatePgHbaConfAndReload(context.Context, *UpdatePgHbaConfRequest) (*UpdatePgHbaConfResponse, error)
+	UpdatePgConf(context.Context, *UpdatePgConfRequest) (*UpdatePgConfRespoonse, error)
+	PgBasebackup(context.Context, *PgBasebackupRequest) (*PgBasebackupResponse, error)
+	GetHostName(context.Context, *GetHostNameRequest) (*GetHostNameReply, error)
+	GetPgHbaConf(context.Context, *GetPgHbaConfRequest) (*GetPgHbaConfReply, error)
+	ModifyPgHbaConfAndReload(context.Context, *ModifyPgHbaConfRequest) (*ModifyPgHbaConfReply, error)
+	InstallSslCertificate(context.Context, *InstallSslCertificateRequest) (*InstallSslCertificateReply, error)
+	ReloadCertificates(context.Context, *ReloadCertificatesRequest) (*ReloadCertificatesReply, error)
+	ReadLogs(*ReadLogsRequest, Agent_ReadLogsServer) error
+	CollectDiagnostics(*CollectDiagnosticsRequest, Agent_CollectDiagnosticsServer) error
+	GetHostInfo(context.Context, *GetHostInfoRequest) (*GetHostInfoReply, error)
+	StartListeners(context.Context, *StartListenersRequest) (*StartListenersReply, error)
+	StopListeners(context.Context, *StopListenersRequest) (*StopListenersReply, error)
+	TestConnectivity(context.Context, *TestConnectivityRequest) (*TestConnectivityReply, error)
+	TestDiskPerformance(context.Context, *TestDiskPerformanceRequest) (*TestDiskPerformanceReply, error)
 }
 
 // UnimplementedAgentServer can be embedded to have forward compatible implementations.
@@ -1215,6 +2619,39 @@ func (*UnimplementedAgentServer) PgBasebackup(ctx context.Context, req *PgBaseba
 func (*UnimplementedAgentServer) GetHostName(ctx context.Context, req *GetHostNameRequest) (*GetHostNameReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetHostName not implemented")
 }
+func (*UnimplementedAgentServer) GetPgHbaConf(ctx context.Context, req *GetPgHbaConfRequest) (*GetPgHbaConfReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPgHbaConf not implemented")
+}
+func (*UnimplementedAgentServer) ModifyPgHbaConfAndReload(ctx context.Context, req *ModifyPgHbaConfRequest) (*ModifyPgHbaConfReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ModifyPgHbaConfAndReload not implemented")
+}
+func (*UnimplementedAgentServer) InstallSslCertificate(ctx context.Context, req *InstallSslCertificateRequest) (*InstallSslCertificateReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method InstallSslCertificate not implemented")
+}
+func (*UnimplementedAgentServer) ReloadCertificates(ctx context.Context, req *ReloadCertificatesRequest) (*ReloadCertificatesReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReloadCertificates not implemented")
+}
+func (*UnimplementedAgentServer) ReadLogs(req *ReadLogsRequest, srv Agent_ReadLogsServer) error {
+	return status.Errorf(codes.Unimplemented, "method ReadLogs not implemented")
+}
+func (*UnimplementedAgentServer) CollectDiagnostics(req *CollectDiagnosticsRequest, srv Agent_CollectDiagnosticsServer) error {
+	return status.Errorf(codes.Unimplemented, "method CollectDiagnostics not implemented")
+}
+func (*UnimplementedAgentServer) GetHostInfo(ctx context.Context, req *GetHostInfoRequest) (*GetHostInfoReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetHostInfo not implemented")
+}
+func (*UnimplementedAgentServer) StartListeners(ctx context.Context, req *StartListenersRequest) (*StartListenersReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartListeners not implemented")
+}
+func (*UnimplementedAgentServer) StopListeners(ctx context.Context, req *StopListenersRequest) (*StopListenersReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StopListeners not implemented")
+}
+func (*UnimplementedAgentServer) TestConnectivity(ctx context.Context, req *TestConnectivityRequest) (*TestConnectivityReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TestConnectivity not implemented")
+}
+func (*UnimplementedAgentServer) TestDiskPerformance(ctx context.Context, req *TestDiskPerformanceRequest) (*TestDiskPerformanceReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TestDiskPerformance not implemented")
+}
 
 func RegisterAgentServer(s *grpc.Server, srv AgentServer) {
 	s.RegisterService(&_Agent_serviceDesc, srv)
@@ -1400,6 +2837,210 @@ func _Agent_GetHostName_Handler(srv interface{}, ctx context.Context, dec func(i
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Agent_GetPgHbaConf_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPgHbaConfRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServer).GetPgHbaConf(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/idl.Agent/GetPgHbaConf",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServer).GetPgHbaConf(ctx, req.(*GetPgHbaConfRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Agent_ModifyPgHbaConfAndReload_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ModifyPgHbaConfRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServer).ModifyPgHbaConfAndReload(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/idl.Agent/ModifyPgHbaConfAndReload",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServer).ModifyPgHbaConfAndReload(ctx, req.(*ModifyPgHbaConfRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Agent_InstallSslCertificate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InstallSslCertificateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServer).InstallSslCertificate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/idl.Agent/InstallSslCertificate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServer).InstallSslCertificate(ctx, req.(*InstallSslCertificateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Agent_ReloadCertificates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReloadCertificatesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServer).ReloadCertificates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/idl.Agent/ReloadCertificates",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServer).ReloadCertificates(ctx, req.(*ReloadCertificatesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Agent_ReadLogs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ReadLogsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AgentServer).ReadLogs(m, &agentReadLogsServer{stream})
+}
+
+type Agent_ReadLogsServer interface {
+	Send(*ReadLogsReply) error
+	grpc.ServerStream
+}
+
+type agentReadLogsServer struct {
+	grpc.ServerStream
+}
+
+func (x *agentReadLogsServer) Send(m *ReadLogsReply) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Agent_CollectDiagnostics_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(CollectDiagnosticsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AgentServer).CollectDiagnostics(m, &agentCollectDiagnosticsServer{stream})
+}
+
+type Agent_CollectDiagnosticsServer interface {
+	Send(*CollectDiagnosticsReply) error
+	grpc.ServerStream
+}
+
+type agentCollectDiagnosticsServer struct {
+	grpc.ServerStream
+}
+
+func (x *agentCollectDiagnosticsServer) Send(m *CollectDiagnosticsReply) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Agent_GetHostInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetHostInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServer).GetHostInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/idl.Agent/GetHostInfo",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServer).GetHostInfo(ctx, req.(*GetHostInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Agent_StartListeners_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartListenersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServer).StartListeners(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/idl.Agent/StartListeners",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServer).StartListeners(ctx, req.(*StartListenersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Agent_StopListeners_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StopListenersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServer).StopListeners(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/idl.Agent/StopListeners",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServer).StopListeners(ctx, req.(*StopListenersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Agent_TestConnectivity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TestConnectivityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServer).TestConnectivity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/idl.Agent/TestConnectivity",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServer).TestConnectivity(ctx, req.(*TestConnectivityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Agent_TestDiskPerformance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TestDiskPerformanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServer).TestDiskPerformance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/idl.Agent/TestDiskPerformance",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServer).TestDiskPerformance(ctx, req.(*TestDiskPerformanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Agent_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "idl.Agent",
 	HandlerType: (*AgentServer)(nil),
@@ -1444,7 +3085,54 @@ var _Agent_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetHostName",
 			Handler:    _Agent_GetHostName_Handler,
 		},
+		{
+			MethodName: "GetPgHbaConf",
+			Handler:    _Agent_GetPgHbaConf_Handler,
+		},
+		{
+			MethodName: "ModifyPgHbaConfAndReload",
+			Handler:    _Agent_ModifyPgHbaConfAndReload_Handler,
+		},
+		{
+			MethodName: "InstallSslCertificate",
+			Handler:    _Agent_InstallSslCertificate_Handler,
+		},
+		{
+			MethodName: "ReloadCertificates",
+			Handler:    _Agent_ReloadCertificates_Handler,
+		},
+		{
+			MethodName: "GetHostInfo",
+			Handler:    _Agent_GetHostInfo_Handler,
+		},
+		{
+			MethodName: "StartListeners",
+			Handler:    _Agent_StartListeners_Handler,
+		},
+		{
+			MethodName: "StopListeners",
+			Handler:    _Agent_StopListeners_Handler,
+		},
+		{
+			MethodName: "TestConnectivity",
+			Handler:    _Agent_TestConnectivity_Handler,
+		},
+		{
+			MethodName: "TestDiskPerformance",
+			Handler:    _Agent_TestDiskPerformance_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ReadLogs",
+			Handler:       _Agent_ReadLogs_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "CollectDiagnostics",
+			Handler:       _Agent_CollectDiagnostics_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "agent.proto",
 }
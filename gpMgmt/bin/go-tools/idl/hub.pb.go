@@ -58,13 +58,45 @@ func (LogLevel) EnumDescriptor() ([]byte, []int) {
 	return fileDescriptor_b3103f8d3056b01c, []int{0}
 }
 
+type HbaPosition int32
+
+const (
+	HbaPosition_APPEND HbaPosition = 0
+	HbaPosition_BEFORE HbaPosition = 1
+	HbaPosition_AFTER  HbaPosition = 2
+)
+
+var HbaPosition_name = map[int32]string{
+	0: "APPEND",
+	1: "BEFORE",
+	2: "AFTER",
+}
+
+var HbaPosition_value = map[string]int32{
+	"APPEND": 0,
+	"BEFORE": 1,
+	"AFTER":  2,
+}
+
+func (x HbaPosition) String() string {
+	return proto.EnumName(HbaPosition_name, int32(x))
+}
+
+func (HbaPosition) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_b3103f8d3056b01c, []int{1}
+}
+
 type AddMirrorsRequest struct {
-	CoordinatorDataDir   string     `protobuf:"bytes,1,opt,name=CoordinatorDataDir,proto3" json:"CoordinatorDataDir,omitempty"`
-	HbaHostnames         bool       `protobuf:"varint,2,opt,name=HbaHostnames,proto3" json:"HbaHostnames,omitempty"`
-	Mirrors              []*Segment `protobuf:"bytes,3,rep,name=mirrors,proto3" json:"mirrors,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
-	XXX_unrecognized     []byte     `json:"-"`
-	XXX_sizecache        int32      `json:"-"`
+	CoordinatorDataDir       string       `protobuf:"bytes,1,opt,name=CoordinatorDataDir,proto3" json:"CoordinatorDataDir,omitempty"`
+	HbaHostnames             bool         `protobuf:"varint,2,opt,name=HbaHostnames,proto3" json:"HbaHostnames,omitempty"`
+	Mirrors                  []*Segment   `protobuf:"bytes,3,rep,name=mirrors,proto3" json:"mirrors,omitempty"`
+	HbaAuthMethod            string       `protobuf:"bytes,4,opt,name=hbaAuthMethod,proto3" json:"hbaAuthMethod,omitempty"`
+	Ssl                      *SslParams   `protobuf:"bytes,5,opt,name=ssl,proto3" json:"ssl,omitempty"`
+	Parallelism              *Parallelism `protobuf:"bytes,6,opt,name=parallelism,proto3" json:"parallelism,omitempty"`
+	HbaReplicationAuthMethod string       `protobuf:"bytes,7,opt,name=hbaReplicationAuthMethod,proto3" json:"hbaReplicationAuthMethod,omitempty"`
+	XXX_NoUnkeyedLiteral     struct{}     `json:"-"`
+	XXX_unrecognized         []byte       `json:"-"`
+	XXX_sizecache            int32        `json:"-"`
 }
 
 func (m *AddMirrorsRequest) Reset()         { *m = AddMirrorsRequest{} }
@@ -113,6 +145,34 @@ func (m *AddMirrorsRequest) GetMirrors() []*Segment {
 	return nil
 }
 
+func (m *AddMirrorsRequest) GetHbaAuthMethod() string {
+	if m != nil {
+		return m.HbaAuthMethod
+	}
+	return ""
+}
+
+func (m *AddMirrorsRequest) GetSsl() *SslParams {
+	if m != nil {
+		return m.Ssl
+	}
+	return nil
+}
+
+func (m *AddMirrorsRequest) GetParallelism() *Parallelism {
+	if m != nil {
+		return m.Parallelism
+	}
+	return nil
+}
+
+func (m *AddMirrorsRequest) GetHbaReplicationAuthMethod() string {
+	if m != nil {
+		return m.HbaReplicationAuthMethod
+	}
+	return ""
+}
+
 type GetAllHostNamesRequest struct {
 	HostList             []string `protobuf:"bytes,1,rep,name=hostList,proto3" json:"hostList,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -351,6 +411,7 @@ type ServiceStatus struct {
 	Status               string   `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
 	Uptime               string   `protobuf:"bytes,3,opt,name=uptime,proto3" json:"uptime,omitempty"`
 	Pid                  uint32   `protobuf:"varint,4,opt,name=pid,proto3" json:"pid,omitempty"`
+	Error                string   `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -409,6 +470,13 @@ func (m *ServiceStatus) GetPid() uint32 {
 	return 0
 }
 
+func (m *ServiceStatus) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
 type StatusAgentsReply struct {
 	Statuses             []*ServiceStatus `protobuf:"bytes,1,rep,name=statuses,proto3" json:"statuses,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
@@ -515,6 +583,7 @@ type MakeClusterRequest struct {
 	ClusterParams        *ClusterParams `protobuf:"bytes,2,opt,name=clusterParams,proto3" json:"clusterParams,omitempty"`
 	ForceFlag            bool           `protobuf:"varint,3,opt,name=forceFlag,proto3" json:"forceFlag,omitempty"`
 	Verbose              bool           `protobuf:"varint,4,opt,name=verbose,proto3" json:"verbose,omitempty"`
+	Parallelism          *Parallelism   `protobuf:"bytes,5,opt,name=parallelism,proto3" json:"parallelism,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
 	XXX_unrecognized     []byte         `json:"-"`
 	XXX_sizecache        int32          `json:"-"`
@@ -573,6 +642,13 @@ func (m *MakeClusterRequest) GetVerbose() bool {
 	return false
 }
 
+func (m *MakeClusterRequest) GetParallelism() *Parallelism {
+	if m != nil {
+		return m.Parallelism
+	}
+	return nil
+}
+
 type HubReply struct {
 	// Types that are valid to be assigned to Message:
 	//
@@ -937,18 +1013,24 @@ func (m *SegmentPair) GetMirror() *Segment {
 }
 
 type ClusterParams struct {
-	CoordinatorConfig    map[string]string `protobuf:"bytes,1,rep,name=CoordinatorConfig,proto3" json:"CoordinatorConfig,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	SegmentConfig        map[string]string `protobuf:"bytes,2,rep,name=SegmentConfig,proto3" json:"SegmentConfig,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	CommonConfig         map[string]string `protobuf:"bytes,3,rep,name=CommonConfig,proto3" json:"CommonConfig,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	Locale               *Locale           `protobuf:"bytes,4,opt,name=locale,proto3" json:"locale,omitempty"`
-	HbaHostnames         bool              `protobuf:"varint,5,opt,name=hbaHostnames,proto3" json:"hbaHostnames,omitempty"`
-	Encoding             string            `protobuf:"bytes,6,opt,name=encoding,proto3" json:"encoding,omitempty"`
-	SuPassword           string            `protobuf:"bytes,7,opt,name=suPassword,proto3" json:"suPassword,omitempty"`
-	DbName               string            `protobuf:"bytes,8,opt,name=dbName,proto3" json:"dbName,omitempty"`
-	DataChecksums        bool              `protobuf:"varint,9,opt,name=dataChecksums,proto3" json:"dataChecksums,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
-	XXX_unrecognized     []byte            `json:"-"`
-	XXX_sizecache        int32             `json:"-"`
+	CoordinatorConfig map[string]string `protobuf:"bytes,1,rep,name=CoordinatorConfig,proto3" json:"CoordinatorConfig,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	SegmentConfig     map[string]string `protobuf:"bytes,2,rep,name=SegmentConfig,proto3" json:"SegmentConfig,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	CommonConfig      map[string]string `protobuf:"bytes,3,rep,name=CommonConfig,proto3" json:"CommonConfig,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Locale            *Locale           `protobuf:"bytes,4,opt,name=locale,proto3" json:"locale,omitempty"`
+	HbaHostnames      bool              `protobuf:"varint,5,opt,name=hbaHostnames,proto3" json:"hbaHostnames,omitempty"`
+	Encoding          string            `protobuf:"bytes,6,opt,name=encoding,proto3" json:"encoding,omitempty"`
+	// SCRAM-SHA-256 verifier of the superuser password, the password itself is never sent
+	SuPasswordVerifier       string     `protobuf:"bytes,7,opt,name=suPasswordVerifier,proto3" json:"suPasswordVerifier,omitempty"`
+	DbName                   string     `protobuf:"bytes,8,opt,name=dbName,proto3" json:"dbName,omitempty"`
+	DataChecksums            bool       `protobuf:"varint,9,opt,name=dataChecksums,proto3" json:"dataChecksums,omitempty"`
+	HbaAuthMethod            string     `protobuf:"bytes,10,opt,name=hbaAuthMethod,proto3" json:"hbaAuthMethod,omitempty"`
+	HbaUserAuthMethod        string     `protobuf:"bytes,11,opt,name=hbaUserAuthMethod,proto3" json:"hbaUserAuthMethod,omitempty"`
+	HbaUserAddrs             []string   `protobuf:"bytes,12,rep,name=hbaUserAddrs,proto3" json:"hbaUserAddrs,omitempty"`
+	Ssl                      *SslParams `protobuf:"bytes,13,opt,name=ssl,proto3" json:"ssl,omitempty"`
+	HbaReplicationAuthMethod string     `protobuf:"bytes,14,opt,name=hbaReplicationAuthMethod,proto3" json:"hbaReplicationAuthMethod,omitempty"`
+	XXX_NoUnkeyedLiteral     struct{}   `json:"-"`
+	XXX_unrecognized         []byte     `json:"-"`
+	XXX_sizecache            int32      `json:"-"`
 }
 
 func (m *ClusterParams) Reset()         { *m = ClusterParams{} }
@@ -1018,9 +1100,9 @@ func (m *ClusterParams) GetEncoding() string {
 	return ""
 }
 
-func (m *ClusterParams) GetSuPassword() string {
+func (m *ClusterParams) GetSuPasswordVerifier() string {
 	if m != nil {
-		return m.SuPassword
+		return m.SuPasswordVerifier
 	}
 	return ""
 }
@@ -1039,6 +1121,207 @@ func (m *ClusterParams) GetDataChecksums() bool {
 	return false
 }
 
+func (m *ClusterParams) GetHbaAuthMethod() string {
+	if m != nil {
+		return m.HbaAuthMethod
+	}
+	return ""
+}
+
+func (m *ClusterParams) GetHbaUserAuthMethod() string {
+	if m != nil {
+		return m.HbaUserAuthMethod
+	}
+	return ""
+}
+
+func (m *ClusterParams) GetHbaUserAddrs() []string {
+	if m != nil {
+		return m.HbaUserAddrs
+	}
+	return nil
+}
+
+func (m *ClusterParams) GetSsl() *SslParams {
+	if m != nil {
+		return m.Ssl
+	}
+	return nil
+}
+
+func (m *ClusterParams) GetHbaReplicationAuthMethod() string {
+	if m != nil {
+		return m.HbaReplicationAuthMethod
+	}
+	return ""
+}
+
+type SslCertFiles struct {
+	CertFile             string   `protobuf:"bytes,1,opt,name=certFile,proto3" json:"certFile,omitempty"`
+	KeyFile              string   `protobuf:"bytes,2,opt,name=keyFile,proto3" json:"keyFile,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SslCertFiles) Reset()         { *m = SslCertFiles{} }
+func (m *SslCertFiles) String() string { return proto.CompactTextString(m) }
+func (*SslCertFiles) ProtoMessage()    {}
+func (*SslCertFiles) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b3103f8d3056b01c, []int{20}
+}
+
+func (m *SslCertFiles) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SslCertFiles.Unmarshal(m, b)
+}
+func (m *SslCertFiles) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SslCertFiles.Marshal(b, m, deterministic)
+}
+func (m *SslCertFiles) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SslCertFiles.Merge(m, src)
+}
+func (m *SslCertFiles) XXX_Size() int {
+	return xxx_messageInfo_SslCertFiles.Size(m)
+}
+func (m *SslCertFiles) XXX_DiscardUnknown() {
+	xxx_messageInfo_SslCertFiles.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SslCertFiles proto.InternalMessageInfo
+
+func (m *SslCertFiles) GetCertFile() string {
+	if m != nil {
+		return m.CertFile
+	}
+	return ""
+}
+
+func (m *SslCertFiles) GetKeyFile() string {
+	if m != nil {
+		return m.KeyFile
+	}
+	return ""
+}
+
+type SslParams struct {
+	Enabled              bool                     `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	CaFile               string                   `protobuf:"bytes,2,opt,name=caFile,proto3" json:"caFile,omitempty"`
+	DefaultCert          *SslCertFiles            `protobuf:"bytes,3,opt,name=defaultCert,proto3" json:"defaultCert,omitempty"`
+	HostCerts            map[string]*SslCertFiles `protobuf:"bytes,4,rep,name=hostCerts,proto3" json:"hostCerts,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	HbaHostssl           bool                     `protobuf:"varint,5,opt,name=hbaHostssl,proto3" json:"hbaHostssl,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                 `json:"-"`
+	XXX_unrecognized     []byte                   `json:"-"`
+	XXX_sizecache        int32                    `json:"-"`
+}
+
+func (m *SslParams) Reset()         { *m = SslParams{} }
+func (m *SslParams) String() string { return proto.CompactTextString(m) }
+func (*SslParams) ProtoMessage()    {}
+func (*SslParams) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b3103f8d3056b01c, []int{21}
+}
+
+func (m *SslParams) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SslParams.Unmarshal(m, b)
+}
+func (m *SslParams) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SslParams.Marshal(b, m, deterministic)
+}
+func (m *SslParams) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SslParams.Merge(m, src)
+}
+func (m *SslParams) XXX_Size() int {
+	return xxx_messageInfo_SslParams.Size(m)
+}
+func (m *SslParams) XXX_DiscardUnknown() {
+	xxx_messageInfo_SslParams.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SslParams proto.InternalMessageInfo
+
+func (m *SslParams) GetEnabled() bool {
+	if m != nil {
+		return m.Enabled
+	}
+	return false
+}
+
+func (m *SslParams) GetCaFile() string {
+	if m != nil {
+		return m.CaFile
+	}
+	return ""
+}
+
+func (m *SslParams) GetDefaultCert() *SslCertFiles {
+	if m != nil {
+		return m.DefaultCert
+	}
+	return nil
+}
+
+func (m *SslParams) GetHostCerts() map[string]*SslCertFiles {
+	if m != nil {
+		return m.HostCerts
+	}
+	return nil
+}
+
+func (m *SslParams) GetHbaHostssl() bool {
+	if m != nil {
+		return m.HbaHostssl
+	}
+	return false
+}
+
+// Limits of the segment operations run at once, the ones of gp.conf when not set
+type Parallelism struct {
+	BatchSize            int32    `protobuf:"varint,1,opt,name=batchSize,proto3" json:"batchSize,omitempty"`
+	Parallel             int32    `protobuf:"varint,2,opt,name=parallel,proto3" json:"parallel,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Parallelism) Reset()         { *m = Parallelism{} }
+func (m *Parallelism) String() string { return proto.CompactTextString(m) }
+func (*Parallelism) ProtoMessage()    {}
+func (*Parallelism) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b3103f8d3056b01c, []int{22}
+}
+
+func (m *Parallelism) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Parallelism.Unmarshal(m, b)
+}
+func (m *Parallelism) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Parallelism.Marshal(b, m, deterministic)
+}
+func (m *Parallelism) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Parallelism.Merge(m, src)
+}
+func (m *Parallelism) XXX_Size() int {
+	return xxx_messageInfo_Parallelism.Size(m)
+}
+func (m *Parallelism) XXX_DiscardUnknown() {
+	xxx_messageInfo_Parallelism.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Parallelism proto.InternalMessageInfo
+
+func (m *Parallelism) GetBatchSize() int32 {
+	if m != nil {
+		return m.BatchSize
+	}
+	return 0
+}
+
+func (m *Parallelism) GetParallel() int32 {
+	if m != nil {
+		return m.Parallel
+	}
+	return 0
+}
+
 type Locale struct {
 	LcAll                string   `protobuf:"bytes,1,opt,name=lc_all,json=lcAll,proto3" json:"lc_all,omitempty"`
 	LcCollate            string   `protobuf:"bytes,2,opt,name=lc_collate,json=lcCollate,proto3" json:"lc_collate,omitempty"`
@@ -1056,7 +1339,7 @@ func (m *Locale) Reset()         { *m = Locale{} }
 func (m *Locale) String() string { return proto.CompactTextString(m) }
 func (*Locale) ProtoMessage()    {}
 func (*Locale) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b3103f8d3056b01c, []int{20}
+	return fileDescriptor_b3103f8d3056b01c, []int{23}
 }
 
 func (m *Locale) XXX_Unmarshal(b []byte) error {
@@ -1126,186 +1409,2098 @@ func (m *Locale) GetLcTime() string {
 	return ""
 }
 
-func init() {
-	proto.RegisterEnum("idl.LogLevel", LogLevel_name, LogLevel_value)
-	proto.RegisterType((*AddMirrorsRequest)(nil), "idl.AddMirrorsRequest")
-	proto.RegisterType((*GetAllHostNamesRequest)(nil), "idl.GetAllHostNamesRequest")
-	proto.RegisterType((*GetAllHostNamesReply)(nil), "idl.GetAllHostNamesReply")
-	proto.RegisterMapType((map[string]string)(nil), "idl.GetAllHostNamesReply.HostNameMapEntry")
-	proto.RegisterType((*StopHubRequest)(nil), "idl.StopHubRequest")
-	proto.RegisterType((*StopHubReply)(nil), "idl.StopHubReply")
-	proto.RegisterType((*StartAgentsRequest)(nil), "idl.StartAgentsRequest")
-	proto.RegisterType((*StartAgentsReply)(nil), "idl.StartAgentsReply")
-	proto.RegisterType((*StatusAgentsRequest)(nil), "idl.StatusAgentsRequest")
-	proto.RegisterType((*ServiceStatus)(nil), "idl.ServiceStatus")
-	proto.RegisterType((*StatusAgentsReply)(nil), "idl.StatusAgentsReply")
-	proto.RegisterType((*StopAgentsRequest)(nil), "idl.StopAgentsRequest")
-	proto.RegisterType((*StopAgentsReply)(nil), "idl.StopAgentsReply")
-	proto.RegisterType((*MakeClusterRequest)(nil), "idl.MakeClusterRequest")
-	proto.RegisterType((*HubReply)(nil), "idl.HubReply")
-	proto.RegisterType((*LogMessage)(nil), "idl.LogMessage")
-	proto.RegisterType((*ProgressMessage)(nil), "idl.ProgressMessage")
-	proto.RegisterType((*GpArray)(nil), "idl.gpArray")
-	proto.RegisterType((*Segment)(nil), "idl.Segment")
-	proto.RegisterType((*SegmentPair)(nil), "idl.SegmentPair")
-	proto.RegisterType((*ClusterParams)(nil), "idl.ClusterParams")
-	proto.RegisterMapType((map[string]string)(nil), "idl.ClusterParams.CommonConfigEntry")
-	proto.RegisterMapType((map[string]string)(nil), "idl.ClusterParams.CoordinatorConfigEntry")
-	proto.RegisterMapType((map[string]string)(nil), "idl.ClusterParams.SegmentConfigEntry")
-	proto.RegisterType((*Locale)(nil), "idl.Locale")
+type HbaTargets struct {
+	Coordinator          bool     `protobuf:"varint,1,opt,name=coordinator,proto3" json:"coordinator,omitempty"`
+	Standby              bool     `protobuf:"varint,2,opt,name=standby,proto3" json:"standby,omitempty"`
+	Segments             bool     `protobuf:"varint,3,opt,name=segments,proto3" json:"segments,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func init() { proto.RegisterFile("hub.proto", fileDescriptor_b3103f8d3056b01c) }
-
-var fileDescriptor_b3103f8d3056b01c = []byte{
-	// 1230 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x56, 0x4b, 0x6f, 0xdb, 0xc6,
-	0x13, 0x37, 0x2d, 0xeb, 0xc1, 0x91, 0x1d, 0xcb, 0x13, 0xc7, 0x51, 0xf4, 0xff, 0x37, 0x35, 0x98,
-	0x34, 0x70, 0x72, 0x50, 0x03, 0x37, 0x40, 0xd3, 0xa2, 0x6d, 0x2a, 0xcb, 0x49, 0x14, 0xc4, 0x76,
-	0x8c, 0x75, 0x8a, 0x00, 0xed, 0xc1, 0xa0, 0xc8, 0x8d, 0x4c, 0x64, 0xc9, 0x65, 0x97, 0x4b, 0x17,
-	0xfa, 0x0c, 0x3d, 0xf4, 0xde, 0x73, 0xcf, 0xbd, 0xf4, 0xd8, 0x8f, 0xd1, 0x2f, 0x54, 0xec, 0x83,
-	0x12, 0x29, 0xa9, 0x87, 0xdc, 0x76, 0x7f, 0xf3, 0xda, 0x99, 0x9d, 0x17, 0xb8, 0x57, 0xf9, 0xb8,
-	0x9f, 0x0a, 0x2e, 0x39, 0xd6, 0xa2, 0x90, 0x79, 0xbf, 0x39, 0xb0, 0x33, 0x08, 0xc3, 0xd3, 0x48,
-	0x08, 0x2e, 0x32, 0x42, 0x7f, 0xce, 0x69, 0x26, 0xb1, 0x0f, 0x38, 0xe4, 0x5c, 0x84, 0x51, 0xe2,
-	0x4b, 0x2e, 0x8e, 0x7d, 0xe9, 0x1f, 0x47, 0xa2, 0xeb, 0xec, 0x3b, 0x07, 0x2e, 0x59, 0x41, 0x41,
-	0x0f, 0x36, 0x47, 0x63, 0x7f, 0xc4, 0x33, 0x99, 0xf8, 0x31, 0xcd, 0xba, 0xeb, 0xfb, 0xce, 0x41,
-	0x8b, 0x54, 0x30, 0x7c, 0x00, 0xcd, 0xd8, 0x58, 0xe9, 0xd6, 0xf6, 0x6b, 0x07, 0xed, 0xc3, 0xcd,
-	0x7e, 0x14, 0xb2, 0xfe, 0x05, 0x9d, 0xc4, 0x34, 0x91, 0xa4, 0x20, 0x7a, 0x4f, 0x60, 0xef, 0x25,
-	0x95, 0x03, 0xc6, 0x94, 0xe8, 0x99, 0x12, 0x2d, 0x5e, 0xd5, 0x83, 0xd6, 0x15, 0xcf, 0xe4, 0x49,
-	0x94, 0xc9, 0xae, 0xb3, 0x5f, 0x3b, 0x70, 0xc9, 0xec, 0xee, 0xfd, 0xe1, 0xc0, 0xee, 0x92, 0x58,
-	0xca, 0xa6, 0x78, 0x02, 0xed, 0x2b, 0x8b, 0x9c, 0xfa, 0xa9, 0x96, 0x6b, 0x1f, 0x3e, 0xd2, 0xa6,
-	0x57, 0xf1, 0xf7, 0x47, 0x73, 0xe6, 0xe7, 0x89, 0x14, 0x53, 0x52, 0x16, 0xef, 0x7d, 0x07, 0x9d,
-	0x45, 0x06, 0xec, 0x40, 0xed, 0x03, 0x9d, 0xda, 0xe8, 0xa8, 0x23, 0xee, 0x42, 0xfd, 0xda, 0x67,
-	0x39, 0xd5, 0x71, 0x70, 0x89, 0xb9, 0x7c, 0xbd, 0xfe, 0xd4, 0xf1, 0x3a, 0x70, 0xe3, 0x42, 0xf2,
-	0x74, 0x94, 0x8f, 0xad, 0x53, 0xde, 0x0d, 0xd8, 0x9c, 0x21, 0x29, 0x9b, 0x7a, 0xbb, 0x80, 0x17,
-	0xd2, 0x17, 0x72, 0x30, 0xa1, 0x89, 0x2c, 0x5c, 0xf7, 0x10, 0x3a, 0x15, 0x54, 0x71, 0xde, 0x82,
-	0x9b, 0x17, 0xd2, 0x97, 0x79, 0x56, 0x65, 0xa5, 0xb0, 0x75, 0x41, 0xc5, 0x75, 0x14, 0x50, 0x43,
-	0x45, 0x84, 0x0d, 0xe5, 0x82, 0x7d, 0xa0, 0x3e, 0xe3, 0x1e, 0x34, 0x32, 0x4d, 0xb5, 0x4f, 0xb4,
-	0x37, 0x85, 0xe7, 0xa9, 0x8c, 0x62, 0xda, 0xad, 0x19, 0xdc, 0xdc, 0x94, 0x8f, 0x69, 0x14, 0x76,
-	0x37, 0xf6, 0x9d, 0x83, 0x2d, 0xa2, 0x8e, 0xde, 0x10, 0x76, 0xaa, 0xd6, 0x55, 0xb0, 0xfb, 0xd0,
-	0x32, 0x8a, 0x68, 0x66, 0x23, 0x8d, 0xf6, 0x93, 0x4b, 0x0f, 0x22, 0x33, 0x1e, 0xef, 0xa6, 0x52,
-	0xc2, 0xd3, 0xaa, 0x03, 0x3b, 0xb0, 0x5d, 0x06, 0x95, 0xab, 0x7f, 0x3a, 0x80, 0xa7, 0xfe, 0x07,
-	0x3a, 0x64, 0x79, 0x26, 0xa9, 0x28, 0x12, 0xe2, 0x01, 0x34, 0x27, 0xe9, 0x40, 0x08, 0xdf, 0x44,
-	0xbf, 0x48, 0x29, 0x8b, 0x91, 0x82, 0x88, 0x4f, 0x61, 0x2b, 0x30, 0x92, 0xe7, 0xbe, 0xf0, 0x63,
-	0xe3, 0x74, 0xf1, 0xb6, 0x61, 0x99, 0x42, 0xaa, 0x8c, 0xf8, 0x7f, 0x70, 0xdf, 0x73, 0x11, 0xd0,
-	0x17, 0xcc, 0x9f, 0xe8, 0x90, 0xb4, 0xc8, 0x1c, 0xc0, 0x2e, 0x34, 0xaf, 0xa9, 0x18, 0xf3, 0x8c,
-	0xea, 0xc8, 0xb4, 0x48, 0x71, 0xf5, 0x7e, 0x77, 0xa0, 0x55, 0x7c, 0x29, 0x3e, 0x84, 0x06, 0xe3,
-	0x93, 0xd3, 0x6c, 0x62, 0x5f, 0xb9, 0xad, 0xed, 0x9e, 0xf0, 0xc9, 0x29, 0xcd, 0x32, 0x7f, 0x42,
-	0x47, 0x6b, 0xc4, 0x32, 0xe0, 0x5d, 0x70, 0x33, 0x19, 0xf2, 0x5c, 0x2a, 0x6e, 0xfd, 0x35, 0xa3,
-	0x35, 0x32, 0x87, 0xf0, 0x29, 0xb4, 0x53, 0xc1, 0x27, 0x82, 0x66, 0x99, 0xe2, 0xa8, 0x69, 0x7d,
-	0xbb, 0x5a, 0xdf, 0x79, 0x81, 0xcf, 0x94, 0x96, 0x59, 0x8f, 0x5c, 0x68, 0xc6, 0x86, 0xe2, 0xbd,
-	0x06, 0x98, 0x1b, 0x57, 0x4e, 0x58, 0x82, 0xcd, 0x90, 0xe2, 0x8a, 0xf7, 0xa0, 0xce, 0xe8, 0x35,
-	0x65, 0xfa, 0x21, 0x37, 0x0e, 0xb7, 0xb4, 0x19, 0xc6, 0x27, 0x27, 0x0a, 0x24, 0x86, 0xe6, 0x7d,
-	0x0b, 0xdb, 0x0b, 0x96, 0x55, 0xfa, 0x33, 0x7f, 0x6c, 0xe5, 0x5c, 0x62, 0x2e, 0x0a, 0x95, 0x5c,
-	0xfa, 0x4c, 0x87, 0xaa, 0x4e, 0xcc, 0xc5, 0xe3, 0xb3, 0x2f, 0xc4, 0x3e, 0xb4, 0x4b, 0xad, 0xa5,
-	0xf2, 0xa3, 0x45, 0x93, 0x28, 0x33, 0xe0, 0x13, 0xd8, 0xb4, 0xb8, 0x49, 0x81, 0x75, 0x9d, 0x70,
-	0x9d, 0xb2, 0xc0, 0xb9, 0x1f, 0x09, 0x52, 0xe1, 0xf2, 0xfe, 0x72, 0xa0, 0x69, 0x01, 0x55, 0x19,
-	0x29, 0x17, 0xa6, 0x32, 0xea, 0x44, 0x9f, 0xf1, 0x3e, 0x6c, 0x85, 0xa6, 0xab, 0xd1, 0x40, 0x72,
-	0x31, 0xb5, 0x4e, 0x54, 0xc1, 0xa2, 0x15, 0xa9, 0x3e, 0x60, 0x2b, 0x65, 0x76, 0xc7, 0x7d, 0xd3,
-	0x71, 0x06, 0x61, 0xa8, 0x82, 0xa2, 0xdd, 0x75, 0x49, 0x19, 0x52, 0x59, 0x15, 0xf0, 0x44, 0xd2,
-	0x44, 0x46, 0x61, 0xb7, 0xae, 0x8d, 0xcf, 0x01, 0xf5, 0xaa, 0x70, 0x1c, 0x85, 0xdd, 0x86, 0x79,
-	0x95, 0x3a, 0x7b, 0x3f, 0x41, 0xbb, 0xe4, 0x92, 0x4a, 0xfc, 0x54, 0x44, 0xb1, 0x2f, 0xa6, 0x2b,
-	0xc3, 0x54, 0x10, 0xf1, 0x3e, 0x34, 0x4c, 0x5b, 0xb5, 0x19, 0x5f, 0x65, 0xb3, 0x34, 0xef, 0xd7,
-	0x3a, 0x6c, 0x55, 0xaa, 0x00, 0xdf, 0xc1, 0x4e, 0x29, 0xd2, 0x43, 0x9e, 0xbc, 0x8f, 0x26, 0xb6,
-	0xa0, 0x1f, 0x2e, 0x17, 0x4d, 0x7f, 0x89, 0xd7, 0x74, 0xce, 0x65, 0x1d, 0xf8, 0x5a, 0x35, 0x27,
-	0x6d, 0xdd, 0x2a, 0x35, 0x9f, 0xf6, 0xd9, 0x0a, 0xa5, 0x15, 0x3e, 0xa3, 0xb0, 0x2a, 0x8b, 0x23,
-	0xd8, 0x1c, 0xf2, 0x38, 0xe6, 0x89, 0xd5, 0x65, 0xc6, 0xca, 0xfd, 0x95, 0x0f, 0x9c, 0xb3, 0x19,
-	0x55, 0x15, 0x49, 0xbc, 0xa7, 0x2a, 0x34, 0xf0, 0x99, 0xa9, 0xe3, 0xf6, 0x61, 0xdb, 0x56, 0xa8,
-	0x82, 0x88, 0x25, 0xa9, 0x21, 0x77, 0x55, 0x1e, 0x72, 0x75, 0x33, 0xe4, 0xca, 0x98, 0xca, 0x0b,
-	0x9a, 0x04, 0x3c, 0x8c, 0x92, 0x89, 0xfe, 0x3f, 0x97, 0xcc, 0xee, 0x78, 0x17, 0x20, 0xcb, 0xcf,
-	0xfd, 0x2c, 0xfb, 0x85, 0x8b, 0xb0, 0xdb, 0xd4, 0xd4, 0x12, 0xa2, 0x7a, 0x6f, 0x38, 0xd6, 0x19,
-	0xd5, 0x32, 0xbd, 0xd7, 0xdc, 0x8a, 0x8c, 0x1c, 0x5e, 0xd1, 0xe0, 0x43, 0x96, 0xc7, 0x59, 0xd7,
-	0xd5, 0x86, 0xab, 0x60, 0xef, 0x18, 0xf6, 0x56, 0x7f, 0xc3, 0xc7, 0xcc, 0xa7, 0xde, 0xf7, 0x80,
-	0xcb, 0x71, 0xff, 0x28, 0x0d, 0xcf, 0x54, 0xea, 0x2c, 0x44, 0xfb, 0xa3, 0x46, 0xe4, 0x3f, 0x0e,
-	0x34, 0x4c, 0xe4, 0xf1, 0x16, 0x34, 0x58, 0x70, 0xe9, 0x33, 0x66, 0x25, 0xeb, 0x2c, 0x18, 0x30,
-	0x86, 0x9f, 0x00, 0xb0, 0xe0, 0x32, 0xe0, 0x8c, 0xf9, 0xb2, 0x50, 0xe0, 0xb2, 0x60, 0x68, 0x00,
-	0xbc, 0x03, 0x2d, 0x45, 0x96, 0xd3, 0xb4, 0xa8, 0xcd, 0x26, 0x0b, 0x86, 0xea, 0x8a, 0x9f, 0x42,
-	0x9b, 0x05, 0x97, 0xb6, 0xbf, 0x15, 0xa5, 0x09, 0x2c, 0xb0, 0x9d, 0x2b, 0x2b, 0x18, 0x78, 0x42,
-	0x75, 0xed, 0xd7, 0x67, 0x0c, 0x16, 0xb1, 0xb6, 0x93, 0x3c, 0xa6, 0x22, 0x0a, 0xec, 0x17, 0xbb,
-	0x2c, 0x38, 0x33, 0x00, 0xde, 0x86, 0x26, 0x0b, 0x2e, 0xf5, 0x00, 0x35, 0x1f, 0xdc, 0x60, 0xc1,
-	0xdb, 0x28, 0xa6, 0x8f, 0x8e, 0xa0, 0x55, 0x74, 0x4e, 0x74, 0xa1, 0xfe, 0x62, 0xf0, 0x76, 0x70,
-	0xd2, 0x59, 0x53, 0xc7, 0xe7, 0x84, 0xbc, 0x21, 0x1d, 0x07, 0xdb, 0xd0, 0x7c, 0x37, 0x20, 0x67,
-	0xaf, 0xce, 0x5e, 0x76, 0xd6, 0xb1, 0x05, 0x1b, 0xaf, 0xce, 0x5e, 0xbc, 0xe9, 0xd4, 0x14, 0xc7,
-	0xf1, 0xf3, 0xa3, 0x1f, 0x5e, 0x76, 0x36, 0x0e, 0xff, 0xae, 0x41, 0x6d, 0x94, 0x8f, 0xf1, 0x31,
-	0x6c, 0xa8, 0x01, 0x89, 0x37, 0x4d, 0x35, 0x57, 0xf6, 0x89, 0xde, 0x4e, 0x15, 0x54, 0xd3, 0x73,
-	0x0d, 0x9f, 0x41, 0xbb, 0xb4, 0x3e, 0xe0, 0x6d, 0xcb, 0xb3, 0xb8, 0x66, 0xf4, 0x6e, 0x2d, 0x13,
-	0x8c, 0x82, 0x23, 0xb5, 0xa5, 0xcc, 0xa7, 0x3d, 0x76, 0x0b, 0xc6, 0xc5, 0xf5, 0xa3, 0xb7, 0xb7,
-	0x82, 0x62, 0x74, 0x7c, 0x03, 0x30, 0x9f, 0xeb, 0xb8, 0x37, 0x7b, 0x67, 0x55, 0x7e, 0x77, 0x09,
-	0x37, 0xd2, 0x5f, 0x41, 0xbb, 0xb4, 0x01, 0x58, 0x17, 0x96, 0x77, 0x82, 0x9e, 0x99, 0x52, 0x73,
-	0xdf, 0x1f, 0x3b, 0xf8, 0x25, 0xc0, 0x7c, 0xc5, 0xb5, 0x86, 0x97, 0x76, 0xde, 0x55, 0x82, 0xaf,
-	0x61, 0x7b, 0x61, 0x47, 0xc4, 0xff, 0xad, 0xde, 0x1c, 0x8d, 0x8a, 0x3b, 0xff, 0xb9, 0x56, 0x7a,
-	0x6b, 0x47, 0xad, 0x1f, 0x1b, 0xfd, 0xfe, 0xe7, 0x51, 0xc8, 0xc6, 0x0d, 0xbd, 0x7f, 0x7f, 0xf1,
-	0x6f, 0x00, 0x00, 0x00, 0xff, 0xff, 0xcc, 0xf2, 0xa1, 0x94, 0x8c, 0x0b, 0x00, 0x00,
+func (m *HbaTargets) Reset()         { *m = HbaTargets{} }
+func (m *HbaTargets) String() string { return proto.CompactTextString(m) }
+func (*HbaTargets) ProtoMessage()    {}
+func (*HbaTargets) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b3103f8d3056b01c, []int{24}
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
-
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
-
-// HubClient is the client API for Hub service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type HubClient interface {
-	Stop(ctx context.Context, in *StopHubRequest, opts ...grpc.CallOption) (*StopHubReply, error)
-	StartAgents(ctx context.Context, in *StartAgentsRequest, opts ...grpc.CallOption) (*StartAgentsReply, error)
-	StatusAgents(ctx context.Context, in *StatusAgentsRequest, opts ...grpc.CallOption) (*StatusAgentsReply, error)
-	StopAgents(ctx context.Context, in *StopAgentsRequest, opts ...grpc.CallOption) (*StopAgentsReply, error)
-	MakeCluster(ctx context.Context, in *MakeClusterRequest, opts ...grpc.CallOption) (Hub_MakeClusterClient, error)
-	AddMirrors(ctx context.Context, in *AddMirrorsRequest, opts ...grpc.CallOption) (Hub_AddMirrorsClient, error)
-	GetAllHostNames(ctx context.Context, in *GetAllHostNamesRequest, opts ...grpc.CallOption) (*GetAllHostNamesReply, error)
+func (m *HbaTargets) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HbaTargets.Unmarshal(m, b)
 }
-
-type hubClient struct {
-	cc *grpc.ClientConn
+func (m *HbaTargets) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HbaTargets.Marshal(b, m, deterministic)
 }
-
-func NewHubClient(cc *grpc.ClientConn) HubClient {
-	return &hubClient{cc}
+func (m *HbaTargets) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HbaTargets.Merge(m, src)
+}
+func (m *HbaTargets) XXX_Size() int {
+	return xxx_messageInfo_HbaTargets.Size(m)
+}
+func (m *HbaTargets) XXX_DiscardUnknown() {
+	xxx_messageInfo_HbaTargets.DiscardUnknown(m)
 }
 
-func (c *hubClient) Stop(ctx context.Context, in *StopHubRequest, opts ...grpc.CallOption) (*StopHubReply, error) {
-	out := new(StopHubReply)
-	err := c.cc.Invoke(ctx, "/idl.Hub/Stop", in, out, opts...)
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_HbaTargets proto.InternalMessageInfo
+
+func (m *HbaTargets) GetCoordinator() bool {
+	if m != nil {
+		return m.Coordinator
 	}
-	return out, nil
+	return false
 }
 
-func (c *hubClient) StartAgents(ctx context.Context, in *StartAgentsRequest, opts ...grpc.CallOption) (*StartAgentsReply, error) {
-	out := new(StartAgentsReply)
-	err := c.cc.Invoke(ctx, "/idl.Hub/StartAgents", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *HbaTargets) GetStandby() bool {
+	if m != nil {
+		return m.Standby
 	}
-	return out, nil
+	return false
 }
 
-func (c *hubClient) StatusAgents(ctx context.Context, in *StatusAgentsRequest, opts ...grpc.CallOption) (*StatusAgentsReply, error) {
-	out := new(StatusAgentsReply)
-	err := c.cc.Invoke(ctx, "/idl.Hub/StatusAgents", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *HbaTargets) GetSegments() bool {
+	if m != nil {
+		return m.Segments
 	}
-	return out, nil
+	return false
 }
 
-func (c *hubClient) StopAgents(ctx context.Context, in *StopAgentsRequest, opts ...grpc.CallOption) (*StopAgentsReply, error) {
-	out := new(StopAgentsReply)
-	err := c.cc.Invoke(ctx, "/idl.Hub/StopAgents", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+type ListPgHbaRequest struct {
+	CoordinatorDataDir   string      `protobuf:"bytes,1,opt,name=coordinatorDataDir,proto3" json:"coordinatorDataDir,omitempty"`
+	Targets              *HbaTargets `protobuf:"bytes,2,opt,name=targets,proto3" json:"targets,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
 }
 
-func (c *hubClient) MakeCluster(ctx context.Context, in *MakeClusterRequest, opts ...grpc.CallOption) (Hub_MakeClusterClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_Hub_serviceDesc.Streams[0], "/idl.Hub/MakeCluster", opts...)
-	if err != nil {
+func (m *ListPgHbaRequest) Reset()         { *m = ListPgHbaRequest{} }
+func (m *ListPgHbaRequest) String() string { return proto.CompactTextString(m) }
+func (*ListPgHbaRequest) ProtoMessage()    {}
+func (*ListPgHbaRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b3103f8d3056b01c, []int{25}
+}
+
+func (m *ListPgHbaRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListPgHbaRequest.Unmarshal(m, b)
+}
+func (m *ListPgHbaRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListPgHbaRequest.Marshal(b, m, deterministic)
+}
+func (m *ListPgHbaRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListPgHbaRequest.Merge(m, src)
+}
+func (m *ListPgHbaRequest) XXX_Size() int {
+	return xxx_messageInfo_ListPgHbaRequest.Size(m)
+}
+func (m *ListPgHbaRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListPgHbaRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListPgHbaRequest proto.InternalMessageInfo
+
+func (m *ListPgHbaRequest) GetCoordinatorDataDir() string {
+	if m != nil {
+		return m.CoordinatorDataDir
+	}
+	return ""
+}
+
+func (m *ListPgHbaRequest) GetTargets() *HbaTargets {
+	if m != nil {
+		return m.Targets
+	}
+	return nil
+}
+
+type PgHbaConf struct {
+	Segment              *Segment `protobuf:"bytes,1,opt,name=segment,proto3" json:"segment,omitempty"`
+	Entries              []string `protobuf:"bytes,2,rep,name=entries,proto3" json:"entries,omitempty"`
+	Error                string   `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PgHbaConf) Reset()         { *m = PgHbaConf{} }
+func (m *PgHbaConf) String() string { return proto.CompactTextString(m) }
+func (*PgHbaConf) ProtoMessage()    {}
+func (*PgHbaConf) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b3103f8d3056b01c, []int{26}
+}
+
+func (m *PgHbaConf) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PgHbaConf.Unmarshal(m, b)
+}
+func (m *PgHbaConf) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PgHbaConf.Marshal(b, m, deterministic)
+}
+func (m *PgHbaConf) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PgHbaConf.Merge(m, src)
+}
+func (m *PgHbaConf) XXX_Size() int {
+	return xxx_messageInfo_PgHbaConf.Size(m)
+}
+func (m *PgHbaConf) XXX_DiscardUnknown() {
+	xxx_messageInfo_PgHbaConf.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PgHbaConf proto.InternalMessageInfo
+
+func (m *PgHbaConf) GetSegment() *Segment {
+	if m != nil {
+		return m.Segment
+	}
+	return nil
+}
+
+func (m *PgHbaConf) GetEntries() []string {
+	if m != nil {
+		return m.Entries
+	}
+	return nil
+}
+
+func (m *PgHbaConf) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+type ListPgHbaReply struct {
+	Confs                []*PgHbaConf `protobuf:"bytes,1,rep,name=confs,proto3" json:"confs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *ListPgHbaReply) Reset()         { *m = ListPgHbaReply{} }
+func (m *ListPgHbaReply) String() string { return proto.CompactTextString(m) }
+func (*ListPgHbaReply) ProtoMessage()    {}
+func (*ListPgHbaReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b3103f8d3056b01c, []int{27}
+}
+
+func (m *ListPgHbaReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListPgHbaReply.Unmarshal(m, b)
+}
+func (m *ListPgHbaReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListPgHbaReply.Marshal(b, m, deterministic)
+}
+func (m *ListPgHbaReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListPgHbaReply.Merge(m, src)
+}
+func (m *ListPgHbaReply) XXX_Size() int {
+	return xxx_messageInfo_ListPgHbaReply.Size(m)
+}
+func (m *ListPgHbaReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListPgHbaReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListPgHbaReply proto.InternalMessageInfo
+
+func (m *ListPgHbaReply) GetConfs() []*PgHbaConf {
+	if m != nil {
+		return m.Confs
+	}
+	return nil
+}
+
+type ModifyPgHbaRequest struct {
+	CoordinatorDataDir   string      `protobuf:"bytes,1,opt,name=coordinatorDataDir,proto3" json:"coordinatorDataDir,omitempty"`
+	Targets              *HbaTargets `protobuf:"bytes,2,opt,name=targets,proto3" json:"targets,omitempty"`
+	AddEntries           []string    `protobuf:"bytes,3,rep,name=addEntries,proto3" json:"addEntries,omitempty"`
+	RemoveEntries        []string    `protobuf:"bytes,4,rep,name=removeEntries,proto3" json:"removeEntries,omitempty"`
+	Position             HbaPosition `protobuf:"varint,5,opt,name=position,proto3,enum=idl.HbaPosition" json:"position,omitempty"`
+	Reference            string      `protobuf:"bytes,6,opt,name=reference,proto3" json:"reference,omitempty"`
+	NoReload             bool        `protobuf:"varint,7,opt,name=noReload,proto3" json:"noReload,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *ModifyPgHbaRequest) Reset()         { *m = ModifyPgHbaRequest{} }
+func (m *ModifyPgHbaRequest) String() string { return proto.CompactTextString(m) }
+func (*ModifyPgHbaRequest) ProtoMessage()    {}
+func (*ModifyPgHbaRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b3103f8d3056b01c, []int{28}
+}
+
+func (m *ModifyPgHbaRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ModifyPgHbaRequest.Unmarshal(m, b)
+}
+func (m *ModifyPgHbaRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ModifyPgHbaRequest.Marshal(b, m, deterministic)
+}
+func (m *ModifyPgHbaRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ModifyPgHbaRequest.Merge(m, src)
+}
+func (m *ModifyPgHbaRequest) XXX_Size() int {
+	return xxx_messageInfo_ModifyPgHbaRequest.Size(m)
+}
+func (m *ModifyPgHbaRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ModifyPgHbaRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ModifyPgHbaRequest proto.InternalMessageInfo
+
+func (m *ModifyPgHbaRequest) GetCoordinatorDataDir() string {
+	if m != nil {
+		return m.CoordinatorDataDir
+	}
+	return ""
+}
+
+func (m *ModifyPgHbaRequest) GetTargets() *HbaTargets {
+	if m != nil {
+		return m.Targets
+	}
+	return nil
+}
+
+func (m *ModifyPgHbaRequest) GetAddEntries() []string {
+	if m != nil {
+		return m.AddEntries
+	}
+	return nil
+}
+
+func (m *ModifyPgHbaRequest) GetRemoveEntries() []string {
+	if m != nil {
+		return m.RemoveEntries
+	}
+	return nil
+}
+
+func (m *ModifyPgHbaRequest) GetPosition() HbaPosition {
+	if m != nil {
+		return m.Position
+	}
+	return HbaPosition_APPEND
+}
+
+func (m *ModifyPgHbaRequest) GetReference() string {
+	if m != nil {
+		return m.Reference
+	}
+	return ""
+}
+
+func (m *ModifyPgHbaRequest) GetNoReload() bool {
+	if m != nil {
+		return m.NoReload
+	}
+	return false
+}
+
+type PgHbaResult struct {
+	Segment              *Segment `protobuf:"bytes,1,opt,name=segment,proto3" json:"segment,omitempty"`
+	BackupFile           string   `protobuf:"bytes,2,opt,name=backupFile,proto3" json:"backupFile,omitempty"`
+	Error                string   `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PgHbaResult) Reset()         { *m = PgHbaResult{} }
+func (m *PgHbaResult) String() string { return proto.CompactTextString(m) }
+func (*PgHbaResult) ProtoMessage()    {}
+func (*PgHbaResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b3103f8d3056b01c, []int{29}
+}
+
+func (m *PgHbaResult) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PgHbaResult.Unmarshal(m, b)
+}
+func (m *PgHbaResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PgHbaResult.Marshal(b, m, deterministic)
+}
+func (m *PgHbaResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PgHbaResult.Merge(m, src)
+}
+func (m *PgHbaResult) XXX_Size() int {
+	return xxx_messageInfo_PgHbaResult.Size(m)
+}
+func (m *PgHbaResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_PgHbaResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PgHbaResult proto.InternalMessageInfo
+
+func (m *PgHbaResult) GetSegment() *Segment {
+	if m != nil {
+		return m.Segment
+	}
+	return nil
+}
+
+func (m *PgHbaResult) GetBackupFile() string {
+	if m != nil {
+		return m.BackupFile
+	}
+	return ""
+}
+
+func (m *PgHbaResult) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+type ModifyPgHbaReply struct {
+	Results              []*PgHbaResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *ModifyPgHbaReply) Reset()         { *m = ModifyPgHbaReply{} }
+func (m *ModifyPgHbaReply) String() string { return proto.CompactTextString(m) }
+func (*ModifyPgHbaReply) ProtoMessage()    {}
+func (*ModifyPgHbaReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b3103f8d3056b01c, []int{30}
+}
+
+func (m *ModifyPgHbaReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ModifyPgHbaReply.Unmarshal(m, b)
+}
+func (m *ModifyPgHbaReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ModifyPgHbaReply.Marshal(b, m, deterministic)
+}
+func (m *ModifyPgHbaReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ModifyPgHbaReply.Merge(m, src)
+}
+func (m *ModifyPgHbaReply) XXX_Size() int {
+	return xxx_messageInfo_ModifyPgHbaReply.Size(m)
+}
+func (m *ModifyPgHbaReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_ModifyPgHbaReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ModifyPgHbaReply proto.InternalMessageInfo
+
+func (m *ModifyPgHbaReply) GetResults() []*PgHbaResult {
+	if m != nil {
+		return m.Results
+	}
+	return nil
+}
+
+type CheckPgHbaRequest struct {
+	CoordinatorDataDir   string      `protobuf:"bytes,1,opt,name=coordinatorDataDir,proto3" json:"coordinatorDataDir,omitempty"`
+	Targets              *HbaTargets `protobuf:"bytes,2,opt,name=targets,proto3" json:"targets,omitempty"`
+	ExpectedEntries      []string    `protobuf:"bytes,3,rep,name=expectedEntries,proto3" json:"expectedEntries,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *CheckPgHbaRequest) Reset()         { *m = CheckPgHbaRequest{} }
+func (m *CheckPgHbaRequest) String() string { return proto.CompactTextString(m) }
+func (*CheckPgHbaRequest) ProtoMessage()    {}
+func (*CheckPgHbaRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b3103f8d3056b01c, []int{31}
+}
+
+func (m *CheckPgHbaRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckPgHbaRequest.Unmarshal(m, b)
+}
+func (m *CheckPgHbaRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckPgHbaRequest.Marshal(b, m, deterministic)
+}
+func (m *CheckPgHbaRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckPgHbaRequest.Merge(m, src)
+}
+func (m *CheckPgHbaRequest) XXX_Size() int {
+	return xxx_messageInfo_CheckPgHbaRequest.Size(m)
+}
+func (m *CheckPgHbaRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckPgHbaRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckPgHbaRequest proto.InternalMessageInfo
+
+func (m *CheckPgHbaRequest) GetCoordinatorDataDir() string {
+	if m != nil {
+		return m.CoordinatorDataDir
+	}
+	return ""
+}
+
+func (m *CheckPgHbaRequest) GetTargets() *HbaTargets {
+	if m != nil {
+		return m.Targets
+	}
+	return nil
+}
+
+func (m *CheckPgHbaRequest) GetExpectedEntries() []string {
+	if m != nil {
+		return m.ExpectedEntries
+	}
+	return nil
+}
+
+type PgHbaDrift struct {
+	Segment              *Segment `protobuf:"bytes,1,opt,name=segment,proto3" json:"segment,omitempty"`
+	Missing              []string `protobuf:"bytes,2,rep,name=missing,proto3" json:"missing,omitempty"`
+	Unexpected           []string `protobuf:"bytes,3,rep,name=unexpected,proto3" json:"unexpected,omitempty"`
+	Error                string   `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	Misordered           []string `protobuf:"bytes,5,rep,name=misordered,proto3" json:"misordered,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PgHbaDrift) Reset()         { *m = PgHbaDrift{} }
+func (m *PgHbaDrift) String() string { return proto.CompactTextString(m) }
+func (*PgHbaDrift) ProtoMessage()    {}
+func (*PgHbaDrift) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b3103f8d3056b01c, []int{32}
+}
+
+func (m *PgHbaDrift) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PgHbaDrift.Unmarshal(m, b)
+}
+func (m *PgHbaDrift) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PgHbaDrift.Marshal(b, m, deterministic)
+}
+func (m *PgHbaDrift) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PgHbaDrift.Merge(m, src)
+}
+func (m *PgHbaDrift) XXX_Size() int {
+	return xxx_messageInfo_PgHbaDrift.Size(m)
+}
+func (m *PgHbaDrift) XXX_DiscardUnknown() {
+	xxx_messageInfo_PgHbaDrift.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PgHbaDrift proto.InternalMessageInfo
+
+func (m *PgHbaDrift) GetSegment() *Segment {
+	if m != nil {
+		return m.Segment
+	}
+	return nil
+}
+
+func (m *PgHbaDrift) GetMissing() []string {
+	if m != nil {
+		return m.Missing
+	}
+	return nil
+}
+
+func (m *PgHbaDrift) GetUnexpected() []string {
+	if m != nil {
+		return m.Unexpected
+	}
+	return nil
+}
+
+func (m *PgHbaDrift) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func (m *PgHbaDrift) GetMisordered() []string {
+	if m != nil {
+		return m.Misordered
+	}
+	return nil
+}
+
+type CheckPgHbaReply struct {
+	Drifts               []*PgHbaDrift `protobuf:"bytes,1,rep,name=drifts,proto3" json:"drifts,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *CheckPgHbaReply) Reset()         { *m = CheckPgHbaReply{} }
+func (m *CheckPgHbaReply) String() string { return proto.CompactTextString(m) }
+func (*CheckPgHbaReply) ProtoMessage()    {}
+func (*CheckPgHbaReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b3103f8d3056b01c, []int{33}
+}
+
+func (m *CheckPgHbaReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckPgHbaReply.Unmarshal(m, b)
+}
+func (m *CheckPgHbaReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckPgHbaReply.Marshal(b, m, deterministic)
+}
+func (m *CheckPgHbaReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckPgHbaReply.Merge(m, src)
+}
+func (m *CheckPgHbaReply) XXX_Size() int {
+	return xxx_messageInfo_CheckPgHbaReply.Size(m)
+}
+func (m *CheckPgHbaReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckPgHbaReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckPgHbaReply proto.InternalMessageInfo
+
+func (m *CheckPgHbaReply) GetDrifts() []*PgHbaDrift {
+	if m != nil {
+		return m.Drifts
+	}
+	return nil
+}
+
+type ReloadCertificatesRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReloadCertificatesRequest) Reset()         { *m = ReloadCertificatesRequest{} }
+func (m *ReloadCertificatesRequest) String() string { return proto.CompactTextString(m) }
+func (*ReloadCertificatesRequest) ProtoMessage()    {}
+func (*ReloadCertificatesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b3103f8d3056b01c, []int{34}
+}
+
+func (m *ReloadCertificatesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReloadCertificatesRequest.Unmarshal(m, b)
+}
+func (m *ReloadCertificatesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReloadCertificatesRequest.Marshal(b, m, deterministic)
+}
+func (m *ReloadCertificatesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReloadCertificatesRequest.Merge(m, src)
+}
+func (m *ReloadCertificatesRequest) XXX_Size() int {
+	return xxx_messageInfo_ReloadCertificatesRequest.Size(m)
+}
+func (m *ReloadCertificatesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReloadCertificatesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReloadCertificatesRequest proto.InternalMessageInfo
+
+type ReloadCertificatesReply struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReloadCertificatesReply) Reset()         { *m = ReloadCertificatesReply{} }
+func (m *ReloadCertificatesReply) String() string { return proto.CompactTextString(m) }
+func (*ReloadCertificatesReply) ProtoMessage()    {}
+func (*ReloadCertificatesReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b3103f8d3056b01c, []int{35}
+}
+
+func (m *ReloadCertificatesReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReloadCertificatesReply.Unmarshal(m, b)
+}
+func (m *ReloadCertificatesReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReloadCertificatesReply.Marshal(b, m, deterministic)
+}
+func (m *ReloadCertificatesReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReloadCertificatesReply.Merge(m, src)
+}
+func (m *ReloadCertificatesReply) XXX_Size() int {
+	return xxx_messageInfo_ReloadCertificatesReply.Size(m)
+}
+func (m *ReloadCertificatesReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReloadCertificatesReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReloadCertificatesReply proto.InternalMessageInfo
+
+// Filters of the log lines. The time range and the severity only apply to the
+// CSV logs of the segments.
+type LogFilter struct {
+	Since                int64    `protobuf:"varint,1,opt,name=since,proto3" json:"since,omitempty"`
+	Until                int64    `protobuf:"varint,2,opt,name=until,proto3" json:"until,omitempty"`
+	MinSeverity          string   `protobuf:"bytes,3,opt,name=minSeverity,proto3" json:"minSeverity,omitempty"`
+	Grep                 string   `protobuf:"bytes,4,opt,name=grep,proto3" json:"grep,omitempty"`
+	Tail                 int32    `protobuf:"varint,5,opt,name=tail,proto3" json:"tail,omitempty"`
+	File                 string   `protobuf:"bytes,6,opt,name=file,proto3" json:"file,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LogFilter) Reset()         { *m = LogFilter{} }
+func (m *LogFilter) String() string { return proto.CompactTextString(m) }
+func (*LogFilter) ProtoMessage()    {}
+func (*LogFilter) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b3103f8d3056b01c, []int{36}
+}
+
+func (m *LogFilter) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LogFilter.Unmarshal(m, b)
+}
+func (m *LogFilter) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LogFilter.Marshal(b, m, deterministic)
+}
+func (m *LogFilter) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LogFilter.Merge(m, src)
+}
+func (m *LogFilter) XXX_Size() int {
+	return xxx_messageInfo_LogFilter.Size(m)
+}
+func (m *LogFilter) XXX_DiscardUnknown() {
+	xxx_messageInfo_LogFilter.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LogFilter proto.InternalMessageInfo
+
+func (m *LogFilter) GetSince() int64 {
+	if m != nil {
+		return m.Since
+	}
+	return 0
+}
+
+func (m *LogFilter) GetUntil() int64 {
+	if m != nil {
+		return m.Until
+	}
+	return 0
+}
+
+func (m *LogFilter) GetMinSeverity() string {
+	if m != nil {
+		return m.MinSeverity
+	}
+	return ""
+}
+
+func (m *LogFilter) GetGrep() string {
+	if m != nil {
+		return m.Grep
+	}
+	return ""
+}
+
+func (m *LogFilter) GetTail() int32 {
+	if m != nil {
+		return m.Tail
+	}
+	return 0
+}
+
+func (m *LogFilter) GetFile() string {
+	if m != nil {
+		return m.File
+	}
+	return ""
+}
+
+type LogFile struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Size                 int64    `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	ModTime              int64    `protobuf:"varint,3,opt,name=modTime,proto3" json:"modTime,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LogFile) Reset()         { *m = LogFile{} }
+func (m *LogFile) String() string { return proto.CompactTextString(m) }
+func (*LogFile) ProtoMessage()    {}
+func (*LogFile) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b3103f8d3056b01c, []int{37}
+}
+
+func (m *LogFile) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LogFile.Unmarshal(m, b)
+}
+func (m *LogFile) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LogFile.Marshal(b, m, deterministic)
+}
+func (m *LogFile) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LogFile.Merge(m, src)
+}
+func (m *LogFile) XXX_Size() int {
+	return xxx_messageInfo_LogFile.Size(m)
+}
+func (m *LogFile) XXX_DiscardUnknown() {
+	xxx_messageInfo_LogFile.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LogFile proto.InternalMessageInfo
+
+func (m *LogFile) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *LogFile) GetSize() int64 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
+func (m *LogFile) GetModTime() int64 {
+	if m != nil {
+		return m.ModTime
+	}
+	return 0
+}
+
+type GetLogsRequest struct {
+	CoordinatorDataDir   string     `protobuf:"bytes,1,opt,name=coordinatorDataDir,proto3" json:"coordinatorDataDir,omitempty"`
+	Contents             []int32    `protobuf:"varint,2,rep,packed,name=contents,proto3" json:"contents,omitempty"`
+	Hosts                []string   `protobuf:"bytes,3,rep,name=hosts,proto3" json:"hosts,omitempty"`
+	Agent                bool       `protobuf:"varint,4,opt,name=agent,proto3" json:"agent,omitempty"`
+	List                 bool       `protobuf:"varint,5,opt,name=list,proto3" json:"list,omitempty"`
+	Follow               bool       `protobuf:"varint,6,opt,name=follow,proto3" json:"follow,omitempty"`
+	Filter               *LogFilter `protobuf:"bytes,7,opt,name=filter,proto3" json:"filter,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *GetLogsRequest) Reset()         { *m = GetLogsRequest{} }
+func (m *GetLogsRequest) String() string { return proto.CompactTextString(m) }
+func (*GetLogsRequest) ProtoMessage()    {}
+func (*GetLogsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b3103f8d3056b01c, []int{38}
+}
+
+func (m *GetLogsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetLogsRequest.Unmarshal(m, b)
+}
+func (m *GetLogsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetLogsRequest.Marshal(b, m, deterministic)
+}
+func (m *GetLogsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetLogsRequest.Merge(m, src)
+}
+func (m *GetLogsRequest) XXX_Size() int {
+	return xxx_messageInfo_GetLogsRequest.Size(m)
+}
+func (m *GetLogsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetLogsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetLogsRequest proto.InternalMessageInfo
+
+func (m *GetLogsRequest) GetCoordinatorDataDir() string {
+	if m != nil {
+		return m.CoordinatorDataDir
+	}
+	return ""
+}
+
+func (m *GetLogsRequest) GetContents() []int32 {
+	if m != nil {
+		return m.Contents
+	}
+	return nil
+}
+
+func (m *GetLogsRequest) GetHosts() []string {
+	if m != nil {
+		return m.Hosts
+	}
+	return nil
+}
+
+func (m *GetLogsRequest) GetAgent() bool {
+	if m != nil {
+		return m.Agent
+	}
+	return false
+}
+
+func (m *GetLogsRequest) GetList() bool {
+	if m != nil {
+		return m.List
+	}
+	return false
+}
+
+func (m *GetLogsRequest) GetFollow() bool {
+	if m != nil {
+		return m.Follow
+	}
+	return false
+}
+
+func (m *GetLogsRequest) GetFilter() *LogFilter {
+	if m != nil {
+		return m.Filter
+	}
+	return nil
+}
+
+type GetLogsReply struct {
+	Host                 string     `protobuf:"bytes,1,opt,name=host,proto3" json:"host,omitempty"`
+	Segment              *Segment   `protobuf:"bytes,2,opt,name=segment,proto3" json:"segment,omitempty"`
+	File                 string     `protobuf:"bytes,3,opt,name=file,proto3" json:"file,omitempty"`
+	Lines                []string   `protobuf:"bytes,4,rep,name=lines,proto3" json:"lines,omitempty"`
+	Files                []*LogFile `protobuf:"bytes,5,rep,name=files,proto3" json:"files,omitempty"`
+	Error                string     `protobuf:"bytes,6,opt,name=error,proto3" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *GetLogsReply) Reset()         { *m = GetLogsReply{} }
+func (m *GetLogsReply) String() string { return proto.CompactTextString(m) }
+func (*GetLogsReply) ProtoMessage()    {}
+func (*GetLogsReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b3103f8d3056b01c, []int{39}
+}
+
+func (m *GetLogsReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetLogsReply.Unmarshal(m, b)
+}
+func (m *GetLogsReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetLogsReply.Marshal(b, m, deterministic)
+}
+func (m *GetLogsReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetLogsReply.Merge(m, src)
+}
+func (m *GetLogsReply) XXX_Size() int {
+	return xxx_messageInfo_GetLogsReply.Size(m)
+}
+func (m *GetLogsReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetLogsReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetLogsReply proto.InternalMessageInfo
+
+func (m *GetLogsReply) GetHost() string {
+	if m != nil {
+		return m.Host
+	}
+	return ""
+}
+
+func (m *GetLogsReply) GetSegment() *Segment {
+	if m != nil {
+		return m.Segment
+	}
+	return nil
+}
+
+func (m *GetLogsReply) GetFile() string {
+	if m != nil {
+		return m.File
+	}
+	return ""
+}
+
+func (m *GetLogsReply) GetLines() []string {
+	if m != nil {
+		return m.Lines
+	}
+	return nil
+}
+
+func (m *GetLogsReply) GetFiles() []*LogFile {
+	if m != nil {
+		return m.Files
+	}
+	return nil
+}
+
+func (m *GetLogsReply) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+type CollectLogsRequest struct {
+	CoordinatorDataDir   string   `protobuf:"bytes,1,opt,name=coordinatorDataDir,proto3" json:"coordinatorDataDir,omitempty"`
+	Hosts                []string `protobuf:"bytes,2,rep,name=hosts,proto3" json:"hosts,omitempty"`
+	Since                int64    `protobuf:"varint,3,opt,name=since,proto3" json:"since,omitempty"`
+	MaxHostSize          int64    `protobuf:"varint,4,opt,name=maxHostSize,proto3" json:"maxHostSize,omitempty"`
+	OutputFile           string   `protobuf:"bytes,5,opt,name=outputFile,proto3" json:"outputFile,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CollectLogsRequest) Reset()         { *m = CollectLogsRequest{} }
+func (m *CollectLogsRequest) String() string { return proto.CompactTextString(m) }
+func (*CollectLogsRequest) ProtoMessage()    {}
+func (*CollectLogsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b3103f8d3056b01c, []int{40}
+}
+
+func (m *CollectLogsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CollectLogsRequest.Unmarshal(m, b)
+}
+func (m *CollectLogsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CollectLogsRequest.Marshal(b, m, deterministic)
+}
+func (m *CollectLogsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CollectLogsRequest.Merge(m, src)
+}
+func (m *CollectLogsRequest) XXX_Size() int {
+	return xxx_messageInfo_CollectLogsRequest.Size(m)
+}
+func (m *CollectLogsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CollectLogsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CollectLogsRequest proto.InternalMessageInfo
+
+func (m *CollectLogsRequest) GetCoordinatorDataDir() string {
+	if m != nil {
+		return m.CoordinatorDataDir
+	}
+	return ""
+}
+
+func (m *CollectLogsRequest) GetHosts() []string {
+	if m != nil {
+		return m.Hosts
+	}
+	return nil
+}
+
+func (m *CollectLogsRequest) GetSince() int64 {
+	if m != nil {
+		return m.Since
+	}
+	return 0
+}
+
+func (m *CollectLogsRequest) GetMaxHostSize() int64 {
+	if m != nil {
+		return m.MaxHostSize
+	}
+	return 0
+}
+
+func (m *CollectLogsRequest) GetOutputFile() string {
+	if m != nil {
+		return m.OutputFile
+	}
+	return ""
+}
+
+type HostDisk struct {
+	Device               string   `protobuf:"bytes,1,opt,name=device,proto3" json:"device,omitempty"`
+	MountPoint           string   `protobuf:"bytes,2,opt,name=mountPoint,proto3" json:"mountPoint,omitempty"`
+	TotalBytes           uint64   `protobuf:"varint,3,opt,name=totalBytes,proto3" json:"totalBytes,omitempty"`
+	FreeBytes            uint64   `protobuf:"varint,4,opt,name=freeBytes,proto3" json:"freeBytes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *HostDisk) Reset()         { *m = HostDisk{} }
+func (m *HostDisk) String() string { return proto.CompactTextString(m) }
+func (*HostDisk) ProtoMessage()    {}
+func (*HostDisk) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b3103f8d3056b01c, []int{41}
+}
+
+func (m *HostDisk) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HostDisk.Unmarshal(m, b)
+}
+func (m *HostDisk) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HostDisk.Marshal(b, m, deterministic)
+}
+func (m *HostDisk) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HostDisk.Merge(m, src)
+}
+func (m *HostDisk) XXX_Size() int {
+	return xxx_messageInfo_HostDisk.Size(m)
+}
+func (m *HostDisk) XXX_DiscardUnknown() {
+	xxx_messageInfo_HostDisk.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HostDisk proto.InternalMessageInfo
+
+func (m *HostDisk) GetDevice() string {
+	if m != nil {
+		return m.Device
+	}
+	return ""
+}
+
+func (m *HostDisk) GetMountPoint() string {
+	if m != nil {
+		return m.MountPoint
+	}
+	return ""
+}
+
+func (m *HostDisk) GetTotalBytes() uint64 {
+	if m != nil {
+		return m.TotalBytes
+	}
+	return 0
+}
+
+func (m *HostDisk) GetFreeBytes() uint64 {
+	if m != nil {
+		return m.FreeBytes
+	}
+	return 0
+}
+
+type HostInterface struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Addrs                []string `protobuf:"bytes,2,rep,name=addrs,proto3" json:"addrs,omitempty"`
+	Mtu                  int32    `protobuf:"varint,3,opt,name=mtu,proto3" json:"mtu,omitempty"`
+	SpeedMbps            int32    `protobuf:"varint,4,opt,name=speedMbps,proto3" json:"speedMbps,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *HostInterface) Reset()         { *m = HostInterface{} }
+func (m *HostInterface) String() string { return proto.CompactTextString(m) }
+func (*HostInterface) ProtoMessage()    {}
+func (*HostInterface) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b3103f8d3056b01c, []int{42}
+}
+
+func (m *HostInterface) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HostInterface.Unmarshal(m, b)
+}
+func (m *HostInterface) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HostInterface.Marshal(b, m, deterministic)
+}
+func (m *HostInterface) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HostInterface.Merge(m, src)
+}
+func (m *HostInterface) XXX_Size() int {
+	return xxx_messageInfo_HostInterface.Size(m)
+}
+func (m *HostInterface) XXX_DiscardUnknown() {
+	xxx_messageInfo_HostInterface.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HostInterface proto.InternalMessageInfo
+
+func (m *HostInterface) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *HostInterface) GetAddrs() []string {
+	if m != nil {
+		return m.Addrs
+	}
+	return nil
+}
+
+func (m *HostInterface) GetMtu() int32 {
+	if m != nil {
+		return m.Mtu
+	}
+	return 0
+}
+
+func (m *HostInterface) GetSpeedMbps() int32 {
+	if m != nil {
+		return m.SpeedMbps
+	}
+	return 0
+}
+
+// Facts about a host used to size the segments. The facts which could not be
+// read are left unset and their errors are listed.
+type HostInfo struct {
+	Hostname             string           `protobuf:"bytes,1,opt,name=hostname,proto3" json:"hostname,omitempty"`
+	Os                   string           `protobuf:"bytes,2,opt,name=os,proto3" json:"os,omitempty"`
+	Kernel               string           `protobuf:"bytes,3,opt,name=kernel,proto3" json:"kernel,omitempty"`
+	Arch                 string           `protobuf:"bytes,4,opt,name=arch,proto3" json:"arch,omitempty"`
+	CpuCount             int32            `protobuf:"varint,5,opt,name=cpuCount,proto3" json:"cpuCount,omitempty"`
+	CpuModel             string           `protobuf:"bytes,6,opt,name=cpuModel,proto3" json:"cpuModel,omitempty"`
+	MemoryBytes          uint64           `protobuf:"varint,7,opt,name=memoryBytes,proto3" json:"memoryBytes,omitempty"`
+	SwapBytes            uint64           `protobuf:"varint,8,opt,name=swapBytes,proto3" json:"swapBytes,omitempty"`
+	Disks                []*HostDisk      `protobuf:"bytes,9,rep,name=disks,proto3" json:"disks,omitempty"`
+	Interfaces           []*HostInterface `protobuf:"bytes,10,rep,name=interfaces,proto3" json:"interfaces,omitempty"`
+	GpVersion            string           `protobuf:"bytes,11,opt,name=gpVersion,proto3" json:"gpVersion,omitempty"`
+	GpHome               string           `protobuf:"bytes,12,opt,name=gpHome,proto3" json:"gpHome,omitempty"`
+	Errors               []string         `protobuf:"bytes,13,rep,name=errors,proto3" json:"errors,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *HostInfo) Reset()         { *m = HostInfo{} }
+func (m *HostInfo) String() string { return proto.CompactTextString(m) }
+func (*HostInfo) ProtoMessage()    {}
+func (*HostInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b3103f8d3056b01c, []int{43}
+}
+
+func (m *HostInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HostInfo.Unmarshal(m, b)
+}
+func (m *HostInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HostInfo.Marshal(b, m, deterministic)
+}
+func (m *HostInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HostInfo.Merge(m, src)
+}
+func (m *HostInfo) XXX_Size() int {
+	return xxx_messageInfo_HostInfo.Size(m)
+}
+func (m *HostInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_HostInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HostInfo proto.InternalMessageInfo
+
+func (m *HostInfo) GetHostname() string {
+	if m != nil {
+		return m.Hostname
+	}
+	return ""
+}
+
+func (m *HostInfo) GetOs() string {
+	if m != nil {
+		return m.Os
+	}
+	return ""
+}
+
+func (m *HostInfo) GetKernel() string {
+	if m != nil {
+		return m.Kernel
+	}
+	return ""
+}
+
+func (m *HostInfo) GetArch() string {
+	if m != nil {
+		return m.Arch
+	}
+	return ""
+}
+
+func (m *HostInfo) GetCpuCount() int32 {
+	if m != nil {
+		return m.CpuCount
+	}
+	return 0
+}
+
+func (m *HostInfo) GetCpuModel() string {
+	if m != nil {
+		return m.CpuModel
+	}
+	return ""
+}
+
+func (m *HostInfo) GetMemoryBytes() uint64 {
+	if m != nil {
+		return m.MemoryBytes
+	}
+	return 0
+}
+
+func (m *HostInfo) GetSwapBytes() uint64 {
+	if m != nil {
+		return m.SwapBytes
+	}
+	return 0
+}
+
+func (m *HostInfo) GetDisks() []*HostDisk {
+	if m != nil {
+		return m.Disks
+	}
+	return nil
+}
+
+func (m *HostInfo) GetInterfaces() []*HostInterface {
+	if m != nil {
+		return m.Interfaces
+	}
+	return nil
+}
+
+func (m *HostInfo) GetGpVersion() string {
+	if m != nil {
+		return m.GpVersion
+	}
+	return ""
+}
+
+func (m *HostInfo) GetGpHome() string {
+	if m != nil {
+		return m.GpHome
+	}
+	return ""
+}
+
+func (m *HostInfo) GetErrors() []string {
+	if m != nil {
+		return m.Errors
+	}
+	return nil
+}
+
+type GetHostsInfoRequest struct {
+	Hosts                []string `protobuf:"bytes,1,rep,name=hosts,proto3" json:"hosts,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetHostsInfoRequest) Reset()         { *m = GetHostsInfoRequest{} }
+func (m *GetHostsInfoRequest) String() string { return proto.CompactTextString(m) }
+func (*GetHostsInfoRequest) ProtoMessage()    {}
+func (*GetHostsInfoRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b3103f8d3056b01c, []int{44}
+}
+
+func (m *GetHostsInfoRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetHostsInfoRequest.Unmarshal(m, b)
+}
+func (m *GetHostsInfoRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetHostsInfoRequest.Marshal(b, m, deterministic)
+}
+func (m *GetHostsInfoRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetHostsInfoRequest.Merge(m, src)
+}
+func (m *GetHostsInfoRequest) XXX_Size() int {
+	return xxx_messageInfo_GetHostsInfoRequest.Size(m)
+}
+func (m *GetHostsInfoRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetHostsInfoRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetHostsInfoRequest proto.InternalMessageInfo
+
+func (m *GetHostsInfoRequest) GetHosts() []string {
+	if m != nil {
+		return m.Hosts
+	}
+	return nil
+}
+
+type HostInfoResult struct {
+	Host                 string    `protobuf:"bytes,1,opt,name=host,proto3" json:"host,omitempty"`
+	Info                 *HostInfo `protobuf:"bytes,2,opt,name=info,proto3" json:"info,omitempty"`
+	Error                string    `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *HostInfoResult) Reset()         { *m = HostInfoResult{} }
+func (m *HostInfoResult) String() string { return proto.CompactTextString(m) }
+func (*HostInfoResult) ProtoMessage()    {}
+func (*HostInfoResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b3103f8d3056b01c, []int{45}
+}
+
+func (m *HostInfoResult) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HostInfoResult.Unmarshal(m, b)
+}
+func (m *HostInfoResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HostInfoResult.Marshal(b, m, deterministic)
+}
+func (m *HostInfoResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HostInfoResult.Merge(m, src)
+}
+func (m *HostInfoResult) XXX_Size() int {
+	return xxx_messageInfo_HostInfoResult.Size(m)
+}
+func (m *HostInfoResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_HostInfoResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HostInfoResult proto.InternalMessageInfo
+
+func (m *HostInfoResult) GetHost() string {
+	if m != nil {
+		return m.Host
+	}
+	return ""
+}
+
+func (m *HostInfoResult) GetInfo() *HostInfo {
+	if m != nil {
+		return m.Info
+	}
+	return nil
+}
+
+func (m *HostInfoResult) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+type GetHostsInfoReply struct {
+	Hosts                []*HostInfoResult `protobuf:"bytes,1,rep,name=hosts,proto3" json:"hosts,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *GetHostsInfoReply) Reset()         { *m = GetHostsInfoReply{} }
+func (m *GetHostsInfoReply) String() string { return proto.CompactTextString(m) }
+func (*GetHostsInfoReply) ProtoMessage()    {}
+func (*GetHostsInfoReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b3103f8d3056b01c, []int{46}
+}
+
+func (m *GetHostsInfoReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetHostsInfoReply.Unmarshal(m, b)
+}
+func (m *GetHostsInfoReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetHostsInfoReply.Marshal(b, m, deterministic)
+}
+func (m *GetHostsInfoReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetHostsInfoReply.Merge(m, src)
+}
+func (m *GetHostsInfoReply) XXX_Size() int {
+	return xxx_messageInfo_GetHostsInfoReply.Size(m)
+}
+func (m *GetHostsInfoReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetHostsInfoReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetHostsInfoReply proto.InternalMessageInfo
+
+func (m *GetHostsInfoReply) GetHosts() []*HostInfoResult {
+	if m != nil {
+		return m.Hosts
+	}
+	return nil
+}
+
+type CheckNetworkRequest struct {
+	GpArray              *GpArray `protobuf:"bytes,1,opt,name=gpArray,proto3" json:"gpArray,omitempty"`
+	Timeout              int32    `protobuf:"varint,2,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	Measure              bool     `protobuf:"varint,3,opt,name=measure,proto3" json:"measure,omitempty"`
+	MeasureBytes         int64    `protobuf:"varint,4,opt,name=measureBytes,proto3" json:"measureBytes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CheckNetworkRequest) Reset()         { *m = CheckNetworkRequest{} }
+func (m *CheckNetworkRequest) String() string { return proto.CompactTextString(m) }
+func (*CheckNetworkRequest) ProtoMessage()    {}
+func (*CheckNetworkRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b3103f8d3056b01c, []int{47}
+}
+
+func (m *CheckNetworkRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckNetworkRequest.Unmarshal(m, b)
+}
+func (m *CheckNetworkRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckNetworkRequest.Marshal(b, m, deterministic)
+}
+func (m *CheckNetworkRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckNetworkRequest.Merge(m, src)
+}
+func (m *CheckNetworkRequest) XXX_Size() int {
+	return xxx_messageInfo_CheckNetworkRequest.Size(m)
+}
+func (m *CheckNetworkRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckNetworkRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckNetworkRequest proto.InternalMessageInfo
+
+func (m *CheckNetworkRequest) GetGpArray() *GpArray {
+	if m != nil {
+		return m.GpArray
+	}
+	return nil
+}
+
+func (m *CheckNetworkRequest) GetTimeout() int32 {
+	if m != nil {
+		return m.Timeout
+	}
+	return 0
+}
+
+func (m *CheckNetworkRequest) GetMeasure() bool {
+	if m != nil {
+		return m.Measure
+	}
+	return false
+}
+
+func (m *CheckNetworkRequest) GetMeasureBytes() int64 {
+	if m != nil {
+		return m.MeasureBytes
+	}
+	return 0
+}
+
+// Result of connecting from the source host to the port of the target host
+type NetworkResult struct {
+	Source               string   `protobuf:"bytes,1,opt,name=source,proto3" json:"source,omitempty"`
+	Target               string   `protobuf:"bytes,2,opt,name=target,proto3" json:"target,omitempty"`
+	Address              string   `protobuf:"bytes,3,opt,name=address,proto3" json:"address,omitempty"`
+	Port                 int32    `protobuf:"varint,4,opt,name=port,proto3" json:"port,omitempty"`
+	Purpose              string   `protobuf:"bytes,5,opt,name=purpose,proto3" json:"purpose,omitempty"`
+	Reachable            bool     `protobuf:"varint,6,opt,name=reachable,proto3" json:"reachable,omitempty"`
+	Error                string   `protobuf:"bytes,7,opt,name=error,proto3" json:"error,omitempty"`
+	LatencyMs            float64  `protobuf:"fixed64,8,opt,name=latencyMs,proto3" json:"latencyMs,omitempty"`
+	ThroughputMBps       float64  `protobuf:"fixed64,9,opt,name=throughputMBps,proto3" json:"throughputMBps,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *NetworkResult) Reset()         { *m = NetworkResult{} }
+func (m *NetworkResult) String() string { return proto.CompactTextString(m) }
+func (*NetworkResult) ProtoMessage()    {}
+func (*NetworkResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b3103f8d3056b01c, []int{48}
+}
+
+func (m *NetworkResult) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NetworkResult.Unmarshal(m, b)
+}
+func (m *NetworkResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NetworkResult.Marshal(b, m, deterministic)
+}
+func (m *NetworkResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NetworkResult.Merge(m, src)
+}
+func (m *NetworkResult) XXX_Size() int {
+	return xxx_messageInfo_NetworkResult.Size(m)
+}
+func (m *NetworkResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_NetworkResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NetworkResult proto.InternalMessageInfo
+
+func (m *NetworkResult) GetSource() string {
+	if m != nil {
+		return m.Source
+	}
+	return ""
+}
+
+func (m *NetworkResult) GetTarget() string {
+	if m != nil {
+		return m.Target
+	}
+	return ""
+}
+
+func (m *NetworkResult) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *NetworkResult) GetPort() int32 {
+	if m != nil {
+		return m.Port
+	}
+	return 0
+}
+
+func (m *NetworkResult) GetPurpose() string {
+	if m != nil {
+		return m.Purpose
+	}
+	return ""
+}
+
+func (m *NetworkResult) GetReachable() bool {
+	if m != nil {
+		return m.Reachable
+	}
+	return false
+}
+
+func (m *NetworkResult) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func (m *NetworkResult) GetLatencyMs() float64 {
+	if m != nil {
+		return m.LatencyMs
+	}
+	return 0
+}
+
+func (m *NetworkResult) GetThroughputMBps() float64 {
+	if m != nil {
+		return m.ThroughputMBps
+	}
+	return 0
+}
+
+type CheckNetworkReply struct {
+	Results              []*NetworkResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *CheckNetworkReply) Reset()         { *m = CheckNetworkReply{} }
+func (m *CheckNetworkReply) String() string { return proto.CompactTextString(m) }
+func (*CheckNetworkReply) ProtoMessage()    {}
+func (*CheckNetworkReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b3103f8d3056b01c, []int{49}
+}
+
+func (m *CheckNetworkReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckNetworkReply.Unmarshal(m, b)
+}
+func (m *CheckNetworkReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckNetworkReply.Marshal(b, m, deterministic)
+}
+func (m *CheckNetworkReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckNetworkReply.Merge(m, src)
+}
+func (m *CheckNetworkReply) XXX_Size() int {
+	return xxx_messageInfo_CheckNetworkReply.Size(m)
+}
+func (m *CheckNetworkReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckNetworkReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckNetworkReply proto.InternalMessageInfo
+
+func (m *CheckNetworkReply) GetResults() []*NetworkResult {
+	if m != nil {
+		return m.Results
+	}
+	return nil
+}
+
+type CheckDiskRequest struct {
+	GpArray              *GpArray `protobuf:"bytes,1,opt,name=gpArray,proto3" json:"gpArray,omitempty"`
+	SizeBytes            int64    `protobuf:"varint,2,opt,name=sizeBytes,proto3" json:"sizeBytes,omitempty"`
+	MaxDuration          int32    `protobuf:"varint,3,opt,name=maxDuration,proto3" json:"maxDuration,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CheckDiskRequest) Reset()         { *m = CheckDiskRequest{} }
+func (m *CheckDiskRequest) String() string { return proto.CompactTextString(m) }
+func (*CheckDiskRequest) ProtoMessage()    {}
+func (*CheckDiskRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b3103f8d3056b01c, []int{50}
+}
+
+func (m *CheckDiskRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckDiskRequest.Unmarshal(m, b)
+}
+func (m *CheckDiskRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckDiskRequest.Marshal(b, m, deterministic)
+}
+func (m *CheckDiskRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckDiskRequest.Merge(m, src)
+}
+func (m *CheckDiskRequest) XXX_Size() int {
+	return xxx_messageInfo_CheckDiskRequest.Size(m)
+}
+func (m *CheckDiskRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckDiskRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckDiskRequest proto.InternalMessageInfo
+
+func (m *CheckDiskRequest) GetGpArray() *GpArray {
+	if m != nil {
+		return m.GpArray
+	}
+	return nil
+}
+
+func (m *CheckDiskRequest) GetSizeBytes() int64 {
+	if m != nil {
+		return m.SizeBytes
+	}
+	return 0
+}
+
+func (m *CheckDiskRequest) GetMaxDuration() int32 {
+	if m != nil {
+		return m.MaxDuration
+	}
+	return 0
+}
+
+// Sequential throughput of the disk of a data directory
+type DiskResult struct {
+	Host                 string   `protobuf:"bytes,1,opt,name=host,proto3" json:"host,omitempty"`
+	Directory            string   `protobuf:"bytes,2,opt,name=directory,proto3" json:"directory,omitempty"`
+	TestDirectory        string   `protobuf:"bytes,3,opt,name=testDirectory,proto3" json:"testDirectory,omitempty"`
+	Bytes                int64    `protobuf:"varint,4,opt,name=bytes,proto3" json:"bytes,omitempty"`
+	WriteMBps            float64  `protobuf:"fixed64,5,opt,name=writeMBps,proto3" json:"writeMBps,omitempty"`
+	ReadMBps             float64  `protobuf:"fixed64,6,opt,name=readMBps,proto3" json:"readMBps,omitempty"`
+	Error                string   `protobuf:"bytes,7,opt,name=error,proto3" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DiskResult) Reset()         { *m = DiskResult{} }
+func (m *DiskResult) String() string { return proto.CompactTextString(m) }
+func (*DiskResult) ProtoMessage()    {}
+func (*DiskResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b3103f8d3056b01c, []int{51}
+}
+
+func (m *DiskResult) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DiskResult.Unmarshal(m, b)
+}
+func (m *DiskResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DiskResult.Marshal(b, m, deterministic)
+}
+func (m *DiskResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DiskResult.Merge(m, src)
+}
+func (m *DiskResult) XXX_Size() int {
+	return xxx_messageInfo_DiskResult.Size(m)
+}
+func (m *DiskResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_DiskResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DiskResult proto.InternalMessageInfo
+
+func (m *DiskResult) GetHost() string {
+	if m != nil {
+		return m.Host
+	}
+	return ""
+}
+
+func (m *DiskResult) GetDirectory() string {
+	if m != nil {
+		return m.Directory
+	}
+	return ""
+}
+
+func (m *DiskResult) GetTestDirectory() string {
+	if m != nil {
+		return m.TestDirectory
+	}
+	return ""
+}
+
+func (m *DiskResult) GetBytes() int64 {
+	if m != nil {
+		return m.Bytes
+	}
+	return 0
+}
+
+func (m *DiskResult) GetWriteMBps() float64 {
+	if m != nil {
+		return m.WriteMBps
+	}
+	return 0
+}
+
+func (m *DiskResult) GetReadMBps() float64 {
+	if m != nil {
+		return m.ReadMBps
+	}
+	return 0
+}
+
+func (m *DiskResult) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+type CheckDiskReply struct {
+	Results              []*DiskResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *CheckDiskReply) Reset()         { *m = CheckDiskReply{} }
+func (m *CheckDiskReply) String() string { return proto.CompactTextString(m) }
+func (*CheckDiskReply) ProtoMessage()    {}
+func (*CheckDiskReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b3103f8d3056b01c, []int{52}
+}
+
+func (m *CheckDiskReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckDiskReply.Unmarshal(m, b)
+}
+func (m *CheckDiskReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckDiskReply.Marshal(b, m, deterministic)
+}
+func (m *CheckDiskReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckDiskReply.Merge(m, src)
+}
+func (m *CheckDiskReply) XXX_Size() int {
+	return xxx_messageInfo_CheckDiskReply.Size(m)
+}
+func (m *CheckDiskReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckDiskReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckDiskReply proto.InternalMessageInfo
+
+func (m *CheckDiskReply) GetResults() []*DiskResult {
+	if m != nil {
+		return m.Results
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterEnum("idl.LogLevel", LogLevel_name, LogLevel_value)
+	proto.RegisterEnum("idl.HbaPosition", HbaPosition_name, HbaPosition_value)
+	proto.RegisterType((*AddMirrorsRequest)(nil), "idl.AddMirrorsRequest")
+	proto.RegisterType((*GetAllHostNamesRequest)(nil), "idl.GetAllHostNamesRequest")
+	proto.RegisterType((*GetAllHostNamesReply)(nil), "idl.GetAllHostNamesReply")
+	proto.RegisterMapType((map[string]string)(nil), "idl.GetAllHostNamesReply.HostNameMapEntry")
+	proto.RegisterType((*StopHubRequest)(nil), "idl.StopHubRequest")
+	proto.RegisterType((*StopHubReply)(nil), "idl.StopHubReply")
+	proto.RegisterType((*StartAgentsRequest)(nil), "idl.StartAgentsRequest")
+	proto.RegisterType((*StartAgentsReply)(nil), "idl.StartAgentsReply")
+	proto.RegisterType((*StatusAgentsRequest)(nil), "idl.StatusAgentsRequest")
+	proto.RegisterType((*ServiceStatus)(nil), "idl.ServiceStatus")
+	proto.RegisterType((*StatusAgentsReply)(nil), "idl.StatusAgentsReply")
+	proto.RegisterType((*StopAgentsRequest)(nil), "idl.StopAgentsRequest")
+	proto.RegisterType((*StopAgentsReply)(nil), "idl.StopAgentsReply")
+	proto.RegisterType((*MakeClusterRequest)(nil), "idl.MakeClusterRequest")
+	proto.RegisterType((*HubReply)(nil), "idl.HubReply")
+	proto.RegisterType((*LogMessage)(nil), "idl.LogMessage")
+	proto.RegisterType((*ProgressMessage)(nil), "idl.ProgressMessage")
+	proto.RegisterType((*GpArray)(nil), "idl.gpArray")
+	proto.RegisterType((*Segment)(nil), "idl.Segment")
+	proto.RegisterType((*SegmentPair)(nil), "idl.SegmentPair")
+	proto.RegisterType((*ClusterParams)(nil), "idl.ClusterParams")
+	proto.RegisterMapType((map[string]string)(nil), "idl.ClusterParams.CommonConfigEntry")
+	proto.RegisterMapType((map[string]string)(nil), "idl.ClusterParams.CoordinatorConfigEntry")
+	proto.RegisterMapType((map[string]string)(nil), "idl.ClusterParams.SegmentConfigEntry")
+	proto.RegisterType((*SslCertFiles)(nil), "idl.SslCertFiles")
+	proto.RegisterType((*SslParams)(nil), "idl.SslParams")
+	proto.RegisterMapType((map[string]*SslCertFiles)(nil), "idl.SslParams.HostCertsEntry")
+	proto.RegisterType((*Parallelism)(nil), "idl.Parallelism")
+	proto.RegisterType((*Locale)(nil), "idl.Locale")
+	proto.RegisterType((*HbaTargets)(nil), "idl.HbaTargets")
+	proto.RegisterType((*ListPgHbaRequest)(nil), "idl.ListPgHbaRequest")
+	proto.RegisterType((*PgHbaConf)(nil), "idl.PgHbaConf")
+	proto.RegisterType((*ListPgHbaReply)(nil), "idl.ListPgHbaReply")
+	proto.RegisterType((*ModifyPgHbaRequest)(nil), "idl.ModifyPgHbaRequest")
+	proto.RegisterType((*PgHbaResult)(nil), "idl.PgHbaResult")
+	proto.RegisterType((*ModifyPgHbaReply)(nil), "idl.ModifyPgHbaReply")
+	proto.RegisterType((*CheckPgHbaRequest)(nil), "idl.CheckPgHbaRequest")
+	proto.RegisterType((*PgHbaDrift)(nil), "idl.PgHbaDrift")
+	proto.RegisterType((*CheckPgHbaReply)(nil), "idl.CheckPgHbaReply")
+	proto.RegisterType((*ReloadCertificatesRequest)(nil), "idl.ReloadCertificatesRequest")
+	proto.RegisterType((*ReloadCertificatesReply)(nil), "idl.ReloadCertificatesReply")
+	proto.RegisterType((*LogFilter)(nil), "idl.LogFilter")
+	proto.RegisterType((*LogFile)(nil), "idl.LogFile")
+	proto.RegisterType((*GetLogsRequest)(nil), "idl.GetLogsRequest")
+	proto.RegisterType((*GetLogsReply)(nil), "idl.GetLogsReply")
+	proto.RegisterType((*CollectLogsRequest)(nil), "idl.CollectLogsRequest")
+	proto.RegisterType((*HostDisk)(nil), "idl.HostDisk")
+	proto.RegisterType((*HostInterface)(nil), "idl.HostInterface")
+	proto.RegisterType((*HostInfo)(nil), "idl.HostInfo")
+	proto.RegisterType((*GetHostsInfoRequest)(nil), "idl.GetHostsInfoRequest")
+	proto.RegisterType((*HostInfoResult)(nil), "idl.HostInfoResult")
+	proto.RegisterType((*GetHostsInfoReply)(nil), "idl.GetHostsInfoReply")
+	proto.RegisterType((*CheckNetworkRequest)(nil), "idl.CheckNetworkRequest")
+	proto.RegisterType((*NetworkResult)(nil), "idl.NetworkResult")
+	proto.RegisterType((*CheckNetworkReply)(nil), "idl.CheckNetworkReply")
+	proto.RegisterType((*CheckDiskRequest)(nil), "idl.CheckDiskRequest")
+	proto.RegisterType((*DiskResult)(nil), "idl.DiskResult")
+	proto.RegisterType((*CheckDiskReply)(nil), "idl.CheckDiskReply")
+}
+
+func init() { proto.RegisterFile("hub.proto", fileDescriptor_b3103f8d3056b01c) }
+
+var fileDescriptor_b3103f8d3056b01c = []byte{
+	// 2950 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x5a, 0x5b, 0x8f, 0x1b, 0xc7,
+	0xb1, 0x16, 0xc9, 0xe5, 0xad, 0xb8, 0xbb, 0xe2, 0xb6, 0x2e, 0xa6, 0x68, 0x59, 0x67, 0xcf, 0x58,
+	0x47, 0x96, 0x75, 0x8c, 0x3d, 0xc6, 0xda, 0x27, 0xf1, 0x25, 0xb1, 0xb3, 0x37, 0x69, 0x05, 0x69,
+	0xa5, 0x45, 0xaf, 0x6c, 0x03, 0x09, 0x02, 0x61, 0x38, 0xd3, 0x24, 0x07, 0x3b, 0x9c, 0x9e, 0xf4,
+	0xf4, 0x48, 0xa6, 0xf3, 0x62, 0xff, 0x08, 0x03, 0x41, 0x80, 0x00, 0x79, 0xc8, 0x73, 0x1e, 0x92,
+	0x1f, 0x11, 0x20, 0xaf, 0x79, 0xcd, 0x53, 0xfe, 0x41, 0xfe, 0x41, 0x50, 0x7d, 0x99, 0xe9, 0x21,
+	0xb9, 0x86, 0x95, 0x00, 0x7e, 0x9b, 0xfa, 0xaa, 0xa6, 0xa7, 0xba, 0x6e, 0x5d, 0xd5, 0x24, 0x74,
+	0xa7, 0xf9, 0x68, 0x27, 0x15, 0x5c, 0x72, 0xd2, 0x88, 0xc2, 0xd8, 0xfb, 0x4b, 0x1d, 0xb6, 0xf6,
+	0xc2, 0xf0, 0x24, 0x12, 0x82, 0x8b, 0x8c, 0xb2, 0x5f, 0xe5, 0x2c, 0x93, 0x64, 0x07, 0xc8, 0x01,
+	0xe7, 0x22, 0x8c, 0x12, 0x5f, 0x72, 0x71, 0xe8, 0x4b, 0xff, 0x30, 0x12, 0x83, 0xda, 0x76, 0xed,
+	0x6e, 0x97, 0xae, 0xe0, 0x10, 0x0f, 0xd6, 0x8f, 0x47, 0xfe, 0x31, 0xcf, 0x64, 0xe2, 0xcf, 0x58,
+	0x36, 0xa8, 0x6f, 0xd7, 0xee, 0x76, 0x68, 0x05, 0x23, 0x77, 0xa0, 0x3d, 0xd3, 0x5f, 0x19, 0x34,
+	0xb6, 0x1b, 0x77, 0x7b, 0xbb, 0xeb, 0x3b, 0x51, 0x18, 0xef, 0x9c, 0xb1, 0xc9, 0x8c, 0x25, 0x92,
+	0x5a, 0x26, 0xb9, 0x0d, 0x1b, 0xd3, 0x91, 0xbf, 0x97, 0xcb, 0xe9, 0x09, 0x93, 0x53, 0x1e, 0x0e,
+	0xd6, 0xd4, 0x67, 0xab, 0x20, 0xd9, 0x86, 0x46, 0x96, 0xc5, 0x83, 0xe6, 0x76, 0xed, 0x6e, 0x6f,
+	0x77, 0x53, 0xaf, 0x94, 0xc5, 0xa7, 0xbe, 0xf0, 0x67, 0x19, 0x45, 0x16, 0xd9, 0x85, 0x5e, 0xea,
+	0x0b, 0x3f, 0x8e, 0x59, 0x1c, 0x65, 0xb3, 0x41, 0x4b, 0x49, 0xf6, 0x95, 0xe4, 0x69, 0x89, 0x53,
+	0x57, 0x88, 0x7c, 0x04, 0x83, 0xe9, 0xc8, 0xa7, 0x2c, 0x8d, 0xa3, 0xc0, 0x97, 0x11, 0x4f, 0x1c,
+	0x35, 0xda, 0x4a, 0x8d, 0x0b, 0xf9, 0xde, 0xfb, 0x70, 0xfd, 0x01, 0x93, 0x7b, 0x71, 0x8c, 0x5b,
+	0x7e, 0x82, 0x5b, 0xb6, 0xd6, 0x1c, 0x42, 0x67, 0xca, 0x33, 0xf9, 0x38, 0xca, 0xe4, 0xa0, 0xb6,
+	0xdd, 0xb8, 0xdb, 0xa5, 0x05, 0xed, 0xfd, 0xa1, 0x06, 0x57, 0x97, 0x5e, 0x4b, 0xe3, 0x39, 0x79,
+	0x0c, 0xbd, 0xa9, 0x41, 0x4e, 0xfc, 0x54, 0xbd, 0xd7, 0xdb, 0xbd, 0xa7, 0xd4, 0x5f, 0x25, 0xbf,
+	0x73, 0x5c, 0x0a, 0x1f, 0x25, 0x52, 0xcc, 0xa9, 0xfb, 0xfa, 0xf0, 0x13, 0xe8, 0x2f, 0x0a, 0x90,
+	0x3e, 0x34, 0xce, 0xd9, 0xdc, 0x78, 0x15, 0x1f, 0xc9, 0x55, 0x68, 0xbe, 0xf0, 0xe3, 0x9c, 0x29,
+	0xff, 0x75, 0xa9, 0x26, 0x3e, 0xaa, 0x7f, 0x50, 0xf3, 0xfa, 0xb0, 0x79, 0x26, 0x79, 0x7a, 0x9c,
+	0x8f, 0xcc, 0xa6, 0xbc, 0x4d, 0x58, 0x2f, 0x90, 0x34, 0x9e, 0x7b, 0x57, 0x81, 0x9c, 0x49, 0x5f,
+	0xc8, 0xbd, 0x09, 0x4b, 0xa4, 0xdd, 0xba, 0x47, 0xa0, 0x5f, 0x41, 0x51, 0xf2, 0x1a, 0x5c, 0x39,
+	0x93, 0xbe, 0xcc, 0xb3, 0xaa, 0xe8, 0xaf, 0x61, 0xe3, 0x8c, 0x89, 0x17, 0x51, 0xc0, 0x34, 0x97,
+	0x10, 0x58, 0xc3, 0x2d, 0x18, 0x05, 0xd5, 0x33, 0xb9, 0x0e, 0xad, 0x4c, 0x71, 0x8d, 0x8a, 0x86,
+	0x42, 0x3c, 0x4f, 0x65, 0x34, 0x63, 0x83, 0x86, 0xc6, 0x35, 0x85, 0x7b, 0x4c, 0x23, 0x1d, 0x42,
+	0x1b, 0x14, 0x1f, 0x71, 0x8f, 0x0c, 0x03, 0x4d, 0x85, 0x4e, 0x97, 0x6a, 0xc2, 0x3b, 0x80, 0xad,
+	0xaa, 0x4e, 0xe8, 0x82, 0x1d, 0xe8, 0xe8, 0xe5, 0x59, 0x66, 0xec, 0x4f, 0x4c, 0xc8, 0x3a, 0x6a,
+	0xd2, 0x42, 0xc6, 0xbb, 0x82, 0x8b, 0xf0, 0xb4, 0xba, 0xad, 0x2d, 0xb8, 0xec, 0x82, 0x68, 0x80,
+	0x7f, 0xd4, 0x80, 0x9c, 0xf8, 0xe7, 0xec, 0x20, 0xce, 0x33, 0xc9, 0x84, 0x0d, 0x93, 0x3b, 0xd0,
+	0x9e, 0xa4, 0x7b, 0x42, 0xf8, 0xda, 0x27, 0x36, 0x41, 0x0c, 0x46, 0x2d, 0x93, 0x7c, 0x00, 0x1b,
+	0x81, 0x7e, 0x53, 0x87, 0xbb, 0x32, 0x85, 0xd5, 0xed, 0xc0, 0xe5, 0xd0, 0xaa, 0x20, 0xb9, 0x09,
+	0xdd, 0x31, 0x17, 0x01, 0xbb, 0x1f, 0xfb, 0x13, 0x65, 0xa8, 0x0e, 0x2d, 0x01, 0x32, 0x80, 0xf6,
+	0x0b, 0x26, 0x46, 0x3c, 0x63, 0xca, 0x5e, 0x1d, 0x6a, 0xc9, 0xc5, 0x54, 0x6a, 0x7e, 0x8f, 0x54,
+	0xf2, 0x7e, 0x5b, 0x83, 0x8e, 0x0d, 0x0e, 0xf2, 0x36, 0xb4, 0x62, 0x3e, 0x39, 0xc9, 0x26, 0x66,
+	0x67, 0x97, 0xd5, 0xbb, 0x8f, 0xf9, 0xe4, 0x84, 0x65, 0x99, 0x3f, 0x61, 0xc7, 0x97, 0xa8, 0x11,
+	0x20, 0xb7, 0xa0, 0x9b, 0xc9, 0x90, 0xe7, 0x12, 0xa5, 0x95, 0x93, 0x8f, 0x2f, 0xd1, 0x12, 0x22,
+	0x1f, 0x40, 0x2f, 0x15, 0x7c, 0x22, 0x58, 0x96, 0x9d, 0x64, 0x7a, 0x17, 0xbd, 0xdd, 0xab, 0x5a,
+	0x17, 0x8b, 0x17, 0x8b, 0xba, 0xa2, 0xfb, 0x5d, 0x68, 0xcf, 0x34, 0xc7, 0x7b, 0x04, 0x50, 0x7e,
+	0x9c, 0x0c, 0x0a, 0x86, 0x89, 0x35, 0x4b, 0x92, 0x37, 0xa1, 0x19, 0xb3, 0x17, 0x2c, 0x56, 0x8a,
+	0x6c, 0xee, 0x6e, 0xa8, 0xcf, 0xc4, 0x7c, 0xf2, 0x18, 0x41, 0xaa, 0x79, 0xde, 0x4f, 0xe1, 0xf2,
+	0xc2, 0x97, 0x31, 0xc8, 0x62, 0x7f, 0x64, 0xde, 0xeb, 0x52, 0x4d, 0x20, 0x2a, 0xb9, 0xf4, 0x63,
+	0x65, 0xde, 0x26, 0xd5, 0x84, 0xc7, 0x0b, 0xb7, 0x93, 0x1d, 0xe8, 0x39, 0xc5, 0xb5, 0x12, 0x05,
+	0xb6, 0x4c, 0xba, 0x02, 0xe4, 0x7d, 0x58, 0x37, 0xb8, 0x0e, 0x9b, 0xfa, 0x76, 0xa3, 0x70, 0x8c,
+	0x61, 0x9c, 0xfa, 0x91, 0xa0, 0x15, 0x29, 0xef, 0xcf, 0x35, 0x68, 0x1b, 0x00, 0x73, 0x2c, 0xe5,
+	0x42, 0xe7, 0x58, 0x93, 0xaa, 0x67, 0x2c, 0xc0, 0xa1, 0xae, 0xeb, 0x2c, 0x90, 0x5c, 0xcc, 0xcd,
+	0x26, 0xaa, 0xa0, 0x2d, 0x6a, 0x58, 0x51, 0x4c, 0xce, 0x15, 0x34, 0xd9, 0xd6, 0xb5, 0x6b, 0x2f,
+	0x0c, 0xd1, 0x28, 0xa6, 0x80, 0xbb, 0x10, 0x46, 0x62, 0xc0, 0x13, 0xc9, 0x12, 0x19, 0x85, 0x2a,
+	0x9e, 0x9a, 0xb4, 0x04, 0x50, 0xab, 0x70, 0x14, 0x85, 0xaa, 0x66, 0x37, 0xa9, 0x7a, 0xf6, 0x7e,
+	0x01, 0x3d, 0x67, 0x4b, 0x98, 0x2c, 0xa9, 0x88, 0x66, 0xbe, 0x98, 0xaf, 0x34, 0x93, 0x65, 0x92,
+	0xdb, 0xd0, 0xd2, 0x07, 0xcb, 0xa0, 0xbe, 0x42, 0xcc, 0xf0, 0xbc, 0x7f, 0xb6, 0x60, 0xa3, 0x92,
+	0x39, 0xe4, 0x0b, 0xd8, 0x72, 0x2c, 0x7d, 0xc0, 0x93, 0x71, 0x34, 0x31, 0x45, 0xe0, 0xed, 0xe5,
+	0x44, 0xdb, 0x59, 0x92, 0xd5, 0x35, 0x78, 0x79, 0x0d, 0xf2, 0x08, 0x36, 0xcc, 0xd7, 0xcd, 0xa2,
+	0xda, 0x69, 0xff, 0xb3, 0x62, 0xd1, 0x8a, 0x9c, 0x5e, 0xb0, 0xfa, 0x2e, 0x39, 0x86, 0xf5, 0x03,
+	0x3e, 0x9b, 0xf1, 0xc4, 0xac, 0xa5, 0x0f, 0xd6, 0xdb, 0x2b, 0x15, 0x2c, 0xc5, 0xf4, 0x52, 0x95,
+	0x37, 0xc9, 0x9b, 0x98, 0xa1, 0x81, 0x1f, 0xeb, 0xdc, 0xef, 0xed, 0xf6, 0x4c, 0x86, 0x22, 0x44,
+	0x0d, 0x0b, 0x8f, 0xf9, 0xa9, 0x7b, 0xcc, 0x37, 0xf5, 0x31, 0xef, 0x62, 0x18, 0x17, 0x2c, 0x09,
+	0x78, 0x18, 0x25, 0x13, 0xe5, 0xbf, 0x2e, 0x2d, 0x68, 0x6c, 0x2b, 0xb2, 0xfc, 0xd4, 0xcf, 0xb2,
+	0x97, 0x5c, 0x84, 0x9f, 0x33, 0x11, 0x8d, 0x23, 0x26, 0xcc, 0xc1, 0xba, 0x82, 0x83, 0x55, 0x3d,
+	0x1c, 0xa9, 0x08, 0xeb, 0xe8, 0xaa, 0xae, 0x29, 0x1b, 0xa1, 0x07, 0x53, 0x16, 0x9c, 0x67, 0xf9,
+	0x2c, 0x1b, 0x74, 0x95, 0x22, 0x55, 0x70, 0xb9, 0x91, 0x80, 0x55, 0x8d, 0xc4, 0x3b, 0xb0, 0x35,
+	0x1d, 0xf9, 0x9f, 0x65, 0x4c, 0x38, 0x92, 0x3d, 0x25, 0xb9, 0xcc, 0x30, 0x16, 0x50, 0x60, 0x18,
+	0x8a, 0x6c, 0xb0, 0xae, 0x8e, 0xf3, 0x0a, 0x66, 0x5b, 0x93, 0x8d, 0x8b, 0x5b, 0x93, 0xef, 0x6a,
+	0x33, 0x36, 0xbf, 0xbb, 0xcd, 0x18, 0x1e, 0xc2, 0xf5, 0xd5, 0xc1, 0xf6, 0x2a, 0xe7, 0xf9, 0xf0,
+	0x67, 0x40, 0x96, 0xa3, 0xeb, 0x95, 0x56, 0xf8, 0x14, 0xb6, 0xdc, 0x00, 0x7a, 0xf5, 0x96, 0xe2,
+	0x10, 0xd6, 0xcf, 0xb2, 0xf8, 0x80, 0x09, 0x79, 0x3f, 0x8a, 0x75, 0xe0, 0x04, 0x86, 0x30, 0x0b,
+	0x14, 0x34, 0x56, 0xe8, 0x73, 0x36, 0x57, 0x2c, 0xbd, 0x8e, 0x25, 0xbd, 0xdf, 0xd7, 0xa1, 0x5b,
+	0x58, 0x17, 0xe5, 0x58, 0xe2, 0x8f, 0x62, 0x16, 0xaa, 0x25, 0x3a, 0xd4, 0x92, 0x18, 0x4a, 0x81,
+	0xef, 0x2c, 0x60, 0x28, 0xf2, 0x1e, 0xf4, 0x42, 0x36, 0xf6, 0xf3, 0x58, 0xa2, 0x26, 0xe6, 0x38,
+	0xd9, 0xb2, 0x4e, 0x2b, 0xb4, 0xa3, 0xae, 0x14, 0xf9, 0x18, 0xba, 0x58, 0xcc, 0xf0, 0x19, 0xab,
+	0x1b, 0xe6, 0xdc, 0x1b, 0x55, 0x3f, 0xef, 0x1c, 0x5b, 0xbe, 0x4e, 0xb6, 0x52, 0x9e, 0xdc, 0x02,
+	0x30, 0x09, 0x63, 0x1b, 0xd8, 0x0e, 0x75, 0x90, 0xe1, 0x53, 0xd8, 0xac, 0xbe, 0xbc, 0xc2, 0xaa,
+	0x6f, 0xb9, 0x56, 0x5d, 0xa9, 0xaf, 0x63, 0xe8, 0x07, 0xd0, 0x73, 0x4e, 0x69, 0x2c, 0xbd, 0x23,
+	0x5f, 0x06, 0xd3, 0xb3, 0xe8, 0x2b, 0x66, 0xea, 0x7e, 0x09, 0xa0, 0x17, 0xec, 0x29, 0xae, 0x16,
+	0x6f, 0xd2, 0x82, 0xf6, 0xfe, 0x56, 0x83, 0x96, 0xae, 0x08, 0xe4, 0x1a, 0xb4, 0xe2, 0xe0, 0xb9,
+	0x1f, 0xc7, 0x46, 0xab, 0x66, 0x1c, 0xec, 0xc5, 0x31, 0x79, 0x03, 0x20, 0x0e, 0x9e, 0x07, 0x3c,
+	0x8e, 0x7d, 0x69, 0x2d, 0xdd, 0x8d, 0x83, 0x03, 0x0d, 0x90, 0x1b, 0xd0, 0x41, 0xb6, 0x9c, 0xa7,
+	0xf6, 0xcc, 0x68, 0xc7, 0xc1, 0x01, 0x92, 0xe4, 0xbf, 0xa0, 0x17, 0x07, 0xcf, 0xcd, 0xb9, 0x6b,
+	0x8f, 0x0c, 0x88, 0x03, 0x73, 0xa2, 0x66, 0x56, 0x80, 0x27, 0x4c, 0x9d, 0x49, 0xcd, 0x42, 0xc0,
+	0x20, 0xe6, 0xdb, 0x49, 0x3e, 0x63, 0x22, 0x0a, 0x4c, 0xe9, 0xe9, 0xc6, 0xc1, 0x13, 0x0d, 0x90,
+	0xd7, 0xa0, 0x1d, 0x07, 0xcf, 0x55, 0x8b, 0xa8, 0x0b, 0x4e, 0x2b, 0x0e, 0x9e, 0x45, 0x33, 0xe6,
+	0x85, 0x00, 0xc7, 0x23, 0xff, 0x99, 0x2f, 0x26, 0x4c, 0x62, 0xf2, 0xf6, 0x82, 0x85, 0x23, 0xb8,
+	0x43, 0x5d, 0x08, 0x63, 0x2c, 0x93, 0x7e, 0x12, 0x8e, 0xe6, 0x66, 0xcc, 0xb1, 0x24, 0xda, 0x2e,
+	0xd3, 0x49, 0x95, 0x99, 0xee, 0xaa, 0xa0, 0xbd, 0x19, 0xf4, 0xb1, 0xdf, 0x3f, 0x9d, 0x1c, 0x8f,
+	0x7c, 0xdb, 0xf0, 0xed, 0x00, 0x09, 0x2e, 0x9c, 0xb2, 0x96, 0x39, 0xe4, 0x6d, 0x68, 0x4b, 0xad,
+	0xe6, 0xa0, 0xee, 0xb4, 0x51, 0xa5, 0xf6, 0xd4, 0xf2, 0xbd, 0x00, 0xba, 0xea, 0x53, 0x98, 0x9c,
+	0x78, 0x56, 0x1a, 0x3d, 0x56, 0x9f, 0x95, 0x86, 0xa9, 0xb3, 0x47, 0x8a, 0x48, 0x0d, 0x70, 0x58,
+	0xd7, 0x2c, 0x59, 0x36, 0xcd, 0x0d, 0xb7, 0x69, 0xfe, 0x11, 0x6c, 0x3a, 0x7b, 0xc2, 0x3e, 0xef,
+	0x36, 0x34, 0x03, 0x9e, 0x8c, 0x6d, 0xbb, 0xac, 0x8b, 0x5f, 0xa1, 0x08, 0xd5, 0x4c, 0xef, 0x77,
+	0x75, 0x20, 0x27, 0x3c, 0x8c, 0xc6, 0xf3, 0x1f, 0xc8, 0x1c, 0x98, 0x73, 0x7e, 0x18, 0x1e, 0x99,
+	0xcd, 0x35, 0xd4, 0xe6, 0x1c, 0x04, 0x8f, 0x0a, 0xc1, 0x66, 0xfc, 0x05, 0xb3, 0x22, 0x6b, 0x4a,
+	0xa4, 0x0a, 0x92, 0x77, 0xa0, 0x93, 0xf2, 0x2c, 0xc2, 0x82, 0xac, 0xe2, 0x6f, 0xd3, 0xb4, 0x5a,
+	0xc7, 0x23, 0xff, 0xd4, 0xe0, 0xb4, 0x90, 0xc0, 0x3c, 0x13, 0x6c, 0xcc, 0x04, 0x4b, 0x02, 0x66,
+	0xc3, 0xb1, 0x00, 0x30, 0x56, 0x12, 0x4e, 0x59, 0xcc, 0x7d, 0x3d, 0x59, 0x76, 0x68, 0x41, 0x7b,
+	0xe7, 0xd0, 0x33, 0x86, 0xc9, 0xf2, 0x58, 0x7e, 0x6f, 0xf7, 0xdd, 0x02, 0x18, 0xf9, 0xc1, 0x79,
+	0x9e, 0x3a, 0x65, 0xce, 0x41, 0x2e, 0x70, 0xe2, 0x27, 0xd0, 0xaf, 0xf8, 0x02, 0xdd, 0x78, 0x0f,
+	0xda, 0x42, 0x7d, 0xdb, 0x3a, 0xb2, 0x5f, 0x3a, 0x52, 0x2b, 0x45, 0xad, 0x80, 0xf7, 0x9b, 0x1a,
+	0x6c, 0xa9, 0x33, 0xf7, 0x87, 0xf2, 0xe5, 0x5d, 0xb8, 0xcc, 0xbe, 0x4c, 0x59, 0x20, 0xd9, 0x82,
+	0x43, 0x17, 0x61, 0x9c, 0xad, 0x41, 0x69, 0x75, 0x28, 0xa2, 0xb1, 0x7c, 0x95, 0x34, 0x98, 0x45,
+	0x59, 0x86, 0x0d, 0x8c, 0x49, 0x03, 0x43, 0xa2, 0x85, 0xf3, 0xc4, 0x7e, 0xc5, 0x86, 0x51, 0x89,
+	0x94, 0x16, 0x5e, 0x73, 0x2c, 0x8c, 0x6f, 0xcd, 0xa2, 0x8c, 0x8b, 0x90, 0x09, 0x86, 0xcd, 0xae,
+	0x7a, 0xab, 0x44, 0xbc, 0x8f, 0xe0, 0xb2, 0x6b, 0x40, 0x74, 0xc0, 0x5b, 0xd0, 0x0a, 0x51, 0x67,
+	0x6b, 0xff, 0xcb, 0xa5, 0xfd, 0xd5, 0x5e, 0xa8, 0x61, 0x7b, 0xaf, 0xc3, 0x0d, 0x1d, 0x34, 0x58,
+	0xf9, 0xa3, 0x31, 0xb6, 0x0b, 0xc5, 0xbd, 0x83, 0x77, 0x03, 0x5e, 0x5b, 0xc5, 0xc4, 0x11, 0xf4,
+	0xdb, 0x1a, 0x74, 0x1f, 0xf3, 0xc9, 0xfd, 0x28, 0x96, 0x4c, 0xa0, 0xde, 0x59, 0x84, 0x61, 0x8a,
+	0x76, 0x69, 0x50, 0x4d, 0x20, 0x9a, 0x27, 0x32, 0xd2, 0xe7, 0x40, 0x83, 0x6a, 0x02, 0x0b, 0xe4,
+	0x2c, 0x4a, 0xce, 0xd8, 0x0b, 0x26, 0x22, 0x39, 0x37, 0xb1, 0xe4, 0x42, 0xd8, 0xbd, 0x4f, 0x04,
+	0x4b, 0x8d, 0x11, 0xd4, 0x33, 0x62, 0xd2, 0x8f, 0x62, 0xd3, 0xea, 0xab, 0x67, 0xc4, 0xc6, 0x51,
+	0x6c, 0x73, 0x43, 0x3d, 0x7b, 0x8f, 0xa0, 0xad, 0xd5, 0x62, 0xc8, 0xc6, 0x8e, 0xd2, 0x8e, 0xff,
+	0xf8, 0x8c, 0x58, 0x86, 0xc7, 0x96, 0xd6, 0x48, 0x3d, 0x2b, 0x77, 0xf1, 0xf0, 0x99, 0x9d, 0xfd,
+	0x1b, 0xd4, 0x92, 0xde, 0xdf, 0x6b, 0xb0, 0xf9, 0x80, 0xc9, 0xc7, 0x7c, 0x92, 0xfd, 0xbb, 0x71,
+	0x89, 0x4d, 0x89, 0x1e, 0x4b, 0x74, 0x4d, 0x6c, 0xd2, 0x82, 0x46, 0xfb, 0xe0, 0xa9, 0x6e, 0xc3,
+	0x4f, 0x13, 0x88, 0xfa, 0x38, 0xeb, 0x9b, 0x19, 0x5a, 0x13, 0xa8, 0x78, 0x1c, 0x65, 0xd2, 0x1c,
+	0xf7, 0xea, 0x19, 0x5b, 0x92, 0x31, 0x8f, 0x63, 0xfe, 0x52, 0x59, 0xa0, 0x43, 0x0d, 0x45, 0xee,
+	0x40, 0x6b, 0xac, 0xfc, 0xa2, 0x0a, 0x83, 0xad, 0xa2, 0x85, 0xb7, 0xa8, 0xe1, 0x7a, 0x7f, 0xac,
+	0xc1, 0x7a, 0xb1, 0x3d, 0x8c, 0x9a, 0x55, 0x17, 0x26, 0x4e, 0xd0, 0xd7, 0xbf, 0x2b, 0xe8, 0xad,
+	0x33, 0x1a, 0xa5, 0x33, 0xd4, 0x14, 0x1b, 0x25, 0x45, 0x35, 0xd4, 0x04, 0xf1, 0xa0, 0x89, 0xdc,
+	0x4c, 0x45, 0xb2, 0x5d, 0xcf, 0x38, 0x8d, 0x6a, 0x56, 0x99, 0x08, 0x2d, 0xb7, 0xd4, 0xfc, 0xa9,
+	0x86, 0xd7, 0x8a, 0x71, 0xcc, 0x82, 0xff, 0xc8, 0x27, 0x85, 0xdd, 0xeb, 0x0b, 0x76, 0xd7, 0x31,
+	0xdc, 0x70, 0x63, 0x18, 0xa3, 0xd5, 0xff, 0x12, 0xfb, 0x29, 0xd5, 0xee, 0xac, 0x29, 0x9e, 0x0b,
+	0x61, 0x76, 0xf2, 0x5c, 0xa6, 0xb9, 0x6e, 0x3c, 0x4d, 0x5b, 0x51, 0x22, 0xde, 0xd7, 0x78, 0x8f,
+	0xc1, 0x33, 0x79, 0x18, 0x65, 0xe7, 0x6a, 0x20, 0x61, 0x78, 0xf7, 0x63, 0xd4, 0x33, 0x94, 0x4a,
+	0x71, 0x9e, 0x27, 0xf2, 0x94, 0x47, 0xc6, 0xd0, 0x5d, 0xea, 0x20, 0xc8, 0x57, 0xc3, 0xfe, 0xfe,
+	0x5c, 0x32, 0xdd, 0x1b, 0xac, 0x51, 0x07, 0x51, 0x17, 0x33, 0x82, 0x31, 0xcd, 0x5e, 0x53, 0xec,
+	0x12, 0xf0, 0x22, 0xd8, 0x40, 0x0d, 0x1e, 0x26, 0x92, 0x89, 0xb1, 0x1f, 0xac, 0x4e, 0x0d, 0x8c,
+	0x3b, 0x35, 0x92, 0x18, 0xab, 0x28, 0x02, 0x5b, 0xc7, 0x99, 0xcc, 0xd5, 0x17, 0x9b, 0x14, 0x1f,
+	0xf1, 0x53, 0x59, 0xca, 0x58, 0x78, 0x32, 0x4a, 0x33, 0x73, 0x11, 0x51, 0x02, 0xde, 0x37, 0x0d,
+	0xbd, 0xdb, 0x87, 0xc9, 0x98, 0xdb, 0x11, 0xdf, 0xf9, 0x54, 0x41, 0x93, 0x4d, 0xa8, 0x73, 0x7b,
+	0x09, 0x57, 0xe7, 0xea, 0x02, 0xee, 0x9c, 0x89, 0x84, 0xc5, 0xf6, 0x02, 0x4e, 0x53, 0xa8, 0xaa,
+	0x2f, 0x82, 0xa9, 0x2d, 0x06, 0xf8, 0xac, 0x92, 0x2a, 0xcd, 0x0f, 0xd0, 0x3c, 0xa6, 0x20, 0x14,
+	0xb4, 0xe1, 0x9d, 0xf0, 0x90, 0xc5, 0x76, 0x7c, 0xb4, 0xb4, 0x72, 0x26, 0x9b, 0x71, 0x31, 0xd7,
+	0x76, 0x6a, 0x2b, 0x3b, 0xb9, 0x90, 0xda, 0xdc, 0x4b, 0x3f, 0xd5, 0xfc, 0x8e, 0xb6, 0x63, 0x01,
+	0xe0, 0x6d, 0x4e, 0x18, 0x65, 0xe7, 0x38, 0x2e, 0x62, 0xe4, 0xea, 0xdb, 0x1c, 0xeb, 0x5b, 0xaa,
+	0x79, 0x64, 0x17, 0x20, 0xb2, 0x86, 0xce, 0x06, 0xe0, 0x5c, 0xfb, 0x55, 0x7c, 0x40, 0x1d, 0x29,
+	0xfc, 0xec, 0x24, 0xfd, 0x9c, 0x89, 0x0c, 0x3b, 0x03, 0x3d, 0x3b, 0x96, 0x00, 0x9a, 0x66, 0x92,
+	0x1e, 0xf3, 0x19, 0x1b, 0xac, 0x6b, 0xd3, 0x68, 0x0a, 0x71, 0xa6, 0xef, 0xc3, 0x37, 0x94, 0xcb,
+	0x0c, 0xe5, 0xfd, 0x2f, 0x5c, 0x79, 0xc0, 0x24, 0x7e, 0x2d, 0x43, 0x37, 0xd8, 0x34, 0x29, 0xc2,
+	0xbe, 0xe6, 0x84, 0xbd, 0xf7, 0x4b, 0x3d, 0x2d, 0x68, 0x41, 0xd5, 0x2e, 0xac, 0xaa, 0x02, 0xff,
+	0x0d, 0x6b, 0x51, 0x32, 0xe6, 0xa6, 0x04, 0x6c, 0x38, 0xdb, 0x19, 0x73, 0xaa, 0x58, 0x17, 0x76,
+	0x07, 0x5b, 0x55, 0x5d, 0xf4, 0x6d, 0x9e, 0xa3, 0x49, 0x6f, 0xf7, 0x4a, 0x75, 0x39, 0xdd, 0x1f,
+	0x18, 0xf5, 0xbe, 0xad, 0xc1, 0x15, 0x75, 0xb8, 0x3d, 0x61, 0xf2, 0x25, 0x17, 0xe7, 0xaf, 0x7a,
+	0xd7, 0x39, 0x80, 0x36, 0xb6, 0xec, 0x3c, 0x97, 0x66, 0x1a, 0xb1, 0xa4, 0xbe, 0xb4, 0xf3, 0xb3,
+	0x5c, 0x30, 0xd3, 0x6b, 0x5b, 0x12, 0x67, 0x74, 0xf3, 0x58, 0xe6, 0x53, 0x83, 0x56, 0x30, 0xef,
+	0x9b, 0x3a, 0x6c, 0x14, 0x2a, 0x29, 0xb3, 0xe1, 0xcd, 0x32, 0xcf, 0x45, 0x99, 0xda, 0x9a, 0x42,
+	0x5c, 0x77, 0x1e, 0x76, 0x70, 0xd4, 0x14, 0x7e, 0xdf, 0x37, 0xf7, 0x5b, 0x66, 0x94, 0x31, 0x64,
+	0x71, 0xa7, 0xb6, 0xe6, 0xdc, 0xa9, 0x0d, 0xa0, 0x9d, 0xe6, 0x22, 0xe5, 0x99, 0x2d, 0x31, 0x96,
+	0xd4, 0x6d, 0xa2, 0x1f, 0x4c, 0x71, 0x4c, 0x35, 0x07, 0x41, 0x09, 0x94, 0x5e, 0x69, 0xbb, 0x1d,
+	0xc5, 0x4d, 0xe8, 0xe2, 0x3c, 0x95, 0x04, 0xf3, 0x13, 0x1d, 0xe6, 0x35, 0x5a, 0x02, 0xe4, 0x0e,
+	0x6c, 0xca, 0xa9, 0xe0, 0xf9, 0x64, 0x9a, 0xe6, 0xf2, 0x64, 0x3f, 0xd5, 0xd7, 0x23, 0x35, 0xba,
+	0x80, 0x7a, 0x7b, 0xa6, 0x71, 0x2b, 0xec, 0x80, 0xbe, 0x7d, 0x67, 0xb1, 0xf5, 0xd3, 0xb1, 0x5f,
+	0xb1, 0x55, 0xd9, 0xfc, 0x7d, 0x05, 0x7d, 0xb5, 0x84, 0x4a, 0xa0, 0x57, 0x74, 0x2d, 0xe6, 0x6a,
+	0xf4, 0x95, 0xf1, 0x91, 0x3e, 0xd0, 0x4b, 0xc0, 0x14, 0xee, 0xc3, 0x5c, 0xa8, 0xfb, 0x0f, 0x53,
+	0xc0, 0x5c, 0xc8, 0xfb, 0x6b, 0x0d, 0x40, 0x7f, 0xf7, 0xc2, 0xb0, 0xbf, 0x09, 0xdd, 0x70, 0xe1,
+	0x16, 0xb3, 0x04, 0xb0, 0xe9, 0x97, 0x0c, 0x93, 0xdf, 0x4a, 0x68, 0x3f, 0x56, 0x41, 0xf4, 0xc0,
+	0xc8, 0x09, 0x23, 0x4d, 0xe0, 0xca, 0x2f, 0x45, 0x24, 0x99, 0x32, 0x6f, 0x53, 0x7b, 0xa0, 0x00,
+	0xb0, 0x88, 0x09, 0xe6, 0x87, 0x8a, 0xd9, 0x52, 0xcc, 0x82, 0x5e, 0xed, 0x51, 0xef, 0x63, 0xd8,
+	0x74, 0x0c, 0xa9, 0x93, 0x6c, 0xc1, 0x11, 0xba, 0x07, 0x2c, 0x77, 0x5c, 0x78, 0xe1, 0xde, 0x3e,
+	0x74, 0xec, 0x9d, 0x34, 0xe9, 0x42, 0xf3, 0xfe, 0xde, 0xb3, 0xbd, 0xc7, 0xfd, 0x4b, 0xf8, 0x78,
+	0x44, 0xe9, 0x53, 0xda, 0xaf, 0x91, 0x1e, 0xb4, 0xbf, 0xd8, 0xa3, 0x4f, 0x1e, 0x3e, 0x79, 0xd0,
+	0xaf, 0x93, 0x0e, 0xac, 0x3d, 0x7c, 0x72, 0xff, 0x69, 0xbf, 0x81, 0x12, 0x87, 0x47, 0xfb, 0x9f,
+	0x3d, 0xe8, 0xaf, 0xdd, 0x7b, 0x17, 0x7a, 0xce, 0x18, 0x43, 0x00, 0x5a, 0x7b, 0xa7, 0xa7, 0x47,
+	0x4f, 0x0e, 0xfb, 0x97, 0xf0, 0x79, 0xff, 0xe8, 0xfe, 0x53, 0x7a, 0xd4, 0xaf, 0xe1, 0x1b, 0x7b,
+	0xf7, 0x9f, 0x1d, 0xd1, 0x7e, 0x7d, 0xf7, 0xeb, 0x0e, 0x34, 0x8e, 0xf3, 0x11, 0x79, 0x17, 0xd6,
+	0xf0, 0x07, 0x0e, 0xa2, 0xcb, 0x40, 0xf5, 0x57, 0xa2, 0xe1, 0x56, 0x15, 0xc4, 0xd6, 0xf3, 0x12,
+	0xf9, 0x14, 0x7a, 0xce, 0x8f, 0x42, 0xe4, 0x35, 0x23, 0xb3, 0xf8, 0xe3, 0xd1, 0xf0, 0xda, 0x32,
+	0x43, 0x2f, 0xb0, 0x8f, 0xbf, 0x3d, 0x95, 0xbf, 0xd6, 0x90, 0x81, 0x15, 0x5c, 0xfc, 0x51, 0x69,
+	0x78, 0x7d, 0x05, 0x47, 0xaf, 0xf1, 0x13, 0x80, 0xf2, 0x77, 0x19, 0x72, 0xbd, 0xd0, 0xb3, 0xfa,
+	0xfe, 0xd5, 0x25, 0x5c, 0xbf, 0xfd, 0x21, 0xf4, 0x9c, 0x5f, 0x70, 0xcc, 0x16, 0x96, 0x7f, 0xd3,
+	0x19, 0x9a, 0x52, 0x5b, 0xec, 0xfd, 0xdd, 0x1a, 0xf9, 0x31, 0x40, 0xf9, 0x83, 0xab, 0xf9, 0xf0,
+	0xd2, 0x2f, 0xb0, 0xab, 0x5e, 0x7c, 0x04, 0x97, 0x17, 0x7e, 0xf9, 0x23, 0xaf, 0xaf, 0xfe, 0x3d,
+	0x50, 0x2f, 0x71, 0xe3, 0xc2, 0x1f, 0x0b, 0xd5, 0x06, 0xba, 0xc5, 0xec, 0x4e, 0xb4, 0xa1, 0x17,
+	0xef, 0x27, 0x86, 0x57, 0x16, 0xe1, 0xc2, 0x7d, 0xce, 0xc4, 0x68, 0xf7, 0xbe, 0x34, 0xcf, 0x0f,
+	0xaf, 0x2d, 0x33, 0x0a, 0xd3, 0x97, 0x03, 0x8f, 0xb1, 0xc0, 0xd2, 0x08, 0x39, 0xbc, 0xba, 0x84,
+	0xeb, 0xb7, 0x9f, 0x01, 0x59, 0x9e, 0x6a, 0xc8, 0x2d, 0x25, 0x7d, 0xe1, 0x2c, 0x34, 0xbc, 0x79,
+	0x21, 0x5f, 0xaf, 0xfa, 0xff, 0xd0, 0x36, 0xbd, 0xb4, 0x09, 0xe4, 0xea, 0xe0, 0x30, 0xdc, 0xaa,
+	0x82, 0xd6, 0x27, 0x1f, 0x42, 0xcf, 0xe9, 0x68, 0x8d, 0x2d, 0x96, 0x7b, 0xdc, 0x55, 0xee, 0xdc,
+	0x57, 0xdd, 0x7b, 0x71, 0xb4, 0x9a, 0x20, 0x5e, 0x71, 0xf2, 0x0f, 0xaf, 0xaf, 0xe0, 0x14, 0x89,
+	0xe0, 0x96, 0x70, 0xb3, 0xc6, 0x8a, 0x03, 0x77, 0x78, 0x7d, 0x05, 0xa7, 0x88, 0x84, 0xa2, 0xf4,
+	0x98, 0x48, 0x58, 0xac, 0xe9, 0xc3, 0x2b, 0x8b, 0xb0, 0x7a, 0x75, 0xbf, 0xf3, 0xf3, 0xd6, 0xce,
+	0xce, 0xff, 0x45, 0x61, 0x3c, 0x6a, 0xa9, 0xbf, 0x14, 0xbc, 0xf7, 0xaf, 0x01, 0x00, 0x81, 0xb7,
+	0x5c, 0xb5, 0x5f, 0x20, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// HubClient is the client API for Hub service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type HubClient interface {
+	Stop(ctx context.Context, in *StopHubRequest, opts ...grpc.CallOption) (*StopHubReply, error)
+	StartAgents(ctx context.Context, in *StartAgentsRequest, opts ...grpc.CallOption) (*StartAgentsReply, error)
+	StatusAgents(ctx context.Context, in *StatusAgentsRequest, opts ...grpc.CallOption) (*StatusAgentsReply, error)
+	StopAgents(ctx context.Context, in *StopAgentsRequest, opts ...grpc.CallOption) (*StopAgentsReply, error)
+	MakeCluster(ctx context.Context, in *MakeClusterRequest, opts ...grpc.CallOption) (Hub_MakeClusterClient, error)
+	AddMirrors(ctx context.Context, in *AddMirrorsRequest, opts ...grpc.CallOption) (Hub_AddMirrorsClient, error)
+	GetAllHostNames(ctx context.Context, in *GetAllHostNamesRequest, opts ...grpc.CallOption) (*GetAllHostNamesReply, error)
+	ListPgHba(ctx context.Context, in *ListPgHbaRequest, opts ...grpc.CallOption) (*ListPgHbaReply, error)
+	ModifyPgHba(ctx context.Context, in *ModifyPgHbaRequest, opts ...grpc.CallOption) (*ModifyPgHbaReply, error)
+	CheckPgHba(ctx context.Context, in *CheckPgHbaRequest, opts ...grpc.CallOption) (*CheckPgHbaReply, error)
+	ReloadCertificates(ctx context.Context, in *ReloadCertificatesRequest, opts ...grpc.CallOption) (*ReloadCertificatesReply, error)
+	GetLogs(ctx context.Context, in *GetLogsRequest, opts ...grpc.CallOption) (Hub_GetLogsClient, error)
+	CollectLogs(ctx context.Context, in *CollectLogsRequest, opts ...grpc.CallOption) (Hub_CollectLogsClient, error)
+	GetHostsInfo(ctx context.Context, in *GetHostsInfoRequest, opts ...grpc.CallOption) (*GetHostsInfoReply, error)
+	CheckNetwork(ctx context.Context, in *CheckNetworkRequest, opts ...grpc.CallOption) (*CheckNetworkReply, error)
+	CheckDisk(ctx context.Context, in *CheckDiskRequest, opts ...grpc.CallOption) (*CheckDiskReply, error)
+}
+
+type hubClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewHubClient(cc *grpc.ClientConn) HubClient {
+	return &hubClient{cc}
+}
+
+func (c *hubClient) Stop(ctx context.Context, in *StopHubRequest, opts ...grpc.CallOption) (*StopHubReply, error) {
+	out := new(StopHubReply)
+	err := c.cc.Invoke(ctx, "/idl.Hub/Stop", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hubClient) StartAgents(ctx context.Context, in *StartAgentsRequest, opts ...grpc.CallOption) (*StartAgentsReply, error) {
+	out := new(StartAgentsReply)
+	err := c.cc.Invoke(ctx, "/idl.Hub/StartAgents", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hubClient) StatusAgents(ctx context.Context, in *StatusAgentsRequest, opts ...grpc.CallOption) (*StatusAgentsReply, error) {
+	out := new(StatusAgentsReply)
+	err := c.cc.Invoke(ctx, "/idl.Hub/StatusAgents", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hubClient) StopAgents(ctx context.Context, in *StopAgentsRequest, opts ...grpc.CallOption) (*StopAgentsReply, error) {
+	out := new(StopAgentsReply)
+	err := c.cc.Invoke(ctx, "/idl.Hub/StopAgents", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hubClient) MakeCluster(ctx context.Context, in *MakeClusterRequest, opts ...grpc.CallOption) (Hub_MakeClusterClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Hub_serviceDesc.Streams[0], "/idl.Hub/MakeCluster", opts...)
+	if err != nil {
 		return nil, err
 	}
 	x := &hubMakeClusterClient{stream}
@@ -1376,6 +3571,133 @@ func (c *hubClient) GetAllHostNames(ctx context.Context, in *GetAllHostNamesRequ
 	return out, nil
 }
 
+func (c *hubClient) ListPgHba(ctx context.Context, in *ListPgHbaRequest, opts ...grpc.CallOption) (*ListPgHbaReply, error) {
+	out := new(ListPgHbaReply)
+	err := c.cc.Invoke(ctx, "/idl.Hub/ListPgHba", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hubClient) ModifyPgHba(ctx context.Context, in *ModifyPgHbaRequest, opts ...grpc.CallOption) (*ModifyPgHbaReply, error) {
+	out := new(ModifyPgHbaReply)
+	err := c.cc.Invoke(ctx, "/idl.Hub/ModifyPgHba", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hubClient) CheckPgHba(ctx context.Context, in *CheckPgHbaRequest, opts ...grpc.CallOption) (*CheckPgHbaReply, error) {
+	out := new(CheckPgHbaReply)
+	err := c.cc.Invoke(ctx, "/idl.Hub/CheckPgHba", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hubClient) ReloadCertificates(ctx context.Context, in *ReloadCertificatesRequest, opts ...grpc.CallOption) (*ReloadCertificatesReply, error) {
+	out := new(ReloadCertificatesReply)
+	err := c.cc.Invoke(ctx, "/idl.Hub/ReloadCertificates", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hubClient) GetLogs(ctx context.Context, in *GetLogsRequest, opts ...grpc.CallOption) (Hub_GetLogsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Hub_serviceDesc.Streams[2], "/idl.Hub/GetLogs", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &hubGetLogsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Hub_GetLogsClient interface {
+	Recv() (*GetLogsReply, error)
+	grpc.ClientStream
+}
+
+type hubGetLogsClient struct {
+	grpc.ClientStream
+}
+
+func (x *hubGetLogsClient) Recv() (*GetLogsReply, error) {
+	m := new(GetLogsReply)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *hubClient) CollectLogs(ctx context.Context, in *CollectLogsRequest, opts ...grpc.CallOption) (Hub_CollectLogsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Hub_serviceDesc.Streams[3], "/idl.Hub/CollectLogs", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &hubCollectLogsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Hub_CollectLogsClient interface {
+	Recv() (*HubReply, error)
+	grpc.ClientStream
+}
+
+type hubCollectLogsClient struct {
+	grpc.ClientStream
+}
+
+func (x *hubCollectLogsClient) Recv() (*HubReply, error) {
+	m := new(HubReply)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *hubClient) GetHostsInfo(ctx context.Context, in *GetHostsInfoRequest, opts ...grpc.CallOption) (*GetHostsInfoReply, error) {
+	out := new(GetHostsInfoReply)
+	err := c.cc.Invoke(ctx, "/idl.Hub/GetHostsInfo", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hubClient) CheckNetwork(ctx context.Context, in *CheckNetworkRequest, opts ...grpc.CallOption) (*CheckNetworkReply, error) {
+	out := new(CheckNetworkReply)
+	err := c.cc.Invoke(ctx, "/idl.Hub/CheckNetwork", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hubClient) CheckDisk(ctx context.Context, in *CheckDiskRequest, opts ...grpc.CallOption) (*CheckDiskReply, error) {
+	out := new(CheckDiskReply)
+	err := c.cc.Invoke(ctx, "/idl.Hub/CheckDisk", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // HubServer is the server API for Hub service.
 type HubServer interface {
 	Stop(context.Context, *StopHubRequest) (*StopHubReply, error)
@@ -1385,6 +3707,15 @@ type HubServer interface {
 	MakeCluster(*MakeClusterRequest, Hub_MakeClusterServer) error
 	AddMirrors(*AddMirrorsRequest, Hub_AddMirrorsServer) error
 	GetAllHostNames(context.Context, *GetAllHostNamesRequest) (*GetAllHostNamesReply, error)
+	ListPgHba(context.Context, *ListPgHbaRequest) (*ListPgHbaReply, error)
+	ModifyPgHba(context.Context, *ModifyPgHbaRequest) (*ModifyPgHbaReply, error)
+	CheckPgHba(context.Context, *CheckPgHbaRequest) (*CheckPgHbaReply, error)
+	ReloadCertificates(context.Context, *ReloadCertificatesRequest) (*ReloadCertificatesReply, error)
+	GetLogs(*GetLogsRequest, Hub_GetLogsServer) error
+	CollectLogs(*CollectLogsRequest, Hub_CollectLogsServer) error
+	GetHostsInfo(context.Context, *GetHostsInfoRequest) (*GetHostsInfoReply, error)
+	CheckNetwork(context.Context, *CheckNetworkRequest) (*CheckNetworkReply, error)
+	CheckDisk(context.Context, *CheckDiskRequest) (*CheckDiskReply, error)
 }
 
 // UnimplementedHubServer can be embedded to have forward compatible implementations.
@@ -1412,6 +3743,33 @@ func (*UnimplementedHubServer) AddMirrors(req *AddMirrorsRequest, srv Hub_AddMir
 func (*UnimplementedHubServer) GetAllHostNames(ctx context.Context, req *GetAllHostNamesRequest) (*GetAllHostNamesReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetAllHostNames not implemented")
 }
+func (*UnimplementedHubServer) ListPgHba(ctx context.Context, req *ListPgHbaRequest) (*ListPgHbaReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPgHba not implemented")
+}
+func (*UnimplementedHubServer) ModifyPgHba(ctx context.Context, req *ModifyPgHbaRequest) (*ModifyPgHbaReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ModifyPgHba not implemented")
+}
+func (*UnimplementedHubServer) CheckPgHba(ctx context.Context, req *CheckPgHbaRequest) (*CheckPgHbaReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckPgHba not implemented")
+}
+func (*UnimplementedHubServer) ReloadCertificates(ctx context.Context, req *ReloadCertificatesRequest) (*ReloadCertificatesReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReloadCertificates not implemented")
+}
+func (*UnimplementedHubServer) GetLogs(req *GetLogsRequest, srv Hub_GetLogsServer) error {
+	return status.Errorf(codes.Unimplemented, "method GetLogs not implemented")
+}
+func (*UnimplementedHubServer) CollectLogs(req *CollectLogsRequest, srv Hub_CollectLogsServer) error {
+	return status.Errorf(codes.Unimplemented, "method CollectLogs not implemented")
+}
+func (*UnimplementedHubServer) GetHostsInfo(ctx context.Context, req *GetHostsInfoRequest) (*GetHostsInfoReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetHostsInfo not implemented")
+}
+func (*UnimplementedHubServer) CheckNetwork(ctx context.Context, req *CheckNetworkRequest) (*CheckNetworkReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckNetwork not implemented")
+}
+func (*UnimplementedHubServer) CheckDisk(ctx context.Context, req *CheckDiskRequest) (*CheckDiskReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckDisk not implemented")
+}
 
 func RegisterHubServer(s *grpc.Server, srv HubServer) {
 	s.RegisterService(&_Hub_serviceDesc, srv)
@@ -1549,6 +3907,174 @@ func _Hub_GetAllHostNames_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Hub_ListPgHba_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPgHbaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HubServer).ListPgHba(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/idl.Hub/ListPgHba",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HubServer).ListPgHba(ctx, req.(*ListPgHbaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Hub_ModifyPgHba_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ModifyPgHbaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HubServer).ModifyPgHba(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/idl.Hub/ModifyPgHba",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HubServer).ModifyPgHba(ctx, req.(*ModifyPgHbaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Hub_CheckPgHba_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckPgHbaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HubServer).CheckPgHba(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/idl.Hub/CheckPgHba",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HubServer).CheckPgHba(ctx, req.(*CheckPgHbaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Hub_ReloadCertificates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReloadCertificatesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HubServer).ReloadCertificates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/idl.Hub/ReloadCertificates",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HubServer).ReloadCertificates(ctx, req.(*ReloadCertificatesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Hub_GetLogs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetLogsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(HubServer).GetLogs(m, &hubGetLogsServer{stream})
+}
+
+type Hub_GetLogsServer interface {
+	Send(*GetLogsReply) error
+	grpc.ServerStream
+}
+
+type hubGetLogsServer struct {
+	grpc.ServerStream
+}
+
+func (x *hubGetLogsServer) Send(m *GetLogsReply) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Hub_CollectLogs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(CollectLogsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(HubServer).CollectLogs(m, &hubCollectLogsServer{stream})
+}
+
+type Hub_CollectLogsServer interface {
+	Send(*HubReply) error
+	grpc.ServerStream
+}
+
+type hubCollectLogsServer struct {
+	grpc.ServerStream
+}
+
+func (x *hubCollectLogsServer) Send(m *HubReply) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Hub_GetHostsInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetHostsInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HubServer).GetHostsInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/idl.Hub/GetHostsInfo",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HubServer).GetHostsInfo(ctx, req.(*GetHostsInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Hub_CheckNetwork_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckNetworkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HubServer).CheckNetwork(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/idl.Hub/CheckNetwork",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HubServer).CheckNetwork(ctx, req.(*CheckNetworkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Hub_CheckDisk_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckDiskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HubServer).CheckDisk(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/idl.Hub/CheckDisk",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HubServer).CheckDisk(ctx, req.(*CheckDiskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Hub_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "idl.Hub",
 	HandlerType: (*HubServer)(nil),
@@ -1573,6 +4099,34 @@ var _Hub_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetAllHostNames",
 			Handler:    _Hub_GetAllHostNames_Handler,
 		},
+		{
+			MethodName: "ListPgHba",
+			Handler:    _Hub_ListPgHba_Handler,
+		},
+		{
+			MethodName: "ModifyPgHba",
+			Handler:    _Hub_ModifyPgHba_Handler,
+		},
+		{
+			MethodName: "CheckPgHba",
+			Handler:    _Hub_CheckPgHba_Handler,
+		},
+		{
+			MethodName: "ReloadCertificates",
+			Handler:    _Hub_ReloadCertificates_Handler,
+		},
+		{
+			MethodName: "GetHostsInfo",
+			Handler:    _Hub_GetHostsInfo_Handler,
+		},
+		{
+			MethodName: "CheckNetwork",
+			Handler:    _Hub_CheckNetwork_Handler,
+		},
+		{
+			MethodName: "CheckDisk",
+			Handler:    _Hub_CheckDisk_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -1585,6 +4139,16 @@ var _Hub_serviceDesc = grpc.ServiceDesc{
 			Handler:       _Hub_AddMirrors_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "GetLogs",
+			Handler:       _Hub_GetLogs_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "CollectLogs",
+			Handler:       _Hub_CollectLogs_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "hub.proto",
 }
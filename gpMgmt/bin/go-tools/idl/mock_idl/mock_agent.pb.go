@@ -11,6 +11,7 @@ import (
 	gomock "github.com/golang/mock/gomock"
 	idl "github.com/greenplum-db/gpdb/gp/idl"
 	grpc "google.golang.org/grpc"
+	metadata "google.golang.org/grpc/metadata"
 )
 
 // MockAgentClient is a mock of AgentClient interface.
@@ -36,6 +37,46 @@ func (m *MockAgentClient) EXPECT() *MockAgentClientMockRecorder {
 	return m.recorder
 }
 
+// CollectDiagnostics mocks base method.
+func (m *MockAgentClient) CollectDiagnostics(ctx context.Context, in *idl.CollectDiagnosticsRequest, opts ...grpc.CallOption) (idl.Agent_CollectDiagnosticsClient, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CollectDiagnostics", varargs...)
+	ret0, _ := ret[0].(idl.Agent_CollectDiagnosticsClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CollectDiagnostics indicates an expected call of CollectDiagnostics.
+func (mr *MockAgentClientMockRecorder) CollectDiagnostics(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CollectDiagnostics", reflect.TypeOf((*MockAgentClient)(nil).CollectDiagnostics), varargs...)
+}
+
+// GetHostInfo mocks base method.
+func (m *MockAgentClient) GetHostInfo(ctx context.Context, in *idl.GetHostInfoRequest, opts ...grpc.CallOption) (*idl.GetHostInfoReply, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetHostInfo", varargs...)
+	ret0, _ := ret[0].(*idl.GetHostInfoReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHostInfo indicates an expected call of GetHostInfo.
+func (mr *MockAgentClientMockRecorder) GetHostInfo(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHostInfo", reflect.TypeOf((*MockAgentClient)(nil).GetHostInfo), varargs...)
+}
+
 // GetHostName mocks base method.
 func (m *MockAgentClient) GetHostName(ctx context.Context, in *idl.GetHostNameRequest, opts ...grpc.CallOption) (*idl.GetHostNameReply, error) {
 	m.ctrl.T.Helper()
@@ -76,6 +117,46 @@ func (mr *MockAgentClientMockRecorder) GetInterfaceAddrs(ctx, in interface{}, op
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInterfaceAddrs", reflect.TypeOf((*MockAgentClient)(nil).GetInterfaceAddrs), varargs...)
 }
 
+// GetPgHbaConf mocks base method.
+func (m *MockAgentClient) GetPgHbaConf(ctx context.Context, in *idl.GetPgHbaConfRequest, opts ...grpc.CallOption) (*idl.GetPgHbaConfReply, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetPgHbaConf", varargs...)
+	ret0, _ := ret[0].(*idl.GetPgHbaConfReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPgHbaConf indicates an expected call of GetPgHbaConf.
+func (mr *MockAgentClientMockRecorder) GetPgHbaConf(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPgHbaConf", reflect.TypeOf((*MockAgentClient)(nil).GetPgHbaConf), varargs...)
+}
+
+// InstallSslCertificate mocks base method.
+func (m *MockAgentClient) InstallSslCertificate(ctx context.Context, in *idl.InstallSslCertificateRequest, opts ...grpc.CallOption) (*idl.InstallSslCertificateReply, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "InstallSslCertificate", varargs...)
+	ret0, _ := ret[0].(*idl.InstallSslCertificateReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InstallSslCertificate indicates an expected call of InstallSslCertificate.
+func (mr *MockAgentClientMockRecorder) InstallSslCertificate(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InstallSslCertificate", reflect.TypeOf((*MockAgentClient)(nil).InstallSslCertificate), varargs...)
+}
+
 // MakeSegment mocks base method.
 func (m *MockAgentClient) MakeSegment(ctx context.Context, in *idl.MakeSegmentRequest, opts ...grpc.CallOption) (*idl.MakeSegmentReply, error) {
 	m.ctrl.T.Helper()
@@ -96,6 +177,26 @@ func (mr *MockAgentClientMockRecorder) MakeSegment(ctx, in interface{}, opts ...
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MakeSegment", reflect.TypeOf((*MockAgentClient)(nil).MakeSegment), varargs...)
 }
 
+// ModifyPgHbaConfAndReload mocks base method.
+func (m *MockAgentClient) ModifyPgHbaConfAndReload(ctx context.Context, in *idl.ModifyPgHbaConfRequest, opts ...grpc.CallOption) (*idl.ModifyPgHbaConfReply, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ModifyPgHbaConfAndReload", varargs...)
+	ret0, _ := ret[0].(*idl.ModifyPgHbaConfReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ModifyPgHbaConfAndReload indicates an expected call of ModifyPgHbaConfAndReload.
+func (mr *MockAgentClientMockRecorder) ModifyPgHbaConfAndReload(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ModifyPgHbaConfAndReload", reflect.TypeOf((*MockAgentClient)(nil).ModifyPgHbaConfAndReload), varargs...)
+}
+
 // PgBasebackup mocks base method.
 func (m *MockAgentClient) PgBasebackup(ctx context.Context, in *idl.PgBasebackupRequest, opts ...grpc.CallOption) (*idl.PgBasebackupResponse, error) {
 	m.ctrl.T.Helper()
@@ -116,6 +217,66 @@ func (mr *MockAgentClientMockRecorder) PgBasebackup(ctx, in interface{}, opts ..
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PgBasebackup", reflect.TypeOf((*MockAgentClient)(nil).PgBasebackup), varargs...)
 }
 
+// ReadLogs mocks base method.
+func (m *MockAgentClient) ReadLogs(ctx context.Context, in *idl.ReadLogsRequest, opts ...grpc.CallOption) (idl.Agent_ReadLogsClient, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ReadLogs", varargs...)
+	ret0, _ := ret[0].(idl.Agent_ReadLogsClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadLogs indicates an expected call of ReadLogs.
+func (mr *MockAgentClientMockRecorder) ReadLogs(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadLogs", reflect.TypeOf((*MockAgentClient)(nil).ReadLogs), varargs...)
+}
+
+// ReloadCertificates mocks base method.
+func (m *MockAgentClient) ReloadCertificates(ctx context.Context, in *idl.ReloadCertificatesRequest, opts ...grpc.CallOption) (*idl.ReloadCertificatesReply, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ReloadCertificates", varargs...)
+	ret0, _ := ret[0].(*idl.ReloadCertificatesReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReloadCertificates indicates an expected call of ReloadCertificates.
+func (mr *MockAgentClientMockRecorder) ReloadCertificates(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReloadCertificates", reflect.TypeOf((*MockAgentClient)(nil).ReloadCertificates), varargs...)
+}
+
+// StartListeners mocks base method.
+func (m *MockAgentClient) StartListeners(ctx context.Context, in *idl.StartListenersRequest, opts ...grpc.CallOption) (*idl.StartListenersReply, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "StartListeners", varargs...)
+	ret0, _ := ret[0].(*idl.StartListenersReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StartListeners indicates an expected call of StartListeners.
+func (mr *MockAgentClientMockRecorder) StartListeners(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartListeners", reflect.TypeOf((*MockAgentClient)(nil).StartListeners), varargs...)
+}
+
 // StartSegment mocks base method.
 func (m *MockAgentClient) StartSegment(ctx context.Context, in *idl.StartSegmentRequest, opts ...grpc.CallOption) (*idl.StartSegmentReply, error) {
 	m.ctrl.T.Helper()
@@ -176,6 +337,66 @@ func (mr *MockAgentClientMockRecorder) Stop(ctx, in interface{}, opts ...interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stop", reflect.TypeOf((*MockAgentClient)(nil).Stop), varargs...)
 }
 
+// StopListeners mocks base method.
+func (m *MockAgentClient) StopListeners(ctx context.Context, in *idl.StopListenersRequest, opts ...grpc.CallOption) (*idl.StopListenersReply, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "StopListeners", varargs...)
+	ret0, _ := ret[0].(*idl.StopListenersReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StopListeners indicates an expected call of StopListeners.
+func (mr *MockAgentClientMockRecorder) StopListeners(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopListeners", reflect.TypeOf((*MockAgentClient)(nil).StopListeners), varargs...)
+}
+
+// TestConnectivity mocks base method.
+func (m *MockAgentClient) TestConnectivity(ctx context.Context, in *idl.TestConnectivityRequest, opts ...grpc.CallOption) (*idl.TestConnectivityReply, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "TestConnectivity", varargs...)
+	ret0, _ := ret[0].(*idl.TestConnectivityReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TestConnectivity indicates an expected call of TestConnectivity.
+func (mr *MockAgentClientMockRecorder) TestConnectivity(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TestConnectivity", reflect.TypeOf((*MockAgentClient)(nil).TestConnectivity), varargs...)
+}
+
+// TestDiskPerformance mocks base method.
+func (m *MockAgentClient) TestDiskPerformance(ctx context.Context, in *idl.TestDiskPerformanceRequest, opts ...grpc.CallOption) (*idl.TestDiskPerformanceReply, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "TestDiskPerformance", varargs...)
+	ret0, _ := ret[0].(*idl.TestDiskPerformanceReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TestDiskPerformance indicates an expected call of TestDiskPerformance.
+func (mr *MockAgentClientMockRecorder) TestDiskPerformance(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TestDiskPerformance", reflect.TypeOf((*MockAgentClient)(nil).TestDiskPerformance), varargs...)
+}
+
 // UpdatePgConf mocks base method.
 func (m *MockAgentClient) UpdatePgConf(ctx context.Context, in *idl.UpdatePgConfRequest, opts ...grpc.CallOption) (*idl.UpdatePgConfRespoonse, error) {
 	m.ctrl.T.Helper()
@@ -236,6 +457,252 @@ func (mr *MockAgentClientMockRecorder) ValidateHostEnv(ctx, in interface{}, opts
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateHostEnv", reflect.TypeOf((*MockAgentClient)(nil).ValidateHostEnv), varargs...)
 }
 
+// MockAgent_ReadLogsClient is a mock of Agent_ReadLogsClient interface.
+type MockAgent_ReadLogsClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockAgent_ReadLogsClientMockRecorder
+}
+
+// MockAgent_ReadLogsClientMockRecorder is the mock recorder for MockAgent_ReadLogsClient.
+type MockAgent_ReadLogsClientMockRecorder struct {
+	mock *MockAgent_ReadLogsClient
+}
+
+// NewMockAgent_ReadLogsClient creates a new mock instance.
+func NewMockAgent_ReadLogsClient(ctrl *gomock.Controller) *MockAgent_ReadLogsClient {
+	mock := &MockAgent_ReadLogsClient{ctrl: ctrl}
+	mock.recorder = &MockAgent_ReadLogsClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAgent_ReadLogsClient) EXPECT() *MockAgent_ReadLogsClientMockRecorder {
+	return m.recorder
+}
+
+// CloseSend mocks base method.
+func (m *MockAgent_ReadLogsClient) CloseSend() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloseSend")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CloseSend indicates an expected call of CloseSend.
+func (mr *MockAgent_ReadLogsClientMockRecorder) CloseSend() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseSend", reflect.TypeOf((*MockAgent_ReadLogsClient)(nil).CloseSend))
+}
+
+// Context mocks base method.
+func (m *MockAgent_ReadLogsClient) Context() context.Context {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Context")
+	ret0, _ := ret[0].(context.Context)
+	return ret0
+}
+
+// Context indicates an expected call of Context.
+func (mr *MockAgent_ReadLogsClientMockRecorder) Context() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Context", reflect.TypeOf((*MockAgent_ReadLogsClient)(nil).Context))
+}
+
+// Header mocks base method.
+func (m *MockAgent_ReadLogsClient) Header() (metadata.MD, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Header")
+	ret0, _ := ret[0].(metadata.MD)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Header indicates an expected call of Header.
+func (mr *MockAgent_ReadLogsClientMockRecorder) Header() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Header", reflect.TypeOf((*MockAgent_ReadLogsClient)(nil).Header))
+}
+
+// Recv mocks base method.
+func (m *MockAgent_ReadLogsClient) Recv() (*idl.ReadLogsReply, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Recv")
+	ret0, _ := ret[0].(*idl.ReadLogsReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Recv indicates an expected call of Recv.
+func (mr *MockAgent_ReadLogsClientMockRecorder) Recv() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Recv", reflect.TypeOf((*MockAgent_ReadLogsClient)(nil).Recv))
+}
+
+// RecvMsg mocks base method.
+func (m_2 *MockAgent_ReadLogsClient) RecvMsg(m any) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "RecvMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecvMsg indicates an expected call of RecvMsg.
+func (mr *MockAgent_ReadLogsClientMockRecorder) RecvMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecvMsg", reflect.TypeOf((*MockAgent_ReadLogsClient)(nil).RecvMsg), m)
+}
+
+// SendMsg mocks base method.
+func (m_2 *MockAgent_ReadLogsClient) SendMsg(m any) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "SendMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMsg indicates an expected call of SendMsg.
+func (mr *MockAgent_ReadLogsClientMockRecorder) SendMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMsg", reflect.TypeOf((*MockAgent_ReadLogsClient)(nil).SendMsg), m)
+}
+
+// Trailer mocks base method.
+func (m *MockAgent_ReadLogsClient) Trailer() metadata.MD {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Trailer")
+	ret0, _ := ret[0].(metadata.MD)
+	return ret0
+}
+
+// Trailer indicates an expected call of Trailer.
+func (mr *MockAgent_ReadLogsClientMockRecorder) Trailer() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Trailer", reflect.TypeOf((*MockAgent_ReadLogsClient)(nil).Trailer))
+}
+
+// MockAgent_CollectDiagnosticsClient is a mock of Agent_CollectDiagnosticsClient interface.
+type MockAgent_CollectDiagnosticsClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockAgent_CollectDiagnosticsClientMockRecorder
+}
+
+// MockAgent_CollectDiagnosticsClientMockRecorder is the mock recorder for MockAgent_CollectDiagnosticsClient.
+type MockAgent_CollectDiagnosticsClientMockRecorder struct {
+	mock *MockAgent_CollectDiagnosticsClient
+}
+
+// NewMockAgent_CollectDiagnosticsClient creates a new mock instance.
+func NewMockAgent_CollectDiagnosticsClient(ctrl *gomock.Controller) *MockAgent_CollectDiagnosticsClient {
+	mock := &MockAgent_CollectDiagnosticsClient{ctrl: ctrl}
+	mock.recorder = &MockAgent_CollectDiagnosticsClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAgent_CollectDiagnosticsClient) EXPECT() *MockAgent_CollectDiagnosticsClientMockRecorder {
+	return m.recorder
+}
+
+// CloseSend mocks base method.
+func (m *MockAgent_CollectDiagnosticsClient) CloseSend() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloseSend")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CloseSend indicates an expected call of CloseSend.
+func (mr *MockAgent_CollectDiagnosticsClientMockRecorder) CloseSend() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseSend", reflect.TypeOf((*MockAgent_CollectDiagnosticsClient)(nil).CloseSend))
+}
+
+// Context mocks base method.
+func (m *MockAgent_CollectDiagnosticsClient) Context() context.Context {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Context")
+	ret0, _ := ret[0].(context.Context)
+	return ret0
+}
+
+// Context indicates an expected call of Context.
+func (mr *MockAgent_CollectDiagnosticsClientMockRecorder) Context() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Context", reflect.TypeOf((*MockAgent_CollectDiagnosticsClient)(nil).Context))
+}
+
+// Header mocks base method.
+func (m *MockAgent_CollectDiagnosticsClient) Header() (metadata.MD, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Header")
+	ret0, _ := ret[0].(metadata.MD)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Header indicates an expected call of Header.
+func (mr *MockAgent_CollectDiagnosticsClientMockRecorder) Header() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Header", reflect.TypeOf((*MockAgent_CollectDiagnosticsClient)(nil).Header))
+}
+
+// Recv mocks base method.
+func (m *MockAgent_CollectDiagnosticsClient) Recv() (*idl.CollectDiagnosticsReply, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Recv")
+	ret0, _ := ret[0].(*idl.CollectDiagnosticsReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Recv indicates an expected call of Recv.
+func (mr *MockAgent_CollectDiagnosticsClientMockRecorder) Recv() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Recv", reflect.TypeOf((*MockAgent_CollectDiagnosticsClient)(nil).Recv))
+}
+
+// RecvMsg mocks base method.
+func (m_2 *MockAgent_CollectDiagnosticsClient) RecvMsg(m any) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "RecvMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecvMsg indicates an expected call of RecvMsg.
+func (mr *MockAgent_CollectDiagnosticsClientMockRecorder) RecvMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecvMsg", reflect.TypeOf((*MockAgent_CollectDiagnosticsClient)(nil).RecvMsg), m)
+}
+
+// SendMsg mocks base method.
+func (m_2 *MockAgent_CollectDiagnosticsClient) SendMsg(m any) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "SendMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMsg indicates an expected call of SendMsg.
+func (mr *MockAgent_CollectDiagnosticsClientMockRecorder) SendMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMsg", reflect.TypeOf((*MockAgent_CollectDiagnosticsClient)(nil).SendMsg), m)
+}
+
+// Trailer mocks base method.
+func (m *MockAgent_CollectDiagnosticsClient) Trailer() metadata.MD {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Trailer")
+	ret0, _ := ret[0].(metadata.MD)
+	return ret0
+}
+
+// Trailer indicates an expected call of Trailer.
+func (mr *MockAgent_CollectDiagnosticsClientMockRecorder) Trailer() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Trailer", reflect.TypeOf((*MockAgent_CollectDiagnosticsClient)(nil).Trailer))
+}
+
 // MockAgentServer is a mock of AgentServer interface.
 type MockAgentServer struct {
 	ctrl     *gomock.Controller
@@ -259,6 +726,35 @@ func (m *MockAgentServer) EXPECT() *MockAgentServerMockRecorder {
 	return m.recorder
 }
 
+// CollectDiagnostics mocks base method.
+func (m *MockAgentServer) CollectDiagnostics(arg0 *idl.CollectDiagnosticsRequest, arg1 idl.Agent_CollectDiagnosticsServer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CollectDiagnostics", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CollectDiagnostics indicates an expected call of CollectDiagnostics.
+func (mr *MockAgentServerMockRecorder) CollectDiagnostics(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CollectDiagnostics", reflect.TypeOf((*MockAgentServer)(nil).CollectDiagnostics), arg0, arg1)
+}
+
+// GetHostInfo mocks base method.
+func (m *MockAgentServer) GetHostInfo(arg0 context.Context, arg1 *idl.GetHostInfoRequest) (*idl.GetHostInfoReply, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHostInfo", arg0, arg1)
+	ret0, _ := ret[0].(*idl.GetHostInfoReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHostInfo indicates an expected call of GetHostInfo.
+func (mr *MockAgentServerMockRecorder) GetHostInfo(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHostInfo", reflect.TypeOf((*MockAgentServer)(nil).GetHostInfo), arg0, arg1)
+}
+
 // GetHostName mocks base method.
 func (m *MockAgentServer) GetHostName(arg0 context.Context, arg1 *idl.GetHostNameRequest) (*idl.GetHostNameReply, error) {
 	m.ctrl.T.Helper()
@@ -289,6 +785,36 @@ func (mr *MockAgentServerMockRecorder) GetInterfaceAddrs(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInterfaceAddrs", reflect.TypeOf((*MockAgentServer)(nil).GetInterfaceAddrs), arg0, arg1)
 }
 
+// GetPgHbaConf mocks base method.
+func (m *MockAgentServer) GetPgHbaConf(arg0 context.Context, arg1 *idl.GetPgHbaConfRequest) (*idl.GetPgHbaConfReply, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPgHbaConf", arg0, arg1)
+	ret0, _ := ret[0].(*idl.GetPgHbaConfReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPgHbaConf indicates an expected call of GetPgHbaConf.
+func (mr *MockAgentServerMockRecorder) GetPgHbaConf(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPgHbaConf", reflect.TypeOf((*MockAgentServer)(nil).GetPgHbaConf), arg0, arg1)
+}
+
+// InstallSslCertificate mocks base method.
+func (m *MockAgentServer) InstallSslCertificate(arg0 context.Context, arg1 *idl.InstallSslCertificateRequest) (*idl.InstallSslCertificateReply, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InstallSslCertificate", arg0, arg1)
+	ret0, _ := ret[0].(*idl.InstallSslCertificateReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InstallSslCertificate indicates an expected call of InstallSslCertificate.
+func (mr *MockAgentServerMockRecorder) InstallSslCertificate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InstallSslCertificate", reflect.TypeOf((*MockAgentServer)(nil).InstallSslCertificate), arg0, arg1)
+}
+
 // MakeSegment mocks base method.
 func (m *MockAgentServer) MakeSegment(arg0 context.Context, arg1 *idl.MakeSegmentRequest) (*idl.MakeSegmentReply, error) {
 	m.ctrl.T.Helper()
@@ -304,6 +830,21 @@ func (mr *MockAgentServerMockRecorder) MakeSegment(arg0, arg1 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MakeSegment", reflect.TypeOf((*MockAgentServer)(nil).MakeSegment), arg0, arg1)
 }
 
+// ModifyPgHbaConfAndReload mocks base method.
+func (m *MockAgentServer) ModifyPgHbaConfAndReload(arg0 context.Context, arg1 *idl.ModifyPgHbaConfRequest) (*idl.ModifyPgHbaConfReply, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ModifyPgHbaConfAndReload", arg0, arg1)
+	ret0, _ := ret[0].(*idl.ModifyPgHbaConfReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ModifyPgHbaConfAndReload indicates an expected call of ModifyPgHbaConfAndReload.
+func (mr *MockAgentServerMockRecorder) ModifyPgHbaConfAndReload(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ModifyPgHbaConfAndReload", reflect.TypeOf((*MockAgentServer)(nil).ModifyPgHbaConfAndReload), arg0, arg1)
+}
+
 // PgBasebackup mocks base method.
 func (m *MockAgentServer) PgBasebackup(arg0 context.Context, arg1 *idl.PgBasebackupRequest) (*idl.PgBasebackupResponse, error) {
 	m.ctrl.T.Helper()
@@ -319,6 +860,50 @@ func (mr *MockAgentServerMockRecorder) PgBasebackup(arg0, arg1 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PgBasebackup", reflect.TypeOf((*MockAgentServer)(nil).PgBasebackup), arg0, arg1)
 }
 
+// ReadLogs mocks base method.
+func (m *MockAgentServer) ReadLogs(arg0 *idl.ReadLogsRequest, arg1 idl.Agent_ReadLogsServer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadLogs", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReadLogs indicates an expected call of ReadLogs.
+func (mr *MockAgentServerMockRecorder) ReadLogs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadLogs", reflect.TypeOf((*MockAgentServer)(nil).ReadLogs), arg0, arg1)
+}
+
+// ReloadCertificates mocks base method.
+func (m *MockAgentServer) ReloadCertificates(arg0 context.Context, arg1 *idl.ReloadCertificatesRequest) (*idl.ReloadCertificatesReply, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReloadCertificates", arg0, arg1)
+	ret0, _ := ret[0].(*idl.ReloadCertificatesReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReloadCertificates indicates an expected call of ReloadCertificates.
+func (mr *MockAgentServerMockRecorder) ReloadCertificates(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReloadCertificates", reflect.TypeOf((*MockAgentServer)(nil).ReloadCertificates), arg0, arg1)
+}
+
+// StartListeners mocks base method.
+func (m *MockAgentServer) StartListeners(arg0 context.Context, arg1 *idl.StartListenersRequest) (*idl.StartListenersReply, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartListeners", arg0, arg1)
+	ret0, _ := ret[0].(*idl.StartListenersReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StartListeners indicates an expected call of StartListeners.
+func (mr *MockAgentServerMockRecorder) StartListeners(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartListeners", reflect.TypeOf((*MockAgentServer)(nil).StartListeners), arg0, arg1)
+}
+
 // StartSegment mocks base method.
 func (m *MockAgentServer) StartSegment(arg0 context.Context, arg1 *idl.StartSegmentRequest) (*idl.StartSegmentReply, error) {
 	m.ctrl.T.Helper()
@@ -364,6 +949,51 @@ func (mr *MockAgentServerMockRecorder) Stop(arg0, arg1 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stop", reflect.TypeOf((*MockAgentServer)(nil).Stop), arg0, arg1)
 }
 
+// StopListeners mocks base method.
+func (m *MockAgentServer) StopListeners(arg0 context.Context, arg1 *idl.StopListenersRequest) (*idl.StopListenersReply, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StopListeners", arg0, arg1)
+	ret0, _ := ret[0].(*idl.StopListenersReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StopListeners indicates an expected call of StopListeners.
+func (mr *MockAgentServerMockRecorder) StopListeners(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopListeners", reflect.TypeOf((*MockAgentServer)(nil).StopListeners), arg0, arg1)
+}
+
+// TestConnectivity mocks base method.
+func (m *MockAgentServer) TestConnectivity(arg0 context.Context, arg1 *idl.TestConnectivityRequest) (*idl.TestConnectivityReply, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TestConnectivity", arg0, arg1)
+	ret0, _ := ret[0].(*idl.TestConnectivityReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TestConnectivity indicates an expected call of TestConnectivity.
+func (mr *MockAgentServerMockRecorder) TestConnectivity(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TestConnectivity", reflect.TypeOf((*MockAgentServer)(nil).TestConnectivity), arg0, arg1)
+}
+
+// TestDiskPerformance mocks base method.
+func (m *MockAgentServer) TestDiskPerformance(arg0 context.Context, arg1 *idl.TestDiskPerformanceRequest) (*idl.TestDiskPerformanceReply, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TestDiskPerformance", arg0, arg1)
+	ret0, _ := ret[0].(*idl.TestDiskPerformanceReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TestDiskPerformance indicates an expected call of TestDiskPerformance.
+func (mr *MockAgentServerMockRecorder) TestDiskPerformance(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TestDiskPerformance", reflect.TypeOf((*MockAgentServer)(nil).TestDiskPerformance), arg0, arg1)
+}
+
 // UpdatePgConf mocks base method.
 func (m *MockAgentServer) UpdatePgConf(arg0 context.Context, arg1 *idl.UpdatePgConfRequest) (*idl.UpdatePgConfRespoonse, error) {
 	m.ctrl.T.Helper()
@@ -408,3 +1038,241 @@ func (mr *MockAgentServerMockRecorder) ValidateHostEnv(arg0, arg1 interface{}) *
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateHostEnv", reflect.TypeOf((*MockAgentServer)(nil).ValidateHostEnv), arg0, arg1)
 }
+
+// MockAgent_ReadLogsServer is a mock of Agent_ReadLogsServer interface.
+type MockAgent_ReadLogsServer struct {
+	ctrl     *gomock.Controller
+	recorder *MockAgent_ReadLogsServerMockRecorder
+}
+
+// MockAgent_ReadLogsServerMockRecorder is the mock recorder for MockAgent_ReadLogsServer.
+type MockAgent_ReadLogsServerMockRecorder struct {
+	mock *MockAgent_ReadLogsServer
+}
+
+// NewMockAgent_ReadLogsServer creates a new mock instance.
+func NewMockAgent_ReadLogsServer(ctrl *gomock.Controller) *MockAgent_ReadLogsServer {
+	mock := &MockAgent_ReadLogsServer{ctrl: ctrl}
+	mock.recorder = &MockAgent_ReadLogsServerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAgent_ReadLogsServer) EXPECT() *MockAgent_ReadLogsServerMockRecorder {
+	return m.recorder
+}
+
+// Context mocks base method.
+func (m *MockAgent_ReadLogsServer) Context() context.Context {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Context")
+	ret0, _ := ret[0].(context.Context)
+	return ret0
+}
+
+// Context indicates an expected call of Context.
+func (mr *MockAgent_ReadLogsServerMockRecorder) Context() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Context", reflect.TypeOf((*MockAgent_ReadLogsServer)(nil).Context))
+}
+
+// RecvMsg mocks base method.
+func (m_2 *MockAgent_ReadLogsServer) RecvMsg(m any) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "RecvMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecvMsg indicates an expected call of RecvMsg.
+func (mr *MockAgent_ReadLogsServerMockRecorder) RecvMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecvMsg", reflect.TypeOf((*MockAgent_ReadLogsServer)(nil).RecvMsg), m)
+}
+
+// Send mocks base method.
+func (m *MockAgent_ReadLogsServer) Send(arg0 *idl.ReadLogsReply) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockAgent_ReadLogsServerMockRecorder) Send(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockAgent_ReadLogsServer)(nil).Send), arg0)
+}
+
+// SendHeader mocks base method.
+func (m *MockAgent_ReadLogsServer) SendHeader(arg0 metadata.MD) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendHeader", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendHeader indicates an expected call of SendHeader.
+func (mr *MockAgent_ReadLogsServerMockRecorder) SendHeader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendHeader", reflect.TypeOf((*MockAgent_ReadLogsServer)(nil).SendHeader), arg0)
+}
+
+// SendMsg mocks base method.
+func (m_2 *MockAgent_ReadLogsServer) SendMsg(m any) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "SendMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMsg indicates an expected call of SendMsg.
+func (mr *MockAgent_ReadLogsServerMockRecorder) SendMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMsg", reflect.TypeOf((*MockAgent_ReadLogsServer)(nil).SendMsg), m)
+}
+
+// SetHeader mocks base method.
+func (m *MockAgent_ReadLogsServer) SetHeader(arg0 metadata.MD) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetHeader", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetHeader indicates an expected call of SetHeader.
+func (mr *MockAgent_ReadLogsServerMockRecorder) SetHeader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetHeader", reflect.TypeOf((*MockAgent_ReadLogsServer)(nil).SetHeader), arg0)
+}
+
+// SetTrailer mocks base method.
+func (m *MockAgent_ReadLogsServer) SetTrailer(arg0 metadata.MD) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetTrailer", arg0)
+}
+
+// SetTrailer indicates an expected call of SetTrailer.
+func (mr *MockAgent_ReadLogsServerMockRecorder) SetTrailer(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTrailer", reflect.TypeOf((*MockAgent_ReadLogsServer)(nil).SetTrailer), arg0)
+}
+
+// MockAgent_CollectDiagnosticsServer is a mock of Agent_CollectDiagnosticsServer interface.
+type MockAgent_CollectDiagnosticsServer struct {
+	ctrl     *gomock.Controller
+	recorder *MockAgent_CollectDiagnosticsServerMockRecorder
+}
+
+// MockAgent_CollectDiagnosticsServerMockRecorder is the mock recorder for MockAgent_CollectDiagnosticsServer.
+type MockAgent_CollectDiagnosticsServerMockRecorder struct {
+	mock *MockAgent_CollectDiagnosticsServer
+}
+
+// NewMockAgent_CollectDiagnosticsServer creates a new mock instance.
+func NewMockAgent_CollectDiagnosticsServer(ctrl *gomock.Controller) *MockAgent_CollectDiagnosticsServer {
+	mock := &MockAgent_CollectDiagnosticsServer{ctrl: ctrl}
+	mock.recorder = &MockAgent_CollectDiagnosticsServerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAgent_CollectDiagnosticsServer) EXPECT() *MockAgent_CollectDiagnosticsServerMockRecorder {
+	return m.recorder
+}
+
+// Context mocks base method.
+func (m *MockAgent_CollectDiagnosticsServer) Context() context.Context {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Context")
+	ret0, _ := ret[0].(context.Context)
+	return ret0
+}
+
+// Context indicates an expected call of Context.
+func (mr *MockAgent_CollectDiagnosticsServerMockRecorder) Context() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Context", reflect.TypeOf((*MockAgent_CollectDiagnosticsServer)(nil).Context))
+}
+
+// RecvMsg mocks base method.
+func (m_2 *MockAgent_CollectDiagnosticsServer) RecvMsg(m any) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "RecvMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecvMsg indicates an expected call of RecvMsg.
+func (mr *MockAgent_CollectDiagnosticsServerMockRecorder) RecvMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecvMsg", reflect.TypeOf((*MockAgent_CollectDiagnosticsServer)(nil).RecvMsg), m)
+}
+
+// Send mocks base method.
+func (m *MockAgent_CollectDiagnosticsServer) Send(arg0 *idl.CollectDiagnosticsReply) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockAgent_CollectDiagnosticsServerMockRecorder) Send(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockAgent_CollectDiagnosticsServer)(nil).Send), arg0)
+}
+
+// SendHeader mocks base method.
+func (m *MockAgent_CollectDiagnosticsServer) SendHeader(arg0 metadata.MD) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendHeader", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendHeader indicates an expected call of SendHeader.
+func (mr *MockAgent_CollectDiagnosticsServerMockRecorder) SendHeader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendHeader", reflect.TypeOf((*MockAgent_CollectDiagnosticsServer)(nil).SendHeader), arg0)
+}
+
+// SendMsg mocks base method.
+func (m_2 *MockAgent_CollectDiagnosticsServer) SendMsg(m any) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "SendMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMsg indicates an expected call of SendMsg.
+func (mr *MockAgent_CollectDiagnosticsServerMockRecorder) SendMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMsg", reflect.TypeOf((*MockAgent_CollectDiagnosticsServer)(nil).SendMsg), m)
+}
+
+// SetHeader mocks base method.
+func (m *MockAgent_CollectDiagnosticsServer) SetHeader(arg0 metadata.MD) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetHeader", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetHeader indicates an expected call of SetHeader.
+func (mr *MockAgent_CollectDiagnosticsServerMockRecorder) SetHeader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetHeader", reflect.TypeOf((*MockAgent_CollectDiagnosticsServer)(nil).SetHeader), arg0)
+}
+
+// SetTrailer mocks base method.
+func (m *MockAgent_CollectDiagnosticsServer) SetTrailer(arg0 metadata.MD) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetTrailer", arg0)
+}
+
+// SetTrailer indicates an expected call of SetTrailer.
+func (mr *MockAgent_CollectDiagnosticsServerMockRecorder) SetTrailer(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTrailer", reflect.TypeOf((*MockAgent_CollectDiagnosticsServer)(nil).SetTrailer), arg0)
+}
@@ -56,6 +56,86 @@ func (mr *MockHubClientMockRecorder) AddMirrors(arg0, arg1 interface{}, arg2 ...
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddMirrors", reflect.TypeOf((*MockHubClient)(nil).AddMirrors), varargs...)
 }
 
+// CheckDisk mocks base method.
+func (m *MockHubClient) CheckDisk(arg0 context.Context, arg1 *idl.CheckDiskRequest, arg2 ...grpc.CallOption) (*idl.CheckDiskReply, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CheckDisk", varargs...)
+	ret0, _ := ret[0].(*idl.CheckDiskReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckDisk indicates an expected call of CheckDisk.
+func (mr *MockHubClientMockRecorder) CheckDisk(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckDisk", reflect.TypeOf((*MockHubClient)(nil).CheckDisk), varargs...)
+}
+
+// CheckNetwork mocks base method.
+func (m *MockHubClient) CheckNetwork(arg0 context.Context, arg1 *idl.CheckNetworkRequest, arg2 ...grpc.CallOption) (*idl.CheckNetworkReply, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CheckNetwork", varargs...)
+	ret0, _ := ret[0].(*idl.CheckNetworkReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckNetwork indicates an expected call of CheckNetwork.
+func (mr *MockHubClientMockRecorder) CheckNetwork(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckNetwork", reflect.TypeOf((*MockHubClient)(nil).CheckNetwork), varargs...)
+}
+
+// CheckPgHba mocks base method.
+func (m *MockHubClient) CheckPgHba(arg0 context.Context, arg1 *idl.CheckPgHbaRequest, arg2 ...grpc.CallOption) (*idl.CheckPgHbaReply, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CheckPgHba", varargs...)
+	ret0, _ := ret[0].(*idl.CheckPgHbaReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckPgHba indicates an expected call of CheckPgHba.
+func (mr *MockHubClientMockRecorder) CheckPgHba(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckPgHba", reflect.TypeOf((*MockHubClient)(nil).CheckPgHba), varargs...)
+}
+
+// CollectLogs mocks base method.
+func (m *MockHubClient) CollectLogs(arg0 context.Context, arg1 *idl.CollectLogsRequest, arg2 ...grpc.CallOption) (idl.Hub_CollectLogsClient, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CollectLogs", varargs...)
+	ret0, _ := ret[0].(idl.Hub_CollectLogsClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CollectLogs indicates an expected call of CollectLogs.
+func (mr *MockHubClientMockRecorder) CollectLogs(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CollectLogs", reflect.TypeOf((*MockHubClient)(nil).CollectLogs), varargs...)
+}
+
 // GetAllHostNames mocks base method.
 func (m *MockHubClient) GetAllHostNames(arg0 context.Context, arg1 *idl.GetAllHostNamesRequest, arg2 ...grpc.CallOption) (*idl.GetAllHostNamesReply, error) {
 	m.ctrl.T.Helper()
@@ -76,6 +156,66 @@ func (mr *MockHubClientMockRecorder) GetAllHostNames(arg0, arg1 interface{}, arg
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllHostNames", reflect.TypeOf((*MockHubClient)(nil).GetAllHostNames), varargs...)
 }
 
+// GetHostsInfo mocks base method.
+func (m *MockHubClient) GetHostsInfo(arg0 context.Context, arg1 *idl.GetHostsInfoRequest, arg2 ...grpc.CallOption) (*idl.GetHostsInfoReply, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetHostsInfo", varargs...)
+	ret0, _ := ret[0].(*idl.GetHostsInfoReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHostsInfo indicates an expected call of GetHostsInfo.
+func (mr *MockHubClientMockRecorder) GetHostsInfo(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHostsInfo", reflect.TypeOf((*MockHubClient)(nil).GetHostsInfo), varargs...)
+}
+
+// GetLogs mocks base method.
+func (m *MockHubClient) GetLogs(arg0 context.Context, arg1 *idl.GetLogsRequest, arg2 ...grpc.CallOption) (idl.Hub_GetLogsClient, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetLogs", varargs...)
+	ret0, _ := ret[0].(idl.Hub_GetLogsClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLogs indicates an expected call of GetLogs.
+func (mr *MockHubClientMockRecorder) GetLogs(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLogs", reflect.TypeOf((*MockHubClient)(nil).GetLogs), varargs...)
+}
+
+// ListPgHba mocks base method.
+func (m *MockHubClient) ListPgHba(arg0 context.Context, arg1 *idl.ListPgHbaRequest, arg2 ...grpc.CallOption) (*idl.ListPgHbaReply, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListPgHba", varargs...)
+	ret0, _ := ret[0].(*idl.ListPgHbaReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPgHba indicates an expected call of ListPgHba.
+func (mr *MockHubClientMockRecorder) ListPgHba(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPgHba", reflect.TypeOf((*MockHubClient)(nil).ListPgHba), varargs...)
+}
+
 // MakeCluster mocks base method.
 func (m *MockHubClient) MakeCluster(arg0 context.Context, arg1 *idl.MakeClusterRequest, arg2 ...grpc.CallOption) (idl.Hub_MakeClusterClient, error) {
 	m.ctrl.T.Helper()
@@ -96,6 +236,46 @@ func (mr *MockHubClientMockRecorder) MakeCluster(arg0, arg1 interface{}, arg2 ..
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MakeCluster", reflect.TypeOf((*MockHubClient)(nil).MakeCluster), varargs...)
 }
 
+// ModifyPgHba mocks base method.
+func (m *MockHubClient) ModifyPgHba(arg0 context.Context, arg1 *idl.ModifyPgHbaRequest, arg2 ...grpc.CallOption) (*idl.ModifyPgHbaReply, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ModifyPgHba", varargs...)
+	ret0, _ := ret[0].(*idl.ModifyPgHbaReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ModifyPgHba indicates an expected call of ModifyPgHba.
+func (mr *MockHubClientMockRecorder) ModifyPgHba(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ModifyPgHba", reflect.TypeOf((*MockHubClient)(nil).ModifyPgHba), varargs...)
+}
+
+// ReloadCertificates mocks base method.
+func (m *MockHubClient) ReloadCertificates(arg0 context.Context, arg1 *idl.ReloadCertificatesRequest, arg2 ...grpc.CallOption) (*idl.ReloadCertificatesReply, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ReloadCertificates", varargs...)
+	ret0, _ := ret[0].(*idl.ReloadCertificatesReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReloadCertificates indicates an expected call of ReloadCertificates.
+func (mr *MockHubClientMockRecorder) ReloadCertificates(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReloadCertificates", reflect.TypeOf((*MockHubClient)(nil).ReloadCertificates), varargs...)
+}
+
 // StartAgents mocks base method.
 func (m *MockHubClient) StartAgents(arg0 context.Context, arg1 *idl.StartAgentsRequest, arg2 ...grpc.CallOption) (*idl.StartAgentsReply, error) {
 	m.ctrl.T.Helper()
@@ -213,6 +393,65 @@ func (mr *MockHubServerMockRecorder) AddMirrors(arg0, arg1 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddMirrors", reflect.TypeOf((*MockHubServer)(nil).AddMirrors), arg0, arg1)
 }
 
+// CheckDisk mocks base method.
+func (m *MockHubServer) CheckDisk(arg0 context.Context, arg1 *idl.CheckDiskRequest) (*idl.CheckDiskReply, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckDisk", arg0, arg1)
+	ret0, _ := ret[0].(*idl.CheckDiskReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckDisk indicates an expected call of CheckDisk.
+func (mr *MockHubServerMockRecorder) CheckDisk(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckDisk", reflect.TypeOf((*MockHubServer)(nil).CheckDisk), arg0, arg1)
+}
+
+// CheckNetwork mocks base method.
+func (m *MockHubServer) CheckNetwork(arg0 context.Context, arg1 *idl.CheckNetworkRequest) (*idl.CheckNetworkReply, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckNetwork", arg0, arg1)
+	ret0, _ := ret[0].(*idl.CheckNetworkReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckNetwork indicates an expected call of CheckNetwork.
+func (mr *MockHubServerMockRecorder) CheckNetwork(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckNetwork", reflect.TypeOf((*MockHubServer)(nil).CheckNetwork), arg0, arg1)
+}
+
+// CheckPgHba mocks base method.
+func (m *MockHubServer) CheckPgHba(arg0 context.Context, arg1 *idl.CheckPgHbaRequest) (*idl.CheckPgHbaReply, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckPgHba", arg0, arg1)
+	ret0, _ := ret[0].(*idl.CheckPgHbaReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckPgHba indicates an expected call of CheckPgHba.
+func (mr *MockHubServerMockRecorder) CheckPgHba(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckPgHba", reflect.TypeOf((*MockHubServer)(nil).CheckPgHba), arg0, arg1)
+}
+
+// CollectLogs mocks base method.
+func (m *MockHubServer) CollectLogs(arg0 *idl.CollectLogsRequest, arg1 idl.Hub_CollectLogsServer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CollectLogs", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CollectLogs indicates an expected call of CollectLogs.
+func (mr *MockHubServerMockRecorder) CollectLogs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CollectLogs", reflect.TypeOf((*MockHubServer)(nil).CollectLogs), arg0, arg1)
+}
+
 // GetAllHostNames mocks base method.
 func (m *MockHubServer) GetAllHostNames(arg0 context.Context, arg1 *idl.GetAllHostNamesRequest) (*idl.GetAllHostNamesReply, error) {
 	m.ctrl.T.Helper()
@@ -228,6 +467,50 @@ func (mr *MockHubServerMockRecorder) GetAllHostNames(arg0, arg1 interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllHostNames", reflect.TypeOf((*MockHubServer)(nil).GetAllHostNames), arg0, arg1)
 }
 
+// GetHostsInfo mocks base method.
+func (m *MockHubServer) GetHostsInfo(arg0 context.Context, arg1 *idl.GetHostsInfoRequest) (*idl.GetHostsInfoReply, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHostsInfo", arg0, arg1)
+	ret0, _ := ret[0].(*idl.GetHostsInfoReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHostsInfo indicates an expected call of GetHostsInfo.
+func (mr *MockHubServerMockRecorder) GetHostsInfo(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHostsInfo", reflect.TypeOf((*MockHubServer)(nil).GetHostsInfo), arg0, arg1)
+}
+
+// GetLogs mocks base method.
+func (m *MockHubServer) GetLogs(arg0 *idl.GetLogsRequest, arg1 idl.Hub_GetLogsServer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLogs", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GetLogs indicates an expected call of GetLogs.
+func (mr *MockHubServerMockRecorder) GetLogs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLogs", reflect.TypeOf((*MockHubServer)(nil).GetLogs), arg0, arg1)
+}
+
+// ListPgHba mocks base method.
+func (m *MockHubServer) ListPgHba(arg0 context.Context, arg1 *idl.ListPgHbaRequest) (*idl.ListPgHbaReply, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPgHba", arg0, arg1)
+	ret0, _ := ret[0].(*idl.ListPgHbaReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPgHba indicates an expected call of ListPgHba.
+func (mr *MockHubServerMockRecorder) ListPgHba(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPgHba", reflect.TypeOf((*MockHubServer)(nil).ListPgHba), arg0, arg1)
+}
+
 // MakeCluster mocks base method.
 func (m *MockHubServer) MakeCluster(arg0 *idl.MakeClusterRequest, arg1 idl.Hub_MakeClusterServer) error {
 	m.ctrl.T.Helper()
@@ -242,6 +525,36 @@ func (mr *MockHubServerMockRecorder) MakeCluster(arg0, arg1 interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MakeCluster", reflect.TypeOf((*MockHubServer)(nil).MakeCluster), arg0, arg1)
 }
 
+// ModifyPgHba mocks base method.
+func (m *MockHubServer) ModifyPgHba(arg0 context.Context, arg1 *idl.ModifyPgHbaRequest) (*idl.ModifyPgHbaReply, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ModifyPgHba", arg0, arg1)
+	ret0, _ := ret[0].(*idl.ModifyPgHbaReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ModifyPgHba indicates an expected call of ModifyPgHba.
+func (mr *MockHubServerMockRecorder) ModifyPgHba(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ModifyPgHba", reflect.TypeOf((*MockHubServer)(nil).ModifyPgHba), arg0, arg1)
+}
+
+// ReloadCertificates mocks base method.
+func (m *MockHubServer) ReloadCertificates(arg0 context.Context, arg1 *idl.ReloadCertificatesRequest) (*idl.ReloadCertificatesReply, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReloadCertificates", arg0, arg1)
+	ret0, _ := ret[0].(*idl.ReloadCertificatesReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReloadCertificates indicates an expected call of ReloadCertificates.
+func (mr *MockHubServerMockRecorder) ReloadCertificates(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReloadCertificates", reflect.TypeOf((*MockHubServer)(nil).ReloadCertificates), arg0, arg1)
+}
+
 // StartAgents mocks base method.
 func (m *MockHubServer) StartAgents(arg0 context.Context, arg1 *idl.StartAgentsRequest) (*idl.StartAgentsReply, error) {
 	m.ctrl.T.Helper()
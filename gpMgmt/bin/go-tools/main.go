@@ -14,6 +14,7 @@ func main() {
 	root.SilenceErrors = true
 
 	err := root.Execute()
+	cli.FinishCommand(err)
 	if err != nil {
 		// gplog is initialised in the PreRun function in cobra and sometimes when the
 		// error is due to the input flags, the cobra pkg would not run the PreRun function.
@@ -54,7 +54,7 @@ func TestStartFailWithoutConfig(t *testing.T) {
 		testutils.InitService(*hostfile, testutils.CertificateParams)
 		_ = testutils.CpCfgWithoutCertificates(configCopy)
 
-		expectedOut := "error while loading server certificate"
+		expectedOut := "could not load the CA certificate"
 
 		result, err := testutils.RunStart("hub", "--config-file", configCopy)
 		if err == nil {
@@ -73,7 +73,7 @@ func TestStartFailWithoutConfig(t *testing.T) {
 		testutils.InitService(*hostfile, testutils.CertificateParams)
 		_ = testutils.CpCfgWithoutCertificates(configCopy)
 
-		expectedOut := "error while loading server certificate"
+		expectedOut := "could not load the CA certificate"
 
 		// start agents
 		result, err := testutils.RunStart("agents", "--config-file", configCopy)
@@ -93,7 +93,7 @@ func TestStartFailWithoutConfig(t *testing.T) {
 		testutils.InitService(*hostfile, testutils.CertificateParams)
 		_ = testutils.CpCfgWithoutCertificates(configCopy)
 
-		expectedOut := "error while loading server certificate"
+		expectedOut := "could not load the CA certificate"
 		// start agents
 		result, err := testutils.RunStart("services", "--config-file", configCopy)
 		if err == nil {
@@ -76,7 +76,7 @@ func TestStatusFailures(t *testing.T) {
 		cliParams := []string{
 			"agents", "--config-file", configCopy,
 		}
-		expectedOut := "error while loading server certificate"
+		expectedOut := "could not load the CA certificate"
 
 		result, err := testutils.RunStatus(cliParams...)
 		if err == nil {
@@ -100,7 +100,7 @@ func TestStatusFailures(t *testing.T) {
 		cliParams := []string{
 			"services", "--config-file", configCopy,
 		}
-		expectedOut := "error while loading server certificate"
+		expectedOut := "could not load the CA certificate"
 
 		result, err := testutils.RunStatus(cliParams...)
 		if err == nil {
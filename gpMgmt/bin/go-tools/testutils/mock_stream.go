@@ -1,6 +1,8 @@
 package testutils
 
 import (
+	"context"
+
 	"github.com/greenplum-db/gpdb/gp/hub"
 	"github.com/greenplum-db/gpdb/gp/idl"
 	"google.golang.org/grpc"
@@ -41,3 +43,7 @@ func (m *MockStream) Send(reply *idl.HubReply) error {
 func (m *MockStream) GetBuffer() []*idl.HubReply {
 	return m.buf
 }
+
+func (m *MockStream) Context() context.Context {
+	return context.Background()
+}
@@ -33,6 +33,7 @@ type MockPlatform struct {
 	StartCmd             *exec.Cmd
 	ConfigFileData       []byte
 	OS                   string
+	DisableLingeringCmd  string
 }
 
 func InitializeTestEnv() *hub.Config {
@@ -88,6 +89,12 @@ func (p *MockPlatform) DisplayServiceStatus(outfile io.Writer, serviceName strin
 func (p *MockPlatform) EnableUserLingering(hostnames []string, gpHome string, serviceUser string) error {
 	return nil
 }
+func (p *MockPlatform) GetRemoveServiceCommand(serviceDir string, serviceName string, process string) string {
+	return fmt.Sprintf("remove %s/%s_%s", serviceDir, serviceName, process)
+}
+func (p *MockPlatform) GetDisableLingeringCommand(serviceUser string) string {
+	return p.DisableLingeringCmd
+}
 func (p *MockPlatform) ReadFile(configFilePath string) (config *hub.Config, err error) {
 	return nil, err
 }
@@ -100,17 +107,22 @@ func (p *MockPlatform) GetPlatformOS() string {
 
 type MockCredentials struct {
 	TlsConnection credentials.TransportCredentials
+	Fingerprint   string
 	Err           error
 }
 
-func (s *MockCredentials) LoadServerCredentials() (credentials.TransportCredentials, error) {
+func (s *MockCredentials) LoadServerCredentials(role string) (credentials.TransportCredentials, error) {
 	return s.TlsConnection, s.Err
 }
 
-func (s *MockCredentials) LoadClientCredentials() (credentials.TransportCredentials, error) {
+func (s *MockCredentials) LoadClientCredentials(role string) (credentials.TransportCredentials, error) {
 	return s.TlsConnection, s.Err
 }
 
+func (s *MockCredentials) ClientFingerprint(role string) (string, error) {
+	return s.Fingerprint, nil
+}
+
 func (s *MockCredentials) SetCredsError(errMsg string) {
 	s.Err = errors.New(errMsg)
 }
@@ -118,6 +130,18 @@ func (s *MockCredentials) ResetCredsError() {
 	s.Err = nil
 }
 
+// MockReloadableCredentials wraps transport credentials to record reloads
+type MockReloadableCredentials struct {
+	credentials.TransportCredentials
+	Reloaded int
+	Err      error
+}
+
+func (c *MockReloadableCredentials) Reload() error {
+	c.Reloaded++
+	return c.Err
+}
+
 func AssertLogMessage(t *testing.T, buffer *gbytes.Buffer, message string) {
 	t.Helper()
 
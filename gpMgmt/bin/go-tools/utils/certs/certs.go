@@ -0,0 +1,283 @@
+package certs
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"os"
+	"time"
+
+	"github.com/greenplum-db/gpdb/gp/utils"
+)
+
+// File names used for the generated certificates, matching the layout created
+// by generate_test_tls_certificates.sh
+const (
+	CACertFile     = "ca-cert.pem"
+	CAKeyFile      = "ca-key.pem"
+	ServerCertFile = "server-cert.pem"
+	ServerKeyFile  = "server-key.pem"
+
+	Organization = "Greenplum"
+)
+
+// ClientCertificateFiles returns the file names of the client certificate and
+// private key of a role
+func ClientCertificateFiles(role string) (string, string) {
+	return role + "-cert.pem", role + "-key.pem"
+}
+
+// ClientCommonName returns the common name of the client certificate of a role,
+// as used in the list of allowed clients
+func ClientCommonName(role string) string {
+	return "gp-" + role
+}
+
+// KeyPair holds a PEM encoded certificate and its private key
+type KeyPair struct {
+	CertPEM  []byte
+	KeyPEM   []byte
+	NotAfter time.Time
+}
+
+// CertificateAuthority issues the server and client certificates used by the
+// gp services. The private key never leaves the coordinator host.
+type CertificateAuthority struct {
+	Certificate *x509.Certificate
+	KeyPair
+	key crypto.Signer
+}
+
+func NewCertificateAuthority(commonName string, validity time.Duration) (*CertificateAuthority, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("generating CA private key: %w", err)
+	}
+
+	template, err := newTemplate(commonName, validity)
+	if err != nil {
+		return nil, err
+	}
+	template.IsCA = true
+	template.BasicConstraintsValid = true
+	template.KeyUsage = x509.KeyUsageCertSign | x509.KeyUsageCRLSign | x509.KeyUsageDigitalSignature
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return nil, fmt.Errorf("creating CA certificate: %w", err)
+	}
+
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, fmt.Errorf("parsing CA certificate: %w", err)
+	}
+
+	keyPEM, err := encodeKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CertificateAuthority{
+		Certificate: cert,
+		KeyPair: KeyPair{
+			CertPEM:  pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+			KeyPEM:   keyPEM,
+			NotAfter: cert.NotAfter,
+		},
+		key: key,
+	}, nil
+}
+
+// LoadCertificateAuthority loads an existing CA, e.g. to issue new certificates
+// during a rotation that are trusted by the hosts which have not been updated yet
+func LoadCertificateAuthority(certFile string, keyFile string) (*CertificateAuthority, error) {
+	certPEM, err := os.ReadFile(certFile)
+	if err != nil {
+		return nil, fmt.Errorf("reading CA certificate: %w", err)
+	}
+
+	cert, err := ParseCertificate(certPEM)
+	if err != nil {
+		return nil, fmt.Errorf("parsing CA certificate %s: %w", certFile, err)
+	}
+	if !cert.IsCA {
+		return nil, fmt.Errorf("%s is not a CA certificate", certFile)
+	}
+
+	keyPEM, err := os.ReadFile(keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("reading CA private key: %w", err)
+	}
+
+	// Checks that the private key belongs to the certificate
+	tlsCert, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return nil, fmt.Errorf("loading CA private key %s: %w", keyFile, err)
+	}
+
+	key, ok := tlsCert.PrivateKey.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("unsupported CA private key type in %s", keyFile)
+	}
+
+	return &CertificateAuthority{
+		Certificate: cert,
+		KeyPair: KeyPair{
+			CertPEM:  certPEM,
+			KeyPEM:   keyPEM,
+			NotAfter: cert.NotAfter,
+		},
+		key: key,
+	}, nil
+}
+
+// IssueServerCertificate issues a certificate valid for the given host as well
+// as for local connections, since the CLI connects to the hub over localhost.
+func (ca *CertificateAuthority) IssueServerCertificate(hostname string, validity time.Duration) (*KeyPair, error) {
+	template, err := newTemplate(hostname, validity)
+	if err != nil {
+		return nil, err
+	}
+	template.KeyUsage = x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment
+	template.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth}
+	template.DNSNames, template.IPAddresses = SubjectAltNames(hostname)
+
+	return ca.issue(template)
+}
+
+// IssueClientCertificate issues a certificate that identifies commonName on the
+// given host when connecting to the gp services
+func (ca *CertificateAuthority) IssueClientCertificate(commonName string, hostname string, validity time.Duration) (*KeyPair, error) {
+	template, err := newTemplate(commonName, validity)
+	if err != nil {
+		return nil, err
+	}
+	template.KeyUsage = x509.KeyUsageDigitalSignature
+	template.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}
+	if ip := net.ParseIP(hostname); ip != nil {
+		template.IPAddresses = []net.IP{ip}
+	} else {
+		template.DNSNames = []string{hostname}
+	}
+
+	return ca.issue(template)
+}
+
+func (ca *CertificateAuthority) issue(template *x509.Certificate) (*KeyPair, error) {
+	if template.NotAfter.After(ca.Certificate.NotAfter) {
+		template.NotAfter = ca.Certificate.NotAfter
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("generating private key for %s: %w", template.Subject.CommonName, err)
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.Certificate, &key.PublicKey, ca.key)
+	if err != nil {
+		return nil, fmt.Errorf("creating certificate for %s: %w", template.Subject.CommonName, err)
+	}
+
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, fmt.Errorf("parsing certificate for %s: %w", template.Subject.CommonName, err)
+	}
+
+	keyPEM, err := encodeKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return &KeyPair{
+		CertPEM:  pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		KeyPEM:   keyPEM,
+		NotAfter: cert.NotAfter,
+	}, nil
+}
+
+// Write writes the certificate and the private key. The private key is only
+// readable by the owner, even when it replaces an existing file.
+func (kp *KeyPair) Write(certFile, keyFile string) error {
+	err := utils.WriteFileAtomic(certFile, kp.CertPEM, 0644)
+	if err != nil {
+		return fmt.Errorf("writing certificate %s: %w", certFile, err)
+	}
+
+	err = utils.WriteFileAtomic(keyFile, kp.KeyPEM, 0600)
+	if err != nil {
+		return fmt.Errorf("writing private key %s: %w", keyFile, err)
+	}
+
+	err = os.Chmod(keyFile, 0600)
+	if err != nil {
+		return fmt.Errorf("setting permissions of private key %s: %w", keyFile, err)
+	}
+
+	return nil
+}
+
+// SubjectAltNames returns the DNS names and IP addresses a server certificate
+// for the host is valid for
+func SubjectAltNames(hostname string) ([]string, []net.IP) {
+	dnsNames := []string{"localhost"}
+	ips := []net.IP{net.IPv4(127, 0, 0, 1), net.IPv6loopback}
+
+	if ip := net.ParseIP(hostname); ip != nil {
+		ips = append(ips, ip)
+	} else if hostname != "localhost" {
+		dnsNames = append([]string{hostname}, dnsNames...)
+	}
+
+	return dnsNames, ips
+}
+
+// ParseCertificate returns the first certificate in the PEM encoded data
+func ParseCertificate(certPEM []byte) (*x509.Certificate, error) {
+	block, _ := pem.Decode(certPEM)
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, errors.New("no PEM encoded certificate found")
+	}
+
+	return x509.ParseCertificate(block.Bytes)
+}
+
+func newTemplate(commonName string, validity time.Duration) (*x509.Certificate, error) {
+	if validity <= 0 {
+		return nil, fmt.Errorf("invalid certificate validity %s", validity)
+	}
+
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, fmt.Errorf("generating certificate serial number: %w", err)
+	}
+
+	// Allow for some clock skew between the hosts
+	now := time.Now()
+	return &x509.Certificate{
+		SerialNumber: serial,
+		Subject: pkix.Name{
+			CommonName:   commonName,
+			Organization: []string{Organization},
+		},
+		NotBefore: now.Add(-time.Hour),
+		NotAfter:  now.Add(validity),
+	}, nil
+}
+
+func encodeKey(key *ecdsa.PrivateKey) ([]byte, error) {
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, fmt.Errorf("encoding private key: %w", err)
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), nil
+}
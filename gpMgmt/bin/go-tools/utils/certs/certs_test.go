@@ -0,0 +1,259 @@
+package certs_test
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/greenplum-db/gpdb/gp/utils/certs"
+)
+
+func TestCertificateAuthority(t *testing.T) {
+	ca, err := certs.NewCertificateAuthority("test CA", 48*time.Hour)
+	if err != nil {
+		t.Fatalf("unexpected error: %#v", err)
+	}
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM(ca.CertPEM) {
+		t.Fatalf("could not parse CA certificate")
+	}
+
+	t.Run("creates a CA certificate", func(t *testing.T) {
+		if !ca.Certificate.IsCA {
+			t.Fatalf("expected a CA certificate")
+		}
+		if ca.Certificate.Subject.CommonName != "test CA" {
+			t.Fatalf("got common name %s, want test CA", ca.Certificate.Subject.CommonName)
+		}
+		if _, err := tls.X509KeyPair(ca.CertPEM, ca.KeyPEM); err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+	})
+
+	t.Run("issues server certificates valid for the host and localhost", func(t *testing.T) {
+		kp, err := ca.IssueServerCertificate("sdw1", 24*time.Hour)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		cert, err := certs.ParseCertificate(kp.CertPEM)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		for _, name := range []string{"sdw1", "localhost", "127.0.0.1"} {
+			_, err = cert.Verify(x509.VerifyOptions{
+				DNSName:   name,
+				Roots:     roots,
+				KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+			})
+			if err != nil {
+				t.Fatalf("unexpected error verifying %s: %v", name, err)
+			}
+		}
+		if _, err := tls.X509KeyPair(kp.CertPEM, kp.KeyPEM); err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		if !kp.NotAfter.Equal(cert.NotAfter) {
+			t.Fatalf("got expiry %s, want %s", kp.NotAfter, cert.NotAfter)
+		}
+	})
+
+	t.Run("issues client certificates", func(t *testing.T) {
+		kp, err := ca.IssueClientCertificate("gp-hub", "cdw", 24*time.Hour)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		cert, err := certs.ParseCertificate(kp.CertPEM)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		_, err = cert.Verify(x509.VerifyOptions{
+			Roots:     roots,
+			KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		if cert.Subject.CommonName != "gp-hub" {
+			t.Fatalf("got common name %s, want gp-hub", cert.Subject.CommonName)
+		}
+		if !reflect.DeepEqual(cert.DNSNames, []string{"cdw"}) {
+			t.Fatalf("got DNS names %v, want [cdw]", cert.DNSNames)
+		}
+	})
+
+	t.Run("does not issue certificates outliving the CA", func(t *testing.T) {
+		kp, err := ca.IssueServerCertificate("sdw1", 100*24*time.Hour)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		if !kp.NotAfter.Equal(ca.NotAfter) {
+			t.Fatalf("got expiry %s, want %s", kp.NotAfter, ca.NotAfter)
+		}
+	})
+
+	t.Run("errors out for an invalid validity", func(t *testing.T) {
+		_, err := ca.IssueClientCertificate("gp-hub", "cdw", 0)
+		expected := "invalid certificate validity 0s"
+		if err == nil || err.Error() != expected {
+			t.Fatalf("got %v, want %s", err, expected)
+		}
+	})
+}
+
+func TestKeyPairWrite(t *testing.T) {
+	kp := &certs.KeyPair{CertPEM: []byte("cert"), KeyPEM: []byte("key")}
+
+	t.Run("writes the private key readable only by the owner", func(t *testing.T) {
+		dir := t.TempDir()
+		certFile := filepath.Join(dir, certs.ServerCertFile)
+		keyFile := filepath.Join(dir, certs.ServerKeyFile)
+
+		// An existing key file must not keep its permissions
+		err := os.WriteFile(keyFile, []byte("old"), 0644)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		err = kp.Write(certFile, keyFile)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		for file, perm := range map[string]os.FileMode{certFile: 0644, keyFile: 0600} {
+			info, err := os.Stat(file)
+			if err != nil {
+				t.Fatalf("unexpected error: %#v", err)
+			}
+			if info.Mode().Perm() != perm {
+				t.Fatalf("got permissions %o for %s, want %o", info.Mode().Perm(), file, perm)
+			}
+		}
+
+		content, err := os.ReadFile(keyFile)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		if string(content) != "key" {
+			t.Fatalf("got %q, want %q", content, "key")
+		}
+	})
+
+	t.Run("errors out when the files cannot be written", func(t *testing.T) {
+		err := kp.Write("/does/not/exist/cert.pem", "/does/not/exist/key.pem")
+		if err == nil {
+			t.Fatalf("expected an error")
+		}
+	})
+}
+
+func TestSubjectAltNames(t *testing.T) {
+	cases := []struct {
+		hostname string
+		dnsNames []string
+		ips      []net.IP
+	}{
+		{"sdw1", []string{"sdw1", "localhost"}, []net.IP{net.IPv4(127, 0, 0, 1), net.IPv6loopback}},
+		{"localhost", []string{"localhost"}, []net.IP{net.IPv4(127, 0, 0, 1), net.IPv6loopback}},
+		{"10.0.0.1", []string{"localhost"}, []net.IP{net.IPv4(127, 0, 0, 1), net.IPv6loopback, net.ParseIP("10.0.0.1")}},
+	}
+
+	for _, tc := range cases {
+		dnsNames, ips := certs.SubjectAltNames(tc.hostname)
+		if !reflect.DeepEqual(dnsNames, tc.dnsNames) {
+			t.Errorf("got DNS names %v for %s, want %v", dnsNames, tc.hostname, tc.dnsNames)
+		}
+		if !reflect.DeepEqual(ips, tc.ips) {
+			t.Errorf("got IP addresses %v for %s, want %v", ips, tc.hostname, tc.ips)
+		}
+	}
+}
+
+func TestParseCertificate(t *testing.T) {
+	_, err := certs.ParseCertificate([]byte("not a certificate"))
+	expected := "no PEM encoded certificate found"
+	if err == nil || err.Error() != expected {
+		t.Fatalf("got %v, want %s", err, expected)
+	}
+}
+
+func TestLoadCertificateAuthority(t *testing.T) {
+	ca, err := certs.NewCertificateAuthority("test CA", 48*time.Hour)
+	if err != nil {
+		t.Fatalf("unexpected error: %#v", err)
+	}
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, certs.CACertFile)
+	keyFile := filepath.Join(dir, certs.CAKeyFile)
+	err = ca.Write(certFile, keyFile)
+	if err != nil {
+		t.Fatalf("unexpected error: %#v", err)
+	}
+
+	t.Run("loads a CA that issues certificates trusted by the original one", func(t *testing.T) {
+		loaded, err := certs.LoadCertificateAuthority(certFile, keyFile)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		kp, err := loaded.IssueServerCertificate("sdw1", 24*time.Hour)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		cert, err := certs.ParseCertificate(kp.CertPEM)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		roots := x509.NewCertPool()
+		roots.AddCert(ca.Certificate)
+		_, err = cert.Verify(x509.VerifyOptions{DNSName: "sdw1", Roots: roots})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+	})
+
+	t.Run("errors out when the certificate is not a CA", func(t *testing.T) {
+		kp, err := ca.IssueServerCertificate("sdw1", 24*time.Hour)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		serverCertFile := filepath.Join(dir, certs.ServerCertFile)
+		serverKeyFile := filepath.Join(dir, certs.ServerKeyFile)
+		err = kp.Write(serverCertFile, serverKeyFile)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		_, err = certs.LoadCertificateAuthority(serverCertFile, serverKeyFile)
+		expected := serverCertFile + " is not a CA certificate"
+		if err == nil || err.Error() != expected {
+			t.Fatalf("got %v, want %s", err, expected)
+		}
+	})
+
+	t.Run("errors out when the private key does not match", func(t *testing.T) {
+		other, err := certs.NewCertificateAuthority("other CA", 48*time.Hour)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		otherKeyFile := filepath.Join(dir, "other-key.pem")
+		err = os.WriteFile(otherKeyFile, other.KeyPEM, 0600)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		_, err = certs.LoadCertificateAuthority(certFile, otherKeyFile)
+		expected := "loading CA private key " + otherKeyFile
+		if err == nil || !strings.HasPrefix(err.Error(), expected) {
+			t.Fatalf("got %v, want %s", err, expected)
+		}
+	})
+}
@@ -2,6 +2,7 @@ package utils
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"io"
 	"os"
@@ -9,8 +10,12 @@ import (
 	"path"
 	"path/filepath"
 	"reflect"
+	"time"
 
 	"github.com/greenplum-db/gp-common-go-libs/gplog"
+	"github.com/greenplum-db/gpdb/gp/utils/metrics"
+	"github.com/greenplum-db/gpdb/gp/utils/tracing"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 type CommandBuilder interface {
@@ -28,7 +33,9 @@ func NewGpSourcedCommand(cmdBuilder CommandBuilder, gpHome string) *exec.Cmd {
 	return System.ExecCommand("bash", "-c", fmt.Sprintf("source %s && %s", gpSourceFilePath, cmd.String()))
 }
 
-func runCommand(cmd *exec.Cmd, filename ...string) (*bytes.Buffer, error) {
+// runCommand runs the command in a span of the trace in ctx, recording its
+// duration under the given name
+func runCommand(ctx context.Context, name string, cmd *exec.Cmd, filename ...string) (*bytes.Buffer, error) {
 	var outfile *os.File
 	var err error
 
@@ -55,8 +62,12 @@ func runCommand(cmd *exec.Cmd, filename ...string) (*bytes.Buffer, error) {
 		cmd.Stderr = stderr
 	}
 
-	gplog.Verbose("Executing command: %s", cmd.String())
+	ctx, span := tracing.StartSpan(ctx, "exec "+name, attribute.String("gp.command", cmd.String()))
+	tracing.Verbose(ctx, "Executing command: %s", cmd.String())
+	start := time.Now()
 	err = cmd.Run()
+	metrics.ObserveCommand(name, start, err)
+	tracing.EndSpan(span, err)
 
 	if err != nil {
 		return stderr, err
@@ -67,18 +78,31 @@ func runCommand(cmd *exec.Cmd, filename ...string) (*bytes.Buffer, error) {
 
 // RunGpCommandAndRedirectOutput executes the command and redirects the stdout and stderr to the given filename
 func RunGpCommandAndRedirectOutput(cmdBuilder CommandBuilder, gpHome string, filename string) (*bytes.Buffer, error) {
-	return runCommand(NewGpCommand(cmdBuilder, gpHome), filename)
+	return RunGpCommandAndRedirectOutputContext(context.Background(), cmdBuilder, gpHome, filename)
+}
+
+// RunGpCommandAndRedirectOutputContext is RunGpCommandAndRedirectOutput as part of the trace in ctx
+func RunGpCommandAndRedirectOutputContext(ctx context.Context, cmdBuilder CommandBuilder, gpHome string, filename string) (*bytes.Buffer, error) {
+	cmd := NewGpCommand(cmdBuilder, gpHome)
+	return runCommand(ctx, filepath.Base(cmd.Path), cmd, filename)
 }
 
 // RunGpCommand executes the given command
 func RunGpCommand(cmdBuilder CommandBuilder, gpHome string) (*bytes.Buffer, error) {
-	out, err := runCommand(NewGpCommand(cmdBuilder, gpHome))
+	return RunGpCommandContext(context.Background(), cmdBuilder, gpHome)
+}
+
+// RunGpCommandContext is RunGpCommand as part of the trace in ctx
+func RunGpCommandContext(ctx context.Context, cmdBuilder CommandBuilder, gpHome string) (*bytes.Buffer, error) {
+	cmd := NewGpCommand(cmdBuilder, gpHome)
+	out, err := runCommand(ctx, filepath.Base(cmd.Path), cmd)
 	return out, err
 }
 
 // RunGpSourcedCommand sources the greenplum_path.sh before executing the given command
 func RunGpSourcedCommand(cmdBuilder CommandBuilder, gpHome string) (*bytes.Buffer, error) {
-	out, err := runCommand(NewGpSourcedCommand(cmdBuilder, gpHome))
+	name := filepath.Base(cmdBuilder.BuildExecCommand(gpHome).Path)
+	out, err := runCommand(context.Background(), name, NewGpSourcedCommand(cmdBuilder, gpHome))
 	return out, err
 }
 
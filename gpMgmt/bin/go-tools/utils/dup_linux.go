@@ -0,0 +1,8 @@
+package utils
+
+import "golang.org/x/sys/unix"
+
+// dupFd makes newfd refer to the same file as oldfd
+func dupFd(oldfd int, newfd int) error {
+	return unix.Dup3(oldfd, newfd, 0)
+}
@@ -0,0 +1,10 @@
+//go:build !linux
+
+package utils
+
+import "golang.org/x/sys/unix"
+
+// dupFd makes newfd refer to the same file as oldfd
+func dupFd(oldfd int, newfd int) error {
+	return unix.Dup2(oldfd, newfd)
+}
@@ -2,6 +2,7 @@ package utils
 
 import (
 	"fmt"
+	"strings"
 
 	"google.golang.org/grpc/status"
 
@@ -16,12 +17,45 @@ func FormatGrpcError(err error) error {
 	grpcErr, ok := status.FromError(err)
 	if ok {
 		errorDescription := grpcErr.Message()
+		if hint, ok := tlsErrorHint(errorDescription); ok {
+			return fmt.Errorf("TLS handshake failed, %s: %s", hint, errorDescription)
+		}
+
 		return fmt.Errorf(errorDescription)
 	}
 
+	if hint, ok := tlsErrorHint(err.Error()); ok {
+		return fmt.Errorf("TLS handshake failed, %s: %w", hint, err)
+	}
+
 	return err
 }
 
+// gRPC reports TLS failures as plain transport errors, so map the underlying
+// cause to the certificate the user needs to look at
+var tlsErrorHints = []struct {
+	cause string
+	hint  string
+}{
+	{"certificate signed by unknown authority", "the peer certificate is not signed by the configured CA"},
+	{"certificate is valid for", "the server certificate is not valid for the host name"},
+	{"certificate is not valid for any names", "the server certificate is not valid for the host name"},
+	{"certificate has expired or is not yet valid", "the certificate has expired or is not yet valid"},
+	{"tls: certificate required", "the server did not accept the client certificate, check that it is signed by the configured CA"},
+	{"tls: bad certificate", "the server rejected the client certificate, check that it is signed by the configured CA and allowed to connect"},
+	{"authentication handshake failed", "check the certificates configured in gp.conf"},
+}
+
+func tlsErrorHint(message string) (string, bool) {
+	for _, h := range tlsErrorHints {
+		if strings.Contains(message, h.cause) {
+			return h.hint, true
+		}
+	}
+
+	return "", false
+}
+
 /*
 LogAndReturnError logs the error using gplog and returns error.
 Make sure to use in hub and agent only
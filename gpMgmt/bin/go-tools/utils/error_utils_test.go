@@ -40,3 +40,28 @@ func TestFormatGrpcError(t *testing.T) {
 		}
 	})
 }
+
+func TestFormatGrpcErrorForTls(t *testing.T) {
+	t.Run("explains TLS handshake failures of RPCs", func(t *testing.T) {
+		grpcErr := status.Error(codes.Unavailable, `connection error: desc = "transport: authentication handshake failed: tls: failed to verify certificate: x509: certificate signed by unknown authority"`)
+		result := utils.FormatGrpcError(grpcErr)
+
+		expected := `TLS handshake failed, the peer certificate is not signed by the configured CA: connection error: desc = "transport: authentication handshake failed: tls: failed to verify certificate: x509: certificate signed by unknown authority"`
+		if result.Error() != expected {
+			t.Fatalf("got %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("explains TLS handshake failures when connecting", func(t *testing.T) {
+		dialErr := errors.New(`context deadline exceeded: connection error: desc = "error reading server preface: remote error: tls: bad certificate"`)
+		result := utils.FormatGrpcError(dialErr)
+
+		expected := "TLS handshake failed, the server rejected the client certificate, check that it is signed by the configured CA and allowed to connect: " + dialErr.Error()
+		if result.Error() != expected {
+			t.Fatalf("got %v, want %v", result, expected)
+		}
+		if !errors.Is(result, dialErr) {
+			t.Fatalf("got %#v, want it to wrap %#v", result, dialErr)
+		}
+	})
+}
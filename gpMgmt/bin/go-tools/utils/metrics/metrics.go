@@ -0,0 +1,151 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc"
+	grpcStatus "google.golang.org/grpc/status"
+
+	"github.com/greenplum-db/gp-common-go-libs/gplog"
+)
+
+// Config of the metrics endpoints of the hub and agents, which are only served
+// when their port is set
+type Config struct {
+	BindAddress string `json:"bindAddress,omitempty"` // defaults to all interfaces
+	HubPort     int    `json:"hubPort,omitempty"`
+	AgentPort   int    `json:"agentPort,omitempty"`
+}
+
+// Registry holds the metrics of the process, along with the Go runtime and
+// process metrics
+var Registry = prometheus.NewRegistry()
+
+var (
+	rpcStarted = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "gp_grpc_server_started_total",
+		Help: "Number of RPCs started on the server.",
+	}, []string{"method"})
+	rpcHandled = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "gp_grpc_server_handled_total",
+		Help: "Number of RPCs completed on the server, by status code.",
+	}, []string{"method", "code"})
+	rpcDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "gp_grpc_server_handling_seconds",
+		Help:    "Time taken by the server to handle RPCs.",
+		Buckets: []float64{0.005, 0.05, 0.5, 1, 5, 30, 60, 300, 900, 3600},
+	}, []string{"method"})
+	rpcInProgress = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "gp_grpc_server_in_progress",
+		Help: "Number of RPCs being handled by the server.",
+	}, []string{"method"})
+
+	commandDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "gp_command_duration_seconds",
+		Help:    "Time taken by the utilities run, such as initdb, pg_basebackup and pg_ctl.",
+		Buckets: []float64{0.1, 0.5, 1, 5, 10, 30, 60, 300, 900, 3600},
+	}, []string{"command"})
+	commandFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "gp_command_failures_total",
+		Help: "Number of utilities run which failed.",
+	}, []string{"command"})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		rpcStarted, rpcHandled, rpcDuration, rpcInProgress,
+		commandDuration, commandFailures,
+	)
+}
+
+// UnaryServerInterceptor records the count, duration and status code of the
+// unary RPCs of a server
+func UnaryServerInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	done := startRPC(info.FullMethod)
+	resp, err := handler(ctx, req)
+	done(err)
+
+	return resp, err
+}
+
+// StreamServerInterceptor records the count, duration and status code of the
+// streaming RPCs of a server
+func StreamServerInterceptor(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	done := startRPC(info.FullMethod)
+	err := handler(srv, stream)
+	done(err)
+
+	return err
+}
+
+func startRPC(method string) func(error) {
+	start := time.Now()
+	rpcStarted.WithLabelValues(method).Inc()
+	rpcInProgress.WithLabelValues(method).Inc()
+
+	return func(err error) {
+		rpcInProgress.WithLabelValues(method).Dec()
+		rpcDuration.WithLabelValues(method).Observe(time.Since(start).Seconds())
+		rpcHandled.WithLabelValues(method, grpcStatus.Code(err).String()).Inc()
+	}
+}
+
+// ObserveCommand records the duration of a utility run since start, and whether
+// it failed
+func ObserveCommand(command string, start time.Time, err error) {
+	commandDuration.WithLabelValues(command).Observe(time.Since(start).Seconds())
+	if err != nil {
+		commandFailures.WithLabelValues(command).Inc()
+	}
+}
+
+// Server serves the metrics of the Registry over HTTP
+type Server struct {
+	httpServer *http.Server
+	listener   net.Listener
+}
+
+// Serve starts serving /metrics on the given address and port, in the background
+func Serve(bindAddress string, port int) (*Server, error) {
+	listener, err := net.Listen("tcp", net.JoinHostPort(bindAddress, strconv.Itoa(port)))
+	if err != nil {
+		return nil, fmt.Errorf("could not listen on metrics port %d: %w", port, err)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(Registry, promhttp.HandlerOpts{}))
+	s := &Server{
+		httpServer: &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second},
+		listener:   listener,
+	}
+
+	go func() {
+		err := s.httpServer.Serve(listener)
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			gplog.Error("Metrics server stopped: %s", err)
+		}
+	}()
+	gplog.Info("Serving metrics on %s", listener.Addr())
+
+	return s, nil
+}
+
+// Addr returns the address the metrics are served on
+func (s *Server) Addr() net.Addr {
+	return s.listener.Addr()
+}
+
+func (s *Server) Close() error {
+	return s.httpServer.Close()
+}
@@ -0,0 +1,126 @@
+package metrics_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/greenplum-db/gp-common-go-libs/testhelper"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	grpcStatus "google.golang.org/grpc/status"
+
+	"github.com/greenplum-db/gpdb/gp/utils/metrics"
+)
+
+func scrape(t *testing.T, server *metrics.Server) string {
+	t.Helper()
+
+	resp, err := http.Get(fmt.Sprintf("http://%s/metrics", server.Addr()))
+	if err != nil {
+		t.Fatalf("unexpected error: %#v", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("unexpected error: %#v", err)
+	}
+
+	return string(body)
+}
+
+func assertMetrics(t *testing.T, contents string, expected ...string) {
+	t.Helper()
+
+	for _, line := range expected {
+		if !strings.Contains(contents, line) {
+			t.Errorf("expected metrics to contain %q, got:\n%s", line, contents)
+		}
+	}
+}
+
+func TestMetrics(t *testing.T) {
+	testhelper.SetupTestLogger()
+
+	server, err := metrics.Serve("127.0.0.1", 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %#v", err)
+	}
+	defer server.Close()
+
+	t.Run("records the unary RPCs by status code", func(t *testing.T) {
+		info := &grpc.UnaryServerInfo{FullMethod: "/idl.Test/Unary"}
+		var inProgress string
+		handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+			inProgress = scrape(t, server)
+			return nil, nil
+		}
+		failing := func(ctx context.Context, req interface{}) (interface{}, error) {
+			return nil, grpcStatus.Error(codes.Unavailable, "error")
+		}
+
+		_, err := metrics.UnaryServerInterceptor(context.Background(), nil, info, handler)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		_, err = metrics.UnaryServerInterceptor(context.Background(), nil, info, failing)
+		if grpcStatus.Code(err) != codes.Unavailable {
+			t.Fatalf("got %v, want code %s", err, codes.Unavailable)
+		}
+
+		assertMetrics(t, inProgress, `gp_grpc_server_in_progress{method="/idl.Test/Unary"} 1`)
+		assertMetrics(t, scrape(t, server),
+			`gp_grpc_server_started_total{method="/idl.Test/Unary"} 2`,
+			`gp_grpc_server_handled_total{code="OK",method="/idl.Test/Unary"} 1`,
+			`gp_grpc_server_handled_total{code="Unavailable",method="/idl.Test/Unary"} 1`,
+			`gp_grpc_server_handling_seconds_count{method="/idl.Test/Unary"} 2`,
+			`gp_grpc_server_in_progress{method="/idl.Test/Unary"} 0`,
+		)
+	})
+
+	t.Run("records the streaming RPCs", func(t *testing.T) {
+		info := &grpc.StreamServerInfo{FullMethod: "/idl.Test/Stream"}
+		handler := func(srv interface{}, stream grpc.ServerStream) error {
+			return errors.New("error")
+		}
+
+		err := metrics.StreamServerInterceptor(nil, nil, info, handler)
+		if err == nil {
+			t.Fatalf("expected an error")
+		}
+
+		assertMetrics(t, scrape(t, server),
+			`gp_grpc_server_started_total{method="/idl.Test/Stream"} 1`,
+			`gp_grpc_server_handled_total{code="Unknown",method="/idl.Test/Stream"} 1`,
+		)
+	})
+
+	t.Run("records the durations and failures of the utilities", func(t *testing.T) {
+		metrics.ObserveCommand("initdb", time.Now().Add(-2*time.Second), nil)
+		metrics.ObserveCommand("initdb", time.Now(), errors.New("error"))
+
+		assertMetrics(t, scrape(t, server),
+			`gp_command_duration_seconds_count{command="initdb"} 2`,
+			`gp_command_duration_seconds_bucket{command="initdb",le="1"} 1`,
+			`gp_command_failures_total{command="initdb"} 1`,
+			"go_goroutines",
+		)
+	})
+
+	t.Run("errors out when the port is in use", func(t *testing.T) {
+		port := server.Addr().(*net.TCPAddr).Port
+
+		_, err := metrics.Serve("127.0.0.1", port)
+		expected := fmt.Sprintf("could not listen on metrics port %d:", port)
+		if err == nil || !strings.HasPrefix(err.Error(), expected) {
+			t.Fatalf("got %v, want %s", err, expected)
+		}
+	})
+}
@@ -0,0 +1,100 @@
+package utils
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"os/user"
+	"strconv"
+	"strings"
+)
+
+// Sockets of the local TCP connections, as listed by the kernel
+var procNetTcpFiles = []string{"/proc/net/tcp", "/proc/net/tcp6"}
+
+/*
+LoopbackPeerUser returns the OS user owning the client end of a TCP connection
+made from the local host to serverPort. The owner is looked up in the socket
+tables of the kernel, so it cannot be forged by the client. Clients on other
+hosts cannot be identified this way.
+*/
+func LoopbackPeerUser(client *net.TCPAddr, serverPort int) (string, error) {
+	if client == nil || !client.IP.IsLoopback() {
+		return "", fmt.Errorf("cannot identify the user of client %s, it is not on the local host", client)
+	}
+
+	for _, file := range procNetTcpFiles {
+		contents, err := System.ReadFile(file)
+		if err != nil {
+			continue
+		}
+
+		uid, found := findSocketOwner(contents, client, serverPort)
+		if !found {
+			continue
+		}
+
+		owner, err := user.LookupId(uid)
+		if err != nil {
+			return "", fmt.Errorf("could not look up the user of client %s: %w", client, err)
+		}
+
+		return owner.Username, nil
+	}
+
+	return "", fmt.Errorf("could not find the connection of client %s", client)
+}
+
+// findSocketOwner returns the uid of the socket whose local address is the
+// client and whose remote port is serverPort
+func findSocketOwner(contents []byte, client *net.TCPAddr, serverPort int) (string, bool) {
+	scanner := bufio.NewScanner(bytes.NewReader(contents))
+	scanner.Scan() // skip the header
+
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 8 {
+			continue
+		}
+
+		local, err := parseProcNetAddr(fields[1])
+		if err != nil || !local.IP.Equal(client.IP) || local.Port != client.Port {
+			continue
+		}
+
+		remote, err := parseProcNetAddr(fields[2])
+		if err != nil || remote.Port != serverPort {
+			continue
+		}
+
+		return fields[7], true
+	}
+
+	return "", false
+}
+
+// parseProcNetAddr parses addresses such as 0100007F:1F90, where the IP address
+// is stored as little endian 32-bit words and the port in hexadecimal
+func parseProcNetAddr(addr string) (*net.TCPAddr, error) {
+	ipHex, portHex, found := strings.Cut(addr, ":")
+	if !found {
+		return nil, fmt.Errorf("invalid address %q", addr)
+	}
+
+	ip, err := hex.DecodeString(ipHex)
+	if err != nil || (len(ip) != net.IPv4len && len(ip) != net.IPv6len) {
+		return nil, fmt.Errorf("invalid address %q", addr)
+	}
+	for i := 0; i < len(ip); i += 4 {
+		ip[i], ip[i+1], ip[i+2], ip[i+3] = ip[i+3], ip[i+2], ip[i+1], ip[i]
+	}
+
+	port, err := strconv.ParseUint(portHex, 16, 16)
+	if err != nil {
+		return nil, fmt.Errorf("invalid address %q", addr)
+	}
+
+	return &net.TCPAddr{IP: net.IP(ip), Port: int(port)}, nil
+}
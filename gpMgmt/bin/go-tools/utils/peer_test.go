@@ -0,0 +1,87 @@
+package utils_test
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"os/user"
+	"strings"
+	"testing"
+
+	"github.com/greenplum-db/gpdb/gp/utils"
+)
+
+func TestLoopbackPeerUser(t *testing.T) {
+	current, err := user.Current()
+	if err != nil {
+		t.Fatalf("unexpected error: %#v", err)
+	}
+
+	t.Run("returns the user of a local client", func(t *testing.T) {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		defer listener.Close()
+
+		conn, err := net.Dial("tcp", listener.Addr().String())
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		defer conn.Close()
+
+		result, err := utils.LoopbackPeerUser(conn.LocalAddr().(*net.TCPAddr), listener.Addr().(*net.TCPAddr).Port)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		if result != current.Username {
+			t.Fatalf("got %q, want %q", result, current.Username)
+		}
+	})
+
+	t.Run("finds the client among the sockets of the host", func(t *testing.T) {
+		defer utils.ResetSystemFunctions()
+		utils.System.ReadFile = func(name string) ([]byte, error) {
+			if name != "/proc/net/tcp" {
+				return nil, os.ErrNotExist
+			}
+
+			// The first socket is the server end of the connection
+			return []byte(fmt.Sprintf(`  sl  local_address rem_address   st tx_queue rx_queue tr tm->when retrnsmt   uid  timeout inode
+   0: 0100007F:1F90 0100007F:D431 01 00000000:00000000 00:00000000 00000000  12345        0 1 1 0000000000000000 20 4 30 10 -1
+   1: 0100007F:D431 0100007F:1F90 01 00000000:00000000 00:00000000 00000000  %s        0 2 1 0000000000000000 20 4 30 10 -1
+`, current.Uid)), nil
+		}
+
+		result, err := utils.LoopbackPeerUser(&net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 54321}, 8080)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		if result != current.Username {
+			t.Fatalf("got %q, want %q", result, current.Username)
+		}
+	})
+
+	t.Run("errors out for clients on other hosts", func(t *testing.T) {
+		_, err := utils.LoopbackPeerUser(&net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 54321}, 8080)
+		expected := "cannot identify the user of client 10.0.0.1:54321, it is not on the local host"
+		if err == nil || err.Error() != expected {
+			t.Fatalf("got %v, want %s", err, expected)
+		}
+	})
+
+	t.Run("errors out when the connection is not found", func(t *testing.T) {
+		defer utils.ResetSystemFunctions()
+		utils.System.ReadFile = func(name string) ([]byte, error) {
+			return nil, errors.New("error")
+		}
+
+		_, err := utils.LoopbackPeerUser(&net.TCPAddr{IP: net.IPv6loopback, Port: 54321}, 8080)
+		if err == nil || !strings.HasPrefix(err.Error(), "could not find the connection of client") {
+			t.Fatalf("got %v, want the connection not to be found", err)
+		}
+	})
+}
@@ -1,6 +1,8 @@
 package utils
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"io"
@@ -34,6 +36,48 @@ type GpPlatform struct {
 	UserArg    string // systemd always needs a "--user" flag passed, launchctl does not
 	ServiceExt string // Extension for service files
 	StatusArg  string // Argument passed to ServiceCmd to get status of a service
+
+	// Install systemd system units run as the given user and group, managed
+	// through sudo, instead of user units
+	SystemUnits  bool
+	ServiceUser  string
+	ServiceGroup string
+
+	// The output of the services goes to <LogDir>/<service-name>_<process>.log,
+	// or to the journal when LogOutput is journald
+	LogDir    string
+	LogOutput string
+
+	// The gp.conf the services are started with, see ConfigFileArgs
+	ConfigFile string
+}
+
+// SystemServiceConfig installs the hub and agents as systemd system units run
+// as the given user and group, instead of user units
+type SystemServiceConfig struct {
+	User  string `json:"user"`
+	Group string `json:"group"`
+}
+
+// ServiceOptions select how the hub and agents are run, as set in gp.conf
+type ServiceOptions struct {
+	Manager       string // systemd, launchd or process, the one of the OS when not set
+	GpHome        string
+	LogDir        string
+	SystemService *SystemServiceConfig
+	ServiceLog    *ServiceLogConfig
+	ConfigFile    string // the gp.conf of the cluster, passed to the services
+}
+
+// ConfigFileArgs returns the arguments starting the hub or agent with the
+// configuration file, which are only needed when it is not the gp.conf of
+// GPHOME, e.g. for the other clusters of the host
+func ConfigFileArgs(gpHome string, configFile string) []string {
+	if configFile == "" || configFile == filepath.Join(gpHome, constants.ConfigFileName) {
+		return nil
+	}
+
+	return []string{"--config-file", configFile}
 }
 
 func NewPlatform(os string) (Platform, error) {
@@ -61,6 +105,53 @@ func NewPlatform(os string) (Platform, error) {
 	}
 }
 
+/*
+NewServicePlatform returns the platform running the hub and agents with the
+service manager set in gp.conf, or with the service manager of the OS when it
+is not set.
+*/
+func NewServicePlatform(os string, opts ServiceOptions) (Platform, error) {
+	if opts.SystemService != nil && (os != constants.PlatformLinux || (opts.Manager != "" && opts.Manager != constants.ServiceManagerSystemd)) {
+		return nil, fmt.Errorf("system services are only supported with the %s service manager on %s", constants.ServiceManagerSystemd, constants.PlatformLinux)
+	}
+
+	logOutput := opts.ServiceLog.GetOutput()
+	if logOutput == constants.ServiceLogOutputJournald && (os != constants.PlatformLinux || (opts.Manager != "" && opts.Manager != constants.ServiceManagerSystemd)) {
+		return nil, fmt.Errorf("the %s service log output is only supported with the %s service manager on %s", logOutput, constants.ServiceManagerSystemd, constants.PlatformLinux)
+	}
+
+	switch opts.Manager {
+	case "", constants.ServiceManagerSystemd, constants.ServiceManagerLaunchd:
+		if opts.Manager != "" && (opts.Manager == constants.ServiceManagerSystemd) != (os == constants.PlatformLinux) {
+			return nil, fmt.Errorf("the %s service manager is not supported on %s", opts.Manager, os)
+		}
+
+		p, err := NewPlatform(os)
+		if err != nil {
+			return nil, err
+		}
+
+		gpPlatform := p.(GpPlatform)
+		gpPlatform.LogDir = opts.LogDir
+		gpPlatform.LogOutput = logOutput
+		gpPlatform.ConfigFile = opts.ConfigFile
+		if opts.SystemService != nil {
+			gpPlatform.UserArg = ""
+			gpPlatform.SystemUnits = true
+			gpPlatform.ServiceUser = opts.SystemService.User
+			gpPlatform.ServiceGroup = opts.SystemService.Group
+		}
+		return gpPlatform, nil
+
+	case constants.ServiceManagerProcess:
+		return ProcessPlatform{OS: os, GpHome: opts.GpHome, LogDir: opts.LogDir, ConfigFile: opts.ConfigFile}, nil
+
+	default:
+		return nil, fmt.Errorf("unsupported service manager %q, expected one of %s, %s or %s", opts.Manager,
+			constants.ServiceManagerSystemd, constants.ServiceManagerLaunchd, constants.ServiceManagerProcess)
+	}
+}
+
 type Platform interface {
 	CreateServiceDir(hostnames []string, serviceDir string, gpHome string) error
 	GenerateServiceFileContents(process string, gpHome string, serviceName string) string
@@ -75,6 +166,8 @@ type Platform interface {
 	ParseServiceStatusMessage(message string) idl.ServiceStatus
 	DisplayServiceStatus(outfile io.Writer, serviceName string, statuses []*idl.ServiceStatus, skipHeader bool)
 	EnableUserLingering(hostnames []string, gpHome string, serviceUser string) error
+	GetRemoveServiceCommand(serviceDir string, serviceName string, process string) string
+	GetDisableLingeringCommand(serviceUser string) string
 	GetPlatformOS() string
 }
 
@@ -93,6 +186,10 @@ func GetPlatform() Platform {
 }
 
 func (p GpPlatform) CreateServiceDir(hostnames []string, serviceDir string, gpHome string) error {
+	if p.SystemUnits { // the system unit directory always exists
+		return nil
+	}
+
 	hostList := make([]string, 0)
 	for _, host := range hostnames {
 		hostList = append(hostList, "-h", host)
@@ -129,14 +226,29 @@ func WriteServiceFile(filename string, contents string) error {
 }
 
 func (p GpPlatform) GenerateServiceFileContents(process string, gpHome string, serviceName string) string {
+	logFile := ServiceLogPath(p.LogDir, serviceName, process)
+	args := append([]string{process}, ConfigFileArgs(gpHome, p.ConfigFile)...)
 	if p.OS == constants.PlatformDarwin {
-		return GenerateDarwinServiceFileContents(process, gpHome, serviceName)
+		return GenerateDarwinServiceFileContents(args, gpHome, serviceName, logFile)
+	}
+
+	// the services redirect their output to the log file themselves once
+	// started, so that they can rotate it
+	output := "append:" + logFile
+	if p.LogOutput == constants.ServiceLogOutputJournald {
+		output = "journal"
+	}
+
+	if p.SystemUnits {
+		return GenerateLinuxSystemServiceFileContents(args, gpHome, serviceName, p.ServiceUser, p.ServiceGroup, output)
 	}
 
-	return GenerateLinuxServiceFileContents(process, gpHome, serviceName)
+	return GenerateLinuxServiceFileContents(args, gpHome, serviceName, output)
 }
 
-func GenerateDarwinServiceFileContents(process string, gpHome string, serviceName string) string {
+// GenerateDarwinServiceFileContents returns a launchd agent running gp with the
+// given arguments, the process first
+func GenerateDarwinServiceFileContents(args []string, gpHome string, serviceName string, logFile string) string {
 	template := `<?xml version="1.0" encoding="UTF-8"?>
 <!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
 <plist version="1.0">
@@ -145,13 +257,12 @@ func GenerateDarwinServiceFileContents(process string, gpHome string, serviceNam
     <string>%[3]s_%[1]s</string>
     <key>ProgramArguments</key>
     <array>
-        <string>%[2]s/bin/gp</string>
-        <string>%[1]s</string>
+        <string>%[2]s/bin/gp</string>%[6]s
     </array>
     <key>StandardOutPath</key>
-    <string>/tmp/grpc_%[1]s.log</string>
+    <string>%[5]s</string>
     <key>StandardErrorPath</key>
-    <string>/tmp/grpc_%[1]s.log</string>
+    <string>%[5]s</string>
     <key>EnvironmentVariables</key>
     <dict>
         <key>PATH</key>
@@ -162,29 +273,75 @@ func GenerateDarwinServiceFileContents(process string, gpHome string, serviceNam
 </dict>
 </plist>
 `
-	return fmt.Sprintf(template, process, gpHome, serviceName, os.Getenv("PATH"))
+	var programArgs strings.Builder
+	for _, arg := range args {
+		programArgs.WriteString("\n        <string>" + arg + "</string>")
+	}
+
+	return fmt.Sprintf(template, args[0], gpHome, serviceName, os.Getenv("PATH"), logFile, programArgs.String())
 }
 
-func GenerateLinuxServiceFileContents(process string, gpHome string, serviceName string) string {
+// GenerateLinuxServiceFileContents returns a user unit running gp with the given
+// arguments, the process first, with its output going to the given systemd
+// output, e.g. append:<file> or journal
+func GenerateLinuxServiceFileContents(args []string, gpHome string, serviceName string, output string) string {
 	template := `[Unit]
 Description=Greenplum Database management utility %[1]s
 
 [Service]
 Type=simple
 Environment=GPHOME=%[2]s
-ExecStart=%[2]s/bin/gp %[1]s
+ExecStart=%[2]s/bin/gp %[5]s
 Restart=on-failure
-StandardOutput=file:/tmp/grpc_%[1]s.log
-StandardError=file:/tmp/grpc_%[1]s.log
+StandardOutput=%[4]s
+StandardError=%[4]s
+SyslogIdentifier=%[3]s_%[1]s
 
 [Install]
 Alias=%[3]s_%[1]s.service
 WantedBy=default.target
 `
-	return fmt.Sprintf(template, process, gpHome, serviceName)
+	return fmt.Sprintf(template, args[0], gpHome, serviceName, output, strings.Join(args, " "))
+}
+
+/*
+GenerateLinuxSystemServiceFileContents returns a system unit running gp with the
+given arguments, the process first, as the given user and group once the network is up, with the open
+files limit required by the segments. The unit is restarted on failure with a
+delay, and gives up after repeated failures.
+*/
+func GenerateLinuxSystemServiceFileContents(args []string, gpHome string, serviceName string, user string, group string, output string) string {
+	template := `[Unit]
+Description=Greenplum Database management utility %[1]s
+Wants=network-online.target
+After=network-online.target
+StartLimitIntervalSec=600
+StartLimitBurst=5
+
+[Service]
+Type=simple
+User=%[4]s
+Group=%[5]s
+Environment=GPHOME=%[2]s
+ExecStart=%[2]s/bin/gp %[8]s
+Restart=on-failure
+RestartSec=10
+LimitNOFILE=%[6]d
+StandardOutput=%[7]s
+StandardError=%[7]s
+SyslogIdentifier=%[3]s_%[1]s
+
+[Install]
+WantedBy=multi-user.target
+`
+	return fmt.Sprintf(template, args[0], gpHome, serviceName, user, group, constants.OsOpenFiles, output, strings.Join(args, " "))
 }
 
 func (p GpPlatform) GetDefaultServiceDir() string {
+	if p.SystemUnits {
+		return constants.SystemServiceDir
+	}
+
 	if p.OS == constants.PlatformDarwin {
 		return "/Users/%s/Library/LaunchAgents"
 	}
@@ -193,6 +350,10 @@ func (p GpPlatform) GetDefaultServiceDir() string {
 }
 
 func (p GpPlatform) CreateAndInstallHubServiceFile(gpHome string, serviceDir string, serviceName string) error {
+	if p.SystemUnits {
+		return p.installHubSystemUnit(gpHome, serviceDir, serviceName)
+	}
+
 	hubServiceContents := p.GenerateServiceFileContents("hub", gpHome, serviceName)
 	hubServiceFilePath := filepath.Join(serviceDir, fmt.Sprintf("%s_hub.%s", serviceName, p.ServiceExt))
 	err := writeServiceFileFunc(hubServiceFilePath, hubServiceContents)
@@ -225,11 +386,22 @@ func (p GpPlatform) ReloadHubService(servicePath string) error {
 		return nil
 	}
 
-	err := execCommand(p.ServiceCmd, p.UserArg, "daemon-reload").Run()
+	reload := p.systemctl("daemon-reload")
+	err := execCommand(reload[0], reload[1:]...).Run()
 	if err != nil {
 		return fmt.Errorf("could not reload hub service file %s: %w", servicePath, err)
 	}
 
+	// The system units are enabled to be started at boot, as they are not
+	// started through the lingering of the user
+	if p.SystemUnits {
+		enable := p.systemctl("enable", filepath.Base(servicePath))
+		err = execCommand(enable[0], enable[1:]...).Run()
+		if err != nil {
+			return fmt.Errorf("could not enable hub service file %s: %w", servicePath, err)
+		}
+	}
+
 	return nil
 }
 
@@ -251,15 +423,62 @@ func (p GpPlatform) ReloadAgentService(gpHome string, hostList []string, service
 		return nil
 	}
 
-	err := execCommand(fmt.Sprintf("%s/bin/gpssh", gpHome), append(args, p.UserArg, "daemon-reload")...).Run()
+	err := runWithGpssh(gpHome, hostList, strings.Join(p.systemctl("daemon-reload"), " "))
 	if err != nil {
 		return fmt.Errorf("could not reload agent service file %s on segment hosts: %w", servicePath, err)
 	}
 
+	if p.SystemUnits {
+		err = runWithGpssh(gpHome, hostList, strings.Join(p.systemctl("enable", filepath.Base(servicePath)), " "))
+		if err != nil {
+			return fmt.Errorf("could not enable agent service file %s on segment hosts: %w", servicePath, err)
+		}
+	}
+
+	return nil
+}
+
+/*
+runWithGpssh runs the shell command on the hosts given as -h <host> with gpssh.
+As gpssh does not return the status of the remote commands, the command echoes
+a marker once it succeeded, and the hosts which did not print it are named in
+the error along with the output.
+*/
+func runWithGpssh(gpHome string, hostList []string, command string) error {
+	out, err := execCommand(fmt.Sprintf("%s/bin/gpssh", gpHome), append(hostList, fmt.Sprintf("(%s) && echo %s", command, constants.RemoteSuccessMarker))...).CombinedOutput()
+	if err != nil {
+		return err
+	}
+
+	succeeded := make(map[string]bool)
+	var output []string
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		host, result, ok := strings.Cut(strings.TrimPrefix(strings.TrimSpace(line), "["), "]")
+		if ok && strings.TrimSpace(result) == constants.RemoteSuccessMarker {
+			succeeded[strings.TrimSpace(host)] = true
+			continue
+		}
+		output = append(output, line)
+	}
+
+	var failed []string
+	for i := 0; i+1 < len(hostList); i++ {
+		if hostList[i] == "-h" && !succeeded[hostList[i+1]] {
+			failed = append(failed, hostList[i+1])
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("the command failed on host(s) %s: %s", strings.Join(failed, ", "), strings.Join(output, "\n"))
+	}
+
 	return nil
 }
 
 func (p GpPlatform) CreateAndInstallAgentServiceFile(hostnames []string, gpHome string, serviceDir string, serviceName string) error {
+	if p.SystemUnits {
+		return p.installAgentSystemUnit(hostnames, gpHome, serviceDir, serviceName)
+	}
+
 	agentServiceContents := p.GenerateServiceFileContents("agent", gpHome, serviceName)
 	localAgentServiceFilePath := fmt.Sprintf("./%s_agent.%s", serviceName, p.ServiceExt)
 	err := writeServiceFileFunc(localAgentServiceFilePath, agentServiceContents)
@@ -290,7 +509,133 @@ func (p GpPlatform) CreateAndInstallAgentServiceFile(hostnames []string, gpHome
 	return nil
 }
 
+// systemctl returns the systemctl command with the arguments, for the user
+// units or run through sudo for the system units
+func (p GpPlatform) systemctl(args ...string) []string {
+	if p.SystemUnits {
+		return append([]string{"sudo", "-n", p.ServiceCmd}, args...)
+	}
+
+	return append([]string{p.ServiceCmd, p.UserArg}, args...)
+}
+
+// ReloadCommand returns the command loading the changed service files, run by
+// gp configure after installing them
+func (p GpPlatform) ReloadCommand() []string {
+	return p.systemctl("daemon-reload")
+}
+
+/*
+installHubSystemUnit writes the unit of the hub locally and installs it with
+sudo, since the system unit directory is owned by root. The unit is written to a
+private directory, so that no other user can replace it before it is installed.
+*/
+func (p GpPlatform) installHubSystemUnit(gpHome string, serviceDir string, serviceName string) error {
+	fileName := fmt.Sprintf("%s_hub.%s", serviceName, p.ServiceExt)
+	localDir, err := os.MkdirTemp("", "gp_service")
+	if err != nil {
+		return fmt.Errorf("could not create a directory for the hub service file: %w", err)
+	}
+	defer os.RemoveAll(localDir)
+
+	localPath := filepath.Join(localDir, fileName)
+	err = writeServiceFileFunc(localPath, p.GenerateServiceFileContents("hub", gpHome, serviceName))
+	if err != nil {
+		return err
+	}
+
+	hubServiceFilePath := filepath.Join(serviceDir, fileName)
+	output, err := execCommand("sudo", "-n", "install", "-m", "0644", localPath, hubServiceFilePath).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("could not install hub service file %s: %w: %s", hubServiceFilePath, err, strings.TrimSpace(string(output)))
+	}
+
+	err = p.ReloadHubService(hubServiceFilePath)
+	if err != nil {
+		return err
+	}
+
+	gplog.Info("Installed hub service file %s on coordinator host", hubServiceFilePath)
+	return nil
+}
+
+/*
+installAgentSystemUnit copies the unit of the agents to a private directory on
+the hosts and installs it with sudo. The directory has a random name and is
+created with mkdir, which fails rather than use a directory created by another
+user.
+*/
+func (p GpPlatform) installAgentSystemUnit(hostnames []string, gpHome string, serviceDir string, serviceName string) error {
+	fileName := fmt.Sprintf("%s_agent.%s", serviceName, p.ServiceExt)
+	localDir, err := os.MkdirTemp("", "gp_service")
+	if err != nil {
+		return fmt.Errorf("could not create a directory for the agent service file: %w", err)
+	}
+	defer os.RemoveAll(localDir)
+
+	localPath := filepath.Join(localDir, fileName)
+	err = writeServiceFileFunc(localPath, p.GenerateServiceFileContents("agent", gpHome, serviceName))
+	if err != nil {
+		return err
+	}
+
+	hostList := make([]string, 0)
+	for _, host := range hostnames {
+		hostList = append(hostList, "-h", host)
+	}
+
+	// The local directory may be on one of the hosts, so use another one there
+	remoteDir, err := randomTempDir("gp_service")
+	if err != nil {
+		return err
+	}
+	err = runWithGpssh(gpHome, hostList, fmt.Sprintf("mkdir -m 0700 %s", remoteDir))
+	if err != nil {
+		return fmt.Errorf("could not create the directory %s for the agent service file on segment hosts: %w", remoteDir, err)
+	}
+
+	remotePath := filepath.Join(remoteDir, fileName)
+	args := append(hostList, localPath, fmt.Sprintf("=:%s", remotePath))
+	err = GpsyncCommand(fmt.Sprintf("%s/bin/gpsync", gpHome), args...).Run()
+	if err != nil {
+		execCommand(fmt.Sprintf("%s/bin/gpssh", gpHome), append(hostList, "rm", "-rf", remoteDir)...).Run() //nolint:errcheck
+		return fmt.Errorf("could not copy agent service files to segment hosts: %w", err)
+	}
+
+	agentServiceFilePath := filepath.Join(serviceDir, fileName)
+	install := fmt.Sprintf("sudo -n install -m 0644 %[1]s %[2]s; status=$?; rm -rf %[3]s; exit $status", remotePath, agentServiceFilePath, remoteDir)
+	err = runWithGpssh(gpHome, hostList, install)
+	if err != nil {
+		return fmt.Errorf("could not install agent service file %s on segment hosts: %w", agentServiceFilePath, err)
+	}
+
+	err = p.ReloadAgentService(gpHome, hostList, agentServiceFilePath)
+	if err != nil {
+		return err
+	}
+
+	gplog.Info("Installed agent service file %s on segment hosts", agentServiceFilePath)
+	return nil
+}
+
+// randomTempDir returns a path in the temporary directory with a random name,
+// which is not created
+func randomTempDir(prefix string) (string, error) {
+	suffix := make([]byte, 8)
+	_, err := rand.Read(suffix)
+	if err != nil {
+		return "", fmt.Errorf("could not generate a temporary directory name: %w", err)
+	}
+
+	return filepath.Join(os.TempDir(), fmt.Sprintf("%s%s", prefix, hex.EncodeToString(suffix))), nil
+}
+
 func (p GpPlatform) GetStartHubCommand(serviceName string) *exec.Cmd {
+	if p.SystemUnits {
+		start := p.systemctl("start", fmt.Sprintf("%s_hub", serviceName))
+		return exec.Command(start[0], start[1:]...)
+	}
+
 	args := []string{p.UserArg, "start", fmt.Sprintf("%s_hub", serviceName)}
 
 	if p.OS == constants.PlatformDarwin { // empty strings are also treated as arguments
@@ -301,13 +646,19 @@ func (p GpPlatform) GetStartHubCommand(serviceName string) *exec.Cmd {
 }
 
 func (p GpPlatform) GetStartAgentCommandString(serviceName string) []string {
+	if p.SystemUnits {
+		return p.systemctl("start", fmt.Sprintf("%s_agent", serviceName))
+	}
+
 	return []string{p.ServiceCmd, p.UserArg, "start", fmt.Sprintf("%s_agent", serviceName)}
 }
 
 func (p GpPlatform) GetServiceStatusMessage(serviceName string) (string, error) {
 	args := []string{p.UserArg, p.StatusArg, serviceName}
 
-	if p.OS == constants.PlatformDarwin { // empty strings are also treated as arguments
+	// empty strings are also treated as arguments, and the status of the
+	// system units can be read without sudo
+	if p.OS == constants.PlatformDarwin || p.SystemUnits {
 		args = args[1:]
 	}
 
@@ -324,11 +675,14 @@ func (p GpPlatform) GetServiceStatusMessage(serviceName string) (string, error)
 /*
 Example service status output
 
-Linux:
+Linux, the same for the user and system units:
 ExecMainStartTimestamp=Sun 2023-08-20 14:43:35 UTC
 ExecMainPID=83008
 ExecMainCode=0
 ExecMainStatus=0
+LoadState=loaded
+ActiveState=active
+SubState=running
 
 Darwin:
 
@@ -344,6 +698,7 @@ Darwin:
 func (p GpPlatform) ParseServiceStatusMessage(message string) idl.ServiceStatus {
 	var uptime string
 	var pid int
+	states := make(map[string]string)
 
 	lines := strings.Split(message, "\n")
 	for _, line := range lines {
@@ -360,18 +715,33 @@ func (p GpPlatform) ParseServiceStatusMessage(message string) idl.ServiceStatus
 		case strings.HasPrefix(line, "ActiveEnterTimestamp="): // for linux
 			result := strings.Split(line, "=")
 			uptime = result[1]
+
+		case strings.HasPrefix(line, "LoadState="), strings.HasPrefix(line, "ActiveState="), strings.HasPrefix(line, "SubState="): // for linux
+			key, value, _ := strings.Cut(line, "=")
+			states[key] = value
 		}
 	}
 
 	status := "not running"
-	if pid > 0 {
+	switch {
+	case pid > 0:
 		status = "running"
+	case states["LoadState"] == "not-found": // e.g. a user unit queried as a system unit
+		status = "not installed"
+	case states["SubState"] == "auto-restart":
+		status = "restarting"
+	case states["ActiveState"] == "failed":
+		status = "failed"
 	}
 
 	return idl.ServiceStatus{Status: status, Uptime: uptime, Pid: uint32(pid)}
 }
 
 func (p GpPlatform) DisplayServiceStatus(outfile io.Writer, serviceName string, statuses []*idl.ServiceStatus, skipHeader bool) {
+	displayServiceStatus(outfile, serviceName, statuses, skipHeader)
+}
+
+func displayServiceStatus(outfile io.Writer, serviceName string, statuses []*idl.ServiceStatus, skipHeader bool) {
 	w := new(tabwriter.Writer)
 	w.Init(outfile, 0, 8, 2, '\t', 0)
 
@@ -388,7 +758,7 @@ func (p GpPlatform) DisplayServiceStatus(outfile io.Writer, serviceName string,
 // Allow systemd services to run on startup and be started/stopped without root access
 // This is a no-op on Mac, as launchctl lacks the concept of user lingering
 func (p GpPlatform) EnableUserLingering(hostnames []string, gpHome string, serviceUser string) error {
-	if p.OS != "linux" {
+	if p.OS != "linux" || p.SystemUnits { // the system units do not need the user to be logged in
 		return nil
 	}
 
@@ -406,6 +776,33 @@ func (p GpPlatform) EnableUserLingering(hostnames []string, gpHome string, servi
 	return nil
 }
 
+// GetRemoveServiceCommand returns the shell command stopping and unloading the
+// service, removing its service file and reloading the service manager
+func (p GpPlatform) GetRemoveServiceCommand(serviceDir string, serviceName string, process string) string {
+	name := fmt.Sprintf("%s_%s", serviceName, process)
+	path := filepath.Join(serviceDir, fmt.Sprintf("%s.%s", name, p.ServiceExt))
+	if p.OS == constants.PlatformDarwin {
+		return fmt.Sprintf("launchctl unload %[1]s 2>/dev/null; rm -f %[1]s", path)
+	}
+
+	remove := fmt.Sprintf("rm -f %s", path)
+	if p.SystemUnits {
+		remove = "sudo -n " + remove
+	}
+
+	return fmt.Sprintf("%s 2>/dev/null; %s && %s", strings.Join(p.systemctl("stop", name), " "), remove, strings.Join(p.systemctl("daemon-reload"), " "))
+}
+
+// GetDisableLingeringCommand returns the shell command undoing
+// EnableUserLingering, or an empty string when lingering is not used
+func (p GpPlatform) GetDisableLingeringCommand(serviceUser string) string {
+	if p.OS != constants.PlatformLinux || p.SystemUnits {
+		return ""
+	}
+
+	return fmt.Sprintf("loginctl disable-linger %s", serviceUser)
+}
+
 func SetExecCommand(command exectest.Command) {
 	execCommand = command
 }
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"reflect"
 	"strings"
 	"testing"
@@ -22,6 +23,8 @@ func init() {
 	exectest.RegisterMains(
 		ServiceStatusOutput,
 		ServiceStopped,
+		GpsshSucceeded,
+		GpsshFailedOnHost2,
 	)
 }
 
@@ -120,7 +123,7 @@ func TestGenerateServiceFileContents(t *testing.T) {
 	testhelper.SetupTestLogger()
 
 	t.Run("GenerateServiceFileContents successfully generates contents for darwin", func(t *testing.T) {
-		platform := GetPlatform("darwin", t)
+		platform := GetServicePlatform(constants.PlatformDarwin, utils.ServiceOptions{LogDir: "/logs"}, t)
 
 		expected := fmt.Sprintf(`<?xml version="1.0" encoding="UTF-8"?>
 <!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
@@ -134,9 +137,9 @@ func TestGenerateServiceFileContents(t *testing.T) {
         <string>hub</string>
     </array>
     <key>StandardOutPath</key>
-    <string>/tmp/grpc_hub.log</string>
+    <string>/logs/gp_hub.log</string>
     <key>StandardErrorPath</key>
-    <string>/tmp/grpc_hub.log</string>
+    <string>/logs/gp_hub.log</string>
     <key>EnvironmentVariables</key>
     <dict>
         <key>PATH</key>
@@ -154,7 +157,7 @@ func TestGenerateServiceFileContents(t *testing.T) {
 	})
 
 	t.Run("GenerateServiceFileContents successfully generates contents for linux", func(t *testing.T) {
-		platform := GetPlatform(constants.PlatformLinux, t)
+		platform := GetServicePlatform(constants.PlatformLinux, utils.ServiceOptions{LogDir: "/logs"}, t)
 
 		expected := `[Unit]
 Description=Greenplum Database management utility hub
@@ -164,8 +167,9 @@ Type=simple
 Environment=GPHOME=/test
 ExecStart=/test/bin/gp hub
 Restart=on-failure
-StandardOutput=file:/tmp/grpc_hub.log
-StandardError=file:/tmp/grpc_hub.log
+StandardOutput=append:/logs/gp_hub.log
+StandardError=append:/logs/gp_hub.log
+SyslogIdentifier=gp_hub
 
 [Install]
 Alias=gp_hub.service
@@ -176,6 +180,87 @@ WantedBy=default.target
 			t.Fatalf("got %q, want %q", contents, expected)
 		}
 	})
+
+	t.Run("GenerateServiceFileContents sends the output to the journal for linux", func(t *testing.T) {
+		platform := GetServicePlatform(constants.PlatformLinux, utils.ServiceOptions{
+			LogDir:     "/logs",
+			ServiceLog: &utils.ServiceLogConfig{Output: constants.ServiceLogOutputJournald},
+		}, t)
+
+		contents := platform.GenerateServiceFileContents("hub", "/test", "gp")
+		expected := "StandardOutput=journal\nStandardError=journal\nSyslogIdentifier=gp_hub\n"
+		if !strings.Contains(contents, expected) {
+			t.Fatalf("got %q, want it to contain %q", contents, expected)
+		}
+	})
+
+	t.Run("GenerateServiceFileContents starts the services with the configuration file of the cluster", func(t *testing.T) {
+		platform := GetServicePlatform(constants.PlatformLinux, utils.ServiceOptions{LogDir: "/logs", ConfigFile: "/etc/gp/dev.conf"}, t)
+
+		contents := platform.GenerateServiceFileContents("hub", "/test", "gp_dev")
+		expected := "ExecStart=/test/bin/gp hub --config-file /etc/gp/dev.conf\n"
+		if !strings.Contains(contents, expected) {
+			t.Fatalf("got %q, want it to contain %q", contents, expected)
+		}
+
+		platform = GetServicePlatform(constants.PlatformDarwin, utils.ServiceOptions{LogDir: "/logs", ConfigFile: "/etc/gp/dev.conf"}, t)
+
+		contents = platform.GenerateServiceFileContents("hub", "/test", "gp_dev")
+		expected = `
+        <string>/test/bin/gp</string>
+        <string>hub</string>
+        <string>--config-file</string>
+        <string>/etc/gp/dev.conf</string>
+    </array>`
+		if !strings.Contains(contents, expected) {
+			t.Fatalf("got %q, want it to contain %q", contents, expected)
+		}
+	})
+
+	t.Run("GenerateServiceFileContents leaves out the gp.conf of GPHOME", func(t *testing.T) {
+		platform := GetServicePlatform(constants.PlatformLinux, utils.ServiceOptions{LogDir: "/logs", ConfigFile: "/test/gp.conf"}, t)
+
+		contents := platform.GenerateServiceFileContents("hub", "/test", "gp")
+		expected := "ExecStart=/test/bin/gp hub\n"
+		if !strings.Contains(contents, expected) {
+			t.Fatalf("got %q, want it to contain %q", contents, expected)
+		}
+	})
+
+	t.Run("GenerateServiceFileContents successfully generates contents for linux system units", func(t *testing.T) {
+		platform := GetServicePlatform(constants.PlatformLinux, utils.ServiceOptions{
+			LogDir:        "/logs",
+			SystemService: &utils.SystemServiceConfig{User: "gpadmin", Group: "gpgroup"},
+		}, t)
+
+		expected := `[Unit]
+Description=Greenplum Database management utility agent
+Wants=network-online.target
+After=network-online.target
+StartLimitIntervalSec=600
+StartLimitBurst=5
+
+[Service]
+Type=simple
+User=gpadmin
+Group=gpgroup
+Environment=GPHOME=/test
+ExecStart=/test/bin/gp agent
+Restart=on-failure
+RestartSec=10
+LimitNOFILE=65535
+StandardOutput=append:/logs/gp_agent.log
+StandardError=append:/logs/gp_agent.log
+SyslogIdentifier=gp_agent
+
+[Install]
+WantedBy=multi-user.target
+`
+		contents := platform.GenerateServiceFileContents("agent", "/test", "gp")
+		if contents != expected {
+			t.Fatalf("got %q, want %q", contents, expected)
+		}
+	})
 }
 
 func TestGetDefaultServiceDir(t *testing.T) {
@@ -225,13 +310,13 @@ func TestReloadServices(t *testing.T) {
 			utils.UnloadServiceCommand = exectest.NewCommand(exectest.Success)
 			utils.LoadServiceCommand = exectest.NewCommand(exectest.Success)
 
-			utils.SetExecCommand(exectest.NewCommand(exectest.Success))
+			utils.SetExecCommand(exectest.NewCommand(GpsshSucceeded))
 			defer utils.ResetExecCommand()
 
 			if tc.service == "hub" {
 				err = platform.ReloadHubService("/path/to/service/file")
 			} else {
-				err = platform.ReloadAgentService("gpHome", []string{"host1"}, "/path/to/service/file")
+				err = platform.ReloadAgentService("gpHome", []string{"-h", "host1"}, "/path/to/service/file")
 			}
 
 			if err != nil {
@@ -257,7 +342,7 @@ func TestReloadServices(t *testing.T) {
 			if tc.service == "hub" {
 				err = platform.ReloadHubService("/path/to/service/file")
 			} else {
-				err = platform.ReloadAgentService("gpHome", []string{"host1"}, "/path/to/service/file")
+				err = platform.ReloadAgentService("gpHome", []string{"-h", "host1"}, "/path/to/service/file")
 			}
 
 			expectedErr := fmt.Sprintf("could not unload %s service file /path/to/service/file%s: exit status 1", tc.service, tc.errSuffix)
@@ -278,7 +363,7 @@ func TestReloadServices(t *testing.T) {
 			if tc.service == "hub" {
 				err = platform.ReloadHubService("/path/to/service/file")
 			} else {
-				err = platform.ReloadAgentService("gpHome", []string{"host1"}, "/path/to/service/file")
+				err = platform.ReloadAgentService("gpHome", []string{"-h", "host1"}, "/path/to/service/file")
 			}
 
 			expectedErr := fmt.Sprintf("could not load %s service file /path/to/service/file%s: exit status 1", tc.service, tc.errSuffix)
@@ -304,7 +389,7 @@ func TestReloadServices(t *testing.T) {
 			if tc.service == "hub" {
 				err = platform.ReloadHubService("/path/to/service/file")
 			} else {
-				err = platform.ReloadAgentService("gpHome", []string{"host1"}, "/path/to/service/file")
+				err = platform.ReloadAgentService("gpHome", []string{"-h", "host1"}, "/path/to/service/file")
 			}
 
 			expectedErr := fmt.Sprintf("could not reload %s service file /path/to/service/file%s: exit status 1", tc.service, tc.errSuffix)
@@ -335,6 +420,40 @@ func TestCreateAndInstallHubServiceFile(t *testing.T) {
 		}
 	})
 
+	t.Run("CreateAndInstallHubServiceFile installs the system unit with sudo", func(t *testing.T) {
+		platform := GetSystemPlatform(t)
+
+		var written string
+		utils.SetWriteServiceFileFunc(func(filename, contents string) error {
+			written = filename
+			return nil
+		})
+		defer utils.ResetWriteServiceFileFunc()
+
+		var calls [][]string
+		utils.SetExecCommand(exectest.NewCommandWithVerifier(exectest.Success, func(utility string, args ...string) {
+			calls = append(calls, append([]string{utility}, args...))
+		}))
+		defer utils.ResetExecCommand()
+
+		err := platform.CreateAndInstallHubServiceFile("gpHome", constants.SystemServiceDir, "gptest")
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		expected := [][]string{
+			{"sudo", "-n", "install", "-m", "0644", written, "/etc/systemd/system/gptest_hub.service"},
+			{"sudo", "-n", "systemctl", "daemon-reload"},
+			{"sudo", "-n", "systemctl", "enable", "gptest_hub.service"},
+		}
+		if !reflect.DeepEqual(calls, expected) {
+			t.Fatalf("got %+v, want %+v", calls, expected)
+		}
+		if filepath.Dir(written) == os.TempDir() {
+			t.Fatalf("got %s, want the unit written to a private directory", written)
+		}
+	})
+
 	t.Run("CreateAndInstallHubServiceFile errors when not able to write to a file", func(t *testing.T) {
 		platform := GetPlatform(constants.PlatformLinux, t)
 
@@ -396,7 +515,7 @@ func TestCreateAndInstallAgentServiceFile(t *testing.T) {
 			}
 		})
 
-		utils.SetExecCommand(exectest.NewCommand(exectest.Success))
+		utils.SetExecCommand(exectest.NewCommand(GpsshSucceeded))
 		defer utils.ResetExecCommand()
 
 		err := platform.CreateAndInstallAgentServiceFile([]string{"host1", "host2"}, "gpHome", "testdir", "gptest")
@@ -405,6 +524,70 @@ func TestCreateAndInstallAgentServiceFile(t *testing.T) {
 		}
 	})
 
+	t.Run("CreateAndInstallAgentServiceFile errors naming the hosts where the remote command failed", func(t *testing.T) {
+		platform := GetSystemPlatform(t)
+
+		utils.SetWriteServiceFileFunc(func(filename, contents string) error {
+			return nil
+		})
+		defer utils.ResetWriteServiceFileFunc()
+
+		setMocks()
+		defer resetMocks()
+		utils.GpsyncCommand = exectest.NewCommand(exectest.Success)
+
+		// gpssh exits with 0 even though the command failed on host2
+		utils.SetExecCommand(exectest.NewCommand(GpsshFailedOnHost2))
+		defer utils.ResetExecCommand()
+
+		err := platform.CreateAndInstallAgentServiceFile([]string{"host1", "host2"}, "gpHome", constants.SystemServiceDir, "gptest")
+		expected := "the command failed on host(s) host2: [host2] sudo: a password is required"
+		if err == nil || !strings.HasSuffix(err.Error(), expected) {
+			t.Fatalf("got %v, want an error ending with %q", err, expected)
+		}
+	})
+
+	t.Run("CreateAndInstallAgentServiceFile installs the system unit from a private directory", func(t *testing.T) {
+		platform := GetSystemPlatform(t)
+
+		utils.SetWriteServiceFileFunc(func(filename, contents string) error {
+			return nil
+		})
+		defer utils.ResetWriteServiceFileFunc()
+
+		setMocks()
+		defer resetMocks()
+		var copied string
+		utils.GpsyncCommand = exectest.NewCommandWithVerifier(exectest.Success, func(utility string, args ...string) {
+			copied = strings.TrimPrefix(args[len(args)-1], "=:")
+		})
+
+		var calls []string
+		utils.SetExecCommand(exectest.NewCommandWithVerifier(GpsshSucceeded, func(utility string, args ...string) {
+			calls = append(calls, strings.Join(args, " "))
+		}))
+		defer utils.ResetExecCommand()
+
+		err := platform.CreateAndInstallAgentServiceFile([]string{"host1"}, "gpHome", constants.SystemServiceDir, "gptest")
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		remoteDir := filepath.Dir(copied)
+		if remoteDir == os.TempDir() || filepath.Base(copied) != "gptest_agent.service" {
+			t.Fatalf("got %s, want the unit copied to a private directory", copied)
+		}
+		expected := []string{
+			fmt.Sprintf("-h host1 (mkdir -m 0700 %s) && echo %s", remoteDir, constants.RemoteSuccessMarker),
+			fmt.Sprintf("-h host1 (sudo -n install -m 0644 %s /etc/systemd/system/gptest_agent.service; status=$?; rm -rf %s; exit $status) && echo %s", copied, remoteDir, constants.RemoteSuccessMarker),
+			"-h host1 (sudo -n systemctl daemon-reload) && echo " + constants.RemoteSuccessMarker,
+			"-h host1 (sudo -n systemctl enable gptest_agent.service) && echo " + constants.RemoteSuccessMarker,
+		}
+		if !reflect.DeepEqual(calls, expected) {
+			t.Fatalf("got %+v, want %+v", calls, expected)
+		}
+	})
+
 	t.Run("CreateAndInstallAgentServiceFile errors when gpsync fails", func(t *testing.T) {
 		platform := GetPlatform(constants.PlatformLinux, t)
 
@@ -494,6 +677,16 @@ func TestGetStartHubCommand(t *testing.T) {
 		}
 	})
 
+	t.Run("GetStartHubCommand returns the correct command for linux system units", func(t *testing.T) {
+		platform := GetSystemPlatform(t)
+
+		result := platform.GetStartHubCommand("gptest").Args
+		expected := []string{"sudo", "-n", "systemctl", "start", "gptest_hub"}
+		if !reflect.DeepEqual(result, expected) {
+			t.Fatalf("got %+v, want %+v", result, expected)
+		}
+	})
+
 	t.Run("GetStartHubCommand returns the correct command for darwin", func(t *testing.T) {
 		platform := GetPlatform(constants.PlatformDarwin, t)
 
@@ -518,6 +711,16 @@ func TestGetStartAgentCommandString(t *testing.T) {
 		}
 	})
 
+	t.Run("GetStartAgentCommandString returns the correct string for linux system units", func(t *testing.T) {
+		platform := GetSystemPlatform(t)
+
+		result := platform.GetStartAgentCommandString("gptest")
+		expected := []string{"sudo", "-n", "systemctl", "start", "gptest_agent"}
+		if !reflect.DeepEqual(result, expected) {
+			t.Fatalf("got %+v, want %+v", result, expected)
+		}
+	})
+
 	t.Run("GetStartAgentCommandString returns the correct string for darwin", func(t *testing.T) {
 		platform := GetPlatform(constants.PlatformDarwin, t)
 
@@ -554,6 +757,28 @@ func TestGetServiceStatusMessage(t *testing.T) {
 		}
 	})
 
+	t.Run("GetServiceStatusMessage gets the status of system units without sudo", func(t *testing.T) {
+		platform := GetSystemPlatform(t)
+
+		utils.SetExecCommand(exectest.NewCommandWithVerifier(ServiceStatusOutput, func(utility string, args ...string) {
+			if utility != "systemctl" {
+				t.Fatalf("got %q, want systemctl", utility)
+			}
+
+			expectedArgs := []string{"show", "gptest"}
+			if !reflect.DeepEqual(args, expectedArgs) {
+				t.Fatalf("got %+v, want %+v", args, expectedArgs)
+			}
+		}))
+		defer utils.ResetExecCommand()
+
+		result, _ := platform.GetServiceStatusMessage("gptest")
+		expected := "got status of the service"
+		if result != expected {
+			t.Fatalf("got %q, want %q", result, expected)
+		}
+	})
+
 	t.Run("GetServiceStatusMessage successfully gets the service status for darwin", func(t *testing.T) {
 		platform := GetPlatform(constants.PlatformDarwin, t)
 
@@ -684,6 +909,39 @@ func TestParseServiceStatusMessage(t *testing.T) {
 			`,
 			expected: &idl.ServiceStatus{Status: "not running", Pid: uint32(0)},
 		},
+		{
+			name: "ParseServiceStatusMessage gets status for linux when service is restarting",
+			os:   constants.PlatformLinux,
+			message: `
+			MainPID=0
+			LoadState=loaded
+			ActiveState=activating
+			SubState=auto-restart
+			`,
+			expected: &idl.ServiceStatus{Status: "restarting"},
+		},
+		{
+			name: "ParseServiceStatusMessage gets status for linux when service has failed",
+			os:   constants.PlatformLinux,
+			message: `
+			MainPID=0
+			LoadState=loaded
+			ActiveState=failed
+			SubState=failed
+			`,
+			expected: &idl.ServiceStatus{Status: "failed"},
+		},
+		{
+			name: "ParseServiceStatusMessage gets status for linux when service is not installed",
+			os:   constants.PlatformLinux,
+			message: `
+			MainPID=0
+			LoadState=not-found
+			ActiveState=inactive
+			SubState=dead
+			`,
+			expected: &idl.ServiceStatus{Status: "not installed"},
+		},
 	}
 
 	for _, tc := range cases {
@@ -788,6 +1046,20 @@ func ServiceStopped() {
 	os.Exit(3)
 }
 
+// GpsshSucceeded prints the success marker for each host, as gpssh does once
+// the remote command succeeded
+func GpsshSucceeded() {
+	for i, arg := range os.Args[:len(os.Args)-1] {
+		if arg == "-h" {
+			fmt.Printf("[%s] %s\n", os.Args[i+1], constants.RemoteSuccessMarker)
+		}
+	}
+}
+
+func GpsshFailedOnHost2() {
+	fmt.Printf("[host1] %s\n[host2] sudo: a password is required\n", constants.RemoteSuccessMarker)
+}
+
 func GetPlatform(os string, t *testing.T) utils.Platform {
 	t.Helper()
 
@@ -798,3 +1070,72 @@ func GetPlatform(os string, t *testing.T) utils.Platform {
 
 	return platform
 }
+
+func GetSystemPlatform(t *testing.T) utils.Platform {
+	t.Helper()
+
+	return GetServicePlatform(constants.PlatformLinux, utils.ServiceOptions{
+		SystemService: &utils.SystemServiceConfig{User: "gpadmin", Group: "gpgroup"},
+	}, t)
+}
+
+func GetServicePlatform(os string, opts utils.ServiceOptions, t *testing.T) utils.Platform {
+	t.Helper()
+
+	platform, err := utils.NewServicePlatform(os, opts)
+	if err != nil {
+		t.Fatalf("unexpected error: %#v", err)
+	}
+
+	return platform
+}
+
+func TestGetRemoveServiceCommand(t *testing.T) {
+	cases := []struct {
+		name     string
+		platform utils.Platform
+		expected string
+	}{
+		{
+			name:     "linux",
+			platform: GetPlatform(constants.PlatformLinux, t),
+			expected: "systemctl --user stop gp_agent 2>/dev/null; rm -f /services/gp_agent.service && systemctl --user daemon-reload",
+		},
+		{
+			name:     "linux system units",
+			platform: GetSystemPlatform(t),
+			expected: "sudo -n systemctl stop gp_agent 2>/dev/null; sudo -n rm -f /services/gp_agent.service && sudo -n systemctl daemon-reload",
+		},
+		{
+			name:     "darwin",
+			platform: GetPlatform(constants.PlatformDarwin, t),
+			expected: "launchctl unload /services/gp_agent.plist 2>/dev/null; rm -f /services/gp_agent.plist",
+		},
+		{
+			name:     "the process service manager",
+			platform: utils.ProcessPlatform{LogDir: "/logs"},
+			expected: "rm -f /logs/gp_agent.pid",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(fmt.Sprintf("returns the command removing the service on %s", tc.name), func(t *testing.T) {
+			result := tc.platform.GetRemoveServiceCommand("/services", "gp", "agent")
+			if result != tc.expected {
+				t.Fatalf("got %q, want %q", result, tc.expected)
+			}
+		})
+	}
+
+	t.Run("returns the command disabling lingering only for the user units", func(t *testing.T) {
+		result := GetPlatform(constants.PlatformLinux, t).GetDisableLingeringCommand("gpadmin")
+		if result != "loginctl disable-linger gpadmin" {
+			t.Fatalf("got %q, want the loginctl command", result)
+		}
+
+		result = GetSystemPlatform(t).GetDisableLingeringCommand("gpadmin")
+		if result != "" {
+			t.Fatalf("got %q, want no command", result)
+		}
+	})
+}
@@ -0,0 +1,225 @@
+//go:build ignore
+
+/*
+This program generates saslprep_tables.go from the tables of RFC 3454
+(stringprep) used by the SASLprep profile. Run it with go generate, or with
+
+	go run gen_saslprep_tables.go -rfc rfc3454.txt
+
+to read a local copy of the RFC.
+*/
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var (
+	rfc    = flag.String("rfc", "https://www.rfc-editor.org/rfc/rfc3454.txt", "path or URL of the text of RFC 3454")
+	output = flag.String("output", "saslprep_tables.go", "file to write the tables to")
+)
+
+var tables = []struct {
+	name string
+	doc  string
+	ids  []string
+}{
+	{"nonASCIISpace", "C.1.2 Non-ASCII space characters, mapped to a space", []string{"C.1.2"}},
+	{"mappedToNothing", "B.1 Commonly mapped to nothing", []string{"B.1"}},
+	{"prohibitedOutput", "C.1.2 to C.9, the characters prohibited in the output", []string{"C.1.2", "C.2.1", "C.2.2", "C.3", "C.4", "C.5", "C.6", "C.7", "C.8", "C.9"}},
+	{"unassigned", "A.1 Unassigned code points in Unicode 3.2", []string{"A.1"}},
+	{"randALCat", "D.1 Characters with bidirectional property R or AL", []string{"D.1"}},
+	{"lCat", "D.2 Characters with bidirectional property L", []string{"D.2"}},
+}
+
+var (
+	startRegex = regexp.MustCompile(`^----- Start Table (\S+) -----$`)
+	endRegex   = regexp.MustCompile(`^----- End Table (\S+) -----$`)
+	// the entries are a code point or a range, followed by a description or
+	// a mapping after a semicolon in some tables
+	entryRegex = regexp.MustCompile(`^\s*([0-9A-F]{4,6})(?:-([0-9A-F]{4,6}))?\s*(?:;|$)`)
+)
+
+type codeRange struct {
+	lo, hi rune
+}
+
+func main() {
+	flag.Parse()
+
+	text, err := readRFC(*rfc)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	rfcTables, err := parseTables(text)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprint(&buf, "// Code generated from the tables of RFC 3454 (stringprep). DO NOT EDIT.\n\n")
+	fmt.Fprint(&buf, "package postgres\n\n")
+	fmt.Fprint(&buf, "import \"unicode\"\n")
+	for _, table := range tables {
+		var ranges []codeRange
+		for _, id := range table.ids {
+			entries, ok := rfcTables[id]
+			if !ok {
+				log.Fatalf("table %s not found in %s", id, *rfc)
+			}
+			ranges = append(ranges, entries...)
+		}
+
+		fmt.Fprintf(&buf, "\n// %s\n", table.doc)
+		writeRangeTable(&buf, table.name, merge(ranges))
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	err = os.WriteFile(*output, src, 0644)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+func readRFC(location string) ([]byte, error) {
+	if !strings.HasPrefix(location, "https://") && !strings.HasPrefix(location, "http://") {
+		return os.ReadFile(location)
+	}
+
+	resp, err := http.Get(location)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("could not fetch %s: %s", location, resp.Status)
+	}
+
+	return io.ReadAll(resp.Body)
+}
+
+// parseTables returns the entries of each table of the RFC by its id, e.g. C.1.2
+func parseTables(text []byte) (map[string][]codeRange, error) {
+	tables := make(map[string][]codeRange)
+	current := ""
+	scanner := bufio.NewScanner(bytes.NewReader(text))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if match := startRegex.FindStringSubmatch(line); match != nil {
+			current = match[1]
+			tables[current] = nil
+			continue
+		}
+		if match := endRegex.FindStringSubmatch(line); match != nil {
+			if match[1] != current {
+				return nil, fmt.Errorf("table %s ends within table %s", match[1], current)
+			}
+			current = ""
+			continue
+		}
+		if current == "" {
+			continue
+		}
+
+		// the page breaks within a table are skipped as they hold no entry
+		match := entryRegex.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+		lo, err := strconv.ParseUint(match[1], 16, 32)
+		if err != nil {
+			return nil, err
+		}
+		hi := lo
+		if match[2] != "" {
+			hi, err = strconv.ParseUint(match[2], 16, 32)
+			if err != nil {
+				return nil, err
+			}
+		}
+		tables[current] = append(tables[current], codeRange{rune(lo), rune(hi)})
+	}
+
+	return tables, scanner.Err()
+}
+
+// merge sorts the ranges and joins the ones which overlap or follow each other
+func merge(ranges []codeRange) []codeRange {
+	sort.Slice(ranges, func(i, j int) bool {
+		return ranges[i].lo < ranges[j].lo
+	})
+
+	var merged []codeRange
+	for _, r := range ranges {
+		last := len(merged) - 1
+		if last >= 0 && r.lo <= merged[last].hi+1 {
+			merged[last].hi = max(merged[last].hi, r.hi)
+			continue
+		}
+		merged = append(merged, r)
+	}
+
+	return merged
+}
+
+// writeRangeTable writes the ranges as a unicode.RangeTable, splitting the one
+// across 0xFFFF between R16 and R32
+func writeRangeTable(w io.Writer, name string, ranges []codeRange) {
+	var r16, r32 []codeRange
+	for _, r := range ranges {
+		switch {
+		case r.hi <= 0xFFFF:
+			r16 = append(r16, r)
+		case r.lo > 0xFFFF:
+			r32 = append(r32, r)
+		default:
+			r16 = append(r16, codeRange{r.lo, 0xFFFF})
+			r32 = append(r32, codeRange{0x10000, r.hi})
+		}
+	}
+
+	latinOffset := 0
+	for _, r := range r16 {
+		if r.hi <= 0xFF {
+			latinOffset++
+		}
+	}
+
+	fmt.Fprintf(w, "var %s = &unicode.RangeTable{\n", name)
+	if len(r16) > 0 {
+		fmt.Fprint(w, "R16: []unicode.Range16{\n")
+		for _, r := range r16 {
+			fmt.Fprintf(w, "{0x%04x, 0x%04x, 1},\n", r.lo, r.hi)
+		}
+		fmt.Fprint(w, "},\n")
+	}
+	if len(r32) > 0 {
+		fmt.Fprint(w, "R32: []unicode.Range32{\n")
+		for _, r := range r32 {
+			fmt.Fprintf(w, "{0x%04x, 0x%04x, 1},\n", r.lo, r.hi)
+		}
+		fmt.Fprint(w, "},\n")
+	}
+	if latinOffset > 0 {
+		fmt.Fprintf(w, "LatinOffset: %d,\n", latinOffset)
+	}
+	fmt.Fprint(w, "}\n")
+}
@@ -0,0 +1,413 @@
+package postgres
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/greenplum-db/gpdb/gp/constants"
+	"github.com/greenplum-db/gpdb/gp/utils"
+)
+
+var (
+	hbaConnectionTypes = []string{"local", "host", "hostssl", "hostnossl", "hostgssenc", "hostnogssenc"}
+	hbaAuthMethods     = []string{constants.AuthTrust, "reject", constants.AuthScramSha256, constants.AuthMd5, "password", "gss",
+		"sspi", "ident", "peer", "ldap", "radius", constants.AuthCert, "pam", "bsd"}
+	hbaAddressKeywords = []string{"all", "samehost", "samenet"}
+	hbaHostnamePattern = regexp.MustCompile(`^\.?[a-zA-Z0-9]([a-zA-Z0-9\-.]*[a-zA-Z0-9])?$`)
+)
+
+// HbaEntry represents a single line of the pg_hba.conf file. Lines which are not
+// rules such as comments, blank lines or lines which could not be parsed are
+// preserved as is in Raw and have an empty Type.
+type HbaEntry struct {
+	Type      string
+	Databases []string
+	Users     []string
+	Address   string
+	Mask      string
+	Method    string
+	Options   []string
+	Raw       string
+}
+
+// HbaFile is the parsed representation of a pg_hba.conf file
+type HbaFile struct {
+	Entries []*HbaEntry
+}
+
+// NewHbaEntry returns a rule with a single database and user. The address is
+// ignored for local connections.
+func NewHbaEntry(connType, database, user, address, method string, options ...string) *HbaEntry {
+	entry := &HbaEntry{
+		Type:      connType,
+		Databases: []string{database},
+		Users:     []string{user},
+		Method:    method,
+		Options:   options,
+	}
+	if connType != "local" {
+		entry.Address = address
+	}
+
+	return entry
+}
+
+// ParseHbaEntry parses a single pg_hba.conf rule. Comments and blank lines are
+// returned as non rule entries.
+func ParseHbaEntry(line string) (*HbaEntry, error) {
+	fields := tokenizeHbaLine(line)
+	if len(fields) == 0 {
+		return &HbaEntry{Raw: strings.TrimSpace(line)}, nil
+	}
+
+	entry := &HbaEntry{Type: strings.ToLower(fields[0])}
+	minFields := 4
+	if entry.Type != "local" {
+		minFields = 5
+	}
+	if len(fields) < minFields {
+		return nil, fmt.Errorf("invalid pg_hba.conf entry %q: expected at least %d fields, got %d", line, minFields, len(fields))
+	}
+
+	entry.Databases = strings.Split(fields[1], ",")
+	entry.Users = strings.Split(fields[2], ",")
+	rest := fields[3:]
+	if entry.Type != "local" {
+		entry.Address = rest[0]
+		rest = rest[1:]
+		if net.ParseIP(entry.Address) != nil && len(rest) > 1 && net.ParseIP(rest[0]) != nil {
+			entry.Mask = rest[0]
+			rest = rest[1:]
+		}
+	}
+	entry.Method = strings.ToLower(rest[0])
+	entry.Options = rest[1:]
+
+	return entry, nil
+}
+
+// IsRule returns true if the entry is an authentication rule and not a comment,
+// a blank line or an unparsable line
+func (e *HbaEntry) IsRule() bool {
+	return e.Type != ""
+}
+
+func (e *HbaEntry) String() string {
+	if !e.IsRule() {
+		return e.Raw
+	}
+
+	fields := []string{e.Type, strings.Join(e.Databases, ","), strings.Join(e.Users, ",")}
+	if e.Address != "" {
+		fields = append(fields, e.Address)
+	}
+	if e.Mask != "" {
+		fields = append(fields, e.Mask)
+	}
+	fields = append(fields, e.Method)
+	fields = append(fields, e.Options...)
+
+	return strings.Join(fields, "\t")
+}
+
+// Equal compares two entries ignoring the whitespace used in the file
+func (e *HbaEntry) Equal(other *HbaEntry) bool {
+	return e.String() == other.String()
+}
+
+// Validate checks that the entry is a well formed pg_hba.conf rule
+func (e *HbaEntry) Validate() error {
+	if !e.IsRule() {
+		if e.Raw != "" && !strings.HasPrefix(e.Raw, "#") {
+			return fmt.Errorf("invalid pg_hba.conf entry %q", e.Raw)
+		}
+		return nil
+	}
+
+	if !slices.Contains(hbaConnectionTypes, e.Type) {
+		return fmt.Errorf("invalid connection type %q in entry %q", e.Type, e.String())
+	}
+
+	if len(e.Databases) == 0 || slices.Contains(e.Databases, "") {
+		return fmt.Errorf("missing database in entry %q", e.String())
+	}
+
+	if len(e.Users) == 0 || slices.Contains(e.Users, "") {
+		return fmt.Errorf("missing user in entry %q", e.String())
+	}
+
+	if e.Type == "local" {
+		if e.Address != "" {
+			return fmt.Errorf("address is not allowed for local connection in entry %q", e.String())
+		}
+	} else {
+		err := validateHbaAddress(e.Address, e.Mask)
+		if err != nil {
+			return fmt.Errorf("%w in entry %q", err, e.String())
+		}
+	}
+
+	if !slices.Contains(hbaAuthMethods, e.Method) {
+		return fmt.Errorf("invalid authentication method %q in entry %q", e.Method, e.String())
+	}
+
+	if e.Method == constants.AuthCert && e.Type != "hostssl" {
+		return fmt.Errorf("authentication method %q is only supported for hostssl connections in entry %q", e.Method, e.String())
+	}
+
+	for _, option := range e.Options {
+		if name, value, ok := strings.Cut(option, "="); !ok || name == "" || value == "" {
+			return fmt.Errorf("invalid option %q in entry %q, expected name=value", option, e.String())
+		}
+	}
+
+	return nil
+}
+
+func validateHbaAddress(address, mask string) error {
+	if address == "" {
+		return errors.New("missing address")
+	}
+
+	if mask != "" {
+		if net.ParseIP(address) == nil || net.ParseIP(mask) == nil {
+			return fmt.Errorf("invalid address %q with mask %q", address, mask)
+		}
+		return nil
+	}
+
+	if slices.Contains(hbaAddressKeywords, address) {
+		return nil
+	}
+
+	if strings.Contains(address, "/") {
+		if _, _, err := net.ParseCIDR(address); err != nil {
+			return fmt.Errorf("invalid CIDR address %q", address)
+		}
+		return nil
+	}
+
+	if net.ParseIP(address) != nil || hbaHostnamePattern.MatchString(address) {
+		return nil
+	}
+
+	return fmt.Errorf("invalid address %q", address)
+}
+
+// tokenizeHbaLine splits the line into fields on whitespace, honouring double
+// quotes and ignoring everything after an unquoted #
+func tokenizeHbaLine(line string) []string {
+	var fields []string
+	var current strings.Builder
+	inQuotes, inField := false, false
+
+	for _, r := range line {
+		switch {
+		case r == '"':
+			inQuotes = !inQuotes
+			inField = true
+			current.WriteRune(r)
+		case r == '#' && !inQuotes:
+			if inField {
+				fields = append(fields, current.String())
+			}
+			return fields
+		case (r == ' ' || r == '\t') && !inQuotes:
+			if inField {
+				fields = append(fields, current.String())
+				current.Reset()
+				inField = false
+			}
+		default:
+			inField = true
+			current.WriteRune(r)
+		}
+	}
+
+	if inField {
+		fields = append(fields, current.String())
+	}
+
+	return fields
+}
+
+// ParseHbaFile parses the contents of a pg_hba.conf file. Lines which cannot be
+// parsed are preserved so that they can be reported by Validate.
+func ParseHbaFile(content string) *HbaFile {
+	hba := &HbaFile{}
+	content = strings.TrimSuffix(content, "\n")
+	if content == "" {
+		return hba
+	}
+
+	for _, line := range strings.Split(content, "\n") {
+		entry, err := ParseHbaEntry(line)
+		if err != nil {
+			entry = &HbaEntry{Raw: strings.TrimSpace(line)}
+		}
+		hba.Entries = append(hba.Entries, entry)
+	}
+
+	return hba
+}
+
+// ReadHbaFile reads and parses the pg_hba.conf file of the given data directory
+func ReadHbaFile(pgdata string) (*HbaFile, error) {
+	content, err := utils.System.ReadFile(filepath.Join(pgdata, pgHbaConfFile))
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseHbaFile(string(content)), nil
+}
+
+// Write overwrites the pg_hba.conf file of the given data directory
+func (f *HbaFile) Write(pgdata string) error {
+	return utils.WriteLinesToFile(filepath.Join(pgdata, pgHbaConfFile), f.Lines())
+}
+
+// WriteWithBackup keeps a backup of the existing pg_hba.conf file and then atomically
+// replaces it with the new contents. It returns the path of the backup file, or an
+// empty path when the file already has the same entries and is left as it is.
+func (f *HbaFile) WriteWithBackup(pgdata string) (string, error) {
+	pgHbaFilePath := filepath.Join(pgdata, pgHbaConfFile)
+
+	existing, err := ReadHbaFile(pgdata)
+	if err != nil {
+		return "", fmt.Errorf("reading %s: %w", pgHbaFilePath, err)
+	}
+	if existing.String() == f.String() {
+		return "", nil
+	}
+
+	backupFile, err := utils.BackupFile(pgHbaFilePath)
+	if err != nil {
+		return "", fmt.Errorf("creating backup of %s: %w", pgHbaFilePath, err)
+	}
+
+	err = utils.WriteFileAtomic(pgHbaFilePath, []byte(f.String()+"\n"), 0600)
+	if err != nil {
+		return "", err
+	}
+
+	return backupFile, nil
+}
+
+func (f *HbaFile) Lines() []string {
+	var lines []string
+	for _, entry := range f.Entries {
+		lines = append(lines, entry.String())
+	}
+
+	return lines
+}
+
+func (f *HbaFile) String() string {
+	return strings.Join(f.Lines(), "\n")
+}
+
+// Rules returns only the authentication rules of the file
+func (f *HbaFile) Rules() []*HbaEntry {
+	var rules []*HbaEntry
+	for _, entry := range f.Entries {
+		if entry.IsRule() {
+			rules = append(rules, entry)
+		}
+	}
+
+	return rules
+}
+
+// Index returns the position of the given rule in the file or -1 if not present
+func (f *HbaFile) Index(entry *HbaEntry) int {
+	return slices.IndexFunc(f.Entries, func(e *HbaEntry) bool {
+		return e.IsRule() && e.Equal(entry)
+	})
+}
+
+// Append adds the rules to the end of the file skipping the ones already present
+func (f *HbaFile) Append(entries ...*HbaEntry) {
+	for _, entry := range entries {
+		if f.Index(entry) == -1 {
+			f.Entries = append(f.Entries, entry)
+		}
+	}
+}
+
+// InsertBefore adds the rules before the given existing rule. Since pg_hba.conf
+// is evaluated top to bottom, this gives the new rules a higher precedence.
+func (f *HbaFile) InsertBefore(existing *HbaEntry, entries ...*HbaEntry) error {
+	return f.insertAt(existing, 0, entries)
+}
+
+// InsertAfter adds the rules after the given existing rule
+func (f *HbaFile) InsertAfter(existing *HbaEntry, entries ...*HbaEntry) error {
+	return f.insertAt(existing, 1, entries)
+}
+
+func (f *HbaFile) insertAt(existing *HbaEntry, offset int, entries []*HbaEntry) error {
+	for _, entry := range entries {
+		if f.Index(entry) != -1 {
+			return fmt.Errorf("entry %q already exists", entry.String())
+		}
+	}
+
+	idx := f.Index(existing)
+	if idx == -1 {
+		return fmt.Errorf("entry %q not found", existing.String())
+	}
+
+	f.Entries = slices.Insert(f.Entries, idx+offset, entries...)
+
+	return nil
+}
+
+// Remove deletes the given rule from the file
+func (f *HbaFile) Remove(entry *HbaEntry) error {
+	idx := f.Index(entry)
+	if idx == -1 {
+		return fmt.Errorf("entry %q not found", entry.String())
+	}
+
+	f.Entries = slices.Delete(f.Entries, idx, idx+1)
+
+	return nil
+}
+
+// RemoveDuplicates removes the repeated rules and blank lines keeping the first
+// occurrence. Comments are always preserved.
+func (f *HbaFile) RemoveDuplicates() {
+	var result []*HbaEntry
+	existing := make(map[string]bool)
+
+	for _, entry := range f.Entries {
+		key := entry.String()
+		if entry.IsRule() || key == "" {
+			if existing[key] {
+				continue
+			}
+			existing[key] = true
+		}
+		result = append(result, entry)
+	}
+
+	f.Entries = result
+}
+
+// Validate checks all the entries of the file and returns the combined errors
+// along with their line numbers
+func (f *HbaFile) Validate() error {
+	var err error
+	for idx, entry := range f.Entries {
+		if e := entry.Validate(); e != nil {
+			err = errors.Join(err, fmt.Errorf("line %d: %w", idx+1, e))
+		}
+	}
+
+	return err
+}
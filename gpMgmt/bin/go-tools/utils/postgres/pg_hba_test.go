@@ -0,0 +1,468 @@
+package postgres_test
+
+import (
+	"errors"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/greenplum-db/gp-common-go-libs/testhelper"
+	"github.com/greenplum-db/gpdb/gp/testutils"
+	"github.com/greenplum-db/gpdb/gp/utils"
+	"github.com/greenplum-db/gpdb/gp/utils/postgres"
+)
+
+func TestParseHbaEntry(t *testing.T) {
+	testhelper.SetupTestLogger()
+
+	cases := []struct {
+		line     string
+		expected *postgres.HbaEntry
+	}{
+		{
+			line: "local   all   gpadmin   ident",
+			expected: &postgres.HbaEntry{
+				Type:      "local",
+				Databases: []string{"all"},
+				Users:     []string{"gpadmin"},
+				Method:    "ident",
+				Options:   []string{},
+			},
+		},
+		{
+			line: "host\tdb1,db2\tuser1,+group1\t192.0.1.0/24\tscram-sha-256",
+			expected: &postgres.HbaEntry{
+				Type:      "host",
+				Databases: []string{"db1", "db2"},
+				Users:     []string{"user1", "+group1"},
+				Address:   "192.0.1.0/24",
+				Method:    "scram-sha-256",
+				Options:   []string{},
+			},
+		},
+		{
+			line: "host all all 192.0.1.1 255.255.255.0 MD5 # trailing comment",
+			expected: &postgres.HbaEntry{
+				Type:      "host",
+				Databases: []string{"all"},
+				Users:     []string{"all"},
+				Address:   "192.0.1.1",
+				Mask:      "255.255.255.0",
+				Method:    "md5",
+				Options:   []string{},
+			},
+		},
+		{
+			line: `hostssl "my db" all sdw cert clientcert=verify-full map=certmap`,
+			expected: &postgres.HbaEntry{
+				Type:      "hostssl",
+				Databases: []string{`"my db"`},
+				Users:     []string{"all"},
+				Address:   "sdw",
+				Method:    "cert",
+				Options:   []string{"clientcert=verify-full", "map=certmap"},
+			},
+		},
+		{
+			line:     "  # a comment",
+			expected: &postgres.HbaEntry{Raw: "# a comment"},
+		},
+		{
+			line:     "",
+			expected: &postgres.HbaEntry{},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run("parses the pg_hba.conf entry", func(t *testing.T) {
+			result, err := postgres.ParseHbaEntry(tc.line)
+			if err != nil {
+				t.Fatalf("unexpected error: %#v", err)
+			}
+
+			if !reflect.DeepEqual(result, tc.expected) {
+				t.Fatalf("got %+v, want %+v", result, tc.expected)
+			}
+		})
+	}
+
+	t.Run("errors out when the entry has too few fields", func(t *testing.T) {
+		_, err := postgres.ParseHbaEntry("host all all trust")
+
+		expected := "expected at least 5 fields, got 4"
+		if err == nil || !strings.Contains(err.Error(), expected) {
+			t.Fatalf("got %v, want %s", err, expected)
+		}
+	})
+}
+
+func TestHbaEntryString(t *testing.T) {
+	testhelper.SetupTestLogger()
+
+	cases := []struct {
+		entry    *postgres.HbaEntry
+		expected string
+	}{
+		{
+			entry:    postgres.NewHbaEntry("local", "all", "gpadmin", "sdw", "peer"),
+			expected: "local\tall\tgpadmin\tpeer",
+		},
+		{
+			entry:    postgres.NewHbaEntry("hostssl", "all", "all", "10.0.0.0/8", "cert", "clientcert=verify-full"),
+			expected: "hostssl\tall\tall\t10.0.0.0/8\tcert\tclientcert=verify-full",
+		},
+		{
+			entry: &postgres.HbaEntry{
+				Type:      "host",
+				Databases: []string{"db1", "db2"},
+				Users:     []string{"all"},
+				Address:   "192.0.1.1",
+				Mask:      "255.255.255.0",
+				Method:    "md5",
+			},
+			expected: "host\tdb1,db2\tall\t192.0.1.1\t255.255.255.0\tmd5",
+		},
+		{
+			entry:    &postgres.HbaEntry{Raw: "# comment"},
+			expected: "# comment",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run("returns the entry as a pg_hba.conf line", func(t *testing.T) {
+			result := tc.entry.String()
+			if result != tc.expected {
+				t.Fatalf("got %q, want %q", result, tc.expected)
+			}
+		})
+	}
+}
+
+func TestHbaEntryValidate(t *testing.T) {
+	testhelper.SetupTestLogger()
+
+	t.Run("succeeds for valid entries", func(t *testing.T) {
+		entries := []string{
+			"local all gpadmin ident",
+			"host all gpadmin samehost trust",
+			"host replication gpadmin 192.0.1.0/24 scram-sha-256",
+			"host all all 2001:db8::/32 md5",
+			"host all all 192.0.1.1 255.255.255.0 reject",
+			"hostssl all all .example.com cert clientcert=verify-full",
+			"# comment",
+			"",
+		}
+
+		for _, line := range entries {
+			entry, err := postgres.ParseHbaEntry(line)
+			if err != nil {
+				t.Fatalf("unexpected error: %#v", err)
+			}
+
+			err = entry.Validate()
+			if err != nil {
+				t.Fatalf("unexpected error for %q: %#v", line, err)
+			}
+		}
+	})
+
+	cases := []struct {
+		entry    *postgres.HbaEntry
+		expected string
+	}{
+		{
+			entry:    postgres.NewHbaEntry("hots", "all", "all", "sdw", "trust"),
+			expected: `invalid connection type "hots"`,
+		},
+		{
+			entry:    postgres.NewHbaEntry("host", "", "all", "sdw", "trust"),
+			expected: "missing database",
+		},
+		{
+			entry:    postgres.NewHbaEntry("host", "all", "", "sdw", "trust"),
+			expected: "missing user",
+		},
+		{
+			entry:    &postgres.HbaEntry{Type: "local", Databases: []string{"all"}, Users: []string{"all"}, Address: "sdw", Method: "peer"},
+			expected: "address is not allowed for local connection",
+		},
+		{
+			entry:    postgres.NewHbaEntry("host", "all", "all", "", "trust"),
+			expected: "missing address",
+		},
+		{
+			entry:    postgres.NewHbaEntry("host", "all", "all", "192.0.1.0/33", "trust"),
+			expected: `invalid CIDR address "192.0.1.0/33"`,
+		},
+		{
+			entry:    postgres.NewHbaEntry("host", "all", "all", "sdw_1", "trust"),
+			expected: `invalid address "sdw_1"`,
+		},
+		{
+			entry:    &postgres.HbaEntry{Type: "host", Databases: []string{"all"}, Users: []string{"all"}, Address: "sdw", Mask: "255.0.0.0", Method: "md5"},
+			expected: `invalid address "sdw" with mask "255.0.0.0"`,
+		},
+		{
+			entry:    postgres.NewHbaEntry("host", "all", "all", "sdw", "foo"),
+			expected: `invalid authentication method "foo"`,
+		},
+		{
+			entry:    postgres.NewHbaEntry("host", "all", "all", "sdw", "cert"),
+			expected: `authentication method "cert" is only supported for hostssl connections`,
+		},
+		{
+			entry:    postgres.NewHbaEntry("host", "all", "all", "sdw", "ldap", "ldapserver"),
+			expected: `invalid option "ldapserver"`,
+		},
+		{
+			entry:    &postgres.HbaEntry{Raw: "foobar"},
+			expected: `invalid pg_hba.conf entry "foobar"`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run("errors out for invalid entries", func(t *testing.T) {
+			err := tc.entry.Validate()
+			if err == nil || !strings.Contains(err.Error(), tc.expected) {
+				t.Fatalf("got %v, want %s", err, tc.expected)
+			}
+		})
+	}
+}
+
+func TestHbaFile(t *testing.T) {
+	testhelper.SetupTestLogger()
+
+	content := `# comment
+local	all	gpadmin	ident
+host	all	gpadmin	sdw1	trust
+host	all	gpadmin	sdw2	trust`
+
+	sdw1 := postgres.NewHbaEntry("host", "all", "gpadmin", "sdw1", "trust")
+	sdw2 := postgres.NewHbaEntry("host", "all", "gpadmin", "sdw2", "trust")
+	newEntry := postgres.NewHbaEntry("host", "all", "all", "10.0.0.0/8", "scram-sha-256")
+
+	t.Run("parses the file and returns the rules", func(t *testing.T) {
+		hba := postgres.ParseHbaFile(content)
+
+		if hba.String() != content {
+			t.Fatalf("got %q, want %q", hba.String(), content)
+		}
+
+		rules := hba.Rules()
+		if len(rules) != 3 {
+			t.Fatalf("got %d rules, want 3", len(rules))
+		}
+
+		if hba.Index(sdw2) != 3 {
+			t.Fatalf("got %d, want 3", hba.Index(sdw2))
+		}
+	})
+
+	t.Run("inserts the entries before an existing rule", func(t *testing.T) {
+		hba := postgres.ParseHbaFile(content)
+
+		err := hba.InsertBefore(sdw1, newEntry)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		expected := `# comment
+local	all	gpadmin	ident
+host	all	all	10.0.0.0/8	scram-sha-256
+host	all	gpadmin	sdw1	trust
+host	all	gpadmin	sdw2	trust`
+		if hba.String() != expected {
+			t.Fatalf("got %q, want %q", hba.String(), expected)
+		}
+	})
+
+	t.Run("inserts the entries after an existing rule", func(t *testing.T) {
+		hba := postgres.ParseHbaFile(content)
+
+		err := hba.InsertAfter(sdw1, newEntry)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		expected := `# comment
+local	all	gpadmin	ident
+host	all	gpadmin	sdw1	trust
+host	all	all	10.0.0.0/8	scram-sha-256
+host	all	gpadmin	sdw2	trust`
+		if hba.String() != expected {
+			t.Fatalf("got %q, want %q", hba.String(), expected)
+		}
+	})
+
+	t.Run("errors out when inserting relative to a missing rule", func(t *testing.T) {
+		hba := postgres.ParseHbaFile(content)
+
+		err := hba.InsertAfter(newEntry, sdw1)
+		expected := `entry "host\tall\tgpadmin\tsdw1\ttrust" already exists`
+		if err == nil || err.Error() != expected {
+			t.Fatalf("got %v, want %s", err, expected)
+		}
+
+		err = hba.InsertBefore(newEntry, postgres.NewHbaEntry("host", "all", "all", "sdw3", "md5"))
+		expected = `entry "host\tall\tall\t10.0.0.0/8\tscram-sha-256" not found`
+		if err == nil || err.Error() != expected {
+			t.Fatalf("got %v, want %s", err, expected)
+		}
+	})
+
+	t.Run("removes the rule", func(t *testing.T) {
+		hba := postgres.ParseHbaFile(content)
+
+		err := hba.Remove(postgres.NewHbaEntry("host", "all", "gpadmin", "sdw1", "TRUST"))
+		if err == nil {
+			t.Fatalf("expected error for a rule that differs in method")
+		}
+
+		err = hba.Remove(sdw1)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		expected := `# comment
+local	all	gpadmin	ident
+host	all	gpadmin	sdw2	trust`
+		if hba.String() != expected {
+			t.Fatalf("got %q, want %q", hba.String(), expected)
+		}
+	})
+
+	t.Run("appends only the new rules and removes duplicates", func(t *testing.T) {
+		hba := postgres.ParseHbaFile(content + "\n\n\nhost all  gpadmin sdw2 trust")
+		hba.Append(sdw1, newEntry)
+		hba.RemoveDuplicates()
+
+		expected := `# comment
+local	all	gpadmin	ident
+host	all	gpadmin	sdw1	trust
+host	all	gpadmin	sdw2	trust
+
+host	all	all	10.0.0.0/8	scram-sha-256`
+		if hba.String() != expected {
+			t.Fatalf("got %q, want %q", hba.String(), expected)
+		}
+	})
+
+	t.Run("validates all the entries", func(t *testing.T) {
+		hba := postgres.ParseHbaFile(content + "\nfoobar\nhost all all sdw cert")
+
+		err := hba.Validate()
+		for _, expected := range []string{`line 5: invalid pg_hba.conf entry "foobar"`, `line 6: authentication method "cert"`} {
+			if err == nil || !strings.Contains(err.Error(), expected) {
+				t.Fatalf("got %v, want %s", err, expected)
+			}
+		}
+
+		err = postgres.ParseHbaFile(content).Validate()
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+	})
+
+	t.Run("reads and writes the file", func(t *testing.T) {
+		dname, confPath := createTempConfFile(t, "pg_hba.conf", content, 0644)
+		defer os.RemoveAll(dname)
+
+		hba, err := postgres.ReadHbaFile(dname)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		hba.Append(newEntry)
+		err = hba.Write(dname)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		testutils.AssertFileContents(t, confPath, content+"\nhost\tall\tall\t10.0.0.0/8\tscram-sha-256")
+	})
+
+	t.Run("writes the file keeping a backup", func(t *testing.T) {
+		dname, confPath := createTempConfFile(t, "pg_hba.conf", content, 0600)
+		defer os.RemoveAll(dname)
+
+		hba, err := postgres.ReadHbaFile(dname)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		err = hba.Remove(sdw2)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		backupFile, err := hba.WriteWithBackup(dname)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		testutils.AssertFileContents(t, backupFile, content)
+		testutils.AssertFileContents(t, confPath, strings.TrimSuffix(content, "\nhost\tall\tgpadmin\tsdw2\ttrust"))
+	})
+
+	t.Run("neither writes nor backs up the file when its entries are unchanged", func(t *testing.T) {
+		dname, confPath := createTempConfFile(t, "pg_hba.conf", content, 0600)
+		defer os.RemoveAll(dname)
+
+		hba, err := postgres.ReadHbaFile(dname)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		backupFile, err := hba.WriteWithBackup(dname)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		if backupFile != "" {
+			t.Fatalf("got backup file %s, want none", backupFile)
+		}
+
+		testutils.AssertFileContents(t, confPath, content)
+		files, err := os.ReadDir(dname)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		if len(files) != 1 {
+			t.Fatalf("got %d files, want only pg_hba.conf", len(files))
+		}
+	})
+
+	t.Run("does not modify the file when not able to create the backup", func(t *testing.T) {
+		dname, confPath := createTempConfFile(t, "pg_hba.conf", content, 0600)
+		defer os.RemoveAll(dname)
+
+		hba := postgres.ParseHbaFile("")
+
+		expectedErr := errors.New("error")
+		utils.System.ReadFile = func(name string) ([]byte, error) {
+			return nil, expectedErr
+		}
+		defer utils.ResetSystemFunctions()
+
+		_, err := hba.WriteWithBackup(dname)
+		if !errors.Is(err, expectedErr) {
+			t.Fatalf("got %#v, want %#v", err, expectedErr)
+		}
+
+		testutils.AssertFileContents(t, confPath, content)
+	})
+
+	t.Run("errors out when not able to read the file", func(t *testing.T) {
+		expectedErr := errors.New("error")
+		utils.System.ReadFile = func(name string) ([]byte, error) {
+			return nil, expectedErr
+		}
+		defer utils.ResetSystemFunctions()
+
+		_, err := postgres.ReadHbaFile("gpseg")
+		if !errors.Is(err, expectedErr) {
+			t.Fatalf("got %#v, want %#v", err, expectedErr)
+		}
+	})
+}
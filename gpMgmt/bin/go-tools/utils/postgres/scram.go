@@ -0,0 +1,136 @@
+package postgres
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"regexp"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/text/unicode/norm"
+)
+
+// The tables of saslprep are generated from the text of RFC 3454
+//go:generate go run gen_saslprep_tables.go
+
+const (
+	scramIterations = 4096
+	scramSaltLength = 16
+)
+
+var scramVerifierRegex = regexp.MustCompile(`^SCRAM-SHA-256\$[0-9]+:[A-Za-z0-9+/=]+\$[A-Za-z0-9+/=]+:[A-Za-z0-9+/=]+$`)
+
+/*
+ScramSha256Verifier returns the SCRAM-SHA-256 verifier of the password, with a
+random salt, in the format stored by the server in pg_authid. The verifier can
+be given to ALTER ROLE ... PASSWORD in place of the password, which is then
+never known to the server.
+*/
+func ScramSha256Verifier(password string) (string, error) {
+	salt := make([]byte, scramSaltLength)
+	_, err := rand.Read(salt)
+	if err != nil {
+		return "", fmt.Errorf("could not generate the password salt: %w", err)
+	}
+
+	return ScramSha256VerifierWithSalt(password, salt, scramIterations), nil
+}
+
+// ScramSha256VerifierWithSalt returns the SCRAM-SHA-256 verifier of the password
+// for the given salt and iteration count
+func ScramSha256VerifierWithSalt(password string, salt []byte, iterations int) string {
+	// Like the server, use the password as is when it cannot be normalized
+	// with SASLprep
+	normalized, ok := saslprep(password)
+	if !ok {
+		normalized = password
+	}
+
+	saltedPassword := pbkdf2.Key([]byte(normalized), salt, iterations, sha256.Size, sha256.New)
+	clientKey := hmacSha256(saltedPassword, "Client Key")
+	storedKey := sha256.Sum256(clientKey)
+	serverKey := hmacSha256(saltedPassword, "Server Key")
+
+	encode := base64.StdEncoding.EncodeToString
+	return fmt.Sprintf("SCRAM-SHA-256$%d:%s$%s:%s", iterations, encode(salt), encode(storedKey[:]), encode(serverKey))
+}
+
+// IsScramSha256Verifier checks whether the value is a SCRAM-SHA-256 verifier
+// rather than a password
+func IsScramSha256Verifier(value string) bool {
+	return scramVerifierRegex.MatchString(value)
+}
+
+/*
+saslprep normalizes the password with SASLprep (RFC 4013) like pg_saslprep of
+the server, which differs from the stringprep profile on a few points: the
+ASCII passwords are left as they are, and the unassigned code points are
+prohibited. It returns false when the password is not valid UTF-8 or contains
+prohibited characters, in which case the server uses it as is.
+*/
+func saslprep(password string) (string, bool) {
+	ascii := true
+	for i := 0; i < len(password); i++ {
+		if password[i] >= utf8.RuneSelf {
+			ascii = false
+			break
+		}
+	}
+	if ascii {
+		return password, true
+	}
+	if !utf8.ValidString(password) {
+		return "", false
+	}
+
+	// map the non-ASCII spaces to a space, and drop the characters mapped to
+	// nothing
+	mapped := make([]rune, 0, len(password))
+	for _, r := range password {
+		switch {
+		case unicode.Is(nonASCIISpace, r):
+			mapped = append(mapped, ' ')
+		case unicode.Is(mappedToNothing, r):
+		default:
+			mapped = append(mapped, r)
+		}
+	}
+	if len(mapped) == 0 {
+		return "", false
+	}
+
+	output := []rune(norm.NFKC.String(string(mapped)))
+	randAL := false
+	for _, r := range output {
+		if unicode.Is(prohibitedOutput, r) || unicode.Is(unassigned, r) {
+			return "", false
+		}
+		randAL = randAL || unicode.Is(randALCat, r)
+	}
+
+	// a string with right-to-left characters must not contain left-to-right
+	// ones, and must start and end with a right-to-left character
+	if randAL {
+		for _, r := range output {
+			if unicode.Is(lCat, r) {
+				return "", false
+			}
+		}
+		if !unicode.Is(randALCat, output[0]) || !unicode.Is(randALCat, output[len(output)-1]) {
+			return "", false
+		}
+	}
+
+	return string(output), true
+}
+
+func hmacSha256(key []byte, message string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(message))
+
+	return mac.Sum(nil)
+}
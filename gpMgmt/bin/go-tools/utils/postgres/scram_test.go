@@ -0,0 +1,97 @@
+package postgres_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/greenplum-db/gpdb/gp/utils/postgres"
+)
+
+func TestScramSha256Verifier(t *testing.T) {
+	t.Run("returns the verifier stored by the server", func(t *testing.T) {
+		expected := "SCRAM-SHA-256$4096:MDEyMzQ1Njc4OWFiY2RlZg==$bpSY5Ze9NUH+I35LC3gVq+DpBfK46iXBxvhAKqVu9pE=:VpYlBuxyzeCI1KnctrefdljpB1mk3Gp7sBI/t11+NkQ="
+
+		result := postgres.ScramSha256VerifierWithSalt("secret", []byte("0123456789abcdef"), 4096)
+		if result != expected {
+			t.Fatalf("got %q, want %q", result, expected)
+		}
+	})
+
+	t.Run("normalizes the password", func(t *testing.T) {
+		salt := []byte("0123456789abcdef")
+
+		result := postgres.ScramSha256VerifierWithSalt("a\u00a0b", salt, 4096)
+		expected := postgres.ScramSha256VerifierWithSalt("a b", salt, 4096)
+		if result != expected {
+			t.Fatalf("got %q, want %q", result, expected)
+		}
+	})
+
+	t.Run("normalizes the compatibility characters like the server", func(t *testing.T) {
+		salt := []byte("0123456789abcdef")
+
+		for password, normalized := range map[string]string{
+			"\ufb01re":        "fire", // the ligature fi
+			"\u2168":          "IX",   // the roman numeral nine
+			"a\u00adb\u200dc": "abc",  // the characters mapped to nothing
+		} {
+			result := postgres.ScramSha256VerifierWithSalt(password, salt, 4096)
+			expected := postgres.ScramSha256VerifierWithSalt(normalized, salt, 4096)
+			if result != expected {
+				t.Fatalf("got %q for %q, want the verifier of %q", result, password, normalized)
+			}
+		}
+	})
+
+	t.Run("uses the password as is when it contains prohibited characters", func(t *testing.T) {
+		salt := []byte("0123456789abcdef")
+
+		for _, password := range []string{
+			"\ufb01\ue000",  // a private use character
+			"\ufb01\u0221",  // a code point unassigned in Unicode 3.2
+			"\u05d0\ufb01a", // mixed right-to-left and left-to-right characters
+		} {
+			result := postgres.ScramSha256VerifierWithSalt(password, salt, 4096)
+			normalized := postgres.ScramSha256VerifierWithSalt(strings.ReplaceAll(password, "\ufb01", "fi"), salt, 4096)
+			if result == normalized {
+				t.Fatalf("expected %q not to be normalized", password)
+			}
+		}
+	})
+
+	t.Run("uses a random salt", func(t *testing.T) {
+		first, err := postgres.ScramSha256Verifier("secret")
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		second, err := postgres.ScramSha256Verifier("secret")
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		if first == second {
+			t.Fatalf("expected different verifiers, got %q twice", first)
+		}
+		if !strings.HasPrefix(first, "SCRAM-SHA-256$4096:") || strings.Contains(first, "secret") {
+			t.Fatalf("unexpected verifier %q", first)
+		}
+		if !postgres.IsScramSha256Verifier(first) {
+			t.Fatalf("expected %q to be a verifier", first)
+		}
+	})
+}
+
+func TestIsScramSha256Verifier(t *testing.T) {
+	cases := map[string]bool{
+		"SCRAM-SHA-256$4096:MDEyMzQ1Njc4OWFiY2RlZg==$bpSY5Ze9NUH+I35LC3gVq+DpBfK46iXBxvhAKqVu9pE=:VpYlBuxyzeCI1KnctrefdljpB1mk3Gp7sBI/t11+NkQ=": true,
+		"md5a3556571e93b0d20722ba62be61e8c2d": false,
+		"secret":                              false,
+		"SCRAM-SHA-256$secret":                false,
+	}
+
+	for value, expected := range cases {
+		if result := postgres.IsScramSha256Verifier(value); result != expected {
+			t.Errorf("got %t for %q, want %t", result, value, expected)
+		}
+	}
+}
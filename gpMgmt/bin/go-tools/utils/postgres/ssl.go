@@ -0,0 +1,62 @@
+package postgres
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/greenplum-db/gpdb/gp/utils"
+)
+
+// The SSL files are stored in the data directory, so that the ssl parameters in
+// postgresql.conf can refer to them relative to it
+const (
+	SslCertFile = "server.crt"
+	SslKeyFile  = "server.key"
+	SslCaFile   = "root.crt"
+)
+
+// SslConfigParams returns the postgresql.conf parameters that enable SSL using
+// the files written by WriteSslFiles
+func SslConfigParams(withCa bool) map[string]string {
+	params := map[string]string{
+		"ssl":           "on",
+		"ssl_cert_file": SslCertFile,
+		"ssl_key_file":  SslKeyFile,
+	}
+	if withCa {
+		params["ssl_ca_file"] = SslCaFile
+	}
+
+	return params
+}
+
+// WriteSslFiles writes the server certificate, private key and optionally the CA
+// certificate to the data directory. The private key is only readable by the
+// owner as required by postgres.
+func WriteSslFiles(pgdata string, cert, key, ca []byte) error {
+	err := utils.WriteFileAtomic(filepath.Join(pgdata, SslCertFile), cert, 0644)
+	if err != nil {
+		return err
+	}
+
+	// An existing key file keeps its permissions when replaced, so enforce them
+	keyFile := filepath.Join(pgdata, SslKeyFile)
+	err = utils.WriteFileAtomic(keyFile, key, 0600)
+	if err != nil {
+		return err
+	}
+
+	err = os.Chmod(keyFile, 0600)
+	if err != nil {
+		return err
+	}
+
+	if len(ca) > 0 {
+		err = utils.WriteFileAtomic(filepath.Join(pgdata, SslCaFile), ca, 0644)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
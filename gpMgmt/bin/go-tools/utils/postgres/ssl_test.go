@@ -0,0 +1,65 @@
+package postgres_test
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/greenplum-db/gpdb/gp/testutils"
+	"github.com/greenplum-db/gpdb/gp/utils/postgres"
+)
+
+func TestSslConfigParams(t *testing.T) {
+	t.Run("returns the parameters without the CA", func(t *testing.T) {
+		expected := map[string]string{
+			"ssl":           "on",
+			"ssl_cert_file": "server.crt",
+			"ssl_key_file":  "server.key",
+		}
+
+		result := postgres.SslConfigParams(false)
+		if !reflect.DeepEqual(result, expected) {
+			t.Fatalf("got %+v, want %+v", result, expected)
+		}
+	})
+
+	t.Run("returns the parameters with the CA", func(t *testing.T) {
+		result := postgres.SslConfigParams(true)
+		if result["ssl_ca_file"] != "root.crt" {
+			t.Fatalf("got %+v, want ssl_ca_file root.crt", result)
+		}
+	})
+}
+
+func TestWriteSslFiles(t *testing.T) {
+	t.Run("writes the files restricting the key permissions", func(t *testing.T) {
+		pgdata := t.TempDir()
+		keyFile := filepath.Join(pgdata, "server.key")
+
+		err := os.WriteFile(keyFile, []byte("old key"), 0644)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		err = postgres.WriteSslFiles(pgdata, []byte("cert"), []byte("key"), nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		testutils.AssertFileContents(t, filepath.Join(pgdata, "server.crt"), "cert")
+		testutils.AssertFileContents(t, keyFile, "key")
+
+		info, err := os.Stat(keyFile)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		if info.Mode().Perm() != 0600 {
+			t.Fatalf("got %o, want %o", info.Mode().Perm(), 0600)
+		}
+
+		if _, err := os.Stat(filepath.Join(pgdata, "root.crt")); !os.IsNotExist(err) {
+			t.Fatalf("expected the CA to not be written, got %v", err)
+		}
+	})
+}
@@ -1,53 +1,75 @@
 package postgres
 
 import (
-	"bufio"
-	"fmt"
-	"path/filepath"
 	"strings"
 
 	"github.com/greenplum-db/gp-common-go-libs/gplog"
+	"github.com/greenplum-db/gpdb/gp/constants"
 	"github.com/greenplum-db/gpdb/gp/utils"
 )
 
 const pgHbaConfFile = "pg_hba.conf"
 
-func BuildCoordinatorPgHbaConf(pgdata string, addrs []string) error {
-	pgHbaFilePath := filepath.Join(pgdata, pgHbaConfFile)
+/*
+HbaAuth describes how the generated rules authenticate the connections. When
+HostSsl is set the rules only match connections made over SSL. The replication
+rules use ReplicationMethod, which defaults to trust: pg_basebackup and the
+walreceiver of the mirrors connect without a password, so the other methods
+need the mirror hosts to provide the credentials, e.g. in ~/.pgpass or as a
+client certificate in ~/.postgresql.
+*/
+type HbaAuth struct {
+	Method            string
+	ReplicationMethod string
+	HostSsl           bool
+}
+
+// HbaUserAccess describes the rules which allow all users to connect to the
+// coordinator from the given addresses using the given authentication
+type HbaUserAccess struct {
+	Addrs []string
+	Auth  HbaAuth
+}
 
-	file, err := utils.System.Open(pgHbaFilePath)
+// BuildCoordinatorPgHbaConf rewrites the coordinator pg_hba.conf retaining only
+// the comments. Access for the current user is allowed from the coordinator host
+// itself, followed by the user access rules if any.
+func BuildCoordinatorPgHbaConf(pgdata string, addrs []string, userAccess HbaUserAccess) error {
+	hba, err := ReadHbaFile(pgdata)
 	if err != nil {
 		return err
 	}
-	defer file.Close()
-
-	updatedLines := []string{}
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		line := scanner.Text()
 
-		if strings.HasPrefix(line, "#") {
-			updatedLines = append(updatedLines, line)
+	var entries []*HbaEntry
+	for _, entry := range hba.Entries {
+		if strings.HasPrefix(entry.Raw, "#") {
+			entries = append(entries, entry)
 		}
 	}
+	hba.Entries = entries
 
 	user, err := utils.System.CurrentUser()
 	if err != nil {
 		return err
 	}
 
-	localAccessString := "local\t%s\t%s\tident"
-
 	// Add local access entries
-	line := fmt.Sprintf(localAccessString, "all", user.Username)
-	updatedLines = append(updatedLines, line)
-	line = fmt.Sprintf(localAccessString, "replication", user.Username)
-	updatedLines = append(updatedLines, line)
+	hba.Append(
+		NewHbaEntry("local", "all", user.Username, "", "ident"),
+		NewHbaEntry("local", "replication", user.Username, "", "ident"),
+	)
+
+	// The coordinator host entries only allow the current user from the coordinator
+	// itself, so they are always trusted to let the hub bootstrap the cluster.
+	hba.Append(createPgHbaEntries(append([]string{"localhost"}, addrs...), user.Username, true, HbaAuth{Method: constants.AuthTrust})...)
+	hba.Append(createPgHbaEntries(userAccess.Addrs, "all", false, userAccess.Auth)...)
 
-	entries := createPgHbaEntries(append([]string{"localhost"}, addrs...), user.Username, true)
-	updatedLines = append(updatedLines, entries...)
+	err = hba.Validate()
+	if err != nil {
+		return err
+	}
 
-	err = utils.WriteLinesToFile(pgHbaFilePath, updatedLines)
+	err = hba.Write(pgdata)
 	if err != nil {
 		return err
 	}
@@ -56,12 +78,15 @@ func BuildCoordinatorPgHbaConf(pgdata string, addrs []string) error {
 	return nil
 }
 
-func UpdateSegmentPgHbaConf(pgdata string, addrs []string, replication bool, coordinatorAddrs ...string) error {
+// UpdateSegmentPgHbaConf appends the rules for the given addresses to the segment
+// pg_hba.conf using the authentication provided. The coordinator addresses, if any,
+// are allowed access for all users.
+func UpdateSegmentPgHbaConf(pgdata string, addrs []string, replication bool, auth HbaAuth, coordinatorAddrs ...string) error {
 	gplog.Info("Starting to update %s for data directory %s", pgHbaConfFile, pgdata)
-	var entries []string
+	var entries []*HbaEntry
 
 	if len(coordinatorAddrs) > 0 {
-		entries = append(entries, createPgHbaEntries(coordinatorAddrs, "all", false)...)
+		entries = append(entries, createPgHbaEntries(coordinatorAddrs, "all", false, auth)...)
 	}
 
 	user, err := utils.System.CurrentUser()
@@ -69,7 +94,7 @@ func UpdateSegmentPgHbaConf(pgdata string, addrs []string, replication bool, coo
 		return err
 	}
 
-	entries = append(entries, createPgHbaEntries(addrs, user.Username, replication)...)
+	entries = append(entries, createPgHbaEntries(addrs, user.Username, replication, auth)...)
 	err = appendPgHbaEntries(pgdata, entries)
 	if err != nil {
 		return err
@@ -79,19 +104,23 @@ func UpdateSegmentPgHbaConf(pgdata string, addrs []string, replication bool, coo
 	return nil
 }
 
-func appendPgHbaEntries(pgdata string, entries []string) error {
-	pgHbaConfFilePath := filepath.Join(pgdata, pgHbaConfFile)
-
-	content, err := utils.System.ReadFile(pgHbaConfFilePath)
+func appendPgHbaEntries(pgdata string, entries []*HbaEntry) error {
+	hba, err := ReadHbaFile(pgdata)
 	if err != nil {
 		return err
 	}
 
-	lines := strings.Split(string(content), "\n")
-	lines = append(lines, entries...)
-	lines = removeDuplicates(lines)
+	for _, entry := range entries {
+		err = entry.Validate()
+		if err != nil {
+			return err
+		}
+	}
+
+	hba.Append(entries...)
+	hba.RemoveDuplicates()
 
-	err = utils.WriteLinesToFile(pgHbaConfFilePath, lines)
+	err = hba.Write(pgdata)
 	if err != nil {
 		return err
 	}
@@ -99,47 +128,38 @@ func appendPgHbaEntries(pgdata string, entries []string) error {
 	return nil
 }
 
-func createPgHbaEntries(addrs []string, username string, replication bool) []string {
-	var entries []string
-	entryString := "host\t%s\t%s\t%s\ttrust"
+func createPgHbaEntries(addrs []string, username string, replication bool, auth HbaAuth) []*HbaEntry {
+	var entries []*HbaEntry
+	authMethod := auth.Method
+	if authMethod == "" {
+		authMethod = constants.AuthTrust
+	}
+
+	connType := "host"
+	if auth.HostSsl || authMethod == constants.AuthCert {
+		connType = "hostssl"
+	}
 
 	for _, addr := range addrs {
-		entries = append(entries, fmt.Sprintf(entryString, "all", username, addr))
+		entries = append(entries, NewHbaEntry(connType, "all", username, addr, authMethod))
 	}
 
+	// The replication rules are trusted unless another method is chosen, see
+	// HbaAuth
 	if replication {
-		addrs = append([]string{"samehost"}, addrs...)
-		for _, addr := range addrs {
-			entries = append(entries, fmt.Sprintf(entryString, "replication", username, addr))
+		replicationMethod := auth.ReplicationMethod
+		if replicationMethod == "" {
+			replicationMethod = constants.AuthTrust
 		}
-	}
-
-	return entries
-}
-
-func reFormatEntry(line string) string {
-	return strings.Join(strings.Fields(line), "\t")
-}
-
-func removeDuplicates(entries []string) []string {
-	var result []string
-	existingEntries := make(map[string]bool)
-
-	for _, entry := range entries {
-		entry = strings.TrimSpace(entry)
-
-		if strings.HasPrefix(entry, "#") {
-			existingEntries[entry] = true
-			result = append(result, entry)
-			continue
+		if replicationMethod == constants.AuthCert {
+			connType = "hostssl"
 		}
 
-		entry = reFormatEntry(entry)
-		if _, ok := existingEntries[entry]; !ok {
-			existingEntries[entry] = true
-			result = append(result, entry)
+		addrs = append([]string{"samehost"}, addrs...)
+		for _, addr := range addrs {
+			entries = append(entries, NewHbaEntry(connType, "replication", username, addr, replicationMethod))
 		}
 	}
 
-	return result
+	return entries
 }
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"os"
 	"os/user"
+	"strings"
 	"testing"
 
 	"github.com/greenplum-db/gp-common-go-libs/testhelper"
@@ -19,6 +20,9 @@ func TestBuildPgHbaConf(t *testing.T) {
 		coordinator      bool
 		coordinatorAddrs []string
 		addrs            []string
+		replication      bool
+		auth             postgres.HbaAuth
+		userAccess       postgres.HbaUserAccess
 		confContent      string
 		expected         string
 	}{
@@ -108,6 +112,91 @@ host	all	gpadmin	sdw	trust`,
 host	all	all	cdw	trust
 host	all	gpadmin	sdw	trust`,
 		},
+		{ // drops the existing rules and adds the user access entries
+			coordinator: true,
+			addrs:       []string{"cdw"},
+			userAccess: postgres.HbaUserAccess{
+				Addrs: []string{"10.0.0.0/8", "client.example.com"},
+				Auth:  postgres.HbaAuth{Method: "scram-sha-256"},
+			},
+			confContent: `# TYPE  DATABASE  USER  ADDRESS  METHOD
+local all all trust
+host all all 127.0.0.1/32 trust`,
+			expected: `# TYPE  DATABASE  USER  ADDRESS  METHOD
+local	all	gpadmin	ident
+local	replication	gpadmin	ident
+host	all	gpadmin	localhost	trust
+host	all	gpadmin	cdw	trust
+host	replication	gpadmin	samehost	trust
+host	replication	gpadmin	localhost	trust
+host	replication	gpadmin	cdw	trust
+host	all	all	10.0.0.0/8	scram-sha-256
+host	all	all	client.example.com	scram-sha-256`,
+		},
+		{
+			coordinator:      false,
+			coordinatorAddrs: []string{"cdw"},
+			addrs:            []string{"sdw"},
+			auth:             postgres.HbaAuth{Method: "scram-sha-256"},
+			confContent:      `host	all	all	cdw	trust`,
+			expected: `host	all	all	cdw	trust
+host	all	all	cdw	scram-sha-256
+host	all	gpadmin	sdw	scram-sha-256`,
+		},
+		{
+			coordinator:      false,
+			coordinatorAddrs: []string{"cdw"},
+			addrs:            []string{"sdw"},
+			auth:             postgres.HbaAuth{Method: "cert"},
+			expected: `hostssl	all	all	cdw	cert
+hostssl	all	gpadmin	sdw	cert`,
+		},
+		{
+			coordinator:      false,
+			coordinatorAddrs: []string{"cdw"},
+			addrs:            []string{"sdw"},
+			auth:             postgres.HbaAuth{Method: "md5", HostSsl: true},
+			expected: `hostssl	all	all	cdw	md5
+hostssl	all	gpadmin	sdw	md5`,
+		},
+		{ // trusts the replication connections of the mirrors by default, which carry no credentials
+			coordinator:      false,
+			coordinatorAddrs: []string{"cdw"},
+			addrs:            []string{"sdw"},
+			replication:      true,
+			auth:             postgres.HbaAuth{Method: "scram-sha-256"},
+			expected: `host	all	all	cdw	scram-sha-256
+host	all	gpadmin	sdw	scram-sha-256
+host	replication	gpadmin	samehost	trust
+host	replication	gpadmin	sdw	trust`,
+		},
+		{ // uses the replication method chosen for the replication connections
+			coordinator:      false,
+			coordinatorAddrs: []string{"cdw"},
+			addrs:            []string{"sdw"},
+			replication:      true,
+			auth:             postgres.HbaAuth{Method: "scram-sha-256", ReplicationMethod: "cert"},
+			expected: `host	all	all	cdw	scram-sha-256
+host	all	gpadmin	sdw	scram-sha-256
+hostssl	replication	gpadmin	samehost	cert
+hostssl	replication	gpadmin	sdw	cert`,
+		},
+		{ // only the user access entries require SSL on the coordinator
+			coordinator: true,
+			addrs:       []string{"cdw"},
+			userAccess: postgres.HbaUserAccess{
+				Addrs: []string{"10.0.0.0/8"},
+				Auth:  postgres.HbaAuth{Method: "scram-sha-256", HostSsl: true},
+			},
+			expected: `local	all	gpadmin	ident
+local	replication	gpadmin	ident
+host	all	gpadmin	localhost	trust
+host	all	gpadmin	cdw	trust
+host	replication	gpadmin	samehost	trust
+host	replication	gpadmin	localhost	trust
+host	replication	gpadmin	cdw	trust
+hostssl	all	all	10.0.0.0/8	scram-sha-256`,
+		},
 	}
 
 	for _, tc := range cases {
@@ -122,9 +211,9 @@ host	all	gpadmin	sdw	trust`,
 
 			var err error
 			if tc.coordinator {
-				err = postgres.BuildCoordinatorPgHbaConf(dname, tc.addrs)
+				err = postgres.BuildCoordinatorPgHbaConf(dname, tc.addrs, tc.userAccess)
 			} else {
-				err = postgres.UpdateSegmentPgHbaConf(dname, tc.addrs, false, tc.coordinatorAddrs...)
+				err = postgres.UpdateSegmentPgHbaConf(dname, tc.addrs, tc.replication, tc.auth, tc.coordinatorAddrs...)
 			}
 
 			if err != nil {
@@ -146,6 +235,43 @@ host	all	gpadmin	sdw	trust`,
 		},
 	}
 
+	t.Run("errors out when the user access entries are not valid", func(t *testing.T) {
+		dname, _ := createTempConfFile(t, "pg_hba.conf", "", 0644)
+		defer os.RemoveAll(dname)
+
+		utils.System.CurrentUser = func() (*user.User, error) {
+			return &user.User{Username: "gpadmin"}, nil
+		}
+		defer utils.ResetSystemFunctions()
+
+		err := postgres.BuildCoordinatorPgHbaConf(dname, []string{"cdw"}, postgres.HbaUserAccess{
+			Addrs: []string{"10.0.0.0/33"},
+			Auth:  postgres.HbaAuth{Method: "md5"},
+		})
+
+		expected := `invalid CIDR address "10.0.0.0/33"`
+		if err == nil || !strings.Contains(err.Error(), expected) {
+			t.Fatalf("got %v, want %s", err, expected)
+		}
+	})
+
+	t.Run("errors out when the authentication method is not valid", func(t *testing.T) {
+		dname, _ := createTempConfFile(t, "pg_hba.conf", "", 0644)
+		defer os.RemoveAll(dname)
+
+		utils.System.CurrentUser = func() (*user.User, error) {
+			return &user.User{Username: "gpadmin"}, nil
+		}
+		defer utils.ResetSystemFunctions()
+
+		err := postgres.UpdateSegmentPgHbaConf(dname, []string{"sdw"}, false, postgres.HbaAuth{Method: "unknown"})
+
+		expected := `invalid authentication method "unknown"`
+		if err == nil || !strings.Contains(err.Error(), expected) {
+			t.Fatalf("got %v, want %s", err, expected)
+		}
+	})
+
 	for _, tc := range failureCases {
 		t.Run("errors out when there is no file present", func(t *testing.T) {
 			dname, _ := createTempConfFile(t, "", "", 0644)
@@ -154,9 +280,9 @@ host	all	gpadmin	sdw	trust`,
 			var err error
 			expectedErr := os.ErrNotExist
 			if tc.coordinator {
-				err = postgres.BuildCoordinatorPgHbaConf(dname, []string{"cdw"})
+				err = postgres.BuildCoordinatorPgHbaConf(dname, []string{"cdw"}, postgres.HbaUserAccess{})
 			} else {
-				err = postgres.UpdateSegmentPgHbaConf(dname, []string{"sdw"}, false)
+				err = postgres.UpdateSegmentPgHbaConf(dname, []string{"sdw"}, false, postgres.HbaAuth{})
 			}
 
 			if !errors.Is(err, expectedErr) {
@@ -176,9 +302,9 @@ host	all	gpadmin	sdw	trust`,
 
 			var err error
 			if tc.coordinator {
-				err = postgres.BuildCoordinatorPgHbaConf(dname, []string{"cdw"})
+				err = postgres.BuildCoordinatorPgHbaConf(dname, []string{"cdw"}, postgres.HbaUserAccess{})
 			} else {
-				err = postgres.UpdateSegmentPgHbaConf(dname, []string{"sdw"}, false)
+				err = postgres.UpdateSegmentPgHbaConf(dname, []string{"sdw"}, false, postgres.HbaAuth{})
 			}
 
 			if !errors.Is(err, expectedErr) {
@@ -198,9 +324,9 @@ host	all	gpadmin	sdw	trust`,
 
 			var err error
 			if tc.coordinator {
-				err = postgres.BuildCoordinatorPgHbaConf(dname, []string{"cdw"})
+				err = postgres.BuildCoordinatorPgHbaConf(dname, []string{"cdw"}, postgres.HbaUserAccess{})
 			} else {
-				err = postgres.UpdateSegmentPgHbaConf(dname, []string{"sdw"}, false)
+				err = postgres.UpdateSegmentPgHbaConf(dname, []string{"sdw"}, false, postgres.HbaAuth{})
 			}
 
 			if !errors.Is(err, expectedErr) {
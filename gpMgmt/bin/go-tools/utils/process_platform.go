@@ -0,0 +1,436 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/greenplum-db/gp-common-go-libs/gplog"
+	"github.com/greenplum-db/gpdb/gp/constants"
+	"github.com/greenplum-db/gpdb/gp/idl"
+)
+
+var (
+	DaemonCommand      = exec.Command
+	daemonStartTimeout = 10 * time.Second
+	serviceStopTimeout = 30 * time.Second
+)
+
+// Layout of the start time in the status of the services, the same as systemd
+const serviceTimeLayout = "Mon 2006-01-02 15:04:05 MST"
+
+/*
+ProcessPlatform runs the hub and agents as plain processes tracked with pid
+files, for the hosts where neither systemd nor launchd can be used. The
+processes either daemonize themselves or run in the foreground under an
+external supervisor. The pid files and the output of the processes are kept in
+the log directory. A service holds a lock on its pid file while it runs, so that
+a pid file left by a service which exited, whose pid may have been reused by
+another process, is known to be stale.
+*/
+type ProcessPlatform struct {
+	OS         string
+	GpHome     string
+	LogDir     string
+	ConfigFile string // see ConfigFileArgs
+}
+
+// SetPlatform replaces the platform returned by GetPlatform
+func SetPlatform(p Platform) {
+	platform = p
+}
+
+// PidFile returns the path of the pid file of the service, e.g. gp_hub
+func (p ProcessPlatform) PidFile(serviceName string) string {
+	return filepath.Join(p.LogDir, serviceName+".pid")
+}
+
+// LogFile returns the path of the file the output of the service is appended to
+func (p ProcessPlatform) LogFile(serviceName string) string {
+	return filepath.Join(p.LogDir, serviceName+".log")
+}
+
+// CreateServiceDir creates the log directory holding the pid files on all
+// hosts, as there are no service files
+func (p ProcessPlatform) CreateServiceDir(hostnames []string, serviceDir string, gpHome string) error {
+	hostList := make([]string, 0)
+	for _, host := range hostnames {
+		hostList = append(hostList, "-h", host)
+	}
+
+	args := append(hostList, "mkdir", "-p", p.LogDir)
+	utility := filepath.Join(gpHome, "bin", constants.GpSSH)
+	err := execCommand(utility, args...).Run()
+	if err != nil {
+		return fmt.Errorf("could not create log directory %s on hosts: %w", p.LogDir, err)
+	}
+
+	gplog.Info("Created log directory %s on all hosts", p.LogDir)
+	return nil
+}
+
+// GenerateServiceFileContents returns the command starting the process, as
+// there are no service files
+func (p ProcessPlatform) GenerateServiceFileContents(process string, gpHome string, serviceName string) string {
+	args := append([]string{filepath.Join(gpHome, "bin", "gp"), process}, ConfigFileArgs(gpHome, p.ConfigFile)...)
+
+	return strings.Join(append(args, "--daemonize"), " ")
+}
+
+func (p ProcessPlatform) GetDefaultServiceDir() string {
+	return ""
+}
+
+func (p ProcessPlatform) ReloadHubService(servicePath string) error {
+	return nil
+}
+
+func (p ProcessPlatform) ReloadAgentService(gpHome string, hostList []string, servicePath string) error {
+	return nil
+}
+
+func (p ProcessPlatform) CreateAndInstallHubServiceFile(gpHome string, serviceDir string, serviceName string) error {
+	gplog.Info("The hub runs without a service manager, with its pid file %s", p.PidFile(serviceName+"_hub"))
+	return nil
+}
+
+func (p ProcessPlatform) CreateAndInstallAgentServiceFile(hostnames []string, gpHome string, serviceDir string, serviceName string) error {
+	gplog.Info("The agents run without a service manager, with their pid file %s", p.PidFile(serviceName+"_agent"))
+	return nil
+}
+
+// GPHOME is set so that the services find gp.conf, and the configuration file
+// is passed when it is another one, as with the service files
+func (p ProcessPlatform) GetStartHubCommand(serviceName string) *exec.Cmd {
+	args := append([]string{"hub"}, ConfigFileArgs(p.GpHome, p.ConfigFile)...)
+	cmd := exec.Command(filepath.Join(p.GpHome, "bin", "gp"), append(args, "--daemonize")...)
+	cmd.Env = append(os.Environ(), "GPHOME="+p.GpHome)
+
+	return cmd
+}
+
+func (p ProcessPlatform) GetStartAgentCommandString(serviceName string) []string {
+	args := []string{"GPHOME=" + p.GpHome, filepath.Join(p.GpHome, "bin", "gp"), "agent"}
+	args = append(args, ConfigFileArgs(p.GpHome, p.ConfigFile)...)
+
+	return append(args, "--daemonize")
+}
+
+/*
+GetServiceStatusMessage returns the pid of the service and the time it
+started, read from its pid file, e.g.
+
+PID=83008
+StartTime=Sun 2023-08-20 14:43:35 UTC
+
+The message is empty when the service is not running.
+*/
+func (p ProcessPlatform) GetServiceStatusMessage(serviceName string) (string, error) {
+	pidFile := p.PidFile(serviceName)
+	pid, err := ReadPidFile(pidFile)
+	if errors.Is(err, os.ErrNotExist) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	if !pidFileLocked(pidFile) {
+		return "", nil
+	}
+
+	info, err := System.Stat(pidFile)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("PID=%d\nStartTime=%s\n", pid, info.ModTime().Format(serviceTimeLayout)), nil
+}
+
+func (p ProcessPlatform) ParseServiceStatusMessage(message string) idl.ServiceStatus {
+	var uptime string
+	var pid int
+
+	for _, line := range strings.Split(message, "\n") {
+		key, value, _ := strings.Cut(strings.TrimSpace(line), "=")
+		switch key {
+		case "PID":
+			pid, _ = strconv.Atoi(value)
+		case "StartTime":
+			uptime = value
+		}
+	}
+
+	status := "not running"
+	if pid > 0 {
+		status = "running"
+	}
+
+	return idl.ServiceStatus{Status: status, Uptime: uptime, Pid: uint32(pid)}
+}
+
+func (p ProcessPlatform) DisplayServiceStatus(outfile io.Writer, serviceName string, statuses []*idl.ServiceStatus, skipHeader bool) {
+	displayServiceStatus(outfile, serviceName, statuses, skipHeader)
+}
+
+// EnableUserLingering is a no-op, as the processes are not tied to a session
+// of the user
+func (p ProcessPlatform) EnableUserLingering(hostnames []string, gpHome string, serviceUser string) error {
+	return nil
+}
+
+// GetRemoveServiceCommand removes the pid file left by the service, as there
+// are no service files
+func (p ProcessPlatform) GetRemoveServiceCommand(serviceDir string, serviceName string, process string) string {
+	return fmt.Sprintf("rm -f %s", p.PidFile(fmt.Sprintf("%s_%s", serviceName, process)))
+}
+
+func (p ProcessPlatform) GetDisableLingeringCommand(serviceUser string) string {
+	return ""
+}
+
+func (p ProcessPlatform) GetPlatformOS() string {
+	return p.OS
+}
+
+/*
+CreatePidFile records the pid of the current process in the pid file of the
+service, and locks it for as long as the process runs. It fails if the service
+is already running, that is if another process holds the lock. The returned
+function removes the pid file and releases the lock.
+*/
+func (p ProcessPlatform) CreatePidFile(serviceName string) (remove func(), err error) {
+	err = os.MkdirAll(p.LogDir, 0755)
+	if err != nil {
+		return nil, fmt.Errorf("could not create log directory %s: %w", p.LogDir, err)
+	}
+
+	pidFile := p.PidFile(serviceName)
+	file, err := lockPidFile(pidFile)
+	if errors.Is(err, syscall.EWOULDBLOCK) {
+		pid, _ := ReadPidFile(pidFile)
+		return nil, fmt.Errorf("the %s service is already running with pid %d", serviceName, pid)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not lock pid file %s: %w", pidFile, err)
+	}
+
+	err = file.Truncate(0)
+	if err == nil {
+		_, err = file.WriteString(fmt.Sprintf("%d\n", os.Getpid()))
+	}
+	if err != nil {
+		file.Close()
+		return nil, fmt.Errorf("could not write pid file %s: %w", pidFile, err)
+	}
+
+	return func() {
+		os.Remove(pidFile)
+		file.Close()
+	}, nil
+}
+
+/*
+lockPidFile opens the pid file, creating it if it does not exist, and locks
+it. A pid file left by a service which exited is reused. As the pid file may be
+removed by the service holding the lock while it is opened, it is opened again
+until the file locked is the one at the path.
+*/
+func lockPidFile(pidFile string) (*os.File, error) {
+	for {
+		file, err := os.OpenFile(pidFile, os.O_CREATE|os.O_EXCL|os.O_RDWR, 0644)
+		if errors.Is(err, os.ErrExist) {
+			file, err = os.OpenFile(pidFile, os.O_RDWR, 0644)
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		err = syscall.Flock(int(file.Fd()), syscall.LOCK_EX|syscall.LOCK_NB)
+		if err != nil {
+			file.Close()
+			return nil, err
+		}
+
+		opened, err := file.Stat()
+		if err != nil {
+			file.Close()
+			return nil, err
+		}
+		current, err := os.Stat(pidFile)
+		if err == nil && os.SameFile(opened, current) {
+			return file, nil
+		}
+		file.Close()
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return nil, err
+		}
+	}
+}
+
+// pidFileLocked returns whether the service of the pid file is running, that
+// is whether it holds the lock on its pid file
+func pidFileLocked(pidFile string) bool {
+	file, err := os.Open(pidFile)
+	if err != nil {
+		return false
+	}
+	defer file.Close()
+
+	err = syscall.Flock(int(file.Fd()), syscall.LOCK_SH|syscall.LOCK_NB)
+	if err != nil {
+		return errors.Is(err, syscall.EWOULDBLOCK)
+	}
+	syscall.Flock(int(file.Fd()), syscall.LOCK_UN) //nolint:errcheck
+
+	return false
+}
+
+/*
+Daemonize starts the service again with the given arguments in the background,
+detached from the terminal and with its output appended to its log file. It
+returns once the service has written its pid file, or with an error if the
+service exits or does not start in time.
+*/
+func (p ProcessPlatform) Daemonize(serviceName string, args []string) error {
+	pid, err := ReadPidFile(p.PidFile(serviceName))
+	if err == nil && pidFileLocked(p.PidFile(serviceName)) {
+		return fmt.Errorf("the %s service is already running with pid %d", serviceName, pid)
+	}
+
+	err = os.MkdirAll(p.LogDir, 0755)
+	if err != nil {
+		return fmt.Errorf("could not create log directory %s: %w", p.LogDir, err)
+	}
+
+	logFile := p.LogFile(serviceName)
+	output, err := os.OpenFile(logFile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("could not open log file %s: %w", logFile, err)
+	}
+	defer output.Close()
+
+	executable, err := os.Executable()
+	if err != nil {
+		return err
+	}
+
+	cmd := DaemonCommand(executable, args...)
+	cmd.Stdout = output
+	cmd.Stderr = output
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setsid: true}
+	err = cmd.Start()
+	if err != nil {
+		return fmt.Errorf("could not start the %s service: %w", serviceName, err)
+	}
+
+	exited := make(chan error, 1)
+	go func() {
+		exited <- cmd.Wait()
+	}()
+
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+	timeout := time.After(daemonStartTimeout)
+	for {
+		select {
+		case err := <-exited:
+			return fmt.Errorf("the %s service exited (%v), see %s for details", serviceName, err, logFile)
+
+		case <-timeout:
+			return fmt.Errorf("the %s service did not start within %s, see %s for details", serviceName, daemonStartTimeout, logFile)
+
+		case <-ticker.C:
+			pid, err := ReadPidFile(p.PidFile(serviceName))
+			if err == nil && pid == cmd.Process.Pid {
+				gplog.Verbose("Started the %s service with pid %d", serviceName, pid)
+				return nil
+			}
+		}
+	}
+}
+
+// StopService sends SIGTERM to the service and waits for it to exit. The pid is
+// only signalled while the service holds the lock on its pid file, as it may
+// belong to another process once the service exited.
+func (p ProcessPlatform) StopService(serviceName string) error {
+	pid, err := ReadPidFile(p.PidFile(serviceName))
+	if errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("the %s service is not running", serviceName)
+	}
+	if err != nil {
+		return err
+	}
+
+	if !pidFileLocked(p.PidFile(serviceName)) {
+		return fmt.Errorf("the %s service is not running, its pid file %s is stale", serviceName, p.PidFile(serviceName))
+	}
+
+	err = syscall.Kill(pid, syscall.SIGTERM)
+	if err != nil {
+		return fmt.Errorf("could not stop the %s service with pid %d: %w", serviceName, pid, err)
+	}
+
+	for start := time.Now(); time.Since(start) < serviceStopTimeout; time.Sleep(100 * time.Millisecond) {
+		if !ProcessIsRunning(pid) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("the %s service with pid %d did not stop within %s", serviceName, pid, serviceStopTimeout)
+}
+
+// ReadPidFile returns the pid recorded in the pid file
+func ReadPidFile(pidFile string) (int, error) {
+	contents, err := System.ReadFile(pidFile)
+	if err != nil {
+		return 0, err
+	}
+
+	pid, err := strconv.Atoi(strings.TrimSpace(string(contents)))
+	if err != nil || pid <= 0 {
+		return 0, fmt.Errorf("invalid pid file %s: %q", pidFile, contents)
+	}
+
+	return pid, nil
+}
+
+// ProcessIsRunning returns whether a process with the pid exists
+func ProcessIsRunning(pid int) bool {
+	err := syscall.Kill(pid, 0)
+
+	return err == nil || errors.Is(err, syscall.EPERM)
+}
+
+// NotifyOnStop calls stop once when the process receives SIGTERM or SIGINT.
+// The returned function stops listening to the signals.
+func NotifyOnStop(stop func()) (cancel func()) {
+	signals := make(chan os.Signal, 1)
+	done := make(chan struct{})
+	signal.Notify(signals, syscall.SIGTERM, syscall.SIGINT)
+
+	go func() {
+		select {
+		case sig := <-signals:
+			gplog.Info("Received %s, stopping", sig)
+			stop()
+		case <-done:
+		}
+	}()
+
+	return func() {
+		signal.Stop(signals)
+		close(done)
+	}
+}
@@ -0,0 +1,277 @@
+package utils_test
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/greenplum-db/gp-common-go-libs/testhelper"
+
+	"github.com/greenplum-db/gpdb/gp/constants"
+	"github.com/greenplum-db/gpdb/gp/testutils/exectest"
+	"github.com/greenplum-db/gpdb/gp/utils"
+)
+
+// DaemonMain writes its pid to the pid file given as its argument
+func DaemonMain() {
+	err := os.WriteFile(os.Args[1], []byte(fmt.Sprintf("%d\n", os.Getpid())), 0644)
+	if err != nil {
+		os.Exit(1)
+	}
+
+	time.Sleep(time.Second)
+}
+
+func init() {
+	exectest.RegisterMains(DaemonMain)
+}
+
+// exitedPid returns the pid of a process which has exited
+func exitedPid(t *testing.T) int {
+	t.Helper()
+
+	cmd := exec.Command("true")
+	err := cmd.Run()
+	if err != nil {
+		t.Fatalf("unexpected error: %#v", err)
+	}
+
+	return cmd.Process.Pid
+}
+
+func TestNewServicePlatform(t *testing.T) {
+	// the output of the services goes to the log directory by default
+	withLogs := func(platform utils.Platform) utils.Platform {
+		gpPlatform := platform.(utils.GpPlatform)
+		gpPlatform.LogDir = "/logs"
+		gpPlatform.LogOutput = constants.ServiceLogOutputFile
+
+		return gpPlatform
+	}
+
+	cases := []struct {
+		os             string
+		serviceManager string
+		systemService  *utils.SystemServiceConfig
+		serviceLog     *utils.ServiceLogConfig
+		expected       utils.Platform
+		err            string
+	}{
+		{os: constants.PlatformLinux, serviceManager: "", expected: withLogs(GetPlatform(constants.PlatformLinux, t))},
+		{os: constants.PlatformLinux, serviceManager: constants.ServiceManagerSystemd, expected: withLogs(GetPlatform(constants.PlatformLinux, t))},
+		{os: constants.PlatformDarwin, serviceManager: constants.ServiceManagerLaunchd, expected: withLogs(GetPlatform(constants.PlatformDarwin, t))},
+		{os: constants.PlatformLinux, serviceManager: constants.ServiceManagerProcess, expected: utils.ProcessPlatform{OS: constants.PlatformLinux, GpHome: "/usr/local/gpdb", LogDir: "/logs"}},
+		{os: constants.PlatformDarwin, serviceManager: constants.ServiceManagerSystemd, err: "the systemd service manager is not supported on darwin"},
+		{os: constants.PlatformLinux, serviceManager: constants.ServiceManagerLaunchd, err: "the launchd service manager is not supported on linux"},
+		{os: constants.PlatformLinux, serviceManager: "upstart", err: `unsupported service manager "upstart", expected one of systemd, launchd or process`},
+		{os: constants.PlatformLinux, serviceManager: "", systemService: &utils.SystemServiceConfig{User: "gpadmin", Group: "gpadmin"}, expected: utils.GpPlatform{
+			OS:           constants.PlatformLinux,
+			ServiceCmd:   "systemctl",
+			ServiceExt:   "service",
+			StatusArg:    "show",
+			SystemUnits:  true,
+			ServiceUser:  "gpadmin",
+			ServiceGroup: "gpadmin",
+			LogDir:       "/logs",
+			LogOutput:    constants.ServiceLogOutputFile,
+		}},
+		{os: constants.PlatformDarwin, serviceManager: "", serviceLog: &utils.ServiceLogConfig{Output: constants.ServiceLogOutputJournald}, err: "the journald service log output is only supported with the systemd service manager on linux"},
+		{os: constants.PlatformDarwin, serviceManager: "", systemService: &utils.SystemServiceConfig{User: "gpadmin"}, err: "system services are only supported with the systemd service manager on linux"},
+		{os: constants.PlatformLinux, serviceManager: constants.ServiceManagerProcess, systemService: &utils.SystemServiceConfig{User: "gpadmin"}, err: "system services are only supported with the systemd service manager on linux"},
+	}
+
+	for _, tc := range cases {
+		t.Run(fmt.Sprintf("returns the platform of the %q service manager on %s", tc.serviceManager, tc.os), func(t *testing.T) {
+			platform, err := utils.NewServicePlatform(tc.os, utils.ServiceOptions{
+				Manager:       tc.serviceManager,
+				GpHome:        "/usr/local/gpdb",
+				LogDir:        "/logs",
+				SystemService: tc.systemService,
+				ServiceLog:    tc.serviceLog,
+			})
+			if tc.err != "" {
+				if err == nil || err.Error() != tc.err {
+					t.Fatalf("got %v, want %s", err, tc.err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %#v", err)
+			}
+			if platform != tc.expected {
+				t.Fatalf("got %#v, want %#v", platform, tc.expected)
+			}
+		})
+	}
+}
+
+func TestProcessPlatformPidFile(t *testing.T) {
+	testhelper.SetupTestLogger()
+
+	t.Run("writes the pid file and reports the service as running", func(t *testing.T) {
+		platform := utils.ProcessPlatform{LogDir: filepath.Join(t.TempDir(), "logs")}
+
+		remove, err := platform.CreatePidFile("gp_hub")
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		message, err := platform.GetServiceStatusMessage("gp_hub")
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		status := platform.ParseServiceStatusMessage(message)
+		if status.Status != "running" || status.Pid != uint32(os.Getpid()) || status.Uptime == "" {
+			t.Fatalf("got status %v from %q", &status, message)
+		}
+
+		remove()
+		if _, err := os.Stat(platform.PidFile("gp_hub")); !os.IsNotExist(err) {
+			t.Fatalf("expected the pid file to be removed, got %v", err)
+		}
+
+		message, err = platform.GetServiceStatusMessage("gp_hub")
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		status = platform.ParseServiceStatusMessage(message)
+		if status.Status != "not running" || status.Pid != 0 {
+			t.Fatalf("got status %v, want not running", &status)
+		}
+	})
+
+	t.Run("errors out when the service is already running", func(t *testing.T) {
+		platform := utils.ProcessPlatform{LogDir: t.TempDir()}
+		remove, err := platform.CreatePidFile("gp_agent")
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		defer remove()
+
+		_, err = platform.CreatePidFile("gp_agent")
+		expected := fmt.Sprintf("the gp_agent service is already running with pid %d", os.Getpid())
+		if err == nil || err.Error() != expected {
+			t.Fatalf("got %v, want %s", err, expected)
+		}
+	})
+
+	t.Run("does not stop the process whose pid is left in a stale pid file", func(t *testing.T) {
+		platform := utils.ProcessPlatform{LogDir: t.TempDir()}
+		// the pid of the service which exited is now the one of another process
+		err := os.WriteFile(platform.PidFile("gp_hub"), []byte(fmt.Sprintf("%d\n", os.Getppid())), 0644)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		message, err := platform.GetServiceStatusMessage("gp_hub")
+		if err != nil || message != "" {
+			t.Fatalf("got %q and %v, want the service not running", message, err)
+		}
+
+		err = platform.StopService("gp_hub")
+		expected := fmt.Sprintf("the gp_hub service is not running, its pid file %s is stale", platform.PidFile("gp_hub"))
+		if err == nil || err.Error() != expected {
+			t.Fatalf("got %v, want %s", err, expected)
+		}
+		if !utils.ProcessIsRunning(os.Getppid()) {
+			t.Fatalf("expected the other process to be left running")
+		}
+	})
+
+	t.Run("replaces a stale pid file", func(t *testing.T) {
+		platform := utils.ProcessPlatform{LogDir: t.TempDir()}
+		err := os.WriteFile(platform.PidFile("gp_agent"), []byte(fmt.Sprintf("%d\n", exitedPid(t))), 0644)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		message, err := platform.GetServiceStatusMessage("gp_agent")
+		if err != nil || message != "" {
+			t.Fatalf("got %q and %v, want the service not running", message, err)
+		}
+
+		remove, err := platform.CreatePidFile("gp_agent")
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		defer remove()
+
+		pid, err := utils.ReadPidFile(platform.PidFile("gp_agent"))
+		if err != nil || pid != os.Getpid() {
+			t.Fatalf("got pid %d and %v, want %d", pid, err, os.Getpid())
+		}
+	})
+
+	t.Run("errors out when stopping a service which is not running", func(t *testing.T) {
+		platform := utils.ProcessPlatform{LogDir: t.TempDir()}
+
+		err := platform.StopService("gp_hub")
+		expected := "the gp_hub service is not running"
+		if err == nil || err.Error() != expected {
+			t.Fatalf("got %v, want %s", err, expected)
+		}
+	})
+}
+
+func TestProcessPlatformDaemonize(t *testing.T) {
+	testhelper.SetupTestLogger()
+
+	t.Run("returns once the service has written its pid file", func(t *testing.T) {
+		platform := utils.ProcessPlatform{LogDir: t.TempDir()}
+		utils.DaemonCommand = exectest.NewCommand(DaemonMain)
+		defer func() { utils.DaemonCommand = exec.Command }()
+
+		err := platform.Daemonize("gp_hub", []string{platform.PidFile("gp_hub")})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		pid, err := utils.ReadPidFile(platform.PidFile("gp_hub"))
+		if err != nil || !utils.ProcessIsRunning(pid) {
+			t.Fatalf("got pid %d and %v, want the daemon running", pid, err)
+		}
+		if _, err := os.Stat(platform.LogFile("gp_hub")); err != nil {
+			t.Fatalf("expected the log file to be created: %v", err)
+		}
+	})
+
+	t.Run("errors out when the service exits", func(t *testing.T) {
+		platform := utils.ProcessPlatform{LogDir: t.TempDir()}
+		utils.DaemonCommand = exectest.NewCommand(exectest.Failure)
+		defer func() { utils.DaemonCommand = exec.Command }()
+
+		err := platform.Daemonize("gp_agent", nil)
+		expected := fmt.Sprintf("the gp_agent service exited (exit status 1), see %s for details", platform.LogFile("gp_agent"))
+		if err == nil || err.Error() != expected {
+			t.Fatalf("got %v, want %s", err, expected)
+		}
+	})
+
+	t.Run("returns the command starting the agents with GPHOME", func(t *testing.T) {
+		platform := utils.ProcessPlatform{GpHome: "/usr/local/gpdb"}
+
+		expected := "GPHOME=/usr/local/gpdb /usr/local/gpdb/bin/gp agent --daemonize"
+		if command := strings.Join(platform.GetStartAgentCommandString("gp"), " "); command != expected {
+			t.Fatalf("got %q, want %q", command, expected)
+		}
+	})
+
+	t.Run("starts the services with the configuration file of the cluster", func(t *testing.T) {
+		platform := utils.ProcessPlatform{GpHome: "/usr/local/gpdb", ConfigFile: "/etc/gp/dev.conf"}
+
+		expected := "GPHOME=/usr/local/gpdb /usr/local/gpdb/bin/gp agent --config-file /etc/gp/dev.conf --daemonize"
+		if command := strings.Join(platform.GetStartAgentCommandString("gp_dev"), " "); command != expected {
+			t.Fatalf("got %q, want %q", command, expected)
+		}
+
+		expected = "/usr/local/gpdb/bin/gp hub --config-file /etc/gp/dev.conf --daemonize"
+		if command := strings.Join(platform.GetStartHubCommand("gp_dev").Args, " "); command != expected {
+			t.Fatalf("got %q, want %q", command, expected)
+		}
+	})
+}
@@ -0,0 +1,302 @@
+package utils
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log/syslog"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/greenplum-db/gpdb/gp/constants"
+)
+
+// Interval at which the log file of the services is checked for rotation
+var serviceLogCheckInterval = time.Minute
+
+// ServiceLogConfig sets where the output of the hub and agents goes, and when
+// their log files are rotated and removed
+type ServiceLogConfig struct {
+	Output      string `json:"output,omitempty"`      // file, journald or syslog, defaults to file
+	MaxSizeMB   int    `json:"maxSizeMB,omitempty"`   // size at which the file is rotated
+	RotateHours int    `json:"rotateHours,omitempty"` // age at which the file is rotated
+	MaxFiles    int    `json:"maxFiles,omitempty"`    // number of rotated files kept
+	MaxAgeDays  int    `json:"maxAgeDays,omitempty"`  // age at which rotated files are removed
+}
+
+// GetOutput returns the output of the services, a file by default
+func (c *ServiceLogConfig) GetOutput() string {
+	if c == nil || c.Output == "" {
+		return constants.ServiceLogOutputFile
+	}
+
+	return c.Output
+}
+
+// Validate checks the output and that the rotation settings are not negative
+func (c *ServiceLogConfig) Validate() error {
+	switch c.GetOutput() {
+	case constants.ServiceLogOutputFile, constants.ServiceLogOutputJournald, constants.ServiceLogOutputSyslog:
+	default:
+		return fmt.Errorf("unsupported service log output %q, expected one of %s, %s or %s", c.Output,
+			constants.ServiceLogOutputFile, constants.ServiceLogOutputJournald, constants.ServiceLogOutputSyslog)
+	}
+
+	if c == nil {
+		return nil
+	}
+	for _, value := range []int{c.MaxSizeMB, c.RotateHours, c.MaxFiles, c.MaxAgeDays} {
+		if value < 0 {
+			return errors.New("the service log rotation settings must not be negative")
+		}
+	}
+
+	return nil
+}
+
+// ServiceLogPath returns the file the output of the hub or agent goes to,
+// e.g. <log-dir>/gp_hub.log
+func ServiceLogPath(logDir string, serviceName string, process string) string {
+	return filepath.Join(logDir, fmt.Sprintf("%s_%s.log", serviceName, process))
+}
+
+/*
+ServiceLogFile is the file the standard output and error of a service are
+redirected to. It is rotated once it grows beyond the maximum size or gets
+older than the rotation age: it is renamed to <file>.1, shifting the older
+files, and the oldest file beyond the maximum count is removed, along with the
+rotated files older than the maximum age.
+*/
+type ServiceLogFile struct {
+	path        string
+	maxSize     int64
+	rotateAfter time.Duration
+	maxFiles    int
+	maxAge      time.Duration
+	redirect    func(file *os.File) error
+	opened      time.Time
+}
+
+// OpenServiceLogFile opens the log file and passes it to redirect, which is
+// called again each time the file is rotated
+func OpenServiceLogFile(path string, conf *ServiceLogConfig, redirect func(file *os.File) error) (*ServiceLogFile, error) {
+	l := &ServiceLogFile{
+		path:        path,
+		maxSize:     constants.DefaultServiceLogMaxSizeMB * 1024 * 1024,
+		rotateAfter: constants.DefaultServiceLogRotateHours * time.Hour,
+		maxFiles:    constants.DefaultServiceLogMaxFiles,
+		maxAge:      constants.DefaultServiceLogMaxAgeDays * 24 * time.Hour,
+		redirect:    redirect,
+	}
+	if conf != nil && conf.MaxSizeMB > 0 {
+		l.maxSize = int64(conf.MaxSizeMB) * 1024 * 1024
+	}
+	if conf != nil && conf.RotateHours > 0 {
+		l.rotateAfter = time.Duration(conf.RotateHours) * time.Hour
+	}
+	if conf != nil && conf.MaxFiles > 0 {
+		l.maxFiles = conf.MaxFiles
+	}
+	if conf != nil && conf.MaxAgeDays > 0 {
+		l.maxAge = time.Duration(conf.MaxAgeDays) * 24 * time.Hour
+	}
+
+	err := os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return nil, fmt.Errorf("could not create service log directory: %w", err)
+	}
+
+	err = l.open(time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	return l, nil
+}
+
+// RotateIfNeeded rotates the file if it is too large or too old, or opens it
+// again if it was removed
+func (l *ServiceLogFile) RotateIfNeeded(now time.Time) error {
+	info, err := os.Stat(l.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return l.open(now)
+	}
+	if err != nil {
+		return fmt.Errorf("could not check service log %s: %w", l.path, err)
+	}
+
+	if info.Size() >= l.maxSize || (info.Size() > 0 && now.Sub(l.opened) >= l.rotateAfter) {
+		return l.rotate(now)
+	}
+
+	return nil
+}
+
+func (l *ServiceLogFile) open(now time.Time) error {
+	file, err := os.OpenFile(l.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("could not open service log: %w", err)
+	}
+	defer file.Close()
+
+	err = l.redirect(file)
+	if err != nil {
+		return fmt.Errorf("could not redirect the output to service log %s: %w", l.path, err)
+	}
+
+	l.opened = now
+	return nil
+}
+
+func (l *ServiceLogFile) rotate(now time.Time) error {
+	err := os.Remove(rotatedServiceLog(l.path, l.maxFiles))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("could not rotate service log %s: %w", l.path, err)
+	}
+	for i := l.maxFiles - 1; i >= 0; i-- {
+		err = os.Rename(rotatedServiceLog(l.path, i), rotatedServiceLog(l.path, i+1))
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("could not rotate service log %s: %w", l.path, err)
+		}
+	}
+
+	for i := 1; i <= l.maxFiles; i++ {
+		info, err := os.Stat(rotatedServiceLog(l.path, i))
+		if err == nil && now.Sub(info.ModTime()) > l.maxAge {
+			os.Remove(rotatedServiceLog(l.path, i))
+		}
+	}
+
+	return l.open(now)
+}
+
+func rotatedServiceLog(path string, index int) string {
+	if index == 0 {
+		return path
+	}
+
+	return fmt.Sprintf("%s.%d", path, index)
+}
+
+/*
+RedirectServiceOutput sends the standard output and error of the running hub
+or agent, including the panics of the Go runtime, to its log file, which is
+rotated in the background. With syslog only the standard output goes to
+syslog: the standard error stays on the log file, so that the panics written
+as the process dies are not lost with the goroutine feeding syslog. With
+journald the output is left to systemd. The returned function stops the
+rotation.
+*/
+func RedirectServiceOutput(conf *ServiceLogConfig, logDir string, serviceName string, process string) (stop func(), err error) {
+	redirect := redirectStdio
+	switch conf.GetOutput() {
+	case constants.ServiceLogOutputJournald:
+		return func() {}, nil
+
+	case constants.ServiceLogOutputSyslog:
+		err = redirectToSyslog(fmt.Sprintf("%s_%s", serviceName, process))
+		if err != nil {
+			return nil, err
+		}
+		redirect = redirectStderr
+	}
+
+	logFile, err := OpenServiceLogFile(ServiceLogPath(logDir, serviceName, process), conf, redirect)
+	if err != nil {
+		return nil, err
+	}
+
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		ticker := time.NewTicker(serviceLogCheckInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case now := <-ticker.C:
+				err := logFile.RotateIfNeeded(now)
+				if err != nil {
+					fmt.Fprintln(os.Stderr, err)
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return func() {
+		close(done)
+		wg.Wait()
+	}, nil
+}
+
+// redirectToSyslog sends each line written to the standard output to syslog,
+// tagged with the name of the service
+func redirectToSyslog(name string) error {
+	writer, err := syslog.New(syslog.LOG_INFO|syslog.LOG_DAEMON, name)
+	if err != nil {
+		return fmt.Errorf("could not connect to syslog: %w", err)
+	}
+
+	reader, pipe, err := os.Pipe()
+	if err != nil {
+		writer.Close()
+		return err
+	}
+	defer pipe.Close()
+
+	err = dupFd(int(pipe.Fd()), int(os.Stdout.Fd()))
+	if err != nil {
+		reader.Close()
+		writer.Close()
+		return fmt.Errorf("could not redirect the output to syslog: %w", err)
+	}
+
+	// the standard output stays open, so this runs until the process exits
+	go ForwardLines(reader, writer.Info)
+
+	return nil
+}
+
+// ForwardLines passes each line read to send until the reader is exhausted.
+// Lines of any length are passed whole, so that the reader is always drained
+// and the writers never block on a full pipe.
+func ForwardLines(reader io.Reader, send func(line string) error) {
+	buffered := bufio.NewReader(reader)
+	for {
+		line, err := buffered.ReadString('\n')
+		line = strings.TrimSuffix(line, "\n")
+		if line != "" {
+			send(line) // nolint
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+// redirectStdio makes the standard output and error of the process refer to
+// the file
+func redirectStdio(file *os.File) error {
+	for _, fd := range []int{int(os.Stdout.Fd()), int(os.Stderr.Fd())} {
+		err := dupFd(int(file.Fd()), fd)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// redirectStderr makes the standard error of the process refer to the file
+func redirectStderr(file *os.File) error {
+	return dupFd(int(file.Fd()), int(os.Stderr.Fd()))
+}
@@ -0,0 +1,184 @@
+package utils_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/greenplum-db/gpdb/gp/constants"
+	"github.com/greenplum-db/gpdb/gp/utils"
+)
+
+func TestServiceLogConfigValidate(t *testing.T) {
+	cases := []struct {
+		name string
+		conf *utils.ServiceLogConfig
+		err  string
+	}{
+		{name: "accepts no configuration", conf: nil},
+		{name: "accepts the journald output", conf: &utils.ServiceLogConfig{Output: constants.ServiceLogOutputJournald}},
+		{name: "accepts the rotation settings", conf: &utils.ServiceLogConfig{MaxSizeMB: 10, RotateHours: 1, MaxFiles: 3, MaxAgeDays: 7}},
+		{name: "errors on an unknown output", conf: &utils.ServiceLogConfig{Output: "console"}, err: `unsupported service log output "console", expected one of file, journald or syslog`},
+		{name: "errors on negative settings", conf: &utils.ServiceLogConfig{MaxFiles: -1}, err: "the service log rotation settings must not be negative"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.conf.Validate()
+			if tc.err == "" && err != nil {
+				t.Fatalf("unexpected error: %#v", err)
+			}
+			if tc.err != "" && (err == nil || err.Error() != tc.err) {
+				t.Fatalf("got %v, want %s", err, tc.err)
+			}
+		})
+	}
+}
+
+func TestServiceLogFile(t *testing.T) {
+	// writes to the file last passed to redirect, as the output of a service would
+	var output *os.File
+	redirect := func(file *os.File) error {
+		if output != nil {
+			output.Close()
+		}
+
+		var err error
+		output, err = os.OpenFile(file.Name(), os.O_APPEND|os.O_WRONLY, 0)
+		return err
+	}
+	t.Cleanup(func() {
+		output.Close()
+	})
+
+	readFile := func(t *testing.T, path string) string {
+		t.Helper()
+
+		contents, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		return string(contents)
+	}
+
+	t.Run("rotates the file once it is too large and keeps the maximum number of files", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "logs", "gp_hub.log")
+		logFile, err := utils.OpenServiceLogFile(path, &utils.ServiceLogConfig{MaxSizeMB: 1, MaxFiles: 2}, redirect)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		for i := 1; i <= 3; i++ {
+			output.WriteString(strings.Repeat("a", 1024*1024))
+			output.WriteString("end of file " + string(rune('0'+i)))
+
+			err = logFile.RotateIfNeeded(time.Now())
+			if err != nil {
+				t.Fatalf("unexpected error: %#v", err)
+			}
+		}
+		output.WriteString("new file")
+
+		if contents := readFile(t, path); contents != "new file" {
+			t.Fatalf("got %q, want the output after the rotation", contents)
+		}
+		if contents := readFile(t, path+".1"); !strings.HasSuffix(contents, "end of file 3") {
+			t.Fatalf("got %q, want the latest rotated file", contents[len(contents)-20:])
+		}
+		if contents := readFile(t, path+".2"); !strings.HasSuffix(contents, "end of file 2") {
+			t.Fatalf("got %q, want the oldest rotated file", contents[len(contents)-20:])
+		}
+		if _, err := os.Stat(path + ".3"); !os.IsNotExist(err) {
+			t.Fatalf("expected the oldest file beyond the maximum count to be removed, got %v", err)
+		}
+	})
+
+	t.Run("rotates the file once it is too old and removes the expired files", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "gp_agent.log")
+		expired := time.Now().Add(-72 * time.Hour)
+		err := os.WriteFile(path+".1", []byte("expired"), 0644)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		err = os.Chtimes(path+".1", expired, expired)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		logFile, err := utils.OpenServiceLogFile(path, &utils.ServiceLogConfig{RotateHours: 1, MaxAgeDays: 2}, redirect)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		output.WriteString("old output")
+
+		err = logFile.RotateIfNeeded(time.Now().Add(30 * time.Minute))
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		if contents := readFile(t, path); contents != "old output" {
+			t.Fatalf("got %q, want the file not to be rotated yet", contents)
+		}
+
+		err = logFile.RotateIfNeeded(time.Now().Add(time.Hour))
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		if contents := readFile(t, path+".1"); contents != "old output" {
+			t.Fatalf("got %q, want the rotated file", contents)
+		}
+		if _, err := os.Stat(path + ".2"); !os.IsNotExist(err) {
+			t.Fatalf("expected the expired file to be removed, got %v", err)
+		}
+	})
+
+	t.Run("opens the file again when it was removed", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "gp_hub.log")
+		logFile, err := utils.OpenServiceLogFile(path, nil, redirect)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		err = os.Remove(path)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		err = logFile.RotateIfNeeded(time.Now())
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		output.WriteString("output")
+
+		if contents := readFile(t, path); contents != "output" {
+			t.Fatalf("got %q, want %q", contents, "output")
+		}
+	})
+}
+
+func TestServiceLogPath(t *testing.T) {
+	result := utils.ServiceLogPath("/logs", "gp", "agent")
+	expected := "/logs/gp_agent.log"
+	if result != expected {
+		t.Fatalf("got %q, want %q", result, expected)
+	}
+}
+
+func TestForwardLines(t *testing.T) {
+	t.Run("passes the lines of any length and drains the reader", func(t *testing.T) {
+		long := strings.Repeat("x", 1024*1024)
+		input := "first\n" + long + "\n\nlast"
+
+		var lines []string
+		utils.ForwardLines(strings.NewReader(input), func(line string) error {
+			lines = append(lines, line)
+			return nil
+		})
+
+		if len(lines) != 3 || lines[0] != "first" || lines[1] != long || lines[2] != "last" {
+			t.Fatalf("got %d lines, want the first, long and last lines", len(lines))
+		}
+	})
+}
@@ -1,17 +1,40 @@
 package utils
 
 import (
+	"crypto/sha256"
 	"crypto/tls"
 	"crypto/x509"
+	"encoding/hex"
 	"fmt"
 	"os"
+	"os/signal"
+	"slices"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
 
+	"github.com/greenplum-db/gp-common-go-libs/gplog"
 	"google.golang.org/grpc/credentials"
 )
 
+// Roles of the gp processes. The role selects the client certificate used for
+// outgoing connections and the clients that may connect to a server.
+const (
+	RoleCli   = "cli"
+	RoleHub   = "hub"
+	RoleAgent = "agent"
+)
+
 type Credentials interface {
-	LoadServerCredentials() (credentials.TransportCredentials, error)
-	LoadClientCredentials() (credentials.TransportCredentials, error)
+	LoadServerCredentials(role string) (credentials.TransportCredentials, error)
+	LoadClientCredentials(role string) (credentials.TransportCredentials, error)
+	ClientFingerprint(role string) (string, error)
+}
+
+type CertificatePaths struct {
+	CertPath string `json:"cert"`
+	KeyPath  string `json:"key"`
 }
 
 type GpCredentials struct {
@@ -19,41 +42,255 @@ type GpCredentials struct {
 	CAKeyPath      string `json:"caKey"`
 	ServerCertPath string `json:"serverCert"`
 	ServerKeyPath  string `json:"serverKey"`
+	// Client certificate per role, the server certificate is used for roles without one
+	ClientCertificates map[string]CertificatePaths `json:"clientCertificates,omitempty"`
+	// Names a client certificate must carry to connect to the server of a role.
+	// Any client certificate signed by the CA is accepted for roles without names.
+	AllowedClients map[string][]string `json:"allowedClients,omitempty"`
+}
+
+/*
+LoadServerCredentials returns credentials that verify clients against the CA.
+The certificate files are reloaded when they change on disk, or when Reload is
+called, so that certificates can be rotated without restarting the server.
+*/
+func (c GpCredentials) LoadServerCredentials(role string) (credentials.TransportCredentials, error) {
+	reloader := &certificateReloader{
+		files: []string{c.ServerCertPath, c.ServerKeyPath, c.CACertPath},
+		load: func() (*tls.Config, error) {
+			return c.serverConfig(role)
+		},
+	}
+	err := reloader.reload()
+	if err != nil {
+		return nil, err
+	}
+
+	return &ReloadableCredentials{
+		TransportCredentials: credentials.NewTLS(&tls.Config{
+			GetConfigForClient: reloader.getConfigForClient,
+		}),
+		reloader: reloader,
+	}, nil
 }
 
-func (c GpCredentials) LoadServerCredentials() (credentials.TransportCredentials, error) {
+func (c GpCredentials) serverConfig(role string) (*tls.Config, error) {
 	serverCert, err := tls.LoadX509KeyPair(c.ServerCertPath, c.ServerKeyPath)
 	if err != nil {
 		return nil, fmt.Errorf("could not load server credentials: %w", err)
 	}
 
+	certPool, err := loadCertPool(c.CACertPath)
+	if err != nil {
+		return nil, fmt.Errorf("could not load server credentials: %w", err)
+	}
+
 	config := &tls.Config{
 		Certificates: []tls.Certificate{serverCert},
-		ClientAuth:   tls.RequireAnyClientCert,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    certPool,
+		MinVersion:   tls.VersionTLS12,
+		NextProtos:   []string{"h2"},
 	}
-	return credentials.NewTLS(config), nil
 
+	allowed := c.AllowedClients[role]
+	if len(allowed) > 0 {
+		config.VerifyConnection = func(state tls.ConnectionState) error {
+			if len(state.PeerCertificates) == 0 {
+				return fmt.Errorf("no client certificate presented to the %s", role)
+			}
+
+			err := VerifyPeerIdentity(state.PeerCertificates[0], allowed)
+			if err != nil {
+				gplog.Warn("Rejected connection to the %s: %s", role, err)
+			}
+
+			return err
+		}
+	}
+
+	return config, nil
 }
 
-func (c GpCredentials) LoadClientCredentials() (credentials.TransportCredentials, error) {
-	caCert, err := os.ReadFile(c.CACertPath)
+func (c GpCredentials) LoadClientCredentials(role string) (credentials.TransportCredentials, error) {
+	certPool, err := loadCertPool(c.CACertPath)
 	if err != nil {
-		return nil, fmt.Errorf("error while loading server certificate: %v", err)
-	}
-	certPool := x509.NewCertPool()
-	if !certPool.AppendCertsFromPEM(caCert) {
-		return nil, fmt.Errorf("failed to add server CA's certificate")
+		return nil, fmt.Errorf("could not load the %s client credentials: %w", role, err)
 	}
 
-	clientCert, err := tls.LoadX509KeyPair(c.ServerCertPath, c.ServerKeyPath)
+	paths, ok := c.ClientCertificates[role]
+	if !ok {
+		paths = CertificatePaths{CertPath: c.ServerCertPath, KeyPath: c.ServerKeyPath}
+	}
+	clientCert, err := tls.LoadX509KeyPair(paths.CertPath, paths.KeyPath)
 	if err != nil {
-		return nil, fmt.Errorf("error while loading server certificate: %v", err)
+		return nil, fmt.Errorf("could not load the %s client certificate %s: %w", role, paths.CertPath, err)
 	}
 
 	config := &tls.Config{
 		Certificates: []tls.Certificate{clientCert},
 		RootCAs:      certPool,
+		MinVersion:   tls.VersionTLS12,
 	}
 
 	return credentials.NewTLS(config), nil
 }
+
+/*
+ClientFingerprint identifies the contents of the client certificate, private key
+and CA certificate used by the role, so that callers can detect a rotation.
+*/
+func (c GpCredentials) ClientFingerprint(role string) (string, error) {
+	paths, ok := c.ClientCertificates[role]
+	if !ok {
+		paths = CertificatePaths{CertPath: c.ServerCertPath, KeyPath: c.ServerKeyPath}
+	}
+
+	hash := sha256.New()
+	for _, file := range []string{paths.CertPath, paths.KeyPath, c.CACertPath} {
+		contents, err := os.ReadFile(file)
+		if err != nil {
+			return "", err
+		}
+		hash.Write(contents)
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// Reloader is implemented by server credentials whose certificates can be reloaded
+type Reloader interface {
+	Reload() error
+}
+
+type ReloadableCredentials struct {
+	credentials.TransportCredentials
+	reloader *certificateReloader
+}
+
+// Reload loads the certificate files again. On failure the current certificates
+// continue to be used.
+func (c *ReloadableCredentials) Reload() error {
+	return c.reloader.forceReload()
+}
+
+/*
+NotifyOnReload calls reload whenever the process receives SIGHUP, until the
+returned function is called.
+*/
+func NotifyOnReload(reload func()) (stop func()) {
+	signals := make(chan os.Signal, 1)
+	done := make(chan struct{})
+	signal.Notify(signals, syscall.SIGHUP)
+
+	go func() {
+		for {
+			select {
+			case <-signals:
+				reload()
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return func() {
+		signal.Stop(signals)
+		close(done)
+	}
+}
+
+// certificateReloader rebuilds a TLS configuration when any of its files change
+type certificateReloader struct {
+	mutex   sync.Mutex
+	files   []string
+	load    func() (*tls.Config, error)
+	config  *tls.Config
+	modTime []time.Time
+}
+
+func (r *certificateReloader) getConfigForClient(*tls.ClientHelloInfo) (*tls.Config, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	// Files being replaced are picked up on a later handshake
+	modTime, err := fileModTimes(r.files)
+	if err == nil && !slices.EqualFunc(modTime, r.modTime, time.Time.Equal) {
+		err = r.reload()
+		if err != nil {
+			gplog.Warn("Could not reload certificates, continuing with the current ones: %s", err)
+		} else {
+			gplog.Info("Reloaded certificates from %s", strings.Join(r.files, ", "))
+		}
+	}
+
+	return r.config, nil
+}
+
+func (r *certificateReloader) forceReload() error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return r.reload()
+}
+
+func (r *certificateReloader) reload() error {
+	config, err := r.load()
+	if err != nil {
+		return err
+	}
+
+	modTime, err := fileModTimes(r.files)
+	if err != nil {
+		return fmt.Errorf("could not load server credentials: %w", err)
+	}
+
+	r.config = config
+	r.modTime = modTime
+	return nil
+}
+
+func fileModTimes(files []string) ([]time.Time, error) {
+	modTimes := make([]time.Time, 0, len(files))
+	for _, file := range files {
+		info, err := System.Stat(file)
+		if err != nil {
+			return nil, err
+		}
+		modTimes = append(modTimes, info.ModTime())
+	}
+
+	return modTimes, nil
+}
+
+/*
+VerifyPeerIdentity checks that the certificate carries one of the allowed names
+as its common name or as a DNS subject alternative name.
+*/
+func VerifyPeerIdentity(cert *x509.Certificate, allowed []string) error {
+	if slices.Contains(allowed, cert.Subject.CommonName) {
+		return nil
+	}
+
+	for _, name := range cert.DNSNames {
+		if slices.Contains(allowed, name) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("certificate %q is not in the list of allowed clients", cert.Subject.CommonName)
+}
+
+func loadCertPool(caCertPath string) (*x509.CertPool, error) {
+	caCert, err := os.ReadFile(caCertPath)
+	if err != nil {
+		return nil, fmt.Errorf("could not load the CA certificate: %w", err)
+	}
+
+	certPool := x509.NewCertPool()
+	if !certPool.AppendCertsFromPEM(caCert) {
+		return nil, fmt.Errorf("no certificate found in the CA certificate %s", caCertPath)
+	}
+
+	return certPool, nil
+}
@@ -1,13 +1,27 @@
 package utils_test
 
 import (
+	"context"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net"
 	"os"
 	"os/exec"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/greenplum-db/gp-common-go-libs/testhelper"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/test/bufconn"
 
 	"github.com/greenplum-db/gpdb/gp/constants"
 
 	"github.com/greenplum-db/gpdb/gp/utils"
+	"github.com/greenplum-db/gpdb/gp/utils/certs"
 )
 
 func TestLoadServerCredentials(t *testing.T) {
@@ -23,7 +37,7 @@ func TestLoadServerCredentials(t *testing.T) {
 			ServerCertPath: "./certificates/server-cert.pem",
 			ServerKeyPath:  "./certificates/server-key.pem",
 		}
-		_, err := creds.LoadServerCredentials()
+		_, err := creds.LoadServerCredentials(utils.RoleHub)
 		if err != nil {
 			t.Errorf("unexpected error %v", err)
 		}
@@ -37,7 +51,7 @@ func TestLoadServerCredentials(t *testing.T) {
 			ServerKeyPath:  "./certificates/server-key.pem",
 		}
 		creds.ServerCertPath = "/dev/null"
-		_, err := creds.LoadServerCredentials()
+		_, err := creds.LoadServerCredentials(utils.RoleHub)
 		if err == nil {
 			t.Fatalf("expected TLS error, did not receive one")
 		}
@@ -65,7 +79,7 @@ func TestLoadClientCredentials(t *testing.T) {
 			ServerCertPath: "./certificates/server-cert.pem",
 			ServerKeyPath:  "./certificates/server-key.pem",
 		}
-		_, err := creds.LoadClientCredentials()
+		_, err := creds.LoadClientCredentials(utils.RoleCli)
 		if err != nil {
 			t.Errorf("unexpected error %v", err)
 		}
@@ -79,17 +93,165 @@ func TestLoadClientCredentials(t *testing.T) {
 			ServerKeyPath:  "./certificates/server-key.pem",
 		}
 		creds.CACertPath = "/dev/null"
-		_, err := creds.LoadClientCredentials()
+		_, err := creds.LoadClientCredentials(utils.RoleCli)
 		if err == nil {
 			t.Fatalf("expected TLS error, did not receive one")
 		}
-		if err.Error() != "failed to add server CA's certificate" {
+		if err.Error() != "could not load the cli client credentials: no certificate found in the CA certificate /dev/null" {
 			t.Errorf("expected TLS error, got %v", err)
 		}
 	})
+	t.Run("uses the client certificate when one is configured", func(t *testing.T) {
+		creds := &utils.GpCredentials{
+			CACertPath:     "./certificates/ca-cert.pem",
+			CAKeyPath:      "./certificates/ca-key.pem",
+			ServerCertPath: "./certificates/server-cert.pem",
+			ServerKeyPath:  "./certificates/server-key.pem",
+			ClientCertificates: map[string]utils.CertificatePaths{
+				utils.RoleCli: {CertPath: "/dev/null", KeyPath: "./certificates/server-key.pem"},
+			},
+		}
+		_, err := creds.LoadClientCredentials(utils.RoleCli)
+		expected := "could not load the cli client certificate /dev/null: tls: failed to find any PEM data in certificate input"
+		if err == nil || err.Error() != expected {
+			t.Errorf("got %v, want %s", err, expected)
+		}
+	})
 
 	err = os.RemoveAll("./certificates")
 	if err != nil {
 		t.Fatalf("Cannot remove test certificates: %v", err)
 	}
 }
+
+func TestMutualTls(t *testing.T) {
+	testhelper.SetupTestLogger()
+
+	dir := t.TempDir()
+	writeKeyPair := func(kp *certs.KeyPair, name string) utils.CertificatePaths {
+		t.Helper()
+
+		paths := utils.CertificatePaths{
+			CertPath: filepath.Join(dir, name+"-cert.pem"),
+			KeyPath:  filepath.Join(dir, name+"-key.pem"),
+		}
+		err := kp.Write(paths.CertPath, paths.KeyPath)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		return paths
+	}
+
+	ca, err := certs.NewCertificateAuthority("test CA", time.Hour)
+	if err != nil {
+		t.Fatalf("unexpected error: %#v", err)
+	}
+	ca.Write(filepath.Join(dir, certs.CACertFile), filepath.Join(dir, certs.CAKeyFile)) // nolint
+
+	otherCa, err := certs.NewCertificateAuthority("other CA", time.Hour)
+	if err != nil {
+		t.Fatalf("unexpected error: %#v", err)
+	}
+
+	server, _ := ca.IssueServerCertificate("localhost", time.Hour)
+	serverPaths := writeKeyPair(server, "server")
+	clientPaths := map[string]utils.CertificatePaths{}
+	for _, role := range []string{utils.RoleCli, utils.RoleHub} {
+		client, _ := ca.IssueClientCertificate(certs.ClientCommonName(role), "localhost", time.Hour)
+		clientPaths[role] = writeKeyPair(client, role)
+	}
+	rogue, _ := otherCa.IssueClientCertificate(certs.ClientCommonName(utils.RoleHub), "localhost", time.Hour)
+	clientPaths["rogue"] = writeKeyPair(rogue, "rogue")
+
+	creds := utils.GpCredentials{
+		CACertPath:         filepath.Join(dir, certs.CACertFile),
+		ServerCertPath:     serverPaths.CertPath,
+		ServerKeyPath:      serverPaths.KeyPath,
+		ClientCertificates: clientPaths,
+		AllowedClients: map[string][]string{
+			utils.RoleAgent: {certs.ClientCommonName(utils.RoleHub)},
+		},
+	}
+
+	serverCreds, err := creds.LoadServerCredentials(utils.RoleAgent)
+	if err != nil {
+		t.Fatalf("unexpected error: %#v", err)
+	}
+	listener := bufconn.Listen(1024 * 1024)
+	grpcServer := grpc.NewServer(grpc.Creds(serverCreds))
+	grpc_health_v1.RegisterHealthServer(grpcServer, health.NewServer())
+	go grpcServer.Serve(listener) // nolint
+	defer grpcServer.Stop()
+
+	check := func(role string) error {
+		clientCreds, err := creds.LoadClientCredentials(role)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		conn, err := grpc.Dial("localhost",
+			grpc.WithTransportCredentials(clientCreds),
+			grpc.WithContextDialer(func(ctx context.Context, s string) (net.Conn, error) {
+				return listener.DialContext(ctx)
+			}),
+		)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		defer conn.Close()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_, err = grpc_health_v1.NewHealthClient(conn).Check(ctx, &grpc_health_v1.HealthCheckRequest{})
+
+		return utils.FormatGrpcError(err)
+	}
+
+	t.Run("accepts an allowed client certificate signed by the CA", func(t *testing.T) {
+		err := check(utils.RoleHub)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+	})
+
+	t.Run("rejects a client certificate that is not allowed", func(t *testing.T) {
+		err := check(utils.RoleCli)
+		if err == nil || !strings.HasPrefix(err.Error(), "TLS handshake failed") {
+			t.Fatalf("got %v, want a TLS handshake error", err)
+		}
+	})
+
+	t.Run("rejects a client certificate signed by another CA", func(t *testing.T) {
+		err := check("rogue")
+		if err == nil || !strings.HasPrefix(err.Error(), "TLS handshake failed") {
+			t.Fatalf("got %v, want a TLS handshake error", err)
+		}
+	})
+}
+
+func TestVerifyPeerIdentity(t *testing.T) {
+	cert := &x509.Certificate{
+		Subject:  pkix.Name{CommonName: "gp-hub"},
+		DNSNames: []string{"cdw"},
+	}
+
+	cases := []struct {
+		allowed []string
+		valid   bool
+	}{
+		{[]string{"gp-hub"}, true},
+		{[]string{"gp-cli", "cdw"}, true},
+		{[]string{"gp-cli", "sdw1"}, false},
+	}
+
+	for _, tc := range cases {
+		err := utils.VerifyPeerIdentity(cert, tc.allowed)
+		if tc.valid && err != nil {
+			t.Errorf("unexpected error for %v: %#v", tc.allowed, err)
+		}
+		if !tc.valid && (err == nil || err.Error() != `certificate "gp-hub" is not in the list of allowed clients`) {
+			t.Errorf("got %v for %v, want an error", err, tc.allowed)
+		}
+	}
+}
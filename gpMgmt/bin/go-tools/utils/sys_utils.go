@@ -1,14 +1,17 @@
 package utils
 
 import (
+	"errors"
 	"fmt"
 	"io/fs"
 	"net"
 	"os"
 	"os/exec"
 	"os/user"
+	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
 )
 
 var System = InitializeSystemFunctions()
@@ -28,6 +31,7 @@ type SystemFunctions struct {
 	RemoveAll      func(path string) error
 	ReadFile       func(name string) ([]byte, error)
 	GetHostName    func() (name string, err error)
+	Rename         func(oldpath, newpath string) error
 }
 
 func InitializeSystemFunctions() *SystemFunctions {
@@ -45,6 +49,7 @@ func InitializeSystemFunctions() *SystemFunctions {
 		RemoveAll:      os.RemoveAll,
 		ReadFile:       os.ReadFile,
 		GetHostName:    os.Hostname,
+		Rename:         os.Rename,
 	}
 }
 
@@ -90,6 +95,94 @@ func AppendLinesToFile(filename string, lines []string) error {
 	return nil
 }
 
+/*
+WriteFileAtomic writes the data to a temporary file in the same directory and renames
+it over the target, so that the file is either fully updated or left untouched.
+The permissions of an existing file are preserved, otherwise perm is used.
+*/
+func WriteFileAtomic(filename string, data []byte, perm os.FileMode) error {
+	if info, err := System.Stat(filename); err == nil {
+		perm = info.Mode().Perm()
+	}
+
+	tmpFile, err := os.CreateTemp(filepath.Dir(filename), filepath.Base(filename)+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmpFile.Name())
+	defer tmpFile.Close()
+
+	_, err = tmpFile.Write(data)
+	if err != nil {
+		return err
+	}
+
+	err = tmpFile.Chmod(perm)
+	if err != nil {
+		return err
+	}
+
+	err = tmpFile.Sync()
+	if err != nil {
+		return err
+	}
+
+	err = tmpFile.Close()
+	if err != nil {
+		return err
+	}
+
+	return System.Rename(tmpFile.Name(), filename)
+}
+
+/*
+BackupFile copies the file to <filename>.<timestamp>.bak in the same directory
+and returns the path of the backup file. The backup file is created
+exclusively, so that a backup taken within the same second gets a counter,
+<filename>.<timestamp>.<n>.bak, rather than replacing the previous backup.
+*/
+func BackupFile(filename string) (string, error) {
+	data, err := System.ReadFile(filename)
+	if err != nil {
+		return "", err
+	}
+
+	info, err := System.Stat(filename)
+	if err != nil {
+		return "", err
+	}
+
+	prefix := fmt.Sprintf("%s.%s", filename, time.Now().Format("20060102_150405"))
+	for n := 0; ; n++ {
+		backupFile := prefix + ".bak"
+		if n > 0 {
+			backupFile = fmt.Sprintf("%s.%d.bak", prefix, n)
+		}
+
+		file, err := System.OpenFile(backupFile, os.O_WRONLY|os.O_CREATE|os.O_EXCL, info.Mode().Perm())
+		if errors.Is(err, fs.ErrExist) {
+			continue
+		}
+		if err != nil {
+			return "", err
+		}
+
+		_, err = file.Write(data)
+		if err == nil {
+			err = file.Sync()
+		}
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			os.Remove(backupFile) // nolint
+			return "", err
+		}
+
+		return backupFile, nil
+	}
+}
+
 func GetHostAddrsNoLoopback() ([]string, error) {
 	var addrs []string
 	ipAddresses, err := System.InterfaceAddrs()
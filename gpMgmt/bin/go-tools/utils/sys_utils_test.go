@@ -145,6 +145,155 @@ func TestWriteLinesToFile(t *testing.T) {
 	})
 }
 
+func TestWriteFileAtomic(t *testing.T) {
+	testhelper.SetupTestLogger()
+
+	t.Run("succesfully writes to a new file", func(t *testing.T) {
+		dname := t.TempDir()
+		filename := filepath.Join(dname, "test")
+
+		err := utils.WriteFileAtomic(filename, []byte("line1\nline2"), 0600)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		testutils.AssertFileContents(t, filename, "line1\nline2")
+
+		info, err := os.Stat(filename)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		if info.Mode().Perm() != 0600 {
+			t.Fatalf("got %o, want %o", info.Mode().Perm(), 0600)
+		}
+	})
+
+	t.Run("replaces an existing file preserving its permissions", func(t *testing.T) {
+		dname := t.TempDir()
+		filename := filepath.Join(dname, "test")
+		err := os.WriteFile(filename, []byte("old"), 0640)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		err = utils.WriteFileAtomic(filename, []byte("new"), 0600)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		testutils.AssertFileContents(t, filename, "new")
+
+		info, err := os.Stat(filename)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		if info.Mode().Perm() != 0640 {
+			t.Fatalf("got %o, want %o", info.Mode().Perm(), 0640)
+		}
+
+		entries, err := os.ReadDir(dname)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		if len(entries) != 1 {
+			t.Fatalf("got %d files, want 1", len(entries))
+		}
+	})
+
+	t.Run("leaves the file untouched when fails to rename", func(t *testing.T) {
+		dname := t.TempDir()
+		filename := filepath.Join(dname, "test")
+		err := os.WriteFile(filename, []byte("old"), 0644)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		expectedErr := errors.New("error")
+		utils.System.Rename = func(oldpath, newpath string) error {
+			return expectedErr
+		}
+		defer utils.ResetSystemFunctions()
+
+		err = utils.WriteFileAtomic(filename, []byte("new"), 0644)
+		if !errors.Is(err, expectedErr) {
+			t.Fatalf("got %#v, want %#v", err, expectedErr)
+		}
+
+		testutils.AssertFileContents(t, filename, "old")
+
+		entries, err := os.ReadDir(dname)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		if len(entries) != 1 {
+			t.Fatalf("got %d files, want 1", len(entries))
+		}
+	})
+
+	t.Run("errors out when not able to create the temporary file", func(t *testing.T) {
+		err := utils.WriteFileAtomic(filepath.Join(t.TempDir(), "nonexistent", "test"), []byte("new"), 0644)
+
+		expectedErr := os.ErrNotExist
+		if !errors.Is(err, expectedErr) {
+			t.Fatalf("got %#v, want %#v", err, expectedErr)
+		}
+	})
+}
+
+func TestBackupFile(t *testing.T) {
+	testhelper.SetupTestLogger()
+
+	t.Run("succesfully creates the backup file", func(t *testing.T) {
+		dname := t.TempDir()
+		filename := filepath.Join(dname, "test")
+		err := os.WriteFile(filename, []byte("contents"), 0600)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		backupFile, err := utils.BackupFile(filename)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		if !strings.HasPrefix(backupFile, filename+".") || !strings.HasSuffix(backupFile, ".bak") {
+			t.Fatalf("got %s, want %s.<timestamp>.bak", backupFile, filename)
+		}
+		testutils.AssertFileContents(t, backupFile, "contents")
+		testutils.AssertFileContents(t, filename, "contents")
+	})
+
+	t.Run("does not replace the backups taken within the same second", func(t *testing.T) {
+		filename := filepath.Join(t.TempDir(), "test")
+		backups := make(map[string]bool)
+		for _, contents := range []string{"first", "second", "third"} {
+			err := os.WriteFile(filename, []byte(contents), 0600)
+			if err != nil {
+				t.Fatalf("unexpected error: %#v", err)
+			}
+
+			backupFile, err := utils.BackupFile(filename)
+			if err != nil {
+				t.Fatalf("unexpected error: %#v", err)
+			}
+			if backups[backupFile] {
+				t.Fatalf("got the backup file %s twice", backupFile)
+			}
+			backups[backupFile] = true
+			testutils.AssertFileContents(t, backupFile, contents)
+		}
+	})
+
+	t.Run("errors out when the file does not exist", func(t *testing.T) {
+		_, err := utils.BackupFile(filepath.Join(t.TempDir(), "test"))
+
+		expectedErr := os.ErrNotExist
+		if !errors.Is(err, expectedErr) {
+			t.Fatalf("got %#v, want %#v", err, expectedErr)
+		}
+	})
+}
+
 func TestAppendLinesToFile(t *testing.T) {
 	testhelper.SetupTestLogger()
 
@@ -0,0 +1,159 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	grpcStatus "google.golang.org/grpc/status"
+)
+
+// metadataCarrier lets the propagator read and write the trace context in the
+// metadata of the gRPC calls
+type metadataCarrier metadata.MD
+
+func (c metadataCarrier) Get(key string) string {
+	values := metadata.MD(c).Get(key)
+	if len(values) == 0 {
+		return ""
+	}
+
+	return values[0]
+}
+
+func (c metadataCarrier) Set(key string, value string) {
+	metadata.MD(c).Set(key, value)
+}
+
+func (c metadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for key := range c {
+		keys = append(keys, key)
+	}
+
+	return keys
+}
+
+// UnaryClientInterceptor traces the call and sends the trace context to the server
+func UnaryClientInterceptor(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	ctx, span := startClientSpan(ctx, method, cc.Target())
+	err := invoker(ctx, method, req, reply, cc, opts...)
+	EndSpan(span, err)
+
+	return err
+}
+
+// StreamClientInterceptor traces the call until the stream ends, and sends the
+// trace context to the server
+func StreamClientInterceptor(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	ctx, span := startClientSpan(ctx, method, cc.Target())
+	stream, err := streamer(ctx, desc, cc, method, opts...)
+	if err != nil {
+		EndSpan(span, err)
+		return nil, err
+	}
+
+	return &tracedClientStream{ClientStream: stream, span: span}, nil
+}
+
+type tracedClientStream struct {
+	grpc.ClientStream
+	span trace.Span
+	once sync.Once
+}
+
+func (s *tracedClientStream) RecvMsg(m interface{}) error {
+	err := s.ClientStream.RecvMsg(m)
+	if err != nil {
+		s.once.Do(func() {
+			if errors.Is(err, io.EOF) {
+				EndSpan(s.span, nil)
+			} else {
+				EndSpan(s.span, err)
+			}
+		})
+	}
+
+	return err
+}
+
+// UnaryServerInterceptor traces the call as part of the trace of the client
+func UnaryServerInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	ctx, span := startServerSpan(ctx, info.FullMethod)
+	resp, err := handler(ctx, req)
+	endServerSpan(ctx, span, info.FullMethod, err)
+
+	return resp, err
+}
+
+// StreamServerInterceptor traces the call as part of the trace of the client
+func StreamServerInterceptor(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, span := startServerSpan(stream.Context(), info.FullMethod)
+	err := handler(srv, &tracedServerStream{ServerStream: stream, ctx: ctx})
+	endServerSpan(ctx, span, info.FullMethod, err)
+
+	return err
+}
+
+// tracedServerStream passes the context holding the span to the handler
+type tracedServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *tracedServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func startClientSpan(ctx context.Context, method string, target string) (context.Context, trace.Span) {
+	attrs := append(rpcAttributes(method), attribute.String("rpc.target", target))
+	ctx, span := otel.Tracer(tracerName).Start(ctx, strings.TrimPrefix(method, "/"),
+		trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attrs...))
+
+	md, ok := metadata.FromOutgoingContext(ctx)
+	if ok {
+		md = md.Copy()
+	} else {
+		md = metadata.MD{}
+	}
+	otel.GetTextMapPropagator().Inject(ctx, metadataCarrier(md))
+
+	return metadata.NewOutgoingContext(ctx, md), span
+}
+
+func startServerSpan(ctx context.Context, method string) (context.Context, trace.Span) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	ctx = otel.GetTextMapPropagator().Extract(ctx, metadataCarrier(md))
+	ctx, span := otel.Tracer(tracerName).Start(ctx, strings.TrimPrefix(method, "/"),
+		trace.WithSpanKind(trace.SpanKindServer), trace.WithAttributes(rpcAttributes(method)...))
+
+	Verbose(ctx, "Handling %s", method)
+
+	return ctx, span
+}
+
+func endServerSpan(ctx context.Context, span trace.Span, method string, err error) {
+	span.SetAttributes(semconv.RPCGRPCStatusCodeKey.Int(int(grpcStatus.Code(err))))
+	if err != nil {
+		Verbose(ctx, "Failed to handle %s: %s", method, err)
+	}
+	EndSpan(span, err)
+}
+
+func rpcAttributes(method string) []attribute.KeyValue {
+	service, name, _ := strings.Cut(strings.TrimPrefix(method, "/"), "/")
+
+	return []attribute.KeyValue{
+		semconv.RPCSystemGRPC,
+		semconv.RPCService(service),
+		semconv.RPCMethod(name),
+	}
+}
@@ -0,0 +1,47 @@
+package tracing
+
+import (
+	"context"
+
+	"github.com/greenplum-db/gp-common-go-libs/gplog"
+)
+
+// Info, Verbose, Debug, Warn and Error log the message with gplog, followed by
+// the ID of the trace in ctx, so that the logs can be joined to the traces and
+// to the logs of the other services taking part in the same trace
+
+func Info(ctx context.Context, s string, v ...interface{}) {
+	gplog.Info(withTraceID(ctx, s), v...)
+}
+
+func Verbose(ctx context.Context, s string, v ...interface{}) {
+	gplog.Verbose(withTraceID(ctx, s), v...)
+}
+
+func Debug(ctx context.Context, s string, v ...interface{}) {
+	gplog.Debug(withTraceID(ctx, s), v...)
+}
+
+func Warn(ctx context.Context, s string, v ...interface{}) {
+	gplog.Warn(withTraceID(ctx, s), v...)
+}
+
+func Error(ctx context.Context, s string, v ...interface{}) {
+	gplog.Error(withTraceID(ctx, s), v...)
+}
+
+func withTraceID(ctx context.Context, s string) string {
+	traceID := TraceID(ctx)
+	if traceID == "" {
+		return s
+	}
+
+	return s + " [trace_id=" + traceID + "]"
+}
+
+// LogAndReturnError logs the error like utils.LogAndReturnError, followed by the
+// ID of the trace in ctx, and returns it
+func LogAndReturnError(ctx context.Context, err error) error {
+	Error(ctx, "%s", err)
+	return err
+}
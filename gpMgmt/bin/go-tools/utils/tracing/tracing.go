@@ -0,0 +1,171 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/greenplum-db/gp-common-go-libs/gplog"
+)
+
+// Exporters of the spans
+const (
+	ExporterOtlp = "otlp"
+	ExporterFile = "file"
+)
+
+// Names of the services reporting spans
+const (
+	ServiceCli   = "gp-cli"
+	ServiceHub   = "gp-hub"
+	ServiceAgent = "gp-agent"
+)
+
+const tracerName = "github.com/greenplum-db/gpdb/gp"
+
+// Config of the export of the spans of the CLI, hub and agents
+type Config struct {
+	Exporter string `json:"exporter,omitempty"` // otlp or file, spans are not exported when not set
+	Endpoint string `json:"endpoint,omitempty"` // host:port of the OTLP gRPC collector, defaults to localhost:4317
+	Insecure bool   `json:"insecure,omitempty"` // connect to the collector without TLS
+	File     string `json:"file,omitempty"`     // defaults to <service>_traces.json in the log directory
+}
+
+func (conf *Config) Validate() error {
+	switch conf.Exporter {
+	case "", ExporterOtlp, ExporterFile:
+		return nil
+	default:
+		return fmt.Errorf("unknown trace exporter %q, expected %s or %s", conf.Exporter, ExporterOtlp, ExporterFile)
+	}
+}
+
+/*
+Setup installs the W3C trace context propagator and a tracer provider for the
+service, exporting its spans if configured. Traces are recorded even when they
+are not exported, so that their IDs can be used to join the logs of the CLI, hub
+and agents. The returned function flushes the pending spans and must be called
+before the process exits.
+*/
+func Setup(conf *Config, service string, logDir string) (func(), error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	hostname, _ := os.Hostname()
+	opts := []sdktrace.TracerProviderOption{
+		sdktrace.WithResource(resource.NewSchemaless(
+			semconv.ServiceName(service),
+			semconv.HostName(hostname),
+		)),
+	}
+
+	var file *os.File
+	if conf != nil && conf.Exporter != "" {
+		exporter, exportFile, err := newExporter(conf, service, logDir)
+		if err != nil {
+			return nil, err
+		}
+		file = exportFile
+		opts = append(opts, sdktrace.WithBatcher(exporter))
+	}
+
+	provider := sdktrace.NewTracerProvider(opts...)
+	otel.SetTracerProvider(provider)
+
+	shutdown := func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		err := provider.Shutdown(ctx)
+		if err != nil {
+			gplog.Warn("Could not export the pending spans: %s", err)
+		}
+		if file != nil {
+			file.Close()
+		}
+	}
+
+	return shutdown, nil
+}
+
+// newExporter returns the configured exporter, along with the file it writes
+// to for the file exporter
+func newExporter(conf *Config, service string, logDir string) (sdktrace.SpanExporter, *os.File, error) {
+	err := conf.Validate()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if conf.Exporter == ExporterOtlp {
+		opts := []otlptracegrpc.Option{}
+		if conf.Endpoint != "" {
+			opts = append(opts, otlptracegrpc.WithEndpoint(conf.Endpoint))
+		}
+		if conf.Insecure {
+			opts = append(opts, otlptracegrpc.WithInsecure())
+		}
+
+		// The exporter connects in the background, so an unavailable collector
+		// does not prevent the services from starting
+		exporter, err := otlptracegrpc.New(context.Background(), opts...)
+		if err != nil {
+			return nil, nil, fmt.Errorf("could not create the OTLP trace exporter: %w", err)
+		}
+
+		return exporter, nil, nil
+	}
+
+	path := conf.File
+	if path == "" {
+		path = filepath.Join(logDir, fmt.Sprintf("%s_traces.json", service))
+	}
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not open the trace file: %w", err)
+	}
+
+	exporter, err := stdouttrace.New(stdouttrace.WithWriter(file))
+	if err != nil {
+		file.Close()
+		return nil, nil, fmt.Errorf("could not create the file trace exporter: %w", err)
+	}
+
+	return exporter, file, nil
+}
+
+// StartSpan starts a span as a child of the span in ctx, if any
+func StartSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// EndSpan ends the span, marking it as failed with the error if not nil
+func EndSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// TraceID returns the ID of the trace in ctx, or an empty string if none
+func TraceID(ctx context.Context) string {
+	spanContext := trace.SpanContextFromContext(ctx)
+	if !spanContext.HasTraceID() {
+		return ""
+	}
+
+	return spanContext.TraceID().String()
+}
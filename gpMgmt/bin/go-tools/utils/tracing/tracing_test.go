@@ -0,0 +1,165 @@
+package tracing_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/greenplum-db/gp-common-go-libs/testhelper"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/greenplum-db/gpdb/gp/testutils"
+	"github.com/greenplum-db/gpdb/gp/utils/tracing"
+)
+
+func TestSetup(t *testing.T) {
+	testhelper.SetupTestLogger()
+
+	t.Run("records the traces without exporting them when not configured", func(t *testing.T) {
+		shutdown, err := tracing.Setup(nil, tracing.ServiceCli, t.TempDir())
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		defer shutdown()
+
+		ctx, span := tracing.StartSpan(context.Background(), "gp test")
+		defer span.End()
+
+		if len(tracing.TraceID(ctx)) != 32 {
+			t.Fatalf("got trace ID %q, want 32 hex digits", tracing.TraceID(ctx))
+		}
+		if tracing.TraceID(context.Background()) != "" {
+			t.Fatalf("expected no trace ID without a span")
+		}
+	})
+
+	t.Run("exports the spans to the file in the log directory", func(t *testing.T) {
+		logDir := t.TempDir()
+		shutdown, err := tracing.Setup(&tracing.Config{Exporter: tracing.ExporterFile}, tracing.ServiceHub, logDir)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		ctx, span := tracing.StartSpan(context.Background(), "exec initdb")
+		tracing.EndSpan(span, errors.New("initdb failed"))
+		shutdown()
+
+		contents, err := os.ReadFile(filepath.Join(logDir, "gp-hub_traces.json"))
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		for _, expected := range []string{`"Name":"exec initdb"`, tracing.TraceID(ctx), "initdb failed", "gp-hub"} {
+			if !strings.Contains(string(contents), expected) {
+				t.Errorf("expected trace file to contain %q, got:\n%s", expected, contents)
+			}
+		}
+	})
+
+	t.Run("errors out for an unknown exporter", func(t *testing.T) {
+		_, err := tracing.Setup(&tracing.Config{Exporter: "jaeger"}, tracing.ServiceHub, t.TempDir())
+		expected := `unknown trace exporter "jaeger", expected otlp or file`
+		if err == nil || err.Error() != expected {
+			t.Fatalf("got %v, want %s", err, expected)
+		}
+	})
+
+	t.Run("errors out when the trace file cannot be opened", func(t *testing.T) {
+		_, err := tracing.Setup(&tracing.Config{Exporter: tracing.ExporterFile}, tracing.ServiceHub, "/does/not/exist")
+		expected := "could not open the trace file:"
+		if err == nil || !strings.HasPrefix(err.Error(), expected) {
+			t.Fatalf("got %v, want %s", err, expected)
+		}
+	})
+}
+
+func TestGrpcInterceptors(t *testing.T) {
+	testhelper.SetupTestLogger()
+
+	shutdown, err := tracing.Setup(nil, tracing.ServiceCli, t.TempDir())
+	if err != nil {
+		t.Fatalf("unexpected error: %#v", err)
+	}
+	defer shutdown()
+
+	var serverTraceID string
+	captureTraceID := func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		serverTraceID = tracing.TraceID(ctx)
+		return handler(ctx, req)
+	}
+
+	listener := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer(grpc.ChainUnaryInterceptor(tracing.UnaryServerInterceptor, captureTraceID))
+	healthpb.RegisterHealthServer(server, health.NewServer())
+	go server.Serve(listener) // nolint
+	defer server.Stop()
+
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, s string) (net.Conn, error) {
+			return listener.Dial()
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithUnaryInterceptor(tracing.UnaryClientInterceptor),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %#v", err)
+	}
+	defer conn.Close()
+
+	t.Run("propagates the trace of the caller to the server", func(t *testing.T) {
+		ctx, span := tracing.StartSpan(context.Background(), "gp status agents")
+		defer span.End()
+
+		_, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		if serverTraceID != tracing.TraceID(ctx) {
+			t.Fatalf("got trace ID %q on the server, want %q", serverTraceID, tracing.TraceID(ctx))
+		}
+	})
+
+	t.Run("starts a new trace when the caller has none", func(t *testing.T) {
+		_, err := healthpb.NewHealthClient(conn).Check(context.Background(), &healthpb.HealthCheckRequest{})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		if serverTraceID == "" {
+			t.Fatalf("expected a trace ID on the server")
+		}
+	})
+}
+
+func TestLog(t *testing.T) {
+	_, _, logfile := testhelper.SetupTestLogger()
+
+	shutdown, err := tracing.Setup(nil, tracing.ServiceAgent, t.TempDir())
+	if err != nil {
+		t.Fatalf("unexpected error: %#v", err)
+	}
+	defer shutdown()
+
+	ctx, span := tracing.StartSpan(context.Background(), "MakeSegment")
+	defer span.End()
+
+	tracing.Info(ctx, "Creating segment %s", "/data/primary/gpseg0")
+	testutils.AssertLogMessage(t, logfile, `Creating segment /data/primary/gpseg0 \[trace_id=`+tracing.TraceID(ctx)+`\]`)
+
+	tracing.Info(context.Background(), "Creating segment without a trace")
+	testutils.AssertLogMessage(t, logfile, `Creating segment without a trace\n`)
+
+	err = tracing.LogAndReturnError(ctx, errors.New("executing initdb: 100% failed"))
+	if err == nil || err.Error() != "executing initdb: 100% failed" {
+		t.Fatalf("got %v, want the error", err)
+	}
+	testutils.AssertLogMessage(t, logfile, `executing initdb: 100% failed \[trace_id=`+tracing.TraceID(ctx)+`\]`)
+}